@@ -99,6 +99,25 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	// V3 routes
 	a.registerCardsRoutes(apiv2)
 	a.registerCardRelationsRoutes(apiv2)
+	a.registerBoardWebhooksRoutes(apiv2)
+	a.registerIncomingWebhooksRoutes(apiv2)
+	a.registerAutomationRulesRoutes(apiv2)
+	a.registerCardRecurrencesRoutes(apiv2)
+	a.registerSchedulesRoutes(apiv2)
+	a.registerCardHierarchyRoutes(apiv2)
+	a.registerCardPropertyValidationRoutes(apiv2)
+	a.registerPropertyMigrationRoutes(apiv2)
+	a.registerWorklogsRoutes(apiv2)
+	a.registerCardGitHubLinksRoutes(apiv2)
+	a.registerGitHubStatusRulesRoutes(apiv2)
+	a.registerBoardGitHubReposRoutes(apiv2)
+	a.registerGitHubIssueSyncRoutes(apiv2)
+	a.registerTrackersRoutes(apiv2)
+
+	// Incoming webhooks are called by external systems, without the CSRF header
+	hooks := r.PathPrefix("/hooks").Subrouter()
+	hooks.Use(a.panicHandler)
+	a.registerHooksRoutes(hooks)
 
 	// System routes are outside the /api/v2 path
 	a.registerSystemRoutes(r)
@@ -171,11 +190,36 @@ func (a *API) userIsGuest(userID string) (bool, error) {
 // Response helpers
 
 func (a *API) errorResponse(w http.ResponseWriter, r *http.Request, err error) {
-	errorResponse := model.ErrorResponse{Error: err.Error()}
+	errorResponse := newErrorResponse(err)
+
+	a.logger.Warn("api error response",
+		mlog.Int("code", errorResponse.ErrorCode),
+		mlog.Err(err),
+		mlog.String("api", r.URL.Path),
+	)
+
+	setResponseHeader(w, "Content-Type", "application/json")
+	data, err := json.Marshal(errorResponse)
+	if err != nil {
+		data = []byte("{}")
+	}
+
+	w.WriteHeader(errorResponse.ErrorCode)
+	_, _ = w.Write(data)
+}
+
+// newErrorResponse returns the error response and status code of an error.
+func newErrorResponse(err error) *model.ErrorResponse {
+	errorResponse := &model.ErrorResponse{Error: err.Error()}
 
 	switch {
 	case model.IsErrBadRequest(err):
 		errorResponse.ErrorCode = http.StatusBadRequest
+
+		var transitionErr *model.ErrStatusTransition
+		if errors.As(err, &transitionErr) {
+			errorResponse.StatusTransitionFailures = transitionErr.Failures
+		}
 	case model.IsErrUnauthorized(err):
 		errorResponse.ErrorCode = http.StatusUnauthorized
 	case model.IsErrForbidden(err):
@@ -190,21 +234,7 @@ func (a *API) errorResponse(w http.ResponseWriter, r *http.Request, err error) {
 		errorResponse.Error = "internal server error"
 		errorResponse.ErrorCode = http.StatusInternalServerError
 	}
-
-	a.logger.Warn("api error response",
-		mlog.Int("code", errorResponse.ErrorCode),
-		mlog.Err(err),
-		mlog.String("api", r.URL.Path),
-	)
-
-	setResponseHeader(w, "Content-Type", "application/json")
-	data, err := json.Marshal(errorResponse)
-	if err != nil {
-		data = []byte("{}")
-	}
-
-	w.WriteHeader(errorResponse.ErrorCode)
-	_, _ = w.Write(data)
+	return errorResponse
 }
 
 func stringResponse(w http.ResponseWriter, message string) {
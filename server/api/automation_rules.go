@@ -0,0 +1,388 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerAutomationRulesRoutes(r *mux.Router) {
+	// Automation Rules APIs
+	r.HandleFunc("/boards/{boardID}/automations", a.sessionRequired(a.handleGetAutomationRules)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/automations", a.sessionRequired(a.handleCreateAutomationRule)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/automations/{ruleID}", a.sessionRequired(a.handleGetAutomationRule)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/automations/{ruleID}", a.sessionRequired(a.handlePatchAutomationRule)).Methods("PATCH")
+	r.HandleFunc("/boards/{boardID}/automations/{ruleID}", a.sessionRequired(a.handleDeleteAutomationRule)).Methods("DELETE")
+}
+
+func (a *API) handleGetAutomationRules(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/automations getAutomationRules
+	//
+	// Returns the automation rules of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/AutomationRule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to automation rules"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getAutomationRules", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	rules, err := a.app.GetAutomationRules(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleCreateAutomationRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/automations createAutomationRule
+	//
+	// Creates an automation rule for a board. The rule actions run on behalf
+	// of the current user.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the automation rule to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/AutomationRule"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/AutomationRule'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to automation rules"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var newRule *model.AutomationRule
+	if err = json.Unmarshal(requestBody, &newRule); err != nil || newRule == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid automation rule"))
+		return
+	}
+	newRule.BoardID = boardID
+	newRule.CreatedBy = userID
+
+	auditRec := a.makeAuditRecord(r, "createAutomationRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	rule, err := a.app.CreateAutomationRule(newRule)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateAutomationRule",
+		mlog.String("boardID", boardID),
+		mlog.String("ruleID", rule.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("ruleID", rule.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetAutomationRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/automations/{ruleID} getAutomationRule
+	//
+	// Returns an automation rule.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: ruleID
+	//   in: path
+	//   description: Automation rule ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/AutomationRule'
+	//   '404':
+	//     description: automation rule not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	ruleID := vars["ruleID"]
+
+	rule, err := a.getAutomationRuleForUser(userID, boardID, ruleID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getAutomationRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("ruleID", ruleID)
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handlePatchAutomationRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/automations/{ruleID} patchAutomationRule
+	//
+	// Updates an automation rule.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: ruleID
+	//   in: path
+	//   description: Automation rule ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: automation rule patch to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/AutomationRulePatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/AutomationRule'
+	//   '404':
+	//     description: automation rule not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	ruleID := vars["ruleID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var patch *model.AutomationRulePatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid automation rule patch"))
+		return
+	}
+
+	if _, err = a.getAutomationRuleForUser(userID, boardID, ruleID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchAutomationRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("ruleID", ruleID)
+
+	rule, err := a.app.PatchAutomationRule(ruleID, patch, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("PatchAutomationRule",
+		mlog.String("boardID", boardID),
+		mlog.String("ruleID", ruleID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteAutomationRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/automations/{ruleID} deleteAutomationRule
+	//
+	// Deletes an automation rule.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: ruleID
+	//   in: path
+	//   description: Automation rule ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: automation rule not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	ruleID := vars["ruleID"]
+
+	if _, err := a.getAutomationRuleForUser(userID, boardID, ruleID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteAutomationRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("ruleID", ruleID)
+
+	if err := a.app.DeleteAutomationRule(ruleID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteAutomationRule",
+		mlog.String("boardID", boardID),
+		mlog.String("ruleID", ruleID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+// getAutomationRuleForUser returns an automation rule of a board if the user
+// can manage the board's automation rules.
+func (a *API) getAutomationRuleForUser(userID, boardID, ruleID string) (*model.AutomationRule, error) {
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		return nil, model.NewErrPermission("access denied to automation rules")
+	}
+
+	rule, err := a.app.GetAutomationRule(ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule.BoardID != boardID {
+		return nil, model.NewErrNotFound("automation rule ID=" + ruleID)
+	}
+	return rule, nil
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerBoardGitHubReposRoutes(r *mux.Router) {
+	// Board GitHub repository APIs
+	r.HandleFunc("/boards/{boardID}/github/repos", a.sessionRequired(a.handleGetBoardGitHubRepos)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/github/repos", a.sessionRequired(a.handleConnectBoardGitHubRepo)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/github/repos/{repoID}", a.sessionRequired(a.handleDisconnectBoardGitHubRepo)).Methods("DELETE")
+}
+
+func (a *API) handleGetBoardGitHubRepos(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/github/repos getBoardGitHubRepos
+	//
+	// Fetches the GitHub repositories connected to a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardGitHubRepo"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to view board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardGitHubRepos", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	repos, err := a.app.GetBoardGitHubRepos(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardGitHubRepos",
+		mlog.String("boardID", boardID),
+		mlog.Int("count", len(repos)),
+	)
+
+	data, err := json.Marshal(repos)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleConnectBoardGitHubRepo(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/github/repos connectBoardGitHubRepo
+	//
+	// Connects a GitHub repository to a board. Its pull requests and commits
+	// mentioning the code of a card of the board are linked to the card. The
+	// repository is scanned with the GitHub account of the current user.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the repository, by owner and name
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardGitHubRepo"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardGitHubRepo"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to manage board properties"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxGitHubRequestSize)
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			a.errorResponse(w, r, model.ErrRequestEntityTooLarge)
+			return
+		}
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var repo *model.BoardGitHubRepo
+	if err = json.Unmarshal(requestBody, &repo); err != nil || repo == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid github repository"))
+		return
+	}
+	repo = &model.BoardGitHubRepo{
+		BoardID:   boardID,
+		Owner:     repo.Owner,
+		Repo:      repo.Repo,
+		CreatedBy: userID,
+	}
+
+	auditRec := a.makeAuditRecord(r, "connectBoardGitHubRepo", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("owner", repo.Owner)
+	auditRec.AddMeta("repo", repo.Repo)
+
+	created, err := a.app.ConnectBoardGitHubRepo(repo)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ConnectBoardGitHubRepo",
+		mlog.String("repoID", created.ID),
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(created)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("repoID", created.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleDisconnectBoardGitHubRepo(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/github/repos/{repoID} disconnectBoardGitHubRepo
+	//
+	// Disconnects a GitHub repository from a board. The links it created are
+	// kept.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: repoID
+	//   in: path
+	//   description: GitHub repository connection ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	repoID := vars["repoID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to manage board properties"))
+		return
+	}
+
+	repo, err := a.app.GetBoardGitHubRepo(repoID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if repo.BoardID != boardID {
+		a.errorResponse(w, r, model.NewErrNotFound("github repository ID="+repoID))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "disconnectBoardGitHubRepo", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("repoID", repoID)
+
+	if err := a.app.DisconnectBoardGitHubRepo(repoID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DisconnectBoardGitHubRepo",
+		mlog.String("repoID", repoID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
@@ -0,0 +1,502 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerBoardWebhooksRoutes(r *mux.Router) {
+	// Board Webhooks APIs
+	r.HandleFunc("/boards/{boardID}/webhooks", a.sessionRequired(a.handleGetBoardWebhooks)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/webhooks", a.sessionRequired(a.handleCreateBoardWebhook)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/webhooks/{webhookID}", a.sessionRequired(a.handleGetBoardWebhook)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/webhooks/{webhookID}", a.sessionRequired(a.handlePatchBoardWebhook)).Methods("PATCH")
+	r.HandleFunc("/boards/{boardID}/webhooks/{webhookID}", a.sessionRequired(a.handleDeleteBoardWebhook)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/webhooks/{webhookID}/deliveries", a.sessionRequired(a.handleGetWebhookDeliveries)).Methods("GET")
+}
+
+func (a *API) handleGetBoardWebhooks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/webhooks getBoardWebhooks
+	//
+	// Returns the webhooks of a board. Secrets are not included.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardWebhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board webhooks"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardWebhooks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	webhooks, err := a.app.GetBoardWebhooks(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetBoardWebhooks",
+		mlog.String("boardID", boardID),
+		mlog.Int("count", len(webhooks)),
+	)
+
+	data, err := json.Marshal(webhooks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleCreateBoardWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/webhooks createBoardWebhook
+	//
+	// Creates a webhook for a board. The response is the only one that
+	// includes the secret used to sign the deliveries. The URL must be an
+	// http or https URL that does not point to a loopback, link-local or
+	// private address.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the webhook to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardWebhook"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/BoardWebhook'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board webhooks"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var newWebhook *model.BoardWebhook
+	if err = json.Unmarshal(requestBody, &newWebhook); err != nil || newWebhook == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid webhook"))
+		return
+	}
+	newWebhook.BoardID = boardID
+	newWebhook.CreatedBy = userID
+
+	auditRec := a.makeAuditRecord(r, "createBoardWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	webhook, err := a.app.CreateBoardWebhook(newWebhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateBoardWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhook.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("webhookID", webhook.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/webhooks/{webhookID} getBoardWebhook
+	//
+	// Returns a webhook of a board. The secret is not included.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/BoardWebhook'
+	//   '404':
+	//     description: webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	auditRec := a.makeAuditRecord(r, "getBoardWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	webhook, err := a.getBoardWebhookForUser(getUserID(r), boardID, webhookID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handlePatchBoardWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/webhooks/{webhookID} patchBoardWebhook
+	//
+	// Updates the URL, events or enabled state of a board webhook.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: webhook patch to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardWebhookPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/BoardWebhook'
+	//   '404':
+	//     description: webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var patch *model.BoardWebhookPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid webhook patch"))
+		return
+	}
+
+	if _, err = a.getBoardWebhookForUser(userID, boardID, webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchBoardWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	webhook, err := a.app.PatchBoardWebhook(webhookID, patch)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("PatchBoardWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteBoardWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/webhooks/{webhookID} deleteBoardWebhook
+	//
+	// Deletes a board webhook and its delivery log.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	if _, err := a.getBoardWebhookForUser(userID, boardID, webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBoardWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	if err := a.app.DeleteBoardWebhook(webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteBoardWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleGetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/webhooks/{webhookID}/deliveries getWebhookDeliveries
+	//
+	// Returns the delivery log of a board webhook, newest first.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: The page to select (default=0)
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: Number of deliveries to return per page(default=100)
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/WebhookDelivery"
+	//   '404':
+	//     description: webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+	query := r.URL.Query()
+	strPage := query.Get("page")
+	strPerPage := query.Get("per_page")
+
+	if strPage == "" {
+		strPage = defaultPage
+	}
+	if strPerPage == "" {
+		strPerPage = defaultPerPage
+	}
+
+	page, err := strconv.Atoi(strPage)
+	if err != nil || page < 0 {
+		message := fmt.Sprintf("invalid `page` parameter: %s", strPage)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	perPage, err := strconv.Atoi(strPerPage)
+	if err != nil || perPage < 0 {
+		message := fmt.Sprintf("invalid `per_page` parameter: %s", strPerPage)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	if _, err = a.getBoardWebhookForUser(userID, boardID, webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getWebhookDeliveries", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	deliveries, err := a.app.GetWebhookDeliveries(webhookID, page, perPage)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetWebhookDeliveries",
+		mlog.String("webhookID", webhookID),
+		mlog.Int("count", len(deliveries)),
+	)
+
+	data, err := json.Marshal(deliveries)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+// getBoardWebhookForUser returns a webhook of a board if the user is an
+// admin of the board.
+func (a *API) getBoardWebhookForUser(userID, boardID, webhookID string) (*model.BoardWebhook, error) {
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		return nil, model.NewErrPermission("access denied to board webhooks")
+	}
+
+	webhook, err := a.app.GetBoardWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.BoardID != boardID {
+		return nil, model.NewErrNotFound("board webhook ID=" + webhookID)
+	}
+	return webhook, nil
+}
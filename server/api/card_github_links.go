@@ -0,0 +1,275 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerCardGitHubLinksRoutes(r *mux.Router) {
+	// Card GitHub link APIs
+	r.HandleFunc("/cards/{cardID}/github/links", a.sessionRequired(a.handleGetCardGitHubLinks)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/github/links", a.sessionRequired(a.handleAddCardGitHubLink)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/github/links/{linkID}", a.sessionRequired(a.handleDeleteCardGitHubLink)).Methods("DELETE")
+
+	// Same APIs for the links to any issue tracker
+	r.HandleFunc("/cards/{cardID}/links", a.sessionRequired(a.handleGetCardGitHubLinks)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/links", a.sessionRequired(a.handleAddCardGitHubLink)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/links/{linkID}", a.sessionRequired(a.handleDeleteCardGitHubLink)).Methods("DELETE")
+}
+
+func (a *API) handleGetCardGitHubLinks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/github/links getCardGitHubLinks
+	//
+	// Fetches the GitHub issues, pull requests and commits linked to a card, with
+	// their state as of their last synchronization.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardGitHubLink"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch github links"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardGitHubLinks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("cardID", cardID)
+
+	links, err := a.app.GetCardGitHubLinks(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCardGitHubLinks",
+		mlog.String("cardID", cardID),
+		mlog.Int("count", len(links)),
+	)
+
+	data, err := json.Marshal(links)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAddCardGitHubLink(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/github/links addCardGitHubLink
+	//
+	// Links a GitHub issue, pull request or commit to a card. Issues and pull
+	// requests are given either by their URL or by their kind, owner, repo
+	// and number, commits by their owner, repo and sha. The item is
+	// synchronized with the GitHub account of the current user. Issues of
+	// other trackers are given by their provider and either their URL or
+	// their project and number; links without a provider are to the tracker
+	// of the board. Also available as POST /cards/{cardID}/links.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the GitHub link
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardGitHubLink"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardGitHubLink"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxGitHubRequestSize)
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			a.errorResponse(w, r, model.ErrRequestEntityTooLarge)
+			return
+		}
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var link *model.CardGitHubLink
+	if err = json.Unmarshal(requestBody, &link); err != nil || link == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid github link"))
+		return
+	}
+	link = &model.CardGitHubLink{
+		CardID:    cardID,
+		Provider:  link.Provider,
+		Kind:      link.Kind,
+		Owner:     link.Owner,
+		Repo:      link.Repo,
+		Project:   link.Project,
+		Number:    link.Number,
+		SHA:       link.SHA,
+		URL:       link.URL,
+		CreatedBy: userID,
+	}
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to link card to github"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "addCardGitHubLink", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("cardID", cardID)
+
+	created, err := a.app.AddCardGitHubLink(link)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("AddCardGitHubLink",
+		mlog.String("linkID", created.ID),
+		mlog.String("cardID", cardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(created)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("linkID", created.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCardGitHubLink(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /cards/{cardID}/github/links/{linkID} deleteCardGitHubLink
+	//
+	// Removes a GitHub link from a card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: linkID
+	//   in: path
+	//   description: GitHub link ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	cardID := vars["cardID"]
+	linkID := vars["linkID"]
+
+	link, err := a.app.GetCardGitHubLink(linkID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if link.CardID != cardID {
+		a.errorResponse(w, r, model.NewErrNotFound("github link ID="+linkID))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, link.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to unlink card from github"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCardGitHubLink", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("linkID", linkID)
+
+	if err := a.app.DeleteCardGitHubLink(linkID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteCardGitHubLink",
+		mlog.String("linkID", linkID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
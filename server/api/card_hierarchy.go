@@ -0,0 +1,305 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerCardHierarchyRoutes(r *mux.Router) {
+	// Card Hierarchy APIs
+	r.HandleFunc("/cards/{cardID}/children", a.sessionRequired(a.handleGetCardChildren)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/parent", a.sessionRequired(a.handleGetCardParent)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/parent", a.sessionRequired(a.handleSetCardParent)).Methods("PUT")
+	r.HandleFunc("/cards/{cardID}/parent", a.sessionRequired(a.handleRemoveCardParent)).Methods("DELETE")
+}
+
+func (a *API) handleGetCardChildren(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/children getCardChildren
+	//
+	// Returns the children of a card, with the number of children, their
+	// completion percentage and the values rolled up from them. Children on
+	// boards the user cannot view are left out.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardChildren'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to card children"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardChildren", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	children, err := a.app.GetCardChildren(cardID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(children)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardParent(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/parent getCardParent
+	//
+	// Returns the parent of a card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardParent'
+	//   '404':
+	//     description: the card has no parent
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to card parent"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardParent", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	parent, err := a.app.GetCardParent(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(parent)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSetCardParent(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /cards/{cardID}/parent setCardParent
+	//
+	// Makes a card a child of another card, possibly on another board,
+	// replacing its previous parent. A card cannot become its own ancestor.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the parent of the card
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardParent"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardParent'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var parent *model.CardParent
+	if err = json.Unmarshal(requestBody, &parent); err != nil || parent == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid card parent"))
+		return
+	}
+	parent.CardID = cardID
+	parent.CreatedBy = userID
+	parent.CreateAt = 0
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	parentCard, err := a.app.GetCardByID(parent.ParentCardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// the parent card's rollups change along with its children
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) ||
+		!a.permissions.HasPermissionToBoard(userID, parentCard.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to set card parent"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setCardParent", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("parentCardID", parentCard.ID)
+
+	parent, err = a.app.SetCardParent(parent)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetCardParent",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("parentCardID", parentCard.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(parent)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleRemoveCardParent(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /cards/{cardID}/parent removeCardParent
+	//
+	// Makes a card a top-level card again.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: the card has no parent
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to remove card parent"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "removeCardParent", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	if err := a.app.RemoveCardParent(cardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("RemoveCardParent",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerCardPropertyValidationRoutes(r *mux.Router) {
+	// Card Property Validation APIs
+	r.HandleFunc("/boards/{boardID}/properties/issues", a.sessionRequired(a.handleGetCardPropertyIssues)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/properties/repair", a.sessionRequired(a.handleRepairCardProperties)).Methods("POST")
+}
+
+func (a *API) handleGetCardPropertyIssues(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/properties/issues getCardPropertyIssues
+	//
+	// Reports the card property values of a board that do not match its
+	// property schema: unknown properties, unknown options, non-numeric
+	// numbers and malformed dates.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardPropertyRepairResult"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.repairCardProperties(w, r, true)
+}
+
+func (a *API) handleRepairCardProperties(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/properties/repair repairCardProperties
+	//
+	// Removes the card property values of a board that do not match its
+	// property schema, and reports them. Invalid elements of multi-select and
+	// multi-person values are dropped, and the rest of the value is kept.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: dry_run
+	//   in: query
+	//   description: Only report the invalid values
+	//   required: false
+	//   type: boolean
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardPropertyRepairResult"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	a.repairCardProperties(w, r, r.URL.Query().Get("dry_run") == "true")
+}
+
+func (a *API) repairCardProperties(w http.ResponseWriter, r *http.Request, dryRun bool) {
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to manage board properties"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "repairCardProperties", audit.Fail)
+	level := audit.LevelModify
+	if dryRun {
+		level = audit.LevelRead
+	}
+	defer a.audit.LogRecord(level, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("dryRun", dryRun)
+
+	result, err := a.app.RepairCardProperties(boardID, userID, dryRun)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("RepairCardProperties",
+		mlog.String("boardID", boardID),
+		mlog.Bool("dryRun", dryRun),
+		mlog.Int("cardsWithIssues", result.CardsWithIssues),
+		mlog.Int("cardsRepaired", result.CardsRepaired),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("cardsRepaired", result.CardsRepaired)
+	auditRec.Success()
+}
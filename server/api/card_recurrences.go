@@ -0,0 +1,233 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerCardRecurrencesRoutes(r *mux.Router) {
+	// Card Recurrences APIs
+	r.HandleFunc("/cards/{cardID}/recurrence", a.sessionRequired(a.handleGetCardRecurrence)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/recurrence", a.sessionRequired(a.handleSetCardRecurrence)).Methods("PUT")
+	r.HandleFunc("/cards/{cardID}/recurrence", a.sessionRequired(a.handleDeleteCardRecurrence)).Methods("DELETE")
+}
+
+func (a *API) handleGetCardRecurrence(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/recurrence getCardRecurrence
+	//
+	// Returns the recurrence of a card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardRecurrence'
+	//   '404':
+	//     description: the card does not recur
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.getCardForRecurrence(userID, cardID, model.PermissionViewBoard)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardRecurrence", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	recurrence, err := a.app.GetCardRecurrence(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(recurrence)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSetCardRecurrence(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /cards/{cardID}/recurrence setCardRecurrence
+	//
+	// Sets the recurrence of a card, replacing the previous one. A copy of the
+	// card is created on each occurrence.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the recurrence of the card
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardRecurrence"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardRecurrence'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var recurrence *model.CardRecurrence
+	if err = json.Unmarshal(requestBody, &recurrence); err != nil || recurrence == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid card recurrence"))
+		return
+	}
+	recurrence.CardID = cardID
+	recurrence.CreatedBy = userID
+
+	card, err := a.getCardForRecurrence(userID, cardID, model.PermissionManageBoardCards)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setCardRecurrence", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	recurrence, err = a.app.SetCardRecurrence(recurrence, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SetCardRecurrence",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(recurrence)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCardRecurrence(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /cards/{cardID}/recurrence deleteCardRecurrence
+	//
+	// Stops a card from recurring.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: the card does not recur
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.getCardForRecurrence(userID, cardID, model.PermissionManageBoardCards)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCardRecurrence", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	if err := a.app.DeleteCardRecurrence(cardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteCardRecurrence",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", cardID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+// getCardForRecurrence returns a card if the user has a permission on its
+// board.
+func (a *API) getCardForRecurrence(userID string, cardID string, permission *mm_model.Permission) (*model.Card, error) {
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, permission) {
+		return nil, model.NewErrPermission("access denied to card recurrence")
+	}
+	return card, nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
@@ -20,6 +21,7 @@ func (a *API) registerCardRelationsRoutes(r *mux.Router) {
 	// Card Relations APIs
 	r.HandleFunc("/cards/{cardID}/relations", a.sessionRequired(a.handleGetCardRelations)).Methods("GET")
 	r.HandleFunc("/cards/{cardID}/relations", a.sessionRequired(a.handleCreateCardRelation)).Methods("POST")
+	r.HandleFunc("/cards/{cardID}/relations/graph", a.sessionRequired(a.handleGetCardRelationGraph)).Methods("GET")
 	r.HandleFunc("/relations/{relationID}", a.sessionRequired(a.handleDeleteCardRelation)).Methods("DELETE")
 }
 
@@ -93,6 +95,130 @@ func (a *API) handleGetCardRelations(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleGetCardRelationGraph(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/relations/graph getCardRelationGraph
+	//
+	// Fetches the cards related to the specified card, directly or through
+	// other cards, across boards, and the cards it is waiting on.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: depth
+	//   in: query
+	//   description: The number of relations to follow from the card, 2 by default and 10 at most
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardRelationGraph"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	depth := model.CardRelationGraphDefaultDepth
+	if depthStr := r.URL.Query().Get("depth"); depthStr != "" {
+		var err error
+		if depth, err = strconv.Atoi(depthStr); err != nil {
+			a.errorResponse(w, r, model.NewErrBadRequest("invalid depth"))
+			return
+		}
+	}
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch card relations"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardRelationGraph", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("depth", depth)
+
+	graph, err := a.app.GetCardRelationGraph(cardID, depth)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	a.filterCardRelationGraph(userID, graph)
+
+	a.logger.Debug("GetCardRelationGraph",
+		mlog.String("cardID", cardID),
+		mlog.String("userID", userID),
+		mlog.Int("nodes", len(graph.Nodes)),
+		mlog.Int("edges", len(graph.Edges)),
+	)
+
+	data, err := json.Marshal(graph)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+// filterCardRelationGraph removes from a graph the cards of the boards the
+// user cannot view, and their relations.
+func (a *API) filterCardRelationGraph(userID string, graph *model.CardRelationGraph) {
+	canView := make(map[string]bool)
+	visible := func(card *model.Card) bool {
+		allowed, ok := canView[card.BoardID]
+		if !ok {
+			allowed = a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard)
+			canView[card.BoardID] = allowed
+		}
+		return allowed
+	}
+
+	visibleCards := make(map[string]bool)
+	nodes := make([]*model.CardRelationGraphNode, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		if visible(node.Card) {
+			visibleCards[node.Card.ID] = true
+			nodes = append(nodes, node)
+		}
+	}
+	graph.Nodes = nodes
+
+	edges := make([]*model.CardRelationEdge, 0, len(graph.Edges))
+	for _, edge := range graph.Edges {
+		if visibleCards[edge.FromCardID] && visibleCards[edge.ToCardID] {
+			edges = append(edges, edge)
+		}
+	}
+	graph.Edges = edges
+
+	blockedBy := make([]*model.Card, 0, len(graph.BlockedBy))
+	for _, card := range graph.BlockedBy {
+		if visible(card) {
+			blockedBy = append(blockedBy, card)
+		}
+	}
+	graph.BlockedBy = blockedBy
+}
+
 func (a *API) handleCreateCardRelation(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /cards/{cardID}/relations createCardRelation
 	//
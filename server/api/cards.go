@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"strconv"
 
@@ -20,14 +21,19 @@ import (
 const (
 	defaultPage    = "0"
 	defaultPerPage = "100"
+
+	maxCardsForViewPerPage = 1000
 )
 
 func (a *API) registerCardsRoutes(r *mux.Router) {
 	// Cards APIs
 	r.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(a.handleCreateCard)).Methods("POST")
 	r.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(a.handleGetCards)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/cards/bulk", a.sessionRequired(a.handleBulkUpdateCards)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/views/{viewID}/cards", a.sessionRequired(a.handleGetCardsForView)).Methods("GET")
 	r.HandleFunc("/cards/{cardID}", a.sessionRequired(a.handlePatchCard)).Methods("PATCH")
 	r.HandleFunc("/cards/{cardID}", a.sessionRequired(a.handleGetCard)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/move", a.sessionRequired(a.handleMoveCard)).Methods("POST")
 	r.HandleFunc("/task/{code}", a.sessionRequired(a.handleGetCardByCode)).Methods("GET")
 }
 
@@ -231,6 +237,149 @@ func (a *API) handleGetCards(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleGetCardsForView(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/views/{viewID}/cards getCardsForView
+	//
+	// Fetches the cards shown by the specified view, with the view's filters,
+	// sort options and grouping applied.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: viewID
+	//   in: path
+	//   description: View ID
+	//   required: true
+	//   type: string
+	// - name: grouped
+	//   in: query
+	//   description: Returns the cards split in the groups shown by the view
+	//   required: false
+	//   type: boolean
+	// - name: page
+	//   in: query
+	//   description: The page to select (default=0), ignored for grouped results
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: Number of cards to return per page(default=100, max=1000, 0 for all), ignored for grouped results
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Card"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+	viewID := mux.Vars(r)["viewID"]
+
+	query := r.URL.Query()
+	grouped := query.Get("grouped") == True
+	strPage := query.Get("page")
+	strPerPage := query.Get("per_page")
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch cards"))
+		return
+	}
+
+	if strPage == "" {
+		strPage = defaultPage
+	}
+	if strPerPage == "" {
+		strPerPage = defaultPerPage
+	}
+
+	page, err := strconv.Atoi(strPage)
+	if err != nil || page < 0 {
+		message := fmt.Sprintf("invalid `page` parameter: %s", strPage)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	perPage, err := strconv.Atoi(strPerPage)
+	if err != nil || perPage < 0 {
+		message := fmt.Sprintf("invalid `per_page` parameter: %s", strPerPage)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+	if perPage > maxCardsForViewPerPage {
+		message := fmt.Sprintf("`per_page` parameter cannot be greater than %d", maxCardsForViewPerPage)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+	if perPage > 0 && page > math.MaxInt/perPage {
+		message := fmt.Sprintf("invalid `page` parameter: %s", strPage)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardsForView", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", viewID)
+
+	groups, err := a.app.GetCardGroupsForView(boardID, viewID, userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var response any = groups
+	count := 0
+	if grouped {
+		for _, group := range groups {
+			count += len(group.Cards)
+		}
+	} else {
+		cards := model.FlattenCardGroups(groups)
+		start := min(page*perPage, len(cards))
+		end := len(cards)
+		if perPage > 0 {
+			end = min(start+perPage, len(cards))
+		}
+		cards = cards[start:end]
+		count = len(cards)
+		response = cards
+	}
+
+	a.logger.Debug("GetCardsForView",
+		mlog.String("boardID", boardID),
+		mlog.String("viewID", viewID),
+		mlog.String("userID", userID),
+		mlog.Bool("grouped", grouped),
+		mlog.Int("count", count),
+	)
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.Success()
+}
+
 func (a *API) handlePatchCard(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation PATCH /cards/{cardID}/cards patchCard
 	//
@@ -328,6 +477,198 @@ func (a *API) handlePatchCard(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+func (a *API) handleMoveCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/move moveCard
+	//
+	// Moves a card and its content to another board, keeping its history and
+	// relations. Property values move to the properties and options of the
+	// same name on the target board, the others are dropped and reported. The
+	// card gets a new number, and its former code still finds it. A dry run
+	// only reports how the card would be moved.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the target board of the card
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardMoveRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardMoveResult'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var moveRequest *model.CardMoveRequest
+	if err = json.Unmarshal(requestBody, &moveRequest); err != nil || moveRequest == nil || moveRequest.BoardID == "" {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid card move request"))
+		return
+	}
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) ||
+		!a.permissions.HasPermissionToBoard(userID, moveRequest.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to move card"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "moveCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.AddMeta("targetBoardID", moveRequest.BoardID)
+	auditRec.AddMeta("dryRun", moveRequest.DryRun)
+
+	result, err := a.app.MoveCardToBoard(card.ID, moveRequest.BoardID, userID, moveRequest.DryRun)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("MoveCard",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("targetBoardID", moveRequest.BoardID),
+		mlog.String("cardID", card.ID),
+		mlog.String("userID", userID),
+		mlog.Bool("dryRun", moveRequest.DryRun),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleBulkUpdateCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/bulk bulkUpdateCards
+	//
+	// Applies a set of operations to many cards of a board: patch, setStatus,
+	// assign, delete and addRelation. Each card goes through the same
+	// validations as a card patch and is changed on its own, so the response
+	// reports the success or failure of each card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the cards and the operations to apply to them
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardBulkRequest"
+	// - name: disable_notify
+	//   in: query
+	//   description: Disables notifications (for bulk data inserting)
+	//   required: false
+	//   type: bool
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/CardBulkResult'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	val := r.URL.Query().Get("disable_notify")
+	disableNotify := val == True
+
+	bulkRequest, err := model.CardBulkRequestFromJSON(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid bulk card request"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to update cards"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "bulkUpdateCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardCount", len(bulkRequest.CardIDs))
+	auditRec.AddMeta("operationCount", len(bulkRequest.Operations))
+
+	result, err := a.app.BulkUpdateCards(boardID, bulkRequest, userID, disableNotify)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	for _, cardResult := range result.Results {
+		if cardResult.Err != nil {
+			cardResult.Error = newErrorResponse(cardResult.Err)
+			a.logger.Debug("BulkUpdateCards card failed",
+				mlog.String("cardID", cardResult.CardID),
+				mlog.Err(cardResult.Err),
+			)
+		}
+	}
+
+	a.logger.Debug("BulkUpdateCards",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+		mlog.Int("succeeded", result.Succeeded),
+		mlog.Int("failed", result.Failed),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("succeeded", result.Succeeded)
+	auditRec.AddMeta("failed", result.Failed)
+	auditRec.Success()
+}
+
 func (a *API) handleGetCard(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /cards/{cardID} getCard
 	//
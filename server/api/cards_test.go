@@ -0,0 +1,49 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
+	"github.com/stretchr/testify/require"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type allowBoardPermissions struct {
+	permissions.PermissionsService
+}
+
+func (p allowBoardPermissions) HasPermissionToBoard(_, _ string, _ *mm_model.Permission) bool {
+	return true
+}
+
+func TestGetCardsForViewPaging(t *testing.T) {
+	testAPI := API{logger: mlog.CreateConsoleTestLogger(t), permissions: allowBoardPermissions{}}
+
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{"negative page", "page=-1"},
+		{"negative per page", "per_page=-1"},
+		{"per page over the maximum", fmt.Sprintf("per_page=%d", maxCardsForViewPerPage+1)},
+		{"page overflowing the offset", fmt.Sprintf("page=%d&per_page=100", math.MaxInt/10)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v2/boards/board-id/views/view-id/cards?"+tc.query, nil)
+			w := httptest.NewRecorder()
+
+			testAPI.handleGetCardsForView(w, r)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
@@ -433,7 +433,6 @@ func (a *API) handleCreateGitHubBranch(w http.ResponseWriter, r *http.Request) {
 	//     required:
 	//       - owner
 	//       - repo
-	//       - branch_name
 	//     properties:
 	//       owner:
 	//         type: string
@@ -441,9 +440,13 @@ func (a *API) handleCreateGitHubBranch(w http.ResponseWriter, r *http.Request) {
 	//         type: string
 	//       branch_name:
 	//         type: string
+	//         description: Branch name, required unless card_id is given (defaults to the card code and title, e.g. AB-123-fix-login-bug)
 	//       base_branch:
 	//         type: string
 	//         description: Optional base branch (defaults to repo's default branch)
+	//       card_id:
+	//         type: string
+	//         description: Optional card the branch is for, moved according to the branch_created GitHub status rule of its board
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -477,19 +480,37 @@ func (a *API) handleCreateGitHubBranch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req github.CreateBranchRequest
+	var req struct {
+		github.CreateBranchRequest
+		CardID string `json:"card_id,omitempty"`
+	}
 	if unmarshalErr := json.Unmarshal(requestBody, &req); unmarshalErr != nil {
 		a.errorResponse(w, r, model.NewErrBadRequest(unmarshalErr.Error()))
 		return
 	}
 
+	userID := getUserID(r)
+
+	if req.BranchName == "" && req.CardID != "" {
+		card, cardErr := a.app.GetCardByID(req.CardID)
+		if cardErr != nil {
+			a.errorResponse(w, r, cardErr)
+			return
+		}
+		if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to card"))
+			return
+		}
+		if card.Code != "" {
+			req.BranchName = model.GitHubBranchName(card.Code, card.Title)
+		}
+	}
+
 	if req.Owner == "" || req.Repo == "" || req.BranchName == "" {
 		a.errorResponse(w, r, model.NewErrBadRequest("owner, repo, and branch_name are required"))
 		return
 	}
 
-	userID := getUserID(r)
-
 	auditRec := a.makeAuditRecord(r, "createGitHubBranch", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("owner", req.Owner)
@@ -502,7 +523,7 @@ func (a *API) handleCreateGitHubBranch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	branch, err := githubService.CreateBranch(userID, req)
+	branch, err := githubService.CreateBranch(userID, req.CreateBranchRequest)
 	if err != nil {
 		a.logger.Error("Failed to create GitHub branch",
 			mlog.String("userID", userID),
@@ -521,11 +542,31 @@ func (a *API) handleCreateGitHubBranch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.CardID != "" {
+		a.applyBranchCreatedStatusRule(userID, req.CardID)
+	}
+
 	jsonBytesResponse(w, http.StatusCreated, data)
 	auditRec.AddMeta("branchRef", branch.Ref)
 	auditRec.Success()
 }
 
+// applyBranchCreatedStatusRule moves the card a branch was created for, if
+// the user can change the card. The branch is created either way.
+func (a *API) applyBranchCreatedStatusRule(userID string, cardID string) {
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.logger.Warn("Cannot get the card of a new GitHub branch", mlog.String("cardID", cardID), mlog.Err(err))
+		return
+	}
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		return
+	}
+	if err := a.app.ApplyGitHubStatusRule(cardID, model.GitHubEventBranchCreated); err != nil {
+		a.logger.Warn("Cannot move the card of a new GitHub branch", mlog.String("cardID", cardID), mlog.Err(err))
+	}
+}
+
 func (a *API) handleGetGitHubPR(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /github/pr/{owner}/{repo}/{number} getGitHubPR
 	//
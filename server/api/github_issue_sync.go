@@ -0,0 +1,294 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerGitHubIssueSyncRoutes(r *mux.Router) {
+	// GitHub issue synchronization APIs
+	r.HandleFunc("/boards/{boardID}/github/issue-sync", a.sessionRequired(a.handleGetGitHubIssueSync)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/github/issue-sync", a.sessionRequired(a.handleSaveGitHubIssueSync)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/github/issue-sync", a.sessionRequired(a.handleDeleteGitHubIssueSync)).Methods("DELETE")
+	r.HandleFunc("/boards/{boardID}/github/issue-sync/status", a.sessionRequired(a.handleGetGitHubIssueSyncStatus)).Methods("GET")
+}
+
+func (a *API) handleGetGitHubIssueSync(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/github/issue-sync getGitHubIssueSync
+	//
+	// Fetches the GitHub issue synchronization of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/GitHubIssueSync"
+	//   '404':
+	//     description: the board is not synchronized with GitHub issues
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to view board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getGitHubIssueSync", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	sync, err := a.app.GetGitHubIssueSync(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(sync)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSaveGitHubIssueSync(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/github/issue-sync saveGitHubIssueSync
+	//
+	// Sets up the GitHub issue synchronization of a board. New issues of the
+	// repository become cards, and the cards and their issues are kept equal
+	// both ways. The repository is synchronized with the GitHub account of the
+	// current user. Moving the synchronization to another repository starts it
+	// over.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the synchronization settings
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/GitHubIssueSync"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/GitHubIssueSync"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to manage board properties"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxGitHubRequestSize)
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			a.errorResponse(w, r, model.ErrRequestEntityTooLarge)
+			return
+		}
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var settings *model.GitHubIssueSync
+	if err = json.Unmarshal(requestBody, &settings); err != nil || settings == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid github issue sync"))
+		return
+	}
+	sync := &model.GitHubIssueSync{
+		BoardID:              boardID,
+		Owner:                settings.Owner,
+		Repo:                 settings.Repo,
+		Enabled:              settings.Enabled,
+		OpenStatusOptionID:   settings.OpenStatusOptionID,
+		ClosedStatusOptionID: settings.ClosedStatusOptionID,
+		LabelPropertyID:      settings.LabelPropertyID,
+		LabelMappings:        settings.LabelMappings,
+		AssigneePropertyID:   settings.AssigneePropertyID,
+		UserMappings:         settings.UserMappings,
+		CreatedBy:            userID,
+	}
+
+	auditRec := a.makeAuditRecord(r, "saveGitHubIssueSync", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("owner", sync.Owner)
+	auditRec.AddMeta("repo", sync.Repo)
+	auditRec.AddMeta("enabled", sync.Enabled)
+
+	saved, err := a.app.SaveGitHubIssueSync(sync)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SaveGitHubIssueSync",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(saved)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteGitHubIssueSync(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/github/issue-sync deleteGitHubIssueSync
+	//
+	// Stops the GitHub issue synchronization of a board. The cards and issues
+	// are kept.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to manage board properties"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteGitHubIssueSync", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err := a.app.DeleteGitHubIssueSync(boardID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteGitHubIssueSync",
+		mlog.String("boardID", boardID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleGetGitHubIssueSyncStatus(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/github/issue-sync/status getGitHubIssueSyncStatus
+	//
+	// Fetches the state of the GitHub issue synchronization of a board: the
+	// last synchronization and its error, the conflicts resolved, and the
+	// number of cards synchronized with an issue.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/GitHubIssueSyncStatus"
+	//   '404':
+	//     description: the board is not synchronized with GitHub issues
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to view board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getGitHubIssueSyncStatus", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	status, err := a.app.GetGitHubIssueSyncStatus(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerGitHubStatusRulesRoutes(r *mux.Router) {
+	// GitHub status rules APIs
+	r.HandleFunc("/boards/{boardID}/github-status-rules", a.sessionRequired(a.handleGetGitHubStatusRules)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/github-status-rules", a.sessionRequired(a.handleSaveGitHubStatusRules)).Methods("POST")
+}
+
+func (a *API) handleGetGitHubStatusRules(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/github-status-rules getGitHubStatusRules
+	//
+	// Get the rules moving the cards of a board to a status on GitHub events
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/GitHubStatusRule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to view board"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getGitHubStatusRules", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	rules, err := a.app.GetGitHubStatusRules(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetGitHubStatusRules",
+		mlog.String("boardID", boardID),
+		mlog.Int("rulesCount", len(rules)),
+	)
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSaveGitHubStatusRules(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/github-status-rules saveGitHubStatusRules
+	//
+	// Replace the rules moving the cards of a board to a status on GitHub
+	// events, such as a linked pull request being opened or merged
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: Array of GitHub status rules, at most one per event
+	//   required: true
+	//   schema:
+	//     type: array
+	//     items:
+	//       "$ref": "#/definitions/GitHubStatusRule"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to manage board properties"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var rules []*model.GitHubStatusRule
+	if err = json.Unmarshal(requestBody, &rules); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid request body: "+err.Error()))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "saveGitHubStatusRules", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("rulesCount", len(rules))
+
+	if err = a.app.ReplaceGitHubStatusRules(boardID, rules); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SaveGitHubStatusRules",
+		mlog.String("boardID", boardID),
+		mlog.Int("rulesCount", len(rules)),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
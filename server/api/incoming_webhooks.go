@@ -0,0 +1,419 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	MaxIncomingWebhookRequestSize = 1024 * 1024 // 1MB limit for JSON request body
+)
+
+func (a *API) registerIncomingWebhooksRoutes(r *mux.Router) {
+	// Incoming Webhooks APIs
+	r.HandleFunc("/boards/{boardID}/incoming-webhooks", a.sessionRequired(a.handleGetIncomingWebhooks)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/incoming-webhooks", a.sessionRequired(a.handleCreateIncomingWebhook)).Methods("POST")
+	r.HandleFunc("/boards/{boardID}/incoming-webhooks/{webhookID}", a.sessionRequired(a.handlePatchIncomingWebhook)).Methods("PATCH")
+	r.HandleFunc("/boards/{boardID}/incoming-webhooks/{webhookID}", a.sessionRequired(a.handleDeleteIncomingWebhook)).Methods("DELETE")
+}
+
+func (a *API) registerHooksRoutes(r *mux.Router) {
+	// Incoming webhook calls, authenticated by the webhook token
+	r.HandleFunc("/incoming/{token}", a.handleExecuteIncomingWebhook).Methods("POST")
+}
+
+func (a *API) handleGetIncomingWebhooks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/incoming-webhooks getIncomingWebhooks
+	//
+	// Returns the incoming webhooks of a board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/IncomingWebhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to incoming webhooks"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getIncomingWebhooks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	webhooks, err := a.app.GetIncomingWebhooks(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(webhooks)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleCreateIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/incoming-webhooks createIncomingWebhook
+	//
+	// Creates an incoming webhook for a board. Cards posted to the webhook
+	// are created on behalf of the current user.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the incoming webhook to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/IncomingWebhook"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/IncomingWebhook'
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to incoming webhooks"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var newWebhook *model.IncomingWebhook
+	if err = json.Unmarshal(requestBody, &newWebhook); err != nil || newWebhook == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid incoming webhook"))
+		return
+	}
+	newWebhook.BoardID = boardID
+	newWebhook.CreatedBy = userID
+
+	auditRec := a.makeAuditRecord(r, "createIncomingWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	webhook, err := a.app.CreateIncomingWebhook(newWebhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateIncomingWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhook.ID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("webhookID", webhook.ID)
+	auditRec.Success()
+}
+
+func (a *API) handlePatchIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/incoming-webhooks/{webhookID} patchIncomingWebhook
+	//
+	// Updates an incoming webhook, or regenerates its token.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Incoming webhook ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: incoming webhook patch to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/IncomingWebhookPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/IncomingWebhook'
+	//   '404':
+	//     description: incoming webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var patch *model.IncomingWebhookPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid incoming webhook patch"))
+		return
+	}
+
+	if err = a.checkIncomingWebhookAccess(userID, boardID, webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchIncomingWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	webhook, err := a.app.PatchIncomingWebhook(webhookID, patch)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("PatchIncomingWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(webhook)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/incoming-webhooks/{webhookID} deleteIncomingWebhook
+	//
+	// Deletes an incoming webhook.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Incoming webhook ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: incoming webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	webhookID := vars["webhookID"]
+
+	if err := a.checkIncomingWebhookAccess(userID, boardID, webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteIncomingWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	if err := a.app.DeleteIncomingWebhook(webhookID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteIncomingWebhook",
+		mlog.String("boardID", boardID),
+		mlog.String("webhookID", webhookID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleExecuteIncomingWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /hooks/incoming/{token} executeIncomingWebhook
+	//
+	// Creates a card from a JSON payload, or updates the card whose code is
+	// mapped from the payload. No session or CSRF header is required, the
+	// token identifies the board.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: token
+	//   in: path
+	//   description: Incoming webhook token
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: a JSON object mapped to the card by the webhook field mapping
+	//   required: true
+	//   schema:
+	//     type: object
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       $ref: '#/definitions/Card'
+	//   '400':
+	//     description: the payload cannot be mapped to a card
+	//   '404':
+	//     description: unknown token or card code
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	token := mux.Vars(r)["token"]
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxIncomingWebhookRequestSize)
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "http: request body too large") {
+			a.errorResponse(w, r, model.ErrRequestEntityTooLarge)
+			return
+		}
+		a.errorResponse(w, r, model.NewErrBadRequest(err.Error()))
+		return
+	}
+
+	var payload map[string]interface{}
+	if err = json.Unmarshal(requestBody, &payload); err != nil || payload == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("payload must be a JSON object"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "executeIncomingWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	card, err := a.app.ExecuteIncomingWebhook(token, payload)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("ExecuteIncomingWebhook",
+		mlog.String("boardID", card.BoardID),
+		mlog.String("cardID", card.ID),
+	)
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("boardID", card.BoardID)
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.Success()
+}
+
+// checkIncomingWebhookAccess returns an error unless the user is an admin of
+// the board and the incoming webhook belongs to it.
+func (a *API) checkIncomingWebhookAccess(userID, boardID, webhookID string) error {
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardRoles) {
+		return model.NewErrPermission("access denied to incoming webhooks")
+	}
+
+	webhook, err := a.app.GetIncomingWebhook(webhookID)
+	if err != nil {
+		return err
+	}
+	if webhook.BoardID != boardID {
+		return model.NewErrNotFound("incoming webhook ID=" + webhookID)
+	}
+	return nil
+}
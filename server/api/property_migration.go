@@ -0,0 +1,119 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerPropertyMigrationRoutes(r *mux.Router) {
+	// Property Migration APIs
+	r.HandleFunc("/boards/{boardID}/properties/migrate", a.sessionRequired(a.handleMigrateCardProperty)).Methods("POST")
+}
+
+func (a *API) handleMigrateCardProperty(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/properties/migrate migrateCardProperty
+	//
+	// Changes the type of a card property, or merges some of its options into
+	// another, and converts the values of all the cards of the board in a
+	// single transaction. Values that cannot be converted are removed.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: dry_run
+	//   in: query
+	//   description: Only report how many cards would change
+	//   required: false
+	//   type: boolean
+	// - name: Body
+	//   in: body
+	//   description: the property migration
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/PropertyMigration"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/PropertyMigrationResult"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to manage board properties"))
+		return
+	}
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var migration *model.PropertyMigration
+	if err = json.Unmarshal(requestBody, &migration); err != nil || migration == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid property migration"))
+		return
+	}
+	if err = migration.IsValid(); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "migrateCardProperty", audit.Fail)
+	level := audit.LevelModify
+	if dryRun {
+		level = audit.LevelRead
+	}
+	defer a.audit.LogRecord(level, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", migration.PropertyID)
+	auditRec.AddMeta("dryRun", dryRun)
+
+	result, err := a.app.MigrateCardProperty(boardID, migration, userID, dryRun)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("MigrateCardProperty",
+		mlog.String("boardID", boardID),
+		mlog.String("propertyID", migration.PropertyID),
+		mlog.Bool("dryRun", dryRun),
+		mlog.Int("cardsChanged", result.CardsChanged),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("cardsChanged", result.CardsChanged)
+	auditRec.Success()
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// maxScheduleBoards is the number of boards a schedule can span.
+const maxScheduleBoards = 20
+
+func (a *API) registerSchedulesRoutes(r *mux.Router) {
+	// Schedules APIs
+	r.HandleFunc("/boards/{boardID}/schedule", a.sessionRequired(a.handleGetBoardSchedule)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/schedule", a.sessionRequired(a.handleGetTeamSchedule)).Methods("GET")
+}
+
+func (a *API) handleGetBoardSchedule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/schedule getBoardSchedule
+	//
+	// Computes the schedule of the cards of a board from their dates and their
+	// blocks relations: the earliest and latest dates, the slack and the
+	// critical path, and the cards planned to start before their blockers
+	// finish.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardSchedule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board schedule"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardSchedule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if a.writeSchedule(w, r, userID, []string{boardID}) {
+		auditRec.Success()
+	}
+}
+
+func (a *API) handleGetTeamSchedule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/schedule getTeamSchedule
+	//
+	// Computes the schedule of the cards of a set of boards of a team, with
+	// the blocks relations between the boards.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: board_ids
+	//   in: query
+	//   description: Comma-separated IDs of the boards to schedule, 20 at most
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardSchedule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+
+	var boardIDs []string
+	seen := make(map[string]bool)
+	for _, boardID := range strings.Split(r.URL.Query().Get("board_ids"), ",") {
+		boardID = strings.TrimSpace(boardID)
+		if boardID != "" && !seen[boardID] {
+			seen[boardID] = true
+			boardIDs = append(boardIDs, boardID)
+		}
+	}
+	if len(boardIDs) == 0 || len(boardIDs) > maxScheduleBoards {
+		a.errorResponse(w, r, model.NewErrBadRequest("board_ids must list between 1 and 20 boards"))
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to team"))
+		return
+	}
+	for _, boardID := range boardIDs {
+		board, err := a.app.GetBoard(boardID)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+		if board.TeamID != teamID || !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to board schedule"))
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "getTeamSchedule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("boardIDs", boardIDs)
+
+	if a.writeSchedule(w, r, userID, boardIDs) {
+		auditRec.Success()
+	}
+}
+
+// writeSchedule computes and writes the schedule of a set of boards. It
+// returns false if an error response was written instead.
+func (a *API) writeSchedule(w http.ResponseWriter, r *http.Request, userID string, boardIDs []string) bool {
+	schedule, err := a.app.GetSchedule(boardIDs)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return false
+	}
+
+	a.logger.Debug("GetSchedule",
+		mlog.Array("boardIDs", boardIDs),
+		mlog.String("userID", userID),
+		mlog.Int("cards", len(schedule.Cards)),
+	)
+
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return false
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	return true
+}
@@ -5,7 +5,10 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
@@ -14,11 +17,15 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
+const maxSearchCardsPerPage = 1000
+
 func (a *API) registerSearchRoutes(r *mux.Router) {
 	r.HandleFunc("/teams/{teamID}/channels", a.sessionRequired(a.handleSearchMyChannels)).Methods("GET")
 	r.HandleFunc("/teams/{teamID}/boards/search", a.sessionRequired(a.handleSearchBoards)).Methods("GET")
 	r.HandleFunc("/teams/{teamID}/boards/search/linkable", a.sessionRequired(a.handleSearchLinkableBoards)).Methods("GET")
 	r.HandleFunc("/boards/search", a.sessionRequired(a.handleSearchAllBoards)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/cards/search", a.sessionRequired(a.handleSearchCards)).Methods("GET")
+	r.HandleFunc("/teams/{teamID}/blocks/search", a.sessionRequired(a.handleSearchBlocks)).Methods("GET")
 }
 
 func (a *API) handleSearchMyChannels(w http.ResponseWriter, r *http.Request) {
@@ -342,3 +349,253 @@ func (a *API) handleSearchAllBoards(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardsCount", len(boards))
 	auditRec.Success()
 }
+
+func (a *API) handleSearchCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/cards/search searchCards
+	//
+	// Returns the cards of the team's boards that match a card query, e.g.
+	// `status:"In Progress" assignee:@me board:AB updated:>2026-01-01 "free text"`.
+	// Only cards of boards the user can view are returned.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: q
+	//   in: query
+	//   description: The card query
+	//   required: true
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: The page to select (default=0)
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: Number of cards to return per page(default=100, max=1000)
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Card"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	query := r.URL.Query()
+	term := query.Get("q")
+	strPage := query.Get("page")
+	strPerPage := query.Get("per_page")
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to team"))
+		return
+	}
+
+	if len(term) == 0 {
+		jsonStringResponse(w, http.StatusOK, "[]")
+		return
+	}
+
+	if strPage == "" {
+		strPage = defaultPage
+	}
+	if strPerPage == "" {
+		strPerPage = defaultPerPage
+	}
+
+	page, err := strconv.Atoi(strPage)
+	if err != nil || page < 0 {
+		message := fmt.Sprintf("invalid `page` parameter: %s", strPage)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	perPage, err := strconv.Atoi(strPerPage)
+	if err != nil || perPage < 0 {
+		message := fmt.Sprintf("invalid `per_page` parameter: %s", strPerPage)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+	if perPage > maxSearchCardsPerPage {
+		message := fmt.Sprintf("`per_page` parameter cannot be greater than %d", maxSearchCardsPerPage)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+	if perPage > 0 && page > math.MaxInt/perPage {
+		message := fmt.Sprintf("invalid `page` parameter: %s", strPage)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "searchCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("page", page)
+	auditRec.AddMeta("per_page", perPage)
+
+	isGuest, err := a.userIsGuest(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	cards, err := a.app.SearchCardsForUser(teamID, userID, term, !isGuest, page, perPage)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SearchCards",
+		mlog.String("teamID", teamID),
+		mlog.String("userID", userID),
+		mlog.Int("cardsCount", len(cards)),
+	)
+
+	data, err := json.Marshal(cards)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("cardsCount", len(cards))
+	auditRec.Success()
+}
+
+func (a *API) handleSearchBlocks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/blocks/search searchBlocks
+	//
+	// Returns the card, text, comment and checkbox blocks of the team's boards
+	// whose text matches the search terms, most relevant first. Only blocks of
+	// boards the user can view are returned.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: q
+	//   in: query
+	//   description: The words to search for
+	//   required: true
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: The page to select (default=0)
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: Number of results to return per page(default=100)
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BlockSearchResult"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	query := r.URL.Query()
+	terms := query.Get("q")
+	strPage := query.Get("page")
+	strPerPage := query.Get("per_page")
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to team"))
+		return
+	}
+
+	if len(terms) == 0 {
+		jsonStringResponse(w, http.StatusOK, "[]")
+		return
+	}
+
+	if strPage == "" {
+		strPage = defaultPage
+	}
+	if strPerPage == "" {
+		strPerPage = defaultPerPage
+	}
+
+	page, err := strconv.Atoi(strPage)
+	if err != nil {
+		message := fmt.Sprintf("invalid `page` parameter: %s", err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	perPage, err := strconv.Atoi(strPerPage)
+	if err != nil {
+		message := fmt.Sprintf("invalid `per_page` parameter: %s", err)
+		a.errorResponse(w, r, model.NewErrBadRequest(message))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "searchBlocks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("page", page)
+	auditRec.AddMeta("per_page", perPage)
+
+	isGuest, err := a.userIsGuest(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	results, err := a.app.SearchBlocksForUser(teamID, userID, terms, !isGuest, page, perPage)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("SearchBlocks",
+		mlog.String("teamID", teamID),
+		mlog.String("userID", userID),
+		mlog.Int("resultsCount", len(results)),
+	)
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// response
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("resultsCount", len(results))
+	auditRec.Success()
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
+	"github.com/stretchr/testify/require"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type allowTeamPermissions struct {
+	permissions.PermissionsService
+}
+
+func (p allowTeamPermissions) HasPermissionToTeam(_, _ string, _ *mm_model.Permission) bool {
+	return true
+}
+
+func TestSearchCardsPaging(t *testing.T) {
+	testAPI := API{logger: mlog.CreateConsoleTestLogger(t), permissions: allowTeamPermissions{}}
+
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{"negative page", "page=-1"},
+		{"negative per page", "per_page=-1"},
+		{"per page over the maximum", fmt.Sprintf("per_page=%d", maxSearchCardsPerPage+1)},
+		{"page overflowing the offset", fmt.Sprintf("page=%d&per_page=100", math.MaxInt/10)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/v2/teams/team-id/cards/search?q=launch&"+tc.query, nil)
+			w := httptest.NewRecorder()
+
+			testAPI.handleSearchCards(w, r)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
@@ -0,0 +1,451 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/tracker"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// BoardTracker is the issue tracker selected by a board.
+// swagger:model
+type BoardTracker struct {
+	// The issue tracker of the board
+	// required: true
+	Provider model.TrackerProvider `json:"provider"`
+
+	// Whether the tracker can be used by the current user
+	// required: true
+	Connected bool `json:"connected"`
+
+	// The account the tracker is used with
+	// required: false
+	Username string `json:"username,omitempty"`
+}
+
+func (a *API) registerTrackersRoutes(r *mux.Router) {
+	// Issue tracker APIs, common to all the providers
+	r.HandleFunc("/trackers/{provider}/connected", a.sessionRequired(a.handleGetTrackerConnected)).Methods("GET")
+	r.HandleFunc("/trackers/{provider}/issues", a.sessionRequired(a.handleSearchTrackerIssues)).Methods("GET")
+	r.HandleFunc("/trackers/{provider}/issues", a.sessionRequired(a.handleCreateTrackerIssue)).Methods("POST")
+	r.HandleFunc("/trackers/{provider}/issue", a.sessionRequired(a.handleGetTrackerIssue)).Methods("GET")
+	r.HandleFunc("/boards/{boardID}/tracker", a.sessionRequired(a.handleGetBoardTracker)).Methods("GET")
+}
+
+// getTrackerProvider returns the tracker of the request, reporting an
+// unavailable tracker as not implemented.
+func (a *API) getTrackerProvider(id model.TrackerProvider) (tracker.Provider, error) {
+	provider, err := a.app.GetTrackerProvider(id)
+	if err != nil && !model.IsErrBadRequest(err) {
+		return nil, model.NewErrNotImplemented(err.Error())
+	}
+	return provider, err
+}
+
+func (a *API) handleGetTrackerConnected(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /trackers/{provider}/connected getTrackerConnected
+	//
+	// Checks whether an issue tracker can be used by the user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: provider
+	//   in: path
+	//   description: The issue tracker, github, gitlab or jira
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: object
+	//       properties:
+	//         provider:
+	//           type: string
+	//         connected:
+	//           type: boolean
+	//         username:
+	//           type: string
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	providerID := model.TrackerProvider(mux.Vars(r)["provider"])
+
+	auditRec := a.makeAuditRecord(r, "getTrackerConnected", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("provider", providerID)
+
+	provider, err := a.getTrackerProvider(providerID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	status, err := provider.GetConnectStatus(userID)
+	if err != nil {
+		a.logger.Error("Failed to check the issue tracker connection",
+			mlog.String("provider", string(providerID)),
+			mlog.String("userID", userID),
+			mlog.Err(err),
+		)
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSearchTrackerIssues(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /trackers/{provider}/issues searchTrackerIssues
+	//
+	// Searches the issues of an issue tracker
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: provider
+	//   in: path
+	//   description: The issue tracker, github, gitlab or jira
+	//   required: true
+	//   type: string
+	// - name: q
+	//   in: query
+	//   description: The text to search
+	//   required: false
+	//   type: string
+	// - name: project
+	//   in: query
+	//   description: The project to search in, owner/repo on GitHub, a project path on GitLab or a project key on Jira
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         type: object
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	providerID := model.TrackerProvider(mux.Vars(r)["provider"])
+	query := r.URL.Query()
+	text := query.Get("q")
+	project := query.Get("project")
+
+	auditRec := a.makeAuditRecord(r, "searchTrackerIssues", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("provider", providerID)
+	auditRec.AddMeta("project", project)
+
+	provider, err := a.getTrackerProvider(providerID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	issues, err := provider.SearchIssues(userID, project, text)
+	if err != nil {
+		a.logger.Error("Failed to search the issue tracker",
+			mlog.String("provider", string(providerID)),
+			mlog.String("userID", userID),
+			mlog.Err(err),
+		)
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(issues)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleCreateTrackerIssue(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /trackers/{provider}/issues createTrackerIssue
+	//
+	// Creates an issue in an issue tracker
+	//
+	// ---
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: provider
+	//   in: path
+	//   description: The issue tracker, github, gitlab or jira
+	//   required: true
+	//   type: string
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     type: object
+	//     required:
+	//       - project
+	//       - title
+	//     properties:
+	//       project:
+	//         type: string
+	//       title:
+	//         type: string
+	//       body:
+	//         type: string
+	//       labels:
+	//         type: array
+	//         items:
+	//           type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: object
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	providerID := model.TrackerProvider(mux.Vars(r)["provider"])
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxGitHubRequestSize)
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			a.errorResponse(w, r, model.ErrRequestEntityTooLarge)
+			return
+		}
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var req tracker.CreateIssueRequest
+	if err = json.Unmarshal(requestBody, &req); err != nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid issue"))
+		return
+	}
+	if req.Project == "" || req.Title == "" {
+		a.errorResponse(w, r, model.NewErrBadRequest("project and title are required"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createTrackerIssue", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("provider", providerID)
+	auditRec.AddMeta("project", req.Project)
+
+	provider, err := a.getTrackerProvider(providerID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	issue, err := provider.CreateIssue(userID, req)
+	if err != nil {
+		a.logger.Error("Failed to create the issue",
+			mlog.String("provider", string(providerID)),
+			mlog.String("userID", userID),
+			mlog.String("project", req.Project),
+			mlog.Err(err),
+		)
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(issue)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("reference", issue.Reference)
+	auditRec.Success()
+}
+
+func (a *API) handleGetTrackerIssue(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /trackers/{provider}/issue getTrackerIssue
+	//
+	// Fetches an issue of an issue tracker
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: provider
+	//   in: path
+	//   description: The issue tracker, github, gitlab or jira
+	//   required: true
+	//   type: string
+	// - name: project
+	//   in: query
+	//   description: The project of the issue
+	//   required: true
+	//   type: string
+	// - name: number
+	//   in: query
+	//   description: The number of the issue in its project
+	//   required: true
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: object
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	providerID := model.TrackerProvider(mux.Vars(r)["provider"])
+	query := r.URL.Query()
+	project := query.Get("project")
+
+	number, err := strconv.Atoi(query.Get("number"))
+	if err != nil || number <= 0 || project == "" {
+		a.errorResponse(w, r, model.NewErrBadRequest("project and number are required"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getTrackerIssue", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("provider", providerID)
+	auditRec.AddMeta("project", project)
+	auditRec.AddMeta("number", number)
+
+	provider, err := a.getTrackerProvider(providerID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	issue, err := provider.GetIssue(userID, project, number)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(issue)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleGetBoardTracker(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/tracker getBoardTracker
+	//
+	// Returns the issue tracker selected by a board, and whether the current
+	// user can use it.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardTracker"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to board tracker"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardTracker", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	board, err := a.app.GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+	providerID, err := model.GetBoardTrackerProvider(board)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	// an unavailable tracker is reported as not connected.
+	result := BoardTracker{Provider: providerID}
+	if provider, err := a.app.GetTrackerProvider(providerID); err == nil {
+		status, err := provider.GetConnectStatus(userID)
+		if err != nil {
+			a.errorResponse(w, r, err)
+			return
+		}
+		result.Connected = status.Connected
+		result.Username = status.Username
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
@@ -0,0 +1,463 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (a *API) registerWorklogsRoutes(r *mux.Router) {
+	// Worklog APIs
+	r.HandleFunc("/cards/{cardID}/worklogs", a.sessionRequired(a.handleGetCardWorklogs)).Methods("GET")
+	r.HandleFunc("/cards/{cardID}/worklogs", a.sessionRequired(a.handleCreateWorklog)).Methods("POST")
+	r.HandleFunc("/worklogs/{worklogID}", a.sessionRequired(a.handlePatchWorklog)).Methods("PATCH")
+	r.HandleFunc("/worklogs/{worklogID}", a.sessionRequired(a.handleDeleteWorklog)).Methods("DELETE")
+	r.HandleFunc("/teams/{teamID}/worklogs/report", a.sessionRequired(a.handleGetWorklogReport)).Methods("GET")
+}
+
+func (a *API) handleGetCardWorklogs(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /cards/{cardID}/worklogs getCardWorklogs
+	//
+	// Fetches the time logged on a card, oldest first.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Worklog"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to fetch worklogs"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardWorklogs", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("cardID", cardID)
+
+	worklogs, err := a.app.GetWorklogsForCard(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetCardWorklogs",
+		mlog.String("cardID", cardID),
+		mlog.Int("count", len(worklogs)),
+	)
+
+	data, err := json.Marshal(worklogs)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleCreateWorklog(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /cards/{cardID}/worklogs createWorklog
+	//
+	// Logs time spent by the current user on a card.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the worklog entry, with its minutes, date and note
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Worklog"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Worklog"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := mux.Vars(r)["cardID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var worklog *model.Worklog
+	if err = json.Unmarshal(requestBody, &worklog); err != nil || worklog == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid worklog"))
+		return
+	}
+	worklog.ID = ""
+	worklog.CardID = cardID
+	worklog.UserID = userID
+	worklog.CreateAt = 0
+	worklog.UpdateAt = 0
+	worklog.DeleteAt = 0
+
+	card, err := a.app.GetCardByID(cardID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, card.BoardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to log time on card"))
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createWorklog", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("minutes", worklog.Minutes)
+
+	created, err := a.app.CreateWorklog(worklog)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("CreateWorklog",
+		mlog.String("worklogID", created.ID),
+		mlog.String("cardID", cardID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(created)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("worklogID", created.ID)
+	auditRec.Success()
+}
+
+// getWorklogToModify returns a worklog entry if the user may change it: its
+// author with card permissions, or a board admin.
+func (a *API) getWorklogToModify(userID string, worklogID string) (*model.Worklog, error) {
+	worklog, err := a.app.GetWorklog(worklogID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, worklog.BoardID, model.PermissionManageBoardCards) {
+		return nil, model.NewErrPermission("access denied to modify worklogs")
+	}
+	if worklog.UserID != userID && !a.permissions.HasPermissionToBoard(userID, worklog.BoardID, model.PermissionManageBoardRoles) {
+		return nil, model.NewErrPermission("access denied to modify other users' worklogs")
+	}
+	return worklog, nil
+}
+
+func (a *API) handlePatchWorklog(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /worklogs/{worklogID} patchWorklog
+	//
+	// Changes the minutes, date or note of a worklog entry. Only its author
+	// and board admins can change an entry.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: worklogID
+	//   in: path
+	//   description: Worklog ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the worklog patch
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/WorklogPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Worklog"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	worklogID := mux.Vars(r)["worklogID"]
+
+	requestBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	var patch *model.WorklogPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r, model.NewErrBadRequest("invalid worklog patch"))
+		return
+	}
+
+	if _, err = a.getWorklogToModify(userID, worklogID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchWorklog", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("worklogID", worklogID)
+
+	worklog, err := a.app.PatchWorklog(worklogID, patch)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("PatchWorklog",
+		mlog.String("worklogID", worklogID),
+		mlog.String("userID", userID),
+	)
+
+	data, err := json.Marshal(worklog)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteWorklog(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /worklogs/{worklogID} deleteWorklog
+	//
+	// Deletes a worklog entry. Only its author and board admins can delete an
+	// entry.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: worklogID
+	//   in: path
+	//   description: Worklog ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	worklogID := mux.Vars(r)["worklogID"]
+
+	worklog, err := a.getWorklogToModify(userID, worklogID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteWorklog", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("worklogID", worklogID)
+	auditRec.AddMeta("cardID", worklog.CardID)
+
+	if err := a.app.DeleteWorklog(worklogID); err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("DeleteWorklog",
+		mlog.String("worklogID", worklogID),
+		mlog.String("userID", userID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleGetWorklogReport(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/worklogs/report getWorklogReport
+	//
+	// Aggregates the time logged on the boards of a team, by user, board,
+	// card, property value or day.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: group_by
+	//   in: query
+	//   description: How to group the entries, one of user, board, card, property or date (default=user)
+	//   required: false
+	//   type: string
+	// - name: property
+	//   in: query
+	//   description: The name of the card property to group by, when group_by is property
+	//   required: false
+	//   type: string
+	// - name: board_id
+	//   in: query
+	//   description: Comma separated board IDs. If empty then all the boards of the team the user can see are included
+	//   required: false
+	//   type: string
+	// - name: user_id
+	//   in: query
+	//   description: If set then only the entries of this user are included
+	//   required: false
+	//   type: string
+	// - name: from
+	//   in: query
+	//   description: Only the entries dated from this time are included; Unix time in milliseconds
+	//   required: false
+	//   type: integer
+	// - name: to
+	//   in: query
+	//   description: Only the entries dated before this time are included; Unix time in milliseconds
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/WorklogReport"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+	query := r.URL.Query()
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r, model.NewErrPermission("access denied to team"))
+		return
+	}
+
+	opts := &model.WorklogReportOptions{
+		UserID:   query.Get("user_id"),
+		GroupBy:  model.WorklogGroupBy(query.Get("group_by")),
+		Property: query.Get("property"),
+	}
+	if opts.GroupBy == "" {
+		opts.GroupBy = model.WorklogGroupByUser
+	}
+	if boardIDs := query.Get("board_id"); boardIDs != "" {
+		opts.BoardIDs = strings.Split(boardIDs, ",")
+	}
+	for name, value := range map[string]*int64{"from": &opts.From, "to": &opts.To} {
+		if str := query.Get(name); str != "" {
+			millis, err := strconv.ParseInt(str, 10, 64)
+			if err != nil {
+				a.errorResponse(w, r, model.NewErrBadRequest(fmt.Sprintf("invalid `%s` parameter: %s", name, err)))
+				return
+			}
+			*value = millis
+		}
+	}
+
+	for _, boardID := range opts.BoardIDs {
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+			a.errorResponse(w, r, model.NewErrPermission("access denied to board "+boardID))
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "getWorklogReport", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("groupBy", opts.GroupBy)
+
+	isGuest, err := a.userIsGuest(userID)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	report, err := a.app.GetWorklogReport(teamID, userID, !isGuest, opts)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	a.logger.Debug("GetWorklogReport",
+		mlog.String("teamID", teamID),
+		mlog.String("groupBy", string(opts.GroupBy)),
+		mlog.Int("entries", report.Entries),
+	)
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		a.errorResponse(w, r, err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/mattermost/mattermost-plugin-boards/server/auth"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/config"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/github"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/metrics"
@@ -57,6 +59,7 @@ type Services struct {
 	Permissions      permissions.PermissionsService
 	SkipTemplateInit bool
 	ServicesAPI      servicesAPI
+	Audit            *audit.Audit
 }
 
 type App struct {
@@ -72,8 +75,11 @@ type App struct {
 	permissions         permissions.PermissionsService
 	blockChangeNotifier *utils.CallbackQueue
 	servicesAPI         servicesAPI
+	audit               *audit.Audit
 	githubService       *github.Service
 	githubServiceMux    sync.Mutex
+	boardsBotID         string
+	boardsBotIDMux      sync.Mutex
 
 	cardLimitMux sync.RWMutex
 	cardLimit    int
@@ -109,6 +115,32 @@ func (a *App) GetGitHubService() *github.Service {
 	return a.githubService
 }
 
+// botServicesAPI is implemented by the services API of the plugin.
+type botServicesAPI interface {
+	EnsureBot(bot *mm_model.Bot) (string, error)
+}
+
+// getBoardsBotID returns the id of the boards bot, which automatic changes
+// are attributed to. The system user is used when the bot is not available.
+func (a *App) getBoardsBotID() string {
+	a.boardsBotIDMux.Lock()
+	defer a.boardsBotIDMux.Unlock()
+
+	if a.boardsBotID == "" {
+		bots, ok := a.servicesAPI.(botServicesAPI)
+		if !ok {
+			return model.SystemUserID
+		}
+		botID, err := bots.EnsureBot(model.FocalboardBot)
+		if err != nil {
+			a.logger.Error("Cannot ensure the boards bot", mlog.Err(err))
+			return model.SystemUserID
+		}
+		a.boardsBotID = botID
+	}
+	return a.boardsBotID
+}
+
 func New(config *config.Configuration, wsAdapter ws.Adapter, services Services) *App {
 	app := &App{
 		config:              config,
@@ -123,6 +155,7 @@ func New(config *config.Configuration, wsAdapter ws.Adapter, services Services)
 		permissions:         services.Permissions,
 		blockChangeNotifier: utils.NewCallbackQueue("blockChangeNotifier", blockChangeNotifierQueueSize, blockChangeNotifierPoolSize, services.Logger),
 		servicesAPI:         services.ServicesAPI,
+		audit:               services.Audit,
 	}
 	app.initialize(services.SkipTemplateInit)
 	return app
@@ -0,0 +1,120 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+)
+
+// CreateAutomationRule creates an automation rule after checking it against
+// the board schema and the permissions of its creator.
+func (a *App) CreateAutomationRule(rule *model.AutomationRule) (*model.AutomationRule, error) {
+	rule.ID = ""
+	rule.CreateAt = 0
+	rule.ModifiedBy = rule.CreatedBy
+
+	if err := a.validateAutomationRule(rule, rule.CreatedBy); err != nil {
+		return nil, err
+	}
+
+	created, err := a.store.CreateAutomationRule(rule)
+	if errors.Is(err, model.ErrInvalidAutomationRule) {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetAutomationRule returns an automation rule.
+func (a *App) GetAutomationRule(ruleID string) (*model.AutomationRule, error) {
+	return a.store.GetAutomationRule(ruleID)
+}
+
+// GetAutomationRules returns the automation rules of a board.
+func (a *App) GetAutomationRules(boardID string) ([]*model.AutomationRule, error) {
+	return a.store.GetAutomationRules(boardID)
+}
+
+// PatchAutomationRule updates an automation rule.
+func (a *App) PatchAutomationRule(ruleID string, patch *model.AutomationRulePatch, userID string) (*model.AutomationRule, error) {
+	existing, err := a.store.GetAutomationRule(ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := existing.Patch(patch)
+	rule.ModifiedBy = userID
+	if patch.Trigger != nil && patch.Trigger.Type == model.AutomationTriggerDueDatePassed &&
+		existing.Trigger.Type != model.AutomationTriggerDueDatePassed {
+		// only dates passing after the trigger was set run the rule
+		rule.DueCheckedAt = rule.UpdateAt
+	}
+
+	if err := a.validateAutomationRule(rule, rule.CreatedBy); err != nil {
+		return nil, err
+	}
+
+	updated, err := a.store.UpdateAutomationRule(rule)
+	if errors.Is(err, model.ErrInvalidAutomationRule) {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteAutomationRule deletes an automation rule.
+func (a *App) DeleteAutomationRule(ruleID string) error {
+	return a.store.DeleteAutomationRule(ruleID)
+}
+
+// validateAutomationRule checks a rule against its board schema, and that the
+// user the rule runs for can reach the boards and channels of its actions.
+func (a *App) validateAutomationRule(rule *model.AutomationRule, userID string) error {
+	board, err := a.store.GetBoard(rule.BoardID)
+	if err != nil {
+		return err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+
+	if err := rule.Trigger.IsValid(); err != nil {
+		return model.NewErrBadRequest(err.Error())
+	}
+	if err := rule.IsValidForSchema(schema); err != nil {
+		return model.NewErrBadRequest(err.Error())
+	}
+
+	for _, action := range rule.Actions {
+		switch action.Type {
+		case model.AutomationActionMoveToBoard, model.AutomationActionCreateLinkedCard:
+			if action.BoardID == "" || action.BoardID == board.ID {
+				// each card created on the board would run the rule again.
+				if action.Type == model.AutomationActionCreateLinkedCard && rule.Trigger.Type == model.AutomationTriggerCardCreated {
+					return model.NewErrBadRequest("a card_created rule cannot create linked cards on its own board")
+				}
+				continue
+			}
+			if !a.permissions.HasPermissionToBoard(userID, action.BoardID, model.PermissionManageBoardCards) {
+				return model.NewErrPermission(fmt.Sprintf("access denied to manage the cards of board %s", action.BoardID))
+			}
+		case model.AutomationActionPostToChannel:
+			if !a.permissions.HasPermissionToChannel(userID, action.ChannelID, mm_model.PermissionCreatePost) {
+				return model.NewErrPermission(fmt.Sprintf("access denied to post in channel %s", action.ChannelID))
+			}
+		}
+	}
+	return nil
+}
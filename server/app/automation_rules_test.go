@@ -0,0 +1,267 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/notifyautomation"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func TestCreateAutomationRule(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": model.PropTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "opt-done", "value": "Done"},
+				},
+			},
+		},
+	}
+
+	newRule := func(status string) *model.AutomationRule {
+		return &model.AutomationRule{
+			ID:        "chosen-id",
+			BoardID:   board.ID,
+			Enabled:   true,
+			CreatedBy: "user-id",
+			Trigger:   model.AutomationTrigger{Type: model.AutomationTriggerStatusEntered, Value: status},
+			Actions:   []model.AutomationAction{{Type: model.AutomationActionAddComment, Text: "Done!"}},
+		}
+	}
+
+	t.Run("unknown status", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		_, err := th.App.CreateAutomationRule(newRule("opt-missing"))
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("valid rule", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().CreateAutomationRule(gomock.Any()).DoAndReturn(func(rule *model.AutomationRule) (*model.AutomationRule, error) {
+			assert.Empty(t, rule.ID)
+			assert.Equal(t, "user-id", rule.ModifiedBy)
+			return rule, nil
+		})
+
+		_, err := th.App.CreateAutomationRule(newRule("opt-done"))
+		require.NoError(t, err)
+	})
+
+	t.Run("card created rule creating linked cards on its board", func(t *testing.T) {
+		for _, boardID := range []string{"", board.ID} {
+			th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+			rule := newRule("")
+			rule.Trigger = model.AutomationTrigger{Type: model.AutomationTriggerCardCreated}
+			rule.Actions = []model.AutomationAction{{Type: model.AutomationActionCreateLinkedCard, BoardID: boardID, Title: "Follow-up"}}
+			_, err := th.App.CreateAutomationRule(rule)
+			require.True(t, model.IsErrBadRequest(err))
+		}
+	})
+}
+
+func TestMoveCard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+	th.Store.EXPECT().GetCardParent(gomock.Any()).Return(nil, model.NewErrNotFound("card parent")).AnyTimes()
+
+	source := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: "team-id", Code: "SRC"}
+	target := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: "team-id",
+		Code:   "DST",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": model.PropTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "opt-done", "value": "Done"},
+				},
+			},
+			{"id": "notes", "name": "Notes", "type": model.PropTypeText},
+		},
+	}
+
+	card := &model.Block{
+		ID:      "card-id",
+		BoardID: source.ID,
+		Type:    model.TypeCard,
+		Number:  3,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"status":  "opt-todo",
+				"notes":   "keep me",
+				"unknown": "drop me",
+			},
+		},
+	}
+
+	t.Run("same board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+
+		_, err := th.App.MoveCard("card-id", source.ID, "user-id")
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("moves the card with a new number", func(t *testing.T) {
+		var moved *model.Block
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+		th.Store.EXPECT().GetBoard(source.ID).Return(source, nil)
+		th.Store.EXPECT().GetBoard(target.ID).Return(target, nil)
+		th.Store.EXPECT().GetNextCardNumber(target.ID).Return(int64(42), nil)
+		th.Store.EXPECT().MoveCardToBoard(gomock.Any(), target.ID, "user-id").DoAndReturn(func(block *model.Block, _ string, _ string) error {
+			moved = block
+			return nil
+		})
+		th.Store.EXPECT().GetBlock("card-id").DoAndReturn(func(_ string) (*model.Block, error) {
+			result := *moved
+			result.BoardID = target.ID
+			return &result, nil
+		})
+		th.Store.EXPECT().GetBlocks(model.QueryBlocksOptions{BoardID: target.ID, ParentID: "card-id"}).Return([]*model.Block{}, nil)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		movedCard, err := th.App.MoveCard("card-id", target.ID, "user-id")
+		require.NoError(t, err)
+		assert.Equal(t, target.ID, movedCard.BoardID)
+		assert.Equal(t, int64(42), movedCard.Number)
+		assert.Equal(t, "DST-42", movedCard.Code)
+		assert.Equal(t, map[string]interface{}{"notes": "keep me"}, movedCard.Properties)
+		assert.Equal(t, source.ID, card.BoardID, "the original block is not modified")
+	})
+}
+
+// allowAllPermissions grants every permission.
+type allowAllPermissions struct {
+	permissions.PermissionsService
+}
+
+func (allowAllPermissions) HasPermissionToBoard(_, _ string, _ *mm_model.Permission) bool {
+	return true
+}
+
+// automationAppAPI runs the automation backend on the app, as the plugin does.
+type automationAppAPI struct {
+	*App
+}
+
+func (a automationAppAPI) GetEnabledAutomationRulesByTrigger(triggerType model.AutomationTriggerType) ([]*model.AutomationRule, error) {
+	return a.store.GetEnabledAutomationRulesByTrigger(triggerType)
+}
+
+func (a automationAppAPI) ClaimAutomationRuleDueCheck(rule *model.AutomationRule, until int64) (bool, error) {
+	return a.store.ClaimAutomationRuleDueCheck(rule, until)
+}
+
+func TestAutomationRuleActionsKeepCardProperties(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": model.PropTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "opt-todo", "value": "To Do"},
+					map[string]interface{}{"id": "opt-done", "value": "Done"},
+				},
+			},
+			{
+				"id":   "priority",
+				"name": "Priority",
+				"type": model.PropTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "opt-high", "value": "High"},
+				},
+			},
+			{"id": "owner", "name": "Owner", "type": model.PropTypeMultiPerson},
+			{"id": "due", "name": "Due", "type": model.PropTypeDate},
+		},
+	}
+	rule := &model.AutomationRule{
+		ID:        "rule-id",
+		BoardID:   board.ID,
+		Enabled:   true,
+		CreatedBy: "creator-id",
+		Trigger:   model.AutomationTrigger{Type: model.AutomationTriggerStatusEntered, Value: "opt-done"},
+		Actions: []model.AutomationAction{
+			{Type: model.AutomationActionSetProperty, PropertyID: "priority", Value: "opt-high"},
+			{Type: model.AutomationActionAssignPerson, PropertyID: "owner", UserID: "reviewer-id"},
+		},
+	}
+	cardWithStatus := func(status string) *model.Block {
+		return model.Card2Block(&model.Card{
+			ID:        "card-id",
+			BoardID:   board.ID,
+			CreatedBy: "creator-id",
+			Title:     "Launch",
+			Properties: map[string]any{
+				"status": status,
+				"owner":  []any{"owner-id"},
+				"due":    `{"from":1700000000000}`,
+			},
+		})
+	}
+
+	block := cardWithStatus("opt-done")
+	th.Store.EXPECT().GetAutomationRules(board.ID).Return([]*model.AutomationRule{rule}, nil)
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetBlock("card-id").DoAndReturn(func(string) (*model.Block, error) {
+		return block, nil
+	}).AnyTimes()
+	th.Store.EXPECT().PatchBlock("card-id", gomock.Any(), "creator-id").DoAndReturn(func(_ string, patch *model.BlockPatch, _ string) error {
+		block = patch.Patch(block)
+		return nil
+	}).Times(2)
+	th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+	th.Store.EXPECT().GetWorklogTotals(gomock.Any()).Return(map[string]int64{}, nil).AnyTimes()
+
+	backend := notifyautomation.New(notifyautomation.BackendParams{
+		AppAPI:      automationAppAPI{th.App},
+		Permissions: allowAllPermissions{},
+		Logger:      mlog.CreateConsoleTestLogger(t),
+	})
+	require.NoError(t, backend.BlockChanged(notify.BlockChangeEvent{
+		Action:       notify.Update,
+		Board:        board,
+		Card:         block,
+		BlockChanged: block,
+		BlockOld:     cardWithStatus("opt-todo"),
+	}))
+
+	card, err := model.Block2Card(block)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"status":   "opt-done",
+		"priority": "opt-high",
+		"owner":    []any{"owner-id", "reviewer-id"},
+		"due":      `{"from":1700000000000}`,
+	}, card.Properties)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/notifymentions"
+)
+
+// SearchBlocksForUser runs a full-text search over the card, text, comment
+// and checkbox blocks of the team boards that the user can view. Results are
+// ranked by relevance and carry a highlighted snippet of the matched text.
+func (a *App) SearchBlocksForUser(teamID, userID, terms string, includePublicBoards bool, page, perPage int) ([]*model.BlockSearchResult, error) {
+	words := model.SearchWords(terms)
+	if len(words) == 0 {
+		return []*model.BlockSearchResult{}, nil
+	}
+
+	boards, err := a.getSearchableBoards(teamID, userID, includePublicBoards)
+	if err != nil {
+		return nil, err
+	}
+	if len(boards) == 0 {
+		return []*model.BlockSearchResult{}, nil
+	}
+
+	boardsByID := make(map[string]*model.Board, len(boards))
+	boardIDs := make([]string, 0, len(boards))
+	for _, board := range boards {
+		boardsByID[board.ID] = board
+		boardIDs = append(boardIDs, board.ID)
+	}
+
+	blocks, err := a.store.SearchBlocks(model.QueryBlockSearchOptions{
+		Terms:    terms,
+		BoardIDs: boardIDs,
+		Page:     page,
+		PerPage:  perPage,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := a.getSearchResultCards(blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	highlighter := searchWordsRegexp(words)
+	results := make([]*model.BlockSearchResult, 0, len(blocks))
+	for _, block := range blocks {
+		cardID := block.ParentID
+		if block.Type == model.TypeCard {
+			cardID = block.ID
+		}
+
+		// content of deleted and template cards is not searchable
+		card, ok := cards[cardID]
+		if !ok || card.IsTemplate {
+			continue
+		}
+		a.populateCardCode(card, boardsByID[card.BoardID])
+
+		results = append(results, &model.BlockSearchResult{
+			BlockID:   block.ID,
+			Type:      block.Type,
+			BoardID:   block.BoardID,
+			CardID:    card.ID,
+			CardCode:  card.Code,
+			CardTitle: card.Title,
+			Snippet:   highlighter.ReplaceAllString(searchSnippet(block.Title, words), "**$0**"),
+			UpdateAt:  block.UpdateAt,
+		})
+	}
+	return results, nil
+}
+
+// getSearchResultCards returns, by ID, the cards that the search result
+// blocks are or belong to.
+func (a *App) getSearchResultCards(blocks []*model.Block) (map[string]*model.Card, error) {
+	cards := map[string]*model.Card{}
+	missingIDs := []string{}
+	for _, block := range blocks {
+		if block.Type != model.TypeCard {
+			missingIDs = append(missingIDs, block.ParentID)
+			continue
+		}
+		card, err := model.Block2Card(block)
+		if err != nil {
+			return nil, fmt.Errorf("Block2Card fail: %w", err)
+		}
+		cards[card.ID] = card
+	}
+
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, id := range missingIDs {
+		if _, ok := cards[id]; !ok && !seen[id] {
+			ids = append(ids, id)
+			seen[id] = true
+		}
+	}
+	if len(ids) == 0 {
+		return cards, nil
+	}
+
+	parents, err := a.store.GetBlocksByIDs(ids)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+	for _, parent := range parents {
+		if parent.Type != model.TypeCard {
+			continue
+		}
+		card, err := model.Block2Card(parent)
+		if err != nil {
+			return nil, fmt.Errorf("Block2Card fail: %w", err)
+		}
+		cards[card.ID] = card
+	}
+	return cards, nil
+}
+
+// searchSnippet returns the text around the first search word found in s.
+func searchSnippet(s string, words []string) string {
+	for _, word := range words {
+		if snippet := notifymentions.ExtractSnippet(s, word); snippet != "" {
+			return snippet
+		}
+	}
+	return notifymentions.ExtractSnippet(s, "")
+}
+
+func searchWordsRegexp(words []string) *regexp.Regexp {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = regexp.QuoteMeta(word)
+	}
+	return regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func TestSearchBlocksForUser(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	teamID := utils.NewID(utils.IDTypeTeam)
+	board := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: teamID, Code: "AB"}
+
+	card := &model.Block{
+		ID:      utils.NewID(utils.IDTypeCard),
+		BoardID: board.ID,
+		Type:    model.TypeCard,
+		Title:   "Release checklist",
+		Number:  3,
+		Fields:  map[string]interface{}{},
+	}
+	comment := &model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		ParentID: card.ID,
+		BoardID:  board.ID,
+		Type:     model.TypeComment,
+		Title:    "first line\nwe should Deploy on friday",
+		UpdateAt: 42,
+	}
+	templateCard := &model.Block{
+		ID:      utils.NewID(utils.IDTypeCard),
+		BoardID: board.ID,
+		Type:    model.TypeCard,
+		Fields:  map[string]interface{}{"isTemplate": true},
+	}
+	templateText := &model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		ParentID: templateCard.ID,
+		BoardID:  board.ID,
+		Type:     model.TypeText,
+		Title:    "deploy template",
+	}
+
+	t.Run("returns snippets and card codes", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardsForUserAndTeam(userID, teamID, true).Return([]*model.Board{board}, nil)
+		th.API.EXPECT().HasPermissionToTeam(userID, teamID, model.PermissionViewTeam).Return(true)
+		th.API.EXPECT().HasPermissionToTeam(userID, teamID, model.PermissionManageTeam).Return(false)
+		th.PermissionsStore.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.PermissionsStore.EXPECT().GetMemberForBoard(board.ID, userID).Return(&model.BoardMember{SchemeViewer: true}, nil)
+
+		th.Store.EXPECT().SearchBlocks(model.QueryBlockSearchOptions{
+			Terms:    "deploy",
+			BoardIDs: []string{board.ID},
+			PerPage:  10,
+		}).Return([]*model.Block{comment, templateText}, nil)
+		th.Store.EXPECT().GetBlocksByIDs([]string{card.ID, templateCard.ID}).Return([]*model.Block{card, templateCard}, nil)
+
+		results, err := th.App.SearchBlocksForUser(teamID, userID, "deploy", true, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, &model.BlockSearchResult{
+			BlockID:   comment.ID,
+			Type:      model.TypeComment,
+			BoardID:   board.ID,
+			CardID:    card.ID,
+			CardCode:  "AB-3",
+			CardTitle: "Release checklist",
+			Snippet:   "first line\nwe should **Deploy** on friday",
+			UpdateAt:  42,
+		}, results[0])
+	})
+
+	t.Run("terms without words", func(t *testing.T) {
+		results, err := th.App.SearchBlocksForUser(teamID, userID, "+-*", true, 0, 10)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+}
@@ -87,6 +87,8 @@ func (a *App) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch,
 		return nil, err
 	}
 
+	applyCardFormulasToPatch(board, oldBlock, blockPatch)
+
 	err = a.store.PatchBlock(blockID, blockPatch, modifiedByID)
 	if err != nil {
 		return nil, err
@@ -111,7 +113,9 @@ func (a *App) PatchBlockAndNotify(blockID string, blockPatch *model.BlockPatch,
 		// send notifications
 		if !disableNotify {
 			a.notifyBlockChanged(notify.Update, block, oldBlock, modifiedByID)
+			a.notifyIfCardDone(board, block, oldBlock, modifiedByID)
 		}
+		a.updateParentRollups(block, oldBlock)
 		return nil
 	})
 	return block, nil
@@ -133,10 +137,19 @@ func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatch
 		return err
 	}
 
+	if err := a.applyCardFormulasToPatches(oldBlocks, blockPatches); err != nil {
+		return err
+	}
+
 	if err := a.store.PatchBlocks(blockPatches, modifiedByID); err != nil {
 		return err
 	}
 
+	oldByID := make(map[string]*model.Block, len(oldBlocks))
+	for _, block := range oldBlocks {
+		oldByID[block.ID] = block
+	}
+
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.metrics.IncrementBlocksPatched(len(oldBlocks))
 		for i, blockID := range blockPatches.BlockIDs {
@@ -146,8 +159,9 @@ func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatch
 			}
 
 			// Populate code field for card blocks before broadcasting
+			var board *model.Board
 			if newBlock.Type == model.TypeCard && newBlock.Number > 0 {
-				board, err := a.store.GetBoard(newBlock.BoardID)
+				board, err = a.store.GetBoard(newBlock.BoardID)
 				if err != nil {
 					a.logger.Error("Failed to get board for code population",
 						mlog.String("block_id", blockID),
@@ -164,7 +178,14 @@ func (a *App) PatchBlocksAndNotify(teamID string, blockPatches *model.BlockPatch
 			a.webhook.NotifyUpdate(newBlock)
 			if !disableNotify {
 				a.notifyBlockChanged(notify.Update, newBlock, oldBlocks[i], modifiedByID)
+				if board == nil && newBlock.Type == model.TypeCard {
+					board, _ = a.store.GetBoard(newBlock.BoardID)
+				}
+				if board != nil {
+					a.notifyIfCardDone(board, newBlock, oldByID[blockID], modifiedByID)
+				}
 			}
+			a.updateParentRollups(newBlock, oldBlocks[i])
 		}
 		return nil
 	})
@@ -181,6 +202,8 @@ func (a *App) InsertBlockAndNotify(block *model.Block, modifiedByID string, disa
 		return bErr
 	}
 
+	applyCardFormulas(board, block)
+
 	err := a.store.InsertBlock(block, modifiedByID)
 	if err == nil {
 		// Populate code field for card blocks before broadcasting
@@ -245,6 +268,7 @@ func (a *App) InsertBlocksAndNotify(blocks []*model.Block, modifiedByID string,
 		if existingBlock == nil && block.Type == model.TypeCard {
 			a.applyDefaultCardProperties(block, board)
 		}
+		applyCardFormulas(board, block)
 
 		if existingBlock == nil && (block.Type == "image" || block.Type == "attachment") {
 			fileIDsToRestore := extractFileIDsFromBlock(block)
@@ -349,6 +373,7 @@ func (a *App) DeleteBlockAndNotify(blockID string, modifiedBy string, disableNot
 		if !disableNotify {
 			a.notifyBlockChanged(notify.Delete, block, block, modifiedBy)
 		}
+		a.updateParentRollups(block, nil)
 		return nil
 	})
 
@@ -401,6 +426,7 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 		a.metrics.IncrementBlocksInserted(1)
 		a.webhook.NotifyUpdate(block)
 		a.notifyBlockChanged(notify.Add, block, nil, modifiedBy)
+		a.updateParentRollups(block, nil)
 
 		return nil
 	})
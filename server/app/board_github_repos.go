@@ -0,0 +1,236 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/github"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	// boardGitHubRepoScanInterval is how often a connected repository is
+	// scanned for card codes.
+	boardGitHubRepoScanInterval  = 15 * time.Minute
+	boardGitHubRepoScanBatchSize = 50
+
+	// boardGitHubRepoScanLookback is how far back the first scan of a
+	// repository looks.
+	boardGitHubRepoScanLookback = 7 * 24 * time.Hour
+
+	// boardGitHubRepoScanOverlap is how far before the previous scan the next
+	// one starts. Commits are listed by their author date, which can be much
+	// older than their push, and items that are already linked are skipped.
+	boardGitHubRepoScanOverlap = 24 * time.Hour
+)
+
+// GetBoardGitHubRepos returns the GitHub repositories connected to a board.
+func (a *App) GetBoardGitHubRepos(boardID string) ([]*model.BoardGitHubRepo, error) {
+	return a.store.GetBoardGitHubRepos(boardID)
+}
+
+// GetBoardGitHubRepo returns a GitHub repository connection.
+func (a *App) GetBoardGitHubRepo(repoID string) (*model.BoardGitHubRepo, error) {
+	return a.store.GetBoardGitHubRepo(repoID)
+}
+
+// ConnectBoardGitHubRepo connects a GitHub repository to a board. The
+// repository is scanned by the next scan with the GitHub account of the
+// user who connected it.
+func (a *App) ConnectBoardGitHubRepo(repo *model.BoardGitHubRepo) (*model.BoardGitHubRepo, error) {
+	if _, err := a.store.GetBoard(repo.BoardID); err != nil {
+		return nil, err
+	}
+
+	repo.Populate()
+	if err := repo.IsValid(); err != nil {
+		return nil, err
+	}
+
+	repos, err := a.store.GetBoardGitHubRepos(repo.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(repos) >= model.MaxBoardGitHubRepos {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("a board cannot have more than %d GitHub repositories", model.MaxBoardGitHubRepos))
+	}
+	for _, existing := range repos {
+		if existing.Same(repo) {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("%s/%s is already connected to the board", repo.Owner, repo.Repo))
+		}
+	}
+
+	return a.store.CreateBoardGitHubRepo(repo)
+}
+
+// DisconnectBoardGitHubRepo disconnects a GitHub repository from its board.
+// The links it created are kept.
+func (a *App) DisconnectBoardGitHubRepo(repoID string) error {
+	return a.store.DeleteBoardGitHubRepo(repoID)
+}
+
+// ScanBoardGitHubRepos scans the connected repositories that were not
+// scanned recently for pull requests and commits mentioning the code of a
+// card of their board, and links them to the card. Repositories that cannot
+// be scanned are retried by the next scan.
+func (a *App) ScanBoardGitHubRepos() error {
+	githubService := a.GetGitHubService()
+	if githubService == nil {
+		return nil
+	}
+
+	now := utils.GetMillis()
+	repos, err := a.store.GetBoardGitHubReposToScan(now-boardGitHubRepoScanInterval.Milliseconds(), boardGitHubRepoScanBatchSize)
+	if err != nil {
+		return err
+	}
+
+	merr := merror.New()
+	for _, repo := range repos {
+		if err := a.scanBoardGitHubRepo(githubService, repo, now); err != nil {
+			merr.Append(fmt.Errorf("github repository %s/%s: %w", repo.Owner, repo.Repo, err))
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+func (a *App) scanBoardGitHubRepo(githubService *github.Service, repo *model.BoardGitHubRepo, now int64) error {
+	board, err := a.store.GetBoard(repo.BoardID)
+	if model.IsErrNotFound(err) {
+		a.logger.Info("Disconnecting the GitHub repository of a board that no longer exists", mlog.String("board_id", repo.BoardID))
+		if err := a.store.DeleteBoardGitHubRepo(repo.ID); err != nil && !model.IsErrNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	since := now - boardGitHubRepoScanLookback.Milliseconds()
+	if repo.ScannedAt > 0 {
+		since = repo.ScannedAt - boardGitHubRepoScanOverlap.Milliseconds()
+	}
+	sinceTime := utils.GetTimeForMillis(since)
+
+	if board.Code != "" {
+		prs, err := githubService.ListPullRequests(repo.CreatedBy, repo.Owner, repo.Repo)
+		if err != nil {
+			return err
+		}
+		for i := range prs {
+			pr := &prs[i]
+			if pr.UpdatedAt.Before(sinceTime) {
+				// Pull requests are listed most recently updated first.
+				break
+			}
+			link := &model.CardGitHubLink{
+				Kind:   model.CardGitHubLinkKindPullRequest,
+				Number: pr.Number,
+			}
+			setCardGitHubLinkPullRequest(link, pr)
+			a.linkCardsByCode(board, repo, link, pr.Title+" "+pr.Head.Ref, now)
+		}
+
+		commits, err := githubService.ListCommits(repo.CreatedBy, repo.Owner, repo.Repo, sinceTime)
+		if err != nil {
+			return err
+		}
+		for i := range commits {
+			link := &model.CardGitHubLink{
+				Kind: model.CardGitHubLinkKindCommit,
+			}
+			setCardGitHubLinkCommit(link, &commits[i])
+			a.linkCardsByCode(board, repo, link, commits[i].Commit.Message, now)
+		}
+	}
+
+	if err := a.store.UpdateBoardGitHubRepoScannedAt(repo.ID, now); err != nil && !model.IsErrNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// linkCardsByCode links an item of a connected repository to the cards of
+// the board whose code is mentioned in a text, and adds a comment from the
+// boards bot to each card. Cards that already link the item are skipped.
+func (a *App) linkCardsByCode(board *model.Board, repo *model.BoardGitHubRepo, item *model.CardGitHubLink, text string, now int64) {
+	for _, code := range model.FindCardCodes(text, board.Code) {
+		card, cardBoard, err := a.store.GetCardByCode(code)
+		if err != nil {
+			if !model.IsErrNotFound(err) {
+				a.logger.Warn("Cannot find the card of a GitHub item", mlog.String("code", code), mlog.Err(err))
+			}
+			continue
+		}
+		if cardBoard.ID != board.ID {
+			continue
+		}
+
+		link := *item
+		link.ID = ""
+		link.BoardID = board.ID
+		link.CardID = card.ID
+		link.Owner = repo.Owner
+		link.Repo = repo.Repo
+		link.SyncedAt = now
+		link.SyncAttemptAt = now
+		link.CreatedBy = repo.CreatedBy
+		link.CreateAt = 0
+		link.UpdateAt = 0
+
+		created, err := a.addCardGitHubLinkByCode(board, &link)
+		if err != nil {
+			a.logger.Warn("Cannot link a GitHub item to a card",
+				mlog.String("card_id", card.ID),
+				mlog.String("item", link.Reference()),
+				mlog.Err(err),
+			)
+			continue
+		}
+		if created != nil {
+			a.applyGitHubLinkStatusRule(created, &model.CardGitHubLink{})
+		}
+	}
+}
+
+// addCardGitHubLinkByCode creates a link found by a repository scan, unless
+// the card already links the item or has too many links, in which case it
+// returns nil.
+func (a *App) addCardGitHubLinkByCode(board *model.Board, link *model.CardGitHubLink) (*model.CardGitHubLink, error) {
+	links, err := a.store.GetCardGitHubLinks(link.CardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) >= model.MaxCardGitHubLinks {
+		return nil, nil
+	}
+	for _, existing := range links {
+		if existing.Same(link) {
+			return nil, nil
+		}
+	}
+
+	created, err := a.store.CreateCardGitHubLink(link)
+	if err != nil {
+		return nil, err
+	}
+
+	var text string
+	if created.Kind == model.CardGitHubLinkKindCommit {
+		text = fmt.Sprintf("Commit [%s](%s) was linked: %s", created.Reference(), created.URL, created.Title)
+	} else {
+		text = fmt.Sprintf("Pull request [%s](%s) was linked: %s", created.Reference(), created.URL, created.Title)
+	}
+	if err := a.addCardComment(board, created.CardID, text, a.getBoardsBotID(), false); err != nil {
+		a.logger.Warn("Cannot add the comment of a GitHub link", mlog.String("card_id", created.CardID), mlog.Err(err))
+	}
+	return created, nil
+}
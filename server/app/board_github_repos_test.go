@@ -0,0 +1,142 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanBoardGitHubRepos(t *testing.T) {
+	board := &model.Board{ID: "board-id", TeamID: "team-id", Code: "AB"}
+	otherBoard := &model.Board{ID: "other-board-id", TeamID: "team-id", Code: "AB"}
+	repo := &model.BoardGitHubRepo{ID: "repo-1", BoardID: board.ID, Owner: "owner", Repo: "repo", CreatedBy: "user-id"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "all", r.URL.Query().Get("state"))
+		_ = json.NewEncoder(w).Encode([]github.PRDetails{
+			{
+				Number:    5,
+				Title:     "Fix the login",
+				State:     "open",
+				HTMLURL:   "https://github.com/owner/repo/pull/5",
+				UpdatedAt: time.Now(),
+				Head:      github.PRBranch{Ref: "ab-1-fix-login"},
+			},
+			{
+				Number:    4,
+				Title:     "AB-1 older than the scan",
+				State:     "closed",
+				UpdatedAt: time.Now().Add(-30 * 24 * time.Hour),
+			},
+		})
+	})
+	mux.HandleFunc("/repos/owner/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.URL.Query().Get("since"))
+		_ = json.NewEncoder(w).Encode([]github.Commit{
+			{
+				SHA:     "0123456789abcdef0123456789abcdef01234567",
+				HTMLURL: "https://github.com/owner/repo/commit/0123456789abcdef0123456789abcdef01234567",
+				Commit:  github.CommitDetail{Message: "AB-1, ab-2: tidy up\n\nDetails"},
+			},
+		})
+	})
+
+	t.Run("links mentioned cards with a comment", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		server := setupFakeGitHub(t, th, mux)
+		defer server.Close()
+
+		card := bulkTestCard("card-1", board.ID, map[string]interface{}{})
+		otherCard := bulkTestCard("card-2", otherBoard.ID, map[string]interface{}{})
+
+		var links []*model.CardGitHubLink
+		var comments []*model.Block
+		th.Store.EXPECT().GetBoardGitHubReposToScan(gomock.Any(), boardGitHubRepoScanBatchSize).Return([]*model.BoardGitHubRepo{repo}, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().GetCardByCode("AB-1").Return(card, board, nil).Times(2)
+		th.Store.EXPECT().GetCardByCode("AB-2").Return(otherCard, otherBoard, nil)
+		th.Store.EXPECT().GetCardGitHubLinks("card-1").DoAndReturn(func(string) ([]*model.CardGitHubLink, error) {
+			return links, nil
+		}).Times(2)
+		th.Store.EXPECT().CreateCardGitHubLink(gomock.Any()).DoAndReturn(func(link *model.CardGitHubLink) (*model.CardGitHubLink, error) {
+			require.NoError(t, link.Populate())
+			require.NoError(t, link.IsValid())
+			links = append(links, link)
+			return link, nil
+		}).Times(2)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), model.SystemUserID).DoAndReturn(func(block *model.Block, _ string) error {
+			comments = append(comments, block)
+			return nil
+		}).Times(2)
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+		th.Store.EXPECT().GetGitHubStatusRules(board.ID).Return([]*model.GitHubStatusRule{}, nil)
+		th.Store.EXPECT().UpdateBoardGitHubRepoScannedAt(repo.ID, gomock.Any()).Return(nil)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return(nil, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardAndCardByID(gomock.Any()).Return(board, nil, nil).AnyTimes()
+		th.Store.EXPECT().GetCardParent(gomock.Any()).Return(nil, model.NewErrNotFound("card parent")).AnyTimes()
+
+		require.NoError(t, th.App.ScanBoardGitHubRepos())
+
+		require.Len(t, links, 2)
+		assert.Equal(t, model.CardGitHubLinkKindPullRequest, links[0].Kind)
+		assert.Equal(t, 5, links[0].Number)
+		assert.Equal(t, "Fix the login", links[0].Title)
+		assert.Equal(t, "user-id", links[0].CreatedBy)
+		assert.Equal(t, model.CardGitHubLinkKindCommit, links[1].Kind)
+		assert.Equal(t, "AB-1, ab-2: tidy up", links[1].Title)
+
+		require.Len(t, comments, 2)
+		assert.EqualValues(t, model.TypeComment, comments[0].Type)
+		assert.Equal(t, "card-1", comments[0].ParentID)
+		assert.Contains(t, comments[0].Title, "owner/repo#5")
+		assert.Contains(t, comments[1].Title, "owner/repo@0123456")
+	})
+
+	t.Run("skips items already linked", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		server := setupFakeGitHub(t, th, mux)
+		defer server.Close()
+
+		card := bulkTestCard("card-1", board.ID, map[string]interface{}{})
+		otherCard := bulkTestCard("card-2", otherBoard.ID, map[string]interface{}{})
+		existing := []*model.CardGitHubLink{
+			{Kind: model.CardGitHubLinkKindPullRequest, Owner: "owner", Repo: "repo", Number: 5},
+			{Kind: model.CardGitHubLinkKindCommit, Owner: "owner", Repo: "repo", SHA: "0123456789ABCDEF0123456789ABCDEF01234567"},
+		}
+
+		th.Store.EXPECT().GetBoardGitHubReposToScan(gomock.Any(), boardGitHubRepoScanBatchSize).Return([]*model.BoardGitHubRepo{repo}, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetCardByCode("AB-1").Return(card, board, nil).Times(2)
+		th.Store.EXPECT().GetCardByCode("AB-2").Return(otherCard, otherBoard, nil)
+		th.Store.EXPECT().GetCardGitHubLinks("card-1").Return(existing, nil).Times(2)
+		th.Store.EXPECT().UpdateBoardGitHubRepoScannedAt(repo.ID, gomock.Any()).Return(nil)
+
+		require.NoError(t, th.App.ScanBoardGitHubRepos())
+	})
+
+	t.Run("disconnects the repositories of deleted boards", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		server := setupFakeGitHub(t, th, mux)
+		defer server.Close()
+
+		th.Store.EXPECT().GetBoardGitHubReposToScan(gomock.Any(), boardGitHubRepoScanBatchSize).Return([]*model.BoardGitHubRepo{repo}, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(nil, model.NewErrNotFound("board"))
+		th.Store.EXPECT().DeleteBoardGitHubRepo(repo.ID).Return(nil)
+
+		require.NoError(t, th.App.ScanBoardGitHubRepos())
+	})
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/webhook"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	webhookDeliveryBatchSize = 100
+	// webhookDeliveryClaimTimeout is how long a delivery being sent is hidden
+	// from the other servers of a cluster.
+	webhookDeliveryClaimTimeout = 5 * time.Minute
+)
+
+// CreateBoardWebhook registers a new webhook for a board. The returned
+// webhook is the only one that carries the signing secret.
+func (a *App) CreateBoardWebhook(boardWebhook *model.BoardWebhook) (*model.BoardWebhook, error) {
+	boardWebhook.ID = ""
+	boardWebhook.Secret = ""
+	boardWebhook.CreateAt = 0
+
+	created, err := a.store.CreateBoardWebhook(boardWebhook)
+	if errors.Is(err, model.ErrInvalidBoardWebhook) {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetBoardWebhook returns a board webhook without its secret.
+func (a *App) GetBoardWebhook(webhookID string) (*model.BoardWebhook, error) {
+	boardWebhook, err := a.store.GetBoardWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	boardWebhook.Secret = ""
+	return boardWebhook, nil
+}
+
+// GetBoardWebhooks returns the webhooks of a board without their secrets.
+func (a *App) GetBoardWebhooks(boardID string) ([]*model.BoardWebhook, error) {
+	webhooks, err := a.store.GetBoardWebhooks(boardID)
+	if err != nil {
+		return nil, err
+	}
+	for _, boardWebhook := range webhooks {
+		boardWebhook.Secret = ""
+	}
+	return webhooks, nil
+}
+
+// PatchBoardWebhook updates the URL, events or enabled state of a webhook.
+func (a *App) PatchBoardWebhook(webhookID string, patch *model.BoardWebhookPatch) (*model.BoardWebhook, error) {
+	existing, err := a.store.GetBoardWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := a.store.UpdateBoardWebhook(existing.Patch(patch))
+	if errors.Is(err, model.ErrInvalidBoardWebhook) {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	updated.Secret = ""
+	return updated, nil
+}
+
+// DeleteBoardWebhook deletes a webhook and its delivery log.
+func (a *App) DeleteBoardWebhook(webhookID string) error {
+	return a.store.DeleteBoardWebhook(webhookID)
+}
+
+// GetWebhookDeliveries returns the delivery log of a webhook, newest first.
+func (a *App) GetWebhookDeliveries(webhookID string, page, perPage int) ([]*model.WebhookDelivery, error) {
+	return a.store.GetWebhookDeliveries(webhookID, page, perPage)
+}
+
+// EnqueueWebhookEvent queues a delivery of the event for every enabled
+// webhook of the board subscribed to it. The deliveries are sent by
+// DeliverPendingWebhooks.
+func (a *App) EnqueueWebhookEvent(board *model.Board, payload *model.WebhookEventPayload) error {
+	webhooks, err := a.store.GetBoardWebhooks(board.ID)
+	if err != nil {
+		return err
+	}
+
+	subscribed := make([]*model.BoardWebhook, 0, len(webhooks))
+	for _, boardWebhook := range webhooks {
+		if boardWebhook.Subscribes(payload.Event) {
+			subscribed = append(subscribed, boardWebhook)
+		}
+	}
+	if len(subscribed) == 0 {
+		return nil
+	}
+
+	payload.ID = utils.NewID(utils.IDTypeNone)
+	payload.TeamID = board.TeamID
+	payload.BoardID = board.ID
+	if payload.Card != nil {
+		a.populateCardCode(payload.Card, board)
+	}
+	if payload.Timestamp == 0 {
+		payload.Timestamp = utils.GetMillis()
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]*model.WebhookDelivery, 0, len(subscribed))
+	for _, boardWebhook := range subscribed {
+		deliveries = append(deliveries, model.NewWebhookDelivery(boardWebhook, payload.Event, string(body)))
+	}
+	return a.store.InsertWebhookDeliveries(deliveries)
+}
+
+// DeliverPendingWebhooks sends the queued webhook deliveries that are due.
+// Failed deliveries are retried with an exponential backoff until
+// MaxWebhookDeliveryAttempts is reached.
+func (a *App) DeliverPendingWebhooks() error {
+	deliveries, err := a.store.GetDueWebhookDeliveries(utils.GetMillis(), webhookDeliveryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	webhooks := map[string]*model.BoardWebhook{}
+	for _, delivery := range deliveries {
+		claimed, err := a.store.ClaimWebhookDelivery(delivery, utils.GetMillis()+webhookDeliveryClaimTimeout.Milliseconds())
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+
+		boardWebhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			boardWebhook, err = a.store.GetBoardWebhook(delivery.WebhookID)
+			if err != nil && !model.IsErrNotFound(err) {
+				return err
+			}
+			webhooks[delivery.WebhookID] = boardWebhook
+		}
+
+		a.deliverWebhook(boardWebhook, delivery)
+
+		if err := a.store.UpdateWebhookDelivery(delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliverWebhook makes one attempt to send a delivery and records its outcome.
+func (a *App) deliverWebhook(boardWebhook *model.BoardWebhook, delivery *model.WebhookDelivery) {
+	now := utils.GetMillis()
+
+	if boardWebhook == nil || !boardWebhook.Enabled {
+		delivery.Status = model.WebhookDeliveryStatusFailed
+		delivery.Error = "webhook is disabled or was deleted"
+		delivery.LastAttemptAt = now
+		return
+	}
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = now
+
+	status, err := a.webhook.Deliver(boardWebhook, delivery)
+	delivery.ResponseCode = status
+	if err == nil {
+		delivery.Status = model.WebhookDeliveryStatusDelivered
+		delivery.Error = ""
+		return
+	}
+
+	delivery.Error = err.Error()
+	if delivery.Attempts >= model.MaxWebhookDeliveryAttempts {
+		delivery.Status = model.WebhookDeliveryStatusFailed
+		a.logger.Warn("Webhook delivery failed",
+			mlog.String("webhookID", boardWebhook.ID),
+			mlog.String("deliveryID", delivery.ID),
+			mlog.Int("attempts", delivery.Attempts),
+			mlog.Err(err),
+		)
+		return
+	}
+	delivery.NextAttemptAt = now + webhook.RetryDelay(delivery.Attempts).Milliseconds()
+}
+
+// enqueueRelationAddedWebhookEvent queues the relation.added event of a board.
+func (a *App) enqueueRelationAddedWebhookEvent(board *model.Board, relation *model.CardRelation) {
+	payload := &model.WebhookEventPayload{
+		Event:    model.WebhookEventRelationAdded,
+		ActorID:  relation.CreatedBy,
+		Relation: relation,
+	}
+	if err := a.EnqueueWebhookEvent(board, payload); err != nil {
+		a.logger.Error("Error queuing relation webhook event",
+			mlog.String("boardID", board.ID),
+			mlog.String("relationID", relation.ID),
+			mlog.Err(err),
+		)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/webhook"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func TestCreateBoardWebhook(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("invalid webhook", func(t *testing.T) {
+		boardWebhook := &model.BoardWebhook{BoardID: "board-id", URL: "ftp://example.com", CreatedBy: "user-id"}
+		th.Store.EXPECT().CreateBoardWebhook(boardWebhook).Return(nil, fmt.Errorf("%w: bad url", model.ErrInvalidBoardWebhook))
+
+		_, err := th.App.CreateBoardWebhook(boardWebhook)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("secret cannot be chosen by the caller", func(t *testing.T) {
+		boardWebhook := &model.BoardWebhook{ID: "id", BoardID: "board-id", URL: "https://example.com", Secret: "mine", CreatedBy: "user-id"}
+		th.Store.EXPECT().CreateBoardWebhook(gomock.Any()).DoAndReturn(func(wh *model.BoardWebhook) (*model.BoardWebhook, error) {
+			assert.Empty(t, wh.ID)
+			assert.Empty(t, wh.Secret)
+			return wh, nil
+		})
+
+		_, err := th.App.CreateBoardWebhook(boardWebhook)
+		require.NoError(t, err)
+	})
+}
+
+func TestGetBoardWebhooks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().GetBoardWebhooks("board-id").Return([]*model.BoardWebhook{{ID: "webhook-id", Secret: "secret"}}, nil)
+
+	webhooks, err := th.App.GetBoardWebhooks("board-id")
+	require.NoError(t, err)
+	require.Len(t, webhooks, 1)
+	assert.Empty(t, webhooks[0].Secret)
+}
+
+func TestEnqueueWebhookEvent(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: "team-id", Code: "AB"}
+	subscribed := &model.BoardWebhook{ID: "subscribed", BoardID: board.ID, Enabled: true, Events: []model.WebhookEvent{model.WebhookEventCardCreated}}
+	allEvents := &model.BoardWebhook{ID: "all-events", BoardID: board.ID, Enabled: true}
+	otherEvent := &model.BoardWebhook{ID: "other-event", BoardID: board.ID, Enabled: true, Events: []model.WebhookEvent{model.WebhookEventCardDeleted}}
+	disabled := &model.BoardWebhook{ID: "disabled", BoardID: board.ID}
+
+	t.Run("queues a delivery per subscribed webhook", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardWebhooks(board.ID).Return([]*model.BoardWebhook{subscribed, allEvents, otherEvent, disabled}, nil)
+		th.Store.EXPECT().InsertWebhookDeliveries(gomock.Any()).DoAndReturn(func(deliveries []*model.WebhookDelivery) error {
+			require.Len(t, deliveries, 2)
+			assert.Equal(t, "subscribed", deliveries[0].WebhookID)
+			assert.Equal(t, "all-events", deliveries[1].WebhookID)
+			assert.Equal(t, model.WebhookDeliveryStatusPending, deliveries[0].Status)
+
+			var payload model.WebhookEventPayload
+			require.NoError(t, json.Unmarshal([]byte(deliveries[0].Payload), &payload))
+			assert.Equal(t, model.WebhookEventCardCreated, payload.Event)
+			assert.Equal(t, "team-id", payload.TeamID)
+			assert.Equal(t, board.ID, payload.BoardID)
+			assert.Equal(t, "AB-7", payload.Card.Code)
+			assert.NotEmpty(t, payload.ID)
+			return nil
+		})
+
+		err := th.App.EnqueueWebhookEvent(board, &model.WebhookEventPayload{
+			Event: model.WebhookEventCardCreated,
+			Card:  &model.Card{ID: "card-id", Number: 7},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("no subscribed webhooks", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardWebhooks(board.ID).Return([]*model.BoardWebhook{otherEvent, disabled}, nil)
+
+		err := th.App.EnqueueWebhookEvent(board, &model.WebhookEventPayload{Event: model.WebhookEventCommentAdded})
+		require.NoError(t, err)
+	})
+}
+
+func TestDeliverPendingWebhooks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	var signature string
+	status := http.StatusOK
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(webhook.HeaderSignature)
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+
+	boardWebhook := &model.BoardWebhook{ID: "webhook-id", BoardID: "board-id", URL: ts.URL, Secret: "secret", Enabled: true}
+
+	newDelivery := func(attempts int) *model.WebhookDelivery {
+		delivery := model.NewWebhookDelivery(boardWebhook, model.WebhookEventCardCreated, `{"event":"card.created"}`)
+		delivery.Attempts = attempts
+		return delivery
+	}
+
+	expectDelivery := func(delivery *model.WebhookDelivery) *model.WebhookDelivery {
+		var updated *model.WebhookDelivery
+		th.Store.EXPECT().GetDueWebhookDeliveries(gomock.Any(), uint64(webhookDeliveryBatchSize)).Return([]*model.WebhookDelivery{delivery}, nil)
+		th.Store.EXPECT().ClaimWebhookDelivery(delivery, gomock.Any()).Return(true, nil)
+		th.Store.EXPECT().GetBoardWebhook("webhook-id").Return(boardWebhook, nil)
+		th.Store.EXPECT().UpdateWebhookDelivery(delivery).DoAndReturn(func(d *model.WebhookDelivery) error {
+			updated = d
+			return nil
+		})
+		require.NoError(t, th.App.DeliverPendingWebhooks())
+		return updated
+	}
+
+	t.Run("delivered", func(t *testing.T) {
+		status = http.StatusOK
+		delivery := newDelivery(0)
+
+		updated := expectDelivery(delivery)
+		assert.Equal(t, model.WebhookDeliveryStatusDelivered, updated.Status)
+		assert.Equal(t, 1, updated.Attempts)
+		assert.Equal(t, http.StatusOK, updated.ResponseCode)
+		assert.True(t, webhook.VerifySignature("secret", []byte(delivery.Payload), signature))
+	})
+
+	t.Run("retried with backoff", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		delivery := newDelivery(2)
+
+		updated := expectDelivery(delivery)
+		assert.Equal(t, model.WebhookDeliveryStatusPending, updated.Status)
+		assert.Equal(t, 3, updated.Attempts)
+		assert.Equal(t, updated.LastAttemptAt+webhook.RetryDelay(3).Milliseconds(), updated.NextAttemptAt)
+		assert.NotEmpty(t, updated.Error)
+	})
+
+	t.Run("failed after the last attempt", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		delivery := newDelivery(model.MaxWebhookDeliveryAttempts - 1)
+
+		updated := expectDelivery(delivery)
+		assert.Equal(t, model.WebhookDeliveryStatusFailed, updated.Status)
+		assert.Equal(t, model.MaxWebhookDeliveryAttempts, updated.Attempts)
+	})
+
+	t.Run("claimed by another server", func(t *testing.T) {
+		delivery := newDelivery(0)
+		th.Store.EXPECT().GetDueWebhookDeliveries(gomock.Any(), uint64(webhookDeliveryBatchSize)).Return([]*model.WebhookDelivery{delivery}, nil)
+		th.Store.EXPECT().ClaimWebhookDelivery(delivery, gomock.Any()).Return(false, nil)
+
+		require.NoError(t, th.App.DeliverPendingWebhooks())
+	})
+}
@@ -405,6 +405,10 @@ func (a *App) PatchBoard(patch *model.BoardPatch, boardID, userID string) (*mode
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
 
+		if len(patch.UpdatedCardProperties) > 0 || len(patch.DeletedCardProperties) > 0 {
+			a.updateBoardFormulas(updatedBoard)
+		}
+
 		if patch.ChannelID != nil {
 			if *patch.ChannelID != "" {
 				members, err := a.GetMembersForBoard(updatedBoard.ID)
@@ -0,0 +1,147 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// isCardDone returns true if the workflow property of a card is set to an
+// option of the done category.
+func isCardDone(board *model.Board, card *model.Card) bool {
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return false
+	}
+	workflowProp, ok := schema.WorkflowPropDef(board)
+	if !ok {
+		return false
+	}
+	optionID, _ := card.Properties[workflowProp.ID].(string)
+	return workflowProp.IsDoneOption(optionID)
+}
+
+// getCardBoard returns the board of a card, through a cache of the boards
+// already fetched.
+func (a *App) getCardBoard(card *model.Card, boards map[string]*model.Board) (*model.Board, error) {
+	if board, ok := boards[card.BoardID]; ok {
+		return board, nil
+	}
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	boards[card.BoardID] = board
+	return board, nil
+}
+
+// getOpenCardBlockers returns the cards that directly block a card and are
+// not done, whatever their board.
+func (a *App) getOpenCardBlockers(cardID string, boards map[string]*model.Board) ([]*model.Card, error) {
+	relations, err := a.store.GetCardRelations(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var blockers []*model.Card
+	for _, relation := range relations {
+		edge := model.NewCardRelationEdge(&relation.CardRelation)
+		if edge.RelationType != model.RelationTypeBlocks || edge.ToCardID != cardID || relation.Card == nil {
+			continue
+		}
+		board, err := a.getCardBoard(relation.Card, boards)
+		if err != nil {
+			return nil, err
+		}
+		if !isCardDone(board, relation.Card) {
+			blockers = append(blockers, relation.Card)
+		}
+	}
+	return blockers, nil
+}
+
+// notifyIfCardDone informs the notification backends of the cards unblocked
+// by a card block that a change moved to a done status.
+func (a *App) notifyIfCardDone(board *model.Board, block *model.Block, oldBlock *model.Block, userID string) {
+	if block.Type != model.TypeCard || oldBlock == nil {
+		return
+	}
+	card, err := model.Block2Card(block)
+	if err != nil {
+		return
+	}
+	oldCard, err := model.Block2Card(oldBlock)
+	if err != nil {
+		return
+	}
+	if isCardDone(board, card) && !isCardDone(board, oldCard) {
+		a.notifyUnblockedCards(board, card, userID)
+	}
+}
+
+// notifyUnblockedCards informs the notification backends of the cards that a
+// completed card was the last open blocker of, on the boards that keep
+// blocked cards out of the done statuses.
+func (a *App) notifyUnblockedCards(board *model.Board, card *model.Card, userID string) {
+	if a.notifications == nil {
+		return
+	}
+
+	relations, err := a.store.GetCardRelations(card.ID)
+	if err != nil {
+		a.logger.Warn("notifyUnblockedCards: could not get card relations",
+			mlog.String("cardID", card.ID),
+			mlog.Err(err))
+		return
+	}
+
+	boards := map[string]*model.Board{board.ID: board}
+	for _, relation := range relations {
+		edge := model.NewCardRelationEdge(&relation.CardRelation)
+		if edge.RelationType != model.RelationTypeBlocks || edge.FromCardID != card.ID || relation.Card == nil {
+			continue
+		}
+
+		blocked := relation.Card
+		blockedBoard, err := a.getCardBoard(blocked, boards)
+		if err != nil {
+			a.logger.Warn("notifyUnblockedCards: could not get board of blocked card",
+				mlog.String("cardID", blocked.ID),
+				mlog.Err(err))
+			continue
+		}
+		if !blockedBoard.GetPropertyBool(model.BoardPropertyBlockDoneWhileBlocked) || isCardDone(blockedBoard, blocked) {
+			continue
+		}
+
+		blockers, err := a.getOpenCardBlockers(blocked.ID, boards)
+		if err != nil {
+			a.logger.Warn("notifyUnblockedCards: could not get blockers of card",
+				mlog.String("cardID", blocked.ID),
+				mlog.Err(err))
+			continue
+		}
+		if len(blockers) > 0 {
+			continue
+		}
+
+		boardMember, _ := a.GetMemberForBoard(blockedBoard.ID, userID)
+		if boardMember == nil {
+			boardMember = &model.BoardMember{
+				BoardID: blockedBoard.ID,
+				UserID:  userID,
+			}
+		}
+
+		a.notifications.CardUnblocked(notify.CardUnblockedEvent{
+			TeamID:     blockedBoard.TeamID,
+			Board:      blockedBoard,
+			Card:       model.Card2Block(blocked),
+			Blocker:    card,
+			ModifiedBy: boardMember,
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUnblockBackend struct {
+	mu     sync.Mutex
+	events []notify.CardUnblockedEvent
+}
+
+func (b *fakeUnblockBackend) Start() error                                 { return nil }
+func (b *fakeUnblockBackend) ShutDown() error                              { return nil }
+func (b *fakeUnblockBackend) BlockChanged(_ notify.BlockChangeEvent) error { return nil }
+func (b *fakeUnblockBackend) Name() string                                 { return "fakeUnblock" }
+
+func (b *fakeUnblockBackend) CardUnblocked(evt notify.CardUnblockedEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.events = append(b.events, evt)
+	return nil
+}
+
+func (b *fakeUnblockBackend) getEvents() []notify.CardUnblockedEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]notify.CardUnblockedEvent{}, b.events...)
+}
+
+func TestNotifyUnblockedCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	backend := &fakeUnblockBackend{}
+	notifications, err := notify.New(th.logger, backend)
+	require.NoError(t, err)
+	th.App.notifications = notifications
+
+	statusProperties := []map[string]interface{}{{
+		"id":   "status",
+		"name": "Status",
+		"type": "select",
+		"options": []interface{}{
+			map[string]interface{}{"id": "todo", "value": "To Do"},
+			map[string]interface{}{"id": "done", "value": "Done"},
+		},
+	}}
+	board := &model.Board{ID: "board-1", TeamID: "team-id", CardProperties: statusProperties}
+	blockingBoard := &model.Board{
+		ID:             "board-2",
+		TeamID:         "team-id",
+		Properties:     map[string]interface{}{model.BoardPropertyBlockDoneWhileBlocked: true},
+		CardProperties: statusProperties,
+	}
+
+	completed := &model.Card{ID: "completed", BoardID: board.ID, Title: "API", Properties: map[string]interface{}{"status": "done"}}
+	unblocked := &model.Card{ID: "unblocked", BoardID: blockingBoard.ID, Properties: map[string]interface{}{"status": "todo"}}
+	stillBlocked := &model.Card{ID: "still-blocked", BoardID: blockingBoard.ID, Properties: map[string]interface{}{"status": "todo"}}
+	other := &model.Card{ID: "other", BoardID: blockingBoard.ID, Properties: map[string]interface{}{"status": "todo"}}
+
+	th.Store.EXPECT().GetCardRelations(completed.ID).Return([]*model.CardRelationWithCard{
+		{CardRelation: model.CardRelation{SourceCardID: completed.ID, TargetCardID: unblocked.ID, RelationType: model.RelationTypeBlocks}, Card: unblocked},
+		{CardRelation: model.CardRelation{SourceCardID: stillBlocked.ID, TargetCardID: completed.ID, RelationType: model.RelationTypeIsBlockedBy}, Card: stillBlocked},
+		{CardRelation: model.CardRelation{SourceCardID: completed.ID, TargetCardID: other.ID, RelationType: model.RelationTypeRelatesTo}, Card: other},
+	}, nil)
+	th.Store.EXPECT().GetBoard(blockingBoard.ID).Return(blockingBoard, nil)
+	th.Store.EXPECT().GetCardRelations(unblocked.ID).Return([]*model.CardRelationWithCard{
+		{CardRelation: model.CardRelation{SourceCardID: completed.ID, TargetCardID: unblocked.ID, RelationType: model.RelationTypeBlocks}, Card: completed},
+	}, nil)
+	th.Store.EXPECT().GetCardRelations(stillBlocked.ID).Return([]*model.CardRelationWithCard{
+		{CardRelation: model.CardRelation{SourceCardID: stillBlocked.ID, TargetCardID: completed.ID, RelationType: model.RelationTypeIsBlockedBy}, Card: completed},
+		{CardRelation: model.CardRelation{SourceCardID: stillBlocked.ID, TargetCardID: other.ID, RelationType: model.RelationTypeIsBlockedBy}, Card: other},
+	}, nil)
+	th.Store.EXPECT().GetMemberForBoard(blockingBoard.ID, "user-id").Return(nil, model.NewErrNotFound("member"))
+
+	th.App.notifyUnblockedCards(board, completed, "user-id")
+
+	require.Len(t, backend.events, 1)
+	assert.Equal(t, unblocked.ID, backend.events[0].Card.ID)
+	assert.Equal(t, completed, backend.events[0].Blocker)
+	assert.Equal(t, "user-id", backend.events[0].ModifiedBy.UserID)
+}
+
+func TestPatchBlockNotifiesUnblockedCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	backend := &fakeUnblockBackend{}
+	notifications, err := notify.New(th.logger, backend)
+	require.NoError(t, err)
+	th.App.notifications = notifications
+
+	board := &model.Board{
+		ID:         "board-1",
+		TeamID:     "team-id",
+		Properties: map[string]interface{}{model.BoardPropertyBlockDoneWhileBlocked: true},
+		CardProperties: []map[string]interface{}{{
+			"id":   "status",
+			"name": "Status",
+			"type": "select",
+			"options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To Do"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			},
+		}},
+	}
+	blocker := bulkTestCard("blocker", board.ID, map[string]interface{}{"status": "todo"})
+	doneBlocker := &model.Card{ID: "blocker", BoardID: board.ID, Properties: map[string]interface{}{"status": "done"}}
+	blocked := &model.Card{ID: "blocked", BoardID: board.ID, Properties: map[string]interface{}{"status": "todo"}}
+
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetBlock("blocker").DoAndReturn(func(string) (*model.Block, error) {
+		return blocker, nil
+	}).AnyTimes()
+	th.Store.EXPECT().PatchBlock("blocker", gomock.Any(), "user-id").DoAndReturn(func(_ string, patch *model.BlockPatch, _ string) error {
+		patched := *blocker
+		patched.Fields = map[string]interface{}{}
+		for key, value := range blocker.Fields {
+			patched.Fields[key] = value
+		}
+		blocker = patch.Patch(&patched)
+		return nil
+	})
+	th.Store.EXPECT().GetCardRelations("blocker").Return([]*model.CardRelationWithCard{
+		{CardRelation: model.CardRelation{SourceCardID: "blocker", TargetCardID: "blocked", RelationType: model.RelationTypeBlocks}, Card: blocked},
+	}, nil)
+	th.Store.EXPECT().GetCardRelations("blocked").Return([]*model.CardRelationWithCard{
+		{CardRelation: model.CardRelation{SourceCardID: "blocker", TargetCardID: "blocked", RelationType: model.RelationTypeBlocks}, Card: doneBlocker},
+	}, nil)
+	th.Store.EXPECT().GetBoardAndCardByID(gomock.Any()).Return(board, blocker, nil).AnyTimes()
+	th.Store.EXPECT().GetMemberForBoard(board.ID, "user-id").Return(nil, model.NewErrNotFound("member")).AnyTimes()
+	th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+	th.Store.EXPECT().GetCardParent("blocker").Return(nil, model.NewErrNotFound("parent")).AnyTimes()
+
+	// the webapp changes the status of a card with a patch of its block.
+	_, err = th.App.PatchBlockAndNotify("blocker", &model.BlockPatch{UpdatedFields: map[string]interface{}{
+		"properties": map[string]interface{}{"status": "done"},
+	}}, "user-id", false)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(backend.getEvents()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	events := backend.getEvents()
+	assert.Equal(t, "blocked", events[0].Card.ID)
+	assert.Equal(t, "blocker", events[0].Blocker.ID)
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+// bulkCardChange is what a bulk request did to one card.
+type bulkCardChange struct {
+	oldBlock  *model.Block
+	oldCard   *model.Card
+	block     *model.Block
+	card      *model.Card
+	deleted   bool
+	relations []*model.CardRelation
+}
+
+// BulkUpdateCards applies the operations of a bulk request to cards of a
+// board. Each card goes through the same validations as PatchCard and is
+// changed on its own, so a card that fails does not stop the others. The
+// changed cards are broadcast in one websocket message, and each card gets
+// one notification.
+func (a *App) BulkUpdateCards(boardID string, request *model.CardBulkRequest, userID string, disableNotify bool) (*model.CardBulkResult, error) {
+	if err := request.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the properties of board %s: %w", board.ID, err)
+	}
+
+	cardPatch, err := buildBulkCardPatch(board, schema, request.Operations)
+	if err != nil {
+		return nil, err
+	}
+	if cardPatch != nil {
+		if err := validateCardProperties(board, cardPatch.UpdatedProperties); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &model.CardBulkResult{
+		Results: make([]*model.CardBulkCardResult, 0, len(request.CardIDs)),
+	}
+	changes := make([]*bulkCardChange, 0, len(request.CardIDs))
+	for _, cardID := range request.CardIDs {
+		cardResult := &model.CardBulkCardResult{CardID: cardID}
+		result.Results = append(result.Results, cardResult)
+
+		change, err := a.bulkUpdateCard(board, cardID, request.Operations, cardPatch, userID, disableNotify)
+		if err != nil {
+			cardResult.Err = err
+			result.Failed++
+			continue
+		}
+
+		cardResult.Success = true
+		cardResult.Deleted = change.deleted
+		cardResult.Relations = change.relations
+		if !change.deleted {
+			cardResult.Card = change.card
+		}
+		result.Succeeded++
+		changes = append(changes, change)
+	}
+
+	if len(changes) > 0 {
+		a.notifyBulkCardChanges(board, changes, userID, disableNotify)
+	}
+	return result, nil
+}
+
+// buildBulkCardPatch merges the patch, setStatus and assign operations of a
+// bulk request into one card patch, with the comment of the setStatus
+// operation. It returns nil if the request has none.
+func buildBulkCardPatch(board *model.Board, schema model.PropSchema, operations []model.CardBulkOperation) (*model.CardPatch, error) {
+	var cardPatch *model.CardPatch
+	getPatch := func() *model.CardPatch {
+		if cardPatch == nil {
+			cardPatch = &model.CardPatch{UpdatedProperties: map[string]any{}}
+		}
+		return cardPatch
+	}
+
+	for _, op := range operations {
+		switch op.Type {
+		case model.CardBulkOperationPatch:
+			patch := getPatch()
+			if op.Patch.Title != nil {
+				patch.Title = op.Patch.Title
+			}
+			if op.Patch.Icon != nil {
+				patch.Icon = op.Patch.Icon
+			}
+			if op.Patch.ContentOrder != nil {
+				patch.ContentOrder = op.Patch.ContentOrder
+			}
+			for propID, value := range op.Patch.UpdatedProperties {
+				patch.UpdatedProperties[propID] = value
+			}
+
+		case model.CardBulkOperationSetStatus:
+			prop, ok := schema.WorkflowPropDef(board)
+			if op.PropertyID != "" {
+				prop, ok = schema[op.PropertyID]
+			}
+			if !ok || prop.Type != model.PropTypeSelect {
+				return nil, model.NewErrBadRequest("the board has no such status property")
+			}
+			if _, ok := prop.Options[op.OptionID]; !ok {
+				return nil, model.NewErrBadRequest(fmt.Sprintf("the %s property has no option %s", prop.Name, op.OptionID))
+			}
+			patch := getPatch()
+			patch.UpdatedProperties[prop.ID] = op.OptionID
+			if strings.TrimSpace(op.Comment) != "" {
+				comment := op.Comment
+				patch.Comment = &comment
+			}
+
+		case model.CardBulkOperationAssign:
+			prop, ok := schema[op.PropertyID]
+			if !ok || (prop.Type != model.PropTypePerson && prop.Type != model.PropTypeMultiPerson) {
+				return nil, model.NewErrBadRequest(fmt.Sprintf("%s is not a person property of the board", op.PropertyID))
+			}
+			if prop.Type == model.PropTypeMultiPerson {
+				userIDs := op.UserIDs
+				if userIDs == nil {
+					userIDs = []string{}
+				}
+				getPatch().UpdatedProperties[prop.ID] = userIDs
+				continue
+			}
+			if len(op.UserIDs) > 1 {
+				return nil, model.NewErrBadRequest(fmt.Sprintf("the %s property holds a single user", prop.Name))
+			}
+			userID := ""
+			if len(op.UserIDs) == 1 {
+				userID = op.UserIDs[0]
+			}
+			getPatch().UpdatedProperties[prop.ID] = userID
+		}
+	}
+	return cardPatch, nil
+}
+
+// bulkUpdateCard validates the operations of a bulk request against one card
+// and applies them. Nothing is changed if the validation fails.
+func (a *App) bulkUpdateCard(board *model.Board, cardID string, operations []model.CardBulkOperation,
+	cardPatch *model.CardPatch, userID string, disableNotify bool) (*bulkCardChange, error) {
+	block, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != model.TypeCard || block.BoardID != board.ID {
+		return nil, model.NewErrNotFound(fmt.Sprintf("card %s on board %s", cardID, board.ID))
+	}
+	card, err := model.Block2Card(block)
+	if err != nil {
+		return nil, err
+	}
+	change := &bulkCardChange{oldBlock: block, oldCard: card, block: block, card: card}
+
+	if operations[0].Type == model.CardBulkOperationDelete {
+		if err := a.DeleteCardRelationsByCard(cardID); err != nil {
+			return nil, fmt.Errorf("cannot delete the relations of card %s: %w", cardID, err)
+		}
+		if err := a.store.DeleteBlock(cardID, userID); err != nil {
+			return nil, err
+		}
+		change.deleted = true
+		return change, nil
+	}
+
+	var relations []*model.CardRelation
+	for _, op := range operations {
+		if op.Type != model.CardBulkOperationAddRelation {
+			continue
+		}
+		relation := &model.CardRelation{
+			SourceCardID: cardID,
+			TargetCardID: op.TargetCardID,
+			RelationType: op.RelationType,
+			CreatedBy:    userID,
+		}
+		relation.Populate()
+		if err := relation.IsValid(); err != nil {
+			return nil, err
+		}
+		target, err := a.store.GetBlock(op.TargetCardID)
+		if err != nil {
+			return nil, err
+		}
+		if target.Type != model.TypeCard || target.BoardID != board.ID {
+			return nil, model.NewErrBadRequest("source and target cards must be on the same board")
+		}
+		if err := a.checkCardRelationCycle(relation); err != nil {
+			return nil, err
+		}
+		relations = append(relations, relation)
+	}
+
+	var blockPatch *model.BlockPatch
+	if cardPatch != nil {
+		if len(cardPatch.UpdatedProperties) > 0 {
+			if err := a.validateStatusTransitions(board, card, cardPatch, userID); err != nil {
+				return nil, err
+			}
+		}
+		if blockPatch, err = model.CardPatch2BlockPatch(cardPatch); err != nil {
+			return nil, err
+		}
+		if len(cardPatch.UpdatedProperties) > 0 {
+			blockPatch.UpdatedFields["properties"] = mergeCardProperties(card, cardPatch.UpdatedProperties)
+		}
+		applyCardFormulasToPatch(board, block, blockPatch)
+		if err := model.ValidateBlockPatch(blockPatch); err != nil {
+			return nil, err
+		}
+	}
+
+	// like PatchCard, the comment goes first, so a transition that requires
+	// it is never stored without it.
+	if cardPatch != nil && cardPatch.Comment != nil {
+		if err := a.addCardComment(board, cardID, *cardPatch.Comment, userID, disableNotify); err != nil {
+			return nil, fmt.Errorf("cannot add comment to card %s: %w", cardID, err)
+		}
+	}
+
+	if blockPatch != nil {
+		if err := a.store.PatchBlock(cardID, blockPatch, userID); err != nil {
+			return nil, err
+		}
+		if change.block, err = a.store.GetBlock(cardID); err != nil {
+			return nil, err
+		}
+		a.PopulateBlockCode(change.block, board)
+		if change.card, err = model.Block2Card(change.block); err != nil {
+			return nil, err
+		}
+	}
+	a.populateCardCode(change.card, board)
+
+	for _, relation := range relations {
+		created, err := a.store.CreateCardRelation(relation)
+		if err != nil {
+			return nil, fmt.Errorf("cannot relate card %s to card %s: %w", cardID, relation.TargetCardID, err)
+		}
+		created.BoardID = board.ID
+		change.relations = append(change.relations, created)
+	}
+	return change, nil
+}
+
+// notifyBulkCardChanges broadcasts the cards changed by a bulk request in one
+// websocket message and sends one notification per card.
+func (a *App) notifyBulkCardChanges(board *model.Board, changes []*bulkCardChange, userID string, disableNotify bool) {
+	a.blockChangeNotifier.Enqueue(func() error {
+		blocks := make([]*model.Block, 0, len(changes))
+		patched, deleted := 0, 0
+		for _, change := range changes {
+			switch {
+			case change.deleted:
+				now := utils.GetMillis()
+				blocks = append(blocks, &model.Block{ID: change.oldBlock.ID, BoardID: board.ID, UpdateAt: now, DeleteAt: now})
+				deleted++
+			case change.block != change.oldBlock:
+				blocks = append(blocks, change.block)
+				patched++
+			}
+		}
+		if len(blocks) > 0 {
+			a.wsAdapter.BroadcastBlocksChange(board.TeamID, board.ID, blocks)
+		}
+		a.metrics.IncrementBlocksPatched(patched)
+		a.metrics.IncrementBlocksDeleted(deleted)
+
+		for _, change := range changes {
+			for _, relation := range change.relations {
+				a.wsAdapter.BroadcastCardRelationChange(board.TeamID, relation)
+				a.enqueueRelationAddedWebhookEvent(board, relation)
+			}
+
+			switch {
+			case change.deleted:
+				if !disableNotify {
+					a.notifyBlockChanged(notify.Delete, change.oldBlock, change.oldBlock, userID)
+				}
+				a.updateParentRollups(change.oldBlock, nil)
+			case change.block != change.oldBlock:
+				a.updateParentRollups(change.block, change.oldBlock)
+				a.webhook.NotifyUpdate(change.block)
+				if !disableNotify {
+					a.notifyBlockChanged(notify.Update, change.block, change.oldBlock, userID)
+					if isCardDone(board, change.card) && !isCardDone(board, change.oldCard) {
+						a.notifyUnblockedCards(board, change.card, userID)
+					}
+				}
+			case !disableNotify:
+				// the relations are the only change to the card
+				for _, relation := range change.relations {
+					a.notifyCardRelationAdded(board, change.oldBlock, relation)
+				}
+			}
+		}
+		return nil
+	})
+}
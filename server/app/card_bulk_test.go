@@ -0,0 +1,233 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bulkTestBoard() *model.Board {
+	return &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		Code:   "BLK",
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To Do"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+			{"id": "owner", "name": "Owner", "type": "person"},
+			{"id": "reviewers", "name": "Reviewers", "type": "multiPerson"},
+		},
+	}
+}
+
+func bulkTestCard(id, boardID string, properties map[string]interface{}) *model.Block {
+	return &model.Block{
+		ID:       id,
+		BoardID:  boardID,
+		ParentID: boardID,
+		Type:     model.TypeCard,
+		Title:    "Card " + id,
+		Number:   1,
+		Fields:   map[string]interface{}{"properties": properties},
+	}
+}
+
+func TestBuildBulkCardPatch(t *testing.T) {
+	board := bulkTestBoard()
+	schema, err := model.ParsePropertySchema(board)
+	require.NoError(t, err)
+
+	t.Run("merges the operations", func(t *testing.T) {
+		title := "renamed"
+		patch, err := buildBulkCardPatch(board, schema, []model.CardBulkOperation{
+			{Type: model.CardBulkOperationPatch, Patch: &model.CardPatch{Title: &title, UpdatedProperties: map[string]any{"status": "todo"}}},
+			{Type: model.CardBulkOperationSetStatus, OptionID: "done"},
+			{Type: model.CardBulkOperationAssign, PropertyID: "owner", UserIDs: []string{"user-1"}},
+			{Type: model.CardBulkOperationAssign, PropertyID: "reviewers"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &title, patch.Title)
+		assert.Equal(t, map[string]any{"status": "done", "owner": "user-1", "reviewers": []string{}}, patch.UpdatedProperties)
+	})
+
+	t.Run("no patch", func(t *testing.T) {
+		patch, err := buildBulkCardPatch(board, schema, []model.CardBulkOperation{
+			{Type: model.CardBulkOperationAddRelation, TargetCardID: "card-2", RelationType: model.RelationTypeBlocks},
+		})
+		require.NoError(t, err)
+		assert.Nil(t, patch)
+	})
+
+	testCases := []struct {
+		name string
+		op   model.CardBulkOperation
+	}{
+		{"unknown option", model.CardBulkOperation{Type: model.CardBulkOperationSetStatus, OptionID: "nope"}},
+		{"not a status property", model.CardBulkOperation{Type: model.CardBulkOperationSetStatus, PropertyID: "owner", OptionID: "todo"}},
+		{"not a person property", model.CardBulkOperation{Type: model.CardBulkOperationAssign, PropertyID: "status"}},
+		{"many users for a person property", model.CardBulkOperation{Type: model.CardBulkOperationAssign, PropertyID: "owner", UserIDs: []string{"a", "b"}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := buildBulkCardPatch(board, schema, []model.CardBulkOperation{tc.op})
+			assert.True(t, model.IsErrBadRequest(err))
+		})
+	}
+}
+
+func TestBulkUpdateCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := bulkTestBoard()
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return(nil, nil).AnyTimes()
+	th.Store.EXPECT().GetBoardAndCardByID(gomock.Any()).Return(board, nil, nil).AnyTimes()
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetBoardWebhooks(board.ID).Return(nil, nil).AnyTimes()
+	th.Store.EXPECT().GetCardParent(gomock.Any()).Return(nil, model.NewErrNotFound("card parent")).AnyTimes()
+
+	t.Run("reports each card", func(t *testing.T) {
+		card1 := bulkTestCard("card-1", board.ID, map[string]interface{}{"reviewers": []interface{}{"user-2"}})
+		otherCard := bulkTestCard("card-2", "other-board", map[string]interface{}{})
+		patched := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "todo", "owner": "user-1"})
+
+		th.Store.EXPECT().GetBlock("card-1").Return(card1, nil)
+		th.Store.EXPECT().GetStatusTransitionRules(board.ID).Return(nil, nil)
+		th.Store.EXPECT().PatchBlock("card-1", gomock.Any(), "user-id").DoAndReturn(func(_ string, patch *model.BlockPatch, _ string) error {
+			assert.Equal(t, map[string]any{"status": "todo", "owner": "user-1", "reviewers": []interface{}{"user-2"}}, patch.UpdatedFields["properties"])
+			return nil
+		})
+		th.Store.EXPECT().GetBlock("card-1").Return(patched, nil)
+		th.Store.EXPECT().GetBlock("card-2").Return(otherCard, nil)
+		th.Store.EXPECT().GetBlock("card-3").Return(nil, model.NewErrNotFound("block ID=card-3"))
+
+		result, err := th.App.BulkUpdateCards(board.ID, &model.CardBulkRequest{
+			CardIDs: []string{"card-1", "card-2", "card-3"},
+			Operations: []model.CardBulkOperation{
+				{Type: model.CardBulkOperationSetStatus, OptionID: "todo"},
+				{Type: model.CardBulkOperationAssign, PropertyID: "owner", UserIDs: []string{"user-1"}},
+			},
+		}, "user-id", true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Succeeded)
+		assert.Equal(t, 2, result.Failed)
+		require.Len(t, result.Results, 3)
+
+		assert.True(t, result.Results[0].Success)
+		assert.Equal(t, "BLK-1", result.Results[0].Card.Code)
+		assert.Equal(t, "user-1", result.Results[0].Card.Properties["owner"])
+		assert.False(t, result.Results[1].Success)
+		assert.True(t, model.IsErrNotFound(result.Results[1].Err))
+		assert.False(t, result.Results[2].Success)
+		assert.True(t, model.IsErrNotFound(result.Results[2].Err))
+	})
+
+	t.Run("status transition rules", func(t *testing.T) {
+		card1 := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "todo"})
+		rules := []*model.StatusTransitionRule{
+			{BoardID: board.ID, FromStatus: "todo", ToStatus: "done", Allowed: false},
+		}
+
+		th.Store.EXPECT().GetBlock("card-1").Return(card1, nil)
+		th.Store.EXPECT().GetStatusTransitionRules(board.ID).Return(rules, nil)
+
+		result, err := th.App.BulkUpdateCards(board.ID, &model.CardBulkRequest{
+			CardIDs:    []string{"card-1"},
+			Operations: []model.CardBulkOperation{{Type: model.CardBulkOperationSetStatus, OptionID: "done"}},
+		}, "user-id", true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Failed)
+		var transitionErr *model.ErrStatusTransition
+		assert.ErrorAs(t, result.Results[0].Err, &transitionErr)
+	})
+
+	t.Run("status transition requiring a comment", func(t *testing.T) {
+		card1 := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "todo"})
+		patched := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "done"})
+		rules := []*model.StatusTransitionRule{
+			{BoardID: board.ID, FromStatus: "todo", ToStatus: "done", Allowed: true, RequireComment: true},
+		}
+
+		th.Store.EXPECT().GetBlock("card-1").Return(card1, nil)
+		th.Store.EXPECT().GetStatusTransitionRules(board.ID).Return(rules, nil)
+		gomock.InOrder(
+			th.Store.EXPECT().InsertBlock(gomock.Any(), "user-id").DoAndReturn(func(comment *model.Block, _ string) error {
+				assert.EqualValues(t, model.TypeComment, comment.Type)
+				assert.Equal(t, "card-1", comment.ParentID)
+				assert.Equal(t, "shipped", comment.Title)
+				return nil
+			}),
+			th.Store.EXPECT().PatchBlock("card-1", gomock.Any(), "user-id").Return(nil),
+		)
+		th.Store.EXPECT().GetBlock("card-1").Return(patched, nil)
+
+		result, err := th.App.BulkUpdateCards(board.ID, &model.CardBulkRequest{
+			CardIDs:    []string{"card-1"},
+			Operations: []model.CardBulkOperation{{Type: model.CardBulkOperationSetStatus, OptionID: "done", Comment: "shipped"}},
+		}, "user-id", true)
+		require.NoError(t, err)
+		require.True(t, result.Results[0].Success)
+		assert.Equal(t, "done", result.Results[0].Card.Properties["status"])
+	})
+
+	t.Run("add relation", func(t *testing.T) {
+		card1 := bulkTestCard("card-1", board.ID, map[string]interface{}{})
+		card2 := bulkTestCard("card-2", board.ID, map[string]interface{}{})
+
+		th.Store.EXPECT().GetBlock("card-1").Return(card1, nil)
+		th.Store.EXPECT().GetBlock("card-2").Return(card2, nil)
+		expectCardRelations(th, nil, graphCards(map[string]string{"card-1": board.ID, "card-2": board.ID}))
+		th.Store.EXPECT().CreateCardRelation(gomock.Any()).DoAndReturn(func(relation *model.CardRelation) (*model.CardRelation, error) {
+			assert.Equal(t, "card-1", relation.SourceCardID)
+			assert.Equal(t, "card-2", relation.TargetCardID)
+			return relation, nil
+		})
+
+		result, err := th.App.BulkUpdateCards(board.ID, &model.CardBulkRequest{
+			CardIDs: []string{"card-1"},
+			Operations: []model.CardBulkOperation{
+				{Type: model.CardBulkOperationAddRelation, TargetCardID: "card-2", RelationType: model.RelationTypeBlocks},
+			},
+		}, "user-id", true)
+		require.NoError(t, err)
+		require.True(t, result.Results[0].Success)
+		require.Len(t, result.Results[0].Relations, 1)
+		assert.Equal(t, board.ID, result.Results[0].Relations[0].BoardID)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		card1 := bulkTestCard("card-1", board.ID, map[string]interface{}{})
+
+		th.Store.EXPECT().GetBlock("card-1").Return(card1, nil)
+		th.Store.EXPECT().GetCardRelations("card-1").Return(nil, nil)
+		th.Store.EXPECT().DeleteBlock("card-1", "user-id").Return(nil)
+
+		result, err := th.App.BulkUpdateCards(board.ID, &model.CardBulkRequest{
+			CardIDs:    []string{"card-1"},
+			Operations: []model.CardBulkOperation{{Type: model.CardBulkOperationDelete}},
+		}, "user-id", true)
+		require.NoError(t, err)
+		assert.True(t, result.Results[0].Success)
+		assert.True(t, result.Results[0].Deleted)
+		assert.Nil(t, result.Results[0].Card)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, err := th.App.BulkUpdateCards(board.ID, &model.CardBulkRequest{
+			CardIDs: []string{"card-1"},
+			Operations: []model.CardBulkOperation{
+				{Type: model.CardBulkOperationDelete},
+				{Type: model.CardBulkOperationSetStatus, OptionID: "done"},
+			},
+		}, "user-id", true)
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+}
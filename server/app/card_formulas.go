@@ -0,0 +1,147 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// populateCardFormulas evaluates the formula properties of a card being read,
+// so that their values are current even if the formulas changed since the
+// card was stored.
+func (a *App) populateCardFormulas(card *model.Card, board *model.Board) {
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return
+	}
+	model.ComputeFormulas(card, schema)
+}
+
+// applyCardFormulasToPatch adds the recomputed values of the formula
+// properties of a card to a patch of the card, so that they are stored
+// along with the values they depend on.
+func applyCardFormulasToPatch(board *model.Board, oldBlock *model.Block, patch *model.BlockPatch) {
+	if oldBlock.Type != model.TypeCard {
+		return
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil || len(schema.FormulaPropDefs()) == 0 {
+		return
+	}
+	card, err := model.Block2Card(oldBlock)
+	if err != nil {
+		return
+	}
+
+	// the formulas are evaluated on the card as it is stored once patched:
+	// the properties of the old block, unless the patch replaces them. A
+	// patch sets the properties field as a whole, which is how clients clear
+	// a property, so server callers updating some properties send them
+	// merged with the current ones, as PatchCard does.
+	for _, field := range patch.DeletedFields {
+		if field == "properties" {
+			card.Properties = map[string]any{}
+		}
+	}
+	if properties, ok := patch.UpdatedFields["properties"].(map[string]any); ok {
+		card.Properties = make(map[string]any, len(properties))
+		for propID, value := range properties {
+			card.Properties[propID] = value
+		}
+	}
+	if patch.Title != nil {
+		card.Title = *patch.Title
+	}
+	card.UpdateAt = utils.GetMillis()
+
+	if !model.ComputeFormulas(card, schema) {
+		return
+	}
+	if patch.UpdatedFields == nil {
+		patch.UpdatedFields = map[string]any{}
+	}
+	patch.UpdatedFields["properties"] = card.Properties
+}
+
+// applyCardFormulasToPatches adds the recomputed values of the formula
+// properties of the cards of a batch of patches to their patches.
+func (a *App) applyCardFormulasToPatches(oldBlocks []*model.Block, blockPatches *model.BlockPatchBatch) error {
+	oldByID := make(map[string]*model.Block, len(oldBlocks))
+	for _, block := range oldBlocks {
+		oldByID[block.ID] = block
+	}
+
+	boards := map[string]*model.Board{}
+	for i, blockID := range blockPatches.BlockIDs {
+		oldBlock, ok := oldByID[blockID]
+		if !ok || oldBlock.Type != model.TypeCard || i >= len(blockPatches.BlockPatches) {
+			continue
+		}
+		board, ok := boards[oldBlock.BoardID]
+		if !ok {
+			var err error
+			if board, err = a.store.GetBoard(oldBlock.BoardID); err != nil {
+				return err
+			}
+			boards[board.ID] = board
+		}
+		applyCardFormulasToPatch(board, oldBlock, &blockPatches.BlockPatches[i])
+	}
+	return nil
+}
+
+// applyCardFormulas recomputes the formula properties of a card block about
+// to be stored.
+func applyCardFormulas(board *model.Board, block *model.Block) {
+	if block.Type != model.TypeCard {
+		return
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return
+	}
+	model.ComputeBlockFormulas(block, schema)
+}
+
+// updateBoardFormulas stores the values of the formula properties of all the
+// cards of a board after its properties changed. It runs on the block change
+// notifier.
+func (a *App) updateBoardFormulas(board *model.Board) {
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil || len(schema.FormulaPropDefs()) == 0 {
+		return
+	}
+
+	blocks, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		a.logger.Warn("updateBoardFormulas: could not get cards",
+			mlog.String("boardID", board.ID),
+			mlog.Err(err))
+		return
+	}
+
+	changed := make([]*model.Block, 0, len(blocks))
+	for _, block := range blocks {
+		if !model.ComputeBlockFormulas(block, schema) {
+			continue
+		}
+		patch := &model.BlockPatch{UpdatedFields: map[string]any{"properties": block.Fields["properties"]}}
+		if err := a.store.PatchBlock(block.ID, patch, model.SystemUserID); err != nil {
+			a.logger.Error("updateBoardFormulas: could not patch card",
+				mlog.String("cardID", block.ID),
+				mlog.Err(err))
+			continue
+		}
+		block.ModifiedBy = model.SystemUserID
+		block.UpdateAt = utils.GetMillis()
+		a.PopulateBlockCode(block, board)
+		changed = append(changed, block)
+	}
+
+	if len(changed) > 0 {
+		a.wsAdapter.BroadcastBlocksChange(board.TeamID, board.ID, changed)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func formulaTestBoard() *model.Board {
+	return &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		Code:   "FRM",
+		CardProperties: []map[string]interface{}{
+			{"id": "estimate", "name": "Estimate", "type": "number"},
+			{"id": "spent", "name": "Spent", "type": "number"},
+			{"id": "remaining", "name": "Remaining", "type": "formula", "formula": `prop("Estimate") - prop("Spent")`},
+		},
+	}
+}
+
+func TestPatchCardFormulas(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := formulaTestBoard()
+	card := bulkTestCard("card-1", board.ID, map[string]interface{}{"estimate": "5", "spent": "1", "remaining": "4"})
+	patched := bulkTestCard("card-1", board.ID, map[string]interface{}{"estimate": "5", "spent": "3", "remaining": "2"})
+
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return(nil, nil).AnyTimes()
+	th.Store.EXPECT().GetBoardAndCardByID(gomock.Any()).Return(board, nil, nil).AnyTimes()
+	th.Store.EXPECT().GetCardParent(gomock.Any()).Return(nil, model.NewErrNotFound("card parent")).AnyTimes()
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+	th.Store.EXPECT().PatchBlock("card-1", gomock.Any(), "user-id").DoAndReturn(func(_ string, patch *model.BlockPatch, _ string) error {
+		assert.Equal(t, map[string]any{"estimate": "5", "spent": "3", "remaining": "2"}, patch.UpdatedFields["properties"])
+		return nil
+	})
+	th.Store.EXPECT().GetBlock("card-1").Return(patched, nil)
+
+	blockPatch := &model.BlockPatch{UpdatedFields: map[string]any{
+		"properties": map[string]any{"estimate": "5", "spent": "3", "remaining": "whatever"},
+	}}
+	block, err := th.App.PatchBlockAndNotify("card-1", blockPatch, "user-id", true)
+	require.NoError(t, err)
+	assert.Equal(t, "FRM-1", block.Code)
+}
+
+func TestPatchCardFormulaInputs(t *testing.T) {
+	board := formulaTestBoard()
+
+	setup := func(t *testing.T) (*TestHelper, func(), func() *model.Block) {
+		th, tearDown := SetupTestHelper(t)
+		block := bulkTestCard("card-1", board.ID, map[string]interface{}{"estimate": "5", "spent": "1", "remaining": "4"})
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return(nil, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardAndCardByID(gomock.Any()).Return(board, nil, nil).AnyTimes()
+		th.Store.EXPECT().GetCardParent(gomock.Any()).Return(nil, model.NewErrNotFound("card parent")).AnyTimes()
+		th.Store.EXPECT().GetStatusTransitionRules(board.ID).Return([]*model.StatusTransitionRule{}, nil).AnyTimes()
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().GetBlock("card-1").DoAndReturn(func(string) (*model.Block, error) {
+			return block, nil
+		}).AnyTimes()
+		th.Store.EXPECT().PatchBlock("card-1", gomock.Any(), "user-id").DoAndReturn(func(_ string, patch *model.BlockPatch, _ string) error {
+			block = patch.Patch(block)
+			return nil
+		})
+		return th, tearDown, func() *model.Block { return block }
+	}
+
+	t.Run("one input of the formula", func(t *testing.T) {
+		th, tearDown, stored := setup(t)
+		defer tearDown()
+
+		card, err := th.App.PatchCard(&model.CardPatch{
+			UpdatedProperties: map[string]any{"spent": "3"},
+		}, "card-1", "user-id", true)
+		require.NoError(t, err)
+
+		expected := map[string]any{"estimate": "5", "spent": "3", "remaining": "2"}
+		assert.Equal(t, expected, card.Properties)
+		assert.Equal(t, expected, stored().Fields["properties"])
+	})
+
+	t.Run("input cleared by a client", func(t *testing.T) {
+		th, tearDown, stored := setup(t)
+		defer tearDown()
+
+		_, err := th.App.PatchBlockAndNotify("card-1", &model.BlockPatch{UpdatedFields: map[string]any{
+			"properties": map[string]any{"estimate": "5", "remaining": "4"},
+		}}, "user-id", true)
+		require.NoError(t, err)
+
+		// the cleared input stays cleared, and the formula missing it has no
+		// value rather than its stale one.
+		assert.Equal(t, map[string]any{"estimate": "5"}, stored().Fields["properties"])
+	})
+}
+
+func TestInsertCardFormulas(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := formulaTestBoard()
+	card := bulkTestCard("card-1", board.ID, map[string]interface{}{"estimate": "2.5", "spent": "1"})
+
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return(nil, nil).AnyTimes()
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+	th.Store.EXPECT().GetBlock("card-1").Return(nil, model.NewErrNotFound("block ID=card-1"))
+	th.Store.EXPECT().InsertBlock(gomock.Any(), "user-id").DoAndReturn(func(block *model.Block, _ string) error {
+		assert.Equal(t, "1.5", block.Fields["properties"].(map[string]any)["remaining"])
+		return nil
+	})
+
+	_, err := th.App.InsertBlocksAndNotify([]*model.Block{card}, "user-id", true)
+	require.NoError(t, err)
+}
+
+func TestUpdateBoardFormulas(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := formulaTestBoard()
+	upToDate := bulkTestCard("card-1", board.ID, map[string]interface{}{"estimate": "3", "spent": "1", "remaining": "2"})
+	stale := bulkTestCard("card-2", board.ID, map[string]interface{}{"estimate": "3", "spent": "2"})
+
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return(nil, nil).AnyTimes()
+	th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]*model.Block{upToDate, stale}, nil)
+	th.Store.EXPECT().PatchBlock("card-2", gomock.Any(), model.SystemUserID).DoAndReturn(func(_ string, patch *model.BlockPatch, _ string) error {
+		assert.Equal(t, map[string]any{"estimate": "3", "spent": "2", "remaining": "1"}, patch.UpdatedFields["properties"])
+		return nil
+	})
+
+	th.App.updateBoardFormulas(board)
+}
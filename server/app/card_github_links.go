@@ -0,0 +1,292 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/github"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	// cardGitHubLinkSyncInterval is how often a link is synchronized with
+	// GitHub.
+	cardGitHubLinkSyncInterval  = 15 * time.Minute
+	cardGitHubLinkSyncBatchSize = 100
+)
+
+var errGitHubUnavailable = errors.New("the GitHub integration is not available")
+
+// GetCardGitHubLinks returns the GitHub links of a card with the state of
+// their items as of their last synchronization. GitHub is not called, so the
+// links are available when it cannot be reached.
+func (a *App) GetCardGitHubLinks(cardID string) ([]*model.CardGitHubLink, error) {
+	return a.store.GetCardGitHubLinks(cardID)
+}
+
+// GetCardGitHubLink returns a GitHub link.
+func (a *App) GetCardGitHubLink(linkID string) (*model.CardGitHubLink, error) {
+	return a.store.GetCardGitHubLink(linkID)
+}
+
+// AddCardGitHubLink links a GitHub issue, pull request or commit, or an
+// issue of another tracker, to a card. Links without a provider are to the
+// tracker of the board. The item is fetched right away; if the tracker cannot
+// be reached the link is still added and its state is filled by the next
+// synchronization.
+func (a *App) AddCardGitHubLink(link *model.CardGitHubLink) (*model.CardGitHubLink, error) {
+	block, err := a.store.GetBlock(link.CardID)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != model.TypeCard {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("block %s is not a card", block.ID))
+	}
+	link.BoardID = block.BoardID
+
+	if link.Provider == "" {
+		board, err := a.store.GetBoard(block.BoardID)
+		if err != nil {
+			return nil, err
+		}
+		if link.Provider, err = model.GetBoardTrackerProvider(board); err != nil {
+			return nil, err
+		}
+	}
+	if link.Provider != model.TrackerProviderGitHub {
+		if err = a.populateTrackerLink(link); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = link.Populate(); err != nil {
+		return nil, err
+	}
+	if err = link.IsValid(); err != nil {
+		return nil, err
+	}
+
+	links, err := a.store.GetCardGitHubLinks(link.CardID)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) >= model.MaxCardGitHubLinks {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("a card cannot have more than %d GitHub links", model.MaxCardGitHubLinks))
+	}
+	for _, existing := range links {
+		if existing.Same(link) {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("%s is already linked to the card", link.Reference()))
+		}
+	}
+
+	if err = a.fetchCardGitHubLink(link); err != nil {
+		a.logger.Debug("AddCardGitHubLink: cannot fetch the linked item",
+			mlog.String("card_id", link.CardID),
+			mlog.Err(err),
+		)
+	}
+	created, err := a.store.CreateCardGitHubLink(link)
+	if err != nil {
+		return nil, err
+	}
+	a.applyGitHubLinkStatusRule(created, &model.CardGitHubLink{})
+	return created, nil
+}
+
+// DeleteCardGitHubLink removes a GitHub link from its card.
+func (a *App) DeleteCardGitHubLink(linkID string) error {
+	return a.store.DeleteCardGitHubLink(linkID)
+}
+
+// SyncCardGitHubLinks refreshes the links that were not synchronized
+// recently with the state of their items on their tracker. Links that cannot
+// be refreshed keep their last known state along with the error.
+func (a *App) SyncCardGitHubLinks() error {
+	if a.GetGitHubService() == nil && !a.hasOtherTrackers() {
+		return nil
+	}
+
+	attemptedBefore := utils.GetMillis() - cardGitHubLinkSyncInterval.Milliseconds()
+	links, err := a.store.GetCardGitHubLinksToSync(attemptedBefore, cardGitHubLinkSyncBatchSize)
+	if err != nil {
+		return err
+	}
+
+	merr := merror.New()
+	for _, link := range links {
+		if _, err := a.store.GetBlock(link.CardID); model.IsErrNotFound(err) {
+			a.logger.Info("Deleting the GitHub link of a card that no longer exists", mlog.String("card_id", link.CardID))
+			if err := a.store.DeleteCardGitHubLink(link.ID); err != nil && !model.IsErrNotFound(err) {
+				merr.Append(fmt.Errorf("github link %s: %w", link.ID, err))
+			}
+			continue
+		}
+
+		previous := *link
+		if err := a.fetchCardGitHubLink(link); err != nil {
+			a.logger.Debug("SyncCardGitHubLinks: cannot fetch the linked item",
+				mlog.String("link_id", link.ID),
+				mlog.Err(err),
+			)
+		}
+		if err := a.store.UpdateCardGitHubLinkSync(link); err != nil {
+			if !model.IsErrNotFound(err) {
+				merr.Append(fmt.Errorf("github link %s: %w", link.ID, err))
+			}
+			continue
+		}
+		a.applyGitHubLinkStatusRule(link, &previous)
+	}
+	return merr.ErrorOrNil()
+}
+
+// fetchCardGitHubLink sets the state of the linked item from its tracker,
+// using the account of the user who added the link. On failure the last
+// known state is kept and the error is recorded in the link.
+func (a *App) fetchCardGitHubLink(link *model.CardGitHubLink) error {
+	now := utils.GetMillis()
+
+	if link.TrackerProvider() != model.TrackerProviderGitHub {
+		return a.fetchTrackerLink(link, now)
+	}
+
+	githubService := a.GetGitHubService()
+	if githubService == nil {
+		link.SetSyncError(errGitHubUnavailable, now)
+		return errGitHubUnavailable
+	}
+
+	switch link.Kind {
+	case model.CardGitHubLinkKindIssue:
+		issue, err := githubService.GetIssue(link.CreatedBy, link.Owner, link.Repo, link.Number)
+		if err != nil {
+			link.SetSyncError(err, now)
+			return err
+		}
+		setCardGitHubLinkIssue(link, issue)
+
+	case model.CardGitHubLinkKindPullRequest:
+		pr, err := githubService.GetPRDetails(link.CreatedBy, link.Owner, link.Repo, link.Number)
+		if err != nil {
+			link.SetSyncError(err, now)
+			return err
+		}
+		setCardGitHubLinkPullRequest(link, pr)
+
+	case model.CardGitHubLinkKindCommit:
+		commit, err := githubService.GetCommit(link.CreatedBy, link.Owner, link.Repo, link.SHA)
+		if err != nil {
+			link.SetSyncError(err, now)
+			return err
+		}
+		setCardGitHubLinkCommit(link, commit)
+	}
+
+	link.SyncError = ""
+	link.SyncedAt = now
+	link.SyncAttemptAt = now
+	link.UpdateAt = now
+	return nil
+}
+
+// populateTrackerLink sets the project and number of a link to an issue of
+// a tracker other than GitHub from its URL.
+func (a *App) populateTrackerLink(link *model.CardGitHubLink) error {
+	if link.Kind == "" {
+		link.Kind = model.CardGitHubLinkKindIssue
+	}
+	if link.Project != "" || link.URL == "" {
+		return nil
+	}
+	provider, err := a.GetTrackerProvider(link.Provider)
+	if err != nil {
+		return err
+	}
+	project, number, err := provider.ParseIssueURL(link.URL)
+	if err != nil {
+		return model.NewErrBadRequest(err.Error())
+	}
+	link.Project, link.Number = project, number
+	return nil
+}
+
+// fetchTrackerLink sets the state of an issue of a tracker other than
+// GitHub.
+func (a *App) fetchTrackerLink(link *model.CardGitHubLink, now int64) error {
+	provider, err := a.GetTrackerProvider(link.TrackerProvider())
+	if err != nil {
+		link.SetSyncError(err, now)
+		return err
+	}
+	issue, err := provider.GetIssue(link.CreatedBy, link.Project, link.Number)
+	if err != nil {
+		link.SetSyncError(err, now)
+		return err
+	}
+	link.URL = issue.URL
+	link.Title = issue.Title
+	link.State = issue.State
+	link.Merged = false
+	link.Labels = issue.Labels
+	link.Assignees = issue.Assignees
+
+	link.SyncError = ""
+	link.SyncedAt = now
+	link.SyncAttemptAt = now
+	link.UpdateAt = now
+	return nil
+}
+
+// hasOtherTrackers returns true if a tracker other than GitHub is
+// configured.
+func (a *App) hasOtherTrackers() bool {
+	return (a.config.GitLabURL != "" && a.config.GitLabAccessToken != "") ||
+		(a.config.JiraURL != "" && a.config.JiraAPIToken != "")
+}
+
+func setCardGitHubLinkIssue(link *model.CardGitHubLink, issue *github.Issue) {
+	link.URL = issue.HTMLURL
+	link.Title = issue.Title
+	link.State = issue.State
+	link.Merged = false
+	link.Labels = []string{}
+	for _, label := range issue.Labels {
+		link.Labels = append(link.Labels, label.Name)
+	}
+	link.Assignees = []string{}
+	for _, assignee := range issue.Assignees {
+		link.Assignees = append(link.Assignees, assignee.Login)
+	}
+}
+
+func setCardGitHubLinkPullRequest(link *model.CardGitHubLink, pr *github.PRDetails) {
+	link.URL = pr.HTMLURL
+	link.Title = pr.Title
+	link.State = pr.State
+	link.Merged = pr.Merged
+	link.Labels = []string{}
+	for _, label := range pr.Labels {
+		link.Labels = append(link.Labels, label.Name)
+	}
+	link.Assignees = []string{}
+	for _, assignee := range pr.Assignees {
+		link.Assignees = append(link.Assignees, assignee.Login)
+	}
+}
+
+func setCardGitHubLinkCommit(link *model.CardGitHubLink, commit *github.Commit) {
+	link.SHA = commit.SHA
+	link.URL = commit.HTMLURL
+	link.Title, _, _ = strings.Cut(commit.Commit.Message, "\n")
+	link.Labels = []string{}
+	link.Assignees = []string{}
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/github"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/tracker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// fakeGitHubAPI hands out a token for every user, as the GitHub plugin does
+// for connected users.
+type fakeGitHubAPI struct {
+	logger mlog.LoggerIFace
+}
+
+func (f *fakeGitHubAPI) PluginHTTP(_ *http.Request) *http.Response {
+	body, _ := json.Marshal(github.TokenResponse{AccessToken: "ghp_test_token"})
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}
+}
+
+func (f *fakeGitHubAPI) GetLogger() mlog.LoggerIFace {
+	return f.logger
+}
+
+// fakeGitHubTransport sends the GitHub API calls to a fake GitHub server.
+type fakeGitHubTransport struct {
+	serverURL *url.URL
+}
+
+func (t *fakeGitHubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.serverURL.Scheme
+	req.URL.Host = t.serverURL.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// setupFakeGitHub makes the app call a fake GitHub server.
+func setupFakeGitHub(t *testing.T, th *TestHelper, handler http.Handler) *httptest.Server {
+	server := httptest.NewServer(handler)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	th.App.githubService = github.NewWithHTTPClient(
+		&fakeGitHubAPI{logger: th.logger},
+		&http.Client{Transport: &fakeGitHubTransport{serverURL: serverURL}},
+	)
+	return server
+}
+
+func TestAddCardGitHubLink(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/issues/12", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer ghp_test_token", r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(github.Issue{
+			Number:    12,
+			Title:     "Crash on save",
+			State:     "open",
+			HTMLURL:   "https://github.com/owner/repo/issues/12",
+			Labels:    []github.Label{{Name: "bug"}},
+			Assignees: []github.User{{Login: "octocat"}},
+		})
+	})
+	server := setupFakeGitHub(t, th, mux)
+	defer server.Close()
+
+	card := bulkTestCard("card-1", "board-id", map[string]interface{}{})
+	board := &model.Board{ID: "board-id", Properties: map[string]interface{}{}}
+	th.Store.EXPECT().GetBoard("board-id").Return(board, nil).AnyTimes()
+
+	t.Run("from the URL of an issue", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+		th.Store.EXPECT().GetCardGitHubLinks("card-1").Return([]*model.CardGitHubLink{}, nil)
+		th.Store.EXPECT().CreateCardGitHubLink(gomock.Any()).DoAndReturn(func(link *model.CardGitHubLink) (*model.CardGitHubLink, error) {
+			return link, nil
+		})
+
+		link, err := th.App.AddCardGitHubLink(&model.CardGitHubLink{
+			CardID:    "card-1",
+			URL:       "https://github.com/owner/repo/issues/12",
+			CreatedBy: "user-id",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "board-id", link.BoardID)
+		assert.Equal(t, model.CardGitHubLinkKindIssue, link.Kind)
+		assert.Equal(t, "Crash on save", link.Title)
+		assert.Equal(t, "open", link.State)
+		assert.Equal(t, []string{"bug"}, link.Labels)
+		assert.Equal(t, []string{"octocat"}, link.Assignees)
+		assert.NotZero(t, link.SyncedAt)
+		assert.Empty(t, link.SyncError)
+	})
+
+	t.Run("kept when GitHub fails", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+		th.Store.EXPECT().GetCardGitHubLinks("card-1").Return([]*model.CardGitHubLink{}, nil)
+		th.Store.EXPECT().CreateCardGitHubLink(gomock.Any()).DoAndReturn(func(link *model.CardGitHubLink) (*model.CardGitHubLink, error) {
+			return link, nil
+		})
+
+		link, err := th.App.AddCardGitHubLink(&model.CardGitHubLink{
+			CardID:    "card-1",
+			Kind:      model.CardGitHubLinkKindPullRequest,
+			Owner:     "owner",
+			Repo:      "repo",
+			Number:    404,
+			CreatedBy: "user-id",
+		})
+		require.NoError(t, err)
+		assert.Zero(t, link.SyncedAt)
+		assert.NotEmpty(t, link.SyncError)
+	})
+
+	t.Run("no duplicates", func(t *testing.T) {
+		existing := &model.CardGitHubLink{Kind: model.CardGitHubLinkKindIssue, Owner: "Owner", Repo: "repo", Number: 12}
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+		th.Store.EXPECT().GetCardGitHubLinks("card-1").Return([]*model.CardGitHubLink{existing}, nil)
+
+		_, err := th.App.AddCardGitHubLink(&model.CardGitHubLink{
+			CardID:    "card-1",
+			URL:       "https://github.com/owner/repo/issues/12",
+			CreatedBy: "user-id",
+		})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
+func TestAddCardTrackerLink(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "glpat-test", r.Header.Get("PRIVATE-TOKEN"))
+		assert.Equal(t, "/api/v4/projects/group%2Fproject/issues/12", r.URL.EscapedPath())
+		_, _ = w.Write([]byte(`{
+			"iid": 12,
+			"title": "Crash on save",
+			"state": "closed",
+			"web_url": "https://gitlab.example.com/group/project/-/issues/12",
+			"labels": ["bug"],
+			"assignees": [{"username": "jdoe"}],
+			"references": {"full": "group/project#12"}
+		}`))
+	}))
+	defer server.Close()
+	th.App.config.GitLabURL = server.URL
+	th.App.config.GitLabAccessToken = "glpat-test"
+
+	card := bulkTestCard("card-1", "board-id", map[string]interface{}{})
+	board := &model.Board{ID: "board-id", Properties: map[string]interface{}{
+		model.BoardPropertyTrackerProvider: string(model.TrackerProviderGitLab),
+	}}
+
+	t.Run("to the tracker of the board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+		th.Store.EXPECT().GetBoard("board-id").Return(board, nil)
+		th.Store.EXPECT().GetCardGitHubLinks("card-1").Return([]*model.CardGitHubLink{}, nil)
+		th.Store.EXPECT().CreateCardGitHubLink(gomock.Any()).DoAndReturn(func(link *model.CardGitHubLink) (*model.CardGitHubLink, error) {
+			return link, nil
+		})
+
+		link, err := th.App.AddCardGitHubLink(&model.CardGitHubLink{
+			CardID:    "card-1",
+			URL:       server.URL + "/group/project/-/issues/12",
+			CreatedBy: "user-id",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, model.TrackerProviderGitLab, link.Provider)
+		assert.Equal(t, model.CardGitHubLinkKindIssue, link.Kind)
+		assert.Equal(t, "group/project", link.Project)
+		assert.Equal(t, 12, link.Number)
+		assert.Equal(t, "Crash on save", link.Title)
+		assert.Equal(t, "closed", link.State)
+		assert.Equal(t, []string{"jdoe"}, link.Assignees)
+		assert.Empty(t, link.SyncError)
+	})
+
+	t.Run("invalid URL", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+
+		_, err := th.App.AddCardGitHubLink(&model.CardGitHubLink{
+			CardID:    "card-1",
+			Provider:  model.TrackerProviderGitLab,
+			URL:       "https://github.com/owner/repo/issues/12",
+			CreatedBy: "user-id",
+		})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("tracker not configured", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+
+		_, err := th.App.AddCardGitHubLink(&model.CardGitHubLink{
+			CardID:    "card-1",
+			Provider:  model.TrackerProviderJira,
+			URL:       "https://example.atlassian.net/browse/PROJ-1",
+			CreatedBy: "user-id",
+		})
+		require.ErrorIs(t, err, tracker.ErrNotConfigured)
+	})
+}
+
+func TestSyncCardGitHubLinks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	var githubDown atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo/pulls/7", func(w http.ResponseWriter, r *http.Request) {
+		if githubDown.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(github.PRDetails{
+			Number:  7,
+			Title:   "Fix crash on save",
+			State:   "closed",
+			HTMLURL: "https://github.com/owner/repo/pull/7",
+			Merged:  true,
+			Labels:  []github.Label{{Name: "bug"}, {Name: "ready"}},
+		})
+	})
+	server := setupFakeGitHub(t, th, mux)
+	defer server.Close()
+
+	card := bulkTestCard("card-1", "board-id", map[string]interface{}{})
+	newLink := func() *model.CardGitHubLink {
+		return &model.CardGitHubLink{
+			ID:        "link-1",
+			BoardID:   "board-id",
+			CardID:    "card-1",
+			Kind:      model.CardGitHubLinkKindPullRequest,
+			Owner:     "owner",
+			Repo:      "repo",
+			Number:    7,
+			Title:     "Fix crash",
+			State:     "open",
+			Labels:    []string{"bug"},
+			Assignees: []string{},
+			SyncedAt:  1000,
+			CreatedBy: "user-id",
+		}
+	}
+
+	t.Run("refreshes the state", func(t *testing.T) {
+		var synced *model.CardGitHubLink
+		th.Store.EXPECT().GetCardGitHubLinksToSync(gomock.Any(), cardGitHubLinkSyncBatchSize).Return([]*model.CardGitHubLink{newLink()}, nil)
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil).Times(2)
+		th.Store.EXPECT().GetGitHubStatusRules("board-id").Return([]*model.GitHubStatusRule{}, nil)
+		th.Store.EXPECT().UpdateCardGitHubLinkSync(gomock.Any()).DoAndReturn(func(link *model.CardGitHubLink) error {
+			synced = link
+			return nil
+		})
+
+		require.NoError(t, th.App.SyncCardGitHubLinks())
+		require.NotNil(t, synced)
+		assert.Equal(t, "Fix crash on save", synced.Title)
+		assert.Equal(t, "closed", synced.State)
+		assert.True(t, synced.Merged)
+		assert.Equal(t, []string{"bug", "ready"}, synced.Labels)
+		assert.Greater(t, synced.SyncedAt, int64(1000))
+		assert.Empty(t, synced.SyncError)
+	})
+
+	t.Run("keeps the cached state when GitHub is unreachable", func(t *testing.T) {
+		githubDown.Store(true)
+		defer githubDown.Store(false)
+
+		var synced *model.CardGitHubLink
+		th.Store.EXPECT().GetCardGitHubLinksToSync(gomock.Any(), cardGitHubLinkSyncBatchSize).Return([]*model.CardGitHubLink{newLink()}, nil)
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+		th.Store.EXPECT().UpdateCardGitHubLinkSync(gomock.Any()).DoAndReturn(func(link *model.CardGitHubLink) error {
+			synced = link
+			return nil
+		})
+
+		require.NoError(t, th.App.SyncCardGitHubLinks())
+		require.NotNil(t, synced)
+		assert.Equal(t, "Fix crash", synced.Title)
+		assert.Equal(t, "open", synced.State)
+		assert.Equal(t, int64(1000), synced.SyncedAt)
+		assert.NotZero(t, synced.SyncAttemptAt)
+		assert.NotEmpty(t, synced.SyncError)
+	})
+
+	t.Run("deletes the links of deleted cards", func(t *testing.T) {
+		th.Store.EXPECT().GetCardGitHubLinksToSync(gomock.Any(), cardGitHubLinkSyncBatchSize).Return([]*model.CardGitHubLink{newLink()}, nil)
+		th.Store.EXPECT().GetBlock("card-1").Return(nil, model.NewErrNotFound("card-1"))
+		th.Store.EXPECT().DeleteCardGitHubLink("link-1").Return(nil)
+
+		require.NoError(t, th.App.SyncCardGitHubLinks())
+	})
+}
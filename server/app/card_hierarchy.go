@@ -0,0 +1,430 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// SetCardParent makes a card a child of another card, possibly on another
+// board, replacing its previous parent. A card cannot become its own
+// ancestor, and a hierarchy cannot be deeper than CardHierarchyMaxDepth.
+func (a *App) SetCardParent(parent *model.CardParent) (*model.CardParent, error) {
+	parent.Populate()
+	if err := parent.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := a.checkCardHierarchy(parent.CardID, parent.ParentCardID); err != nil {
+		return nil, err
+	}
+
+	previous, err := a.store.GetCardParent(parent.CardID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	created, err := a.store.SetCardParent(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.updateCardRollups(created.ParentCardID, 0)
+		if previous != nil && previous.ParentCardID != created.ParentCardID {
+			a.updateCardRollups(previous.ParentCardID, 0)
+		}
+		return nil
+	})
+	return created, nil
+}
+
+// RemoveCardParent makes a card a top-level card again.
+func (a *App) RemoveCardParent(cardID string) error {
+	parent, err := a.store.GetCardParent(cardID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.store.DeleteCardParent(cardID); err != nil {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.updateCardRollups(parent.ParentCardID, 0)
+		return nil
+	})
+	return nil
+}
+
+// GetCardParent returns the parent link of a card.
+func (a *App) GetCardParent(cardID string) (*model.CardParent, error) {
+	return a.store.GetCardParent(cardID)
+}
+
+// checkCardHierarchy returns an error if a card cannot be the child of
+// another card: the card is one of its ancestors, or the hierarchy would
+// become too deep.
+func (a *App) checkCardHierarchy(cardID string, parentCardID string) error {
+	for _, id := range []string{cardID, parentCardID} {
+		block, err := a.store.GetBlock(id)
+		if err != nil {
+			return err
+		}
+		if block.Type != model.TypeCard {
+			return model.NewErrBadRequest(fmt.Sprintf("block %s is not a card", id))
+		}
+	}
+
+	depth := 1
+	for ancestorID := parentCardID; ; depth++ {
+		if ancestorID == cardID {
+			return model.NewErrBadRequest(fmt.Sprintf("card %s cannot be the parent of card %s, which is one of its ancestors", parentCardID, cardID))
+		}
+		if depth > model.CardHierarchyMaxDepth {
+			return model.NewErrBadRequest(fmt.Sprintf("a card can have at most %d ancestors", model.CardHierarchyMaxDepth))
+		}
+		ancestor, err := a.store.GetCardParent(ancestorID)
+		if model.IsErrNotFound(err) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		ancestorID = ancestor.ParentCardID
+	}
+
+	// the card brings its own descendants along
+	levels, err := a.getCardDescendantLevels(cardID, model.CardHierarchyMaxDepth-depth+1)
+	if err != nil {
+		return err
+	}
+	if depth+levels > model.CardHierarchyMaxDepth {
+		return model.NewErrBadRequest(fmt.Sprintf("a card can have at most %d ancestors", model.CardHierarchyMaxDepth))
+	}
+	return nil
+}
+
+// getCardDescendantLevels returns the number of levels of descendants of a
+// card, stopping past a maximum.
+func (a *App) getCardDescendantLevels(cardID string, maxLevels int) (int, error) {
+	levels := 0
+	current := []string{cardID}
+	seen := map[string]bool{cardID: true}
+	for len(current) > 0 && levels <= maxLevels {
+		var next []string
+		for _, id := range current {
+			children, err := a.store.GetCardChildren(id)
+			if err != nil {
+				return 0, err
+			}
+			for _, child := range children {
+				if !seen[child.CardID] {
+					seen[child.CardID] = true
+					next = append(next, child.CardID)
+				}
+			}
+		}
+		if len(next) > 0 {
+			levels++
+		}
+		current = next
+	}
+	return levels, nil
+}
+
+// GetCardChildren returns the children of a card on the boards a user can
+// view, with their progress and the values rolled up from them.
+func (a *App) GetCardChildren(cardID string, userID string) (*model.CardChildren, error) {
+	card, err := a.GetCardByID(cardID)
+	if err != nil {
+		return nil, err
+	}
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := a.getChildCards(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the caller can view the board of the card
+	canView := map[string]bool{board.ID: true}
+	visible := make([]*model.Card, 0, len(children))
+	for _, child := range children {
+		allowed, ok := canView[child.BoardID]
+		if !ok {
+			allowed = a.permissions.HasPermissionToBoard(userID, child.BoardID, model.PermissionViewBoard)
+			canView[child.BoardID] = allowed
+		}
+		if allowed {
+			visible = append(visible, child)
+		}
+	}
+	children = visible
+
+	summary, err := a.summarizeCardChildren(board, children)
+	if err != nil {
+		return nil, err
+	}
+
+	boards := map[string]*model.Board{board.ID: board}
+	for _, child := range children {
+		childBoard, err := a.getCardBoard(child, boards)
+		if err != nil {
+			return nil, err
+		}
+		a.populateCardCode(child, childBoard)
+		a.populateCardFormulas(child, childBoard)
+	}
+
+	return &model.CardChildren{
+		CardID:   cardID,
+		Summary:  summary,
+		Children: children,
+	}, nil
+}
+
+// getChildCards returns the cards that are children of a card, leaving out
+// the deleted ones.
+func (a *App) getChildCards(cardID string) ([]*model.Card, error) {
+	links, err := a.store.GetCardChildren(cardID)
+	if err != nil {
+		return nil, err
+	}
+	childIDs := make([]string, len(links))
+	for i, link := range links {
+		childIDs[i] = link.CardID
+	}
+	return a.getCardsByIDs(childIDs)
+}
+
+// summarizeCardChildren counts the done children of a card and rolls up
+// their values into the rollup properties of the card's board. A child's
+// value is the number property with the rollup's property name on the
+// child's board.
+func (a *App) summarizeCardChildren(board *model.Board, children []*model.Card) (*model.CardChildrenSummary, error) {
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the properties of board %s: %w", board.ID, err)
+	}
+
+	summary := &model.CardChildrenSummary{
+		ChildCount: len(children),
+		Rollups:    map[string]float64{},
+	}
+	rollups := schema.RollupPropDefs()
+	values := make(map[string][]float64, len(rollups))
+
+	boards := map[string]*model.Board{board.ID: board}
+	schemas := map[string]model.PropSchema{board.ID: schema}
+	for _, child := range children {
+		childBoard, err := a.getCardBoard(child, boards)
+		if err != nil {
+			return nil, err
+		}
+		if isCardDone(childBoard, child) {
+			summary.DoneCount++
+		}
+
+		childSchema, ok := schemas[childBoard.ID]
+		if !ok {
+			if childSchema, err = model.ParsePropertySchema(childBoard); err != nil {
+				return nil, fmt.Errorf("cannot parse the properties of board %s: %w", childBoard.ID, err)
+			}
+			schemas[childBoard.ID] = childSchema
+		}
+		for _, rollup := range rollups {
+			prop, ok := childSchema.NumberPropDefByName(rollup.Rollup.PropertyName)
+			if !ok {
+				continue
+			}
+			if value, ok := model.ParseNumberValue(child.Properties[prop.ID]); ok {
+				values[rollup.ID] = append(values[rollup.ID], value)
+			}
+		}
+	}
+
+	for _, rollup := range rollups {
+		if value, ok := rollup.Rollup.Function.Compute(values[rollup.ID]); ok {
+			summary.Rollups[rollup.ID] = value
+		}
+	}
+	summary.SetCompletion()
+	return summary, nil
+}
+
+// getChildCardsForBoardViewers leaves out the children on boards that some
+// of the users who can view a board cannot view. The values rolled up from
+// the children are stored on the parent card, where all of them see them.
+func (a *App) getChildCardsForBoardViewers(board *model.Board, children []*model.Card) ([]*model.Card, error) {
+	var members []*model.BoardMember
+	membersLoaded := false
+	boards := map[string]*model.Board{board.ID: board}
+	canView := map[string]bool{board.ID: true}
+	visible := make([]*model.Card, 0, len(children))
+	for _, child := range children {
+		allowed, ok := canView[child.BoardID]
+		if !ok {
+			childBoard, err := a.getCardBoard(child, boards)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case childBoard.Type == model.BoardTypeOpen && childBoard.TeamID == board.TeamID:
+				allowed = true
+			case board.Type == model.BoardTypeOpen || board.ChannelID != "":
+				// the users of the team or of the channel can view the board
+				allowed = false
+			default:
+				if !membersLoaded {
+					if members, err = a.store.GetMembersForBoard(board.ID); err != nil {
+						return nil, err
+					}
+					membersLoaded = true
+				}
+				allowed = true
+				for _, member := range members {
+					if !a.permissions.HasPermissionToBoard(member.UserID, childBoard.ID, model.PermissionViewBoard) {
+						allowed = false
+						break
+					}
+				}
+			}
+			canView[child.BoardID] = allowed
+		}
+		if allowed {
+			visible = append(visible, child)
+		}
+	}
+	return visible, nil
+}
+
+// updateParentRollups recomputes the rollups of the parent of a changed card
+// if its properties changed, or if it was deleted or restored. It runs on the
+// block change notifier.
+func (a *App) updateParentRollups(block *model.Block, oldBlock *model.Block) {
+	if block == nil || block.Type != model.TypeCard {
+		return
+	}
+	if oldBlock != nil && block.DeleteAt == oldBlock.DeleteAt &&
+		reflect.DeepEqual(block.Fields["properties"], oldBlock.Fields["properties"]) {
+		return
+	}
+	parent, err := a.store.GetCardParent(block.ID)
+	if err != nil {
+		if !model.IsErrNotFound(err) {
+			a.logger.Warn("updateParentRollups: could not get card parent",
+				mlog.String("cardID", block.ID),
+				mlog.Err(err))
+		}
+		return
+	}
+	a.updateCardRollups(parent.ParentCardID, 0)
+}
+
+// updateCardRollups stores the values rolled up from the children of a card
+// in its rollup properties, then moves on to its parent if they changed.
+func (a *App) updateCardRollups(cardID string, depth int) {
+	if depth > model.CardHierarchyMaxDepth {
+		return
+	}
+
+	block, err := a.store.GetBlock(cardID)
+	if err != nil || block.Type != model.TypeCard || block.DeleteAt != 0 {
+		return
+	}
+	card, err := model.Block2Card(block)
+	if err != nil {
+		return
+	}
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		a.logger.Warn("updateCardRollups: could not get board",
+			mlog.String("cardID", cardID),
+			mlog.Err(err))
+		return
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil || len(schema.RollupPropDefs()) == 0 {
+		return
+	}
+
+	children, err := a.getChildCards(cardID)
+	if err != nil {
+		a.logger.Warn("updateCardRollups: could not get children",
+			mlog.String("cardID", cardID),
+			mlog.Err(err))
+		return
+	}
+	children, err = a.getChildCardsForBoardViewers(board, children)
+	if err != nil {
+		a.logger.Warn("updateCardRollups: could not check the boards of the children",
+			mlog.String("cardID", cardID),
+			mlog.Err(err))
+		return
+	}
+	summary, err := a.summarizeCardChildren(board, children)
+	if err != nil {
+		a.logger.Warn("updateCardRollups: could not summarize children",
+			mlog.String("cardID", cardID),
+			mlog.Err(err))
+		return
+	}
+
+	// only the rollups and the formulas that can depend on them are
+	// written, on top of the properties stored since the card was read.
+	updatedProperties := map[string]any{}
+	var deletedProperties []string
+	if card.Properties == nil {
+		card.Properties = map[string]any{}
+	}
+	for _, rollup := range schema.RollupPropDefs() {
+		current, hasCurrent := card.Properties[rollup.ID]
+		if value, ok := summary.Rollups[rollup.ID]; ok {
+			formatted := model.FormatNumberValue(value)
+			if !hasCurrent || current != formatted {
+				updatedProperties[rollup.ID] = formatted
+			}
+			card.Properties[rollup.ID] = formatted
+		} else if hasCurrent {
+			deletedProperties = append(deletedProperties, rollup.ID)
+			delete(card.Properties, rollup.ID)
+		}
+	}
+	if len(updatedProperties) == 0 && len(deletedProperties) == 0 {
+		return
+	}
+	model.ComputeFormulas(card, schema)
+	for _, formula := range schema.FormulaPropDefs() {
+		if value, ok := card.Properties[formula.ID]; ok {
+			updatedProperties[formula.ID] = value
+		} else {
+			deletedProperties = append(deletedProperties, formula.ID)
+		}
+	}
+
+	if err := a.store.PatchBlockProperties(cardID, updatedProperties, deletedProperties, model.SystemUserID); err != nil {
+		a.logger.Error("updateCardRollups: could not patch card",
+			mlog.String("cardID", cardID),
+			mlog.Err(err))
+		return
+	}
+	updated, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return
+	}
+	a.PopulateBlockCode(updated, board)
+	a.wsAdapter.BroadcastBlockChange(board.TeamID, updated)
+
+	if parent, err := a.store.GetCardParent(cardID); err == nil {
+		a.updateCardRollups(parent.ParentCardID, depth+1)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hierarchyTestBoard() *model.Board {
+	return &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		Code:   "EPC",
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To Do"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+			{"id": "estimate", "name": "Estimate", "type": "number"},
+			{"id": "total", "name": "Total", "type": "number", "rollup": map[string]interface{}{
+				"function": "sum", "propertyName": "Estimate",
+			}},
+		},
+	}
+}
+
+func TestSetCardParent(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := hierarchyTestBoard()
+
+	t.Run("a card cannot be the child of its descendant", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-1").Return(bulkTestCard("card-1", board.ID, nil), nil)
+		th.Store.EXPECT().GetBlock("card-3").Return(bulkTestCard("card-3", board.ID, nil), nil)
+		// card-3 is a child of card-2, which is a child of card-1
+		th.Store.EXPECT().GetCardParent("card-3").Return(&model.CardParent{CardID: "card-3", ParentCardID: "card-2"}, nil)
+		th.Store.EXPECT().GetCardParent("card-2").Return(&model.CardParent{CardID: "card-2", ParentCardID: "card-1"}, nil)
+
+		_, err := th.App.SetCardParent(&model.CardParent{CardID: "card-1", ParentCardID: "card-3", CreatedBy: "user-id"})
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("the parent must be a card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-1").Return(bulkTestCard("card-1", board.ID, nil), nil)
+		th.Store.EXPECT().GetBlock("view-1").Return(&model.Block{ID: "view-1", BoardID: board.ID, Type: model.TypeView}, nil)
+
+		_, err := th.App.SetCardParent(&model.CardParent{CardID: "card-1", ParentCardID: "view-1", CreatedBy: "user-id"})
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("too deep", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-1").Return(bulkTestCard("card-1", board.ID, nil), nil)
+		th.Store.EXPECT().GetBlock("card-2").Return(bulkTestCard("card-2", board.ID, nil), nil)
+		th.Store.EXPECT().GetCardParent("card-2").Return(nil, model.NewErrNotFound("card parent"))
+		// card-1 has a chain of descendants as long as the limit
+		for i := 0; i < model.CardHierarchyMaxDepth; i++ {
+			parentID := "card-1"
+			if i > 0 {
+				parentID = "descendant-" + string(rune('a'+i-1))
+			}
+			childID := "descendant-" + string(rune('a'+i))
+			th.Store.EXPECT().GetCardChildren(parentID).Return([]*model.CardParent{{CardID: childID, ParentCardID: parentID}}, nil).MaxTimes(1)
+		}
+		th.Store.EXPECT().GetCardChildren("descendant-j").Return(nil, nil).MaxTimes(1)
+
+		_, err := th.App.SetCardParent(&model.CardParent{CardID: "card-1", ParentCardID: "card-2", CreatedBy: "user-id"})
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("sets the parent", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-1").Return(bulkTestCard("card-1", board.ID, nil), nil)
+		th.Store.EXPECT().GetBlock("card-2").Return(bulkTestCard("card-2", board.ID, nil), nil)
+		th.Store.EXPECT().GetCardParent("card-2").Return(nil, model.NewErrNotFound("card parent"))
+		th.Store.EXPECT().GetCardChildren("card-1").Return(nil, nil)
+		th.Store.EXPECT().GetCardParent("card-1").Return(nil, model.NewErrNotFound("card parent"))
+		th.Store.EXPECT().SetCardParent(gomock.Any()).DoAndReturn(func(parent *model.CardParent) (*model.CardParent, error) {
+			assert.NotZero(t, parent.CreateAt)
+			return parent, nil
+		})
+		// the rollups of the new parent are recomputed in the background
+		th.Store.EXPECT().GetBlock("card-2").Return(nil, model.NewErrNotFound("block ID=card-2")).AnyTimes()
+
+		parent, err := th.App.SetCardParent(&model.CardParent{CardID: "card-1", ParentCardID: "card-2", CreatedBy: "user-id"})
+		require.NoError(t, err)
+		assert.Equal(t, "card-2", parent.ParentCardID)
+	})
+}
+
+func TestGetCardChildren(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := hierarchyTestBoard()
+	parent := bulkTestCard("parent", board.ID, map[string]interface{}{})
+	child1 := bulkTestCard("child-1", board.ID, map[string]interface{}{"status": "done", "estimate": "3"})
+	child2 := bulkTestCard("child-2", board.ID, map[string]interface{}{"status": "todo", "estimate": "2.5"})
+	child3 := bulkTestCard("child-3", board.ID, map[string]interface{}{"status": "todo"})
+	hidden := bulkTestCard("child-4", "other-board", map[string]interface{}{"status": "done", "estimate": "10"})
+
+	th.Store.EXPECT().GetBlock("parent").Return(parent, nil)
+	th.Store.EXPECT().GetWorklogTotals([]string{"parent"}).Return(map[string]int64{}, nil)
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetCardChildren("parent").Return([]*model.CardParent{
+		{CardID: "child-1", ParentCardID: "parent"},
+		{CardID: "child-2", ParentCardID: "parent"},
+		{CardID: "child-3", ParentCardID: "parent"},
+		{CardID: "child-4", ParentCardID: "parent"},
+	}, nil)
+	th.Store.EXPECT().GetBlocksByIDs([]string{"child-1", "child-2", "child-3", "child-4"}).Return([]*model.Block{child1, child2, child3, hidden}, nil)
+	// the user cannot view the board of the fourth child
+	th.PermissionsStore.EXPECT().GetBoard("other-board").Return(&model.Board{ID: "other-board", TeamID: "other-team"}, nil)
+	th.API.EXPECT().HasPermissionToTeam("user-id", "other-team", model.PermissionViewTeam).Return(false)
+
+	children, err := th.App.GetCardChildren("parent", "user-id")
+	require.NoError(t, err)
+	require.Len(t, children.Children, 3)
+	assert.Equal(t, "EPC-1", children.Children[0].Code)
+	assert.Equal(t, 3, children.Summary.ChildCount)
+	assert.Equal(t, 1, children.Summary.DoneCount)
+	assert.Equal(t, 33.33, children.Summary.Completion)
+	assert.Equal(t, map[string]float64{"total": 5.5}, children.Summary.Rollups)
+}
+
+func TestUpdateCardRollups(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := hierarchyTestBoard()
+	parent := bulkTestCard("parent", board.ID, map[string]interface{}{"status": "todo", "total": "1"})
+	child := bulkTestCard("child", board.ID, map[string]interface{}{"estimate": "4"})
+
+	th.Store.EXPECT().GetMembersForBoard(board.ID).Return(nil, nil).AnyTimes()
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetCardChildren("parent").Return([]*model.CardParent{{CardID: "child", ParentCardID: "parent"}}, nil).Times(2)
+	th.Store.EXPECT().GetBlocksByIDs([]string{"child"}).Return([]*model.Block{child}, nil).Times(2)
+
+	t.Run("stores the rolled up values", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("parent").Return(parent, nil)
+		th.Store.EXPECT().PatchBlockProperties("parent", map[string]any{"total": "4"}, nil, model.SystemUserID).Return(nil)
+		th.Store.EXPECT().GetBlock("parent").Return(parent, nil)
+		th.Store.EXPECT().GetCardParent("parent").Return(nil, model.NewErrNotFound("card parent"))
+
+		th.App.updateCardRollups("parent", 0)
+	})
+
+	t.Run("unchanged values are not stored", func(t *testing.T) {
+		upToDate := bulkTestCard("parent", board.ID, map[string]interface{}{"total": "4"})
+		th.Store.EXPECT().GetBlock("parent").Return(upToDate, nil)
+
+		th.App.updateCardRollups("parent", 0)
+	})
+}
+
+func TestUpdateCardRollupsHiddenChildren(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := hierarchyTestBoard()
+	board.Type = model.BoardTypePrivate
+	openBoard := &model.Board{ID: "open-board", TeamID: board.TeamID, Type: model.BoardTypeOpen, CardProperties: board.CardProperties}
+	secretBoard := &model.Board{ID: "secret-board", TeamID: "other-team", Type: model.BoardTypePrivate, CardProperties: board.CardProperties}
+	parent := bulkTestCard("parent", board.ID, map[string]interface{}{})
+
+	th.Store.EXPECT().GetBlock("parent").Return(parent, nil).Times(2)
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetBoard(openBoard.ID).Return(openBoard, nil).AnyTimes()
+	th.Store.EXPECT().GetBoard(secretBoard.ID).Return(secretBoard, nil).AnyTimes()
+	th.Store.EXPECT().GetCardChildren("parent").Return([]*model.CardParent{
+		{CardID: "child-1", ParentCardID: "parent"},
+		{CardID: "child-2", ParentCardID: "parent"},
+		{CardID: "child-3", ParentCardID: "parent"},
+	}, nil)
+	th.Store.EXPECT().GetBlocksByIDs([]string{"child-1", "child-2", "child-3"}).Return([]*model.Block{
+		bulkTestCard("child-1", board.ID, map[string]interface{}{"estimate": "4"}),
+		bulkTestCard("child-2", openBoard.ID, map[string]interface{}{"estimate": "1"}),
+		bulkTestCard("child-3", secretBoard.ID, map[string]interface{}{"estimate": "10"}),
+	}, nil)
+	th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{{BoardID: board.ID, UserID: "user-id"}}, nil).AnyTimes()
+	// a member of the board of the parent cannot view the board of the third child
+	th.PermissionsStore.EXPECT().GetBoard(secretBoard.ID).Return(secretBoard, nil)
+	th.API.EXPECT().HasPermissionToTeam("user-id", "other-team", model.PermissionViewTeam).Return(false)
+
+	th.Store.EXPECT().PatchBlockProperties("parent", map[string]any{"total": "5"}, nil, model.SystemUserID).Return(nil)
+	th.Store.EXPECT().GetCardParent("parent").Return(nil, model.NewErrNotFound("card parent"))
+
+	th.App.updateCardRollups("parent", 0)
+}
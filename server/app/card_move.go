@@ -0,0 +1,274 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+)
+
+// MoveCard moves a card and its content blocks to another board. See
+// MoveCardToBoard.
+func (a *App) MoveCard(cardID string, boardID string, userID string) (*model.Card, error) {
+	result, err := a.MoveCardToBoard(cardID, boardID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	return result.Card, nil
+}
+
+// MoveCardToBoard moves a card and its content blocks to another board,
+// keeping its ID, history and relations. Property values are moved to the
+// target board's properties and options of the same name, and the values
+// that have no place on the target board are dropped and reported. The card
+// gets a new number on the target board, and its former code stays an alias
+// of the card. A dry run only reports how the card would be moved.
+func (a *App) MoveCardToBoard(cardID string, boardID string, userID string, dryRun bool) (*model.CardMoveResult, error) {
+	oldBlock, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if oldBlock.Type != model.TypeCard {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("block %s is not a card", cardID))
+	}
+	if oldBlock.BoardID == boardID {
+		return nil, model.NewErrBadRequest("card is already in the target board")
+	}
+
+	sourceBoard, err := a.store.GetBoard(oldBlock.BoardID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get board: %w", err)
+	}
+	targetBoard, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get target board: %w", err)
+	}
+
+	sourceSchema, err := model.ParsePropertySchema(sourceBoard)
+	if err != nil {
+		return nil, err
+	}
+	targetSchema, err := model.ParsePropertySchema(targetBoard)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.CardMoveResult{
+		DryRun:   dryRun,
+		Mapped:   []model.CardMovePropertyMapping{},
+		Unmapped: []model.CardMoveUnmappedField{},
+	}
+	if sourceBoard.Code != "" && oldBlock.Number > 0 {
+		result.PreviousCode = fmt.Sprintf("%s-%d", sourceBoard.Code, oldBlock.Number)
+	}
+
+	moved := *oldBlock
+	moved.Fields = make(map[string]interface{}, len(oldBlock.Fields))
+	for key, value := range oldBlock.Fields {
+		moved.Fields[key] = value
+	}
+	if properties, ok := oldBlock.Fields["properties"].(map[string]interface{}); ok {
+		moved.Fields["properties"], result.Mapped, result.Unmapped = mapCardProperties(properties, sourceSchema, targetSchema)
+	}
+	model.ComputeBlockFormulas(&moved, targetSchema)
+
+	if dryRun {
+		moved.BoardID = boardID
+		moved.Number = 0
+		if result.Card, err = model.Block2Card(&moved); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	nextNumber, err := a.store.GetNextCardNumber(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get next card number: %w", err)
+	}
+	moved.Number = nextNumber
+
+	if err := a.store.MoveCardToBoard(&moved, boardID, userID); err != nil {
+		return nil, fmt.Errorf("cannot move card %s: %w", cardID, err)
+	}
+
+	block, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	children, err := a.store.GetBlocks(model.QueryBlocksOptions{BoardID: boardID, ParentID: cardID})
+	if err != nil {
+		return nil, err
+	}
+
+	a.PopulateBlockCode(block, targetBoard)
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBlockDelete(sourceBoard.TeamID, cardID, sourceBoard.ID)
+		a.wsAdapter.BroadcastBlockChange(targetBoard.TeamID, block)
+		for _, child := range children {
+			a.wsAdapter.BroadcastBlockChange(targetBoard.TeamID, child)
+		}
+		a.webhook.NotifyUpdate(block)
+		a.notifyBlockChanged(notify.Update, block, oldBlock, userID)
+		a.updateParentRollups(block, oldBlock)
+		return nil
+	})
+
+	if result.Card, err = model.Block2Card(block); err != nil {
+		return nil, err
+	}
+	a.populateCardCode(result.Card, targetBoard)
+
+	return result, nil
+}
+
+// mapCardProperties moves the property values of a card to the properties of
+// a target board schema with the same name and type, or else the same ID.
+// Select values move to the options with the same value. It returns the
+// moved values, the properties that were moved and the values dropped.
+func mapCardProperties(properties map[string]interface{}, source model.PropSchema, target model.PropSchema) (map[string]interface{}, []model.CardMovePropertyMapping, []model.CardMoveUnmappedField) {
+	mapped := make(map[string]interface{}, len(properties))
+	mappings := []model.CardMovePropertyMapping{}
+	unmapped := []model.CardMoveUnmappedField{}
+
+	propIDs := make([]string, 0, len(properties))
+	for propID := range properties {
+		propIDs = append(propIDs, propID)
+	}
+	sort.Slice(propIDs, func(i, j int) bool { return source[propIDs[i]].Index < source[propIDs[j]].Index })
+
+	for _, propID := range propIDs {
+		value := properties[propID]
+		sourceProp, ok := source[propID]
+		if !ok {
+			// values of properties the source board does not know only move
+			// to the target property with the same ID.
+			if sourceProp, ok = target[propID]; !ok {
+				unmapped = append(unmapped, model.CardMoveUnmappedField{
+					PropertyID: propID,
+					Value:      value,
+					Reason:     "the property no longer exists on the source board",
+				})
+				continue
+			}
+		}
+
+		targetProp, reason := findTargetProperty(sourceProp, target)
+		if reason == "" {
+			if _, taken := mapped[targetProp.ID]; taken {
+				reason = fmt.Sprintf("another property is already moved to the %s property", targetProp.Name)
+			}
+		}
+		if reason != "" {
+			unmapped = append(unmapped, model.CardMoveUnmappedField{PropertyID: propID, Name: sourceProp.Name, Value: value, Reason: reason})
+			continue
+		}
+
+		targetValue, dropped, reason := mapPropertyValue(value, sourceProp, targetProp)
+		if dropped != nil {
+			unmapped = append(unmapped, model.CardMoveUnmappedField{PropertyID: propID, Name: sourceProp.Name, Value: dropped, Reason: reason})
+		}
+		if targetValue == nil {
+			continue
+		}
+
+		mapped[targetProp.ID] = targetValue
+		mappings = append(mappings, model.CardMovePropertyMapping{
+			SourcePropertyID: propID,
+			TargetPropertyID: targetProp.ID,
+			Name:             sourceProp.Name,
+		})
+	}
+
+	return mapped, mappings, unmapped
+}
+
+// findTargetProperty returns the property of a target schema with the name
+// and type of a property, or else its ID and type. Otherwise it returns why
+// there is none.
+func findTargetProperty(sourceProp model.PropDef, target model.PropSchema) (model.PropDef, string) {
+	var found model.PropDef
+	nameTaken := false
+	for _, prop := range target {
+		if !strings.EqualFold(strings.TrimSpace(prop.Name), strings.TrimSpace(sourceProp.Name)) {
+			continue
+		}
+		nameTaken = true
+		if prop.Type == sourceProp.Type && (found.ID == "" || prop.Index < found.Index) {
+			found = prop
+		}
+	}
+	if found.ID != "" {
+		return found, ""
+	}
+
+	if prop, ok := target[sourceProp.ID]; ok && prop.Type == sourceProp.Type {
+		return prop, ""
+	}
+
+	if nameTaken {
+		return model.PropDef{}, fmt.Sprintf("the %s property of the target board is not a %s property", sourceProp.Name, sourceProp.Type)
+	}
+	return model.PropDef{}, fmt.Sprintf("the target board has no %s property", sourceProp.Name)
+}
+
+// mapPropertyValue returns the value of a property on a target property, and
+// the part of the value that is dropped with the reason. Select options are
+// matched by value.
+func mapPropertyValue(value interface{}, sourceProp model.PropDef, targetProp model.PropDef) (interface{}, interface{}, string) {
+	findOption := func(optionID string) (string, bool) {
+		option, ok := sourceProp.Options[optionID]
+		if !ok {
+			return "", false
+		}
+		for _, targetOption := range targetProp.Options {
+			if strings.EqualFold(strings.TrimSpace(targetOption.Value), strings.TrimSpace(option.Value)) {
+				return targetOption.ID, true
+			}
+		}
+		return "", false
+	}
+
+	switch sourceProp.Type {
+	case model.PropTypeSelect:
+		optionID, _ := value.(string)
+		if _, ok := sourceProp.Options[optionID]; !ok {
+			return nil, value, "the option no longer exists on the source board"
+		}
+		targetOptionID, ok := findOption(optionID)
+		if !ok {
+			return nil, value, fmt.Sprintf("the %s property of the target board has no %s option", targetProp.Name, sourceProp.Options[optionID].Value)
+		}
+		return targetOptionID, nil, ""
+
+	case model.PropTypeMultiSelect:
+		optionIDs, _ := value.([]interface{})
+		options := make([]interface{}, 0, len(optionIDs))
+		var dropped []interface{}
+		var names []string
+		for _, optionID := range optionIDs {
+			id, _ := optionID.(string)
+			if targetOptionID, ok := findOption(id); ok {
+				options = append(options, targetOptionID)
+				continue
+			}
+			dropped = append(dropped, optionID)
+			names = append(names, sourceProp.Options[id].Value)
+		}
+		var mapped interface{}
+		if len(options) > 0 {
+			mapped = options
+		}
+		if len(dropped) > 0 {
+			return mapped, dropped, fmt.Sprintf("the %s property of the target board has no %s options", targetProp.Name, strings.Join(names, ", "))
+		}
+		return mapped, nil, ""
+	}
+
+	return value, nil, ""
+}
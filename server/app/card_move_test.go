@@ -0,0 +1,163 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func moveTestBoards() (*model.Board, *model.Board) {
+	source := &model.Board{
+		ID:     "source",
+		TeamID: "team-id",
+		Code:   "SRC",
+		CardProperties: []map[string]interface{}{
+			{"id": "s-status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "s-todo", "value": "To Do"},
+				map[string]interface{}{"id": "s-blocked", "value": "Blocked"},
+			}},
+			{"id": "s-labels", "name": "Labels", "type": "multiSelect", "options": []interface{}{
+				map[string]interface{}{"id": "s-bug", "value": "Bug"},
+				map[string]interface{}{"id": "s-ui", "value": "UI"},
+			}},
+			{"id": "s-estimate", "name": "Estimate", "type": "number"},
+			{"id": "s-owner", "name": "Owner", "type": "person"},
+			{"id": "shared", "name": "Notes", "type": "text"},
+		},
+	}
+	target := &model.Board{
+		ID:     "target",
+		TeamID: "team-id",
+		Code:   "TGT",
+		CardProperties: []map[string]interface{}{
+			{"id": "t-status", "name": "status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "t-todo", "value": "to do"},
+			}},
+			{"id": "t-labels", "name": "Labels", "type": "multiSelect", "options": []interface{}{
+				map[string]interface{}{"id": "t-bug", "value": "Bug"},
+			}},
+			{"id": "t-estimate", "name": "Estimate", "type": "text"},
+			{"id": "shared", "name": "Remarks", "type": "text"},
+		},
+	}
+	return source, target
+}
+
+func TestMapCardProperties(t *testing.T) {
+	source, target := moveTestBoards()
+	sourceSchema, err := model.ParsePropertySchema(source)
+	require.NoError(t, err)
+	targetSchema, err := model.ParsePropertySchema(target)
+	require.NoError(t, err)
+
+	properties := map[string]interface{}{
+		"s-status":   "s-todo",
+		"s-labels":   []interface{}{"s-bug", "s-ui"},
+		"s-estimate": "3",
+		"s-owner":    "user-id",
+		"shared":     "keep me",
+		"gone":       "stale",
+	}
+	mapped, mappings, unmapped := mapCardProperties(properties, sourceSchema, targetSchema)
+
+	assert.Equal(t, map[string]interface{}{
+		"t-status": "t-todo",
+		"t-labels": []interface{}{"t-bug"},
+		"shared":   "keep me",
+	}, mapped)
+	assert.Equal(t, []model.CardMovePropertyMapping{
+		{SourcePropertyID: "s-status", TargetPropertyID: "t-status", Name: "Status"},
+		{SourcePropertyID: "s-labels", TargetPropertyID: "t-labels", Name: "Labels"},
+		{SourcePropertyID: "shared", TargetPropertyID: "shared", Name: "Notes"},
+	}, mappings)
+
+	reasons := make(map[string]string)
+	for _, field := range unmapped {
+		reasons[field.PropertyID] = field.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"gone":       "the property no longer exists on the source board",
+		"s-labels":   "the Labels property of the target board has no UI options",
+		"s-estimate": "the Estimate property of the target board is not a number property",
+		"s-owner":    "the target board has no Owner property",
+	}, reasons)
+
+	t.Run("select option missing on the target board", func(t *testing.T) {
+		mapped, _, unmapped := mapCardProperties(map[string]interface{}{"s-status": "s-blocked"}, sourceSchema, targetSchema)
+		assert.Empty(t, mapped)
+		require.Len(t, unmapped, 1)
+		assert.Equal(t, "the status property of the target board has no Blocked option", unmapped[0].Reason)
+	})
+}
+
+func TestMoveCardToBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+	th.Store.EXPECT().GetCardParent(gomock.Any()).Return(nil, model.NewErrNotFound("card parent")).AnyTimes()
+
+	source, target := moveTestBoards()
+	cardBlock := &model.Block{
+		ID:      "card-id",
+		BoardID: source.ID,
+		Type:    model.TypeCard,
+		Title:   "Moving card",
+		Number:  7,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"s-status": "s-todo", "s-owner": "user-id"},
+		},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(cardBlock, nil)
+		th.Store.EXPECT().GetBoard(source.ID).Return(source, nil)
+		th.Store.EXPECT().GetBoard(target.ID).Return(target, nil)
+
+		result, err := th.App.MoveCardToBoard("card-id", target.ID, "user-id", true)
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, "SRC-7", result.PreviousCode)
+		assert.Equal(t, target.ID, result.Card.BoardID)
+		assert.Equal(t, map[string]interface{}{"t-status": "t-todo"}, result.Card.Properties)
+		require.Len(t, result.Unmapped, 1)
+		assert.Equal(t, "s-owner", result.Unmapped[0].PropertyID)
+		assert.Equal(t, source.ID, cardBlock.BoardID, "the card is left in place")
+	})
+
+	t.Run("move", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(cardBlock, nil)
+		th.Store.EXPECT().GetBoard(source.ID).Return(source, nil)
+		th.Store.EXPECT().GetBoard(target.ID).Return(target, nil)
+		th.Store.EXPECT().GetNextCardNumber(target.ID).Return(int64(12), nil)
+		th.Store.EXPECT().MoveCardToBoard(gomock.Any(), target.ID, "user-id").DoAndReturn(func(card *model.Block, _ string, _ string) error {
+			assert.Equal(t, int64(12), card.Number)
+			assert.Equal(t, map[string]interface{}{"t-status": "t-todo"}, card.Fields["properties"])
+			return nil
+		})
+		movedBlock := *cardBlock
+		movedBlock.BoardID = target.ID
+		movedBlock.Number = 12
+		movedBlock.Fields = map[string]interface{}{"properties": map[string]interface{}{"t-status": "t-todo"}}
+		th.Store.EXPECT().GetBlock("card-id").Return(&movedBlock, nil)
+		th.Store.EXPECT().GetBlocks(model.QueryBlocksOptions{BoardID: target.ID, ParentID: "card-id"}).Return(nil, nil)
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return(nil, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardAndCardByID(gomock.Any()).Return(target, &movedBlock, nil).AnyTimes()
+
+		result, err := th.App.MoveCardToBoard("card-id", target.ID, "user-id", false)
+		require.NoError(t, err)
+		assert.False(t, result.DryRun)
+		assert.Equal(t, "TGT-12", result.Card.Code)
+		assert.Equal(t, "SRC-7", result.PreviousCode)
+	})
+
+	t.Run("same board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(cardBlock, nil)
+		_, err := th.App.MoveCardToBoard("card-id", source.ID, "user-id", true)
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+}
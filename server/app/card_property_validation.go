@@ -0,0 +1,78 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+// validateCardProperties checks card property values against the property
+// schema of the board, if the board asks for strict validation.
+func validateCardProperties(board *model.Board, properties map[string]any) error {
+	if len(properties) == 0 || !board.GetPropertyBool(model.BoardPropertyStrictPropertyValidation) {
+		return nil
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return fmt.Errorf("cannot parse the properties of board %s: %w", board.ID, err)
+	}
+	return model.ValidateCardProperties(properties, schema)
+}
+
+// RepairCardProperties checks the property values stored in the cards of a
+// board against its property schema, and removes the invalid ones unless
+// dryRun is set.
+func (a *App) RepairCardProperties(boardID string, userID string, dryRun bool) (*model.CardPropertyRepairResult, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the properties of board %s: %w", board.ID, err)
+	}
+
+	blocks, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.CardPropertyRepairResult{
+		BoardID: boardID,
+		DryRun:  dryRun,
+		Issues:  []*model.CardPropertyIssue{},
+	}
+	patches := &model.BlockPatchBatch{}
+	for _, block := range blocks {
+		card, err := model.Block2Card(block)
+		if err != nil {
+			return nil, err
+		}
+		result.CardsChecked++
+
+		repaired, issues := model.RepairCardProperties(card, schema)
+		if len(issues) == 0 {
+			continue
+		}
+		result.CardsWithIssues++
+		result.Issues = append(result.Issues, issues...)
+		patches.BlockIDs = append(patches.BlockIDs, card.ID)
+		patches.BlockPatches = append(patches.BlockPatches, model.BlockPatch{
+			UpdatedFields: map[string]any{"properties": repaired},
+		})
+	}
+
+	if dryRun || len(patches.BlockIDs) == 0 {
+		return result, nil
+	}
+
+	// the repair is maintenance, so it is broadcast without notifications
+	if err := a.PatchBlocksAndNotify(board.TeamID, patches, userID, true); err != nil {
+		return nil, err
+	}
+	result.CardsRepaired = len(patches.BlockIDs)
+	return result, nil
+}
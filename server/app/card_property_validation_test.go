@@ -0,0 +1,121 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validationTestBoard(strict bool) *model.Board {
+	return &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		Properties: map[string]interface{}{
+			model.BoardPropertyStrictPropertyValidation: strict,
+		},
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To Do"},
+			}},
+			{"id": "estimate", "name": "Estimate", "type": "number"},
+		},
+	}
+}
+
+func TestPatchCardPropertyValidation(t *testing.T) {
+	t.Run("strict board rejects invalid values", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		board := validationTestBoard(true)
+		card := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "todo"})
+
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		patch := &model.CardPatch{UpdatedProperties: map[string]any{"estimate": "a lot"}}
+		_, err := th.App.PatchCard(patch, "card-1", "user-id", true)
+		require.True(t, model.IsErrBadRequest(err))
+		assert.Contains(t, err.Error(), `invalid value for property "Estimate"`)
+
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		patch = &model.CardPatch{UpdatedProperties: map[string]any{"priority": "high"}}
+		_, err = th.App.PatchCard(patch, "card-1", "user-id", true)
+		require.True(t, model.IsErrBadRequest(err))
+		assert.Contains(t, err.Error(), `unknown card property "priority"`)
+	})
+
+	t.Run("other boards accept any value", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		board := validationTestBoard(false)
+		card := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "todo"})
+		patched := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "todo", "estimate": "a lot"})
+
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return(nil, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardAndCardByID(gomock.Any()).Return(board, nil, nil).AnyTimes()
+		th.Store.EXPECT().GetCardParent(gomock.Any()).Return(nil, model.NewErrNotFound("card parent")).AnyTimes()
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil).Times(2)
+		th.Store.EXPECT().PatchBlock("card-1", gomock.Any(), "user-id").Return(nil)
+		th.Store.EXPECT().GetBlock("card-1").Return(patched, nil)
+
+		patch := &model.CardPatch{UpdatedProperties: map[string]any{"estimate": "a lot"}}
+		_, err := th.App.PatchCard(patch, "card-1", "user-id", true)
+		require.NoError(t, err)
+	})
+}
+
+func TestRepairCardProperties(t *testing.T) {
+	board := validationTestBoard(false)
+	valid := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "todo", "estimate": "3"})
+	invalid := bulkTestCard("card-2", board.ID, map[string]interface{}{"status": "gone", "estimate": "3", "orphan": "x"})
+
+	t.Run("dry run only reports", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]*model.Block{valid, invalid}, nil)
+
+		result, err := th.App.RepairCardProperties(board.ID, "user-id", true)
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 2, result.CardsChecked)
+		assert.Equal(t, 1, result.CardsWithIssues)
+		assert.Equal(t, 0, result.CardsRepaired)
+		require.Len(t, result.Issues, 2)
+		assert.Equal(t, "status", result.Issues[0].PropertyID)
+		assert.Equal(t, "orphan", result.Issues[1].PropertyID)
+	})
+
+	t.Run("repair removes invalid values", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return(nil, nil).AnyTimes()
+		th.Store.EXPECT().GetCardParent(gomock.Any()).Return(nil, model.NewErrNotFound("card parent")).AnyTimes()
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]*model.Block{valid, invalid}, nil)
+		th.Store.EXPECT().GetBlocksByIDs([]string{"card-2"}).Return([]*model.Block{invalid}, nil)
+		th.Store.EXPECT().PatchBlocks(gomock.Any(), "user-id").DoAndReturn(func(patches *model.BlockPatchBatch, _ string) error {
+			require.Equal(t, []string{"card-2"}, patches.BlockIDs)
+			assert.Equal(t, map[string]any{"estimate": "3"}, patches.BlockPatches[0].UpdatedFields["properties"])
+			return nil
+		})
+		th.Store.EXPECT().GetBlock("card-2").Return(invalid, nil).AnyTimes()
+
+		result, err := th.App.RepairCardProperties(board.ID, "user-id", false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.CardsRepaired)
+	})
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// maxRecurrenceCatchUp is the number of missed occurrences of a recurrence
+// created in one run. Longer backlogs are caught up by the next runs.
+const maxRecurrenceCatchUp = 20
+
+func (a *App) GetCardRecurrence(cardID string) (*model.CardRecurrence, error) {
+	return a.store.GetCardRecurrence(cardID)
+}
+
+// SetCardRecurrence sets the recurrence of a card and schedules its next
+// occurrence after the current time.
+func (a *App) SetCardRecurrence(recurrence *model.CardRecurrence, userID string) (*model.CardRecurrence, error) {
+	block, err := a.store.GetBlock(recurrence.CardID)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != model.TypeCard {
+		return nil, model.NewErrBadRequest("block " + block.ID + " is not a card")
+	}
+
+	board, err := a.store.GetBoard(block.BoardID)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	recurrence.BoardID = block.BoardID
+	recurrence.ModifiedBy = userID
+	if recurrence.Interval == 0 {
+		recurrence.Interval = 1
+	}
+	if recurrence.StartAt == 0 {
+		recurrence.StartAt = now.UnixMilli()
+	}
+	if recurrence.TimeZone == "" {
+		recurrence.TimeZone, err = a.store.GetUserTimezone(userID)
+		if err != nil || recurrence.TimeZone == "" {
+			recurrence.TimeZone = "UTC"
+		}
+	}
+
+	if err := recurrence.IsValid(); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err := recurrence.IsValidForSchema(schema); err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+
+	recurrence.NextAt = 0
+	if next, ok := recurrence.NextOccurrence(now); ok {
+		recurrence.NextAt = next.UnixMilli()
+	}
+
+	return a.store.UpsertCardRecurrence(recurrence)
+}
+
+func (a *App) DeleteCardRecurrence(cardID string) error {
+	return a.store.DeleteCardRecurrence(cardID)
+}
+
+// CreateRecurringCards creates the cards of the occurrences that are due.
+// Occurrences missed while the server was down are created in order. Each
+// occurrence is claimed before its card is created, so that it is created
+// once across servers.
+func (a *App) CreateRecurringCards(now time.Time) error {
+	recurrences, err := a.store.GetDueCardRecurrences(now.UnixMilli())
+	if err != nil {
+		return err
+	}
+
+	merr := merror.New()
+	for _, recurrence := range recurrences {
+		if err := a.catchUpRecurrence(recurrence, now); err != nil {
+			merr.Append(fmt.Errorf("card recurrence %s: %w", recurrence.CardID, err))
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+func (a *App) catchUpRecurrence(recurrence *model.CardRecurrence, now time.Time) error {
+	for i := 0; i < maxRecurrenceCatchUp && recurrence.NextAt > 0 && recurrence.NextAt <= now.UnixMilli(); i++ {
+		occurrence := utils.GetTimeForMillis(recurrence.NextAt).In(recurrence.Location())
+
+		var nextAt int64
+		if next, ok := recurrence.NextOccurrence(occurrence); ok {
+			nextAt = next.UnixMilli()
+		}
+
+		claimed, err := a.store.UpdateCardRecurrenceNextAt(recurrence.CardID, recurrence.NextAt, nextAt)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			// another server is creating the occurrences of this recurrence.
+			return nil
+		}
+
+		if err := a.createCardOccurrence(recurrence, occurrence); err != nil {
+			if model.IsErrNotFound(err) {
+				a.logger.Info("Deleting the recurrence of a card that no longer exists", mlog.String("card_id", recurrence.CardID))
+				return a.store.DeleteCardRecurrence(recurrence.CardID)
+			}
+
+			// release the occurrence so that the next run retries it.
+			if _, releaseErr := a.store.UpdateCardRecurrenceNextAt(recurrence.CardID, nextAt, recurrence.NextAt); releaseErr != nil {
+				a.logger.Error("Cannot release card occurrence", mlog.String("card_id", recurrence.CardID), mlog.Err(releaseErr))
+			}
+			return err
+		}
+		recurrence.NextAt = nextAt
+	}
+	return nil
+}
+
+// createCardOccurrence duplicates the card of a recurrence, with a new number
+// and its date property set to the day of the occurrence.
+func (a *App) createCardOccurrence(recurrence *model.CardRecurrence, occurrence time.Time) error {
+	userID := recurrence.CreatedBy
+	if !a.permissions.HasPermissionToBoard(userID, recurrence.BoardID, model.PermissionManageBoardCards) {
+		a.logger.Warn("Skipping card occurrence, the recurrence creator cannot manage the cards of the board",
+			mlog.String("card_id", recurrence.CardID),
+			mlog.String("user_id", userID),
+		)
+		return nil
+	}
+
+	board, err := a.store.GetBoard(recurrence.BoardID)
+	if err != nil {
+		return err
+	}
+	subtree, err := a.store.GetSubTree2(board.ID, recurrence.CardID, model.QuerySubtreeOptions{})
+	if err != nil {
+		return err
+	}
+
+	// the copy gets its number in the same insert as its blocks, so a
+	// failure never leaves two cards with the same code.
+	number, err := a.store.GetNextCardNumber(board.ID)
+	if err != nil {
+		return fmt.Errorf("cannot get next card number: %w", err)
+	}
+
+	var card *model.Block
+	blocks := []*model.Block{}
+	for _, block := range subtree {
+		if block.Type == model.TypeComment {
+			continue
+		}
+		if block.ID == recurrence.CardID {
+			card = block
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	if card == nil {
+		return model.NewErrNotFound("card ID=" + recurrence.CardID)
+	}
+
+	if card.Fields == nil {
+		card.Fields = map[string]interface{}{}
+	}
+	card.Fields["isTemplate"] = false
+	card.Number = number
+	if recurrence.DatePropertyID != "" {
+		properties, _ := card.Fields["properties"].(map[string]interface{})
+		if properties == nil {
+			properties = map[string]interface{}{}
+		}
+		properties[recurrence.DatePropertyID] = model.OccurrenceDateValue(occurrence)
+		card.Fields["properties"] = properties
+	}
+	applyCardFormulas(board, card)
+
+	blocks = model.GenerateBlockIDs(append([]*model.Block{card}, blocks...), a.logger)
+	if err = a.store.InsertBlocks(blocks, userID); err != nil {
+		return fmt.Errorf("cannot insert card occurrence: %w", err)
+	}
+	if err = a.CopyAndUpdateCardFiles(board.ID, userID, blocks, false); err != nil {
+		a.logger.Error("Cannot copy the files of a card occurrence", mlog.String("card_id", recurrence.CardID), mlog.Err(err))
+	}
+
+	card = blocks[0]
+	a.PopulateBlockCode(card, board)
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		for _, block := range blocks {
+			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+		}
+		a.webhook.NotifyUpdate(card)
+		a.notifyBlockChanged(notify.Add, card, nil, userID)
+		return nil
+	})
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func TestCreateRecurringCards(t *testing.T) {
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: "team-id",
+		Code:   "OPS",
+		CardProperties: []map[string]interface{}{
+			{"id": "due", "name": "Due", "type": model.PropTypeDate},
+		},
+	}
+	source := &model.Block{
+		ID:      "card-id",
+		BoardID: board.ID,
+		Type:    model.TypeCard,
+		Title:   "Water the plants",
+		Fields:  map[string]interface{}{"isTemplate": true},
+	}
+
+	now := time.Date(2026, time.October, 17, 8, 0, 0, 0, time.UTC)
+	newRecurrence := func() *model.CardRecurrence {
+		return &model.CardRecurrence{
+			CardID:         source.ID,
+			BoardID:        board.ID,
+			Frequency:      model.RecurrenceDaily,
+			Interval:       1,
+			DatePropertyID: "due",
+			TimeZone:       "UTC",
+			StartAt:        now.AddDate(0, 0, -5).UnixMilli(),
+			// the server was down for the last two occurrences
+			NextAt:    time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC).UnixMilli(),
+			CreatedBy: "user-id",
+		}
+	}
+
+	setupPermissions := func(th *TestHelper) {
+		th.API.EXPECT().HasPermissionToTeam("user-id", board.TeamID, model.PermissionViewTeam).Return(true).AnyTimes()
+		th.API.EXPECT().HasPermissionToTeam("user-id", board.TeamID, model.PermissionManageTeam).Return(false).AnyTimes()
+		th.PermissionsStore.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.PermissionsStore.EXPECT().GetMemberForBoard(board.ID, "user-id").Return(&model.BoardMember{SchemeEditor: true}, nil).AnyTimes()
+	}
+
+	t.Run("catches up missed occurrences once", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		setupPermissions(th)
+
+		recurrence := newRecurrence()
+		th.Store.EXPECT().GetDueCardRecurrences(now.UnixMilli()).Return([]*model.CardRecurrence{recurrence}, nil)
+
+		var claims []string
+		th.Store.EXPECT().UpdateCardRecurrenceNextAt(source.ID, gomock.Any(), gomock.Any()).DoAndReturn(func(_ string, from, to int64) (bool, error) {
+			claims = append(claims, utils.GetTimeForMillis(from).UTC().Format("01-02")+">"+utils.GetTimeForMillis(to).UTC().Format("01-02"))
+			return true, nil
+		}).Times(3)
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().GetSubTree2(board.ID, source.ID, gomock.Any()).DoAndReturn(func(_, _ string, _ model.QuerySubtreeOptions) ([]*model.Block, error) {
+			card := *source
+			card.Number = 3
+			card.Fields = map[string]interface{}{"isTemplate": true}
+			return []*model.Block{
+				&card,
+				{ID: "comment-id", BoardID: board.ID, ParentID: source.ID, Type: model.TypeComment, Title: "Done"},
+				{ID: "text-id", BoardID: board.ID, ParentID: source.ID, Type: model.TypeText, Title: "Use rain water"},
+			}, nil
+		}).Times(3)
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		number := int64(10)
+		th.Store.EXPECT().GetNextCardNumber(board.ID).DoAndReturn(func(_ string) (int64, error) {
+			number++
+			return number, nil
+		}).Times(3)
+
+		var created []*model.Block
+		th.Store.EXPECT().InsertBlocks(gomock.Any(), "user-id").DoAndReturn(func(blocks []*model.Block, _ string) error {
+			require.Len(t, blocks, 2, "the comments are not copied")
+			assert.NotEqual(t, source.ID, blocks[0].ID)
+			assert.Equal(t, blocks[0].ID, blocks[1].ParentID)
+			assert.Equal(t, false, blocks[0].Fields["isTemplate"])
+			created = append(created, blocks[0])
+			return nil
+		}).Times(3)
+
+		require.NoError(t, th.App.CreateRecurringCards(now))
+
+		assert.Equal(t, []string{"10-15>10-16", "10-16>10-17", "10-17>10-18"}, claims)
+		require.Len(t, created, 3)
+		for i, card := range created {
+			day := time.Date(2026, time.October, 15+i, 0, 0, 0, 0, time.UTC)
+			assert.Equal(t, int64(11+i), card.Number)
+			assert.Equal(t, fmt.Sprintf("OPS-%d", 11+i), card.Code)
+			assert.Equal(t, fmt.Sprintf(`{"from":%d}`, day.UnixMilli()), card.Fields["properties"].(map[string]interface{})["due"])
+		}
+	})
+
+	t.Run("occurrence claimed by another server", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		recurrence := newRecurrence()
+		th.Store.EXPECT().GetDueCardRecurrences(now.UnixMilli()).Return([]*model.CardRecurrence{recurrence}, nil)
+		th.Store.EXPECT().UpdateCardRecurrenceNextAt(source.ID, recurrence.NextAt, gomock.Any()).Return(false, nil)
+
+		require.NoError(t, th.App.CreateRecurringCards(now))
+	})
+
+	t.Run("failed occurrence is released", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+		setupPermissions(th)
+
+		recurrence := newRecurrence()
+		nextAt := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC).UnixMilli()
+		th.Store.EXPECT().GetDueCardRecurrences(now.UnixMilli()).Return([]*model.CardRecurrence{recurrence}, nil)
+		th.Store.EXPECT().UpdateCardRecurrenceNextAt(source.ID, recurrence.NextAt, nextAt).Return(true, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetSubTree2(board.ID, source.ID, gomock.Any()).Return([]*model.Block{source}, nil)
+		th.Store.EXPECT().GetNextCardNumber(board.ID).Return(int64(11), nil)
+		th.Store.EXPECT().InsertBlocks(gomock.Any(), "user-id").Return(assert.AnError)
+		th.Store.EXPECT().UpdateCardRecurrenceNextAt(source.ID, nextAt, recurrence.NextAt).Return(true, nil)
+
+		require.Error(t, th.App.CreateRecurringCards(now))
+	})
+}
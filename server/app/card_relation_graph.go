@@ -0,0 +1,233 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+// relationFollower returns the card an edge leads to from a card, or an empty
+// string if the edge is not followed.
+type relationFollower func(cardID string, edge *model.CardRelationEdge) string
+
+// walkCardRelations walks the relations from a card breadth first, across
+// boards, up to maxDepth relations away or all the way if maxDepth is zero.
+// It returns the depth of each card reached and the edges followed.
+func (a *App) walkCardRelations(cardID string, maxDepth int, follow relationFollower) (map[string]int, []*model.CardRelationEdge, error) {
+	depths := map[string]int{cardID: 0}
+	edges := []*model.CardRelationEdge{}
+	seenEdges := make(map[string]bool)
+
+	frontier := []string{cardID}
+	for depth := 1; len(frontier) > 0 && (maxDepth == 0 || depth <= maxDepth); depth++ {
+		relations, err := a.store.GetCardRelationsForCards(frontier)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		inFrontier := make(map[string]bool, len(frontier))
+		for _, id := range frontier {
+			inFrontier[id] = true
+		}
+
+		var next []string
+		for _, relation := range relations {
+			edge := model.NewCardRelationEdge(relation)
+			for _, fromID := range []string{edge.FromCardID, edge.ToCardID} {
+				if !inFrontier[fromID] {
+					continue
+				}
+				toID := follow(fromID, edge)
+				if toID == "" {
+					continue
+				}
+				if !seenEdges[edge.ID] {
+					seenEdges[edge.ID] = true
+					edges = append(edges, edge)
+				}
+				if _, seen := depths[toID]; !seen {
+					depths[toID] = depth
+					next = append(next, toID)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return depths, edges, nil
+}
+
+// checkCardRelationCycle returns an error if a directional relation would
+// close a loop of relations of its type, e.g. A blocks B, B blocks C and C
+// blocks A.
+func (a *App) checkCardRelationCycle(relation *model.CardRelation) error {
+	if !relation.RelationType.IsDirectional() {
+		return nil
+	}
+
+	newEdge := model.NewCardRelationEdge(relation)
+	_, edges, err := a.walkCardRelations(newEdge.ToCardID, 0, func(cardID string, edge *model.CardRelationEdge) string {
+		if edge.ID == relation.ID || edge.RelationType != newEdge.RelationType || edge.FromCardID != cardID {
+			return ""
+		}
+		return edge.ToCardID
+	})
+	if err != nil {
+		return err
+	}
+
+	path := model.FindCardRelationPath(edges, newEdge.RelationType, newEdge.ToCardID, newEdge.FromCardID)
+	if path == nil {
+		return nil
+	}
+
+	titles, err := a.getCardTitles(path)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(path)+1)
+	for _, cardID := range append([]string{newEdge.FromCardID}, path...) {
+		names = append(names, titles[cardID])
+	}
+	return model.NewErrInvalidCardRelation(fmt.Sprintf("the relation would create a cycle of %s relations: %s",
+		newEdge.RelationType, strings.Join(names, " -> ")))
+}
+
+// GetCardBlockers returns the cards a card is waiting on: the cards that block
+// it, the cards that block those, and so on, nearest first.
+func (a *App) GetCardBlockers(cardID string) ([]*model.Card, error) {
+	depths, _, err := a.walkCardRelations(cardID, 0, func(cardID string, edge *model.CardRelationEdge) string {
+		if edge.RelationType != model.RelationTypeBlocks || edge.ToCardID != cardID {
+			return ""
+		}
+		return edge.FromCardID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	delete(depths, cardID)
+	cards, err := a.getCardsByIDs(sortedByDepth(depths))
+	if err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+// GetCardRelationGraph returns the cards related to a card up to depth
+// relations away, whatever their board, and the cards the card is waiting on.
+func (a *App) GetCardRelationGraph(cardID string, depth int) (*model.CardRelationGraph, error) {
+	if depth < 1 || depth > model.CardRelationGraphMaxDepth {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("depth must be between 1 and %d", model.CardRelationGraphMaxDepth))
+	}
+
+	depths, edges, err := a.walkCardRelations(cardID, depth, func(cardID string, edge *model.CardRelationEdge) string {
+		if edge.FromCardID == cardID {
+			return edge.ToCardID
+		}
+		return edge.FromCardID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := a.getCardsByIDs(sortedByDepth(depths))
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &model.CardRelationGraph{
+		CardID: cardID,
+		Depth:  depth,
+		Nodes:  make([]*model.CardRelationGraphNode, 0, len(cards)),
+		Edges:  make([]*model.CardRelationEdge, 0, len(edges)),
+	}
+	found := make(map[string]bool, len(cards))
+	for _, card := range cards {
+		found[card.ID] = true
+		graph.Nodes = append(graph.Nodes, &model.CardRelationGraphNode{Card: card, Depth: depths[card.ID]})
+	}
+	for _, edge := range edges {
+		if found[edge.FromCardID] && found[edge.ToCardID] {
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+
+	if graph.BlockedBy, err = a.GetCardBlockers(cardID); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// getCardsByIDs returns the cards in the order of their IDs, leaving out the
+// deleted ones.
+func (a *App) getCardsByIDs(cardIDs []string) ([]*model.Card, error) {
+	if len(cardIDs) == 0 {
+		return []*model.Card{}, nil
+	}
+
+	blocks, err := a.store.GetBlocksByIDs(cardIDs)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	byID := make(map[string]*model.Card, len(blocks))
+	for _, block := range blocks {
+		if block.Type != model.TypeCard {
+			continue
+		}
+		card, err := model.Block2Card(block)
+		if err != nil {
+			return nil, err
+		}
+		byID[card.ID] = card
+	}
+
+	cards := make([]*model.Card, 0, len(byID))
+	for _, cardID := range cardIDs {
+		if card, ok := byID[cardID]; ok {
+			cards = append(cards, card)
+		}
+	}
+	return cards, nil
+}
+
+// getCardTitles returns the titles of cards by ID, or their ID when they have
+// none.
+func (a *App) getCardTitles(cardIDs []string) (map[string]string, error) {
+	cards, err := a.getCardsByIDs(cardIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make(map[string]string, len(cardIDs))
+	for _, cardID := range cardIDs {
+		titles[cardID] = cardID
+	}
+	for _, card := range cards {
+		if card.Title != "" {
+			titles[card.ID] = card.Title
+		}
+	}
+	return titles, nil
+}
+
+// sortedByDepth returns the cards sorted by depth, then by ID.
+func sortedByDepth(depths map[string]int) []string {
+	cardIDs := make([]string, 0, len(depths))
+	for cardID := range depths {
+		cardIDs = append(cardIDs, cardID)
+	}
+	sort.Slice(cardIDs, func(i, j int) bool {
+		if depths[cardIDs[i]] != depths[cardIDs[j]] {
+			return depths[cardIDs[i]] < depths[cardIDs[j]]
+		}
+		return cardIDs[i] < cardIDs[j]
+	})
+	return cardIDs
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expectCardRelations makes the store serve a set of relations and cards.
+func expectCardRelations(th *TestHelper, relations []*model.CardRelation, cards map[string]*model.Card) {
+	th.Store.EXPECT().GetCardRelationsForCards(gomock.Any()).DoAndReturn(func(cardIDs []string) ([]*model.CardRelation, error) {
+		result := []*model.CardRelation{}
+		for _, relation := range relations {
+			for _, cardID := range cardIDs {
+				if relation.SourceCardID == cardID || relation.TargetCardID == cardID {
+					result = append(result, relation)
+					break
+				}
+			}
+		}
+		return result, nil
+	}).AnyTimes()
+
+	th.Store.EXPECT().GetBlocksByIDs(gomock.Any()).DoAndReturn(func(ids []string) ([]*model.Block, error) {
+		blocks := []*model.Block{}
+		for _, id := range ids {
+			if card, ok := cards[id]; ok {
+				blocks = append(blocks, model.Card2Block(card))
+			}
+		}
+		if len(blocks) != len(ids) {
+			return blocks, model.NewErrNotAllFound("block", ids)
+		}
+		return blocks, nil
+	}).AnyTimes()
+}
+
+func relation(id, sourceCardID string, relationType model.RelationType, targetCardID string) *model.CardRelation {
+	return &model.CardRelation{
+		ID:           id,
+		SourceCardID: sourceCardID,
+		TargetCardID: targetCardID,
+		RelationType: relationType,
+		CreatedBy:    "user-id",
+	}
+}
+
+func graphCards(boardIDs map[string]string) map[string]*model.Card {
+	cards := make(map[string]*model.Card)
+	for cardID, boardID := range boardIDs {
+		cards[cardID] = &model.Card{ID: cardID, BoardID: boardID, Title: "Card " + cardID}
+	}
+	return cards
+}
+
+func TestCheckCardRelationCycle(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	cards := graphCards(map[string]string{"a": "board-1", "b": "board-1", "c": "board-2", "d": "board-2"})
+	expectCardRelations(th, []*model.CardRelation{
+		relation("r1", "a", model.RelationTypeBlocks, "b"),
+		relation("r2", "c", model.RelationTypeIsBlockedBy, "b"),
+		relation("r3", "c", model.RelationTypeCauses, "d"),
+		relation("r4", "d", model.RelationTypeRelatesTo, "a"),
+	}, cards)
+
+	t.Run("a blocking chain cannot loop back", func(t *testing.T) {
+		err := th.App.checkCardRelationCycle(relation("", "c", model.RelationTypeBlocks, "a"))
+		require.Error(t, err)
+		assert.True(t, model.IsErrBadRequest(err))
+		assert.Equal(t, "the relation would create a cycle of blocks relations: Card c -> Card a -> Card b -> Card c", err.Error())
+	})
+
+	t.Run("the inverse type is read backwards", func(t *testing.T) {
+		err := th.App.checkCardRelationCycle(relation("", "a", model.RelationTypeIsBlockedBy, "c"))
+		require.Error(t, err)
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("a chain of another type is not a cycle", func(t *testing.T) {
+		require.NoError(t, th.App.checkCardRelationCycle(relation("", "d", model.RelationTypeCauses, "a")))
+		require.NoError(t, th.App.checkCardRelationCycle(relation("", "d", model.RelationTypeBlocks, "c")))
+	})
+
+	t.Run("symmetric relations are not checked", func(t *testing.T) {
+		require.NoError(t, th.App.checkCardRelationCycle(relation("", "c", model.RelationTypeRelatesTo, "a")))
+	})
+
+	t.Run("the updated relation is left out", func(t *testing.T) {
+		require.NoError(t, th.App.checkCardRelationCycle(relation("r1", "b", model.RelationTypeBlocks, "a")))
+	})
+}
+
+func TestGetCardRelationGraph(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	// e is deleted, and f blocks c, which blocks b, which blocks a.
+	cards := graphCards(map[string]string{"a": "board-1", "b": "board-1", "c": "board-2", "d": "board-2", "f": "board-3"})
+	expectCardRelations(th, []*model.CardRelation{
+		relation("r1", "a", model.RelationTypeIsBlockedBy, "b"),
+		relation("r2", "c", model.RelationTypeBlocks, "b"),
+		relation("r3", "a", model.RelationTypeRelatesTo, "d"),
+		relation("r4", "d", model.RelationTypeDuplicates, "e"),
+		relation("r5", "f", model.RelationTypeBlocks, "c"),
+	}, cards)
+
+	t.Run("depth 1", func(t *testing.T) {
+		graph, err := th.App.GetCardRelationGraph("a", 1)
+		require.NoError(t, err)
+
+		nodes := make(map[string]int)
+		for _, node := range graph.Nodes {
+			nodes[node.Card.ID] = node.Depth
+		}
+		assert.Equal(t, map[string]int{"a": 0, "b": 1, "d": 1}, nodes)
+		require.Len(t, graph.Edges, 2)
+		assert.Equal(t, &model.CardRelationEdge{ID: "r1", FromCardID: "b", ToCardID: "a", RelationType: model.RelationTypeBlocks}, graph.Edges[0])
+
+		blockers := []string{}
+		for _, card := range graph.BlockedBy {
+			blockers = append(blockers, card.ID)
+		}
+		assert.Equal(t, []string{"b", "c", "f"}, blockers)
+	})
+
+	t.Run("depth 2 crosses boards and skips deleted cards", func(t *testing.T) {
+		graph, err := th.App.GetCardRelationGraph("a", 2)
+		require.NoError(t, err)
+
+		nodes := make(map[string]int)
+		for _, node := range graph.Nodes {
+			nodes[node.Card.ID] = node.Depth
+		}
+		assert.Equal(t, map[string]int{"a": 0, "b": 1, "d": 1, "c": 2}, nodes)
+		assert.Len(t, graph.Edges, 3)
+	})
+
+	t.Run("invalid depth", func(t *testing.T) {
+		_, err := th.App.GetCardRelationGraph("a", 0)
+		assert.True(t, model.IsErrBadRequest(err))
+		_, err = th.App.GetCardRelationGraph("a", model.CardRelationGraphMaxDepth+1)
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+}
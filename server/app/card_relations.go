@@ -5,18 +5,24 @@ package app
 
 import (
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
-// CreateCardRelation creates a new card relation.
+// CreateCardRelation creates a new card relation. Directional relations that
+// would loop back to their source card are refused.
 func (a *App) CreateCardRelation(relation *model.CardRelation, boardID string) (*model.CardRelation, error) {
+	if err := a.checkCardRelationCycle(relation); err != nil {
+		return nil, err
+	}
+
 	createdRelation, err := a.store.CreateCardRelation(relation)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get the board for the source card to get team ID
-	board, _, err := a.store.GetBoardAndCardByID(relation.SourceCardID)
+	board, card, err := a.store.GetBoardAndCardByID(relation.SourceCardID)
 	if err != nil {
 		a.logger.Warn("CreateCardRelation: could not get board for source card",
 			mlog.String("cardID", relation.SourceCardID),
@@ -25,6 +31,8 @@ func (a *App) CreateCardRelation(relation *model.CardRelation, boardID string) (
 		createdRelation.BoardID = board.ID
 		a.blockChangeNotifier.Enqueue(func() error {
 			a.wsAdapter.BroadcastCardRelationChange(board.TeamID, createdRelation)
+			a.enqueueRelationAddedWebhookEvent(board, createdRelation)
+			a.notifyCardRelationAdded(board, card, createdRelation)
 			return nil
 		})
 	}
@@ -32,6 +40,29 @@ func (a *App) CreateCardRelation(relation *model.CardRelation, boardID string) (
 	return createdRelation, nil
 }
 
+// notifyCardRelationAdded informs the notification backends of a new relation.
+func (a *App) notifyCardRelationAdded(board *model.Board, card *model.Block, relation *model.CardRelation) {
+	if a.notifications == nil || card == nil || relation.CreatedBy == model.SystemUserID {
+		return
+	}
+
+	boardMember, _ := a.GetMemberForBoard(board.ID, relation.CreatedBy)
+	if boardMember == nil {
+		boardMember = &model.BoardMember{
+			BoardID: board.ID,
+			UserID:  relation.CreatedBy,
+		}
+	}
+
+	a.notifications.CardRelationAdded(notify.CardRelationEvent{
+		TeamID:     board.TeamID,
+		Board:      board,
+		Card:       card,
+		Relation:   relation,
+		ModifiedBy: boardMember,
+	})
+}
+
 // GetCardRelations returns all relations for a card.
 func (a *App) GetCardRelations(cardID string) ([]*model.CardRelationWithCard, error) {
 	return a.store.GetCardRelations(cardID)
@@ -44,6 +75,16 @@ func (a *App) GetCardRelation(relationID string) (*model.CardRelation, error) {
 
 // UpdateCardRelation updates an existing card relation.
 func (a *App) UpdateCardRelation(relation *model.CardRelation) (*model.CardRelation, error) {
+	existing, err := a.store.GetCardRelation(relation.ID)
+	if err != nil {
+		return nil, err
+	}
+	changed := *existing
+	changed.RelationType = relation.RelationType
+	if err = a.checkCardRelationCycle(&changed); err != nil {
+		return nil, err
+	}
+
 	updatedRelation, err := a.store.UpdateCardRelation(relation)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,95 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+// GetSchedule computes the schedule of the cards of a set of boards from the
+// date property that schedules each board and the blocks relations between
+// the cards. Relations to cards of other boards are left out.
+func (a *App) GetSchedule(boardIDs []string) (*model.CardSchedule, error) {
+	schedule := &model.CardSchedule{
+		BoardIDs:           boardIDs,
+		Cards:              []*model.CardScheduleEntry{},
+		Dependencies:       []*model.CardScheduleDependency{},
+		UnscheduledCardIDs: []string{},
+	}
+
+	scheduled := make(map[string]bool)
+	for _, boardID := range boardIDs {
+		board, err := a.store.GetBoard(boardID)
+		if err != nil {
+			return nil, err
+		}
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse the properties of board %s: %w", boardID, err)
+		}
+		dateProp, hasDateProp := schema.SchedulePropDef(board)
+
+		blocks, err := a.store.GetBlocksWithType(boardID, string(model.TypeCard))
+		if err != nil {
+			return nil, err
+		}
+		for _, block := range blocks {
+			card, err := model.Block2Card(block)
+			if err != nil {
+				return nil, err
+			}
+
+			var start, finish int64
+			ok := false
+			if hasDateProp {
+				start, finish, ok = model.GetCardScheduleDates(card, dateProp.ID)
+			}
+			if !ok {
+				schedule.UnscheduledCardIDs = append(schedule.UnscheduledCardIDs, card.ID)
+				continue
+			}
+
+			scheduled[card.ID] = true
+			schedule.Cards = append(schedule.Cards, &model.CardScheduleEntry{
+				CardID:        card.ID,
+				BoardID:       card.BoardID,
+				Title:         card.Title,
+				PropertyID:    dateProp.ID,
+				PlannedStart:  start,
+				PlannedFinish: finish,
+			})
+		}
+	}
+
+	cardIDs := make([]string, 0, len(schedule.Cards))
+	for _, entry := range schedule.Cards {
+		cardIDs = append(cardIDs, entry.CardID)
+	}
+	relations, err := a.store.GetCardRelationsForCards(cardIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, relation := range relations {
+		edge := model.NewCardRelationEdge(relation)
+		if edge.RelationType != model.RelationTypeBlocks || !scheduled[edge.FromCardID] || !scheduled[edge.ToCardID] {
+			continue
+		}
+		schedule.Dependencies = append(schedule.Dependencies, &model.CardScheduleDependency{
+			RelationID:    edge.ID,
+			BlockerCardID: edge.FromCardID,
+			CardID:        edge.ToCardID,
+		})
+	}
+
+	if err := schedule.ComputeSchedule(); err != nil {
+		if errors.Is(err, model.ErrScheduleCycle) {
+			return nil, model.NewErrBadRequest(err.Error())
+		}
+		return nil, err
+	}
+	return schedule, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSchedule(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	dateProperties := []map[string]interface{}{
+		{"id": "due", "name": "Due", "type": "date"},
+		{"id": "planned", "name": "Planned", "type": "date"},
+	}
+	roadmap := &model.Board{
+		ID:             "roadmap",
+		Properties:     map[string]interface{}{model.BoardPropertySchedulePropertyID: "planned"},
+		CardProperties: dateProperties,
+	}
+	backlog := &model.Board{ID: "backlog", CardProperties: dateProperties}
+
+	cardBlock := func(cardID, boardID string, properties map[string]interface{}) *model.Block {
+		return model.Card2Block(&model.Card{ID: cardID, BoardID: boardID, Title: cardID, Properties: properties})
+	}
+
+	th.Store.EXPECT().GetBoard("roadmap").Return(roadmap, nil)
+	th.Store.EXPECT().GetBoard("backlog").Return(backlog, nil)
+	th.Store.EXPECT().GetBlocksWithType("roadmap", "card").Return([]*model.Block{
+		cardBlock("design", "roadmap", map[string]interface{}{"planned": `{"from":86400000}`, "due": `{"from":864000000}`}),
+		cardBlock("launch", "roadmap", map[string]interface{}{"planned": `{"from":172800000}`}),
+		cardBlock("idea", "roadmap", map[string]interface{}{"due": `{"from":86400000}`}),
+	}, nil)
+	th.Store.EXPECT().GetBlocksWithType("backlog", "card").Return([]*model.Block{
+		cardBlock("api", "backlog", map[string]interface{}{"due": `{"from":86400000}`}),
+	}, nil)
+	th.Store.EXPECT().GetCardRelationsForCards(gomock.InAnyOrder([]string{"design", "launch", "api"})).Return([]*model.CardRelation{
+		relation("r1", "launch", model.RelationTypeIsBlockedBy, "api"),
+		relation("r2", "design", model.RelationTypeBlocks, "launch"),
+		relation("r3", "design", model.RelationTypeRelatesTo, "api"),
+		relation("r4", "design", model.RelationTypeBlocks, "outside"),
+	}, nil)
+
+	schedule, err := th.App.GetSchedule([]string{"roadmap", "backlog"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"idea"}, schedule.UnscheduledCardIDs)
+	require.Len(t, schedule.Cards, 3)
+	require.Len(t, schedule.Dependencies, 2)
+	assert.Equal(t, "api", schedule.Dependencies[0].BlockerCardID)
+	assert.Equal(t, "launch", schedule.Dependencies[0].CardID)
+	assert.Equal(t, []string{"api", "design", "launch"}, schedule.CriticalPath)
+	assert.Equal(t, "launch", schedule.Cards[2].CardID)
+	assert.Equal(t, "planned", schedule.Cards[2].PropertyID)
+	assert.Equal(t, int64(172800000), schedule.Cards[2].EarliestStart)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+// SearchCardsForUser runs a card query against all the boards of a team that
+// the user can view, and returns the matching cards with their codes.
+func (a *App) SearchCardsForUser(teamID, userID, term string, includePublicBoards bool, page, perPage int) ([]*model.Card, error) {
+	query, err := model.ParseCardQuery(term)
+	if err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if query.IsEmpty() {
+		return []*model.Card{}, nil
+	}
+
+	boards, err := a.getSearchableBoards(teamID, userID, includePublicBoards)
+	if err != nil {
+		return nil, err
+	}
+	boardsByID := make(map[string]*model.Board, len(boards))
+	for _, board := range boards {
+		boardsByID[board.ID] = board
+	}
+
+	search, err := query.Resolve(boards, a.cardQueryUserResolver(userID))
+	if errors.Is(err, model.ErrInvalidCardQuery) {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(search.Boards) == 0 {
+		return []*model.Card{}, nil
+	}
+
+	blocks, err := a.store.SearchCards(search, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]*model.Card, 0, len(blocks))
+	for _, block := range blocks {
+		card, err := model.Block2Card(block)
+		if err != nil {
+			return nil, fmt.Errorf("Block2Card fail: %w", err)
+		}
+		if board, ok := boardsByID[card.BoardID]; ok {
+			a.populateCardCode(card, board)
+			a.populateCardFormulas(card, board)
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// getSearchableBoards returns the boards of a team, templates excluded, that
+// the user has permission to view.
+func (a *App) getSearchableBoards(teamID, userID string, includePublicBoards bool) ([]*model.Board, error) {
+	teamBoards, err := a.store.GetBoardsForUserAndTeam(userID, teamID, includePublicBoards)
+	if err != nil {
+		return nil, err
+	}
+
+	boards := make([]*model.Board, 0, len(teamBoards))
+	for _, board := range teamBoards {
+		if board.IsTemplate || !a.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionViewBoard) {
+			continue
+		}
+		boards = append(boards, board)
+	}
+	return boards, nil
+}
+
+// cardQueryUserResolver resolves the person values of a card query, either
+// `@me` or a username with or without the leading `@`.
+func (a *App) cardQueryUserResolver(userID string) model.CardQueryUserResolver {
+	return func(value string) (string, error) {
+		if strings.EqualFold(value, model.CardQueryUserMe) {
+			return userID, nil
+		}
+
+		user, err := a.store.GetUserByUsername(strings.TrimPrefix(value, "@"))
+		if model.IsErrNotFound(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		return user.ID, nil
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func TestSearchCardsForUser(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	teamID := utils.NewID(utils.IDTypeTeam)
+	makeBoard := func(code string) *model.Board {
+		return &model.Board{
+			ID:     utils.NewID(utils.IDTypeBoard),
+			TeamID: teamID,
+			Code:   code,
+			CardProperties: []map[string]interface{}{
+				{
+					"id":   "status",
+					"name": "Status",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": "done", "value": "Done"},
+					},
+				},
+			},
+		}
+	}
+	visibleBoard := makeBoard("AB")
+	hiddenBoard := makeBoard("CD")
+
+	t.Run("only searches boards the user can view", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardsForUserAndTeam(userID, teamID, true).Return([]*model.Board{visibleBoard, hiddenBoard}, nil)
+		th.API.EXPECT().HasPermissionToTeam(userID, teamID, model.PermissionViewTeam).Return(true).Times(2)
+		th.API.EXPECT().HasPermissionToTeam(userID, teamID, model.PermissionManageTeam).Return(false)
+		th.PermissionsStore.EXPECT().GetBoard(visibleBoard.ID).Return(visibleBoard, nil)
+		th.PermissionsStore.EXPECT().GetMemberForBoard(visibleBoard.ID, userID).Return(&model.BoardMember{SchemeViewer: true}, nil)
+		th.PermissionsStore.EXPECT().GetBoard(hiddenBoard.ID).Return(hiddenBoard, nil)
+		th.PermissionsStore.EXPECT().GetMemberForBoard(hiddenBoard.ID, userID).Return(nil, model.NewErrNotFound("member"))
+
+		expectedSearch := &model.CardSearch{
+			Boards: []model.CardSearchBoard{{
+				BoardID:    visibleBoard.ID,
+				Properties: []model.CardSearchProperty{{PropertyID: "status", Match: model.CardSearchMatchEquals, Values: []string{"done"}}},
+			}},
+			Text: []string{"release"},
+		}
+		cardBlock := &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: visibleBoard.ID,
+			Type:    model.TypeCard,
+			Title:   "Release notes",
+			Number:  7,
+			Fields:  map[string]interface{}{"properties": map[string]interface{}{"status": "done"}},
+		}
+		th.Store.EXPECT().SearchCards(gomock.Eq(expectedSearch), 0, 100).Return([]*model.Block{cardBlock}, nil)
+
+		cards, err := th.App.SearchCardsForUser(teamID, userID, "status:done release", true, 0, 100)
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		assert.Equal(t, cardBlock.ID, cards[0].ID)
+		assert.Equal(t, "AB-7", cards[0].Code)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		cards, err := th.App.SearchCardsForUser(teamID, userID, `status:"done`, true, 0, 100)
+		require.True(t, model.IsErrBadRequest(err))
+		require.Nil(t, cards)
+	})
+
+	t.Run("no matching board skips the store search", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardsForUserAndTeam(userID, teamID, false).Return([]*model.Board{}, nil)
+
+		cards, err := th.App.SearchCardsForUser(teamID, userID, "status:done", false, 0, 100)
+		require.NoError(t, err)
+		require.Empty(t, cards)
+	})
+}
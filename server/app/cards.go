@@ -9,6 +9,8 @@ import (
 
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
 	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
 )
 
 func (a *App) CreateCard(card *model.Card, boardID string, userID string, disableNotify bool) (*model.Card, error) {
@@ -23,6 +25,16 @@ func (a *App) CreateCard(card *model.Card, boardID string, userID string, disabl
 	card.UpdateAt = now
 	card.DeleteAt = 0
 
+	// Get board to populate card code
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get board: %w", err)
+	}
+
+	if err := validateCardProperties(board, card.Properties); err != nil {
+		return nil, err
+	}
+
 	// Get the next card number for this board
 	nextNumber, err := a.store.GetNextCardNumber(boardID)
 	if err != nil {
@@ -30,12 +42,6 @@ func (a *App) CreateCard(card *model.Card, boardID string, userID string, disabl
 	}
 	card.Number = nextNumber
 
-	// Get board to populate card code
-	board, err := a.store.GetBoard(boardID)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get board: %w", err)
-	}
-
 	block := model.Card2Block(card)
 
 	newBlocks, err := a.InsertBlocksAndNotify([]*model.Block{block}, userID, disableNotify)
@@ -71,6 +77,10 @@ func (a *App) GetCardsForBoard(boardID string, page int, perPage int) ([]*model.
 	if err != nil {
 		return nil, fmt.Errorf("cannot get board: %w", err)
 	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the properties of board %s: %w", board.ID, err)
+	}
 
 	cards := make([]*model.Card, 0, len(blocks))
 	for _, blk := range blocks {
@@ -79,9 +89,11 @@ func (a *App) GetCardsForBoard(boardID string, page int, perPage int) ([]*model.
 			return nil, fmt.Errorf("Block2Card fail: %w", err)
 		} else {
 			a.populateCardCode(card, board)
+			model.ComputeFormulas(card, schema)
 			cards = append(cards, card)
 		}
 	}
+	a.populateCardsTimeSpent(cards...)
 	return cards, nil
 }
 
@@ -109,9 +121,13 @@ func (a *App) PatchCard(cardPatch *model.CardPatch, cardID string, userID string
 		return nil, fmt.Errorf("cannot get board: %w", err)
 	}
 
+	if err := validateCardProperties(board, cardPatch.UpdatedProperties); err != nil {
+		return nil, err
+	}
+
 	// Validate status transitions if properties are being updated
 	if len(cardPatch.UpdatedProperties) > 0 {
-		if validationErr := a.validateStatusTransitions(board, currentCard, cardPatch); validationErr != nil {
+		if validationErr := a.validateStatusTransitions(board, currentCard, cardPatch, userID); validationErr != nil {
 			return nil, validationErr
 		}
 	}
@@ -120,6 +136,17 @@ func (a *App) PatchCard(cardPatch *model.CardPatch, cardID string, userID string
 	if err != nil {
 		return nil, err
 	}
+	if len(cardPatch.UpdatedProperties) > 0 {
+		blockPatch.UpdatedFields["properties"] = mergeCardProperties(currentCard, cardPatch.UpdatedProperties)
+	}
+
+	// the comment goes first, so a transition that requires it is never
+	// stored without it.
+	if cardPatch.Comment != nil && strings.TrimSpace(*cardPatch.Comment) != "" {
+		if err := a.addCardComment(board, cardID, *cardPatch.Comment, userID, disableNotify); err != nil {
+			return nil, fmt.Errorf("cannot add comment to card %s: %w", cardID, err)
+		}
+	}
 
 	newBlock, err := a.PatchBlockAndNotify(cardID, blockPatch, userID, disableNotify)
 	if err != nil {
@@ -136,6 +163,39 @@ func (a *App) PatchCard(cardPatch *model.CardPatch, cardID string, userID string
 	return newCard, nil
 }
 
+// mergeCardProperties returns the properties of a card with the updated ones
+// applied. A block patch replaces the properties field of the card as a
+// whole, so the properties a card patch doesn't touch are carried over.
+func mergeCardProperties(card *model.Card, updated map[string]any) map[string]any {
+	properties := make(map[string]any, len(card.Properties)+len(updated))
+	for propID, value := range card.Properties {
+		properties[propID] = value
+	}
+	for propID, value := range updated {
+		properties[propID] = value
+	}
+	return properties
+}
+
+// addCardComment adds a comment block to a card.
+func (a *App) addCardComment(board *model.Board, cardID string, text string, userID string, disableNotify bool) error {
+	now := utils.GetMillis()
+	comment := &model.Block{
+		ID:         utils.NewID(utils.IDTypeBlock),
+		ParentID:   cardID,
+		BoardID:    board.ID,
+		CreatedBy:  userID,
+		ModifiedBy: userID,
+		Schema:     1,
+		Type:       model.TypeComment,
+		Title:      text,
+		Fields:     map[string]interface{}{},
+		CreateAt:   now,
+		UpdateAt:   now,
+	}
+	return a.InsertBlockAndNotify(comment, userID, disableNotify)
+}
+
 func (a *App) GetCardByID(cardID string) (*model.Card, error) {
 	cardBlock, err := a.GetBlockByID(cardID)
 	if err != nil {
@@ -153,6 +213,8 @@ func (a *App) GetCardByID(cardID string) (*model.Card, error) {
 		return nil, fmt.Errorf("cannot get board: %w", err)
 	}
 	a.populateCardCode(card, board)
+	a.populateCardFormulas(card, board)
+	a.populateCardsTimeSpent(card)
 
 	return card, nil
 }
@@ -169,6 +231,8 @@ func (a *App) GetCardByCode(code string) (*model.Card, *model.Board, string, err
 	}
 
 	a.populateCardCode(card, board)
+	a.populateCardFormulas(card, board)
+	a.populateCardsTimeSpent(card)
 
 	// Get first view for the board
 	views, err := a.store.GetBlocksWithType(board.ID, string(model.TypeView))
@@ -184,101 +248,216 @@ func (a *App) GetCardByCode(code string) (*model.Card, *model.Board, string, err
 	return card, board, viewID, nil
 }
 
-// validateStatusTransitions checks if status property changes are allowed based on transition rules.
-func (a *App) validateStatusTransitions(board *model.Board, currentCard *model.Card, cardPatch *model.CardPatch) error {
-	// Find status properties in the board's card properties
-	for _, cardProp := range board.CardProperties {
-		propID, ok := cardProp["id"].(string)
-		if !ok || propID == "" {
-			continue
-		}
+// validateStatusTransitions checks that a change of the workflow property of
+// a card meets the conditions of the board's transition rules, and that the
+// card is not blocked when it moves to a done status, if the board asks so.
+func (a *App) validateStatusTransitions(board *model.Board, currentCard *model.Card, cardPatch *model.CardPatch, userID string) error {
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return fmt.Errorf("cannot parse the properties of board %s: %w", board.ID, err)
+	}
+	workflowProp, ok := schema.WorkflowPropDef(board)
+	if !ok {
+		return nil
+	}
+	propID := workflowProp.ID
 
-		propType, _ := cardProp["type"].(string)
-		propName, _ := cardProp["name"].(string)
+	// Check if this property is being updated in the patch
+	newValue, isBeingUpdated := cardPatch.UpdatedProperties[propID]
+	if !isBeingUpdated {
+		return nil
+	}
 
-		// Check if this is a select-type property named "Status" (case-insensitive)
-		if propType != "select" {
-			continue
-		}
+	// Get the current value of this property
+	currentValue, hasCurrentValue := currentCard.Properties[propID]
 
-		// Only validate properties named "Status" (case-insensitive)
-		if strings.ToLower(propName) != "status" {
-			continue
-		}
+	// If newValue is nil/null, this is clearing the status, which is always allowed
+	if newValue == nil {
+		return nil
+	}
 
-		// Check if this property is being updated in the patch
-		newValue, isBeingUpdated := cardPatch.UpdatedProperties[propID]
-		if !isBeingUpdated {
-			continue
+	// Convert values to strings (they should be option IDs)
+	fromStatus := ""
+	if hasCurrentValue {
+		if fromStatus, ok = currentValue.(string); !ok {
+			return model.NewErrBadRequest("Current status property value must be a string")
 		}
+	}
+
+	toStatus, ok := newValue.(string)
+	if !ok {
+		return model.NewErrBadRequest("New status property value must be a string")
+	}
 
-		// Get the current value of this property
-		currentValue, hasCurrentValue := currentCard.Properties[propID]
+	// Skip if the value isn't actually changing
+	if fromStatus == toStatus {
+		return nil
+	}
 
-		// If there's no current value, this is setting an initial value, which is always allowed
-		if !hasCurrentValue {
-			continue
-		}
+	transitionErr := &model.ErrStatusTransition{
+		FromStatus: a.getStatusOptionValue(workflowProp, fromStatus),
+		ToStatus:   a.getStatusOptionValue(workflowProp, toStatus),
+	}
 
-		// If newValue is nil/null, this is clearing the status, which is always allowed
-		if newValue == nil {
-			continue
+	// a card without a status only matches the rules of entering the new
+	// status from any status.
+	if err := a.checkStatusTransitionRules(board, schema, currentCard, cardPatch, userID, fromStatus, toStatus, transitionErr); err != nil {
+		return err
+	}
+
+	if board.GetPropertyBool(model.BoardPropertyBlockDoneWhileBlocked) && workflowProp.IsDoneOption(toStatus) {
+		blockers, err := a.getOpenCardBlockers(currentCard.ID, map[string]*model.Board{board.ID: board})
+		if err != nil {
+			return fmt.Errorf("error checking status transition: %w", err)
+		}
+		if len(blockers) > 0 {
+			ids := make([]string, len(blockers))
+			titles := make([]string, len(blockers))
+			for i, blocker := range blockers {
+				ids[i] = blocker.ID
+				titles[i] = blocker.Title
+			}
+			transitionErr.Failures = append(transitionErr.Failures, model.StatusTransitionFailure{
+				Condition:       model.StatusTransitionConditionBlocked,
+				BlockingCardIDs: ids,
+				Message:         "the card is blocked by " + strings.Join(titles, ", "),
+			})
 		}
+	}
 
-		// Convert values to strings (they should be option IDs)
-		fromStatus, ok1 := currentValue.(string)
-		toStatus, ok2 := newValue.(string)
+	if len(transitionErr.Failures) > 0 {
+		return transitionErr
+	}
+	return nil
+}
 
-		if !ok1 {
-			return model.NewErrBadRequest("Current status property value must be a string")
-		}
+// checkStatusTransitionRules adds to a transition error the conditions of
+// the board's transition rules that a card change does not meet. It returns
+// the error right away if the transition is not allowed at all.
+func (a *App) checkStatusTransitionRules(board *model.Board, schema model.PropSchema, currentCard *model.Card, cardPatch *model.CardPatch,
+	userID string, fromStatus string, toStatus string, transitionErr *model.ErrStatusTransition) error {
+	allRules, err := a.store.GetStatusTransitionRules(board.ID)
+	if err != nil {
+		return fmt.Errorf("error checking status transition: %w", err)
+	}
+	rules := model.GetStatusTransitionRules(allRules, fromStatus, toStatus)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	// the rule of the transition itself takes precedence over the rule of
+	// entering the status from any status.
+	if !rules[len(rules)-1].Allowed {
+		transitionErr.Failures = append(transitionErr.Failures, model.StatusTransitionFailure{
+			Condition: model.StatusTransitionConditionAllowed,
+			Message:   "the transition is not allowed",
+		})
+		return transitionErr
+	}
+
+	checkedProps := make(map[string]bool)
+	for _, rule := range rules {
+		for _, requiredPropID := range rule.RequiredProperties {
+			if checkedProps[requiredPropID] {
+				continue
+			}
+			checkedProps[requiredPropID] = true
 
-		if !ok2 {
-			return model.NewErrBadRequest("New status property value must be a string")
+			value, ok := cardPatch.UpdatedProperties[requiredPropID]
+			if !ok {
+				value = currentCard.Properties[requiredPropID]
+			}
+			if isEmptyPropertyValue(value) {
+				name := requiredPropID
+				if prop, ok := schema[requiredPropID]; ok {
+					name = prop.Name
+				}
+				transitionErr.Failures = append(transitionErr.Failures, model.StatusTransitionFailure{
+					Condition:  model.StatusTransitionConditionRequiredProperty,
+					PropertyID: requiredPropID,
+					Message:    name + " is required",
+				})
+			}
 		}
+	}
 
-		// Skip if the value isn't actually changing
-		if fromStatus == toStatus {
+	for _, rule := range rules {
+		if len(rule.AllowedRoles) == 0 {
 			continue
 		}
-
-		// Check if this transition is allowed
-		allowed, err := a.store.IsStatusTransitionAllowed(board.ID, fromStatus, toStatus)
-		if err != nil {
-			return fmt.Errorf("error checking status transition: %w", err)
+		if !a.hasBoardRole(board.ID, userID, rule.AllowedRoles) {
+			transitionErr.Failures = append(transitionErr.Failures, model.StatusTransitionFailure{
+				Condition: model.StatusTransitionConditionRole,
+				Message:   "only the " + joinBoardRoles(rule.AllowedRoles) + " of the board can make the transition",
+			})
+			break
 		}
+	}
 
-		if !allowed {
-			// Get the option values for better error message
-			fromStatusValue := a.getStatusOptionValue(cardProp, fromStatus)
-			toStatusValue := a.getStatusOptionValue(cardProp, toStatus)
-
-			return model.NewErrBadRequest(fmt.Sprintf("The %s card can't move to the %s status.", fromStatusValue, toStatusValue))
+	for _, rule := range rules {
+		if rule.RequireComment && (cardPatch.Comment == nil || strings.TrimSpace(*cardPatch.Comment) == "") {
+			transitionErr.Failures = append(transitionErr.Failures, model.StatusTransitionFailure{
+				Condition: model.StatusTransitionConditionComment,
+				Message:   "a comment is required",
+			})
+			break
 		}
 	}
-
 	return nil
 }
 
-// getStatusOptionValue retrieves the display value for a status option ID.
-func (a *App) getStatusOptionValue(cardProp map[string]interface{}, optionID string) string {
-	options, ok := cardProp["options"].([]interface{})
-	if !ok {
-		return optionID
-	}
-
-	for _, opt := range options {
-		optMap, ok := opt.(map[string]interface{})
-		if !ok {
+// hasBoardRole returns true if a user has one of the roles on a board. The
+// roles are resolved through the permissions of the user on the board, so
+// implicit members and team admins get the roles they are granted.
+func (a *App) hasBoardRole(boardID string, userID string, roles []model.BoardRole) bool {
+	for _, role := range roles {
+		var permission *mm_model.Permission
+		switch role {
+		case model.BoardRoleAdmin:
+			permission = model.PermissionManageBoardRoles
+		case model.BoardRoleEditor:
+			permission = model.PermissionManageBoardCards
+		case model.BoardRoleCommenter:
+			permission = model.PermissionCommentBoardCards
+		case model.BoardRoleViewer:
+			permission = model.PermissionViewBoard
+		default:
 			continue
 		}
-
-		if id, ok := optMap["id"].(string); ok && id == optionID {
-			if value, ok := optMap["value"].(string); ok {
-				return value
-			}
+		if a.permissions.HasPermissionToBoard(userID, boardID, permission) {
+			return true
 		}
 	}
+	return false
+}
+
+func joinBoardRoles(roles []model.BoardRole) string {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role) + "s"
+	}
+	return strings.Join(names, " and ")
+}
+
+// isEmptyPropertyValue returns true if a card property has no value.
+func isEmptyPropertyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	case []string:
+		return len(v) == 0
+	}
+	return false
+}
 
+// getStatusOptionValue retrieves the display value for a status option ID.
+func (a *App) getStatusOptionValue(prop model.PropDef, optionID string) string {
+	if option, ok := prop.Options[optionID]; ok {
+		return option.Value
+	}
 	return optionID
 }
@@ -213,6 +213,88 @@ func TestPatchCard(t *testing.T) {
 		require.Error(t, err, "error")
 		require.Nil(t, patchedCard)
 	})
+
+	t.Run("keeps the properties it does not update", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		board := &model.Board{
+			ID: utils.NewID(utils.IDTypeBoard),
+		}
+		userID := utils.NewID(utils.IDTypeUser)
+
+		props := makeProps(3)
+		card := &model.Card{
+			ID:         utils.NewID(utils.IDTypeBlock),
+			BoardID:    board.ID,
+			CreatedBy:  userID,
+			ModifiedBy: userID,
+			Title:      "test card for patch",
+			Properties: copyProps(props),
+		}
+		var patchedID string
+		for propID := range props {
+			patchedID = propID
+			break
+		}
+
+		block := model.Card2Block(card)
+		th.Store.EXPECT().GetBlock(card.ID).DoAndReturn(func(string) (*model.Block, error) {
+			return block, nil
+		}).AnyTimes()
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().PatchBlock(card.ID, gomock.Any(), userID).DoAndReturn(func(_ string, patch *model.BlockPatch, _ string) error {
+			block = patch.Patch(block)
+			return nil
+		})
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil)
+
+		patchedCard, err := th.App.PatchCard(&model.CardPatch{
+			UpdatedProperties: map[string]any{patchedID: "patched"},
+		}, card.ID, userID, false)
+		require.NoError(t, err)
+
+		expected := copyProps(props)
+		expected[patchedID] = "patched"
+		require.EqualValues(t, expected, patchedCard.Properties)
+	})
+
+	t.Run("adds the comment before patching the card", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		board := &model.Board{
+			ID: utils.NewID(utils.IDTypeBoard),
+		}
+		userID := utils.NewID(utils.IDTypeUser)
+		card := &model.Card{
+			ID:         utils.NewID(utils.IDTypeBlock),
+			BoardID:    board.ID,
+			CreatedBy:  userID,
+			ModifiedBy: userID,
+			Title:      "test card for patch",
+			Properties: map[string]any{},
+		}
+
+		block := model.Card2Block(card)
+		th.Store.EXPECT().GetBlock(card.ID).Return(block, nil).AnyTimes()
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		gomock.InOrder(
+			th.Store.EXPECT().InsertBlock(gomock.Any(), userID).DoAndReturn(func(comment *model.Block, _ string) error {
+				require.EqualValues(t, model.TypeComment, comment.Type)
+				require.Equal(t, card.ID, comment.ParentID)
+				require.Equal(t, "approved", comment.Title)
+				return nil
+			}),
+			th.Store.EXPECT().PatchBlock(card.ID, gomock.Any(), userID).Return(nil),
+		)
+
+		comment := "approved"
+		title := "patched"
+		_, err := th.App.PatchCard(&model.CardPatch{Title: &title, Comment: &comment}, card.ID, userID, false)
+		require.NoError(t, err)
+	})
 }
 
 func TestGetCard(t *testing.T) {
@@ -368,9 +450,11 @@ func TestValidateStatusTransitions(t *testing.T) {
 			},
 		}
 
-		th.Store.EXPECT().IsStatusTransitionAllowed(boardID, statusOption1, statusOption2).Return(true, nil)
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return([]*model.StatusTransitionRule{
+			{BoardID: boardID, FromStatus: statusOption1, ToStatus: statusOption2, Allowed: true},
+		}, nil)
 
-		err := th.App.validateStatusTransitions(board, card, cardPatch)
+		err := th.App.validateStatusTransitions(board, card, cardPatch, userID)
 		require.NoError(t, err)
 	})
 
@@ -382,15 +466,16 @@ func TestValidateStatusTransitions(t *testing.T) {
 			},
 		}
 
-		th.Store.EXPECT().IsStatusTransitionAllowed(boardID, statusOption1, statusOption3).Return(false, nil)
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return([]*model.StatusTransitionRule{
+			{BoardID: boardID, FromStatus: statusOption1, ToStatus: statusOption3, Allowed: false},
+		}, nil)
 
-		err := th.App.validateStatusTransitions(board, card, cardPatch)
+		err := th.App.validateStatusTransitions(board, card, cardPatch, userID)
 		require.Error(t, err)
 		require.Contains(t, err.Error(), "can't move to")
 	})
 
-	t.Run("no current value - always allowed", func(t *testing.T) {
-		// Setting initial status is always allowed
+	t.Run("no current value - rules of entering the status", func(t *testing.T) {
 		cardWithoutStatus := &model.Card{
 			ID:         utils.NewID(utils.IDTypeBlock),
 			BoardID:    boardID,
@@ -406,9 +491,20 @@ func TestValidateStatusTransitions(t *testing.T) {
 			},
 		}
 
-		// Should not call IsStatusTransitionAllowed since there's no current value
-		err := th.App.validateStatusTransitions(board, cardWithoutStatus, cardPatch)
-		require.NoError(t, err)
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return([]*model.StatusTransitionRule{
+			{BoardID: boardID, FromStatus: statusOption2, ToStatus: statusOption1, Allowed: false},
+		}, nil)
+		require.NoError(t, th.App.validateStatusTransitions(board, cardWithoutStatus, cardPatch, userID))
+
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return([]*model.StatusTransitionRule{
+			{BoardID: boardID, FromStatus: model.StatusTransitionAnyStatus, ToStatus: statusOption1, Allowed: true, RequireComment: true},
+		}, nil)
+		err := th.App.validateStatusTransitions(board, cardWithoutStatus, cardPatch, userID)
+		var transitionErr *model.ErrStatusTransition
+		require.ErrorAs(t, err, &transitionErr)
+		require.Len(t, transitionErr.Failures, 1)
+		require.Equal(t, model.StatusTransitionConditionComment, transitionErr.Failures[0].Condition)
+		require.Equal(t, "The card can't move to the To Do status: a comment is required.", err.Error())
 	})
 
 	t.Run("same value - no validation needed", func(t *testing.T) {
@@ -419,8 +515,8 @@ func TestValidateStatusTransitions(t *testing.T) {
 			},
 		}
 
-		// Should not call IsStatusTransitionAllowed since value isn't changing
-		err := th.App.validateStatusTransitions(board, card, cardPatch)
+		// Should not call GetStatusTransitionRules since value isn't changing
+		err := th.App.validateStatusTransitions(board, card, cardPatch, userID)
 		require.NoError(t, err)
 	})
 
@@ -444,7 +540,214 @@ func TestValidateStatusTransitions(t *testing.T) {
 			},
 		}
 
-		err := th.App.validateStatusTransitions(boardWithTextProp, card, cardPatch)
+		err := th.App.validateStatusTransitions(boardWithTextProp, card, cardPatch, userID)
 		require.NoError(t, err)
 	})
 }
+
+func TestValidateStatusTransitionConditions(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	boardID := utils.NewID(utils.IDTypeBoard)
+	userID := utils.NewID(utils.IDTypeUser)
+
+	board := &model.Board{
+		ID:     boardID,
+		TeamID: utils.NewID(utils.IDTypeTeam),
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do"},
+					map[string]interface{}{"id": "doing", "value": "In Progress"},
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+			{"id": "assignee", "name": "Assignee", "type": "person"},
+			{"id": "estimate", "name": "Estimate", "type": "number"},
+		},
+	}
+
+	card := &model.Card{
+		ID:         utils.NewID(utils.IDTypeBlock),
+		BoardID:    boardID,
+		Title:      "test card",
+		Properties: map[string]interface{}{"status": "todo", "estimate": ""},
+	}
+
+	enterInProgress := &model.StatusTransitionRule{
+		BoardID:            boardID,
+		FromStatus:         model.StatusTransitionAnyStatus,
+		ToStatus:           "doing",
+		Allowed:            true,
+		RequiredProperties: []string{"assignee", "estimate"},
+	}
+
+	t.Run("required properties", func(t *testing.T) {
+		cardPatch := &model.CardPatch{
+			UpdatedProperties: map[string]interface{}{"status": "doing", "assignee": userID},
+		}
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return([]*model.StatusTransitionRule{enterInProgress}, nil)
+
+		err := th.App.validateStatusTransitions(board, card, cardPatch, userID)
+		require.True(t, model.IsErrBadRequest(err))
+
+		var transitionErr *model.ErrStatusTransition
+		require.ErrorAs(t, err, &transitionErr)
+		require.Len(t, transitionErr.Failures, 1)
+		require.Equal(t, model.StatusTransitionConditionRequiredProperty, transitionErr.Failures[0].Condition)
+		require.Equal(t, "estimate", transitionErr.Failures[0].PropertyID)
+		require.Equal(t, "The To Do card can't move to the In Progress status: Estimate is required.", err.Error())
+	})
+
+	t.Run("required properties set by the patch", func(t *testing.T) {
+		cardPatch := &model.CardPatch{
+			UpdatedProperties: map[string]interface{}{"status": "doing", "assignee": userID, "estimate": "3"},
+		}
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return([]*model.StatusTransitionRule{enterInProgress}, nil)
+
+		require.NoError(t, th.App.validateStatusTransitions(board, card, cardPatch, userID))
+	})
+
+	t.Run("role and comment", func(t *testing.T) {
+		rule := &model.StatusTransitionRule{
+			BoardID:        boardID,
+			FromStatus:     "todo",
+			ToStatus:       "done",
+			Allowed:        true,
+			AllowedRoles:   []model.BoardRole{model.BoardRoleAdmin},
+			RequireComment: true,
+		}
+		cardPatch := &model.CardPatch{
+			UpdatedProperties: map[string]interface{}{"status": "done"},
+		}
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return([]*model.StatusTransitionRule{rule}, nil)
+		th.PermissionsStore.EXPECT().GetBoard(boardID).Return(board, nil)
+		th.API.EXPECT().HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam).Return(true)
+		th.PermissionsStore.EXPECT().GetMemberForBoard(boardID, userID).Return(&model.BoardMember{SchemeEditor: true}, nil)
+		th.API.EXPECT().HasPermissionToTeam(userID, board.TeamID, model.PermissionManageTeam).Return(false)
+
+		err := th.App.validateStatusTransitions(board, card, cardPatch, userID)
+		var transitionErr *model.ErrStatusTransition
+		require.ErrorAs(t, err, &transitionErr)
+		require.Len(t, transitionErr.Failures, 2)
+		require.Equal(t, model.StatusTransitionConditionRole, transitionErr.Failures[0].Condition)
+		require.Equal(t, model.StatusTransitionConditionComment, transitionErr.Failures[1].Condition)
+
+		comment := "approved"
+		cardPatch.Comment = &comment
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return([]*model.StatusTransitionRule{rule}, nil)
+		th.PermissionsStore.EXPECT().GetBoard(boardID).Return(board, nil)
+		th.API.EXPECT().HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam).Return(true)
+		th.PermissionsStore.EXPECT().GetMemberForBoard(boardID, userID).Return(&model.BoardMember{SchemeAdmin: true}, nil)
+
+		require.NoError(t, th.App.validateStatusTransitions(board, card, cardPatch, userID))
+	})
+
+	t.Run("role of an implicit member", func(t *testing.T) {
+		rule := &model.StatusTransitionRule{
+			BoardID:      boardID,
+			FromStatus:   "todo",
+			ToStatus:     "done",
+			Allowed:      true,
+			AllowedRoles: []model.BoardRole{model.BoardRoleAdmin},
+		}
+		cardPatch := &model.CardPatch{
+			UpdatedProperties: map[string]interface{}{"status": "done"},
+		}
+		// team admins get the admin role on the boards of the team they
+		// are a synthetic member of.
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return([]*model.StatusTransitionRule{rule}, nil)
+		th.PermissionsStore.EXPECT().GetBoard(boardID).Return(board, nil)
+		th.API.EXPECT().HasPermissionToTeam(userID, board.TeamID, model.PermissionViewTeam).Return(true)
+		th.PermissionsStore.EXPECT().GetMemberForBoard(boardID, userID).Return(&model.BoardMember{SchemeEditor: true, Synthetic: true}, nil)
+		th.API.EXPECT().HasPermissionToTeam(userID, board.TeamID, model.PermissionManageTeam).Return(true)
+
+		require.NoError(t, th.App.validateStatusTransitions(board, card, cardPatch, userID))
+	})
+
+	t.Run("transition rule takes precedence", func(t *testing.T) {
+		rules := []*model.StatusTransitionRule{
+			{BoardID: boardID, FromStatus: model.StatusTransitionAnyStatus, ToStatus: "done", Allowed: false},
+			{BoardID: boardID, FromStatus: "todo", ToStatus: "done", Allowed: true},
+		}
+		cardPatch := &model.CardPatch{
+			UpdatedProperties: map[string]interface{}{"status": "done"},
+		}
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return(rules, nil)
+
+		require.NoError(t, th.App.validateStatusTransitions(board, card, cardPatch, userID))
+	})
+
+	t.Run("workflow property set on the board", func(t *testing.T) {
+		stageBoard := &model.Board{
+			ID:             boardID,
+			Properties:     map[string]interface{}{model.BoardPropertyWorkflowPropertyID: "stage"},
+			CardProperties: append(board.CardProperties, map[string]interface{}{"id": "stage", "name": "Stage", "type": "select"}),
+		}
+		stageCard := &model.Card{
+			ID:         card.ID,
+			BoardID:    boardID,
+			Properties: map[string]interface{}{"status": "todo", "stage": "alpha"},
+		}
+
+		// the status is no longer the workflow property.
+		cardPatch := &model.CardPatch{
+			UpdatedProperties: map[string]interface{}{"status": "doing"},
+		}
+		require.NoError(t, th.App.validateStatusTransitions(stageBoard, stageCard, cardPatch, userID))
+
+		cardPatch = &model.CardPatch{
+			UpdatedProperties: map[string]interface{}{"stage": "beta"},
+		}
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return([]*model.StatusTransitionRule{
+			{BoardID: boardID, FromStatus: "alpha", ToStatus: "beta", Allowed: false},
+		}, nil)
+		require.Error(t, th.App.validateStatusTransitions(stageBoard, stageCard, cardPatch, userID))
+	})
+
+	t.Run("open blockers", func(t *testing.T) {
+		blockingBoard := &model.Board{
+			ID:             boardID,
+			Properties:     map[string]interface{}{model.BoardPropertyBlockDoneWhileBlocked: true},
+			CardProperties: board.CardProperties,
+		}
+		blockers := []*model.CardRelationWithCard{
+			{
+				CardRelation: model.CardRelation{ID: "r1", SourceCardID: card.ID, TargetCardID: "b1", RelationType: model.RelationTypeIsBlockedBy},
+				Card:         &model.Card{ID: "b1", BoardID: boardID, Title: "API", Properties: map[string]interface{}{"status": "doing"}},
+			},
+			{
+				CardRelation: model.CardRelation{ID: "r2", SourceCardID: "b2", TargetCardID: card.ID, RelationType: model.RelationTypeBlocks},
+				Card:         &model.Card{ID: "b2", BoardID: boardID, Title: "Schema", Properties: map[string]interface{}{"status": "done"}},
+			},
+			{
+				CardRelation: model.CardRelation{ID: "r3", SourceCardID: card.ID, TargetCardID: "b3", RelationType: model.RelationTypeBlocks},
+				Card:         &model.Card{ID: "b3", BoardID: boardID, Title: "Docs", Properties: map[string]interface{}{"status": "todo"}},
+			},
+		}
+		cardPatch := &model.CardPatch{
+			UpdatedProperties: map[string]interface{}{"status": "done"},
+		}
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return(nil, nil)
+		th.Store.EXPECT().GetCardRelations(card.ID).Return(blockers, nil)
+
+		err := th.App.validateStatusTransitions(blockingBoard, card, cardPatch, userID)
+		var transitionErr *model.ErrStatusTransition
+		require.ErrorAs(t, err, &transitionErr)
+		require.Len(t, transitionErr.Failures, 1)
+		require.Equal(t, model.StatusTransitionConditionBlocked, transitionErr.Failures[0].Condition)
+		require.Equal(t, []string{"b1"}, transitionErr.Failures[0].BlockingCardIDs)
+		require.Equal(t, "The To Do card can't move to the Done status: the card is blocked by API.", err.Error())
+
+		// the setting is off by default, and not done statuses are not checked.
+		th.Store.EXPECT().GetStatusTransitionRules(boardID).Return(nil, nil).Times(2)
+		require.NoError(t, th.App.validateStatusTransitions(board, card, cardPatch, userID))
+		require.NoError(t, th.App.validateStatusTransitions(blockingBoard, card, &model.CardPatch{
+			UpdatedProperties: map[string]interface{}{"status": "doing"},
+		}, userID))
+	})
+}
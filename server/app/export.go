@@ -103,6 +103,17 @@ func (a *App) writeArchiveBoard(zw *zip.Writer, board model.Board, opt model.Exp
 		}
 	}
 
+	worklogs, err := a.store.GetWorklogs(model.QueryWorklogsOptions{BoardIDs: []string{board.ID}})
+	if err != nil {
+		return err
+	}
+
+	for _, worklog := range worklogs {
+		if err = a.writeArchiveWorklogLine(w, worklog); err != nil {
+			return err
+		}
+	}
+
 	boardMembers, err := a.GetMembersForBoard(board.ID)
 	if err != nil {
 		return err
@@ -148,6 +159,31 @@ func (a *App) writeArchiveBoardMemberLine(w io.Writer, boardMember *model.BoardM
 	return err
 }
 
+// writeArchiveWorklogLine writes a single worklog entry to the archive.
+func (a *App) writeArchiveWorklogLine(w io.Writer, worklog *model.Worklog) error {
+	b, err := json.Marshal(&worklog)
+	if err != nil {
+		return err
+	}
+	line := model.ArchiveLine{
+		Type: "worklog",
+		Data: b,
+	}
+
+	b, err = json.Marshal(&line)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(newline)
+	return err
+}
+
 // writeArchiveBlockLine writes a single block to the archive.
 func (a *App) writeArchiveBlockLine(w io.Writer, block *model.Block) error {
 	b, err := json.Marshal(&block)
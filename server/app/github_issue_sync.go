@@ -0,0 +1,490 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/audit"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/github"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	// githubIssueSyncInterval is how often a board is synchronized with
+	// its GitHub issues.
+	githubIssueSyncInterval  = 2 * time.Minute
+	githubIssueSyncBatchSize = 20
+)
+
+// GetGitHubIssueSync returns the GitHub issue synchronization of a board.
+func (a *App) GetGitHubIssueSync(boardID string) (*model.GitHubIssueSync, error) {
+	return a.store.GetGitHubIssueSync(boardID)
+}
+
+// SaveGitHubIssueSync sets up the GitHub issue synchronization of a board.
+// Moving it to another repository starts it over, without the cards of the
+// issues of the previous one.
+func (a *App) SaveGitHubIssueSync(sync *model.GitHubIssueSync) (*model.GitHubIssueSync, error) {
+	board, err := a.store.GetBoard(sync.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	sync.Populate()
+	sync.UpdateAt = utils.GetMillis()
+	if err = model.ValidateGitHubIssueSync(board, sync); err != nil {
+		return nil, err
+	}
+
+	existing, err := a.store.GetGitHubIssueSync(sync.BoardID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+	if existing != nil && (existing.Owner != sync.Owner || existing.Repo != sync.Repo) {
+		if err = a.store.DeleteGitHubIssueSync(sync.BoardID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = a.store.SaveGitHubIssueSync(sync); err != nil {
+		return nil, err
+	}
+	return a.store.GetGitHubIssueSync(sync.BoardID)
+}
+
+// DeleteGitHubIssueSync stops the GitHub issue synchronization of a board.
+// The cards and issues are kept.
+func (a *App) DeleteGitHubIssueSync(boardID string) error {
+	return a.store.DeleteGitHubIssueSync(boardID)
+}
+
+// GetGitHubIssueSyncStatus returns the state of the GitHub issue
+// synchronization of a board.
+func (a *App) GetGitHubIssueSyncStatus(boardID string) (*model.GitHubIssueSyncStatus, error) {
+	sync, err := a.store.GetGitHubIssueSync(boardID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := a.store.GetGitHubIssueSyncItems(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.GitHubIssueSyncStatus{
+		Sync:      sync,
+		ItemCount: len(items),
+	}, nil
+}
+
+// SyncGitHubIssues synchronizes the boards that were not synchronized
+// recently with the issues of their repository. The outcome is recorded in
+// the synchronization of each board.
+func (a *App) SyncGitHubIssues() error {
+	githubService := a.GetGitHubService()
+	if githubService == nil {
+		return nil
+	}
+
+	now := utils.GetMillis()
+	syncs, err := a.store.GetGitHubIssueSyncsToRun(now-githubIssueSyncInterval.Milliseconds(), githubIssueSyncBatchSize)
+	if err != nil {
+		return err
+	}
+
+	merr := merror.New()
+	for _, sync := range syncs {
+		syncErr := a.syncGitHubIssuesOfBoard(githubService, sync, now)
+		if syncErr != nil {
+			sync.SetSyncError(syncErr, now)
+		} else {
+			sync.SyncError = ""
+			sync.SyncedAt = now
+			sync.SyncAttemptAt = now
+		}
+		if err := a.store.UpdateGitHubIssueSyncState(sync); err != nil && !model.IsErrNotFound(err) {
+			merr.Append(fmt.Errorf("github issue sync of board %s: %w", sync.BoardID, err))
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// githubIssueSyncer synchronizes a board with the issues of its repository.
+type githubIssueSyncer struct {
+	app           *App
+	githubService *github.Service
+	sync          *model.GitHubIssueSync
+	board         *model.Board
+	statusPropID  string
+	botID         string
+	now           int64
+}
+
+func (a *App) syncGitHubIssuesOfBoard(githubService *github.Service, sync *model.GitHubIssueSync, now int64) error {
+	board, err := a.store.GetBoard(sync.BoardID)
+	if err != nil {
+		return err
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+
+	s := &githubIssueSyncer{
+		app:           a,
+		githubService: githubService,
+		sync:          sync,
+		board:         board,
+		botID:         a.getBoardsBotID(),
+		now:           now,
+	}
+	if statusProp, ok := schema.WorkflowPropDef(board); ok {
+		s.statusPropID = statusProp.ID
+	}
+
+	items, err := a.store.GetGitHubIssueSyncItems(board.ID)
+	if err != nil {
+		return err
+	}
+	itemsByNumber := make(map[int]*model.GitHubIssueSyncItem, len(items))
+	for _, item := range items {
+		itemsByNumber[item.Number] = item
+	}
+
+	var since time.Time
+	if sync.IssuesSyncedAt > 0 {
+		since = utils.GetTimeForMillis(sync.IssuesSyncedAt)
+	}
+	issues, err := githubService.ListIssues(sync.CreatedBy, sync.Owner, sync.Repo, since)
+	if err != nil {
+		return err
+	}
+
+	// the error is recorded as the sync error of the board.
+	merr := merror.New()
+	merr.SetFormatter(func(merr *merror.MError) string {
+		msgs := make([]string, 0, merr.Len())
+		for _, err := range merr.Errors() {
+			msgs = append(msgs, err.Error())
+		}
+		return strings.Join(msgs, "; ")
+	})
+	synced := map[string]bool{}
+	failed := false
+	for i := range issues {
+		issue := &issues[i]
+		var issueErr error
+		if item, ok := itemsByNumber[issue.Number]; ok {
+			synced[item.CardID] = true
+			issueErr = s.syncItem(item, issue)
+		} else {
+			issueErr = s.importIssue(issue)
+		}
+		if issueErr != nil {
+			merr.Append(fmt.Errorf("issue #%d: %w", issue.Number, issueErr))
+			failed = true
+			continue
+		}
+		// the issues come by update time, so the next synchronization lists
+		// the failed issue and the ones after it again.
+		if failed {
+			continue
+		}
+		if updateAt := utils.GetMillisForTime(issue.UpdatedAt); updateAt > sync.IssuesSyncedAt {
+			sync.IssuesSyncedAt = updateAt
+		}
+	}
+
+	// the cards of the issues that did not change may have.
+	for _, item := range items {
+		if synced[item.CardID] {
+			continue
+		}
+		if err := s.syncItem(item, nil); err != nil {
+			merr.Append(fmt.Errorf("issue #%d: %w", item.Number, err))
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// importIssue creates the card of a new issue.
+func (s *githubIssueSyncer) importIssue(issue *github.Issue) error {
+	card := &model.Card{
+		Title:      issue.Title,
+		Properties: map[string]any{},
+	}
+	s.setCardProperties(card.Properties, issue, nil)
+
+	var description *model.Block
+	if issue.Body != "" {
+		description = s.newDescriptionBlock(issue.Body)
+		card.ContentOrder = []string{description.ID}
+	}
+	card.PopulateWithBoardID(s.board.ID)
+
+	created, err := s.app.CreateCard(card, s.board.ID, s.botID, false)
+	if err != nil {
+		return err
+	}
+
+	item := &model.GitHubIssueSyncItem{
+		CardID:        created.ID,
+		BoardID:       s.board.ID,
+		Number:        issue.Number,
+		CardUpdateAt:  created.UpdateAt,
+		IssueUpdateAt: utils.GetMillisForTime(issue.UpdatedAt),
+		SyncedAt:      s.now,
+	}
+	if description != nil {
+		description.ParentID = created.ID
+		if err := s.app.InsertBlockAndNotify(description, s.botID, true); err != nil {
+			return err
+		}
+		item.DescriptionBlockID = description.ID
+		item.CardUpdateAt = max(item.CardUpdateAt, description.UpdateAt)
+	}
+	return s.app.store.SaveGitHubIssueSyncItem(item)
+}
+
+// syncItem synchronizes a card with its issue, which is nil if it did not
+// change. When both changed since their last synchronization, the last
+// change wins and the conflict is audited.
+func (s *githubIssueSyncer) syncItem(item *model.GitHubIssueSyncItem, issue *github.Issue) error {
+	block, err := s.app.store.GetBlock(item.CardID)
+	if model.IsErrNotFound(err) {
+		return s.app.store.DeleteGitHubIssueSyncItem(item.CardID)
+	}
+	if err != nil {
+		return err
+	}
+	card, err := model.Block2Card(block)
+	if err != nil {
+		return err
+	}
+
+	var description *model.Block
+	if item.DescriptionBlockID != "" {
+		description, err = s.app.store.GetBlock(item.DescriptionBlockID)
+		if err != nil && !model.IsErrNotFound(err) {
+			return err
+		}
+		if description != nil && description.DeleteAt != 0 {
+			description = nil
+		}
+	}
+
+	cardUpdateAt := card.UpdateAt
+	if description != nil {
+		cardUpdateAt = max(cardUpdateAt, description.UpdateAt)
+	}
+	cardChanged := cardUpdateAt > item.CardUpdateAt
+
+	var issueUpdateAt int64
+	if issue != nil {
+		issueUpdateAt = utils.GetMillisForTime(issue.UpdatedAt)
+	}
+	issueChanged := issue != nil && issueUpdateAt > item.IssueUpdateAt
+
+	switch {
+	case cardChanged && issueChanged:
+		cardWins := cardUpdateAt > issueUpdateAt
+		s.recordConflict(item, cardWins)
+		if cardWins {
+			return s.pushCard(item, card, description, issue)
+		}
+		return s.pullIssue(item, card, description, issue)
+	case cardChanged:
+		return s.pushCard(item, card, description, issue)
+	case issueChanged:
+		return s.pullIssue(item, card, description, issue)
+	}
+	return nil
+}
+
+// pushCard updates an issue from its card.
+func (s *githubIssueSyncer) pushCard(item *model.GitHubIssueSyncItem, card *model.Card, description *model.Block, issue *github.Issue) error {
+	if issue == nil {
+		var err error
+		issue, err = s.githubService.GetIssue(s.sync.CreatedBy, s.sync.Owner, s.sync.Repo, item.Number)
+		if err != nil {
+			return err
+		}
+	}
+
+	body := ""
+	if description != nil {
+		body = description.Title
+	}
+	req := github.UpdateIssueRequest{
+		Title: &card.Title,
+		Body:  &body,
+	}
+	if s.statusPropID != "" {
+		status, _ := card.Properties[s.statusPropID].(string)
+		if state, ok := s.sync.IssueState(status); ok {
+			req.State = &state
+		}
+	}
+	if s.sync.LabelPropertyID != "" {
+		current := make([]string, 0, len(issue.Labels))
+		for _, label := range issue.Labels {
+			current = append(current, label.Name)
+		}
+		option, _ := card.Properties[s.sync.LabelPropertyID].(string)
+		labels := s.sync.IssueLabels(current, option)
+		req.Labels = &labels
+	}
+	if s.sync.AssigneePropertyID != "" {
+		userID, _ := card.Properties[s.sync.AssigneePropertyID].(string)
+		if assignees, ok := s.sync.IssueAssignees(userID); ok {
+			req.Assignees = &assignees
+		}
+	}
+
+	updated, err := s.githubService.UpdateIssue(s.sync.CreatedBy, s.sync.Owner, s.sync.Repo, item.Number, req)
+	if err != nil {
+		return err
+	}
+
+	item.CardUpdateAt = card.UpdateAt
+	if description != nil {
+		item.CardUpdateAt = max(item.CardUpdateAt, description.UpdateAt)
+	}
+	item.IssueUpdateAt = utils.GetMillisForTime(updated.UpdatedAt)
+	item.SyncedAt = s.now
+	return s.app.store.SaveGitHubIssueSyncItem(item)
+}
+
+// pullIssue updates a card from its issue. The change is made by the boards
+// bot through PatchCard, so the status transition rules of the board apply.
+func (s *githubIssueSyncer) pullIssue(item *model.GitHubIssueSyncItem, card *model.Card, description *model.Block, issue *github.Issue) error {
+	patch := &model.CardPatch{
+		UpdatedProperties: map[string]any{},
+	}
+	if issue.Title != card.Title {
+		patch.Title = &issue.Title
+	}
+	s.setCardProperties(patch.UpdatedProperties, issue, card.Properties)
+
+	descriptionUpdateAt := int64(0)
+	switch {
+	case description != nil && description.Title != issue.Body:
+		updated, err := s.app.PatchBlockAndNotify(description.ID, &model.BlockPatch{Title: &issue.Body}, s.botID, true)
+		if err != nil {
+			return err
+		}
+		descriptionUpdateAt = updated.UpdateAt
+	case description != nil:
+		descriptionUpdateAt = description.UpdateAt
+	case issue.Body != "":
+		description = s.newDescriptionBlock(issue.Body)
+		description.ParentID = card.ID
+		if err := s.app.InsertBlockAndNotify(description, s.botID, true); err != nil {
+			return err
+		}
+		contentOrder := append([]string{description.ID}, card.ContentOrder...)
+		patch.ContentOrder = &contentOrder
+		item.DescriptionBlockID = description.ID
+		descriptionUpdateAt = description.UpdateAt
+	}
+
+	cardUpdateAt := card.UpdateAt
+	if patch.Title != nil || patch.ContentOrder != nil || len(patch.UpdatedProperties) > 0 {
+		patched, err := s.app.PatchCard(patch, card.ID, s.botID, false)
+		if err != nil {
+			return err
+		}
+		cardUpdateAt = patched.UpdateAt
+	}
+
+	item.CardUpdateAt = max(cardUpdateAt, descriptionUpdateAt)
+	item.IssueUpdateAt = utils.GetMillisForTime(issue.UpdatedAt)
+	item.SyncedAt = s.now
+	return s.app.store.SaveGitHubIssueSyncItem(item)
+}
+
+// setCardProperties sets the status, label and assignee of the card of an
+// issue in properties, where they differ from the current ones.
+func (s *githubIssueSyncer) setCardProperties(properties map[string]any, issue *github.Issue, current map[string]any) {
+	if s.statusPropID != "" {
+		status, _ := current[s.statusPropID].(string)
+		if option, ok := s.sync.CardStatus(issue.State, status); ok {
+			properties[s.statusPropID] = option
+		}
+	}
+	if s.sync.LabelPropertyID != "" {
+		labels := make([]string, 0, len(issue.Labels))
+		for _, label := range issue.Labels {
+			labels = append(labels, label.Name)
+		}
+		previous, _ := current[s.sync.LabelPropertyID].(string)
+		if option := s.sync.CardLabelOption(labels); option != previous {
+			properties[s.sync.LabelPropertyID] = option
+		}
+	}
+	if s.sync.AssigneePropertyID != "" {
+		logins := make([]string, 0, len(issue.Assignees))
+		for _, assignee := range issue.Assignees {
+			logins = append(logins, assignee.Login)
+		}
+		previous, _ := current[s.sync.AssigneePropertyID].(string)
+		if userID, ok := s.sync.CardAssignee(logins); ok && userID != previous {
+			properties[s.sync.AssigneePropertyID] = userID
+		}
+	}
+}
+
+func (s *githubIssueSyncer) newDescriptionBlock(body string) *model.Block {
+	now := utils.GetMillis()
+	return &model.Block{
+		ID:         utils.NewID(utils.IDTypeBlock),
+		BoardID:    s.board.ID,
+		CreatedBy:  s.botID,
+		ModifiedBy: s.botID,
+		Schema:     1,
+		Type:       model.TypeText,
+		Title:      body,
+		Fields:     map[string]interface{}{},
+		CreateAt:   now,
+		UpdateAt:   now,
+	}
+}
+
+// recordConflict counts a card and its issue both changed since their last
+// synchronization, and audits which one won.
+func (s *githubIssueSyncer) recordConflict(item *model.GitHubIssueSyncItem, cardWins bool) {
+	s.sync.ConflictCount++
+	s.sync.LastConflictAt = s.now
+
+	winner := "issue"
+	if cardWins {
+		winner = "card"
+	}
+	s.app.logger.Info("GitHub issue sync conflict",
+		mlog.String("board_id", item.BoardID),
+		mlog.String("card_id", item.CardID),
+		mlog.Int("issue", item.Number),
+		mlog.String("winner", winner),
+	)
+
+	if s.app.audit == nil {
+		return
+	}
+	rec := &audit.Record{
+		Event:  "githubIssueSyncConflict",
+		Status: audit.Success,
+		UserID: s.botID,
+	}
+	rec.AddMeta("boardID", item.BoardID)
+	rec.AddMeta("cardID", item.CardID)
+	rec.AddMeta("repository", s.sync.Owner+"/"+s.sync.Repo)
+	rec.AddMeta("issue", item.Number)
+	rec.AddMeta("winner", winner)
+	s.app.audit.LogRecord(audit.LevelModify, rec)
+}
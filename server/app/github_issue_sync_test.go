@@ -0,0 +1,455 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/github"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func githubIssueSyncTestBoard() *model.Board {
+	return &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To Do"},
+				map[string]interface{}{"id": "doing", "value": "Doing"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+			{"id": "kind", "name": "Kind", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "bug", "value": "Bug"},
+				map[string]interface{}{"id": "feature", "value": "Feature"},
+			}},
+			{"id": "owner", "name": "Owner", "type": "person"},
+		},
+	}
+}
+
+func githubIssueSyncTestSync() *model.GitHubIssueSync {
+	return &model.GitHubIssueSync{
+		BoardID:              "board-id",
+		Owner:                "owner",
+		Repo:                 "repo",
+		Enabled:              true,
+		OpenStatusOptionID:   "todo",
+		ClosedStatusOptionID: "done",
+		LabelPropertyID:      "kind",
+		LabelMappings:        []model.GitHubLabelMapping{{Label: "bug", OptionID: "bug"}, {Label: "enhancement", OptionID: "feature"}},
+		AssigneePropertyID:   "owner",
+		UserMappings:         []model.GitHubUserMapping{{GitHubLogin: "octocat", UserID: "user-id"}},
+		CreatedBy:            "user-id",
+	}
+}
+
+// expectGitHubIssueSyncBlocks backs the block calls of the synchronization
+// with an in-memory set of blocks.
+func expectGitHubIssueSyncBlocks(th *TestHelper, board *model.Board, blocks map[string]*model.Block) {
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetBlock(gomock.Any()).DoAndReturn(func(blockID string) (*model.Block, error) {
+		if block, ok := blocks[blockID]; ok {
+			copied := *block
+			return &copied, nil
+		}
+		return nil, model.NewErrNotFound("block ID=" + blockID)
+	}).AnyTimes()
+	th.Store.EXPECT().InsertBlock(gomock.Any(), gomock.Any()).DoAndReturn(func(block *model.Block, _ string) error {
+		copied := *block
+		blocks[block.ID] = &copied
+		return nil
+	}).AnyTimes()
+	th.Store.EXPECT().PatchBlock(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(blockID string, patch *model.BlockPatch, _ string) error {
+		patched := patch.Patch(blocks[blockID])
+		patched.UpdateAt = utils.GetMillis()
+		// the fields are stored as JSON.
+		data, err := json.Marshal(patched.Fields)
+		if err != nil {
+			return err
+		}
+		patched.Fields = nil
+		if err := json.Unmarshal(data, &patched.Fields); err != nil {
+			return err
+		}
+		blocks[blockID] = patched
+		return nil
+	}).AnyTimes()
+	th.Store.EXPECT().GetNextCardNumber(board.ID).Return(int64(1), nil).AnyTimes()
+	th.Store.EXPECT().GetStatusTransitionRules(board.ID).Return([]*model.StatusTransitionRule{}, nil).AnyTimes()
+	th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return(nil, nil).AnyTimes()
+	th.Store.EXPECT().GetBoardAndCardByID(gomock.Any()).Return(board, nil, nil).AnyTimes()
+	th.Store.EXPECT().GetCardParent(gomock.Any()).Return(nil, model.NewErrNotFound("card parent")).AnyTimes()
+}
+
+func TestSyncGitHubIssues(t *testing.T) {
+	board := githubIssueSyncTestBoard()
+	now := time.Now()
+
+	t.Run("imports new issues as cards", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/owner/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "open", r.URL.Query().Get("state"))
+			_ = json.NewEncoder(w).Encode([]github.Issue{{
+				Number:    7,
+				Title:     "Crash on save",
+				Body:      "Steps to reproduce",
+				State:     "open",
+				UpdatedAt: now,
+				Labels:    []github.Label{{Name: "Bug"}, {Name: "p1"}},
+				Assignees: []github.User{{Login: "octocat"}},
+			}})
+		})
+		server := setupFakeGitHub(t, th, mux)
+		defer server.Close()
+
+		blocks := map[string]*model.Block{}
+		expectGitHubIssueSyncBlocks(th, board, blocks)
+
+		var item *model.GitHubIssueSyncItem
+		var state *model.GitHubIssueSync
+		th.Store.EXPECT().GetGitHubIssueSyncsToRun(gomock.Any(), githubIssueSyncBatchSize).Return([]*model.GitHubIssueSync{githubIssueSyncTestSync()}, nil)
+		th.Store.EXPECT().GetGitHubIssueSyncItems(board.ID).Return([]*model.GitHubIssueSyncItem{}, nil)
+		th.Store.EXPECT().SaveGitHubIssueSyncItem(gomock.Any()).DoAndReturn(func(i *model.GitHubIssueSyncItem) error {
+			item = i
+			return nil
+		})
+		th.Store.EXPECT().UpdateGitHubIssueSyncState(gomock.Any()).DoAndReturn(func(s *model.GitHubIssueSync) error {
+			state = s
+			return nil
+		})
+
+		require.NoError(t, th.App.SyncGitHubIssues())
+
+		require.NotNil(t, item)
+		assert.Equal(t, 7, item.Number)
+		card := blocks[item.CardID]
+		require.NotNil(t, card)
+		assert.Equal(t, "Crash on save", card.Title)
+		props := card.Fields["properties"].(map[string]interface{})
+		assert.Equal(t, "todo", props["status"])
+		assert.Equal(t, "bug", props["kind"])
+		assert.Equal(t, "user-id", props["owner"])
+
+		description := blocks[item.DescriptionBlockID]
+		require.NotNil(t, description)
+		assert.EqualValues(t, model.TypeText, description.Type)
+		assert.Equal(t, card.ID, description.ParentID)
+		assert.Equal(t, "Steps to reproduce", description.Title)
+
+		require.NotNil(t, state)
+		assert.Empty(t, state.SyncError)
+		assert.NotZero(t, state.SyncedAt)
+		assert.Equal(t, utils.GetMillisForTime(now), state.IssuesSyncedAt)
+	})
+
+	t.Run("keeps failed issues after the last synchronized time", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/owner/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode([]github.Issue{
+				{Number: 6, Title: "First", State: "open", UpdatedAt: now.Add(-2 * time.Hour)},
+				{Number: 7, Title: "Second", State: "open", UpdatedAt: now.Add(-time.Hour)},
+				{Number: 8, Title: "Third", State: "open", UpdatedAt: now},
+			})
+		})
+		server := setupFakeGitHub(t, th, mux)
+		defer server.Close()
+
+		blocks := map[string]*model.Block{}
+		expectGitHubIssueSyncBlocks(th, board, blocks)
+
+		var state *model.GitHubIssueSync
+		th.Store.EXPECT().GetGitHubIssueSyncsToRun(gomock.Any(), githubIssueSyncBatchSize).Return([]*model.GitHubIssueSync{githubIssueSyncTestSync()}, nil)
+		th.Store.EXPECT().GetGitHubIssueSyncItems(board.ID).Return([]*model.GitHubIssueSyncItem{}, nil)
+		th.Store.EXPECT().SaveGitHubIssueSyncItem(gomock.Any()).DoAndReturn(func(i *model.GitHubIssueSyncItem) error {
+			if i.Number == 7 {
+				return errors.New("database is locked")
+			}
+			return nil
+		}).Times(3)
+		th.Store.EXPECT().UpdateGitHubIssueSyncState(gomock.Any()).DoAndReturn(func(s *model.GitHubIssueSync) error {
+			state = s
+			return nil
+		})
+
+		require.NoError(t, th.App.SyncGitHubIssues())
+
+		require.NotNil(t, state)
+		assert.Contains(t, state.SyncError, "issue #7")
+		assert.Equal(t, utils.GetMillisForTime(now.Add(-2*time.Hour)), state.IssuesSyncedAt)
+	})
+
+	t.Run("pushes card changes to the issue", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		var update github.UpdateIssueRequest
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/owner/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "all", r.URL.Query().Get("state"))
+			_ = json.NewEncoder(w).Encode([]github.Issue{})
+		})
+		mux.HandleFunc("/repos/owner/repo/issues/7", func(w http.ResponseWriter, r *http.Request) {
+			issue := github.Issue{
+				Number:    7,
+				Title:     "Crash on save",
+				State:     "open",
+				UpdatedAt: now.Add(-time.Hour),
+				Labels:    []github.Label{{Name: "bug"}, {Name: "p1"}},
+			}
+			if r.Method == http.MethodPatch {
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+				issue.UpdatedAt = now
+			}
+			_ = json.NewEncoder(w).Encode(issue)
+		})
+		server := setupFakeGitHub(t, th, mux)
+		defer server.Close()
+
+		card := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "done", "kind": "feature"})
+		card.Title = "Crash when saving"
+		card.UpdateAt = utils.GetMillisForTime(now.Add(-time.Minute))
+		blocks := map[string]*model.Block{card.ID: card}
+		expectGitHubIssueSyncBlocks(th, board, blocks)
+
+		sync := githubIssueSyncTestSync()
+		sync.IssuesSyncedAt = utils.GetMillisForTime(now.Add(-time.Hour))
+		item := &model.GitHubIssueSyncItem{
+			CardID:        card.ID,
+			BoardID:       board.ID,
+			Number:        7,
+			CardUpdateAt:  utils.GetMillisForTime(now.Add(-time.Hour)),
+			IssueUpdateAt: utils.GetMillisForTime(now.Add(-time.Hour)),
+		}
+
+		var saved *model.GitHubIssueSyncItem
+		th.Store.EXPECT().GetGitHubIssueSyncsToRun(gomock.Any(), githubIssueSyncBatchSize).Return([]*model.GitHubIssueSync{sync}, nil)
+		th.Store.EXPECT().GetGitHubIssueSyncItems(board.ID).Return([]*model.GitHubIssueSyncItem{item}, nil)
+		th.Store.EXPECT().SaveGitHubIssueSyncItem(gomock.Any()).DoAndReturn(func(i *model.GitHubIssueSyncItem) error {
+			saved = i
+			return nil
+		})
+		th.Store.EXPECT().UpdateGitHubIssueSyncState(gomock.Any()).Return(nil)
+
+		require.NoError(t, th.App.SyncGitHubIssues())
+
+		require.NotNil(t, update.Title)
+		assert.Equal(t, "Crash when saving", *update.Title)
+		require.NotNil(t, update.State)
+		assert.Equal(t, "closed", *update.State)
+		require.NotNil(t, update.Labels)
+		assert.Equal(t, []string{"p1", "enhancement"}, *update.Labels)
+		require.NotNil(t, update.Assignees)
+		assert.Empty(t, *update.Assignees)
+
+		require.NotNil(t, saved)
+		assert.Equal(t, card.UpdateAt, saved.CardUpdateAt)
+		assert.Equal(t, utils.GetMillisForTime(now), saved.IssueUpdateAt)
+	})
+
+	t.Run("pulls issue changes to the card", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/owner/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode([]github.Issue{{
+				Number:    7,
+				Title:     "Crash on save as",
+				Body:      "Now with steps",
+				State:     "closed",
+				UpdatedAt: now,
+				Assignees: []github.User{{Login: "octocat"}},
+			}})
+		})
+		mux.HandleFunc("/repos/owner/repo/issues/7", func(w http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "the issue should not be updated")
+		})
+		server := setupFakeGitHub(t, th, mux)
+		defer server.Close()
+
+		card := bulkTestCard("card-1", board.ID, map[string]interface{}{
+			"status":   "doing",
+			"kind":     "bug",
+			"priority": "high",
+			"due":      "1700000000000",
+		})
+		card.UpdateAt = utils.GetMillisForTime(now.Add(-time.Hour))
+		blocks := map[string]*model.Block{card.ID: card}
+		expectGitHubIssueSyncBlocks(th, board, blocks)
+
+		sync := githubIssueSyncTestSync()
+		sync.IssuesSyncedAt = utils.GetMillisForTime(now.Add(-time.Hour))
+		item := &model.GitHubIssueSyncItem{
+			CardID:        card.ID,
+			BoardID:       board.ID,
+			Number:        7,
+			CardUpdateAt:  card.UpdateAt,
+			IssueUpdateAt: utils.GetMillisForTime(now.Add(-time.Hour)),
+		}
+
+		var saved *model.GitHubIssueSyncItem
+		th.Store.EXPECT().GetGitHubIssueSyncsToRun(gomock.Any(), githubIssueSyncBatchSize).Return([]*model.GitHubIssueSync{sync}, nil)
+		th.Store.EXPECT().GetGitHubIssueSyncItems(board.ID).Return([]*model.GitHubIssueSyncItem{item}, nil)
+		th.Store.EXPECT().SaveGitHubIssueSyncItem(gomock.Any()).DoAndReturn(func(i *model.GitHubIssueSyncItem) error {
+			saved = i
+			return nil
+		})
+		th.Store.EXPECT().UpdateGitHubIssueSyncState(gomock.Any()).Return(nil)
+
+		require.NoError(t, th.App.SyncGitHubIssues())
+
+		patched := blocks[card.ID]
+		assert.Equal(t, "Crash on save as", patched.Title)
+		props := patched.Fields["properties"].(map[string]interface{})
+		assert.Equal(t, "done", props["status"])
+		assert.Equal(t, "", props["kind"])
+		assert.Equal(t, "user-id", props["owner"])
+		// the properties that are not synchronized are kept.
+		assert.Equal(t, "high", props["priority"])
+		assert.Equal(t, "1700000000000", props["due"])
+
+		require.NotNil(t, saved)
+		require.NotEmpty(t, saved.DescriptionBlockID)
+		assert.Equal(t, "Now with steps", blocks[saved.DescriptionBlockID].Title)
+		assert.Equal(t, []interface{}{saved.DescriptionBlockID}, patched.Fields["contentOrder"])
+		assert.Equal(t, patched.UpdateAt, saved.CardUpdateAt)
+		assert.Equal(t, utils.GetMillisForTime(now), saved.IssueUpdateAt)
+	})
+
+	t.Run("resolves conflicts with the last change", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		var patched atomic.Bool
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/owner/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode([]github.Issue{{
+				Number:    7,
+				Title:     "Renamed on GitHub",
+				State:     "open",
+				UpdatedAt: now.Add(-time.Minute),
+			}})
+		})
+		mux.HandleFunc("/repos/owner/repo/issues/7", func(w http.ResponseWriter, r *http.Request) {
+			patched.Store(true)
+			_ = json.NewEncoder(w).Encode(github.Issue{Number: 7, UpdatedAt: now})
+		})
+		server := setupFakeGitHub(t, th, mux)
+		defer server.Close()
+
+		card := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "todo"})
+		card.Title = "Renamed on the board"
+		card.UpdateAt = utils.GetMillisForTime(now.Add(-10 * time.Minute))
+		blocks := map[string]*model.Block{card.ID: card}
+		expectGitHubIssueSyncBlocks(th, board, blocks)
+
+		sync := githubIssueSyncTestSync()
+		sync.IssuesSyncedAt = utils.GetMillisForTime(now.Add(-time.Hour))
+		item := &model.GitHubIssueSyncItem{
+			CardID:        card.ID,
+			BoardID:       board.ID,
+			Number:        7,
+			CardUpdateAt:  utils.GetMillisForTime(now.Add(-time.Hour)),
+			IssueUpdateAt: utils.GetMillisForTime(now.Add(-time.Hour)),
+		}
+
+		var state *model.GitHubIssueSync
+		th.Store.EXPECT().GetGitHubIssueSyncsToRun(gomock.Any(), githubIssueSyncBatchSize).Return([]*model.GitHubIssueSync{sync}, nil)
+		th.Store.EXPECT().GetGitHubIssueSyncItems(board.ID).Return([]*model.GitHubIssueSyncItem{item}, nil)
+		th.Store.EXPECT().SaveGitHubIssueSyncItem(gomock.Any()).Return(nil)
+		th.Store.EXPECT().UpdateGitHubIssueSyncState(gomock.Any()).DoAndReturn(func(s *model.GitHubIssueSync) error {
+			state = s
+			return nil
+		})
+
+		require.NoError(t, th.App.SyncGitHubIssues())
+
+		assert.False(t, patched.Load(), "the older card change should not be pushed")
+		assert.Equal(t, "Renamed on GitHub", blocks[card.ID].Title)
+		require.NotNil(t, state)
+		assert.EqualValues(t, 1, state.ConflictCount)
+		assert.NotZero(t, state.LastConflictAt)
+	})
+
+	t.Run("records the error of a failed synchronization", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/repos/owner/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		server := setupFakeGitHub(t, th, mux)
+		defer server.Close()
+
+		var state *model.GitHubIssueSync
+		th.Store.EXPECT().GetGitHubIssueSyncsToRun(gomock.Any(), githubIssueSyncBatchSize).Return([]*model.GitHubIssueSync{githubIssueSyncTestSync()}, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetGitHubIssueSyncItems(board.ID).Return([]*model.GitHubIssueSyncItem{}, nil)
+		th.Store.EXPECT().UpdateGitHubIssueSyncState(gomock.Any()).DoAndReturn(func(s *model.GitHubIssueSync) error {
+			state = s
+			return nil
+		})
+
+		require.NoError(t, th.App.SyncGitHubIssues())
+
+		require.NotNil(t, state)
+		assert.NotEmpty(t, state.SyncError)
+		assert.NotZero(t, state.SyncAttemptAt)
+		assert.Zero(t, state.SyncedAt)
+	})
+}
+
+func TestSaveGitHubIssueSync(t *testing.T) {
+	board := githubIssueSyncTestBoard()
+
+	t.Run("starts over on another repository", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		existing := githubIssueSyncTestSync()
+		existing.Repo = "old-repo"
+		sync := githubIssueSyncTestSync()
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetGitHubIssueSync(board.ID).Return(existing, nil)
+		th.Store.EXPECT().DeleteGitHubIssueSync(board.ID).Return(nil)
+		th.Store.EXPECT().SaveGitHubIssueSync(sync).Return(nil)
+		th.Store.EXPECT().GetGitHubIssueSync(board.ID).Return(sync, nil)
+
+		saved, err := th.App.SaveGitHubIssueSync(sync)
+		require.NoError(t, err)
+		assert.Equal(t, "repo", saved.Repo)
+	})
+
+	t.Run("invalid mapping", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		sync := githubIssueSyncTestSync()
+		sync.LabelMappings = []model.GitHubLabelMapping{{Label: "bug", OptionID: "missing"}}
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		_, err := th.App.SaveGitHubIssueSync(sync)
+		require.Error(t, err)
+		assert.True(t, model.IsErrBadRequest(err))
+	})
+}
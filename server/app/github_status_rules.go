@@ -0,0 +1,104 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// GetGitHubStatusRules returns the GitHub status rules of a board.
+func (a *App) GetGitHubStatusRules(boardID string) ([]*model.GitHubStatusRule, error) {
+	return a.store.GetGitHubStatusRules(boardID)
+}
+
+// ReplaceGitHubStatusRules replaces the GitHub status rules of a board.
+func (a *App) ReplaceGitHubStatusRules(boardID string, rules []*model.GitHubStatusRule) error {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+	if err := model.ValidateGitHubStatusRules(board, rules); err != nil {
+		return err
+	}
+	return a.store.ReplaceGitHubStatusRules(boardID, rules)
+}
+
+// ApplyGitHubStatusRule moves a card to the status its board maps to a
+// GitHub event, if any. The change is made by the boards bot through
+// PatchCard, so the status transition rules of the board apply.
+func (a *App) ApplyGitHubStatusRule(cardID string, event model.GitHubEvent) error {
+	block, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return err
+	}
+	card, err := model.Block2Card(block)
+	if err != nil {
+		return err
+	}
+
+	rules, err := a.store.GetGitHubStatusRules(card.BoardID)
+	if err != nil {
+		return err
+	}
+	var rule *model.GitHubStatusRule
+	for _, r := range rules {
+		if r.Event == event {
+			rule = r
+			break
+		}
+	}
+	if rule == nil {
+		return nil
+	}
+
+	board, err := a.store.GetBoard(card.BoardID)
+	if err != nil {
+		return err
+	}
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+	statusProp, ok := schema.WorkflowPropDef(board)
+	if !ok {
+		return nil
+	}
+	if current, _ := card.Properties[statusProp.ID].(string); current == rule.StatusOptionID {
+		return nil
+	}
+
+	patch := &model.CardPatch{
+		UpdatedProperties: map[string]any{statusProp.ID: rule.StatusOptionID},
+	}
+	if _, err := a.PatchCard(patch, cardID, a.getBoardsBotID(), false); err != nil {
+		return fmt.Errorf("cannot apply the %s status rule to card %s: %w", event, cardID, err)
+	}
+
+	a.logger.Debug("Applied GitHub status rule",
+		mlog.String("card_id", cardID),
+		mlog.String("event", string(event)),
+		mlog.String("status", rule.StatusOptionID),
+	)
+	return nil
+}
+
+// applyGitHubLinkStatusRule applies the status rule of the change of state
+// of a linked pull request since its previous synchronization.
+func (a *App) applyGitHubLinkStatusRule(link *model.CardGitHubLink, previous *model.CardGitHubLink) {
+	event, ok := link.PullRequestEvent(previous)
+	if !ok {
+		return
+	}
+	if err := a.ApplyGitHubStatusRule(link.CardID, event); err != nil {
+		a.logger.Warn("Cannot move card after a pull request change",
+			mlog.String("card_id", link.CardID),
+			mlog.String("link_id", link.ID),
+			mlog.Err(err),
+		)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func githubStatusRulesTestBoard() *model.Board {
+	return &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To Do"},
+				map[string]interface{}{"id": "review", "value": "In Review"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+		},
+	}
+}
+
+func TestReplaceGitHubStatusRules(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := githubStatusRulesTestBoard()
+
+	t.Run("valid rules", func(t *testing.T) {
+		rules := []*model.GitHubStatusRule{
+			{BoardID: board.ID, Event: model.GitHubEventPROpened, StatusOptionID: "review"},
+			{BoardID: board.ID, Event: model.GitHubEventPRMerged, StatusOptionID: "done"},
+		}
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().ReplaceGitHubStatusRules(board.ID, rules).Return(nil)
+
+		require.NoError(t, th.App.ReplaceGitHubStatusRules(board.ID, rules))
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		rules := []*model.GitHubStatusRule{
+			{BoardID: board.ID, Event: model.GitHubEventPRMerged, StatusOptionID: "shipped"},
+		}
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		require.True(t, model.IsErrBadRequest(th.App.ReplaceGitHubStatusRules(board.ID, rules)))
+	})
+
+	t.Run("one rule per event", func(t *testing.T) {
+		rules := []*model.GitHubStatusRule{
+			{BoardID: board.ID, Event: model.GitHubEventPRMerged, StatusOptionID: "done"},
+			{BoardID: board.ID, Event: model.GitHubEventPRMerged, StatusOptionID: "review"},
+		}
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		require.True(t, model.IsErrBadRequest(th.App.ReplaceGitHubStatusRules(board.ID, rules)))
+	})
+}
+
+func TestApplyGitHubStatusRule(t *testing.T) {
+	board := githubStatusRulesTestBoard()
+	rules := []*model.GitHubStatusRule{
+		{BoardID: board.ID, Event: model.GitHubEventPRMerged, StatusOptionID: "done"},
+	}
+
+	t.Run("moves the card as the bot", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		card := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "review"})
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil).AnyTimes()
+		th.Store.EXPECT().GetGitHubStatusRules(board.ID).Return(rules, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().GetStatusTransitionRules(board.ID).Return([]*model.StatusTransitionRule{}, nil)
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardAndCardByID(gomock.Any()).Return(board, card, nil).AnyTimes()
+		th.Store.EXPECT().GetCardParent(gomock.Any()).Return(nil, model.NewErrNotFound("parent")).AnyTimes()
+
+		var patch *model.BlockPatch
+		th.Store.EXPECT().PatchBlock("card-1", gomock.Any(), model.SystemUserID).DoAndReturn(
+			func(_ string, p *model.BlockPatch, _ string) error {
+				patch = p
+				return nil
+			})
+
+		require.NoError(t, th.App.ApplyGitHubStatusRule("card-1", model.GitHubEventPRMerged))
+		require.NotNil(t, patch)
+		assert.Equal(t, map[string]any{"status": "done"}, patch.UpdatedFields["properties"])
+	})
+
+	t.Run("keeps the other properties of the card", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		card := bulkTestCard("card-1", board.ID, map[string]interface{}{
+			"status":   "review",
+			"assignee": "user-1",
+			"estimate": "3",
+		})
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil).AnyTimes()
+		th.Store.EXPECT().GetGitHubStatusRules(board.ID).Return(rules, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().GetStatusTransitionRules(board.ID).Return([]*model.StatusTransitionRule{}, nil)
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardAndCardByID(gomock.Any()).Return(board, card, nil).AnyTimes()
+		th.Store.EXPECT().GetCardParent(gomock.Any()).Return(nil, model.NewErrNotFound("parent")).AnyTimes()
+
+		var patch *model.BlockPatch
+		th.Store.EXPECT().PatchBlock("card-1", gomock.Any(), model.SystemUserID).DoAndReturn(
+			func(_ string, p *model.BlockPatch, _ string) error {
+				patch = p
+				return nil
+			})
+
+		require.NoError(t, th.App.ApplyGitHubStatusRule("card-1", model.GitHubEventPRMerged))
+		require.NotNil(t, patch)
+		assert.Equal(t, map[string]any{
+			"status":   "done",
+			"assignee": "user-1",
+			"estimate": "3",
+		}, patch.UpdatedFields["properties"])
+	})
+
+	t.Run("no rule for the event", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		card := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "review"})
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+		th.Store.EXPECT().GetGitHubStatusRules(board.ID).Return(rules, nil)
+
+		require.NoError(t, th.App.ApplyGitHubStatusRule("card-1", model.GitHubEventPROpened))
+	})
+
+	t.Run("card already in the status", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		card := bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "done"})
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+		th.Store.EXPECT().GetGitHubStatusRules(board.ID).Return(rules, nil)
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		require.NoError(t, th.App.ApplyGitHubStatusRule("card-1", model.GitHubEventPRMerged))
+	})
+}
@@ -27,6 +27,8 @@ type TestHelper struct {
 	FilesBackend *mocks.FileBackend
 	logger       mlog.LoggerIFace
 	API          *mmpermissionsMocks.MockAPI
+
+	PermissionsStore *permissionsMocks.MockStore
 }
 
 func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
@@ -69,5 +71,7 @@ func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
 		FilesBackend: filesBackend,
 		logger:       logger,
 		API:          mockAPI,
+
+		PermissionsStore: mockStore,
 	}, tearDown
 }
@@ -166,6 +166,7 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (*mo
 	now := utils.GetMillis()
 	var boardID string
 	var boardMembers []*model.BoardMember
+	var worklogs []*model.Worklog
 
 	lineNum := 1
 	firstLine := true
@@ -241,6 +242,12 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (*mo
 						return nil, fmt.Errorf("invalid board Member in archive line %d: %w", lineNum, err2)
 					}
 					boardMembers = append(boardMembers, boardMember)
+				case "worklog":
+					var worklog *model.Worklog
+					if err2 := json.Unmarshal(archiveLine.Data, &worklog); err2 != nil {
+						return nil, fmt.Errorf("invalid worklog in archive line %d: %w", lineNum, err2)
+					}
+					worklogs = append(worklogs, worklog)
 				default:
 					return nil, model.NewErrUnsupportedArchiveLineType(lineNum, archiveLine.Type)
 				}
@@ -262,6 +269,14 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (*mo
 
 	a.fixBoardsandBlocks(boardsAndBlocks, opt)
 
+	// remember the archive ids of the cards to attach the worklogs to the new cards
+	archiveCardIDs := map[*model.Block]string{}
+	for _, block := range boardsAndBlocks.Blocks {
+		if block.Type == model.TypeCard {
+			archiveCardIDs[block] = block.ID
+		}
+	}
+
 	var err error
 	boardsAndBlocks, err = model.GenerateBoardsAndBlocksIDs(boardsAndBlocks, a.logger)
 	if err != nil {
@@ -277,6 +292,10 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (*mo
 		return nil, err
 	}
 
+	if err := a.importWorklogs(worklogs, archiveCardIDs); err != nil {
+		return nil, err
+	}
+
 	// find new board id
 	for _, board := range boardsAndBlocks.Boards {
 		return board, nil
@@ -479,3 +498,35 @@ func parseVersionFile(r io.Reader) (int, error) {
 	}
 	return header.Version, nil
 }
+
+// importWorklogs creates the worklog entries of an imported board on the new
+// ids of their cards. Entries of cards that were not imported are skipped.
+func (a *App) importWorklogs(worklogs []*model.Worklog, archiveCardIDs map[*model.Block]string) error {
+	if len(worklogs) == 0 {
+		return nil
+	}
+
+	cards := make(map[string]*model.Block, len(archiveCardIDs))
+	for block, archiveID := range archiveCardIDs {
+		cards[archiveID] = block
+	}
+
+	for _, worklog := range worklogs {
+		card, ok := cards[worklog.CardID]
+		if !ok {
+			a.logger.Debug("skipping worklog of a card not imported",
+				mlog.String("worklogID", worklog.ID),
+				mlog.String("cardID", worklog.CardID),
+			)
+			continue
+		}
+		worklog.ID = ""
+		worklog.CardID = card.ID
+		worklog.BoardID = card.BoardID
+		worklog.DeleteAt = 0
+		if _, err := a.store.CreateWorklog(worklog); err != nil {
+			return fmt.Errorf("cannot import worklog: %w", err)
+		}
+	}
+	return nil
+}
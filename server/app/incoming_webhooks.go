@@ -0,0 +1,127 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+// CreateIncomingWebhook creates an incoming webhook with a new token.
+func (a *App) CreateIncomingWebhook(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	webhook.ID = ""
+	webhook.Token = ""
+	webhook.CreateAt = 0
+
+	created, err := a.store.CreateIncomingWebhook(webhook)
+	if errors.Is(err, model.ErrInvalidIncomingWebhook) {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetIncomingWebhook returns an incoming webhook.
+func (a *App) GetIncomingWebhook(webhookID string) (*model.IncomingWebhook, error) {
+	return a.store.GetIncomingWebhook(webhookID)
+}
+
+// GetIncomingWebhooks returns the incoming webhooks of a board.
+func (a *App) GetIncomingWebhooks(boardID string) ([]*model.IncomingWebhook, error) {
+	return a.store.GetIncomingWebhooks(boardID)
+}
+
+// PatchIncomingWebhook updates an incoming webhook, optionally replacing its
+// token.
+func (a *App) PatchIncomingWebhook(webhookID string, patch *model.IncomingWebhookPatch) (*model.IncomingWebhook, error) {
+	existing, err := a.store.GetIncomingWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := a.store.UpdateIncomingWebhook(existing.Patch(patch))
+	if errors.Is(err, model.ErrInvalidIncomingWebhook) {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteIncomingWebhook deletes an incoming webhook.
+func (a *App) DeleteIncomingWebhook(webhookID string) error {
+	return a.store.DeleteIncomingWebhook(webhookID)
+}
+
+// ExecuteIncomingWebhook maps a payload received on an incoming webhook to a
+// card. Payloads that carry a card code patch that card, other payloads
+// create a new card. Cards are changed on behalf of the webhook creator, who
+// must still be allowed to manage the board cards.
+func (a *App) ExecuteIncomingWebhook(token string, payload map[string]interface{}) (*model.Card, error) {
+	webhook, err := a.store.GetIncomingWebhookByToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if !webhook.Enabled {
+		return nil, model.NewErrForbidden("incoming webhook is disabled")
+	}
+
+	userID := webhook.CreatedBy
+	if !a.permissions.HasPermissionToBoard(userID, webhook.BoardID, model.PermissionManageBoardCards) {
+		return nil, model.NewErrPermission("incoming webhook creator cannot manage the board cards")
+	}
+
+	board, err := a.store.GetBoard(webhook.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := model.MapIncomingWebhookPayload(payload, webhook.FieldMapping, schema, a.cardQueryUserResolver(userID))
+	if errors.Is(err, model.ErrInvalidIncomingWebhookPayload) {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if values.Title == nil && len(values.Properties) == 0 {
+		return nil, model.NewErrBadRequest("payload does not set any card field")
+	}
+
+	if values.Code == "" {
+		card := &model.Card{Properties: values.Properties}
+		if values.Title != nil {
+			card.Title = *values.Title
+		}
+		card.PopulateWithBoardID(board.ID)
+		if err := card.CheckValid(); err != nil {
+			return nil, model.NewErrBadRequest(err.Error())
+		}
+		return a.CreateCard(card, board.ID, userID, false)
+	}
+
+	block, cardBoard, err := a.store.GetCardByCode(values.Code)
+	if err != nil {
+		return nil, err
+	}
+	if cardBoard.ID != board.ID {
+		return nil, model.NewErrNotFound(fmt.Sprintf("card %s", values.Code))
+	}
+
+	patch := &model.CardPatch{
+		Title:             values.Title,
+		UpdatedProperties: values.Properties,
+	}
+	return a.PatchCard(patch, block.ID, userID, false)
+}
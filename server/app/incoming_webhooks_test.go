@@ -0,0 +1,151 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func TestExecuteIncomingWebhook(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	teamID := utils.NewID(utils.IDTypeTeam)
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: teamID,
+		Code:   "OPS",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "severity",
+				"name": "Severity",
+				"type": model.PropTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "sev-high", "value": "High"},
+				},
+			},
+		},
+	}
+	webhook := &model.IncomingWebhook{
+		ID:           "webhook-id",
+		BoardID:      board.ID,
+		Token:        "token",
+		FieldMapping: map[string]string{"alert.name": "title", "alert.severity": "Severity", "ticket": "code"},
+		Enabled:      true,
+		CreatedBy:    userID,
+	}
+
+	expectCanManageCards := func() {
+		th.API.EXPECT().HasPermissionToTeam(userID, teamID, model.PermissionViewTeam).Return(true)
+		th.API.EXPECT().HasPermissionToTeam(userID, teamID, model.PermissionManageTeam).Return(false)
+		th.PermissionsStore.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.PermissionsStore.EXPECT().GetMemberForBoard(board.ID, userID).Return(&model.BoardMember{SchemeEditor: true}, nil)
+	}
+
+	t.Run("unknown token", func(t *testing.T) {
+		th.Store.EXPECT().GetIncomingWebhookByToken("unknown").Return(nil, model.NewErrNotFound("incoming webhook"))
+
+		_, err := th.App.ExecuteIncomingWebhook("unknown", map[string]interface{}{})
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("disabled webhook", func(t *testing.T) {
+		disabled := *webhook
+		disabled.Enabled = false
+		th.Store.EXPECT().GetIncomingWebhookByToken("token").Return(&disabled, nil)
+
+		_, err := th.App.ExecuteIncomingWebhook("token", map[string]interface{}{})
+		require.True(t, model.IsErrForbidden(err))
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		th.Store.EXPECT().GetIncomingWebhookByToken("token").Return(webhook, nil)
+		expectCanManageCards()
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+
+		_, err := th.App.ExecuteIncomingWebhook("token", map[string]interface{}{
+			"alert": map[string]interface{}{"severity": "unknown"},
+		})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("creates a card", func(t *testing.T) {
+		th.Store.EXPECT().GetIncomingWebhookByToken("token").Return(webhook, nil)
+		expectCanManageCards()
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).Times(3)
+		th.Store.EXPECT().GetNextCardNumber(board.ID).Return(int64(4), nil)
+		th.Store.EXPECT().GetBlock(gomock.Any()).Return(nil, model.NewErrNotFound("block not found"))
+		th.Store.EXPECT().InsertBlock(gomock.Any(), userID).Return(nil)
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		card, err := th.App.ExecuteIncomingWebhook("token", map[string]interface{}{
+			"alert": map[string]interface{}{"name": "Disk full", "severity": "high"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Disk full", card.Title)
+		assert.Equal(t, "OPS-4", card.Code)
+		assert.Equal(t, "sev-high", card.Properties["severity"])
+		assert.Equal(t, userID, card.CreatedBy)
+	})
+
+	t.Run("card code of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetIncomingWebhookByToken("token").Return(webhook, nil)
+		expectCanManageCards()
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetCardByCode("XYZ-1").Return(&model.Block{ID: "card-id", Type: model.TypeCard}, &model.Board{ID: "other-board"}, nil)
+
+		_, err := th.App.ExecuteIncomingWebhook("token", map[string]interface{}{
+			"ticket": "XYZ-1",
+			"alert":  map[string]interface{}{"name": "Disk full"},
+		})
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("updates a card keeping its unmapped properties", func(t *testing.T) {
+		block := model.Card2Block(&model.Card{
+			ID:        "card-id",
+			BoardID:   board.ID,
+			CreatedBy: userID,
+			Title:     "Disk almost full",
+			Properties: map[string]any{
+				"status":   "in-progress",
+				"assignee": userID,
+				"due":      "1700000000000",
+			},
+		})
+		th.Store.EXPECT().GetIncomingWebhookByToken("token").Return(webhook, nil)
+		expectCanManageCards()
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil).AnyTimes()
+		th.Store.EXPECT().GetCardByCode("OPS-4").Return(block, board, nil)
+		th.Store.EXPECT().GetBlock("card-id").DoAndReturn(func(string) (*model.Block, error) {
+			return block, nil
+		}).AnyTimes()
+		th.Store.EXPECT().PatchBlock("card-id", gomock.Any(), userID).DoAndReturn(func(_ string, patch *model.BlockPatch, _ string) error {
+			block = patch.Patch(block)
+			return nil
+		})
+		th.Store.EXPECT().GetMembersForBoard(board.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		card, err := th.App.ExecuteIncomingWebhook("token", map[string]interface{}{
+			"ticket": "OPS-4",
+			"alert":  map[string]interface{}{"severity": "high"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"severity": "sev-high",
+			"status":   "in-progress",
+			"assignee": userID,
+			"due":      "1700000000000",
+		}, card.Properties)
+		assert.Equal(t, "Disk almost full", card.Title)
+	})
+}
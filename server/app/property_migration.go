@@ -0,0 +1,68 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+// MigrateCardProperty changes the type of a card property of a board, or
+// merges some of its options, and rewrites the values of the affected cards
+// in the same transaction as the property. With dryRun set, it only reports
+// how many cards would change.
+func (a *App) MigrateCardProperty(boardID string, migration *model.PropertyMigration, userID string, dryRun bool) (*model.PropertyMigrationResult, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+	cards := make([]*model.Card, 0, len(blocks))
+	for _, block := range blocks {
+		card, err := model.Block2Card(block)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+
+	plan, err := model.PlanPropertyMigration(board, cards, migration)
+	if err != nil {
+		return nil, err
+	}
+	plan.Result.DryRun = dryRun
+	if dryRun {
+		return plan.Result, nil
+	}
+
+	pbab := &model.PatchBoardsAndBlocks{
+		BoardIDs: []string{board.ID},
+		BoardPatches: []*model.BoardPatch{
+			{UpdatedCardProperties: []map[string]interface{}{plan.Property}},
+		},
+		BlockIDs:     plan.CardIDs,
+		BlockPatches: make([]*model.BlockPatch, 0, len(plan.CardIDs)),
+	}
+	for _, properties := range plan.CardProperties {
+		pbab.BlockPatches = append(pbab.BlockPatches, &model.BlockPatch{
+			UpdatedFields: map[string]any{"properties": properties},
+		})
+	}
+
+	bab, err := a.PatchBoardsAndBlocks(pbab, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// formulas may read the property differently with its new type
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.updateBoardFormulas(bab.Boards[0])
+		return nil
+	})
+
+	return plan.Result, nil
+}
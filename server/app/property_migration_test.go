@@ -0,0 +1,69 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateCardProperty(t *testing.T) {
+	board := &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "owner", "name": "Owner", "type": "person"},
+		},
+	}
+	withOwner := bulkTestCard("card-1", board.ID, map[string]interface{}{"owner": "user-1"})
+	withoutOwner := bulkTestCard("card-2", board.ID, map[string]interface{}{})
+	migration := &model.PropertyMigration{PropertyID: "owner", Type: model.PropTypeMultiPerson}
+
+	t.Run("dry run", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]*model.Block{withOwner, withoutOwner}, nil)
+
+		result, err := th.App.MigrateCardProperty(board.ID, migration, "user-id", true)
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 1, result.CardsChecked)
+		assert.Equal(t, 1, result.CardsChanged)
+		assert.Equal(t, model.PropTypeMultiPerson, result.Property["type"])
+	})
+
+	t.Run("patches the board and cards together", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		migrated := *board
+		migrated.CardProperties = []map[string]interface{}{
+			{"id": "owner", "name": "Owner", "type": "multiPerson", "options": []interface{}{}},
+		}
+
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).Return(nil, nil).AnyTimes()
+		th.Store.EXPECT().GetBoardAndCardByID(gomock.Any()).Return(board, nil, nil).AnyTimes()
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return([]*model.Block{withOwner, withoutOwner}, nil)
+		th.Store.EXPECT().GetBlocksByIDs([]string{"card-1"}).Return([]*model.Block{withOwner}, nil)
+		th.Store.EXPECT().PatchBoardsAndBlocks(gomock.Any(), "user-id").DoAndReturn(func(pbab *model.PatchBoardsAndBlocks, _ string) (*model.BoardsAndBlocks, error) {
+			require.Equal(t, []string{board.ID}, pbab.BoardIDs)
+			assert.Equal(t, model.PropTypeMultiPerson, pbab.BoardPatches[0].UpdatedCardProperties[0]["type"])
+			require.Equal(t, []string{"card-1"}, pbab.BlockIDs)
+			assert.Equal(t, map[string]any{"owner": []any{"user-1"}}, pbab.BlockPatches[0].UpdatedFields["properties"])
+			return &model.BoardsAndBlocks{Boards: []*model.Board{&migrated}, Blocks: []*model.Block{withOwner}}, nil
+		})
+
+		result, err := th.App.MigrateCardProperty(board.ID, migration, "user-id", false)
+		require.NoError(t, err)
+		assert.False(t, result.DryRun)
+		assert.Equal(t, 1, result.CardsChanged)
+	})
+}
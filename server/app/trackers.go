@@ -0,0 +1,50 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/tracker"
+)
+
+// GetTrackerProvider returns an issue tracker. GitLab and Jira are only
+// available once their site and credentials are set in the plugin settings.
+func (a *App) GetTrackerProvider(id model.TrackerProvider) (tracker.Provider, error) {
+	switch id {
+	case model.TrackerProviderGitHub:
+		githubService := a.GetGitHubService()
+		if githubService == nil {
+			return nil, errGitHubUnavailable
+		}
+		return tracker.NewGitHubProvider(githubService), nil
+
+	case model.TrackerProviderGitLab:
+		if a.config.GitLabURL == "" || a.config.GitLabAccessToken == "" {
+			return nil, fmt.Errorf("gitlab: %w", tracker.ErrNotConfigured)
+		}
+		return tracker.NewGitLabProvider(a.config.GitLabURL, a.config.GitLabAccessToken), nil
+
+	case model.TrackerProviderJira:
+		if a.config.JiraURL == "" || a.config.JiraAPIToken == "" {
+			return nil, fmt.Errorf("jira: %w", tracker.ErrNotConfigured)
+		}
+		return tracker.NewJiraProvider(a.config.JiraURL, a.config.JiraEmail, a.config.JiraAPIToken), nil
+	}
+	return nil, model.NewErrBadRequest(fmt.Sprintf("invalid issue tracker %q", id))
+}
+
+// GetBoardTrackerProvider returns the issue tracker selected by a board.
+func (a *App) GetBoardTrackerProvider(boardID string) (tracker.Provider, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	id, err := model.GetBoardTrackerProvider(board)
+	if err != nil {
+		return nil, err
+	}
+	return a.GetTrackerProvider(id)
+}
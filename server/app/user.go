@@ -30,6 +30,12 @@ func (a *App) SearchTeamUsers(teamID string, searchQuery string, asGuestID strin
 }
 
 func (a *App) UpdateUserConfig(userID string, patch model.UserPreferencesPatch) ([]mmModel.Preference, error) {
+	if value, ok := patch.UpdatedFields[model.PreferenceActivityDigest]; ok {
+		if frequency := model.ActivityDigestFrequency(value); frequency != model.ActivityDigestOff && frequency.Period() == 0 {
+			return nil, model.NewErrBadRequest("invalid activity digest frequency " + value)
+		}
+	}
+
 	updatedPreferences, err := a.store.PatchUserPreferences(userID, patch)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,103 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+// GetCardGroupsForView returns the cards shown by a view, filtered, sorted and
+// grouped the same way the webapp displays them. Views that are not grouped
+// return a single group holding all of their cards.
+func (a *App) GetCardGroupsForView(boardID, viewID, userID string) ([]*model.ViewCardGroup, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	viewBlock, err := a.store.GetBlock(viewID)
+	if err != nil {
+		return nil, err
+	}
+	if viewBlock.BoardID != boardID || viewBlock.Type != model.TypeView {
+		return nil, model.NewErrNotFound("view ID=" + viewID)
+	}
+
+	view, err := model.ParseViewFields(viewBlock)
+	if err != nil {
+		return nil, model.NewErrBadRequest(err.Error())
+	}
+	if !view.IsVisibleTo(viewBlock, userID) {
+		return nil, model.NewErrPermission("access denied to view")
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse property schema for board %s: %w", boardID, err)
+	}
+
+	cardBlocks, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]*model.Card, 0, len(cardBlocks))
+	for _, block := range cardBlocks {
+		card, err := model.Block2Card(block)
+		if err != nil {
+			return nil, fmt.Errorf("Block2Card fail: %w", err)
+		}
+		// template cards are never shown in views
+		if card.IsTemplate {
+			continue
+		}
+		a.populateCardCode(card, board)
+		model.ComputeFormulas(card, schema)
+		cards = append(cards, card)
+	}
+
+	lastCommentAt, err := a.getLastCommentTimes(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards = model.FilterCards(cards, &view.Filter, schema)
+	model.SortCards(cards, view, schema, a.store, lastCommentAt)
+
+	isGroupedView := view.ViewType == model.ViewTypeBoard || view.ViewType == model.ViewTypeTable
+	if isGroupedView && view.GroupByID != "" {
+		if groups := model.GroupCards(cards, view, schema); groups != nil {
+			return groups, nil
+		}
+	}
+
+	return []*model.ViewCardGroup{{Cards: cards}}, nil
+}
+
+// GetCardsForView returns the cards shown by a view in display order.
+func (a *App) GetCardsForView(boardID, viewID, userID string) ([]*model.Card, error) {
+	groups, err := a.GetCardGroupsForView(boardID, viewID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return model.FlattenCardGroups(groups), nil
+}
+
+// getLastCommentTimes returns the update time of the most recent comment of each card of a board.
+func (a *App) getLastCommentTimes(boardID string) (map[string]int64, error) {
+	comments, err := a.store.GetBlocksWithType(boardID, model.TypeComment)
+	if err != nil {
+		return nil, err
+	}
+
+	lastCommentAt := make(map[string]int64, len(comments))
+	for _, comment := range comments {
+		if comment.UpdateAt > lastCommentAt[comment.ParentID] {
+			lastCommentAt[comment.ParentID] = comment.UpdateAt
+		}
+	}
+	return lastCommentAt, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCardsForView(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	userID := utils.NewID(utils.IDTypeUser)
+	board := &model.Board{
+		ID:   utils.NewID(utils.IDTypeBoard),
+		Code: "TB",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": "select",
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do"},
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+		},
+	}
+
+	makeCard := func(title string, number int64, status string, isTemplate bool) *model.Block {
+		return &model.Block{
+			ID:      utils.NewID(utils.IDTypeCard),
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Title:   title,
+			Number:  number,
+			Fields: map[string]interface{}{
+				"isTemplate": isTemplate,
+				"properties": map[string]interface{}{"status": status},
+			},
+		}
+	}
+	cardBlocks := []*model.Block{
+		makeCard("b card", 1, "todo", false),
+		makeCard("a card", 2, "todo", false),
+		makeCard("c card", 3, "done", false),
+		makeCard("template", 4, "todo", true),
+	}
+
+	view := &model.Block{
+		ID:        utils.NewID(utils.IDTypeView),
+		BoardID:   board.ID,
+		Type:      model.TypeView,
+		CreatedBy: userID,
+		Fields: map[string]interface{}{
+			"viewType":    "table",
+			"sortOptions": []interface{}{map[string]interface{}{"propertyId": "__title", "reversed": false}},
+			"filter": map[string]interface{}{
+				"operation": "and",
+				"filters": []interface{}{
+					map[string]interface{}{"propertyId": "status", "condition": "includes", "values": []interface{}{"todo"}},
+				},
+			},
+		},
+	}
+
+	t.Run("applies filters and sort options", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlock(view.ID).Return(view, nil)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return(cardBlocks, nil)
+		th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeComment).Return([]*model.Block{}, nil)
+
+		cards, err := th.App.GetCardsForView(board.ID, view.ID, userID)
+		require.NoError(t, err)
+		require.Len(t, cards, 2)
+		assert.Equal(t, "a card", cards[0].Title)
+		assert.Equal(t, "TB-2", cards[0].Code)
+		assert.Equal(t, "b card", cards[1].Title)
+	})
+
+	t.Run("view from another board", func(t *testing.T) {
+		otherView := &model.Block{ID: utils.NewID(utils.IDTypeView), BoardID: "other", Type: model.TypeView}
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlock(otherView.ID).Return(otherView, nil)
+
+		cards, err := th.App.GetCardsForView(board.ID, otherView.ID, userID)
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, cards)
+	})
+
+	t.Run("owner only view of another user", func(t *testing.T) {
+		privateView := &model.Block{
+			ID:        utils.NewID(utils.IDTypeView),
+			BoardID:   board.ID,
+			Type:      model.TypeView,
+			CreatedBy: utils.NewID(utils.IDTypeUser),
+			Fields:    map[string]interface{}{"visibility": "owner-only"},
+		}
+		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+		th.Store.EXPECT().GetBlock(privateView.ID).Return(privateView, nil)
+
+		_, err := th.App.GetCardsForView(board.ID, privateView.ID, userID)
+		require.True(t, model.IsErrForbidden(err))
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// CreateWorklog logs time on a card. The board of the entry is the board of
+// the card.
+func (a *App) CreateWorklog(worklog *model.Worklog) (*model.Worklog, error) {
+	block, err := a.store.GetBlock(worklog.CardID)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != model.TypeCard {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("block %s is not a card", block.ID))
+	}
+	worklog.BoardID = block.BoardID
+
+	return a.store.CreateWorklog(worklog)
+}
+
+// GetWorklog returns a worklog entry.
+func (a *App) GetWorklog(worklogID string) (*model.Worklog, error) {
+	return a.store.GetWorklog(worklogID)
+}
+
+// GetWorklogsForCard returns the entries of a card, oldest first.
+func (a *App) GetWorklogsForCard(cardID string) ([]*model.Worklog, error) {
+	return a.store.GetWorklogs(model.QueryWorklogsOptions{CardID: cardID})
+}
+
+// PatchWorklog updates the duration, date or note of a worklog entry.
+func (a *App) PatchWorklog(worklogID string, patch *model.WorklogPatch) (*model.Worklog, error) {
+	worklog, err := a.store.GetWorklog(worklogID)
+	if err != nil {
+		return nil, err
+	}
+	return a.store.UpdateWorklog(patch.Patch(worklog))
+}
+
+// DeleteWorklog deletes a worklog entry. It stays in the compliance history.
+func (a *App) DeleteWorklog(worklogID string) error {
+	return a.store.DeleteWorklog(worklogID)
+}
+
+// populateCardsTimeSpent sets the time logged on each card. Cards are still
+// returned if the totals cannot be read.
+func (a *App) populateCardsTimeSpent(cards ...*model.Card) {
+	cardIDs := make([]string, 0, len(cards))
+	for _, card := range cards {
+		cardIDs = append(cardIDs, card.ID)
+	}
+	totals, err := a.store.GetWorklogTotals(cardIDs)
+	if err != nil {
+		a.logger.Warn("populateCardsTimeSpent: could not get worklog totals", mlog.Err(err))
+		return
+	}
+	for _, card := range cards {
+		card.TimeSpent = totals[card.ID]
+	}
+}
+
+// GetWorklogReport aggregates the time logged on the given boards, or on
+// all the boards of a team the user can see if none is given. Public boards
+// the user is not a member of are only included if includePublicBoards is set.
+func (a *App) GetWorklogReport(teamID string, userID string, includePublicBoards bool, opts *model.WorklogReportOptions) (*model.WorklogReport, error) {
+	if err := opts.IsValid(); err != nil {
+		return nil, err
+	}
+
+	boards, err := a.GetBoardsForUserAndTeam(userID, teamID, includePublicBoards)
+	if err != nil {
+		return nil, err
+	}
+	boardsByID := make(map[string]*model.Board, len(boards))
+	for _, board := range boards {
+		boardsByID[board.ID] = board
+	}
+
+	boardIDs := opts.BoardIDs
+	if len(boardIDs) == 0 {
+		boardIDs = make([]string, 0, len(boards))
+		for _, board := range boards {
+			boardIDs = append(boardIDs, board.ID)
+		}
+	}
+	for _, boardID := range boardIDs {
+		if _, ok := boardsByID[boardID]; !ok {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("board %s is not in team %s", boardID, teamID))
+		}
+	}
+
+	var worklogs []*model.Worklog
+	if len(boardIDs) > 0 {
+		worklogs, err = a.store.GetWorklogs(model.QueryWorklogsOptions{
+			BoardIDs: boardIDs,
+			UserID:   opts.UserID,
+			From:     opts.From,
+			To:       opts.To,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var keys func(*model.Worklog) []string
+	switch opts.GroupBy {
+	case model.WorklogGroupByUser:
+		keys = func(w *model.Worklog) []string { return []string{w.UserID} }
+	case model.WorklogGroupByBoard:
+		keys = func(w *model.Worklog) []string { return []string{w.BoardID} }
+	case model.WorklogGroupByCard:
+		keys = func(w *model.Worklog) []string { return []string{w.CardID} }
+	case model.WorklogGroupByDate:
+		keys = func(w *model.Worklog) []string { return []string{model.WorklogDateKey(w.Date)} }
+	case model.WorklogGroupByProperty:
+		if keys, err = a.worklogPropertyKeys(worklogs, boardsByID, opts.Property); err != nil {
+			return nil, err
+		}
+	}
+
+	report := model.BuildWorklogReport(worklogs, opts.GroupBy, keys)
+	report.From = opts.From
+	report.To = opts.To
+	return report, nil
+}
+
+// worklogPropertyKeys returns the function giving the report keys of the
+// entries from the value of the named property of their cards.
+func (a *App) worklogPropertyKeys(worklogs []*model.Worklog, boards map[string]*model.Board, property string) (func(*model.Worklog) []string, error) {
+	cardIDs := []string{}
+	seen := map[string]bool{}
+	for _, worklog := range worklogs {
+		if !seen[worklog.CardID] {
+			seen[worklog.CardID] = true
+			cardIDs = append(cardIDs, worklog.CardID)
+		}
+	}
+
+	cards := map[string]*model.Card{}
+	if len(cardIDs) > 0 {
+		blocks, err := a.store.GetBlocksByIDs(cardIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, block := range blocks {
+			if card, err := model.Block2Card(block); err == nil {
+				cards[card.ID] = card
+			}
+		}
+	}
+
+	// the property is found by name, as its id differs from board to board
+	defs := map[string]*model.PropDef{}
+	for boardID, board := range boards {
+		schema, err := model.ParsePropertySchema(board)
+		if err != nil {
+			continue
+		}
+		for _, def := range schema {
+			if strings.EqualFold(def.Name, property) {
+				def := def
+				defs[boardID] = &def
+				break
+			}
+		}
+	}
+
+	return func(w *model.Worklog) []string {
+		card, ok := cards[w.CardID]
+		def := defs[w.BoardID]
+		if !ok || def == nil {
+			return []string{""}
+		}
+		return model.WorklogPropertyKeys(*def, card.Properties[def.ID])
+	}, nil
+}
+
+// GetWorklogsComplianceHistory returns the worklog entries modified since a
+// time, deleted ones included if requested.
+func (a *App) GetWorklogsComplianceHistory(opts model.QueryWorklogsComplianceHistoryOptions) ([]*model.Worklog, bool, error) {
+	return a.store.GetWorklogsComplianceHistory(opts)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWorklog(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	card := bulkTestCard("card-1", "board-id", map[string]interface{}{})
+
+	t.Run("uses the board of the card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+		th.Store.EXPECT().CreateWorklog(gomock.Any()).DoAndReturn(func(w *model.Worklog) (*model.Worklog, error) {
+			return w, nil
+		})
+
+		worklog, err := th.App.CreateWorklog(&model.Worklog{CardID: "card-1", BoardID: "other-board", UserID: "user-id", Minutes: 30})
+		require.NoError(t, err)
+		assert.Equal(t, "board-id", worklog.BoardID)
+	})
+
+	t.Run("only on cards", func(t *testing.T) {
+		view := &model.Block{ID: "view-1", BoardID: "board-id", Type: model.TypeView}
+		th.Store.EXPECT().GetBlock("view-1").Return(view, nil)
+
+		_, err := th.App.CreateWorklog(&model.Worklog{CardID: "view-1", UserID: "user-id", Minutes: 30})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
+func TestGetWorklogReport(t *testing.T) {
+	board := &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To Do"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+		},
+	}
+	worklogs := []*model.Worklog{
+		{BoardID: board.ID, CardID: "card-1", UserID: "alice", Minutes: 30},
+		{BoardID: board.ID, CardID: "card-2", UserID: "bob", Minutes: 60},
+		{BoardID: board.ID, CardID: "card-1", UserID: "bob", Minutes: 15},
+	}
+
+	t.Run("by property", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id", true).Return([]*model.Board{board}, nil)
+		th.Store.EXPECT().GetWorklogs(model.QueryWorklogsOptions{BoardIDs: []string{board.ID}, From: 100}).Return(worklogs, nil)
+		th.Store.EXPECT().GetBlocksByIDs([]string{"card-1", "card-2"}).Return([]*model.Block{
+			bulkTestCard("card-1", board.ID, map[string]interface{}{"status": "done"}),
+			bulkTestCard("card-2", board.ID, map[string]interface{}{}),
+		}, nil)
+
+		opts := &model.WorklogReportOptions{GroupBy: model.WorklogGroupByProperty, Property: "status", From: 100}
+		report, err := th.App.GetWorklogReport("team-id", "user-id", true, opts)
+		require.NoError(t, err)
+		assert.Equal(t, int64(105), report.TotalMinutes)
+		assert.Equal(t, int64(100), report.From)
+		assert.Equal(t, []*model.WorklogReportGroup{
+			{Key: "", Minutes: 60, Entries: 1},
+			{Key: "Done", Minutes: 45, Entries: 2},
+		}, report.Groups)
+	})
+
+	t.Run("without public boards", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		// the public board is not returned for the guest, so it cannot be asked for
+		th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id", false).Return([]*model.Board{}, nil)
+
+		opts := &model.WorklogReportOptions{GroupBy: model.WorklogGroupByUser, BoardIDs: []string{board.ID}}
+		_, err := th.App.GetWorklogReport("team-id", "user-id", false, opts)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("boards outside the team are refused", func(t *testing.T) {
+		th, tearDown := SetupTestHelper(t)
+		defer tearDown()
+
+		th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id", true).Return([]*model.Board{board}, nil)
+
+		opts := &model.WorklogReportOptions{GroupBy: model.WorklogGroupByUser, BoardIDs: []string{"elsewhere"}}
+		_, err := th.App.GetWorklogReport("team-id", "user-id", true, opts)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+}
+
+func TestImportWorklogs(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	card := &model.Block{ID: "new-card", BoardID: "new-board", Type: model.TypeCard}
+	archiveCardIDs := map[*model.Block]string{card: "old-card"}
+	worklogs := []*model.Worklog{
+		{ID: "w1", BoardID: "old-board", CardID: "old-card", UserID: "user-id", Minutes: 20, DeleteAt: 5},
+		{ID: "w2", BoardID: "old-board", CardID: "skipped-card", UserID: "user-id", Minutes: 10},
+	}
+
+	th.Store.EXPECT().CreateWorklog(gomock.Any()).DoAndReturn(func(w *model.Worklog) (*model.Worklog, error) {
+		assert.Empty(t, w.ID)
+		assert.Equal(t, "new-card", w.CardID)
+		assert.Equal(t, "new-board", w.BoardID)
+		assert.Zero(t, w.DeleteAt)
+		return w, nil
+	})
+
+	require.NoError(t, th.App.importWorklogs(worklogs, archiveCardIDs))
+}
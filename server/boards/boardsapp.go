@@ -30,6 +30,16 @@ const (
 
 	notifyFreqCardSecondsKey  = "notify_freq_card_seconds"
 	notifyFreqBoardSecondsKey = "notify_freq_board_seconds"
+
+	dueDateReminderMinutesKey = "due_date_reminder_minutes"
+	dueDateDigestHourKey      = "due_date_digest_hour"
+
+	// Mattermost converts plugin setting keys to lowercase.
+	gitLabURLKey         = "gitlaburl"
+	gitLabAccessTokenKey = "gitlabaccesstoken"
+	jiraURLKey           = "jiraurl"
+	jiraEmailKey         = "jiraemail"
+	jiraAPITokenKey      = "jiraapitoken"
 )
 
 type BoardsEmbed struct {
@@ -120,6 +130,20 @@ func NewBoardsApp(api model.ServicesAPI, manifest *mm_model.Manifest) (*BoardsAp
 	notifyBackends = append(notifyBackends, subscriptionsBackend)
 	mentionsBackend.AddListener(subscriptionsBackend)
 
+	notifyBackends = append(notifyBackends, createWebhooksNotifyBackend(backendParams))
+
+	automationBackend, err := createAutomationNotifyBackend(backendParams)
+	if err != nil {
+		return nil, fmt.Errorf("error creating automation backend: %w", err)
+	}
+	notifyBackends = append(notifyBackends, automationBackend)
+
+	dueDatesBackend, err := createDueDatesNotifyBackend(backendParams)
+	if err != nil {
+		return nil, fmt.Errorf("error creating due dates backend: %w", err)
+	}
+	notifyBackends = append(notifyBackends, dueDatesBackend)
+
 	params := server.Params{
 		Cfg:                cfg,
 		SingleUserToken:    "",
@@ -108,6 +108,13 @@ func createBoardsConfig(mmconfig mm_model.Config, baseURL string, serverID strin
 		FeatureFlags:             featureFlags,
 		NotifyFreqCardSeconds:    getPluginSettingInt(mmconfig, notifyFreqCardSecondsKey, 120),
 		NotifyFreqBoardSeconds:   getPluginSettingInt(mmconfig, notifyFreqBoardSecondsKey, 86400),
+		DueDateReminderMinutes:   getPluginSettingInt(mmconfig, dueDateReminderMinutesKey, 1440),
+		DueDateDigestHour:        getPluginSettingInt(mmconfig, dueDateDigestHourKey, 9),
+		GitLabURL:                getPluginSettingString(mmconfig, gitLabURLKey),
+		GitLabAccessToken:        getPluginSettingString(mmconfig, gitLabAccessTokenKey),
+		JiraURL:                  getPluginSettingString(mmconfig, jiraURLKey),
+		JiraEmail:                getPluginSettingString(mmconfig, jiraEmailKey),
+		JiraAPIToken:             getPluginSettingString(mmconfig, jiraAPITokenKey),
 		EnableDataRetention:      enableBoardsDeletion,
 		DataRetentionDays:        *mmconfig.DataRetentionSettings.BoardsRetentionDays,
 		TeammateNameDisplay:      *mmconfig.TeamSettings.TeammateNameDisplay,
@@ -155,3 +162,15 @@ func getPluginSettingInt(mmConfig mm_model.Config, key string, def int) int {
 	}
 	return int(math.Round(valFloat))
 }
+
+func getPluginSettingString(mmConfig mm_model.Config, key string) string {
+	val, ok := getPluginSetting(mmConfig, key)
+	if !ok {
+		return ""
+	}
+	valString, ok := val.(string)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(valString)
+}
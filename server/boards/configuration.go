@@ -150,6 +150,11 @@ func (b *BoardsApp) OnConfigurationChange() error {
 	b.logger.Info("Figma token set in server config", mlog.Int("tokenLength", len(figmaToken)))
 	b.server.Config().AllowedBotUserIDs = allowedBotUserIDs
 	b.logger.Info("Allowed bot user IDs set in server config", mlog.Int("count", len(allowedBotUserIDs)))
+	b.server.Config().GitLabURL = getPluginSettingString(*mmconfig, gitLabURLKey)
+	b.server.Config().GitLabAccessToken = getPluginSettingString(*mmconfig, gitLabAccessTokenKey)
+	b.server.Config().JiraURL = getPluginSettingString(*mmconfig, jiraURLKey)
+	b.server.Config().JiraEmail = getPluginSettingString(*mmconfig, jiraEmailKey)
+	b.server.Config().JiraAPIToken = getPluginSettingString(*mmconfig, jiraAPITokenKey)
 
 	b.server.UpdateAppConfig()
 	b.wsPluginAdapter.BroadcastConfigChange(*b.server.App().GetClientConfig())
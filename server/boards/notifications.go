@@ -9,12 +9,16 @@ import (
 
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/config"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/notifyautomation"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/notifyduedates"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/notifymentions"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/notifysubscriptions"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/notifywebhooks"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/notify/plugindelivery"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
 
+	mm_model "github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
@@ -65,6 +69,48 @@ func createSubscriptionsNotifyBackend(params notifyBackendParams) (*notifysubscr
 	return backend, nil
 }
 
+func createWebhooksNotifyBackend(params notifyBackendParams) *notifywebhooks.Backend {
+	backendParams := notifywebhooks.BackendParams{
+		AppAPI: params.appAPI,
+		Logger: params.logger,
+	}
+	return notifywebhooks.New(backendParams)
+}
+
+func createAutomationNotifyBackend(params notifyBackendParams) (*notifyautomation.Backend, error) {
+	delivery, err := createDelivery(params.servicesAPI, params.serverRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifyautomation.BackendParams{
+		ServerRoot:  params.serverRoot,
+		AppAPI:      params.appAPI,
+		Permissions: params.permissions,
+		Delivery:    delivery,
+		Logger:      params.logger,
+	}
+	return notifyautomation.New(backendParams), nil
+}
+
+func createDueDatesNotifyBackend(params notifyBackendParams) (*notifyduedates.Backend, error) {
+	delivery, err := createDelivery(params.servicesAPI, params.serverRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifyduedates.BackendParams{
+		ServerRoot:      params.serverRoot,
+		AppAPI:          params.appAPI,
+		Permissions:     params.permissions,
+		Delivery:        delivery,
+		Logger:          params.logger,
+		ReminderMinutes: params.cfg.DueDateReminderMinutes,
+		DigestHour:      params.cfg.DueDateDigestHour,
+	}
+	return notifyduedates.New(backendParams), nil
+}
+
 func createDelivery(servicesAPI model.ServicesAPI, serverRoot string) (*plugindelivery.PluginDelivery, error) {
 	bot := model.FocalboardBot
 
@@ -79,6 +125,14 @@ func createDelivery(servicesAPI model.ServicesAPI, serverRoot string) (*pluginde
 type appIface interface {
 	CreateSubscription(sub *model.Subscription) (*model.Subscription, error)
 	AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, error)
+	EnqueueWebhookEvent(board *model.Board, payload *model.WebhookEventPayload) error
+	GetCardByID(cardID string) (*model.Card, error)
+	GetCardsForBoard(boardID string, page int, perPage int) ([]*model.Card, error)
+	PatchCard(cardPatch *model.CardPatch, cardID string, userID string, disableNotify bool) (*model.Card, error)
+	CreateCard(card *model.Card, boardID string, userID string, disableNotify bool) (*model.Card, error)
+	MoveCard(cardID string, boardID string, userID string) (*model.Card, error)
+	InsertBlockAndNotify(block *model.Block, modifiedByID string, disableNotify bool) error
+	CreateCardRelation(relation *model.CardRelation, boardID string) (*model.CardRelation, error)
 }
 
 // appAPI provides app and store APIs for notification services. Where appropriate calls are made to the
@@ -137,3 +191,91 @@ func (a *appAPI) GetMemberForBoard(boardID, userID string) (*model.BoardMember,
 func (a *appAPI) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, error) {
 	return a.app.AddMemberToBoard(member)
 }
+
+func (a *appAPI) EnqueueWebhookEvent(board *model.Board, payload *model.WebhookEventPayload) error {
+	return a.app.EnqueueWebhookEvent(board, payload)
+}
+
+func (a *appAPI) GetAutomationRules(boardID string) ([]*model.AutomationRule, error) {
+	return a.store.GetAutomationRules(boardID)
+}
+
+func (a *appAPI) GetEnabledAutomationRulesByTrigger(triggerType model.AutomationTriggerType) ([]*model.AutomationRule, error) {
+	return a.store.GetEnabledAutomationRulesByTrigger(triggerType)
+}
+
+func (a *appAPI) ClaimAutomationRuleDueCheck(rule *model.AutomationRule, until int64) (bool, error) {
+	return a.store.ClaimAutomationRuleDueCheck(rule, until)
+}
+
+func (a *appAPI) GetBoard(boardID string) (*model.Board, error) {
+	return a.store.GetBoard(boardID)
+}
+
+func (a *appAPI) GetCardByID(cardID string) (*model.Card, error) {
+	return a.app.GetCardByID(cardID)
+}
+
+func (a *appAPI) GetCardsForBoard(boardID string, page int, perPage int) ([]*model.Card, error) {
+	return a.app.GetCardsForBoard(boardID, page, perPage)
+}
+
+func (a *appAPI) PatchCard(cardPatch *model.CardPatch, cardID string, userID string, disableNotify bool) (*model.Card, error) {
+	return a.app.PatchCard(cardPatch, cardID, userID, disableNotify)
+}
+
+func (a *appAPI) CreateCard(card *model.Card, boardID string, userID string, disableNotify bool) (*model.Card, error) {
+	return a.app.CreateCard(card, boardID, userID, disableNotify)
+}
+
+func (a *appAPI) MoveCard(cardID string, boardID string, userID string) (*model.Card, error) {
+	return a.app.MoveCard(cardID, boardID, userID)
+}
+
+func (a *appAPI) InsertBlockAndNotify(block *model.Block, userID string, disableNotify bool) error {
+	return a.app.InsertBlockAndNotify(block, userID, disableNotify)
+}
+
+func (a *appAPI) CreateCardRelation(relation *model.CardRelation, boardID string) (*model.CardRelation, error) {
+	return a.app.CreateCardRelation(relation, boardID)
+}
+
+func (a *appAPI) GetBoardsForCompliance(opts model.QueryBoardsForComplianceOptions) ([]*model.Board, bool, error) {
+	return a.store.GetBoardsForCompliance(opts)
+}
+
+func (a *appAPI) GetUserTimezone(userID string) (string, error) {
+	return a.store.GetUserTimezone(userID)
+}
+
+func (a *appAPI) ClaimDueDateNotification(notification *model.DueDateNotification) (bool, error) {
+	return a.store.ClaimDueDateNotification(notification)
+}
+
+func (a *appAPI) DeleteDueDateNotificationsBefore(createAt int64) error {
+	return a.store.DeleteDueDateNotificationsBefore(createAt)
+}
+
+func (a *appAPI) GetUserPreferences(userID string) (mm_model.Preferences, error) {
+	return a.store.GetUserPreferences(userID)
+}
+
+func (a *appAPI) UpsertActivityDigestChange(change *model.ActivityDigestChange) error {
+	return a.store.UpsertActivityDigestChange(change)
+}
+
+func (a *appAPI) GetActivityDigestChanges(since int64) ([]*model.ActivityDigestChange, error) {
+	return a.store.GetActivityDigestChanges(since)
+}
+
+func (a *appAPI) DeleteActivityDigestChangesBefore(updateAt int64) error {
+	return a.store.DeleteActivityDigestChangesBefore(updateAt)
+}
+
+func (a *appAPI) GetActivityDigestSentAt(userID string) (int64, error) {
+	return a.store.GetActivityDigestSentAt(userID)
+}
+
+func (a *appAPI) ClaimActivityDigest(userID string, lastSentAt int64, sentAt int64) (bool, error) {
+	return a.store.ClaimActivityDigest(userID, lastSentAt, sentAt)
+}
@@ -10,10 +10,13 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/mattermost/mattermost-plugin-boards/server/api"
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/tracker"
 
 	mmModel "github.com/mattermost/mattermost/server/public/model"
 )
@@ -363,6 +366,23 @@ func (c *Client) GetCards(boardID string, page int, perPage int) ([]*model.Card,
 	return cards, BuildResponse(r)
 }
 
+func (c *Client) GetCardsForView(boardID, viewID string, page int, perPage int) ([]*model.Card, *Response) {
+	url := fmt.Sprintf("%s/views/%s/cards?page=%d&per_page=%d", c.GetBoardRoute(boardID), viewID, page, perPage)
+	r, err := c.DoAPIGet(url, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	defer closeBody(r)
+
+	var cards []*model.Card
+	if err := json.NewDecoder(r.Body).Decode(&cards); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return cards, BuildResponse(r)
+}
+
 func (c *Client) PatchCard(cardID string, cardPatch *model.CardPatch, disableNotify bool) (*model.Card, *Response) {
 	var queryParams string
 	if disableNotify {
@@ -681,6 +701,38 @@ func (c *Client) SearchBoardsForUser(teamID, term string, field model.BoardSearc
 	return model.BoardsFromJSON(r.Body), BuildResponse(r)
 }
 
+func (c *Client) SearchCards(teamID, term string, page int, perPage int) ([]*model.Card, *Response) {
+	query := fmt.Sprintf("q=%s&page=%d&per_page=%d", url.QueryEscape(term), page, perPage)
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/cards/search?"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var cards []*model.Card
+	if err := json.NewDecoder(r.Body).Decode(&cards); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return cards, BuildResponse(r)
+}
+
+func (c *Client) SearchBlocks(teamID, terms string, page int, perPage int) ([]*model.BlockSearchResult, *Response) {
+	query := fmt.Sprintf("q=%s&page=%d&per_page=%d", url.QueryEscape(terms), page, perPage)
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/blocks/search?"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var results []*model.BlockSearchResult
+	if err := json.NewDecoder(r.Body).Decode(&results); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return results, BuildResponse(r)
+}
+
 func (c *Client) SearchBoardsForTeam(teamID, term string) ([]*model.Board, *Response) {
 	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards/search?q="+term, "")
 	if err != nil {
@@ -981,3 +1033,806 @@ func (c *Client) UnhideBoard(teamID, categoryID, boardID string) *Response {
 	defer closeBody(r)
 	return BuildResponse(r)
 }
+
+func (c *Client) GetBoardWebhooks(boardID string) ([]*model.BoardWebhook, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/webhooks", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var webhooks []*model.BoardWebhook
+	if err := json.NewDecoder(r.Body).Decode(&webhooks); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return webhooks, BuildResponse(r)
+}
+
+func (c *Client) CreateBoardWebhook(boardID string, webhook *model.BoardWebhook) (*model.BoardWebhook, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/webhooks", toJSON(webhook))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.BoardWebhook
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return created, BuildResponse(r)
+}
+
+func (c *Client) GetBoardWebhook(boardID, webhookID string) (*model.BoardWebhook, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/webhooks/"+webhookID, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var webhook *model.BoardWebhook
+	if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return webhook, BuildResponse(r)
+}
+
+func (c *Client) PatchBoardWebhook(boardID, webhookID string, patch *model.BoardWebhookPatch) (*model.BoardWebhook, *Response) {
+	r, err := c.DoAPIPatch(c.GetBoardRoute(boardID)+"/webhooks/"+webhookID, toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var webhook *model.BoardWebhook
+	if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return webhook, BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardWebhook(boardID, webhookID string) *Response {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/webhooks/"+webhookID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetWebhookDeliveries(boardID, webhookID string, page, perPage int) ([]*model.WebhookDelivery, *Response) {
+	query := fmt.Sprintf("?page=%d&per_page=%d", page, perPage)
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/webhooks/"+webhookID+"/deliveries"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var deliveries []*model.WebhookDelivery
+	if err := json.NewDecoder(r.Body).Decode(&deliveries); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return deliveries, BuildResponse(r)
+}
+
+func (c *Client) GetIncomingWebhooks(boardID string) ([]*model.IncomingWebhook, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/incoming-webhooks", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var webhooks []*model.IncomingWebhook
+	if err := json.NewDecoder(r.Body).Decode(&webhooks); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return webhooks, BuildResponse(r)
+}
+
+func (c *Client) CreateIncomingWebhook(boardID string, webhook *model.IncomingWebhook) (*model.IncomingWebhook, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/incoming-webhooks", toJSON(webhook))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.IncomingWebhook
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return created, BuildResponse(r)
+}
+
+func (c *Client) PatchIncomingWebhook(boardID, webhookID string, patch *model.IncomingWebhookPatch) (*model.IncomingWebhook, *Response) {
+	r, err := c.DoAPIPatch(c.GetBoardRoute(boardID)+"/incoming-webhooks/"+webhookID, toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var webhook *model.IncomingWebhook
+	if err := json.NewDecoder(r.Body).Decode(&webhook); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return webhook, BuildResponse(r)
+}
+
+func (c *Client) DeleteIncomingWebhook(boardID, webhookID string) *Response {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/incoming-webhooks/"+webhookID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+// ExecuteIncomingWebhook posts a payload to an incoming webhook, as an
+// external system would.
+func (c *Client) ExecuteIncomingWebhook(token string, payload map[string]interface{}) (*model.Card, *Response) {
+	r, err := c.DoAPIRequest(http.MethodPost, c.URL+"/hooks/incoming/"+token, toJSON(payload), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return card, BuildResponse(r)
+}
+
+func (c *Client) GetAutomationRules(boardID string) ([]*model.AutomationRule, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/automations", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var rules []*model.AutomationRule
+	if err := json.NewDecoder(r.Body).Decode(&rules); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return rules, BuildResponse(r)
+}
+
+func (c *Client) CreateAutomationRule(boardID string, rule *model.AutomationRule) (*model.AutomationRule, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/automations", toJSON(rule))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.AutomationRule
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return created, BuildResponse(r)
+}
+
+func (c *Client) GetAutomationRule(boardID, ruleID string) (*model.AutomationRule, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/automations/"+ruleID, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var rule *model.AutomationRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return rule, BuildResponse(r)
+}
+
+func (c *Client) PatchAutomationRule(boardID, ruleID string, patch *model.AutomationRulePatch) (*model.AutomationRule, *Response) {
+	r, err := c.DoAPIPatch(c.GetBoardRoute(boardID)+"/automations/"+ruleID, toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var rule *model.AutomationRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return rule, BuildResponse(r)
+}
+
+func (c *Client) DeleteAutomationRule(boardID, ruleID string) *Response {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/automations/"+ruleID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetCardRecurrence(cardID string) (*model.CardRecurrence, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/recurrence", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var recurrence *model.CardRecurrence
+	if err := json.NewDecoder(r.Body).Decode(&recurrence); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return recurrence, BuildResponse(r)
+}
+
+func (c *Client) SetCardRecurrence(cardID string, recurrence *model.CardRecurrence) (*model.CardRecurrence, *Response) {
+	r, err := c.DoAPIPut(c.GetCardRoute(cardID)+"/recurrence", toJSON(recurrence))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updated *model.CardRecurrence
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return updated, BuildResponse(r)
+}
+
+func (c *Client) DeleteCardRecurrence(cardID string) *Response {
+	r, err := c.DoAPIDelete(c.GetCardRoute(cardID)+"/recurrence", "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetCardRelationGraph(cardID string, depth int) (*model.CardRelationGraph, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/relations/graph?depth=%d", c.GetCardRoute(cardID), depth), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var graph *model.CardRelationGraph
+	if err := json.NewDecoder(r.Body).Decode(&graph); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return graph, BuildResponse(r)
+}
+
+func (c *Client) GetBoardSchedule(boardID string) (*model.CardSchedule, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/schedule", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var schedule *model.CardSchedule
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return schedule, BuildResponse(r)
+}
+
+func (c *Client) GetTeamSchedule(teamID string, boardIDs []string) (*model.CardSchedule, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/schedule?board_ids="+strings.Join(boardIDs, ","), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var schedule *model.CardSchedule
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return schedule, BuildResponse(r)
+}
+
+func (c *Client) MoveCard(cardID string, request *model.CardMoveRequest) (*model.CardMoveResult, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/move", toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.CardMoveResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
+func (c *Client) BulkUpdateCards(boardID string, request *model.CardBulkRequest, disableNotify bool) (*model.CardBulkResult, *Response) {
+	var queryParams string
+	if disableNotify {
+		queryParams = "?" + disableNotifyQueryParam
+	}
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards/bulk"+queryParams, toJSON(request))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.CardBulkResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
+func (c *Client) GetCardChildren(cardID string) (*model.CardChildren, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/children", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var children *model.CardChildren
+	if err := json.NewDecoder(r.Body).Decode(&children); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return children, BuildResponse(r)
+}
+
+func (c *Client) GetCardParent(cardID string) (*model.CardParent, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/parent", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var parent *model.CardParent
+	if err := json.NewDecoder(r.Body).Decode(&parent); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return parent, BuildResponse(r)
+}
+
+func (c *Client) SetCardParent(cardID string, parentCardID string) (*model.CardParent, *Response) {
+	r, err := c.DoAPIPut(c.GetCardRoute(cardID)+"/parent", toJSON(&model.CardParent{ParentCardID: parentCardID}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var parent *model.CardParent
+	if err := json.NewDecoder(r.Body).Decode(&parent); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return parent, BuildResponse(r)
+}
+
+func (c *Client) RemoveCardParent(cardID string) *Response {
+	r, err := c.DoAPIDelete(c.GetCardRoute(cardID)+"/parent", "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetCardPropertyIssues(boardID string) (*model.CardPropertyRepairResult, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/properties/issues", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.CardPropertyRepairResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
+func (c *Client) RepairCardProperties(boardID string, dryRun bool) (*model.CardPropertyRepairResult, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/properties/repair?dry_run=%t", c.GetBoardRoute(boardID), dryRun), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.CardPropertyRepairResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
+func (c *Client) MigrateCardProperty(boardID string, migration *model.PropertyMigration, dryRun bool) (*model.PropertyMigrationResult, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/properties/migrate?dry_run=%t", c.GetBoardRoute(boardID), dryRun), toJSON(migration))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.PropertyMigrationResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return result, BuildResponse(r)
+}
+
+func (c *Client) GetCardWorklogs(cardID string) ([]*model.Worklog, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/worklogs", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var worklogs []*model.Worklog
+	if err := json.NewDecoder(r.Body).Decode(&worklogs); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return worklogs, BuildResponse(r)
+}
+
+func (c *Client) CreateWorklog(cardID string, worklog *model.Worklog) (*model.Worklog, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/worklogs", toJSON(worklog))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.Worklog
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return created, BuildResponse(r)
+}
+
+func (c *Client) PatchWorklog(worklogID string, patch *model.WorklogPatch) (*model.Worklog, *Response) {
+	r, err := c.DoAPIPatch("/worklogs/"+worklogID, toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var worklog *model.Worklog
+	if err := json.NewDecoder(r.Body).Decode(&worklog); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return worklog, BuildResponse(r)
+}
+
+func (c *Client) DeleteWorklog(worklogID string) *Response {
+	r, err := c.DoAPIDelete("/worklogs/"+worklogID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetWorklogReport(teamID string, opts *model.WorklogReportOptions) (*model.WorklogReport, *Response) {
+	query := url.Values{}
+	query.Set("group_by", string(opts.GroupBy))
+	if opts.Property != "" {
+		query.Set("property", opts.Property)
+	}
+	if len(opts.BoardIDs) != 0 {
+		query.Set("board_id", strings.Join(opts.BoardIDs, ","))
+	}
+	if opts.UserID != "" {
+		query.Set("user_id", opts.UserID)
+	}
+	if opts.From != 0 {
+		query.Set("from", strconv.FormatInt(opts.From, 10))
+	}
+	if opts.To != 0 {
+		query.Set("to", strconv.FormatInt(opts.To, 10))
+	}
+
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/worklogs/report?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var report *model.WorklogReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return report, BuildResponse(r)
+}
+
+func (c *Client) GetWorklogsComplianceHistory(
+	modifiedSince int64, includeDeleted bool, teamID, boardID string, page, perPage int,
+) (*model.WorklogsComplianceHistoryResponse, *Response) {
+	query := fmt.Sprintf("?modified_since=%d&include_deleted=%t&team_id=%s&board_id=%s&page=%d&per_page=%d",
+		modifiedSince, includeDeleted, teamID, boardID, page, perPage)
+	r, err := c.DoAPIGet("/admin/worklogs_history"+query, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var res *model.WorklogsComplianceHistoryResponse
+	if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return res, BuildResponse(r)
+}
+
+func (c *Client) GetCardGitHubLinks(cardID string) ([]*model.CardGitHubLink, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(cardID)+"/github/links", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var links []*model.CardGitHubLink
+	if err := json.NewDecoder(r.Body).Decode(&links); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return links, BuildResponse(r)
+}
+
+func (c *Client) AddCardGitHubLink(cardID string, link *model.CardGitHubLink) (*model.CardGitHubLink, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(cardID)+"/github/links", toJSON(link))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.CardGitHubLink
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return created, BuildResponse(r)
+}
+
+func (c *Client) DeleteCardGitHubLink(cardID, linkID string) *Response {
+	r, err := c.DoAPIDelete(c.GetCardRoute(cardID)+"/github/links/"+linkID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetGitHubStatusRules(boardID string) ([]*model.GitHubStatusRule, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/github-status-rules", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var rules []*model.GitHubStatusRule
+	if err := json.NewDecoder(r.Body).Decode(&rules); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return rules, BuildResponse(r)
+}
+
+func (c *Client) SaveGitHubStatusRules(boardID string, rules []*model.GitHubStatusRule) *Response {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/github-status-rules", toJSON(rules))
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetBoardGitHubRepos(boardID string) ([]*model.BoardGitHubRepo, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/github/repos", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var repos []*model.BoardGitHubRepo
+	if err := json.NewDecoder(r.Body).Decode(&repos); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return repos, BuildResponse(r)
+}
+
+func (c *Client) ConnectBoardGitHubRepo(boardID string, repo *model.BoardGitHubRepo) (*model.BoardGitHubRepo, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/github/repos", toJSON(repo))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.BoardGitHubRepo
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return created, BuildResponse(r)
+}
+
+func (c *Client) DisconnectBoardGitHubRepo(boardID, repoID string) *Response {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/github/repos/"+repoID, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetGitHubIssueSync(boardID string) (*model.GitHubIssueSync, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/github/issue-sync", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var sync *model.GitHubIssueSync
+	if err := json.NewDecoder(r.Body).Decode(&sync); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return sync, BuildResponse(r)
+}
+
+func (c *Client) SaveGitHubIssueSync(boardID string, sync *model.GitHubIssueSync) (*model.GitHubIssueSync, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/github/issue-sync", toJSON(sync))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var saved *model.GitHubIssueSync
+	if err := json.NewDecoder(r.Body).Decode(&saved); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return saved, BuildResponse(r)
+}
+
+func (c *Client) DeleteGitHubIssueSync(boardID string) *Response {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/github/issue-sync", "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+func (c *Client) GetGitHubIssueSyncStatus(boardID string) (*model.GitHubIssueSyncStatus, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/github/issue-sync/status", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var status *model.GitHubIssueSyncStatus
+	if err := json.NewDecoder(r.Body).Decode(&status); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return status, BuildResponse(r)
+}
+
+func (c *Client) GetTrackerRoute(provider model.TrackerProvider) string {
+	return "/trackers/" + string(provider)
+}
+
+func (c *Client) GetTrackerConnected(provider model.TrackerProvider) (*tracker.ConnectStatus, *Response) {
+	r, err := c.DoAPIGet(c.GetTrackerRoute(provider)+"/connected", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var status *tracker.ConnectStatus
+	if err := json.NewDecoder(r.Body).Decode(&status); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return status, BuildResponse(r)
+}
+
+func (c *Client) SearchTrackerIssues(provider model.TrackerProvider, project, text string) ([]tracker.Issue, *Response) {
+	query := url.Values{}
+	query.Set("q", text)
+	if project != "" {
+		query.Set("project", project)
+	}
+
+	r, err := c.DoAPIGet(c.GetTrackerRoute(provider)+"/issues?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var issues []tracker.Issue
+	if err := json.NewDecoder(r.Body).Decode(&issues); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return issues, BuildResponse(r)
+}
+
+func (c *Client) CreateTrackerIssue(provider model.TrackerProvider, req tracker.CreateIssueRequest) (*tracker.Issue, *Response) {
+	r, err := c.DoAPIPost(c.GetTrackerRoute(provider)+"/issues", toJSON(req))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var issue *tracker.Issue
+	if err := json.NewDecoder(r.Body).Decode(&issue); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return issue, BuildResponse(r)
+}
+
+func (c *Client) GetTrackerIssue(provider model.TrackerProvider, project string, number int) (*tracker.Issue, *Response) {
+	query := url.Values{}
+	query.Set("project", project)
+	query.Set("number", strconv.Itoa(number))
+
+	r, err := c.DoAPIGet(c.GetTrackerRoute(provider)+"/issue?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var issue *tracker.Issue
+	if err := json.NewDecoder(r.Body).Decode(&issue); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return issue, BuildResponse(r)
+}
+
+func (c *Client) GetBoardTracker(boardID string) (*api.BoardTracker, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/tracker", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var boardTracker *api.BoardTracker
+	if err := json.NewDecoder(r.Body).Decode(&boardTracker); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+
+	return boardTracker, BuildResponse(r)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"time"
+
+	mmModel "github.com/mattermost/mattermost/server/public/model"
+)
+
+// PreferenceActivityDigest is the user preference holding the frequency of
+// the user's activity digest.
+const PreferenceActivityDigest = "activityDigest"
+
+// ActivityDigestFrequency is how often a user receives the digest of the
+// changes of the boards and cards they follow, instead of a notification per
+// change.
+type ActivityDigestFrequency string
+
+const (
+	ActivityDigestOff    ActivityDigestFrequency = ""
+	ActivityDigestDaily  ActivityDigestFrequency = "daily"
+	ActivityDigestWeekly ActivityDigestFrequency = "weekly"
+)
+
+// Period returns the time between two digests, or zero if the digest is off.
+func (f ActivityDigestFrequency) Period() time.Duration {
+	switch f {
+	case ActivityDigestDaily:
+		return 24 * time.Hour
+	case ActivityDigestWeekly:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// GetActivityDigestFrequency returns the digest frequency set in the
+// preferences of a user. Unknown values turn the digest off.
+func GetActivityDigestFrequency(preferences mmModel.Preferences) ActivityDigestFrequency {
+	for _, preference := range preferences {
+		if preference.Category != PreferencesCategoryFocalboard || preference.Name != PreferenceActivityDigest {
+			continue
+		}
+		frequency := ActivityDigestFrequency(preference.Value)
+		if frequency.Period() > 0 {
+			return frequency
+		}
+	}
+	return ActivityDigestOff
+}
+
+// ActivityDigestChange records that a card changed, for the activity digests
+// of its subscribers.
+// swagger:ignore
+type ActivityDigestChange struct {
+	BoardID  string `json:"boardId"`
+	CardID   string `json:"cardId"`
+	UpdateAt int64  `json:"updateAt"`
+}
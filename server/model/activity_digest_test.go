@@ -0,0 +1,41 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	mmModel "github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestGetActivityDigestFrequency(t *testing.T) {
+	preference := func(category, name, value string) mmModel.Preference {
+		return mmModel.Preference{UserId: "user-id", Category: category, Name: name, Value: value}
+	}
+
+	testCases := []struct {
+		name        string
+		preferences mmModel.Preferences
+		expected    ActivityDigestFrequency
+	}{
+		{"no preferences", nil, ActivityDigestOff},
+		{"daily", mmModel.Preferences{preference(PreferencesCategoryFocalboard, PreferenceActivityDigest, "daily")}, ActivityDigestDaily},
+		{"weekly", mmModel.Preferences{preference(PreferencesCategoryFocalboard, PreferenceActivityDigest, "weekly")}, ActivityDigestWeekly},
+		{"unknown value", mmModel.Preferences{preference(PreferencesCategoryFocalboard, PreferenceActivityDigest, "hourly")}, ActivityDigestOff},
+		{"other category", mmModel.Preferences{preference("display_settings", PreferenceActivityDigest, "daily")}, ActivityDigestOff},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, GetActivityDigestFrequency(tc.preferences))
+		})
+	}
+
+	assert.Equal(t, 24*time.Hour, ActivityDigestDaily.Period())
+	assert.Equal(t, 7*24*time.Hour, ActivityDigestWeekly.Period())
+	assert.Zero(t, ActivityDigestOff.Period())
+}
@@ -0,0 +1,381 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+var ErrInvalidAutomationRule = errors.New("invalid automation rule")
+
+// AutomationTriggerType is the kind of change that runs an automation rule.
+type AutomationTriggerType string
+
+const (
+	// AutomationTriggerCardCreated runs when a card is created.
+	AutomationTriggerCardCreated AutomationTriggerType = "card_created"
+	// AutomationTriggerStatusEntered runs when a card enters a status.
+	AutomationTriggerStatusEntered AutomationTriggerType = "status_entered"
+	// AutomationTriggerPropertyChanged runs when a card property changes,
+	// optionally to a given value.
+	AutomationTriggerPropertyChanged AutomationTriggerType = "property_changed"
+	// AutomationTriggerRelationAdded runs when a relation is added from a card.
+	AutomationTriggerRelationAdded AutomationTriggerType = "relation_added"
+	// AutomationTriggerDueDatePassed runs when the date of a card passes.
+	AutomationTriggerDueDatePassed AutomationTriggerType = "due_date_passed"
+)
+
+// AutomationActionType is the kind of change made by an automation rule.
+type AutomationActionType string
+
+const (
+	AutomationActionSetProperty      AutomationActionType = "set_property"
+	AutomationActionAssignPerson     AutomationActionType = "assign_person"
+	AutomationActionAddComment       AutomationActionType = "add_comment"
+	AutomationActionMoveToBoard      AutomationActionType = "move_to_board"
+	AutomationActionPostToChannel    AutomationActionType = "post_to_channel"
+	AutomationActionCreateLinkedCard AutomationActionType = "create_linked_card"
+)
+
+const (
+	maxAutomationRuleTitleLength = 255
+	maxAutomationRuleActions     = 10
+)
+
+// AutomationRule runs actions on a card when a trigger matches a change of
+// the card. Actions run on behalf of the user who created the rule.
+// swagger:model
+type AutomationRule struct {
+	// The id of the rule
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board the rule belongs to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// A name to recognize the rule
+	// required: false
+	Title string `json:"title"`
+
+	// Whether the rule runs
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// The change that runs the rule
+	// required: true
+	Trigger AutomationTrigger `json:"trigger"`
+
+	// The actions run, in order, when the rule is triggered
+	// required: true
+	Actions []AutomationAction `json:"actions"`
+
+	// The id of the user who created the rule. Actions run on behalf of this user
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The id of the user who last modified the rule
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The time up to which due dates have been checked, for due date triggers
+	DueCheckedAt int64 `json:"-"`
+}
+
+// AutomationTrigger describes the change that runs an automation rule.
+// swagger:model
+type AutomationTrigger struct {
+	// The type of the trigger
+	// required: true
+	Type AutomationTriggerType `json:"type"`
+
+	// The property watched by status, property and due date triggers. Status
+	// triggers default to the board's status property
+	// required: false
+	PropertyID string `json:"propertyId,omitempty"`
+
+	// The option id a status trigger waits for, or the value a property
+	// trigger waits for
+	// required: false
+	Value string `json:"value,omitempty"`
+
+	// The relation type a relation trigger waits for, any type if empty
+	// required: false
+	RelationType RelationType `json:"relationType,omitempty"`
+}
+
+// AutomationAction describes a change made by an automation rule. Text and
+// titles can reference the card with {{card.title}}, {{card.code}},
+// {{card.url}} and {{board.title}}.
+// swagger:model
+type AutomationAction struct {
+	// The type of the action
+	// required: true
+	Type AutomationActionType `json:"type"`
+
+	// The property set by set_property and assign_person actions
+	// required: false
+	PropertyID string `json:"propertyId,omitempty"`
+
+	// The value set by set_property actions, in the stored property format
+	// required: false
+	Value interface{} `json:"value,omitempty"`
+
+	// The user assigned by assign_person actions
+	// required: false
+	UserID string `json:"userId,omitempty"`
+
+	// The comment of add_comment actions or the message of post_to_channel actions
+	// required: false
+	Text string `json:"text,omitempty"`
+
+	// The target board of move_to_board and create_linked_card actions
+	// required: false
+	BoardID string `json:"boardId,omitempty"`
+
+	// The channel of post_to_channel actions
+	// required: false
+	ChannelID string `json:"channelId,omitempty"`
+
+	// The title of the card created by create_linked_card actions
+	// required: false
+	Title string `json:"title,omitempty"`
+
+	// The relation from the triggering card to the card created by
+	// create_linked_card actions, relates_to if empty
+	// required: false
+	RelationType RelationType `json:"relationType,omitempty"`
+}
+
+// Populate populates an AutomationRule with default values.
+func (r *AutomationRule) Populate() {
+	if r.ID == "" {
+		r.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if r.Actions == nil {
+		r.Actions = []AutomationAction{}
+	}
+	if r.CreateAt == 0 {
+		r.CreateAt = utils.GetMillis()
+	}
+	if r.ModifiedBy == "" {
+		r.ModifiedBy = r.CreatedBy
+	}
+	r.UpdateAt = r.CreateAt
+	r.DueCheckedAt = r.CreateAt
+}
+
+// IsValid validates the structure of the rule.
+func (r *AutomationRule) IsValid() error {
+	if r.ID == "" {
+		return fmt.Errorf("%w: id cannot be empty", ErrInvalidAutomationRule)
+	}
+	if r.BoardID == "" {
+		return fmt.Errorf("%w: board id cannot be empty", ErrInvalidAutomationRule)
+	}
+	if len(r.Title) > maxAutomationRuleTitleLength {
+		return fmt.Errorf("%w: title is too long", ErrInvalidAutomationRule)
+	}
+	if r.CreatedBy == "" {
+		return fmt.Errorf("%w: created by cannot be empty", ErrInvalidAutomationRule)
+	}
+	if err := r.Trigger.IsValid(); err != nil {
+		return err
+	}
+	if len(r.Actions) == 0 {
+		return fmt.Errorf("%w: at least one action is required", ErrInvalidAutomationRule)
+	}
+	if len(r.Actions) > maxAutomationRuleActions {
+		return fmt.Errorf("%w: a rule cannot have more than %d actions", ErrInvalidAutomationRule, maxAutomationRuleActions)
+	}
+	for i := range r.Actions {
+		if err := r.Actions[i].IsValid(); err != nil {
+			return fmt.Errorf("action %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// IsValidForSchema checks that the properties and options referenced by the
+// rule exist in the board's property schema.
+func (r *AutomationRule) IsValidForSchema(schema PropSchema) error {
+	switch r.Trigger.Type {
+	case AutomationTriggerStatusEntered:
+		prop, ok := r.Trigger.StatusPropDef(schema)
+		if !ok {
+			return fmt.Errorf("%w: the board has no status property", ErrInvalidAutomationRule)
+		}
+		if prop.Type != PropTypeSelect {
+			return fmt.Errorf("%w: status property %q is not a select property", ErrInvalidAutomationRule, prop.Name)
+		}
+		if _, ok := prop.Options[r.Trigger.Value]; !ok {
+			return fmt.Errorf("%w: unknown status %q", ErrInvalidAutomationRule, r.Trigger.Value)
+		}
+	case AutomationTriggerPropertyChanged:
+		if _, ok := schema[r.Trigger.PropertyID]; !ok {
+			return fmt.Errorf("%w: unknown property %q", ErrInvalidAutomationRule, r.Trigger.PropertyID)
+		}
+	case AutomationTriggerDueDatePassed:
+		prop, ok := schema[r.Trigger.PropertyID]
+		if !ok || prop.Type != PropTypeDate {
+			return fmt.Errorf("%w: %q is not a date property", ErrInvalidAutomationRule, r.Trigger.PropertyID)
+		}
+	}
+
+	for i, action := range r.Actions {
+		switch action.Type {
+		case AutomationActionSetProperty:
+			prop, ok := schema[action.PropertyID]
+			if !ok {
+				return fmt.Errorf("%w: action %d: unknown property %q", ErrInvalidAutomationRule, i+1, action.PropertyID)
+			}
+			if isReadOnlyPropType(prop.Type) {
+				return fmt.Errorf("%w: action %d: property %q cannot be set", ErrInvalidAutomationRule, i+1, prop.Name)
+			}
+			if prop.Type == PropTypeSelect {
+				if optionID, _ := action.Value.(string); optionID != "" {
+					if _, ok := prop.Options[optionID]; !ok {
+						return fmt.Errorf("%w: action %d: unknown option %q", ErrInvalidAutomationRule, i+1, optionID)
+					}
+				}
+			}
+		case AutomationActionAssignPerson:
+			prop, ok := schema[action.PropertyID]
+			if !ok || (prop.Type != PropTypePerson && prop.Type != PropTypeMultiPerson) {
+				return fmt.Errorf("%w: action %d: %q is not a person property", ErrInvalidAutomationRule, i+1, action.PropertyID)
+			}
+		}
+	}
+	return nil
+}
+
+// Patch returns an updated version of the rule.
+func (r *AutomationRule) Patch(patch *AutomationRulePatch) *AutomationRule {
+	updated := *r
+	if patch.Title != nil {
+		updated.Title = *patch.Title
+	}
+	if patch.Enabled != nil {
+		updated.Enabled = *patch.Enabled
+	}
+	if patch.Trigger != nil {
+		updated.Trigger = *patch.Trigger
+	}
+	if patch.Actions != nil {
+		updated.Actions = *patch.Actions
+	}
+	updated.UpdateAt = utils.GetMillis()
+	return &updated
+}
+
+// IsValid validates the structure of the trigger.
+func (t AutomationTrigger) IsValid() error {
+	switch t.Type {
+	case AutomationTriggerCardCreated, AutomationTriggerRelationAdded:
+	case AutomationTriggerStatusEntered:
+		if t.Value == "" {
+			return fmt.Errorf("%w: status trigger requires a value", ErrInvalidAutomationRule)
+		}
+	case AutomationTriggerPropertyChanged, AutomationTriggerDueDatePassed:
+		if t.PropertyID == "" {
+			return fmt.Errorf("%w: %s trigger requires a property id", ErrInvalidAutomationRule, t.Type)
+		}
+	default:
+		return fmt.Errorf("%w: unknown trigger type %q", ErrInvalidAutomationRule, t.Type)
+	}
+	return nil
+}
+
+// StatusPropDef returns the property watched by a status trigger.
+func (t AutomationTrigger) StatusPropDef(schema PropSchema) (PropDef, bool) {
+	if t.PropertyID != "" {
+		prop, ok := schema[t.PropertyID]
+		return prop, ok
+	}
+	return schema.StatusPropDef()
+}
+
+// IsValid validates the structure of the action.
+func (a AutomationAction) IsValid() error {
+	switch a.Type {
+	case AutomationActionSetProperty:
+		if a.PropertyID == "" {
+			return fmt.Errorf("%w: set_property requires a property id", ErrInvalidAutomationRule)
+		}
+	case AutomationActionAssignPerson:
+		if a.PropertyID == "" || a.UserID == "" {
+			return fmt.Errorf("%w: assign_person requires a property id and a user id", ErrInvalidAutomationRule)
+		}
+	case AutomationActionAddComment:
+		if strings.TrimSpace(a.Text) == "" {
+			return fmt.Errorf("%w: add_comment requires a text", ErrInvalidAutomationRule)
+		}
+	case AutomationActionMoveToBoard:
+		if a.BoardID == "" {
+			return fmt.Errorf("%w: move_to_board requires a board id", ErrInvalidAutomationRule)
+		}
+	case AutomationActionPostToChannel:
+		if a.ChannelID == "" || strings.TrimSpace(a.Text) == "" {
+			return fmt.Errorf("%w: post_to_channel requires a channel id and a text", ErrInvalidAutomationRule)
+		}
+	case AutomationActionCreateLinkedCard:
+		if strings.TrimSpace(a.Title) == "" {
+			return fmt.Errorf("%w: create_linked_card requires a title", ErrInvalidAutomationRule)
+		}
+	default:
+		return fmt.Errorf("%w: unknown action type %q", ErrInvalidAutomationRule, a.Type)
+	}
+	return nil
+}
+
+// AutomationRulePatch is a patch for modifying an automation rule.
+// swagger:model
+type AutomationRulePatch struct {
+	// A name to recognize the rule
+	// required: false
+	Title *string `json:"title"`
+
+	// Whether the rule runs
+	// required: false
+	Enabled *bool `json:"enabled"`
+
+	// The new trigger
+	// required: false
+	Trigger *AutomationTrigger `json:"trigger"`
+
+	// The new actions
+	// required: false
+	Actions *[]AutomationAction `json:"actions"`
+}
+
+// ExpandAutomationText replaces the card and board placeholders of an
+// automation text.
+func ExpandAutomationText(text string, card *Card, board *Board, cardURL string) string {
+	return strings.NewReplacer(
+		"{{card.title}}", card.Title,
+		"{{card.code}}", card.Code,
+		"{{card.url}}", cardURL,
+		"{{board.title}}", board.Title,
+	).Replace(text)
+}
+
+func isReadOnlyPropType(propType string) bool {
+	switch propType {
+	case PropTypeCreatedTime, PropTypeCreatedBy, PropTypeUpdatedTime, PropTypeUpdatedBy:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationRuleIsValid(t *testing.T) {
+	newRule := func() *AutomationRule {
+		rule := &AutomationRule{
+			BoardID:   "board-id",
+			CreatedBy: "user-id",
+			Trigger:   AutomationTrigger{Type: AutomationTriggerCardCreated},
+			Actions:   []AutomationAction{{Type: AutomationActionAddComment, Text: "Welcome"}},
+		}
+		rule.Populate()
+		return rule
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		rule := newRule()
+		require.NoError(t, rule.IsValid())
+		assert.Equal(t, "user-id", rule.ModifiedBy)
+		assert.Equal(t, rule.CreateAt, rule.DueCheckedAt)
+	})
+
+	testCases := []struct {
+		name   string
+		modify func(rule *AutomationRule)
+	}{
+		{"no board", func(rule *AutomationRule) { rule.BoardID = "" }},
+		{"unknown trigger", func(rule *AutomationRule) { rule.Trigger.Type = "card_archived" }},
+		{"status trigger without value", func(rule *AutomationRule) { rule.Trigger.Type = AutomationTriggerStatusEntered }},
+		{"due date trigger without property", func(rule *AutomationRule) { rule.Trigger.Type = AutomationTriggerDueDatePassed }},
+		{"no actions", func(rule *AutomationRule) { rule.Actions = nil }},
+		{"unknown action", func(rule *AutomationRule) { rule.Actions[0].Type = "send_email" }},
+		{"comment without text", func(rule *AutomationRule) { rule.Actions[0].Text = " " }},
+		{"post without channel", func(rule *AutomationRule) { rule.Actions[0].Type = AutomationActionPostToChannel }},
+		{"assign without user", func(rule *AutomationRule) {
+			rule.Actions[0] = AutomationAction{Type: AutomationActionAssignPerson, PropertyID: "owner"}
+		}},
+		{"too many actions", func(rule *AutomationRule) {
+			for i := 0; i < maxAutomationRuleActions; i++ {
+				rule.Actions = append(rule.Actions, rule.Actions[0])
+			}
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := newRule()
+			tc.modify(rule)
+			err := rule.IsValid()
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidAutomationRule))
+		})
+	}
+}
+
+func TestAutomationRuleIsValidForSchema(t *testing.T) {
+	schema := PropSchema{
+		"status": {ID: "status", Name: "Status", Type: PropTypeSelect, Options: map[string]PropDefOption{
+			"opt-done": {ID: "opt-done", Value: "Done"},
+		}},
+		"owner":    {ID: "owner", Name: "Owner", Type: PropTypePerson},
+		"due":      {ID: "due", Name: "Due", Type: PropTypeDate},
+		"modified": {ID: "modified", Name: "Modified", Type: PropTypeUpdatedTime},
+	}
+
+	testCases := []struct {
+		name    string
+		trigger AutomationTrigger
+		action  AutomationAction
+		valid   bool
+	}{
+		{
+			name:    "status trigger on the status property",
+			trigger: AutomationTrigger{Type: AutomationTriggerStatusEntered, Value: "opt-done"},
+			action:  AutomationAction{Type: AutomationActionSetProperty, PropertyID: "owner", Value: "user-id"},
+			valid:   true,
+		},
+		{
+			name:    "unknown status",
+			trigger: AutomationTrigger{Type: AutomationTriggerStatusEntered, Value: "opt-missing"},
+			action:  AutomationAction{Type: AutomationActionAddComment, Text: "done"},
+		},
+		{
+			name:    "due date trigger on a date",
+			trigger: AutomationTrigger{Type: AutomationTriggerDueDatePassed, PropertyID: "due"},
+			action:  AutomationAction{Type: AutomationActionAssignPerson, PropertyID: "owner", UserID: "user-id"},
+			valid:   true,
+		},
+		{
+			name:    "due date trigger on a person",
+			trigger: AutomationTrigger{Type: AutomationTriggerDueDatePassed, PropertyID: "owner"},
+			action:  AutomationAction{Type: AutomationActionAddComment, Text: "late"},
+		},
+		{
+			name:    "unknown option",
+			trigger: AutomationTrigger{Type: AutomationTriggerCardCreated},
+			action:  AutomationAction{Type: AutomationActionSetProperty, PropertyID: "status", Value: "opt-missing"},
+		},
+		{
+			name:    "read only property",
+			trigger: AutomationTrigger{Type: AutomationTriggerCardCreated},
+			action:  AutomationAction{Type: AutomationActionSetProperty, PropertyID: "modified", Value: "1"},
+		},
+		{
+			name:    "assign to a date",
+			trigger: AutomationTrigger{Type: AutomationTriggerCardCreated},
+			action:  AutomationAction{Type: AutomationActionAssignPerson, PropertyID: "due", UserID: "user-id"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := &AutomationRule{Trigger: tc.trigger, Actions: []AutomationAction{tc.action}}
+			err := rule.IsValidForSchema(schema)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidAutomationRule)
+			}
+		})
+	}
+}
+
+func TestExpandAutomationText(t *testing.T) {
+	card := &Card{Title: "Launch", Code: "RM-4"}
+	board := &Board{Title: "Roadmap"}
+
+	text := ExpandAutomationText("{{card.code}} {{card.title}} on {{board.title}}: {{card.url}} {{unknown}}", card, board, "http://card")
+	assert.Equal(t, "RM-4 Launch on Roadmap: http://card {{unknown}}", text)
+}
+
+func TestCardDateEnd(t *testing.T) {
+	card := &Card{Properties: map[string]interface{}{
+		"date":  `{"from":1000}`,
+		"range": `{"from":1000,"to":2000}`,
+	}}
+
+	end, ok := CardDateEnd(card, "date")
+	require.True(t, ok)
+	assert.Equal(t, int64(1000), end)
+
+	end, ok = CardDateEnd(card, "range")
+	require.True(t, ok)
+	assert.Equal(t, int64(2000), end)
+
+	_, ok = CardDateEnd(card, "missing")
+	assert.False(t, ok)
+}
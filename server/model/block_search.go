@@ -0,0 +1,65 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// BlockSearchTypes are the types of the blocks covered by the full-text index.
+var BlockSearchTypes = []BlockType{TypeCard, TypeText, TypeComment, TypeCheckbox}
+
+// QueryBlockSearchOptions are the options of a full-text search over block titles.
+type QueryBlockSearchOptions struct {
+	Terms    string   // the words to search for
+	BoardIDs []string // the boards to search in
+	Page     int      // page number to select when paginating
+	PerPage  int      // number of blocks per page (default=-1, meaning unlimited)
+}
+
+// BlockSearchResult is a block matched by a full-text search.
+// swagger:model
+type BlockSearchResult struct {
+	// The ID of the matched block
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The type of the matched block
+	// required: true
+	Type BlockType `json:"type"`
+
+	// The board of the matched block
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The card the matched block belongs to, the block itself for cards
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The code of the card the matched block belongs to
+	// required: false
+	CardCode string `json:"cardCode,omitempty"`
+
+	// The title of the card the matched block belongs to
+	// required: true
+	CardTitle string `json:"cardTitle"`
+
+	// The part of the block's text around the first match, with the
+	// matched words in bold
+	// required: true
+	Snippet string `json:"snippet"`
+
+	// The update time in miliseconds since the current epoch of the matched block
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// SearchWords splits full-text search terms into words, dropping the
+// punctuation and operators that the database search syntaxes give a meaning to.
+func SearchWords(terms string) []string {
+	return strings.FieldsFunc(terms, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+}
@@ -28,6 +28,20 @@ const (
 	BoardRoleAdmin     BoardRole = "admin"
 )
 
+// BoardPropertyWorkflowPropertyID is the board property holding the id of
+// the select property that is the workflow field of the board's cards.
+const BoardPropertyWorkflowPropertyID = "workflowPropertyId"
+
+// BoardPropertyBlockDoneWhileBlocked is the board property that, when true,
+// keeps the cards of the board out of the done statuses while a card that
+// blocks them is not done.
+const BoardPropertyBlockDoneWhileBlocked = "blockDoneWhileBlocked"
+
+// BoardPropertyTrackerProvider is the board property holding the issue
+// tracker that the cards of the board are linked to. GitHub is used when it
+// is not set.
+const BoardPropertyTrackerProvider = "trackerProvider"
+
 const (
 	BoardSearchFieldNone         BoardSearchField = ""
 	BoardSearchFieldTitle        BoardSearchField = "title"
@@ -129,6 +143,13 @@ func (b *Board) GetPropertyString(propName string) (string, error) {
 	return s, nil
 }
 
+// GetPropertyBool returns the value of the specified property as a bool,
+// or false if the property does not exist or is not a bool.
+func (b *Board) GetPropertyBool(propName string) bool {
+	val, ok := b.Properties[propName].(bool)
+	return ok && val
+}
+
 // BoardPatch is a patch for modify boards
 // swagger:model
 type BoardPatch struct {
@@ -389,6 +410,15 @@ func (p *BoardPatch) IsValid() error {
 		return InvalidBoardErr{"invalid-channel-id"}
 	}
 
+	for _, prop := range p.UpdatedCardProperties {
+		if getMapString("type", prop) != PropTypeFormula {
+			continue
+		}
+		if _, err := ParseFormula(getMapString("formula", prop)); err != nil {
+			return InvalidBoardErr{"invalid-card-property-formula"}
+		}
+	}
+
 	return nil
 }
 
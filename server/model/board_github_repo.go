@@ -0,0 +1,158 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+const (
+	// MaxBoardGitHubRepos is the largest number of repositories connected to
+	// a board.
+	MaxBoardGitHubRepos = 20
+
+	maxGitHubBranchNameSlugLength = 50
+)
+
+// BoardGitHubRepo is a GitHub repository connected to a board. Its pull
+// requests and commits that mention the code of a card of the board, such
+// as AB-123, are linked to the card.
+// swagger:model
+type BoardGitHubRepo struct {
+	// The id of the connection
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The owner of the repository
+	// required: true
+	Owner string `json:"owner"`
+
+	// The name of the repository
+	// required: true
+	Repo string `json:"repo"`
+
+	// The time of the last scan of the repository in milliseconds since the
+	// current epoch, or zero
+	// required: false
+	ScannedAt int64 `json:"scannedAt"`
+
+	// The id of the user who connected the repository. Their GitHub account
+	// is used to scan it.
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// Populate populates a BoardGitHubRepo with default values.
+func (r *BoardGitHubRepo) Populate() {
+	if r.ID == "" {
+		r.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if r.CreateAt == 0 {
+		r.CreateAt = utils.GetMillis()
+	}
+}
+
+// IsValid validates the connection.
+func (r *BoardGitHubRepo) IsValid() error {
+	if r.ID == "" {
+		return NewErrBadRequest("github repository id cannot be empty")
+	}
+	if r.BoardID == "" {
+		return NewErrBadRequest("github repository board id cannot be empty")
+	}
+	if r.Owner == "" || r.Repo == "" || strings.ContainsRune(r.Owner, '/') || strings.ContainsRune(r.Repo, '/') {
+		return NewErrBadRequest("github repository needs an owner and a name")
+	}
+	if r.CreatedBy == "" {
+		return NewErrBadRequest("github repository creator cannot be empty")
+	}
+	return nil
+}
+
+// Same returns true if both connections are to the same repository.
+func (r *BoardGitHubRepo) Same(other *BoardGitHubRepo) bool {
+	return strings.EqualFold(r.Owner, other.Owner) && strings.EqualFold(r.Repo, other.Repo)
+}
+
+// FindCardCodes returns the codes of the cards of a board mentioned in a
+// text, such as a branch name, a pull request title or a commit message, in
+// order of appearance and without duplicates. Codes are matched regardless
+// of case and returned with the board code as given, so that "ab-12-fix"
+// yields "AB-12" for the board code "AB".
+func FindCardCodes(text, boardCode string) []string {
+	if !IsValidBoardCode(boardCode) {
+		return nil
+	}
+	re := regexp.MustCompile(`(?i)` + regexp.QuoteMeta(boardCode) + `-([0-9]+)`)
+
+	codes := []string{}
+	seen := map[string]bool{}
+	for _, m := range re.FindAllStringSubmatchIndex(text, -1) {
+		// The code must not be part of a longer word, such as XAB-12 or AB-12x.
+		if m[0] > 0 && isASCIIAlphanumeric(text[m[0]-1]) {
+			continue
+		}
+		if m[1] < len(text) && isASCIIAlphanumeric(text[m[1]]) {
+			continue
+		}
+		number, err := strconv.ParseInt(text[m[2]:m[3]], 10, 64)
+		if err != nil || number <= 0 {
+			continue
+		}
+		code := fmt.Sprintf("%s-%d", boardCode, number)
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// GitHubBranchName returns the default name of the branch of a card, made
+// of its code and a slug of its title, such as "AB-123-fix-login-bug".
+func GitHubBranchName(code, title string) string {
+	var slug strings.Builder
+	dash := false
+	for _, c := range strings.ToLower(title) {
+		if slug.Len() >= maxGitHubBranchNameSlugLength {
+			break
+		}
+		if c >= utf8.RuneSelf || !isASCIIAlphanumeric(byte(c)) {
+			dash = slug.Len() > 0
+			continue
+		}
+		if dash {
+			slug.WriteByte('-')
+			dash = false
+		}
+		slug.WriteRune(c)
+	}
+	name := slug.String()
+	if len(name) > maxGitHubBranchNameSlugLength {
+		name = name[:maxGitHubBranchNameSlugLength]
+	}
+	name = strings.TrimRight(name, "-")
+	if name == "" {
+		return code
+	}
+	return code + "-" + name
+}
+
+func isASCIIAlphanumeric(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCardCodes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"branch name", "ab-12-fix-login", []string{"AB-12"}},
+		{"branch with prefix", "feature/AB-7_retry", []string{"AB-7"}},
+		{"pull request title", "AB-3, AB-4: fix login (AB-3)", []string{"AB-3", "AB-4"}},
+		{"leading zeros", "AB-007", []string{"AB-7"}},
+		{"longer board code", "XAB-12 and AB-12x", []string{}},
+		{"other board", "CD-12", []string{}},
+		{"no number", "AB-", []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FindCardCodes(tc.text, "AB"))
+		})
+	}
+
+	assert.Nil(t, FindCardCodes("AB-1", ""))
+}
+
+func TestGitHubBranchName(t *testing.T) {
+	assert.Equal(t, "AB-123-fix-the-login-bug", GitHubBranchName("AB-123", "Fix the login bug!"))
+	assert.Equal(t, "AB-1-menu-soup-salad", GitHubBranchName("AB-1", "  Menu: soup & salad  "))
+	assert.Equal(t, "AB-1", GitHubBranchName("AB-1", "???"))
+
+	name := GitHubBranchName("AB-1", "a very long title that goes on and on and on well past the limit of a branch")
+	assert.LessOrEqual(t, len(name), len("AB-1-")+maxGitHubBranchNameSlugLength)
+	assert.NotEqual(t, '-', name[len(name)-1])
+}
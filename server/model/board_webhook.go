@@ -0,0 +1,376 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+var ErrInvalidBoardWebhook = errors.New("invalid board webhook")
+
+// WebhookEvent is the type of a change sent to board webhooks.
+type WebhookEvent string
+
+const (
+	WebhookEventCardCreated   WebhookEvent = "card.created"
+	WebhookEventCardUpdated   WebhookEvent = "card.updated"
+	WebhookEventCardDeleted   WebhookEvent = "card.deleted"
+	WebhookEventCommentAdded  WebhookEvent = "comment.added"
+	WebhookEventStatusChanged WebhookEvent = "card.status_changed"
+	WebhookEventRelationAdded WebhookEvent = "relation.added"
+)
+
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+const (
+	// MaxWebhookDeliveryAttempts is the number of attempts after which a delivery is marked as failed.
+	MaxWebhookDeliveryAttempts = 8
+
+	webhookSecretLength      = 32
+	maxBoardWebhookURLLength = 2048
+)
+
+// WebhookEvents are all the events a board webhook can subscribe to.
+var WebhookEvents = []WebhookEvent{
+	WebhookEventCardCreated,
+	WebhookEventCardUpdated,
+	WebhookEventCardDeleted,
+	WebhookEventCommentAdded,
+	WebhookEventStatusChanged,
+	WebhookEventRelationAdded,
+}
+
+// BoardWebhook is an outbound webhook that receives the changes of a board.
+// swagger:model
+type BoardWebhook struct {
+	// The id of the webhook
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board the webhook belongs to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The URL the events are posted to
+	// required: true
+	URL string `json:"url"`
+
+	// The secret used to sign the payloads. It is only returned when the
+	// webhook is created
+	// required: false
+	Secret string `json:"secret,omitempty"`
+
+	// The events the webhook receives, all events if empty
+	// required: true
+	Events []WebhookEvent `json:"events"`
+
+	// Whether events are sent to the webhook
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// The id of the user who created the webhook
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// Populate populates a BoardWebhook with default values.
+func (wh *BoardWebhook) Populate() error {
+	if wh.ID == "" {
+		wh.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if wh.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return err
+		}
+		wh.Secret = secret
+	}
+	if wh.Events == nil {
+		wh.Events = []WebhookEvent{}
+	}
+	if wh.CreateAt == 0 {
+		wh.CreateAt = utils.GetMillis()
+	}
+	wh.UpdateAt = wh.CreateAt
+	return nil
+}
+
+// IsValid validates the board webhook.
+func (wh *BoardWebhook) IsValid() error {
+	if wh.ID == "" {
+		return fmt.Errorf("%w: id cannot be empty", ErrInvalidBoardWebhook)
+	}
+	if wh.BoardID == "" {
+		return fmt.Errorf("%w: board id cannot be empty", ErrInvalidBoardWebhook)
+	}
+	if err := validateWebhookURL(wh.URL); err != nil {
+		return err
+	}
+	if wh.Secret == "" {
+		return fmt.Errorf("%w: secret cannot be empty", ErrInvalidBoardWebhook)
+	}
+	for _, event := range wh.Events {
+		if !IsValidWebhookEvent(event) {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidBoardWebhook, event)
+		}
+	}
+	if wh.CreatedBy == "" {
+		return fmt.Errorf("%w: created by cannot be empty", ErrInvalidBoardWebhook)
+	}
+	return nil
+}
+
+// Subscribes returns true if the webhook receives the event.
+func (wh *BoardWebhook) Subscribes(event WebhookEvent) bool {
+	if !wh.Enabled {
+		return false
+	}
+	if len(wh.Events) == 0 {
+		return true
+	}
+	for _, e := range wh.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Patch returns an updated version of the webhook.
+func (wh *BoardWebhook) Patch(patch *BoardWebhookPatch) *BoardWebhook {
+	updated := *wh
+	if patch.URL != nil {
+		updated.URL = *patch.URL
+	}
+	if patch.Events != nil {
+		updated.Events = *patch.Events
+	}
+	if patch.Enabled != nil {
+		updated.Enabled = *patch.Enabled
+	}
+	updated.UpdateAt = utils.GetMillis()
+	return &updated
+}
+
+// BoardWebhookPatch is a patch for modifying a board webhook.
+// swagger:model
+type BoardWebhookPatch struct {
+	// The URL the events are posted to
+	// required: false
+	URL *string `json:"url"`
+
+	// The events the webhook receives, all events if empty
+	// required: false
+	Events *[]WebhookEvent `json:"events"`
+
+	// Whether events are sent to the webhook
+	// required: false
+	Enabled *bool `json:"enabled"`
+}
+
+// IsValidWebhookEvent returns true if the event is a known webhook event.
+func IsValidWebhookEvent(event WebhookEvent) bool {
+	for _, e := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func validateWebhookURL(rawURL string) error {
+	if rawURL == "" {
+		return fmt.Errorf("%w: url cannot be empty", ErrInvalidBoardWebhook)
+	}
+	if len(rawURL) > maxBoardWebhookURLLength {
+		return fmt.Errorf("%w: url is too long", ErrInvalidBoardWebhook)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidBoardWebhook)
+	}
+	if isInternalWebhookHost(u.Hostname()) {
+		return fmt.Errorf("%w: url cannot point to a loopback, link-local or private address", ErrInvalidBoardWebhook)
+	}
+	return nil
+}
+
+// isInternalWebhookHost returns true if a host is localhost or a loopback,
+// link-local, private or unspecified IP address. The addresses a host name
+// resolves to are checked when the deliveries are sent.
+func isInternalWebhookHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsPrivate() || ip.IsUnspecified()
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("cannot generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// WebhookEventPayload is the body posted to board webhooks.
+// swagger:model
+type WebhookEventPayload struct {
+	// The id of the event, the same for all the delivery attempts
+	// required: true
+	ID string `json:"id"`
+
+	// The event type
+	// required: true
+	Event WebhookEvent `json:"event"`
+
+	// The id of the team of the board
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the user who made the change
+	// required: true
+	ActorID string `json:"actorId"`
+
+	// The time of the event in milliseconds since the current epoch
+	// required: true
+	Timestamp int64 `json:"timestamp"`
+
+	// The card the event is about
+	// required: false
+	Card *Card `json:"card,omitempty"`
+
+	// The comment that was added
+	// required: false
+	Comment *Block `json:"comment,omitempty"`
+
+	// The relation that was added
+	// required: false
+	Relation *CardRelation `json:"relation,omitempty"`
+
+	// The previous and new status of the card
+	// required: false
+	Status *WebhookStatusChange `json:"status,omitempty"`
+}
+
+// WebhookStatusChange describes a change of the status property of a card.
+// swagger:model
+type WebhookStatusChange struct {
+	// The id of the status property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The previous status option id
+	// required: true
+	From string `json:"from"`
+
+	// The previous status value
+	// required: true
+	FromValue string `json:"fromValue"`
+
+	// The new status option id
+	// required: true
+	To string `json:"to"`
+
+	// The new status value
+	// required: true
+	ToValue string `json:"toValue"`
+}
+
+// WebhookDelivery is a webhook event queued for, or sent to, a webhook.
+// swagger:model
+type WebhookDelivery struct {
+	// The id of the delivery
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the webhook
+	// required: true
+	WebhookID string `json:"webhookId"`
+
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The event type
+	// required: true
+	Event WebhookEvent `json:"event"`
+
+	// The JSON body that is posted
+	// required: true
+	Payload string `json:"payload"`
+
+	// The delivery status, pending, delivered or failed
+	// required: true
+	Status string `json:"status"`
+
+	// The number of attempts made
+	// required: true
+	Attempts int `json:"attempts"`
+
+	// The time of the next attempt in milliseconds since the current epoch
+	// required: true
+	NextAttemptAt int64 `json:"nextAttemptAt"`
+
+	// The time of the last attempt in milliseconds since the current epoch
+	// required: true
+	LastAttemptAt int64 `json:"lastAttemptAt"`
+
+	// The HTTP status code of the last attempt
+	// required: true
+	ResponseCode int `json:"responseCode"`
+
+	// The error of the last attempt
+	// required: true
+	Error string `json:"error"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// NewWebhookDelivery creates a pending delivery of an event payload to a webhook.
+func NewWebhookDelivery(webhook *BoardWebhook, event WebhookEvent, payload string) *WebhookDelivery {
+	now := utils.GetMillis()
+	return &WebhookDelivery{
+		ID:            utils.NewID(utils.IDTypeNone),
+		WebhookID:     webhook.ID,
+		BoardID:       webhook.BoardID,
+		Event:         event,
+		Payload:       payload,
+		Status:        WebhookDeliveryStatusPending,
+		NextAttemptAt: now,
+		CreateAt:      now,
+	}
+}
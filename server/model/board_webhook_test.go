@@ -0,0 +1,75 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardWebhookIsValid(t *testing.T) {
+	newWebhook := func() *BoardWebhook {
+		wh := &BoardWebhook{BoardID: "board-id", URL: "https://example.com/hook", CreatedBy: "user-id"}
+		require.NoError(t, wh.Populate())
+		return wh
+	}
+
+	t.Run("populated webhook", func(t *testing.T) {
+		wh := newWebhook()
+		require.NoError(t, wh.IsValid())
+		assert.Len(t, wh.Secret, webhookSecretLength*2)
+		assert.NotEqual(t, wh.Secret, newWebhook().Secret)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		for _, url := range []string{"", "example.com", "ftp://example.com", "https://"} {
+			wh := newWebhook()
+			wh.URL = url
+			require.ErrorIs(t, wh.IsValid(), ErrInvalidBoardWebhook, url)
+		}
+	})
+
+	t.Run("internal address", func(t *testing.T) {
+		for _, url := range []string{
+			"http://localhost:8065/hook",
+			"http://api.localhost/hook",
+			"http://127.0.0.1/hook",
+			"http://[::1]/hook",
+			"http://169.254.169.254/latest/meta-data",
+			"http://10.0.0.5/hook",
+			"https://192.168.1.10/hook",
+			"http://172.16.0.1/hook",
+			"http://[fd00::1]/hook",
+			"http://0.0.0.0/hook",
+		} {
+			wh := newWebhook()
+			wh.URL = url
+			require.ErrorIs(t, wh.IsValid(), ErrInvalidBoardWebhook, url)
+		}
+
+		wh := newWebhook()
+		wh.URL = "https://203.0.113.10/hook"
+		require.NoError(t, wh.IsValid())
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		wh := newWebhook()
+		wh.Events = []WebhookEvent{WebhookEventCardCreated, "card.archived"}
+		require.ErrorIs(t, wh.IsValid(), ErrInvalidBoardWebhook)
+	})
+}
+
+func TestBoardWebhookSubscribes(t *testing.T) {
+	wh := &BoardWebhook{Enabled: true}
+	assert.True(t, wh.Subscribes(WebhookEventCardDeleted))
+
+	wh.Events = []WebhookEvent{WebhookEventCommentAdded}
+	assert.True(t, wh.Subscribes(WebhookEventCommentAdded))
+	assert.False(t, wh.Subscribes(WebhookEventCardDeleted))
+
+	wh.Enabled = false
+	assert.False(t, wh.Subscribes(WebhookEventCommentAdded))
+}
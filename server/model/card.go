@@ -76,6 +76,10 @@ type Card struct {
 	// required: false
 	Code string `json:"code,omitempty"`
 
+	// The total time logged on the card, in minutes
+	// required: false
+	TimeSpent int64 `json:"timeSpent,omitempty"`
+
 	// True if this card belongs to a template
 	// required: false
 	IsTemplate bool `json:"isTemplate"`
@@ -166,6 +170,11 @@ type CardPatch struct {
 	// A map of property ids to property option ids to be updated
 	// required: false
 	UpdatedProperties map[string]any `json:"updatedProperties"`
+
+	// A comment added to the card along with the patch, as required by some
+	// status transitions
+	// required: false
+	Comment *string `json:"comment,omitempty"`
 }
 
 // Patch returns an updated version of the card.
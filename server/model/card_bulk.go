@@ -0,0 +1,210 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// CardBulkMaxCards is the largest number of cards of a bulk request.
+const CardBulkMaxCards = 100
+
+// CardBulkOperationType is the kind of change a bulk operation makes to the
+// cards.
+type CardBulkOperationType string
+
+const (
+	// CardBulkOperationPatch patches the title, icon, content order and
+	// properties of the cards.
+	CardBulkOperationPatch CardBulkOperationType = "patch"
+
+	// CardBulkOperationSetStatus moves the cards to a status option.
+	CardBulkOperationSetStatus CardBulkOperationType = "setStatus"
+
+	// CardBulkOperationAssign sets the users of a person property.
+	CardBulkOperationAssign CardBulkOperationType = "assign"
+
+	// CardBulkOperationDelete deletes the cards.
+	CardBulkOperationDelete CardBulkOperationType = "delete"
+
+	// CardBulkOperationAddRelation relates the cards to another card.
+	CardBulkOperationAddRelation CardBulkOperationType = "addRelation"
+)
+
+// CardBulkOperation is a change applied to every card of a bulk request.
+// swagger:model
+type CardBulkOperation struct {
+	// The kind of change: patch, setStatus, assign, delete or addRelation
+	// required: true
+	Type CardBulkOperationType `json:"type"`
+
+	// The patch of a patch operation
+	// required: false
+	Patch *CardPatch `json:"patch,omitempty"`
+
+	// The property of a setStatus or assign operation. A setStatus operation
+	// uses the workflow property of the board when it is not set
+	// required: false
+	PropertyID string `json:"propertyId,omitempty"`
+
+	// The status option of a setStatus operation
+	// required: false
+	OptionID string `json:"optionId,omitempty"`
+
+	// The comment a setStatus operation adds to each card, for the status
+	// transitions that require one
+	// required: false
+	Comment string `json:"comment,omitempty"`
+
+	// The users of an assign operation, none to unassign the cards
+	// required: false
+	UserIDs []string `json:"userIds,omitempty"`
+
+	// The card an addRelation operation relates the cards to
+	// required: false
+	TargetCardID string `json:"targetCardId,omitempty"`
+
+	// The type of the relations of an addRelation operation
+	// required: false
+	RelationType RelationType `json:"relationType,omitempty"`
+}
+
+// CardBulkRequest is a set of operations to apply to cards of a board.
+// swagger:model
+type CardBulkRequest struct {
+	// The IDs of the cards to change
+	// required: true
+	CardIDs []string `json:"cardIds"`
+
+	// The operations to apply to each card, in order
+	// required: true
+	Operations []CardBulkOperation `json:"operations"`
+}
+
+// CardBulkRequestFromJSON decodes a bulk request.
+func CardBulkRequestFromJSON(data io.Reader) (*CardBulkRequest, error) {
+	var request CardBulkRequest
+	if err := json.NewDecoder(data).Decode(&request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// IsValid returns an error if the request has no cards or operations, too
+// many cards, or an operation without its fields.
+func (r *CardBulkRequest) IsValid() error {
+	if len(r.CardIDs) == 0 {
+		return NewErrBadRequest("the request has no cards")
+	}
+	if len(r.CardIDs) > CardBulkMaxCards {
+		return NewErrBadRequest(fmt.Sprintf("a request can change at most %d cards", CardBulkMaxCards))
+	}
+	seen := make(map[string]bool, len(r.CardIDs))
+	for _, cardID := range r.CardIDs {
+		if cardID == "" {
+			return NewErrBadRequest("card id cannot be empty")
+		}
+		if seen[cardID] {
+			return NewErrBadRequest(fmt.Sprintf("card %s is in the request twice", cardID))
+		}
+		seen[cardID] = true
+	}
+
+	if len(r.Operations) == 0 {
+		return NewErrBadRequest("the request has no operations")
+	}
+	for i, op := range r.Operations {
+		if err := op.isValid(); err != nil {
+			return NewErrBadRequest(fmt.Sprintf("operation %d: %s", i+1, err.Error()))
+		}
+		if op.Type == CardBulkOperationDelete && len(r.Operations) > 1 {
+			return NewErrBadRequest("a delete operation cannot be combined with other operations")
+		}
+	}
+	return nil
+}
+
+func (op CardBulkOperation) isValid() error {
+	if op.Comment != "" && op.Type != CardBulkOperationSetStatus {
+		return fmt.Errorf("only a setStatus operation can add a comment")
+	}
+	switch op.Type {
+	case CardBulkOperationPatch:
+		if op.Patch == nil {
+			return fmt.Errorf("patch cannot be empty")
+		}
+		if op.Patch.Comment != nil {
+			return fmt.Errorf("a bulk patch cannot add comments")
+		}
+		return op.Patch.CheckValid()
+	case CardBulkOperationSetStatus:
+		if op.OptionID == "" {
+			return fmt.Errorf("option id cannot be empty")
+		}
+	case CardBulkOperationAssign:
+		if op.PropertyID == "" {
+			return fmt.Errorf("property id cannot be empty")
+		}
+	case CardBulkOperationDelete:
+	case CardBulkOperationAddRelation:
+		if op.TargetCardID == "" {
+			return fmt.Errorf("target card id cannot be empty")
+		}
+		if op.RelationType != RelationTypeRelatesTo && GetInverseRelationType(op.RelationType) == op.RelationType {
+			return fmt.Errorf("invalid relation type: %s", op.RelationType)
+		}
+	default:
+		return fmt.Errorf("invalid operation type: %s", op.Type)
+	}
+	return nil
+}
+
+// CardBulkCardResult is the outcome of a bulk request for one card.
+// swagger:model
+type CardBulkCardResult struct {
+	// The ID of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// Whether all the operations were applied to the card
+	// required: true
+	Success bool `json:"success"`
+
+	// The changed card, unless it was deleted or not changed
+	// required: false
+	Card *Card `json:"card,omitempty"`
+
+	// Whether the card was deleted
+	// required: false
+	Deleted bool `json:"deleted,omitempty"`
+
+	// The relations added to the card
+	// required: false
+	Relations []*CardRelation `json:"relations,omitempty"`
+
+	// Why the card was not changed
+	// required: false
+	Error *ErrorResponse `json:"error,omitempty"`
+
+	// Err is the error that left the card unchanged.
+	Err error `json:"-"`
+}
+
+// CardBulkResult is the outcome of a bulk request.
+// swagger:model
+type CardBulkResult struct {
+	// The number of cards all the operations were applied to
+	// required: true
+	Succeeded int `json:"succeeded"`
+
+	// The number of cards left unchanged
+	// required: true
+	Failed int `json:"failed"`
+
+	// The outcome for each card, in the order of the request
+	// required: true
+	Results []*CardBulkCardResult `json:"results"`
+}
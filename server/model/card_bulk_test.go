@@ -0,0 +1,56 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardBulkRequestIsValid(t *testing.T) {
+	setStatus := CardBulkOperation{Type: CardBulkOperationSetStatus, OptionID: "done"}
+
+	testCases := []struct {
+		name    string
+		request CardBulkRequest
+		valid   bool
+	}{
+		{"valid", CardBulkRequest{CardIDs: []string{"a", "b"}, Operations: []CardBulkOperation{setStatus}}, true},
+		{"no cards", CardBulkRequest{Operations: []CardBulkOperation{setStatus}}, false},
+		{"duplicate card", CardBulkRequest{CardIDs: []string{"a", "a"}, Operations: []CardBulkOperation{setStatus}}, false},
+		{"no operations", CardBulkRequest{CardIDs: []string{"a"}}, false},
+		{"unknown operation", CardBulkRequest{CardIDs: []string{"a"}, Operations: []CardBulkOperation{{Type: "archive"}}}, false},
+		{"patch without patch", CardBulkRequest{CardIDs: []string{"a"}, Operations: []CardBulkOperation{{Type: CardBulkOperationPatch}}}, false},
+		{"set status with a comment", CardBulkRequest{CardIDs: []string{"a"}, Operations: []CardBulkOperation{
+			{Type: CardBulkOperationSetStatus, OptionID: "done", Comment: "shipped"},
+		}}, true},
+		{"comment on another operation", CardBulkRequest{CardIDs: []string{"a"}, Operations: []CardBulkOperation{
+			{Type: CardBulkOperationAssign, PropertyID: "owner", Comment: "yours"},
+		}}, false},
+		{"delete with other operations", CardBulkRequest{CardIDs: []string{"a"}, Operations: []CardBulkOperation{{Type: CardBulkOperationDelete}, setStatus}}, false},
+		{"invalid relation type", CardBulkRequest{CardIDs: []string{"a"}, Operations: []CardBulkOperation{
+			{Type: CardBulkOperationAddRelation, TargetCardID: "b", RelationType: "follows"},
+		}}, false},
+		{"relation", CardBulkRequest{CardIDs: []string{"a"}, Operations: []CardBulkOperation{
+			{Type: CardBulkOperationAddRelation, TargetCardID: "b", RelationType: RelationTypeIsBlockedBy},
+		}}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.request.IsValid()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, IsErrBadRequest(err))
+			}
+		})
+	}
+
+	cardIDs := make([]string, CardBulkMaxCards+1)
+	for i := range cardIDs {
+		cardIDs[i] = string(rune('a'+i%26)) + string(rune('a'+i/26))
+	}
+	assert.Error(t, (&CardBulkRequest{CardIDs: cardIDs, Operations: []CardBulkOperation{setStatus}}).IsValid())
+}
@@ -0,0 +1,696 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The filter, sort and grouping rules below mirror the ones implemented by the
+// webapp (cardFilter.ts, store/cards.ts and boardUtils.ts) so that the server
+// returns the same result set as the UI for a given view.
+
+const halfDayMillis = 12 * 60 * 60 * 1000
+
+var nonNumericPattern = regexp.MustCompile(`[^\d.-]`)
+
+// FilterCards returns the cards that meet the filter group.
+func FilterCards(cards []*Card, filter *FilterGroup, schema PropSchema) []*Card {
+	result := make([]*Card, 0, len(cards))
+	for _, card := range cards {
+		if filter == nil || filter.IsMet(card, schema) {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+// IsMet returns true if the card meets the filter group.
+func (fg *FilterGroup) IsMet(card *Card, schema PropSchema) bool {
+	if len(fg.Filters) == 0 {
+		return true
+	}
+
+	if fg.Operation == FilterGroupOperationOr {
+		for _, item := range fg.Filters {
+			if item.isMet(card, schema) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, item := range fg.Filters {
+		if !item.isMet(card, schema) {
+			return false
+		}
+	}
+	return true
+}
+
+func (fi FilterItem) isMet(card *Card, schema PropSchema) bool {
+	if fi.Group != nil {
+		return fi.Group.IsMet(card, schema)
+	}
+	if fi.Clause != nil {
+		return fi.Clause.IsMet(card, schema)
+	}
+	return true
+}
+
+// dateRange is the decoded value of a date property.
+type dateRange struct {
+	From        int64
+	To          int64
+	IncludeTime bool
+}
+
+// parseDateRange decodes a date property value, which is either a millisecond
+// timestamp or a JSON object of the form {"from": 1642161600000, "to": 1642161600000}.
+func parseDateRange(value string) dateRange {
+	if value == "" {
+		return dateRange{}
+	}
+
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return dateRange{From: millis}
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal([]byte(value), &m); err != nil {
+		return dateRange{}
+	}
+
+	var dr dateRange
+	if from, ok := m["from"].(float64); ok {
+		dr.From = int64(from)
+	}
+	if to, ok := m["to"].(float64); ok {
+		dr.To = int64(to)
+	}
+	if includeTime, ok := m["includeTime"].(bool); ok {
+		dr.IncludeTime = includeTime
+	}
+	return dr
+}
+
+// CardDateEnd returns the end of a card's date property in milliseconds, which
+// is the end of a date range or the date itself. It returns false if the card
+// has no date set for the property.
+func CardDateEnd(card *Card, propertyID string) (int64, bool) {
+	dr := parseDateRange(propValueString(card.Properties[propertyID]))
+	if dr.To != 0 {
+		return dr.To, true
+	}
+	return dr.From, dr.From != 0
+}
+
+// IsMet returns true if the card meets the filter clause.
+func (fc *FilterClause) IsMet(card *Card, schema PropSchema) bool {
+	value := card.Properties[fc.PropertyID]
+	if fc.PropertyID == FilterTitlePropertyID {
+		value = strings.ToLower(card.Title)
+	}
+
+	def, hasDef := schema[fc.PropertyID]
+
+	var date *dateRange
+	if hasDef && def.Type == PropTypeDate {
+		dr := parseDateRange(propValueString(value))
+		date = &dr
+	}
+
+	isTimestamp := hasDef && (def.Type == PropTypeCreatedTime || def.Type == PropTypeUpdatedTime)
+	if !isPropValueSet(value) && hasDef {
+		switch def.Type {
+		case PropTypeCreatedBy:
+			value = card.CreatedBy
+		case PropTypeUpdatedBy:
+			value = card.ModifiedBy
+		case PropTypeCreatedTime:
+			value = strconv.FormatInt(card.CreateAt, 10)
+			date = &dateRange{From: card.CreateAt}
+		case PropTypeUpdatedTime:
+			value = strconv.FormatInt(card.UpdateAt, 10)
+			date = &dateRange{From: card.UpdateAt}
+		}
+	}
+
+	firstValue := ""
+	if len(fc.Values) > 0 {
+		firstValue = strings.ToLower(fc.Values[0])
+	}
+	text := propValueString(value)
+
+	switch fc.Condition {
+	case FilterConditionIncludes:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		return propValueIncludesAny(value, fc.Values)
+	case FilterConditionNotIncludes:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		return !propValueIncludesAny(value, fc.Values)
+	case FilterConditionIsEmpty:
+		return propValueLen(value) == 0
+	case FilterConditionIsNotEmpty:
+		return propValueLen(value) > 0
+	case FilterConditionIsSet:
+		return isPropValueSet(value)
+	case FilterConditionIsNotSet:
+		return !isPropValueSet(value)
+	case FilterConditionIs:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		if date != nil {
+			filterDate, _ := strconv.ParseInt(fc.Values[0], 10, 64)
+			if isTimestamp {
+				// createdTime and updatedTime include the time of day, so
+				// the value "is" the date when it falls within 12 hours of it.
+				return date.From != 0 && date.From > filterDate-halfDayMillis && date.From < filterDate+halfDayMillis
+			}
+			if date.From != 0 && date.To != 0 {
+				return date.From <= filterDate && date.To >= filterDate
+			}
+			return date.From == filterDate
+		}
+		s, ok := value.(string)
+		return ok && s == firstValue
+	case FilterConditionContains:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		return strings.Contains(text, firstValue)
+	case FilterConditionNotContains:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		return !strings.Contains(text, firstValue)
+	case FilterConditionStartsWith:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		return strings.HasPrefix(text, firstValue)
+	case FilterConditionNotStartsWith:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		return !strings.HasPrefix(text, firstValue)
+	case FilterConditionEndsWith:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		return strings.HasSuffix(text, firstValue)
+	case FilterConditionNotEndsWith:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		return !strings.HasSuffix(text, firstValue)
+	case FilterConditionIsBefore:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		if date == nil || date.From == 0 {
+			return false
+		}
+		filterDate, _ := strconv.ParseInt(fc.Values[0], 10, 64)
+		if isTimestamp {
+			return date.From < filterDate-halfDayMillis
+		}
+		return date.From < filterDate
+	case FilterConditionIsAfter:
+		if len(fc.Values) == 0 {
+			return true
+		}
+		if date == nil {
+			return false
+		}
+		filterDate, _ := strconv.ParseInt(fc.Values[0], 10, 64)
+		if isTimestamp {
+			return date.From != 0 && date.From > filterDate+halfDayMillis
+		}
+		if date.To != 0 {
+			return date.To > filterDate
+		}
+		return date.From != 0 && date.From > filterDate
+	}
+
+	// unknown conditions are ignored
+	return true
+}
+
+// SortCards sorts the cards in place following the view sort options. If the
+// view has no sort options, the manual card order of the view is used.
+// lastCommentAt optionally maps card ids to the update time of their most
+// recent comment, which counts as a card update when sorting by updated time.
+func SortCards(cards []*Card, view *ViewFields, schema PropSchema, resolver PropValueResolver, lastCommentAt map[string]int64) {
+	if len(view.SortOptions) == 0 {
+		order := make(map[string]int, len(view.CardOrder))
+		for i, id := range view.CardOrder {
+			if _, ok := order[id]; !ok {
+				order[id] = i
+			}
+		}
+		sort.SliceStable(cards, func(i, j int) bool {
+			return manualOrder(order, cards[i], cards[j]) < 0
+		})
+		return
+	}
+
+	usernames := map[string]string{}
+	username := func(userID string) string {
+		if name, ok := usernames[userID]; ok {
+			return name
+		}
+		name := ""
+		if resolver != nil {
+			if user, err := resolver.GetUserByID(userID); err == nil && user != nil {
+				name = user.Username
+			}
+		}
+		usernames[userID] = name
+		return name
+	}
+
+	// sort options are applied one after the other with a stable sort, as the webapp does
+	for _, option := range view.SortOptions {
+		var compare func(a, b *Card) int
+
+		reversed := option.Reversed
+		switch option.PropertyID {
+		case TitlePropertyID:
+			compare = func(a, b *Card) int {
+				return reverseIf(titleOrCreatedOrder(a, b), reversed)
+			}
+		case CodePropertyID:
+			compare = func(a, b *Card) int {
+				return reverseIf(compareInt64(a.Number, b.Number), reversed)
+			}
+		default:
+			def, ok := schema[option.PropertyID]
+			if !ok {
+				// the property no longer exists, stop sorting as the webapp does
+				return
+			}
+			compare = func(a, b *Card) int {
+				return comparePropertyValues(def, a, b, reversed, username, lastCommentAt)
+			}
+		}
+
+		sort.SliceStable(cards, func(i, j int) bool {
+			return compare(cards[i], cards[j]) < 0
+		})
+	}
+}
+
+// comparePropertyValues compares two cards by a property value. Cards with
+// empty values are always placed at the bottom, regardless of the sort direction.
+func comparePropertyValues(def PropDef, a, b *Card, reversed bool, username func(string) string, lastCommentAt map[string]int64) int {
+	aValue := a.Properties[def.ID]
+	bValue := b.Properties[def.ID]
+
+	switch def.Type {
+	case PropTypeCreatedBy:
+		aValue = username(a.CreatedBy)
+		bValue = username(b.CreatedBy)
+	case PropTypeUpdatedBy:
+		aValue = username(a.ModifiedBy)
+		bValue = username(b.ModifiedBy)
+	}
+
+	result := 0
+	switch def.Type {
+	case PropTypeNumber, PropTypeDate:
+		aNum, aOK := sortableNumber(def, aValue)
+		bNum, bOK := sortableNumber(def, bValue)
+		// empty values always go to the bottom
+		switch {
+		case aOK && !bOK:
+			return -1
+		case bOK && !aOK:
+			return 1
+		case !aOK && !bOK:
+			return titleOrCreatedOrder(a, b)
+		}
+		result = compareFloat64(aNum, bNum)
+	case PropTypeCreatedTime:
+		result = compareInt64(a.CreateAt, b.CreateAt)
+	case PropTypeUpdatedTime:
+		aUpdateAt := max(a.UpdateAt, lastCommentAt[a.ID])
+		bUpdateAt := max(b.UpdateAt, lastCommentAt[b.ID])
+		result = compareInt64(aUpdateAt, bUpdateAt)
+	default:
+		// text based sort, empty values always go to the bottom
+		aLen := propValueLen(aValue)
+		bLen := propValueLen(bValue)
+		switch {
+		case aLen > 0 && bLen == 0:
+			return -1
+		case bLen > 0 && aLen == 0:
+			return 1
+		case aLen == 0 && bLen == 0:
+			return titleOrCreatedOrder(a, b)
+		}
+		result = compareTextPropertyValues(def, aValue, bValue, username)
+	}
+
+	if result == 0 {
+		result = titleOrCreatedOrder(a, b)
+	}
+	return reverseIf(result, reversed)
+}
+
+func reverseIf(result int, reversed bool) int {
+	if reversed {
+		return -result
+	}
+	return result
+}
+
+func compareTextPropertyValues(def PropDef, aValue, bValue any, username func(string) string) int {
+	switch def.Type {
+	case PropTypeSelect, PropTypeMultiSelect:
+		aOptionID := firstPropValue(aValue)
+		bOptionID := firstPropValue(bValue)
+
+		if def.SortRule == SortRuleByOrder {
+			// unknown or deleted options go to the end
+			aIndex, bIndex := len(def.Options), len(def.Options)
+			if opt, ok := def.Options[aOptionID]; ok {
+				aIndex = opt.Index
+			}
+			if opt, ok := def.Options[bOptionID]; ok {
+				bIndex = opt.Index
+			}
+			return aIndex - bIndex
+		}
+
+		aText := def.Options[aOptionID].Value
+		bText := def.Options[bOptionID].Value
+		if def.SortRule == SortRuleAsNumber {
+			return compareFloat64(extractNumber(aText), extractNumber(bText))
+		}
+		return compareText(aText, bText)
+	case PropTypeMultiPerson:
+		return compareText(joinUsernames(aValue, username), joinUsernames(bValue, username))
+	case PropTypeFormula:
+		// numeric results sort as numbers, the others as text
+		aNum, aErr := strconv.ParseFloat(propValueString(aValue), 64)
+		bNum, bErr := strconv.ParseFloat(propValueString(bValue), 64)
+		if aErr == nil && bErr == nil {
+			return compareFloat64(aNum, bNum)
+		}
+	}
+
+	aText := propValueString(aValue)
+	bText := propValueString(bValue)
+	if def.SortRule == SortRuleAsNumber {
+		return compareFloat64(extractNumber(aText), extractNumber(bText))
+	}
+	return compareText(aText, bText)
+}
+
+// GroupCards splits the cards into the groups shown by the view, following
+// the order of the view's visible option ids. Hidden groups are not returned.
+// Cards keep their relative order within each group.
+func GroupCards(cards []*Card, view *ViewFields, schema PropSchema) []*ViewCardGroup {
+	def, ok := schema[view.GroupByID]
+	if !ok {
+		return nil
+	}
+
+	hidden := make(map[string]bool, len(view.HiddenOptionIDs))
+	for _, id := range view.HiddenOptionIDs {
+		hidden[id] = true
+	}
+
+	if def.Type == PropTypePerson || def.Type == PropTypeCreatedBy || def.Type == PropTypeUpdatedBy {
+		return groupCardsByPerson(cards, def, hidden)
+	}
+
+	optionIDs := make([]string, 0, len(def.Options)+1)
+	optionIDs = append(optionIDs, view.VisibleOptionIDs...)
+	visible := make(map[string]bool, len(view.VisibleOptionIDs))
+	for _, id := range view.VisibleOptionIDs {
+		visible[id] = true
+	}
+	for _, opt := range def.OrderedOptions() {
+		if !visible[opt.ID] && !hidden[opt.ID] {
+			optionIDs = append(optionIDs, opt.ID)
+		}
+	}
+	// if the position of the empty group is not explicitly set, it goes first
+	if !visible[""] && !hidden[""] {
+		optionIDs = append([]string{""}, optionIDs...)
+	}
+
+	groups := make([]*ViewCardGroup, 0, len(optionIDs))
+	for _, optionID := range optionIDs {
+		if optionID == "" {
+			group := &ViewCardGroup{Value: "No " + def.Name, Cards: []*Card{}}
+			for _, card := range cards {
+				value, _ := card.Properties[def.ID].(string)
+				if _, known := def.Options[value]; !known {
+					group.Cards = append(group.Cards, card)
+				}
+			}
+			groups = append(groups, group)
+			continue
+		}
+
+		opt, ok := def.Options[optionID]
+		if !ok {
+			// deleted options can be ignored
+			continue
+		}
+		group := &ViewCardGroup{OptionID: opt.ID, Value: opt.Value, Cards: []*Card{}}
+		for _, card := range cards {
+			if value, _ := card.Properties[def.ID].(string); value == opt.ID {
+				group.Cards = append(group.Cards, card)
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+func groupCardsByPerson(cards []*Card, def PropDef, hidden map[string]bool) []*ViewCardGroup {
+	groups := []*ViewCardGroup{}
+	byKey := map[string]*ViewCardGroup{}
+	for _, card := range cards {
+		var key string
+		switch def.Type {
+		case PropTypeCreatedBy:
+			key = card.CreatedBy
+		case PropTypeUpdatedBy:
+			key = card.ModifiedBy
+		default:
+			key, _ = card.Properties[def.ID].(string)
+		}
+
+		if hidden[key] {
+			continue
+		}
+
+		group, ok := byKey[key]
+		if !ok {
+			group = &ViewCardGroup{OptionID: key, Value: key, Cards: []*Card{}}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.Cards = append(group.Cards, card)
+	}
+	return groups
+}
+
+// FlattenCardGroups returns the cards of all groups, in group order.
+func FlattenCardGroups(groups []*ViewCardGroup) []*Card {
+	cards := []*Card{}
+	for _, group := range groups {
+		cards = append(cards, group.Cards...)
+	}
+	return cards
+}
+
+func manualOrder(order map[string]int, a, b *Card) int {
+	aIndex, aOK := order[a.ID]
+	bIndex, bOK := order[b.ID]
+
+	switch {
+	case !aOK && !bOK:
+		return titleOrCreatedOrder(a, b)
+	case !aOK:
+		// cards without a defined order go to the end
+		return 1
+	case !bOK:
+		return -1
+	}
+	return aIndex - bIndex
+}
+
+func titleOrCreatedOrder(a, b *Card) int {
+	switch {
+	case a.Title != "" && b.Title != "":
+		return compareText(a.Title, b.Title)
+	case a.Title != "":
+		// untitled cards always go to the bottom
+		return -1
+	case b.Title != "":
+		return 1
+	}
+	return compareInt64(a.CreateAt, b.CreateAt)
+}
+
+func compareText(a, b string) int {
+	if result := strings.Compare(strings.ToLower(a), strings.ToLower(b)); result != 0 {
+		return result
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func sortableNumber(def PropDef, value any) (float64, bool) {
+	s := propValueString(value)
+	if s == "" {
+		return 0, false
+	}
+	if def.Type == PropTypeDate {
+		dr := parseDateRange(s)
+		return float64(dr.From), dr.From != 0
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, true
+	}
+	return n, true
+}
+
+func extractNumber(s string) float64 {
+	n, err := strconv.ParseFloat(nonNumericPattern.ReplaceAllString(s, ""), 64)
+	if err != nil {
+		return math.Inf(1)
+	}
+	return n
+}
+
+func joinUsernames(value any, username func(string) string) string {
+	ids := propValueList(value)
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		names = append(names, username(id))
+	}
+	return strings.Join(names, ",")
+}
+
+// propValueString returns the string representation of a property value.
+func propValueString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []any, []string:
+		return strings.Join(propValueList(v), ",")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// propValueList returns the elements of a multi-value property, or the
+// value itself for single value properties.
+func propValueList(value any) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []string:
+		return v
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			list = append(list, propValueString(item))
+		}
+		return list
+	default:
+		return []string{propValueString(v)}
+	}
+}
+
+func firstPropValue(value any) string {
+	if list := propValueList(value); len(list) > 0 {
+		return list[0]
+	}
+	return ""
+}
+
+func propValueLen(value any) int {
+	switch v := value.(type) {
+	case []any:
+		return len(v)
+	case []string:
+		return len(v)
+	}
+	return len(propValueString(value))
+}
+
+func isPropValueSet(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	case bool:
+		return v
+	}
+	return true
+}
+
+func propValueIncludesAny(value any, candidates []string) bool {
+	_, isList := value.([]any)
+	if _, ok := value.([]string); ok {
+		isList = true
+	}
+
+	for _, candidate := range candidates {
+		if isList {
+			for _, item := range propValueList(value) {
+				if item == candidate {
+					return true
+				}
+			}
+			continue
+		}
+		if s, ok := value.(string); ok && s == candidate {
+			return true
+		}
+	}
+	return false
+}
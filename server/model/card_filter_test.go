@@ -0,0 +1,278 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testStatusPropID   = "status"
+	testTagsPropID     = "tags"
+	testEstimatePropID = "estimate"
+	testDuePropID      = "due"
+	testCreatedPropID  = "created"
+)
+
+func testFilterSchema() PropSchema {
+	return PropSchema{
+		testStatusPropID: {
+			ID:   testStatusPropID,
+			Name: "Status",
+			Type: PropTypeSelect,
+			Options: map[string]PropDefOption{
+				"todo":  {ID: "todo", Index: 0, Value: "To Do"},
+				"doing": {ID: "doing", Index: 1, Value: "Doing"},
+				"done":  {ID: "done", Index: 2, Value: "Done"},
+			},
+		},
+		testTagsPropID: {
+			ID:   testTagsPropID,
+			Name: "Tags",
+			Type: PropTypeMultiSelect,
+			Options: map[string]PropDefOption{
+				"bug":     {ID: "bug", Index: 0, Value: "Bug"},
+				"feature": {ID: "feature", Index: 1, Value: "Feature"},
+			},
+		},
+		testEstimatePropID: {ID: testEstimatePropID, Name: "Estimate", Type: PropTypeNumber, Index: 2},
+		testDuePropID:      {ID: testDuePropID, Name: "Due", Type: PropTypeDate, Index: 3},
+		testCreatedPropID:  {ID: testCreatedPropID, Name: "Created", Type: PropTypeCreatedTime, Index: 4},
+	}
+}
+
+func testFilterCards() []*Card {
+	return []*Card{
+		{ID: "c1", Title: "Alpha", CreateAt: 1000, Number: 3, Properties: map[string]any{
+			testStatusPropID:   "todo",
+			testTagsPropID:     []any{"bug"},
+			testEstimatePropID: "5",
+			testDuePropID:      `{"from":1700000000000}`,
+		}},
+		{ID: "c2", Title: "beta", CreateAt: 2000, Number: 1, Properties: map[string]any{
+			testStatusPropID:   "doing",
+			testTagsPropID:     []any{"bug", "feature"},
+			testEstimatePropID: "10",
+		}},
+		{ID: "c3", Title: "Gamma", CreateAt: 3000, Number: 2, Properties: map[string]any{
+			testStatusPropID: "done",
+			testDuePropID:    `{"from":1600000000000,"to":1800000000000}`,
+		}},
+		{ID: "c4", Title: "", CreateAt: 4000, Number: 4, Properties: map[string]any{}},
+	}
+}
+
+func cardIDs(cards []*Card) []string {
+	ids := make([]string, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	return ids
+}
+
+func TestParseViewFields(t *testing.T) {
+	t.Run("parses nested filter groups", func(t *testing.T) {
+		var fields map[string]any
+		err := json.Unmarshal([]byte(`{
+			"viewType": "table",
+			"groupById": "status",
+			"sortOptions": [{"propertyId": "estimate", "reversed": true}],
+			"filter": {
+				"operation": "or",
+				"filters": [
+					{"propertyId": "status", "condition": "includes", "values": ["todo"]},
+					{"operation": "and", "filters": [
+						{"propertyId": "tags", "condition": "includes", "values": ["feature"]}
+					]}
+				]
+			}
+		}`), &fields)
+		require.NoError(t, err)
+
+		view, err := ParseViewFields(&Block{Type: TypeView, Fields: fields})
+		require.NoError(t, err)
+
+		assert.Equal(t, ViewTypeTable, view.ViewType)
+		assert.Equal(t, "status", view.GroupByID)
+		require.Len(t, view.SortOptions, 1)
+		assert.True(t, view.SortOptions[0].Reversed)
+		assert.Equal(t, FilterGroupOperationOr, view.Filter.Operation)
+		require.Len(t, view.Filter.Filters, 2)
+		require.NotNil(t, view.Filter.Filters[0].Clause)
+		assert.Equal(t, FilterConditionIncludes, view.Filter.Filters[0].Clause.Condition)
+		require.NotNil(t, view.Filter.Filters[1].Group)
+		assert.Len(t, view.Filter.Filters[1].Group.Filters, 1)
+		assert.Equal(t, ViewVisibilityEveryone, view.Visibility)
+	})
+
+	t.Run("defaults for empty fields", func(t *testing.T) {
+		view, err := ParseViewFields(&Block{Type: TypeView, Fields: map[string]any{}})
+		require.NoError(t, err)
+		assert.Equal(t, ViewTypeBoard, view.ViewType)
+		assert.Equal(t, FilterGroupOperationAnd, view.Filter.Operation)
+	})
+
+	t.Run("rejects non view blocks", func(t *testing.T) {
+		_, err := ParseViewFields(&Block{Type: TypeCard})
+		require.ErrorIs(t, err, ErrNotViewBlock)
+	})
+}
+
+func TestFilterCards(t *testing.T) {
+	schema := testFilterSchema()
+
+	testCases := []struct {
+		name     string
+		filter   FilterGroup
+		expected []string
+	}{
+		{
+			name:     "no filters",
+			filter:   FilterGroup{Operation: FilterGroupOperationAnd},
+			expected: []string{"c1", "c2", "c3", "c4"},
+		},
+		{
+			name: "select includes",
+			filter: FilterGroup{Operation: FilterGroupOperationAnd, Filters: []FilterItem{
+				{Clause: &FilterClause{PropertyID: testStatusPropID, Condition: FilterConditionIncludes, Values: []string{"todo", "done"}}},
+			}},
+			expected: []string{"c1", "c3"},
+		},
+		{
+			name: "multi select not includes",
+			filter: FilterGroup{Operation: FilterGroupOperationAnd, Filters: []FilterItem{
+				{Clause: &FilterClause{PropertyID: testTagsPropID, Condition: FilterConditionNotIncludes, Values: []string{"feature"}}},
+			}},
+			expected: []string{"c1", "c3", "c4"},
+		},
+		{
+			name: "is empty",
+			filter: FilterGroup{Operation: FilterGroupOperationAnd, Filters: []FilterItem{
+				{Clause: &FilterClause{PropertyID: testTagsPropID, Condition: FilterConditionIsEmpty}},
+			}},
+			expected: []string{"c3", "c4"},
+		},
+		{
+			name: "title contains is case insensitive",
+			filter: FilterGroup{Operation: FilterGroupOperationAnd, Filters: []FilterItem{
+				{Clause: &FilterClause{PropertyID: FilterTitlePropertyID, Condition: FilterConditionContains, Values: []string{"A"}}},
+			}},
+			expected: []string{"c1", "c2", "c3"},
+		},
+		{
+			name: "date is within range",
+			filter: FilterGroup{Operation: FilterGroupOperationAnd, Filters: []FilterItem{
+				{Clause: &FilterClause{PropertyID: testDuePropID, Condition: FilterConditionIs, Values: []string{"1700000000000"}}},
+			}},
+			expected: []string{"c1", "c3"},
+		},
+		{
+			name: "date is before",
+			filter: FilterGroup{Operation: FilterGroupOperationAnd, Filters: []FilterItem{
+				{Clause: &FilterClause{PropertyID: testDuePropID, Condition: FilterConditionIsBefore, Values: []string{"1650000000000"}}},
+			}},
+			expected: []string{"c3"},
+		},
+		{
+			name: "created time is after",
+			filter: FilterGroup{Operation: FilterGroupOperationAnd, Filters: []FilterItem{
+				{Clause: &FilterClause{PropertyID: testCreatedPropID, Condition: FilterConditionIsAfter, Values: []string{"-43197500"}}},
+			}},
+			expected: []string{"c3", "c4"},
+		},
+		{
+			name: "or with nested and group",
+			filter: FilterGroup{Operation: FilterGroupOperationOr, Filters: []FilterItem{
+				{Clause: &FilterClause{PropertyID: testStatusPropID, Condition: FilterConditionIncludes, Values: []string{"done"}}},
+				{Group: &FilterGroup{Operation: FilterGroupOperationAnd, Filters: []FilterItem{
+					{Clause: &FilterClause{PropertyID: testTagsPropID, Condition: FilterConditionIncludes, Values: []string{"bug"}}},
+					{Clause: &FilterClause{PropertyID: testStatusPropID, Condition: FilterConditionIncludes, Values: []string{"doing"}}},
+				}}},
+			}},
+			expected: []string{"c2", "c3"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filtered := FilterCards(testFilterCards(), &tc.filter, schema)
+			assert.Equal(t, tc.expected, cardIDs(filtered))
+		})
+	}
+}
+
+func TestSortCards(t *testing.T) {
+	schema := testFilterSchema()
+
+	t.Run("manual order", func(t *testing.T) {
+		cards := testFilterCards()
+		SortCards(cards, &ViewFields{CardOrder: []string{"c3", "c1"}}, schema, nil, nil)
+		assert.Equal(t, []string{"c3", "c1", "c2", "c4"}, cardIDs(cards))
+	})
+
+	t.Run("by title", func(t *testing.T) {
+		cards := testFilterCards()
+		SortCards(cards, &ViewFields{SortOptions: []SortOption{{PropertyID: TitlePropertyID}}}, schema, nil, nil)
+		assert.Equal(t, []string{"c1", "c2", "c3", "c4"}, cardIDs(cards))
+	})
+
+	t.Run("by code reversed", func(t *testing.T) {
+		cards := testFilterCards()
+		SortCards(cards, &ViewFields{SortOptions: []SortOption{{PropertyID: CodePropertyID, Reversed: true}}}, schema, nil, nil)
+		assert.Equal(t, []string{"c4", "c1", "c3", "c2"}, cardIDs(cards))
+	})
+
+	t.Run("by number keeps empty values at the bottom", func(t *testing.T) {
+		cards := testFilterCards()
+		SortCards(cards, &ViewFields{SortOptions: []SortOption{{PropertyID: testEstimatePropID, Reversed: true}}}, schema, nil, nil)
+		assert.Equal(t, []string{"c2", "c1"}, cardIDs(cards)[:2])
+	})
+
+	t.Run("by select option order", func(t *testing.T) {
+		byOrder := testFilterSchema()
+		def := byOrder[testStatusPropID]
+		def.SortRule = SortRuleByOrder
+		byOrder[testStatusPropID] = def
+
+		cards := testFilterCards()
+		SortCards(cards, &ViewFields{SortOptions: []SortOption{{PropertyID: testStatusPropID}}}, byOrder, nil, nil)
+		assert.Equal(t, []string{"c1", "c2", "c3", "c4"}, cardIDs(cards))
+	})
+
+	t.Run("by select option value", func(t *testing.T) {
+		cards := testFilterCards()
+		SortCards(cards, &ViewFields{SortOptions: []SortOption{{PropertyID: testStatusPropID}}}, schema, nil, nil)
+		assert.Equal(t, []string{"c2", "c3", "c1", "c4"}, cardIDs(cards))
+	})
+}
+
+func TestGroupCards(t *testing.T) {
+	schema := testFilterSchema()
+
+	t.Run("groups by select option", func(t *testing.T) {
+		view := &ViewFields{
+			GroupByID:        testStatusPropID,
+			VisibleOptionIDs: []string{"done"},
+			HiddenOptionIDs:  []string{"doing"},
+		}
+		groups := GroupCards(testFilterCards(), view, schema)
+		require.Len(t, groups, 3)
+
+		assert.Equal(t, "", groups[0].OptionID)
+		assert.Equal(t, "No Status", groups[0].Value)
+		assert.Equal(t, []string{"c4"}, cardIDs(groups[0].Cards))
+		assert.Equal(t, "done", groups[1].OptionID)
+		assert.Equal(t, []string{"c3"}, cardIDs(groups[1].Cards))
+		assert.Equal(t, "todo", groups[2].OptionID)
+		assert.Equal(t, []string{"c1"}, cardIDs(groups[2].Cards))
+	})
+
+	t.Run("unknown group property", func(t *testing.T) {
+		assert.Nil(t, GroupCards(testFilterCards(), &ViewFields{GroupByID: "missing"}, schema))
+	})
+}
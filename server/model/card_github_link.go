@@ -0,0 +1,300 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+// CardGitHubLinkKind is the kind of GitHub item linked to a card.
+type CardGitHubLinkKind string
+
+const (
+	CardGitHubLinkKindIssue       CardGitHubLinkKind = "issue"
+	CardGitHubLinkKindPullRequest CardGitHubLinkKind = "pull_request"
+	CardGitHubLinkKindCommit      CardGitHubLinkKind = "commit"
+)
+
+// IsValid returns true if the kind is known.
+func (k CardGitHubLinkKind) IsValid() bool {
+	return k == CardGitHubLinkKindIssue || k == CardGitHubLinkKindPullRequest || k == CardGitHubLinkKindCommit
+}
+
+const (
+	// MaxCardGitHubLinks is the largest number of GitHub links of a card.
+	MaxCardGitHubLinks = 50
+
+	maxCardGitHubLinkSyncErrorLength = 500
+)
+
+// CardGitHubLink is a GitHub issue, pull request or commit linked to a card,
+// along with the state of the item as of its last synchronization. The state is
+// kept when GitHub cannot be reached, so that cards always show the last
+// known state. Issues of the other trackers are linked the same way, by
+// their project and number.
+// swagger:model
+type CardGitHubLink struct {
+	// The id of the link
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The issue tracker of the item, github by default
+	// required: false
+	Provider TrackerProvider `json:"provider"`
+
+	// The kind of item, issue, pull_request or commit. Only issues are linked
+	// from the other trackers.
+	// required: true
+	Kind CardGitHubLinkKind `json:"kind"`
+
+	// The owner of the GitHub repository
+	// required: false
+	Owner string `json:"owner"`
+
+	// The name of the GitHub repository
+	// required: false
+	Repo string `json:"repo"`
+
+	// The project of the issue on the other trackers, such as a GitLab
+	// project path or a Jira project key
+	// required: false
+	Project string `json:"project,omitempty"`
+
+	// The number of the issue or pull request
+	// required: false
+	Number int `json:"number"`
+
+	// The SHA of the commit
+	// required: false
+	SHA string `json:"sha,omitempty"`
+
+	// The URL of the item on its tracker
+	// required: false
+	URL string `json:"url"`
+
+	// The title, or the first line of the commit message, as of the last
+	// synchronization
+	// required: false
+	Title string `json:"title"`
+
+	// The state (open or closed), as of the last synchronization
+	// required: false
+	State string `json:"state"`
+
+	// The names of the labels, as of the last synchronization
+	// required: false
+	Labels []string `json:"labels"`
+
+	// The logins of the assignees, as of the last synchronization
+	// required: false
+	Assignees []string `json:"assignees"`
+
+	// True if the pull request is merged, as of the last synchronization
+	// required: false
+	Merged bool `json:"merged"`
+
+	// The time of the last successful synchronization in milliseconds since
+	// the current epoch, or zero
+	// required: false
+	SyncedAt int64 `json:"syncedAt"`
+
+	// The error of the last synchronization attempt, empty if it succeeded.
+	// The other fields then hold the last known state.
+	// required: false
+	SyncError string `json:"syncError,omitempty"`
+
+	// The time of the last synchronization attempt in milliseconds since
+	// the current epoch, or zero
+	// required: false
+	SyncAttemptAt int64 `json:"syncAttemptAt"`
+
+	// The id of the user who added the link. Their GitHub account is used
+	// to synchronize the links to GitHub.
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// Populate populates a CardGitHubLink with default values, filling the
+// repository and number of a GitHub link from its URL if they are missing.
+func (l *CardGitHubLink) Populate() error {
+	if l.Provider == "" {
+		l.Provider = TrackerProviderGitHub
+	}
+	if l.Provider == TrackerProviderGitHub && l.Owner == "" && l.URL != "" {
+		kind, owner, repo, number, err := ParseGitHubItemURL(l.URL)
+		if err != nil {
+			return err
+		}
+		l.Kind, l.Owner, l.Repo, l.Number = kind, owner, repo, number
+	}
+	if l.ID == "" {
+		l.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if l.Labels == nil {
+		l.Labels = []string{}
+	}
+	if l.Assignees == nil {
+		l.Assignees = []string{}
+	}
+	now := utils.GetMillis()
+	if l.CreateAt == 0 {
+		l.CreateAt = now
+	}
+	if l.UpdateAt == 0 {
+		l.UpdateAt = now
+	}
+	return nil
+}
+
+// IsValid validates the link.
+func (l *CardGitHubLink) IsValid() error {
+	if l.ID == "" {
+		return NewErrBadRequest("github link id cannot be empty")
+	}
+	if l.BoardID == "" {
+		return NewErrBadRequest("github link board id cannot be empty")
+	}
+	if l.CardID == "" {
+		return NewErrBadRequest("github link card id cannot be empty")
+	}
+	if !l.Provider.IsValid() {
+		return NewErrBadRequest(fmt.Sprintf("invalid issue tracker %q", l.Provider))
+	}
+	if !l.Kind.IsValid() {
+		return NewErrBadRequest(fmt.Sprintf("invalid github link kind %q", l.Kind))
+	}
+	if l.CreatedBy == "" {
+		return NewErrBadRequest("github link creator cannot be empty")
+	}
+	if l.Provider != TrackerProviderGitHub {
+		if l.Kind != CardGitHubLinkKindIssue {
+			return NewErrBadRequest(fmt.Sprintf("only issues can be linked from %s", l.Provider))
+		}
+		if l.Project == "" || l.Owner != "" || l.Repo != "" || l.SHA != "" {
+			return NewErrBadRequest(fmt.Sprintf("%s link needs a project and no repository", l.Provider))
+		}
+		if l.Number <= 0 {
+			return NewErrBadRequest("github link number must be positive")
+		}
+		return nil
+	}
+	if l.Owner == "" || l.Repo == "" || strings.ContainsRune(l.Owner, '/') || strings.ContainsRune(l.Repo, '/') {
+		return NewErrBadRequest("github link needs a repository owner and name")
+	}
+	if l.Kind == CardGitHubLinkKindCommit {
+		if l.SHA == "" || l.Number != 0 {
+			return NewErrBadRequest("github commit link needs a sha and no number")
+		}
+	} else if l.Number <= 0 {
+		return NewErrBadRequest("github link number must be positive")
+	}
+	if l.Project != "" {
+		return NewErrBadRequest("github link cannot have a project")
+	}
+	return nil
+}
+
+// Same returns true if both links are to the same item.
+func (l *CardGitHubLink) Same(other *CardGitHubLink) bool {
+	return l.TrackerProvider() == other.TrackerProvider() &&
+		l.Kind == other.Kind &&
+		strings.EqualFold(l.Project, other.Project) &&
+		strings.EqualFold(l.Owner, other.Owner) &&
+		strings.EqualFold(l.Repo, other.Repo) &&
+		l.Number == other.Number &&
+		strings.EqualFold(l.SHA, other.SHA)
+}
+
+// Reference returns the short reference of the linked item, such as
+// owner/repo#12, owner/repo@0123abc, group/project#12 or PROJ-12.
+func (l *CardGitHubLink) Reference() string {
+	switch l.TrackerProvider() {
+	case TrackerProviderGitLab:
+		return fmt.Sprintf("%s#%d", l.Project, l.Number)
+	case TrackerProviderJira:
+		return fmt.Sprintf("%s-%d", l.Project, l.Number)
+	}
+	if l.Kind == CardGitHubLinkKindCommit {
+		sha := l.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		return fmt.Sprintf("%s/%s@%s", l.Owner, l.Repo, sha)
+	}
+	return fmt.Sprintf("%s/%s#%d", l.Owner, l.Repo, l.Number)
+}
+
+// TrackerProvider returns the issue tracker of the linked item. Links
+// created before trackers were pluggable have no provider and are to GitHub.
+func (l *CardGitHubLink) TrackerProvider() TrackerProvider {
+	if l.Provider == "" {
+		return TrackerProviderGitHub
+	}
+	return l.Provider
+}
+
+// SetSyncError records a failed synchronization attempt, keeping the last
+// known state of the item.
+func (l *CardGitHubLink) SetSyncError(err error, now int64) {
+	msg := err.Error()
+	if len(msg) > maxCardGitHubLinkSyncErrorLength {
+		msg = msg[:maxCardGitHubLinkSyncErrorLength]
+	}
+	l.SyncError = msg
+	l.SyncAttemptAt = now
+	l.UpdateAt = now
+}
+
+// ParseGitHubItemURL returns the kind, repository and number of an issue or
+// pull request from its URL, such as
+// https://github.com/owner/repo/issues/12 or
+// https://github.com/owner/repo/pull/34.
+func ParseGitHubItemURL(itemURL string) (CardGitHubLinkKind, string, string, int, error) {
+	invalid := NewErrBadRequest(fmt.Sprintf("%q is not the URL of a GitHub issue or pull request", itemURL))
+
+	u, err := url.Parse(strings.TrimSpace(itemURL))
+	if err != nil || !strings.EqualFold(u.Hostname(), "github.com") {
+		return "", "", "", 0, invalid
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", 0, invalid
+	}
+
+	var kind CardGitHubLinkKind
+	switch parts[2] {
+	case "issues":
+		kind = CardGitHubLinkKindIssue
+	case "pull", "pulls":
+		kind = CardGitHubLinkKindPullRequest
+	default:
+		return "", "", "", 0, invalid
+	}
+	number, err := strconv.Atoi(parts[3])
+	if err != nil || number <= 0 {
+		return "", "", "", 0, invalid
+	}
+	return kind, parts[0], parts[1], number, nil
+}
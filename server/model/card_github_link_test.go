@@ -0,0 +1,119 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGitHubItemURL(t *testing.T) {
+	testCases := []struct {
+		name   string
+		url    string
+		kind   CardGitHubLinkKind
+		owner  string
+		repo   string
+		number int
+	}{
+		{"issue", "https://github.com/owner/repo/issues/12", CardGitHubLinkKindIssue, "owner", "repo", 12},
+		{"pull request", "https://github.com/owner/repo/pull/34", CardGitHubLinkKindPullRequest, "owner", "repo", 34},
+		{"pull request files", "https://github.com/owner/repo/pull/34/files", CardGitHubLinkKindPullRequest, "owner", "repo", 34},
+		{"not github", "https://gitlab.com/owner/repo/issues/12", "", "", "", 0},
+		{"repository", "https://github.com/owner/repo", "", "", "", 0},
+		{"not a number", "https://github.com/owner/repo/issues/new", "", "", "", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kind, owner, repo, number, err := ParseGitHubItemURL(tc.url)
+			if tc.kind == "" {
+				require.True(t, IsErrBadRequest(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.kind, kind)
+			assert.Equal(t, tc.owner, owner)
+			assert.Equal(t, tc.repo, repo)
+			assert.Equal(t, tc.number, number)
+		})
+	}
+}
+
+func TestCardGitHubLink(t *testing.T) {
+	t.Run("populate from URL", func(t *testing.T) {
+		link := &CardGitHubLink{BoardID: "board-id", CardID: "card-id", CreatedBy: "user-id", URL: "https://github.com/owner/repo/pull/7"}
+		require.NoError(t, link.Populate())
+		require.NoError(t, link.IsValid())
+		assert.Equal(t, CardGitHubLinkKindPullRequest, link.Kind)
+		assert.Equal(t, 7, link.Number)
+		assert.NotNil(t, link.Labels)
+	})
+
+	t.Run("needs a repository", func(t *testing.T) {
+		link := &CardGitHubLink{BoardID: "board-id", CardID: "card-id", CreatedBy: "user-id", Kind: CardGitHubLinkKindIssue, Number: 1}
+		require.NoError(t, link.Populate())
+		require.True(t, IsErrBadRequest(link.IsValid()))
+	})
+
+	t.Run("commit needs a sha", func(t *testing.T) {
+		link := &CardGitHubLink{BoardID: "board-id", CardID: "card-id", CreatedBy: "user-id", Kind: CardGitHubLinkKindCommit, Owner: "owner", Repo: "repo"}
+		require.NoError(t, link.Populate())
+		require.True(t, IsErrBadRequest(link.IsValid()))
+
+		link.SHA = "0123456789abcdef"
+		require.NoError(t, link.IsValid())
+		assert.False(t, link.Same(&CardGitHubLink{Kind: CardGitHubLinkKindCommit, Owner: "owner", Repo: "repo", SHA: "fedcba"}))
+	})
+
+	t.Run("issue of another tracker", func(t *testing.T) {
+		link := &CardGitHubLink{BoardID: "board-id", CardID: "card-id", CreatedBy: "user-id", Provider: TrackerProviderJira, Kind: CardGitHubLinkKindIssue, Project: "PROJ", Number: 12}
+		require.NoError(t, link.Populate())
+		require.NoError(t, link.IsValid())
+		assert.Equal(t, "PROJ-12", link.Reference())
+		assert.False(t, link.Same(&CardGitHubLink{Provider: TrackerProviderGitLab, Kind: CardGitHubLinkKindIssue, Project: "PROJ", Number: 12}))
+
+		link.Kind = CardGitHubLinkKindPullRequest
+		require.True(t, IsErrBadRequest(link.IsValid()))
+
+		link.Kind = CardGitHubLinkKindIssue
+		link.Owner = "owner"
+		require.True(t, IsErrBadRequest(link.IsValid()))
+	})
+
+	t.Run("links without a provider are to GitHub", func(t *testing.T) {
+		link := &CardGitHubLink{Kind: CardGitHubLinkKindIssue, Owner: "owner", Repo: "repo", Number: 12}
+		assert.Equal(t, TrackerProviderGitHub, link.TrackerProvider())
+		assert.True(t, link.Same(&CardGitHubLink{Provider: TrackerProviderGitHub, Kind: CardGitHubLinkKindIssue, Owner: "owner", Repo: "repo", Number: 12}))
+	})
+
+	t.Run("sync error keeps the state", func(t *testing.T) {
+		link := &CardGitHubLink{Title: "title", State: "open", SyncedAt: 10}
+		link.SetSyncError(errors.New(strings.Repeat("x", 1000)), 20)
+		assert.Equal(t, "title", link.Title)
+		assert.Equal(t, int64(10), link.SyncedAt)
+		assert.Equal(t, int64(20), link.SyncAttemptAt)
+		assert.Len(t, link.SyncError, maxCardGitHubLinkSyncErrorLength)
+	})
+}
+
+func TestGetBoardTrackerProvider(t *testing.T) {
+	board := &Board{Properties: map[string]interface{}{}}
+	provider, err := GetBoardTrackerProvider(board)
+	require.NoError(t, err)
+	assert.Equal(t, TrackerProviderGitHub, provider)
+
+	board.Properties[BoardPropertyTrackerProvider] = "jira"
+	provider, err = GetBoardTrackerProvider(board)
+	require.NoError(t, err)
+	assert.Equal(t, TrackerProviderJira, provider)
+
+	board.Properties[BoardPropertyTrackerProvider] = "bitbucket"
+	_, err = GetBoardTrackerProvider(board)
+	require.True(t, IsErrBadRequest(err))
+}
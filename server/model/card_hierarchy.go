@@ -0,0 +1,217 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+// CardHierarchyMaxDepth is the largest number of ancestors of a card.
+const CardHierarchyMaxDepth = 10
+
+// RollupFunction is how the values of the children of a card are combined.
+type RollupFunction string
+
+const (
+	RollupSum     RollupFunction = "sum"
+	RollupMin     RollupFunction = "min"
+	RollupMax     RollupFunction = "max"
+	RollupAverage RollupFunction = "average"
+)
+
+// IsValid returns true if the rollup function is known.
+func (f RollupFunction) IsValid() bool {
+	switch f {
+	case RollupSum, RollupMin, RollupMax, RollupAverage:
+		return true
+	}
+	return false
+}
+
+// Compute combines values with the function. It returns false if there are
+// no values.
+func (f RollupFunction) Compute(values []float64) (float64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	result := values[0]
+	switch f {
+	case RollupSum, RollupAverage:
+		for _, value := range values[1:] {
+			result += value
+		}
+		if f == RollupAverage {
+			result /= float64(len(values))
+		}
+	case RollupMin:
+		for _, value := range values[1:] {
+			result = math.Min(result, value)
+		}
+	case RollupMax:
+		for _, value := range values[1:] {
+			result = math.Max(result, value)
+		}
+	default:
+		return 0, false
+	}
+	return result, true
+}
+
+// PropRollup makes a number property of a card hold a value computed from
+// the number property of the same name of its children.
+// swagger:model
+type PropRollup struct {
+	// How the values are combined: sum, min, max or average
+	// required: true
+	Function RollupFunction `json:"function"`
+
+	// The name of the number property of the children
+	// required: true
+	PropertyName string `json:"propertyName"`
+}
+
+// RollupPropDefs returns the number properties of a schema that are rolled up
+// from the children of the cards.
+func (ps PropSchema) RollupPropDefs() []PropDef {
+	var rollups []PropDef
+	for _, pd := range ps {
+		if pd.Type == PropTypeNumber && pd.Rollup != nil && pd.Rollup.Function.IsValid() {
+			rollups = append(rollups, pd)
+		}
+	}
+	return rollups
+}
+
+// NumberPropDefByName returns the number property of a schema with a name,
+// ignoring case.
+func (ps PropSchema) NumberPropDefByName(name string) (PropDef, bool) {
+	var found PropDef
+	ok := false
+	for _, pd := range ps {
+		if pd.Type != PropTypeNumber || !strings.EqualFold(strings.TrimSpace(pd.Name), strings.TrimSpace(name)) {
+			continue
+		}
+		if !ok || pd.Index < found.Index {
+			found = pd
+			ok = true
+		}
+	}
+	return found, ok
+}
+
+// ParseNumberValue returns the value of a number property.
+func ParseNumberValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return number, err == nil
+	}
+	return 0, false
+}
+
+// FormatNumberValue returns a number as the value of a number property.
+func FormatNumberValue(number float64) string {
+	return strconv.FormatFloat(number, 'f', -1, 64)
+}
+
+// CardParent makes a card a child of another card, possibly on another board.
+// swagger:model
+type CardParent struct {
+	// The ID of the child card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The ID of the parent card
+	// required: true
+	ParentCardID string `json:"parentCardId"`
+
+	// The ID of the user that set the parent
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// Populate sets the creation time of a card parent if it is not set.
+func (cp *CardParent) Populate() {
+	if cp.CreateAt == 0 {
+		cp.CreateAt = utils.GetMillis()
+	}
+}
+
+// IsValid returns an error if the card parent misses a field or makes a card
+// its own parent.
+func (cp *CardParent) IsValid() error {
+	if cp.CardID == "" {
+		return NewErrBadRequest("card id cannot be empty")
+	}
+	if cp.ParentCardID == "" {
+		return NewErrBadRequest("parent card id cannot be empty")
+	}
+	if cp.CardID == cp.ParentCardID {
+		return NewErrBadRequest("a card cannot be its own parent")
+	}
+	if cp.CreatedBy == "" {
+		return NewErrBadRequest("created by cannot be empty")
+	}
+	return nil
+}
+
+// CardChildrenSummary is the progress of the children of a card and the
+// values rolled up from them.
+// swagger:model
+type CardChildrenSummary struct {
+	// The number of children
+	// required: true
+	ChildCount int `json:"childCount"`
+
+	// The number of children in a done status
+	// required: true
+	DoneCount int `json:"doneCount"`
+
+	// The percentage of children in a done status
+	// required: true
+	Completion float64 `json:"completion"`
+
+	// The rolled up values, by ID of the property of the parent card
+	// required: true
+	Rollups map[string]float64 `json:"rollups"`
+}
+
+// SetCompletion computes the completion percentage from the counts.
+func (s *CardChildrenSummary) SetCompletion() {
+	s.Completion = 0
+	if s.ChildCount > 0 {
+		s.Completion = math.Round(float64(s.DoneCount)*10000/float64(s.ChildCount)) / 100
+	}
+}
+
+// CardChildren is the children of a card.
+// swagger:model
+type CardChildren struct {
+	// The ID of the parent card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The progress of the children and the rolled up values
+	// required: true
+	Summary *CardChildrenSummary `json:"summary"`
+
+	// The child cards
+	// required: true
+	Children []*Card `json:"children"`
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupFunctionCompute(t *testing.T) {
+	values := []float64{3, 1.5, 6}
+
+	testCases := []struct {
+		function RollupFunction
+		expected float64
+	}{
+		{RollupSum, 10.5},
+		{RollupMin, 1.5},
+		{RollupMax, 6},
+		{RollupAverage, 3.5},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.function), func(t *testing.T) {
+			value, ok := tc.function.Compute(values)
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+
+	t.Run("no values", func(t *testing.T) {
+		_, ok := RollupSum.Compute(nil)
+		assert.False(t, ok)
+	})
+
+	t.Run("unknown function", func(t *testing.T) {
+		_, ok := RollupFunction("median").Compute(values)
+		assert.False(t, ok)
+	})
+}
+
+func TestParsePropertySchemaRollup(t *testing.T) {
+	board := &Board{
+		CardProperties: []map[string]interface{}{
+			{"id": "points", "name": "Points", "type": "number", "rollup": map[string]interface{}{
+				"function": "sum", "propertyName": "Estimate",
+			}},
+			{"id": "estimate", "name": "Estimate", "type": "number"},
+			{"id": "bad", "name": "Bad", "type": "number", "rollup": map[string]interface{}{
+				"function": "median", "propertyName": "Estimate",
+			}},
+		},
+	}
+	schema, err := ParsePropertySchema(board)
+	require.NoError(t, err)
+
+	rollups := schema.RollupPropDefs()
+	require.Len(t, rollups, 1)
+	assert.Equal(t, "points", rollups[0].ID)
+	assert.Equal(t, &PropRollup{Function: RollupSum, PropertyName: "Estimate"}, rollups[0].Rollup)
+
+	prop, ok := schema.NumberPropDefByName(" estimate ")
+	require.True(t, ok)
+	assert.Equal(t, "estimate", prop.ID)
+	_, ok = schema.NumberPropDefByName("Status")
+	assert.False(t, ok)
+}
+
+func TestParseNumberValue(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    interface{}
+		expected float64
+		ok       bool
+	}{
+		{"string", "2.5", 2.5, true},
+		{"padded string", " 3 ", 3, true},
+		{"float", 4.0, 4, true},
+		{"int", 5, 5, true},
+		{"empty string", "", 0, false},
+		{"text", "five", 0, false},
+		{"nil", nil, 0, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, ok := ParseNumberValue(tc.value)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+
+	assert.Equal(t, "2.5", FormatNumberValue(2.5))
+	assert.Equal(t, "10", FormatNumberValue(10))
+}
+
+func TestCardParentIsValid(t *testing.T) {
+	parent := &CardParent{CardID: "card-1", ParentCardID: "card-2", CreatedBy: "user-1"}
+	require.NoError(t, parent.IsValid())
+
+	parent.ParentCardID = "card-1"
+	assert.True(t, IsErrBadRequest(parent.IsValid()))
+
+	parent.ParentCardID = ""
+	assert.True(t, IsErrBadRequest(parent.IsValid()))
+}
+
+func TestCardChildrenSummarySetCompletion(t *testing.T) {
+	summary := &CardChildrenSummary{ChildCount: 3, DoneCount: 1}
+	summary.SetCompletion()
+	assert.Equal(t, 33.33, summary.Completion)
+
+	summary = &CardChildrenSummary{}
+	summary.SetCompletion()
+	assert.Equal(t, 0.0, summary.Completion)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// CardMoveRequest is a request to move a card to another board.
+// swagger:model
+type CardMoveRequest struct {
+	// The ID of the board to move the card to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// Only report how the card would be moved
+	// required: false
+	DryRun bool `json:"dryRun"`
+}
+
+// CardMovePropertyMapping is a property of a moved card and the property of
+// the target board that holds its value.
+// swagger:model
+type CardMovePropertyMapping struct {
+	// The ID of the property on the source board
+	// required: true
+	SourcePropertyID string `json:"sourcePropertyId"`
+
+	// The ID of the property on the target board
+	// required: true
+	TargetPropertyID string `json:"targetPropertyId"`
+
+	// The name of the property
+	// required: true
+	Name string `json:"name"`
+}
+
+// CardMoveUnmappedField is a value of a moved card that has no place on the
+// target board and is dropped.
+// swagger:model
+type CardMoveUnmappedField struct {
+	// The ID of the property on the source board
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The name of the property on the source board, if it still exists
+	// required: false
+	Name string `json:"name,omitempty"`
+
+	// The dropped value
+	// required: true
+	Value interface{} `json:"value"`
+
+	// Why the value cannot be moved
+	// required: true
+	Reason string `json:"reason"`
+}
+
+// CardMoveResult describes the move of a card to another board.
+// swagger:model
+type CardMoveResult struct {
+	// Whether the card was left in place
+	// required: true
+	DryRun bool `json:"dryRun"`
+
+	// The card on the target board, or as it would be
+	// required: true
+	Card *Card `json:"card"`
+
+	// The code of the card before the move, which still finds the card
+	// required: false
+	PreviousCode string `json:"previousCode,omitempty"`
+
+	// The properties whose values are moved
+	// required: true
+	Mapped []CardMovePropertyMapping `json:"mapped"`
+
+	// The values that are dropped
+	// required: true
+	Unmapped []CardMoveUnmappedField `json:"unmapped"`
+}
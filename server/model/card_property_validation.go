@@ -0,0 +1,280 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// BoardPropertyStrictPropertyValidation is the board property that, when
+// true, rejects card property values that do not match the board's property
+// schema when cards are created or patched.
+const BoardPropertyStrictPropertyValidation = "strictPropertyValidation"
+
+// CardPropertyIssue is a card property value that does not match the
+// property schema of the card's board.
+// swagger:model
+type CardPropertyIssue struct {
+	// The ID of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The ID of the property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The name of the property, empty if the board has no such property
+	// required: false
+	PropertyName string `json:"propertyName,omitempty"`
+
+	// The invalid value
+	// required: true
+	Value any `json:"value"`
+
+	// Why the value is invalid
+	// required: true
+	Reason string `json:"reason"`
+
+	// The value kept by the repair, nil if the value is removed
+	// required: false
+	RepairedValue any `json:"repairedValue,omitempty"`
+}
+
+// CardPropertyRepairResult is the report of a check or repair of the card
+// property values of a board.
+// swagger:model
+type CardPropertyRepairResult struct {
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// True if the invalid values were only reported
+	// required: true
+	DryRun bool `json:"dryRun"`
+
+	// The number of cards checked
+	// required: true
+	CardsChecked int `json:"cardsChecked"`
+
+	// The number of cards with invalid values
+	// required: true
+	CardsWithIssues int `json:"cardsWithIssues"`
+
+	// The number of cards whose invalid values were removed
+	// required: true
+	CardsRepaired int `json:"cardsRepaired"`
+
+	// The invalid values
+	// required: true
+	Issues []*CardPropertyIssue `json:"issues"`
+}
+
+// ValidateCardProperties returns a bad request error naming the first
+// property whose value does not match the schema. Nil values, which unset a
+// property, are always valid.
+func ValidateCardProperties(properties map[string]any, schema PropSchema) error {
+	for _, propID := range sortedPropertyIDs(properties, schema) {
+		value := properties[propID]
+		if value == nil {
+			continue
+		}
+		def, ok := schema[propID]
+		if !ok {
+			return NewErrBadRequest(fmt.Sprintf("unknown card property %q", propID))
+		}
+		if reason, _ := checkPropertyValue(def, value); reason != "" {
+			return NewErrBadRequest(fmt.Sprintf("invalid value for property %q: %s", def.Name, reason))
+		}
+	}
+	return nil
+}
+
+// RepairCardProperties returns the issues of the property values of a card,
+// and its properties without the invalid values. Invalid elements of multi
+// value properties are dropped, other invalid values are removed.
+func RepairCardProperties(card *Card, schema PropSchema) (map[string]any, []*CardPropertyIssue) {
+	repaired := make(map[string]any, len(card.Properties))
+	var issues []*CardPropertyIssue
+
+	for _, propID := range sortedPropertyIDs(card.Properties, schema) {
+		value := card.Properties[propID]
+		issue := &CardPropertyIssue{CardID: card.ID, PropertyID: propID, Value: value}
+
+		def, ok := schema[propID]
+		if !ok {
+			issue.Reason = "the board has no such property"
+			issues = append(issues, issue)
+			continue
+		}
+		if value == nil {
+			repaired[propID] = value
+			continue
+		}
+
+		reason, kept := checkPropertyValue(def, value)
+		if reason == "" {
+			repaired[propID] = value
+			continue
+		}
+		issue.PropertyName = def.Name
+		issue.Reason = reason
+		if kept != nil {
+			issue.RepairedValue = kept
+			repaired[propID] = kept
+		}
+		issues = append(issues, issue)
+	}
+	return repaired, issues
+}
+
+// sortedPropertyIDs returns the IDs of property values in the order of the
+// schema, followed by the unknown ones.
+func sortedPropertyIDs(properties map[string]any, schema PropSchema) []string {
+	propIDs := make([]string, 0, len(properties))
+	for propID := range properties {
+		propIDs = append(propIDs, propID)
+	}
+	sort.Slice(propIDs, func(i, j int) bool {
+		a, aOK := schema[propIDs[i]]
+		b, bOK := schema[propIDs[j]]
+		switch {
+		case aOK && bOK && a.Index != b.Index:
+			return a.Index < b.Index
+		case aOK != bOK:
+			return aOK
+		}
+		return propIDs[i] < propIDs[j]
+	})
+	return propIDs
+}
+
+// checkPropertyValue returns why a value does not match a property, or an
+// empty string if it does. For multi value properties, it also returns the
+// valid elements of an invalid value.
+func checkPropertyValue(def PropDef, value any) (string, any) {
+	switch def.Type {
+	case PropTypeText, PropTypeURL, PropTypeEmail, PropTypePhone, PropTypePerson:
+		if _, ok := value.(string); !ok {
+			return fmt.Sprintf("expected text, got %T", value), nil
+		}
+
+	case PropTypeNumber:
+		switch v := value.(type) {
+		case string:
+			if _, ok := ParseNumberValue(v); !ok && v != "" {
+				return fmt.Sprintf("%q is not a number", v), nil
+			}
+		case float64, int, int64:
+		default:
+			return fmt.Sprintf("expected a number, got %T", value), nil
+		}
+
+	case PropTypeCheckbox:
+		switch v := value.(type) {
+		case bool:
+		case string:
+			if v != "" && v != "true" && v != "false" {
+				return fmt.Sprintf("%q is not true or false", v), nil
+			}
+		default:
+			return fmt.Sprintf("expected true or false, got %T", value), nil
+		}
+
+	case PropTypeSelect:
+		optionID, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("expected an option id, got %T", value), nil
+		}
+		if _, ok := def.Options[optionID]; !ok && optionID != "" {
+			return fmt.Sprintf("option %q does not exist", optionID), nil
+		}
+
+	case PropTypeMultiSelect:
+		return checkPropertyList(value, "option id", func(optionID string) string {
+			if _, ok := def.Options[optionID]; !ok {
+				return fmt.Sprintf("option %q does not exist", optionID)
+			}
+			return ""
+		})
+
+	case PropTypeMultiPerson:
+		return checkPropertyList(value, "user id", func(userID string) string {
+			if userID == "" {
+				return "empty user id"
+			}
+			return ""
+		})
+
+	case PropTypeDate:
+		date, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("expected a date, got %T", value), nil
+		}
+		if date == "" {
+			return "", nil
+		}
+		if _, err := strconv.ParseInt(date, 10, 64); err == nil {
+			return "", nil
+		}
+		var dr struct {
+			From *int64 `json:"from"`
+			To   *int64 `json:"to"`
+		}
+		if err := json.Unmarshal([]byte(date), &dr); err != nil || dr.From == nil {
+			return fmt.Sprintf(`%q is not a date of the form {"from": <milliseconds>}`, date), nil
+		}
+		if dr.To != nil && *dr.To < *dr.From {
+			return fmt.Sprintf("%q ends before it starts", date), nil
+		}
+
+	case PropTypeCreatedTime, PropTypeCreatedBy, PropTypeUpdatedTime, PropTypeUpdatedBy:
+		return "the property is computed from the card and cannot be set", nil
+	}
+
+	// formula values are recomputed whenever the card is stored
+	return "", nil
+}
+
+// checkPropertyList checks the elements of a multi value property, and
+// returns the valid ones if some are not.
+func checkPropertyList(value any, kind string, check func(string) string) (string, any) {
+	var items []string
+	switch v := value.(type) {
+	case []string:
+		items = v
+	case []any:
+		items = make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return fmt.Sprintf("expected a list of %ss, got a %T in it", kind, item), nil
+			}
+			items = append(items, s)
+		}
+	default:
+		return fmt.Sprintf("expected a list of %ss, got %T", kind, value), nil
+	}
+
+	reason := ""
+	valid := make([]any, 0, len(items))
+	for _, item := range items {
+		if itemReason := check(item); itemReason != "" {
+			if reason == "" {
+				reason = itemReason
+			}
+			continue
+		}
+		valid = append(valid, item)
+	}
+	if reason == "" {
+		return "", nil
+	}
+	if len(valid) == 0 {
+		return reason, nil
+	}
+	return reason, valid
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validationTestSchema(t *testing.T) PropSchema {
+	schema, err := ParsePropertySchema(&Board{
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To Do"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+			{"id": "labels", "name": "Labels", "type": "multiSelect", "options": []interface{}{
+				map[string]interface{}{"id": "bug", "value": "Bug"},
+				map[string]interface{}{"id": "ui", "value": "UI"},
+			}},
+			{"id": "estimate", "name": "Estimate", "type": "number"},
+			{"id": "due", "name": "Due", "type": "date"},
+			{"id": "done", "name": "Done", "type": "checkbox"},
+			{"id": "notes", "name": "Notes", "type": "text"},
+			{"id": "created", "name": "Created", "type": "createdTime"},
+		},
+	})
+	require.NoError(t, err)
+	return schema
+}
+
+func TestValidateCardProperties(t *testing.T) {
+	schema := validationTestSchema(t)
+
+	valid := map[string]any{
+		"status":   "todo",
+		"labels":   []any{"bug", "ui"},
+		"estimate": "2.5",
+		"due":      `{"from":1700000000000,"to":1700086400000}`,
+		"done":     "true",
+		"notes":    "",
+	}
+	require.NoError(t, ValidateCardProperties(valid, schema))
+	require.NoError(t, ValidateCardProperties(map[string]any{"status": nil, "estimate": ""}, schema))
+
+	testCases := []struct {
+		name       string
+		properties map[string]any
+		message    string
+	}{
+		{"unknown property", map[string]any{"nope": "x"}, `unknown card property "nope"`},
+		{"unknown option", map[string]any{"status": "later"}, `invalid value for property "Status": option "later" does not exist`},
+		{"unknown multi-select option", map[string]any{"labels": []any{"bug", "feature"}}, `option "feature" does not exist`},
+		{"not a number", map[string]any{"estimate": "two"}, `invalid value for property "Estimate": "two" is not a number`},
+		{"malformed date", map[string]any{"due": `{"start":1}`}, `invalid value for property "Due"`},
+		{"date range ending first", map[string]any{"due": `{"from":2,"to":1}`}, "ends before it starts"},
+		{"not a checkbox", map[string]any{"done": "yes"}, `"yes" is not true or false`},
+		{"wrong type", map[string]any{"notes": 12.0}, "expected text"},
+		{"computed property", map[string]any{"created": "1"}, "cannot be set"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCardProperties(tc.properties, schema)
+			require.True(t, IsErrBadRequest(err), "expected a bad request, got %v", err)
+			assert.Contains(t, err.Error(), tc.message)
+		})
+	}
+}
+
+func TestRepairCardProperties(t *testing.T) {
+	schema := validationTestSchema(t)
+	card := &Card{
+		ID: "card-1",
+		Properties: map[string]any{
+			"status":   "later",
+			"labels":   []any{"bug", "feature"},
+			"estimate": "3",
+			"notes":    "kept",
+			"orphan":   "value",
+		},
+	}
+
+	repaired, issues := RepairCardProperties(card, schema)
+	assert.Equal(t, map[string]any{
+		"labels":   []any{"bug"},
+		"estimate": "3",
+		"notes":    "kept",
+	}, repaired)
+
+	require.Len(t, issues, 3)
+	assert.Equal(t, "status", issues[0].PropertyID)
+	assert.Equal(t, "Status", issues[0].PropertyName)
+	assert.Nil(t, issues[0].RepairedValue)
+	assert.Equal(t, "labels", issues[1].PropertyID)
+	assert.Equal(t, []any{"bug"}, issues[1].RepairedValue)
+	assert.Equal(t, "orphan", issues[2].PropertyID)
+	assert.Empty(t, issues[2].PropertyName)
+
+	_, issues = RepairCardProperties(&Card{ID: "card-2", Properties: map[string]any{"status": "done"}}, schema)
+	assert.Empty(t, issues)
+}
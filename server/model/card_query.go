@@ -0,0 +1,432 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var ErrInvalidCardQuery = errors.New("invalid card query")
+
+// Fields of the card query language that do not refer to a card property.
+const (
+	CardQueryFieldBoard   = "board"
+	CardQueryFieldCreated = "created"
+	CardQueryFieldUpdated = "updated"
+)
+
+// CardQueryUserMe is the person value that refers to the user running the query.
+const CardQueryUserMe = "@me"
+
+const cardQueryDateLayout = "2006-01-02"
+
+type CardQueryOperator string
+
+const (
+	CardQueryOperatorIs             CardQueryOperator = ":"
+	CardQueryOperatorGreater        CardQueryOperator = ">"
+	CardQueryOperatorGreaterOrEqual CardQueryOperator = ">="
+	CardQueryOperatorLess           CardQueryOperator = "<"
+	CardQueryOperatorLessOrEqual    CardQueryOperator = "<="
+)
+
+// CardQueryTerm is a single `field:value` term of a card query.
+type CardQueryTerm struct {
+	Field    string
+	Operator CardQueryOperator
+	Value    string
+}
+
+// CardQuery is a parsed card search query such as
+// `status:"In Progress" assignee:@me board:AB updated:>2026-01-01 "free text"`.
+//
+// Terms are combined with AND. Fields other than board, created and updated
+// refer to card properties by name, case insensitively.
+type CardQuery struct {
+	// Words and quoted phrases that must appear in the card title
+	Text []string
+
+	// The field terms of the query
+	Terms []CardQueryTerm
+}
+
+// IsEmpty returns true if the query has no text and no terms.
+func (q *CardQuery) IsEmpty() bool {
+	return len(q.Text) == 0 && len(q.Terms) == 0
+}
+
+// ParseCardQuery parses a card search query.
+func ParseCardQuery(query string) (*CardQuery, error) {
+	p := &cardQueryParser{input: []rune(query)}
+	result := &CardQuery{Text: []string{}, Terms: []CardQueryTerm{}}
+
+	for {
+		p.skipSpaces()
+		if p.done() {
+			break
+		}
+
+		first, quoted, err := p.readSegment(true)
+		if err != nil {
+			return nil, err
+		}
+
+		if p.done() || p.peek() != ':' {
+			if first != "" {
+				result.Text = append(result.Text, first)
+			}
+			continue
+		}
+		p.pos++
+
+		field := strings.TrimSpace(first)
+		if field == "" {
+			return nil, fmt.Errorf("%w: missing field name", ErrInvalidCardQuery)
+		}
+		if !quoted {
+			field = strings.ToLower(field)
+		}
+
+		operator := p.readOperator()
+		value, _, err := p.readSegment(false)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			return nil, fmt.Errorf("%w: missing value for %q", ErrInvalidCardQuery, field)
+		}
+
+		term := CardQueryTerm{Field: field, Operator: operator, Value: value}
+		if err := term.validate(); err != nil {
+			return nil, err
+		}
+		result.Terms = append(result.Terms, term)
+	}
+
+	return result, nil
+}
+
+func (t CardQueryTerm) validate() error {
+	if t.isTimeField() {
+		if _, _, err := parseCardQueryTime(t.Value); err != nil {
+			return err
+		}
+		return nil
+	}
+	if t.Operator != CardQueryOperatorIs {
+		return fmt.Errorf("%w: operator %q is only supported for %s and %s", ErrInvalidCardQuery, t.Operator, CardQueryFieldCreated, CardQueryFieldUpdated)
+	}
+	return nil
+}
+
+func (t CardQueryTerm) isTimeField() bool {
+	return t.Field == CardQueryFieldCreated || t.Field == CardQueryFieldUpdated
+}
+
+type cardQueryParser struct {
+	input []rune
+	pos   int
+}
+
+func (p *cardQueryParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *cardQueryParser) peek() rune {
+	return p.input[p.pos]
+}
+
+func (p *cardQueryParser) skipSpaces() {
+	for !p.done() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+// readSegment reads either a double quoted string or a bare word. Bare field
+// names stop at the first colon, bare values only at whitespace.
+func (p *cardQueryParser) readSegment(stopAtColon bool) (string, bool, error) {
+	if p.done() {
+		return "", false, nil
+	}
+
+	if p.peek() == '"' {
+		p.pos++
+		start := p.pos
+		for !p.done() && p.peek() != '"' {
+			p.pos++
+		}
+		if p.done() {
+			return "", true, fmt.Errorf("%w: unterminated quote", ErrInvalidCardQuery)
+		}
+		segment := string(p.input[start:p.pos])
+		p.pos++
+		return segment, true, nil
+	}
+
+	start := p.pos
+	for !p.done() && !unicode.IsSpace(p.peek()) && !(stopAtColon && p.peek() == ':') {
+		p.pos++
+	}
+	return string(p.input[start:p.pos]), false, nil
+}
+
+func (p *cardQueryParser) readOperator() CardQueryOperator {
+	for _, op := range []CardQueryOperator{CardQueryOperatorGreaterOrEqual, CardQueryOperatorLessOrEqual, CardQueryOperatorGreater, CardQueryOperatorLess} {
+		n := len(op)
+		if p.pos+n <= len(p.input) && string(p.input[p.pos:p.pos+n]) == string(op) {
+			p.pos += n
+			return op
+		}
+	}
+	return CardQueryOperatorIs
+}
+
+// parseCardQueryTime parses a YYYY-MM-DD date or an RFC3339 timestamp and
+// returns the [start, end) range in milliseconds that it covers. Dates are
+// interpreted in UTC.
+func parseCardQueryTime(value string) (int64, int64, error) {
+	if day, err := time.Parse(cardQueryDateLayout, value); err == nil {
+		return day.UnixMilli(), day.AddDate(0, 0, 1).UnixMilli(), nil
+	}
+	if instant, err := time.Parse(time.RFC3339, value); err == nil {
+		return instant.UnixMilli(), instant.UnixMilli() + 1, nil
+	}
+	return 0, 0, fmt.Errorf("%w: invalid date %q, expected YYYY-MM-DD", ErrInvalidCardQuery, value)
+}
+
+type CardSearchMatch string
+
+const (
+	// CardSearchMatchEquals matches property values equal to one of the values.
+	CardSearchMatchEquals CardSearchMatch = "equals"
+	// CardSearchMatchNotEquals matches property values different from all of the values, including unset ones.
+	CardSearchMatchNotEquals CardSearchMatch = "notEquals"
+	// CardSearchMatchContains matches property values containing one of the values, case insensitively.
+	CardSearchMatchContains CardSearchMatch = "contains"
+	// CardSearchMatchArrayContains matches array property values holding one of the values.
+	CardSearchMatchArrayContains CardSearchMatch = "arrayContains"
+)
+
+// CardSearchProperty is a card query term resolved against the schema of a board.
+type CardSearchProperty struct {
+	PropertyID string
+	Match      CardSearchMatch
+	Values     []string
+}
+
+// CardSearchBoard holds the property conditions a card of a board must meet.
+type CardSearchBoard struct {
+	BoardID    string
+	Properties []CardSearchProperty
+}
+
+// CardSearchTimeRange is a [From, To) range in milliseconds, a zero bound is unbounded.
+type CardSearchTimeRange struct {
+	From int64
+	To   int64
+}
+
+// IsEmpty returns true if the range can never match.
+func (r CardSearchTimeRange) IsEmpty() bool {
+	return r.To != 0 && r.From >= r.To
+}
+
+func (r *CardSearchTimeRange) restrict(op CardQueryOperator, start, end int64) {
+	from, to := int64(0), int64(math.MaxInt64)
+	switch op {
+	case CardQueryOperatorGreater:
+		from = end
+	case CardQueryOperatorGreaterOrEqual:
+		from = start
+	case CardQueryOperatorLess:
+		to = start
+	case CardQueryOperatorLessOrEqual:
+		to = end
+	default:
+		from, to = start, end
+	}
+
+	if from > r.From {
+		r.From = from
+	}
+	if to != math.MaxInt64 && (r.To == 0 || to < r.To) {
+		r.To = to
+	}
+}
+
+// CardSearch is a card query resolved against the boards a user can see,
+// ready to be run by the store.
+type CardSearch struct {
+	// The boards to search and the per board property conditions
+	Boards []CardSearchBoard
+
+	// Words and phrases that must appear in the card title
+	Text []string
+
+	// The range the card creation time must be in
+	CreatedAt CardSearchTimeRange
+
+	// The range the card update time must be in
+	UpdatedAt CardSearchTimeRange
+}
+
+// CardQueryUserResolver returns the ID of the user referenced by a person
+// value of a query, or an empty string if there is no such user.
+type CardQueryUserResolver func(value string) (string, error)
+
+// Resolve maps the query's property names and option values to the IDs used
+// by each of the boards. Boards that cannot contain a matching card are left
+// out of the result.
+func (q *CardQuery) Resolve(boards []*Board, resolveUser CardQueryUserResolver) (*CardSearch, error) {
+	search := &CardSearch{
+		Boards: []CardSearchBoard{},
+		Text:   q.Text,
+	}
+
+	for _, term := range q.Terms {
+		if !term.isTimeField() {
+			continue
+		}
+		start, end, err := parseCardQueryTime(term.Value)
+		if err != nil {
+			return nil, err
+		}
+		if term.Field == CardQueryFieldCreated {
+			search.CreatedAt.restrict(term.Operator, start, end)
+		} else {
+			search.UpdatedAt.restrict(term.Operator, start, end)
+		}
+	}
+	if search.CreatedAt.IsEmpty() || search.UpdatedAt.IsEmpty() {
+		return search, nil
+	}
+
+	users := map[string]string{}
+	userID := func(value string) (string, error) {
+		if id, ok := users[value]; ok {
+			return id, nil
+		}
+		id, err := resolveUser(value)
+		if err != nil {
+			return "", err
+		}
+		users[value] = id
+		return id, nil
+	}
+
+	for _, board := range boards {
+		searchBoard, ok, err := q.resolveForBoard(board, userID)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			search.Boards = append(search.Boards, searchBoard)
+		}
+	}
+
+	return search, nil
+}
+
+func (q *CardQuery) resolveForBoard(board *Board, resolveUser CardQueryUserResolver) (CardSearchBoard, bool, error) {
+	searchBoard := CardSearchBoard{BoardID: board.ID, Properties: []CardSearchProperty{}}
+
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return searchBoard, false, fmt.Errorf("cannot parse property schema for board %s: %w", board.ID, err)
+	}
+
+	for _, term := range q.Terms {
+		if term.isTimeField() {
+			continue
+		}
+
+		if term.Field == CardQueryFieldBoard {
+			if !strings.EqualFold(board.Code, term.Value) && !strings.EqualFold(board.Title, term.Value) {
+				return searchBoard, false, nil
+			}
+			continue
+		}
+
+		prop, ok := findPropDefByName(schema, term.Field)
+		if !ok {
+			return searchBoard, false, nil
+		}
+
+		property, ok, err := resolveCardQueryProperty(prop, term.Value, resolveUser)
+		if err != nil || !ok {
+			return searchBoard, false, err
+		}
+		searchBoard.Properties = append(searchBoard.Properties, property)
+	}
+
+	return searchBoard, true, nil
+}
+
+func findPropDefByName(schema PropSchema, name string) (PropDef, bool) {
+	var found PropDef
+	ok := false
+	for _, prop := range schema {
+		// the lowest index wins if several properties share the same name
+		if strings.EqualFold(prop.Name, name) && (!ok || prop.Index < found.Index) {
+			found = prop
+			ok = true
+		}
+	}
+	return found, ok
+}
+
+func resolveCardQueryProperty(prop PropDef, value string, resolveUser CardQueryUserResolver) (CardSearchProperty, bool, error) {
+	property := CardSearchProperty{PropertyID: prop.ID}
+
+	switch prop.Type {
+	case PropTypeSelect, PropTypeMultiSelect:
+		for _, option := range prop.Options {
+			if strings.EqualFold(option.Value, value) {
+				property.Values = append(property.Values, option.ID)
+			}
+		}
+		property.Match = CardSearchMatchEquals
+		if prop.Type == PropTypeMultiSelect {
+			property.Match = CardSearchMatchArrayContains
+		}
+
+	case PropTypePerson, PropTypeMultiPerson:
+		id, err := resolveUser(value)
+		if err != nil {
+			return property, false, err
+		}
+		if id != "" {
+			property.Values = []string{id}
+		}
+		property.Match = CardSearchMatchEquals
+		if prop.Type == PropTypeMultiPerson {
+			property.Match = CardSearchMatchArrayContains
+		}
+
+	case PropTypeCheckbox:
+		property.Values = []string{"true"}
+		switch strings.ToLower(value) {
+		case "true", "yes", "checked":
+			property.Match = CardSearchMatchEquals
+		case "false", "no", "unchecked":
+			property.Match = CardSearchMatchNotEquals
+		default:
+			return property, false, fmt.Errorf("%w: invalid value %q for checkbox %q", ErrInvalidCardQuery, value, prop.Name)
+		}
+
+	case PropTypeText, PropTypeNumber, PropTypeURL, PropTypeEmail, PropTypePhone, PropTypeFormula:
+		property.Match = CardSearchMatchContains
+		property.Values = []string{value}
+
+	default:
+		return property, false, fmt.Errorf("%w: property %q of type %s cannot be searched", ErrInvalidCardQuery, prop.Name, prop.Type)
+	}
+
+	return property, len(property.Values) > 0, nil
+}
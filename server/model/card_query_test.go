@@ -0,0 +1,148 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCardQuery(t *testing.T) {
+	t.Run("parses terms and free text", func(t *testing.T) {
+		query, err := ParseCardQuery(`status:"In Progress" assignee:@me board:AB updated:>2026-01-01 "free text" word`)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"free text", "word"}, query.Text)
+		assert.Equal(t, []CardQueryTerm{
+			{Field: "status", Operator: CardQueryOperatorIs, Value: "In Progress"},
+			{Field: "assignee", Operator: CardQueryOperatorIs, Value: "@me"},
+			{Field: CardQueryFieldBoard, Operator: CardQueryOperatorIs, Value: "AB"},
+			{Field: CardQueryFieldUpdated, Operator: CardQueryOperatorGreater, Value: "2026-01-01"},
+		}, query.Terms)
+	})
+
+	t.Run("quoted field names keep their case", func(t *testing.T) {
+		query, err := ParseCardQuery(`"Due Soon":yes Priority:high`)
+		require.NoError(t, err)
+		require.Len(t, query.Terms, 2)
+		assert.Equal(t, "Due Soon", query.Terms[0].Field)
+		assert.Equal(t, "priority", query.Terms[1].Field)
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		query, err := ParseCardQuery("   ")
+		require.NoError(t, err)
+		assert.True(t, query.IsEmpty())
+	})
+
+	testCases := []struct {
+		name  string
+		query string
+	}{
+		{name: "unterminated quote", query: `status:"In Progress`},
+		{name: "missing value", query: `status: done`},
+		{name: "missing field", query: `:done`},
+		{name: "invalid date", query: `created:yesterday`},
+		{name: "comparison on property", query: `estimate:>5`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseCardQuery(tc.query)
+			require.ErrorIs(t, err, ErrInvalidCardQuery)
+		})
+	}
+}
+
+func TestResolveCardQuery(t *testing.T) {
+	makeBoard := func(id, code string) *Board {
+		return &Board{
+			ID:    id,
+			Code:  code,
+			Title: "Board " + code,
+			CardProperties: []map[string]interface{}{
+				{
+					"id":   id + "-status",
+					"name": "Status",
+					"type": "select",
+					"options": []interface{}{
+						map[string]interface{}{"id": id + "-progress", "value": "In Progress"},
+						map[string]interface{}{"id": id + "-done", "value": "Done"},
+					},
+				},
+				{"id": id + "-assignee", "name": "Assignee", "type": "person"},
+				{"id": id + "-due", "name": "Due", "type": "date"},
+			},
+		}
+	}
+	boards := []*Board{makeBoard("b1", "AB"), makeBoard("b2", "CD")}
+	noUsers := func(string) (string, error) { return "", nil }
+
+	t.Run("maps names to the ids of each board", func(t *testing.T) {
+		query, err := ParseCardQuery(`status:"in progress" assignee:@me`)
+		require.NoError(t, err)
+
+		search, err := query.Resolve(boards, func(value string) (string, error) {
+			assert.Equal(t, CardQueryUserMe, value)
+			return "user1", nil
+		})
+		require.NoError(t, err)
+		require.Len(t, search.Boards, 2)
+		assert.Equal(t, []CardSearchProperty{
+			{PropertyID: "b2-status", Match: CardSearchMatchEquals, Values: []string{"b2-progress"}},
+			{PropertyID: "b2-assignee", Match: CardSearchMatchEquals, Values: []string{"user1"}},
+		}, search.Boards[1].Properties)
+	})
+
+	t.Run("board code restricts the boards", func(t *testing.T) {
+		query, err := ParseCardQuery(`board:cd fix`)
+		require.NoError(t, err)
+
+		search, err := query.Resolve(boards, noUsers)
+		require.NoError(t, err)
+		require.Len(t, search.Boards, 1)
+		assert.Equal(t, "b2", search.Boards[0].BoardID)
+		assert.Equal(t, []string{"fix"}, search.Text)
+	})
+
+	t.Run("unknown option or property excludes the board", func(t *testing.T) {
+		for _, q := range []string{`status:blocked`, `owner:me`, `assignee:@nobody`} {
+			query, err := ParseCardQuery(q)
+			require.NoError(t, err)
+
+			search, err := query.Resolve(boards, noUsers)
+			require.NoError(t, err)
+			assert.Empty(t, search.Boards, q)
+		}
+	})
+
+	t.Run("date property cannot be searched", func(t *testing.T) {
+		query, err := ParseCardQuery(`due:soon`)
+		require.NoError(t, err)
+
+		_, err = query.Resolve(boards, noUsers)
+		require.ErrorIs(t, err, ErrInvalidCardQuery)
+	})
+
+	t.Run("time ranges", func(t *testing.T) {
+		query, err := ParseCardQuery(`updated:>=2026-01-01 updated:<2026-02-01 created:2026-01-15`)
+		require.NoError(t, err)
+
+		search, err := query.Resolve(boards, noUsers)
+		require.NoError(t, err)
+		assert.Equal(t, CardSearchTimeRange{From: 1767225600000, To: 1769904000000}, search.UpdatedAt)
+		assert.Equal(t, CardSearchTimeRange{From: 1768435200000, To: 1768521600000}, search.CreatedAt)
+		assert.Len(t, search.Boards, 2)
+	})
+
+	t.Run("empty time range matches nothing", func(t *testing.T) {
+		query, err := ParseCardQuery(`created:>2026-02-01 created:<2026-01-01`)
+		require.NoError(t, err)
+
+		search, err := query.Resolve(boards, noUsers)
+		require.NoError(t, err)
+		assert.Empty(t, search.Boards)
+	})
+}
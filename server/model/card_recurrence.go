@@ -0,0 +1,252 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+var ErrInvalidCardRecurrence = errors.New("invalid card recurrence")
+
+// RecurrenceFrequency is how often a recurring card occurs, as the FREQ part
+// of an RRULE.
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "daily"
+	RecurrenceWeekly  RecurrenceFrequency = "weekly"
+	RecurrenceMonthly RecurrenceFrequency = "monthly"
+)
+
+const (
+	maxRecurrenceInterval = 99
+
+	// maxRecurrenceSearchDays bounds the search of the next occurrence, which
+	// is always found within an interval of the longest frequency.
+	maxRecurrenceSearchDays = 31*maxRecurrenceInterval + 31
+)
+
+// recurrenceWeekdays maps the RRULE BYDAY codes to weekdays.
+var recurrenceWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// CardRecurrence creates a copy of a card, or of a template card, on each
+// occurrence of an RRULE-style schedule. Occurrences start at midnight in the
+// recurrence's timezone.
+// swagger:model
+type CardRecurrence struct {
+	// The id of the card copied on each occurrence
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The id of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// How often the card occurs: daily, weekly or monthly
+	// required: true
+	Frequency RecurrenceFrequency `json:"frequency"`
+
+	// The card occurs every Interval days, weeks or months. Defaults to 1
+	// required: false
+	Interval int `json:"interval"`
+
+	// The days of the week of a weekly recurrence, as RRULE codes (MO, TU, WE, TH, FR, SA, SU)
+	// required: false
+	Weekdays []string `json:"weekdays,omitempty"`
+
+	// The day of the month of a monthly recurrence. Months without the day
+	// occur on their last day
+	// required: false
+	MonthDay int `json:"monthDay,omitempty"`
+
+	// The date property set to the day of each occurrence
+	// required: false
+	DatePropertyID string `json:"datePropertyId,omitempty"`
+
+	// The timezone of the occurrences. Defaults to the timezone of the user
+	// setting the recurrence
+	// required: false
+	TimeZone string `json:"timeZone"`
+
+	// The time from which the card occurs, in milliseconds since the current epoch
+	// required: false
+	StartAt int64 `json:"startAt"`
+
+	// The time of the next occurrence in milliseconds since the current epoch,
+	// zero if the card does not occur again
+	// required: false
+	NextAt int64 `json:"nextAt"`
+
+	// The id of the user who set the recurrence. Cards are created on behalf of this user
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The id of the user who last modified the recurrence
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// IsValid checks the rule of the recurrence.
+func (r *CardRecurrence) IsValid() error {
+	if r.CardID == "" || r.BoardID == "" {
+		return fmt.Errorf("%w: card is required", ErrInvalidCardRecurrence)
+	}
+	if r.Interval < 1 || r.Interval > maxRecurrenceInterval {
+		return fmt.Errorf("%w: interval must be between 1 and %d", ErrInvalidCardRecurrence, maxRecurrenceInterval)
+	}
+	if _, err := time.LoadLocation(r.TimeZone); err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", ErrInvalidCardRecurrence, r.TimeZone)
+	}
+
+	switch r.Frequency {
+	case RecurrenceDaily:
+	case RecurrenceWeekly:
+		if len(r.Weekdays) == 0 {
+			return fmt.Errorf("%w: weekly recurrence needs weekdays", ErrInvalidCardRecurrence)
+		}
+		for _, day := range r.Weekdays {
+			if _, ok := recurrenceWeekdays[day]; !ok {
+				return fmt.Errorf("%w: unknown weekday %q", ErrInvalidCardRecurrence, day)
+			}
+		}
+	case RecurrenceMonthly:
+		if r.MonthDay < 1 || r.MonthDay > 31 {
+			return fmt.Errorf("%w: month day must be between 1 and 31", ErrInvalidCardRecurrence)
+		}
+	default:
+		return fmt.Errorf("%w: unknown frequency %q", ErrInvalidCardRecurrence, r.Frequency)
+	}
+	return nil
+}
+
+// IsValidForSchema checks that the date property of the recurrence is a date
+// of the board.
+func (r *CardRecurrence) IsValidForSchema(schema PropSchema) error {
+	if r.DatePropertyID == "" {
+		return nil
+	}
+	prop, ok := schema[r.DatePropertyID]
+	if !ok || prop.Type != PropTypeDate {
+		return fmt.Errorf("%w: property %s is not a date", ErrInvalidCardRecurrence, r.DatePropertyID)
+	}
+	return nil
+}
+
+// Location returns the location of the recurrence's timezone.
+func (r *CardRecurrence) Location() *time.Location {
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// NextOccurrence returns the first occurrence strictly after a time. It
+// returns false if there is none.
+func (r *CardRecurrence) NextOccurrence(after time.Time) (time.Time, bool) {
+	loc := r.Location()
+	start := startOfDay(utils.GetTimeForMillis(r.StartAt).In(loc))
+
+	day := startOfDay(after.In(loc))
+	if !day.After(after) {
+		day = day.AddDate(0, 0, 1)
+	}
+	if day.Before(start) {
+		day = start
+	}
+
+	for i := 0; i < maxRecurrenceSearchDays; i++ {
+		if r.occursOn(start, day) {
+			return day, true
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}, false
+}
+
+func (r *CardRecurrence) occursOn(start time.Time, day time.Time) bool {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch r.Frequency {
+	case RecurrenceDaily:
+		return daysBetween(start, day)%interval == 0
+
+	case RecurrenceWeekly:
+		if daysBetween(startOfWeek(start), startOfWeek(day))/7%interval != 0 {
+			return false
+		}
+		for _, code := range r.Weekdays {
+			if recurrenceWeekdays[code] == day.Weekday() {
+				return true
+			}
+		}
+		return false
+
+	case RecurrenceMonthly:
+		months := (day.Year()-start.Year())*12 + int(day.Month()) - int(start.Month())
+		if months%interval != 0 {
+			return false
+		}
+		monthDay := r.MonthDay
+		if last := daysInMonth(day); monthDay > last {
+			monthDay = last
+		}
+		return day.Day() == monthDay
+	}
+	return false
+}
+
+// OccurrenceDateValue returns the value of a date property without time set
+// to the day of an occurrence, which the webapp stores as the UTC midnight of
+// the day.
+func OccurrenceDateValue(occurrence time.Time) string {
+	day := time.Date(occurrence.Year(), occurrence.Month(), occurrence.Day(), 0, 0, 0, 0, time.UTC)
+	return fmt.Sprintf(`{"from":%d}`, day.UnixMilli())
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns the monday of the week of a day, as the RRULE default
+// week start.
+func startOfWeek(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// daysBetween returns the number of calendar days between two days, which
+// is not a multiple of 24 hours across daylight saving changes.
+func daysBetween(from time.Time, to time.Time) int {
+	fromUTC := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toUTC := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toUTC.Sub(fromUTC).Hours() / 24)
+}
+
+func daysInMonth(day time.Time) int {
+	return time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardRecurrenceIsValid(t *testing.T) {
+	newRecurrence := func() *CardRecurrence {
+		return &CardRecurrence{
+			CardID:    "card-id",
+			BoardID:   "board-id",
+			Frequency: RecurrenceWeekly,
+			Interval:  1,
+			Weekdays:  []string{"MO", "FR"},
+			TimeZone:  "Europe/Paris",
+		}
+	}
+
+	require.NoError(t, newRecurrence().IsValid())
+
+	testCases := []struct {
+		name   string
+		modify func(r *CardRecurrence)
+	}{
+		{"no card", func(r *CardRecurrence) { r.CardID = "" }},
+		{"unknown frequency", func(r *CardRecurrence) { r.Frequency = "yearly" }},
+		{"zero interval", func(r *CardRecurrence) { r.Interval = 0 }},
+		{"weekly without weekdays", func(r *CardRecurrence) { r.Weekdays = nil }},
+		{"unknown weekday", func(r *CardRecurrence) { r.Weekdays = []string{"MONDAY"} }},
+		{"monthly without day", func(r *CardRecurrence) { r.Frequency = RecurrenceMonthly }},
+		{"unknown timezone", func(r *CardRecurrence) { r.TimeZone = "Mars/Olympus" }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newRecurrence()
+			tc.modify(r)
+			err := r.IsValid()
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidCardRecurrence))
+		})
+	}
+}
+
+func TestCardRecurrenceNextOccurrence(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	// Thursday, October 15 2026
+	start := time.Date(2026, time.October, 15, 10, 30, 0, 0, paris)
+
+	occurrences := func(r *CardRecurrence, count int) []string {
+		r.TimeZone = "Europe/Paris"
+		r.StartAt = start.UnixMilli()
+		if r.Interval == 0 {
+			r.Interval = 1
+		}
+
+		var days []string
+		after := start
+		for i := 0; i < count; i++ {
+			next, ok := r.NextOccurrence(after)
+			require.True(t, ok)
+			require.Equal(t, paris, next.Location())
+			require.Zero(t, next.Hour())
+			days = append(days, next.Format("Mon 2006-01-02"))
+			after = next
+		}
+		return days
+	}
+
+	t.Run("every other day", func(t *testing.T) {
+		r := &CardRecurrence{Frequency: RecurrenceDaily, Interval: 2}
+		assert.Equal(t, []string{"Sat 2026-10-17", "Mon 2026-10-19", "Wed 2026-10-21"}, occurrences(r, 3))
+	})
+
+	t.Run("weekly on weekdays", func(t *testing.T) {
+		r := &CardRecurrence{Frequency: RecurrenceWeekly, Weekdays: []string{"MO", "TH"}}
+		assert.Equal(t, []string{"Mon 2026-10-19", "Thu 2026-10-22", "Mon 2026-10-26"}, occurrences(r, 3))
+	})
+
+	t.Run("every other week", func(t *testing.T) {
+		r := &CardRecurrence{Frequency: RecurrenceWeekly, Interval: 2, Weekdays: []string{"FR"}}
+		assert.Equal(t, []string{"Fri 2026-10-16", "Fri 2026-10-30"}, occurrences(r, 2))
+	})
+
+	t.Run("monthly on a day missing from some months", func(t *testing.T) {
+		r := &CardRecurrence{Frequency: RecurrenceMonthly, MonthDay: 31}
+		assert.Equal(t, []string{"Sat 2026-10-31", "Mon 2026-11-30", "Thu 2026-12-31"}, occurrences(r, 3))
+	})
+
+	t.Run("across daylight saving", func(t *testing.T) {
+		r := &CardRecurrence{Frequency: RecurrenceDaily, Interval: 7}
+		assert.Equal(t, []string{"Thu 2026-10-22", "Thu 2026-10-29"}, occurrences(r, 2))
+	})
+}
+
+func TestOccurrenceDateValue(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	occurrence := time.Date(2026, time.October, 18, 0, 0, 0, 0, tokyo)
+	assert.Equal(t, `{"from":1792281600000}`, OccurrenceDateValue(occurrence))
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+const (
+	// CardRelationGraphDefaultDepth is the depth of a relation graph when none
+	// is requested.
+	CardRelationGraphDefaultDepth = 2
+
+	// CardRelationGraphMaxDepth is the largest depth of a relation graph.
+	CardRelationGraphMaxDepth = 10
+)
+
+// IsDirectional returns true if the relation type has a direction, so that a
+// chain of relations of the type must not loop back to its first card.
+func (rt RelationType) IsDirectional() bool {
+	inverse := GetInverseRelationType(rt)
+	return inverse != "" && inverse != rt
+}
+
+// Canonical returns the forward type of a relation type and whether the
+// relation has to be read backwards, e.g. "A is_blocked_by B" is the same
+// relation as "B blocks A".
+func (rt RelationType) Canonical() (RelationType, bool) {
+	switch rt {
+	case RelationTypeIsBlockedBy, RelationTypeIsDuplicatedBy, RelationTypeIsClonedBy, RelationTypeIsCausedBy:
+		return GetInverseRelationType(rt), true
+	}
+	return rt, false
+}
+
+// CardRelationEdge is a relation of a relation graph, in its forward
+// direction.
+// swagger:model
+type CardRelationEdge struct {
+	// The ID of the relation
+	// required: true
+	ID string `json:"id"`
+
+	// The card the relation starts from
+	// required: true
+	FromCardID string `json:"fromCardId"`
+
+	// The card the relation points to
+	// required: true
+	ToCardID string `json:"toCardId"`
+
+	// The forward type of the relation: blocks, duplicates, clones, causes or
+	// relates_to
+	// required: true
+	RelationType RelationType `json:"relationType"`
+}
+
+// NewCardRelationEdge returns the forward edge of a relation.
+func NewCardRelationEdge(relation *CardRelation) *CardRelationEdge {
+	relationType, backwards := relation.RelationType.Canonical()
+	edge := &CardRelationEdge{
+		ID:           relation.ID,
+		FromCardID:   relation.SourceCardID,
+		ToCardID:     relation.TargetCardID,
+		RelationType: relationType,
+	}
+	if backwards {
+		edge.FromCardID, edge.ToCardID = edge.ToCardID, edge.FromCardID
+	}
+	return edge
+}
+
+// CardRelationGraphNode is a card of a relation graph.
+// swagger:model
+type CardRelationGraphNode struct {
+	// The card
+	// required: true
+	Card *Card `json:"card"`
+
+	// The number of relations between the card and the root card
+	// required: true
+	Depth int `json:"depth"`
+}
+
+// CardRelationGraph is the cards related to a card, directly or through other
+// cards, across boards.
+// swagger:model
+type CardRelationGraph struct {
+	// The card the graph starts from
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The depth the graph was walked to
+	// required: true
+	Depth int `json:"depth"`
+
+	// The cards of the graph, including the root card
+	// required: true
+	Nodes []*CardRelationGraphNode `json:"nodes"`
+
+	// The relations between the cards of the graph
+	// required: true
+	Edges []*CardRelationEdge `json:"edges"`
+
+	// The cards the root card is waiting on, directly or transitively,
+	// whatever the depth of the graph
+	// required: true
+	BlockedBy []*Card `json:"blockedBy"`
+}
+
+// FindCardRelationPath returns the cards of a path from one card to another
+// following the edges of a relation type, or nil if there is none.
+func FindCardRelationPath(edges []*CardRelationEdge, relationType RelationType, fromCardID, toCardID string) []string {
+	next := make(map[string][]string)
+	for _, edge := range edges {
+		if edge.RelationType == relationType {
+			next[edge.FromCardID] = append(next[edge.FromCardID], edge.ToCardID)
+		}
+	}
+
+	previous := map[string]string{fromCardID: ""}
+	queue := []string{fromCardID}
+	for len(queue) > 0 {
+		cardID := queue[0]
+		queue = queue[1:]
+		if cardID == toCardID {
+			path := []string{}
+			for ; cardID != ""; cardID = previous[cardID] {
+				path = append([]string{cardID}, path...)
+			}
+			return path
+		}
+		for _, nextID := range next[cardID] {
+			if _, seen := previous[nextID]; !seen {
+				previous[nextID] = cardID
+				queue = append(queue, nextID)
+			}
+		}
+	}
+	return nil
+}
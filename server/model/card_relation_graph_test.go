@@ -0,0 +1,59 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelationTypeCanonical(t *testing.T) {
+	testCases := []struct {
+		relationType RelationType
+		canonical    RelationType
+		backwards    bool
+		directional  bool
+	}{
+		{RelationTypeBlocks, RelationTypeBlocks, false, true},
+		{RelationTypeIsBlockedBy, RelationTypeBlocks, true, true},
+		{RelationTypeIsDuplicatedBy, RelationTypeDuplicates, true, true},
+		{RelationTypeClones, RelationTypeClones, false, true},
+		{RelationTypeIsCausedBy, RelationTypeCauses, true, true},
+		{RelationTypeRelatesTo, RelationTypeRelatesTo, false, false},
+		{RelationType("unknown"), RelationType("unknown"), false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.relationType), func(t *testing.T) {
+			canonical, backwards := tc.relationType.Canonical()
+			assert.Equal(t, tc.canonical, canonical)
+			assert.Equal(t, tc.backwards, backwards)
+			assert.Equal(t, tc.directional, tc.relationType.IsDirectional())
+		})
+	}
+}
+
+func TestNewCardRelationEdge(t *testing.T) {
+	edge := NewCardRelationEdge(&CardRelation{ID: "r1", SourceCardID: "a", TargetCardID: "b", RelationType: RelationTypeIsBlockedBy})
+	assert.Equal(t, &CardRelationEdge{ID: "r1", FromCardID: "b", ToCardID: "a", RelationType: RelationTypeBlocks}, edge)
+
+	edge = NewCardRelationEdge(&CardRelation{ID: "r2", SourceCardID: "a", TargetCardID: "b", RelationType: RelationTypeBlocks})
+	assert.Equal(t, &CardRelationEdge{ID: "r2", FromCardID: "a", ToCardID: "b", RelationType: RelationTypeBlocks}, edge)
+}
+
+func TestFindCardRelationPath(t *testing.T) {
+	edges := []*CardRelationEdge{
+		{ID: "1", FromCardID: "a", ToCardID: "b", RelationType: RelationTypeBlocks},
+		{ID: "2", FromCardID: "b", ToCardID: "c", RelationType: RelationTypeBlocks},
+		{ID: "3", FromCardID: "c", ToCardID: "d", RelationType: RelationTypeCauses},
+		{ID: "4", FromCardID: "a", ToCardID: "c", RelationType: RelationTypeBlocks},
+	}
+
+	assert.Equal(t, []string{"a", "c"}, FindCardRelationPath(edges, RelationTypeBlocks, "a", "c"))
+	assert.Equal(t, []string{"b", "c"}, FindCardRelationPath(edges, RelationTypeBlocks, "b", "c"))
+	assert.Nil(t, FindCardRelationPath(edges, RelationTypeBlocks, "c", "a"))
+	assert.Nil(t, FindCardRelationPath(edges, RelationTypeBlocks, "a", "d"))
+	assert.Equal(t, []string{"c", "d"}, FindCardRelationPath(edges, RelationTypeCauses, "c", "d"))
+}
@@ -0,0 +1,284 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// BoardPropertySchedulePropertyID is the board property holding the id of the
+// date property that schedules the board's cards. The first date property of
+// the board is used when it is not set.
+const BoardPropertySchedulePropertyID = "schedulePropertyId"
+
+// ErrScheduleCycle is returned when the blocks relations of the scheduled
+// cards form a cycle.
+var ErrScheduleCycle = errors.New("the blocks relations of the cards form a cycle")
+
+// SchedulePropDef returns the date property that schedules the cards of a
+// board: the one set in the board's properties, or else the first one.
+func (ps PropSchema) SchedulePropDef(board *Board) (PropDef, bool) {
+	if propID, err := board.GetPropertyString(BoardPropertySchedulePropertyID); err == nil && propID != "" {
+		if pd, ok := ps[propID]; ok && pd.Type == PropTypeDate {
+			return pd, true
+		}
+	}
+
+	var first PropDef
+	found := false
+	for _, pd := range ps {
+		if pd.Type == PropTypeDate && (!found || pd.Index < first.Index) {
+			first = pd
+			found = true
+		}
+	}
+	return first, found
+}
+
+// GetCardScheduleDates returns the planned start and finish of a card from
+// its date property, the finish being exclusive. Dates without a time last
+// the whole day. It returns false if the card has no date for the property.
+func GetCardScheduleDates(card *Card, propertyID string) (int64, int64, bool) {
+	dr := parseDateRange(propValueString(card.Properties[propertyID]))
+	if dr.From == 0 {
+		return 0, 0, false
+	}
+	finish := dr.From
+	if dr.To > dr.From {
+		finish = dr.To
+	}
+	if !dr.IncludeTime {
+		finish += (24 * time.Hour).Milliseconds()
+	}
+	return dr.From, finish, true
+}
+
+// CardScheduleEntry is a scheduled card. Times are in milliseconds, finishes
+// are exclusive and durations are in milliseconds.
+// swagger:model
+type CardScheduleEntry struct {
+	// The ID of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The ID of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// The ID of the date property that schedules the card
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The start of the card's date property
+	// required: true
+	PlannedStart int64 `json:"plannedStart"`
+
+	// The end of the card's date property
+	// required: true
+	PlannedFinish int64 `json:"plannedFinish"`
+
+	// The earliest the card can start, after its planned start and its blockers
+	// required: true
+	EarliestStart int64 `json:"earliestStart"`
+
+	// The earliest the card can finish
+	// required: true
+	EarliestFinish int64 `json:"earliestFinish"`
+
+	// The latest the card can start without delaying the end of the schedule
+	// required: true
+	LatestStart int64 `json:"latestStart"`
+
+	// The latest the card can finish without delaying the end of the schedule
+	// required: true
+	LatestFinish int64 `json:"latestFinish"`
+
+	// How long the card can slip without delaying the end of the schedule
+	// required: true
+	Slack int64 `json:"slack"`
+
+	// How long the blockers of the card push its start past its planned start
+	// required: true
+	Slip int64 `json:"slip"`
+
+	// Whether the card is on the critical path
+	// required: true
+	Critical bool `json:"critical"`
+
+	// Whether the card's dates overlap the dates of one of its blockers
+	// required: true
+	ViolatesDependencies bool `json:"violatesDependencies"`
+}
+
+// CardScheduleDependency is a blocks relation between two scheduled cards.
+// swagger:model
+type CardScheduleDependency struct {
+	// The ID of the relation
+	// required: true
+	RelationID string `json:"relationId"`
+
+	// The card that has to finish first
+	// required: true
+	BlockerCardID string `json:"blockerCardId"`
+
+	// The card that waits on the blocker
+	// required: true
+	CardID string `json:"cardId"`
+
+	// Whether the card is planned to start before its blocker finishes
+	// required: true
+	Violated bool `json:"violated"`
+}
+
+// CardSchedule is the schedule of the cards of one or more boards, computed
+// from the cards' dates and their blocks relations.
+// swagger:model
+type CardSchedule struct {
+	// The IDs of the scheduled boards
+	// required: true
+	BoardIDs []string `json:"boardIds"`
+
+	// The earliest planned start of the cards
+	// required: true
+	Start int64 `json:"start"`
+
+	// The earliest finish of the last card
+	// required: true
+	Finish int64 `json:"finish"`
+
+	// The cards that have dates, by earliest start
+	// required: true
+	Cards []*CardScheduleEntry `json:"cards"`
+
+	// The blocks relations between the scheduled cards
+	// required: true
+	Dependencies []*CardScheduleDependency `json:"dependencies"`
+
+	// The IDs of the cards on the critical path, by earliest start
+	// required: true
+	CriticalPath []string `json:"criticalPath"`
+
+	// The IDs of the cards that have no dates
+	// required: true
+	UnscheduledCardIDs []string `json:"unscheduledCardIds"`
+}
+
+// ComputeSchedule fills in the earliest and latest dates, slack, slip and
+// critical path of a schedule from the planned dates of its cards and their
+// dependencies. A card starts no earlier than planned and after its blockers
+// finish. It returns ErrScheduleCycle if the dependencies form a cycle.
+func (s *CardSchedule) ComputeSchedule() error {
+	entries := make(map[string]*CardScheduleEntry, len(s.Cards))
+	for _, entry := range s.Cards {
+		entries[entry.CardID] = entry
+	}
+
+	blockers := make(map[string][]string)
+	blocked := make(map[string][]string)
+	for _, dep := range s.Dependencies {
+		blocker, card := entries[dep.BlockerCardID], entries[dep.CardID]
+		if blocker == nil || card == nil {
+			continue
+		}
+		dep.Violated = card.PlannedStart < blocker.PlannedFinish
+		if dep.Violated {
+			card.ViolatesDependencies = true
+		}
+		blockers[dep.CardID] = append(blockers[dep.CardID], dep.BlockerCardID)
+		blocked[dep.BlockerCardID] = append(blocked[dep.BlockerCardID], dep.CardID)
+	}
+
+	order, err := s.topologicalOrder(blockers, blocked)
+	if err != nil {
+		return err
+	}
+
+	// forward pass
+	s.Start, s.Finish = 0, 0
+	for i, entry := range order {
+		entry.EarliestStart = entry.PlannedStart
+		for _, blockerID := range blockers[entry.CardID] {
+			if finish := entries[blockerID].EarliestFinish; finish > entry.EarliestStart {
+				entry.EarliestStart = finish
+			}
+		}
+		entry.EarliestFinish = entry.EarliestStart + (entry.PlannedFinish - entry.PlannedStart)
+		entry.Slip = entry.EarliestStart - entry.PlannedStart
+
+		if i == 0 || entry.PlannedStart < s.Start {
+			s.Start = entry.PlannedStart
+		}
+		if entry.EarliestFinish > s.Finish {
+			s.Finish = entry.EarliestFinish
+		}
+	}
+
+	// backward pass
+	for i := len(order) - 1; i >= 0; i-- {
+		entry := order[i]
+		entry.LatestFinish = s.Finish
+		for _, cardID := range blocked[entry.CardID] {
+			if start := entries[cardID].LatestStart; start < entry.LatestFinish {
+				entry.LatestFinish = start
+			}
+		}
+		entry.LatestStart = entry.LatestFinish - (entry.PlannedFinish - entry.PlannedStart)
+		entry.Slack = entry.LatestStart - entry.EarliestStart
+		entry.Critical = entry.Slack == 0
+	}
+
+	sort.SliceStable(s.Cards, func(i, j int) bool {
+		if s.Cards[i].EarliestStart != s.Cards[j].EarliestStart {
+			return s.Cards[i].EarliestStart < s.Cards[j].EarliestStart
+		}
+		return s.Cards[i].CardID < s.Cards[j].CardID
+	})
+
+	s.CriticalPath = []string{}
+	for _, entry := range s.Cards {
+		if entry.Critical {
+			s.CriticalPath = append(s.CriticalPath, entry.CardID)
+		}
+	}
+	return nil
+}
+
+// topologicalOrder returns the cards of a schedule with each card after its
+// blockers.
+func (s *CardSchedule) topologicalOrder(blockers, blocked map[string][]string) ([]*CardScheduleEntry, error) {
+	entries := make(map[string]*CardScheduleEntry, len(s.Cards))
+	waiting := make(map[string]int, len(s.Cards))
+	var ready []string
+	for _, entry := range s.Cards {
+		entries[entry.CardID] = entry
+		waiting[entry.CardID] = len(blockers[entry.CardID])
+		if waiting[entry.CardID] == 0 {
+			ready = append(ready, entry.CardID)
+		}
+	}
+
+	order := make([]*CardScheduleEntry, 0, len(s.Cards))
+	for len(ready) > 0 {
+		cardID := ready[0]
+		ready = ready[1:]
+		order = append(order, entries[cardID])
+		for _, nextID := range blocked[cardID] {
+			waiting[nextID]--
+			if waiting[nextID] == 0 {
+				ready = append(ready, nextID)
+			}
+		}
+	}
+
+	if len(order) != len(s.Cards) {
+		return nil, ErrScheduleCycle
+	}
+	return order, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCardScheduleDates(t *testing.T) {
+	day := (24 * time.Hour).Milliseconds()
+	card := &Card{Properties: map[string]any{
+		"range":    `{"from":864000000,"to":1036800000}`,
+		"single":   `{"from":864000000}`,
+		"withTime": `{"from":864000000,"to":864060000,"includeTime":true}`,
+	}}
+
+	start, finish, ok := GetCardScheduleDates(card, "range")
+	require.True(t, ok)
+	assert.Equal(t, int64(864000000), start)
+	assert.Equal(t, 1036800000+day, finish)
+
+	start, finish, ok = GetCardScheduleDates(card, "single")
+	require.True(t, ok)
+	assert.Equal(t, day, finish-start)
+
+	start, finish, ok = GetCardScheduleDates(card, "withTime")
+	require.True(t, ok)
+	assert.Equal(t, int64(60000), finish-start)
+
+	_, _, ok = GetCardScheduleDates(card, "missing")
+	assert.False(t, ok)
+}
+
+func TestSchedulePropDef(t *testing.T) {
+	schema := PropSchema{
+		"due":     {ID: "due", Index: 2, Name: "Due", Type: PropTypeDate},
+		"planned": {ID: "planned", Index: 1, Name: "Planned", Type: PropTypeDate},
+		"status":  {ID: "status", Index: 0, Name: "Status", Type: PropTypeSelect},
+	}
+
+	prop, ok := schema.SchedulePropDef(&Board{})
+	require.True(t, ok)
+	assert.Equal(t, "planned", prop.ID)
+
+	prop, ok = schema.SchedulePropDef(&Board{Properties: map[string]any{BoardPropertySchedulePropertyID: "due"}})
+	require.True(t, ok)
+	assert.Equal(t, "due", prop.ID)
+
+	_, ok = PropSchema{"status": schema["status"]}.SchedulePropDef(&Board{})
+	assert.False(t, ok)
+}
+
+func TestComputeSchedule(t *testing.T) {
+	day := (24 * time.Hour).Milliseconds()
+	entry := func(cardID string, start, days int64) *CardScheduleEntry {
+		return &CardScheduleEntry{CardID: cardID, PlannedStart: start * day, PlannedFinish: (start + days) * day}
+	}
+	dependency := func(blockerCardID, cardID string) *CardScheduleDependency {
+		return &CardScheduleDependency{RelationID: blockerCardID + cardID, BlockerCardID: blockerCardID, CardID: cardID}
+	}
+
+	t.Run("critical path, slack and violations", func(t *testing.T) {
+		schedule := &CardSchedule{
+			Cards: []*CardScheduleEntry{
+				entry("d", 3, 1),
+				entry("a", 0, 2),
+				entry("b", 2, 1),
+				entry("c", 1, 1),
+				entry("e", 0, 1),
+			},
+			Dependencies: []*CardScheduleDependency{
+				dependency("a", "b"),
+				dependency("a", "c"),
+				dependency("b", "d"),
+				dependency("c", "d"),
+			},
+		}
+		require.NoError(t, schedule.ComputeSchedule())
+
+		assert.Equal(t, int64(0), schedule.Start)
+		assert.Equal(t, 4*day, schedule.Finish)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, schedule.CriticalPath)
+
+		entries := make(map[string]*CardScheduleEntry)
+		for _, e := range schedule.Cards {
+			entries[e.CardID] = e
+		}
+
+		// c is planned to start before a finishes, so it slips by a day.
+		assert.True(t, entries["c"].ViolatesDependencies)
+		assert.Equal(t, 2*day, entries["c"].EarliestStart)
+		assert.Equal(t, day, entries["c"].Slip)
+		assert.True(t, schedule.Dependencies[1].Violated)
+		assert.False(t, schedule.Dependencies[0].Violated)
+		assert.False(t, entries["b"].ViolatesDependencies)
+
+		assert.Equal(t, 3*day, entries["e"].Slack)
+		assert.Equal(t, 3*day, entries["e"].LatestStart)
+		assert.Equal(t, 4*day, entries["e"].LatestFinish)
+		assert.False(t, entries["e"].Critical)
+	})
+
+	t.Run("a slip pushes the cards waiting on it", func(t *testing.T) {
+		schedule := &CardSchedule{
+			Cards: []*CardScheduleEntry{
+				entry("a", 0, 5),
+				entry("milestone", 3, 1),
+			},
+			Dependencies: []*CardScheduleDependency{dependency("a", "milestone")},
+		}
+		require.NoError(t, schedule.ComputeSchedule())
+
+		assert.Equal(t, 5*day, schedule.Cards[1].EarliestStart)
+		assert.Equal(t, 2*day, schedule.Cards[1].Slip)
+		assert.Equal(t, 6*day, schedule.Finish)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		schedule := &CardSchedule{
+			Cards:        []*CardScheduleEntry{entry("a", 0, 1), entry("b", 1, 1)},
+			Dependencies: []*CardScheduleDependency{dependency("a", "b"), dependency("b", "a")},
+		}
+		require.ErrorIs(t, schedule.ComputeSchedule(), ErrScheduleCycle)
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+type DueDateNotificationKind string
+
+const (
+	// DueDateNotificationReminder is sent to the assignees of a card before
+	// the card is due.
+	DueDateNotificationReminder DueDateNotificationKind = "reminder"
+
+	// DueDateNotificationOverdueDigest is sent once a day to users that are
+	// assigned to overdue cards.
+	DueDateNotificationOverdueDigest DueDateNotificationKind = "overdue_digest"
+)
+
+// DueDateNotification records that a due date notification was sent, so that
+// it is sent once across servers.
+// swagger:ignore
+type DueDateNotification struct {
+	Kind   DueDateNotificationKind
+	UserID string
+	CardID string
+
+	// Key identifies the notification for the user and card: the property
+	// and due date of a reminder, or the local day of a digest.
+	Key      string
+	CreateAt int64
+}
+
+// DigestKey returns the key of the digest sent on the day of t.
+func DigestKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// CardDueDate is the due date of a card, held by one of its date properties.
+type CardDueDate struct {
+	PropertyID  string
+	Millis      int64
+	IncludeTime bool
+}
+
+// GetCardDueDate returns the due date held by a card's date property, which is
+// the end of a date range or the date itself. It returns false if the card has
+// no date for the property.
+func GetCardDueDate(card *Card, propertyID string) (CardDueDate, bool) {
+	dr := parseDateRange(propValueString(card.Properties[propertyID]))
+	millis := dr.From
+	if dr.To != 0 {
+		millis = dr.To
+	}
+	if millis == 0 {
+		return CardDueDate{}, false
+	}
+	return CardDueDate{PropertyID: propertyID, Millis: millis, IncludeTime: dr.IncludeTime}, true
+}
+
+// DueAt returns the time at which the date is due for a user in the given
+// location. Dates without a time are stored as the UTC midnight of their day,
+// and are due at the start of that day in the user's location.
+func (d CardDueDate) DueAt(loc *time.Location) time.Time {
+	t := utils.GetTimeForMillis(d.Millis)
+	if d.IncludeTime {
+		return t.In(loc)
+	}
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// Format returns the due date as shown to a user in the given location.
+func (d CardDueDate) Format(loc *time.Location) string {
+	if d.IncludeTime {
+		return d.DueAt(loc).Format("January 02, 2006 15:04 MST")
+	}
+	return d.DueAt(loc).Format("January 02, 2006")
+}
+
+// ReminderKey returns the key of the reminder for a due date, which changes
+// when the date changes so that a new reminder is sent.
+func (d CardDueDate) ReminderKey() string {
+	return d.PropertyID + ":" + strconv.FormatInt(d.Millis, 10)
+}
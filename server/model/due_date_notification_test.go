@@ -0,0 +1,45 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardDueDate(t *testing.T) {
+	// October 18, 2026 at midnight UTC, as stored for a date without time
+	day := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC).UnixMilli()
+	honolulu, err := time.LoadLocation("Pacific/Honolulu")
+	require.NoError(t, err)
+
+	card := &Card{Properties: map[string]interface{}{
+		"day":   fmt.Sprintf(`{"from":%d}`, day),
+		"range": fmt.Sprintf(`{"from":1000,"to":%d,"includeTime":true}`, day),
+	}}
+
+	t.Run("date without time", func(t *testing.T) {
+		due, ok := GetCardDueDate(card, "day")
+		require.True(t, ok)
+		assert.Equal(t, fmt.Sprintf("day:%d", day), due.ReminderKey())
+		assert.Equal(t, time.Date(2026, time.October, 18, 0, 0, 0, 0, honolulu), due.DueAt(honolulu))
+		assert.Equal(t, "October 18, 2026", due.Format(honolulu))
+	})
+
+	t.Run("date range with time", func(t *testing.T) {
+		due, ok := GetCardDueDate(card, "range")
+		require.True(t, ok)
+		assert.True(t, due.DueAt(honolulu).Equal(time.UnixMilli(day)))
+		assert.Equal(t, "October 17, 2026 14:00 HST", due.Format(honolulu))
+	})
+
+	t.Run("no date", func(t *testing.T) {
+		_, ok := GetCardDueDate(card, "missing")
+		assert.False(t, ok)
+	})
+}
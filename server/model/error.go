@@ -171,7 +171,9 @@ func (ni *ErrNotImplemented) Error() string {
 // - model.ErrBoardMemberIsLastAdmin
 // - model.ErrBoardIDMismatch
 // - model.ErrBlockTitleSizeLimitExceeded
-// - model.ErrBlockFieldsSizeLimitExceeded.
+// - model.ErrBlockFieldsSizeLimitExceeded
+// - model.ErrStatusTransition
+// - model.ErrInvalidCardRelation.
 func IsErrBadRequest(err error) bool {
 	if err == nil {
 		return false
@@ -209,6 +211,18 @@ func IsErrBadRequest(err error) bool {
 		return true
 	}
 
+	// check if this is a model.ErrStatusTransition
+	var st *ErrStatusTransition
+	if errors.As(err, &st) {
+		return true
+	}
+
+	// check if this is a model.ErrInvalidCardRelation
+	var icr ErrInvalidCardRelation
+	if errors.As(err, &icr) {
+		return true
+	}
+
 	// check if this is a model.ErrBlockTitleSizeLimitExceeded
 	return errors.Is(err, ErrBlockFieldsSizeLimitExceeded)
 }
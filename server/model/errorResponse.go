@@ -13,4 +13,8 @@ type ErrorResponse struct {
 	// The error code
 	// required: false
 	ErrorCode int `json:"errorCode"`
+
+	// The failed conditions of a rejected status transition
+	// required: false
+	StatusTransitionFailures []StatusTransitionFailure `json:"statusTransitionFailures,omitempty"`
 }
@@ -0,0 +1,1002 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+var ErrInvalidFormula = errors.New("invalid formula")
+
+// The formula of a formula property is an expression over the other
+// properties of the same card, such as
+// `if(prop("Spent") > prop("Estimate"), "late", concat(prop("Owner"), " on it"))`.
+//
+// Properties are referred to by name with prop("Name"), case insensitively.
+// The expression supports numbers, double quoted strings, true and false, the
+// arithmetic operators + - * / %, the comparisons == != < <= > >=, the logical
+// operators && || ! (or and, or, not) and the functions listed in
+// formulaFunctions. The + operator concatenates when one of its operands is
+// text. Arithmetic on an empty value has an empty result.
+
+type formulaValueKind int
+
+const (
+	formulaEmpty formulaValueKind = iota
+	formulaNumber
+	formulaText
+	formulaBool
+	formulaDate
+)
+
+const (
+	formulaDateLayout     = "2006-01-02"
+	formulaDateTimeLayout = "2006-01-02T15:04:05Z"
+)
+
+// formulaValue is the value of a formula expression. Dates are in
+// milliseconds, and may or may not include a time of day.
+type formulaValue struct {
+	kind     formulaValueKind
+	number   float64
+	text     string
+	boolean  bool
+	date     int64
+	dateTime bool
+}
+
+func formulaNumberValue(number float64) formulaValue {
+	return formulaValue{kind: formulaNumber, number: number}
+}
+
+func formulaTextValue(text string) formulaValue {
+	if text == "" {
+		return formulaValue{}
+	}
+	return formulaValue{kind: formulaText, text: text}
+}
+
+func formulaBoolValue(boolean bool) formulaValue {
+	return formulaValue{kind: formulaBool, boolean: boolean}
+}
+
+func formulaDateValue(millis int64, dateTime bool) formulaValue {
+	return formulaValue{kind: formulaDate, date: millis, dateTime: dateTime}
+}
+
+// String returns the value as stored in the card properties.
+func (v formulaValue) String() string {
+	switch v.kind {
+	case formulaNumber:
+		// drop the floating point noise of decimal arithmetic
+		return FormatNumberValue(math.Round(v.number*1e9) / 1e9)
+	case formulaText:
+		return v.text
+	case formulaBool:
+		return strconv.FormatBool(v.boolean)
+	case formulaDate:
+		if v.dateTime {
+			return utils.GetTimeForMillis(v.date).UTC().Format(formulaDateTimeLayout)
+		}
+		return utils.GetTimeForMillis(v.date).UTC().Format(formulaDateLayout)
+	}
+	return ""
+}
+
+func (v formulaValue) truthy() bool {
+	switch v.kind {
+	case formulaNumber:
+		return v.number != 0
+	case formulaText:
+		return v.text != ""
+	case formulaBool:
+		return v.boolean
+	case formulaDate:
+		return true
+	}
+	return false
+}
+
+func (v formulaValue) kindName() string {
+	switch v.kind {
+	case formulaNumber:
+		return "number"
+	case formulaText:
+		return "text"
+	case formulaBool:
+		return "boolean"
+	case formulaDate:
+		return "date"
+	}
+	return "empty"
+}
+
+// Formula is a parsed formula expression.
+type Formula struct {
+	root formulaNode
+}
+
+type formulaNode interface {
+	eval(ctx *formulaContext) (formulaValue, error)
+}
+
+type formulaLiteral struct {
+	value formulaValue
+}
+
+type formulaPropRef struct {
+	name string
+}
+
+type formulaUnary struct {
+	op      string
+	operand formulaNode
+}
+
+type formulaBinary struct {
+	op          string
+	left, right formulaNode
+}
+
+type formulaCall struct {
+	name string
+	args []formulaNode
+}
+
+// formulaFunction is a function of the formula language, with its number of
+// arguments. A negative maximum allows any number of arguments.
+type formulaFunction struct {
+	minArgs int
+	maxArgs int
+	call    func(ctx *formulaContext, args []formulaValue) (formulaValue, error)
+}
+
+// formulaFunctions are the functions of the formula language, by lower case
+// name. if() is evaluated lazily by formulaCall.eval and only needs an arity.
+var formulaFunctions = map[string]formulaFunction{
+	"if":          {3, 3, nil},
+	"concat":      {1, -1, formulaConcat},
+	"length":      {1, 1, formulaLength},
+	"lower":       {1, 1, formulaLower},
+	"upper":       {1, 1, formulaUpper},
+	"contains":    {2, 2, formulaContains},
+	"empty":       {1, 1, formulaIsEmpty},
+	"format":      {1, 1, formulaFormat},
+	"tonumber":    {1, 1, formulaToNumber},
+	"round":       {1, 2, formulaRound},
+	"floor":       {1, 1, formulaMath("floor", math.Floor)},
+	"ceil":        {1, 1, formulaMath("ceil", math.Ceil)},
+	"abs":         {1, 1, formulaMath("abs", math.Abs)},
+	"min":         {1, -1, formulaMinMax(math.Min)},
+	"max":         {1, -1, formulaMinMax(math.Max)},
+	"now":         {0, 0, formulaNow},
+	"today":       {0, 0, formulaToday},
+	"datebetween": {3, 3, formulaDateBetween},
+	"dateadd":     {3, 3, formulaDateAdd},
+}
+
+// ParseFormula parses a formula expression.
+func ParseFormula(expression string) (*Formula, error) {
+	tokens, err := tokenizeFormula(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: the formula is empty", ErrInvalidFormula)
+	}
+
+	p := &formulaParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFormula, p.peek().text)
+	}
+	return &Formula{root: root}, nil
+}
+
+type formulaTokenKind int
+
+const (
+	formulaTokenNumber formulaTokenKind = iota
+	formulaTokenString
+	formulaTokenIdent
+	formulaTokenOperator
+)
+
+type formulaToken struct {
+	kind formulaTokenKind
+	text string
+}
+
+var formulaOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "+", "-", "*", "/", "%", "<", ">", "!", "(", ")", ","}
+
+func tokenizeFormula(expression string) ([]formulaToken, error) {
+	input := []rune(expression)
+	tokens := []formulaToken{}
+
+	for pos := 0; pos < len(input); {
+		r := input[pos]
+		switch {
+		case unicode.IsSpace(r):
+			pos++
+
+		case unicode.IsDigit(r) || (r == '.' && pos+1 < len(input) && unicode.IsDigit(input[pos+1])):
+			start := pos
+			for pos < len(input) && (unicode.IsDigit(input[pos]) || input[pos] == '.') {
+				pos++
+			}
+			tokens = append(tokens, formulaToken{kind: formulaTokenNumber, text: string(input[start:pos])})
+
+		case r == '"':
+			var sb strings.Builder
+			pos++
+			for ; pos < len(input) && input[pos] != '"'; pos++ {
+				if input[pos] == '\\' && pos+1 < len(input) {
+					pos++
+				}
+				sb.WriteRune(input[pos])
+			}
+			if pos >= len(input) {
+				return nil, fmt.Errorf("%w: unterminated string", ErrInvalidFormula)
+			}
+			pos++
+			tokens = append(tokens, formulaToken{kind: formulaTokenString, text: sb.String()})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := pos
+			for pos < len(input) && (unicode.IsLetter(input[pos]) || unicode.IsDigit(input[pos]) || input[pos] == '_') {
+				pos++
+			}
+			tokens = append(tokens, formulaToken{kind: formulaTokenIdent, text: string(input[start:pos])})
+
+		default:
+			found := false
+			for _, op := range formulaOperators {
+				if strings.HasPrefix(string(input[pos:]), op) {
+					tokens = append(tokens, formulaToken{kind: formulaTokenOperator, text: op})
+					pos += len([]rune(op))
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("%w: unexpected character %q", ErrInvalidFormula, r)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+type formulaParser struct {
+	tokens []formulaToken
+	pos    int
+}
+
+func (p *formulaParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *formulaParser) peek() formulaToken {
+	return p.tokens[p.pos]
+}
+
+// formulaKeywordOperators are the keywords that can be written instead of
+// the logical operators.
+var formulaKeywordOperators = map[string]string{"and": "&&", "or": "||", "not": "!"}
+
+// accept consumes the next token if it is one of the operators, and returns
+// the operator.
+func (p *formulaParser) accept(ops ...string) (string, bool) {
+	if p.done() {
+		return "", false
+	}
+	token := p.peek()
+	op := token.text
+	if token.kind == formulaTokenIdent {
+		op = formulaKeywordOperators[strings.ToLower(token.text)]
+	} else if token.kind != formulaTokenOperator {
+		return "", false
+	}
+	for _, candidate := range ops {
+		if op == candidate {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *formulaParser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		if p.done() {
+			return fmt.Errorf("%w: expected %q at the end", ErrInvalidFormula, op)
+		}
+		return fmt.Errorf("%w: expected %q instead of %q", ErrInvalidFormula, op, p.peek().text)
+	}
+	return nil
+}
+
+// parseBinary parses a chain of binary operators of the same precedence.
+func (p *formulaParser) parseBinary(next func() (formulaNode, error), ops ...string) (formulaNode, error) {
+	left, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(ops...)
+		if !ok {
+			return left, nil
+		}
+		right, err := next()
+		if err != nil {
+			return nil, err
+		}
+		left = &formulaBinary{op: op, left: left, right: right}
+	}
+}
+
+func (p *formulaParser) parseOr() (formulaNode, error) {
+	return p.parseBinary(p.parseAnd, "||")
+}
+
+func (p *formulaParser) parseAnd() (formulaNode, error) {
+	return p.parseBinary(p.parseEquality, "&&")
+}
+
+func (p *formulaParser) parseEquality() (formulaNode, error) {
+	return p.parseBinary(p.parseComparison, "==", "!=")
+}
+
+func (p *formulaParser) parseComparison() (formulaNode, error) {
+	return p.parseBinary(p.parseAdditive, "<", "<=", ">", ">=")
+}
+
+func (p *formulaParser) parseAdditive() (formulaNode, error) {
+	return p.parseBinary(p.parseMultiplicative, "+", "-")
+}
+
+func (p *formulaParser) parseMultiplicative() (formulaNode, error) {
+	return p.parseBinary(p.parseUnary, "*", "/", "%")
+}
+
+func (p *formulaParser) parseUnary() (formulaNode, error) {
+	if op, ok := p.accept("-", "!"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &formulaUnary{op: op, operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *formulaParser) parsePrimary() (formulaNode, error) {
+	if p.done() {
+		return nil, fmt.Errorf("%w: unexpected end of the formula", ErrInvalidFormula)
+	}
+
+	token := p.peek()
+	p.pos++
+	switch token.kind {
+	case formulaTokenNumber:
+		number, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid number %q", ErrInvalidFormula, token.text)
+		}
+		return &formulaLiteral{value: formulaNumberValue(number)}, nil
+
+	case formulaTokenString:
+		return &formulaLiteral{value: formulaTextValue(token.text)}, nil
+
+	case formulaTokenIdent:
+		name := strings.ToLower(token.text)
+		switch name {
+		case "true", "false":
+			return &formulaLiteral{value: formulaBoolValue(name == "true")}, nil
+		case "prop":
+			return p.parsePropRef()
+		}
+		return p.parseCall(name, token.text)
+
+	case formulaTokenOperator:
+		if token.text == "(" {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expect(")")
+		}
+	}
+	return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFormula, token.text)
+}
+
+func (p *formulaParser) parsePropRef() (formulaNode, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	if p.done() || p.peek().kind != formulaTokenString {
+		return nil, fmt.Errorf("%w: prop() takes the name of a property in double quotes", ErrInvalidFormula)
+	}
+	name := p.peek().text
+	p.pos++
+	return &formulaPropRef{name: name}, p.expect(")")
+}
+
+func (p *formulaParser) parseCall(name, text string) (formulaNode, error) {
+	function, ok := formulaFunctions[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown function %q", ErrInvalidFormula, text)
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	call := &formulaCall{name: name}
+	if _, ok := p.accept(")"); !ok {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if _, ok := p.accept(","); !ok {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(call.args) < function.minArgs || (function.maxArgs >= 0 && len(call.args) > function.maxArgs) {
+		return nil, fmt.Errorf("%w: wrong number of arguments for %s()", ErrInvalidFormula, text)
+	}
+	return call, nil
+}
+
+// formulaContext evaluates the formulas of one card. The results of the
+// formula properties are kept, as formulas can refer to each other.
+type formulaContext struct {
+	card    *Card
+	schema  PropSchema
+	now     int64
+	results map[string]formulaValue
+	errs    map[string]error
+	pending map[string]bool
+}
+
+func newFormulaContext(card *Card, schema PropSchema, now int64) *formulaContext {
+	return &formulaContext{
+		card:    card,
+		schema:  schema,
+		now:     now,
+		results: map[string]formulaValue{},
+		errs:    map[string]error{},
+		pending: map[string]bool{},
+	}
+}
+
+// formulaResult evaluates a formula property of the card.
+func (ctx *formulaContext) formulaResult(def PropDef) (formulaValue, error) {
+	if value, ok := ctx.results[def.ID]; ok {
+		return value, nil
+	}
+	if err, ok := ctx.errs[def.ID]; ok {
+		return formulaValue{}, err
+	}
+	if ctx.pending[def.ID] {
+		return formulaValue{}, fmt.Errorf("%w: the formula of %q refers to itself", ErrInvalidFormula, def.Name)
+	}
+
+	ctx.pending[def.ID] = true
+	defer delete(ctx.pending, def.ID)
+
+	formula := def.formula
+	if formula == nil {
+		parsed, err := ParseFormula(def.Formula)
+		if err != nil {
+			ctx.errs[def.ID] = err
+			return formulaValue{}, err
+		}
+		formula = parsed
+	}
+
+	value, err := formula.root.eval(ctx)
+	if err != nil {
+		ctx.errs[def.ID] = err
+		return formulaValue{}, err
+	}
+	ctx.results[def.ID] = value
+	return value, nil
+}
+
+// propValue returns the value of a property of the card as seen by formulas.
+func (ctx *formulaContext) propValue(def PropDef) (formulaValue, error) {
+	card := ctx.card
+	value := card.Properties[def.ID]
+
+	switch def.Type {
+	case PropTypeFormula:
+		return ctx.formulaResult(def)
+	case PropTypeNumber:
+		if number, ok := ParseNumberValue(value); ok {
+			return formulaNumberValue(number), nil
+		}
+		return formulaValue{}, nil
+	case PropTypeCheckbox:
+		return formulaBoolValue(propValueString(value) == "true"), nil
+	case PropTypeDate:
+		dr := parseDateRange(propValueString(value))
+		if dr.From == 0 {
+			return formulaValue{}, nil
+		}
+		return formulaDateValue(dr.From, dr.IncludeTime), nil
+	case PropTypeCreatedTime:
+		return formulaDateValue(card.CreateAt, true), nil
+	case PropTypeUpdatedTime:
+		return formulaDateValue(card.UpdateAt, true), nil
+	case PropTypeCreatedBy:
+		return formulaTextValue(card.CreatedBy), nil
+	case PropTypeUpdatedBy:
+		return formulaTextValue(card.ModifiedBy), nil
+	case PropTypeSelect, PropTypeMultiSelect:
+		ids := propValueList(value)
+		values := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if option, ok := def.Options[id]; ok {
+				values = append(values, option.Value)
+			}
+		}
+		return formulaTextValue(strings.Join(values, ", ")), nil
+	}
+	return formulaTextValue(strings.Join(propValueList(value), ", ")), nil
+}
+
+func (n *formulaLiteral) eval(_ *formulaContext) (formulaValue, error) {
+	return n.value, nil
+}
+
+func (n *formulaPropRef) eval(ctx *formulaContext) (formulaValue, error) {
+	def, ok := findPropDefByName(ctx.schema, n.name)
+	if !ok {
+		if strings.EqualFold(n.name, "title") {
+			return formulaTextValue(ctx.card.Title), nil
+		}
+		return formulaValue{}, fmt.Errorf("%w: unknown property %q", ErrInvalidFormula, n.name)
+	}
+	return ctx.propValue(def)
+}
+
+func (n *formulaUnary) eval(ctx *formulaContext) (formulaValue, error) {
+	value, err := n.operand.eval(ctx)
+	if err != nil {
+		return formulaValue{}, err
+	}
+	if n.op == "!" {
+		return formulaBoolValue(!value.truthy()), nil
+	}
+	switch value.kind {
+	case formulaEmpty:
+		return value, nil
+	case formulaNumber:
+		return formulaNumberValue(-value.number), nil
+	}
+	return formulaValue{}, fmt.Errorf("%w: cannot negate a %s", ErrInvalidFormula, value.kindName())
+}
+
+func (n *formulaBinary) eval(ctx *formulaContext) (formulaValue, error) {
+	left, err := n.left.eval(ctx)
+	if err != nil {
+		return formulaValue{}, err
+	}
+
+	// the logical operators only evaluate their right side when needed
+	switch n.op {
+	case "&&":
+		if !left.truthy() {
+			return formulaBoolValue(false), nil
+		}
+	case "||":
+		if left.truthy() {
+			return formulaBoolValue(true), nil
+		}
+	}
+
+	right, err := n.right.eval(ctx)
+	if err != nil {
+		return formulaValue{}, err
+	}
+
+	switch n.op {
+	case "&&", "||":
+		return formulaBoolValue(right.truthy()), nil
+	case "==", "!=":
+		equal := formulaEqual(left, right)
+		return formulaBoolValue(equal == (n.op == "==")), nil
+	case "<", "<=", ">", ">=":
+		return formulaCompare(n.op, left, right)
+	case "+":
+		if left.kind == formulaText || right.kind == formulaText {
+			return formulaTextValue(left.String() + right.String()), nil
+		}
+	}
+	return formulaArithmetic(n.op, left, right)
+}
+
+func formulaEqual(left, right formulaValue) bool {
+	if left.kind != right.kind {
+		return false
+	}
+	switch left.kind {
+	case formulaNumber:
+		return left.number == right.number
+	case formulaText:
+		return left.text == right.text
+	case formulaBool:
+		return left.boolean == right.boolean
+	case formulaDate:
+		return left.date == right.date
+	}
+	return true
+}
+
+func formulaCompare(op string, left, right formulaValue) (formulaValue, error) {
+	if left.kind == formulaEmpty || right.kind == formulaEmpty {
+		return formulaBoolValue(false), nil
+	}
+	if left.kind != right.kind {
+		return formulaValue{}, fmt.Errorf("%w: cannot compare a %s with a %s", ErrInvalidFormula, left.kindName(), right.kindName())
+	}
+
+	var result int
+	switch left.kind {
+	case formulaNumber:
+		result = compareFloat64(left.number, right.number)
+	case formulaText:
+		result = strings.Compare(left.text, right.text)
+	case formulaDate:
+		result = compareInt64(left.date, right.date)
+	default:
+		return formulaValue{}, fmt.Errorf("%w: cannot compare booleans", ErrInvalidFormula)
+	}
+
+	switch op {
+	case "<":
+		return formulaBoolValue(result < 0), nil
+	case "<=":
+		return formulaBoolValue(result <= 0), nil
+	case ">":
+		return formulaBoolValue(result > 0), nil
+	}
+	return formulaBoolValue(result >= 0), nil
+}
+
+func formulaArithmetic(op string, left, right formulaValue) (formulaValue, error) {
+	if left.kind == formulaEmpty || right.kind == formulaEmpty {
+		return formulaValue{}, nil
+	}
+	if left.kind != formulaNumber || right.kind != formulaNumber {
+		return formulaValue{}, fmt.Errorf("%w: cannot compute a %s %s a %s, use dateBetween or dateAdd for dates",
+			ErrInvalidFormula, left.kindName(), op, right.kindName())
+	}
+
+	switch op {
+	case "+":
+		return formulaNumberValue(left.number + right.number), nil
+	case "-":
+		return formulaNumberValue(left.number - right.number), nil
+	case "*":
+		return formulaNumberValue(left.number * right.number), nil
+	}
+	if right.number == 0 {
+		return formulaValue{}, fmt.Errorf("%w: division by zero", ErrInvalidFormula)
+	}
+	if op == "%" {
+		return formulaNumberValue(math.Mod(left.number, right.number)), nil
+	}
+	return formulaNumberValue(left.number / right.number), nil
+}
+
+func (n *formulaCall) eval(ctx *formulaContext) (formulaValue, error) {
+	if n.name == "if" {
+		condition, err := n.args[0].eval(ctx)
+		if err != nil {
+			return formulaValue{}, err
+		}
+		if condition.truthy() {
+			return n.args[1].eval(ctx)
+		}
+		return n.args[2].eval(ctx)
+	}
+
+	args := make([]formulaValue, len(n.args))
+	for i, arg := range n.args {
+		value, err := arg.eval(ctx)
+		if err != nil {
+			return formulaValue{}, err
+		}
+		args[i] = value
+	}
+	return formulaFunctions[n.name].call(ctx, args)
+}
+
+func formulaConcat(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+	var sb strings.Builder
+	for _, arg := range args {
+		sb.WriteString(arg.String())
+	}
+	return formulaTextValue(sb.String()), nil
+}
+
+func formulaLength(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+	return formulaNumberValue(float64(len([]rune(args[0].String())))), nil
+}
+
+func formulaLower(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+	return formulaTextValue(strings.ToLower(args[0].String())), nil
+}
+
+func formulaUpper(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+	return formulaTextValue(strings.ToUpper(args[0].String())), nil
+}
+
+func formulaContains(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+	return formulaBoolValue(strings.Contains(strings.ToLower(args[0].String()), strings.ToLower(args[1].String()))), nil
+}
+
+func formulaIsEmpty(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+	return formulaBoolValue(args[0].kind == formulaEmpty), nil
+}
+
+func formulaFormat(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+	return formulaTextValue(args[0].String()), nil
+}
+
+func formulaToNumber(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+	arg := args[0]
+	switch arg.kind {
+	case formulaNumber:
+		return arg, nil
+	case formulaBool:
+		if arg.boolean {
+			return formulaNumberValue(1), nil
+		}
+		return formulaNumberValue(0), nil
+	case formulaDate:
+		return formulaNumberValue(float64(arg.date)), nil
+	case formulaText:
+		if number, ok := ParseNumberValue(arg.text); ok {
+			return formulaNumberValue(number), nil
+		}
+	}
+	return formulaValue{}, nil
+}
+
+// numberArgs returns the numbers of a function call. It returns false if one
+// of them is empty.
+func numberArgs(name string, args []formulaValue) ([]float64, bool, error) {
+	numbers := make([]float64, 0, len(args))
+	for _, arg := range args {
+		switch arg.kind {
+		case formulaEmpty:
+			return nil, false, nil
+		case formulaNumber:
+			numbers = append(numbers, arg.number)
+		default:
+			return nil, false, fmt.Errorf("%w: %s() takes numbers, not a %s", ErrInvalidFormula, name, arg.kindName())
+		}
+	}
+	return numbers, true, nil
+}
+
+func formulaRound(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+	numbers, ok, err := numberArgs("round", args)
+	if err != nil || !ok {
+		return formulaValue{}, err
+	}
+	scale := 1.0
+	if len(numbers) == 2 {
+		scale = math.Pow(10, math.Trunc(numbers[1]))
+	}
+	return formulaNumberValue(math.Round(numbers[0]*scale) / scale), nil
+}
+
+func formulaMath(name string, fn func(float64) float64) func(*formulaContext, []formulaValue) (formulaValue, error) {
+	return func(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+		numbers, ok, err := numberArgs(name, args)
+		if err != nil || !ok {
+			return formulaValue{}, err
+		}
+		return formulaNumberValue(fn(numbers[0])), nil
+	}
+}
+
+// formulaMinMax returns min() or max(), which skip empty values.
+func formulaMinMax(fn func(float64, float64) float64) func(*formulaContext, []formulaValue) (formulaValue, error) {
+	return func(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+		var result formulaValue
+		for _, arg := range args {
+			switch arg.kind {
+			case formulaEmpty:
+				continue
+			case formulaNumber:
+			default:
+				return formulaValue{}, fmt.Errorf("%w: min() and max() take numbers, not a %s", ErrInvalidFormula, arg.kindName())
+			}
+			if result.kind == formulaEmpty {
+				result = arg
+				continue
+			}
+			result = formulaNumberValue(fn(result.number, arg.number))
+		}
+		return result, nil
+	}
+}
+
+func formulaNow(ctx *formulaContext, _ []formulaValue) (formulaValue, error) {
+	return formulaDateValue(ctx.now, true), nil
+}
+
+func formulaToday(ctx *formulaContext, _ []formulaValue) (formulaValue, error) {
+	now := utils.GetTimeForMillis(ctx.now).UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return formulaDateValue(today.UnixMilli(), false), nil
+}
+
+// formulaUnit returns the unit argument of the date functions.
+func formulaUnit(name string, arg formulaValue) (string, error) {
+	unit := strings.ToLower(strings.TrimSuffix(arg.String(), "s"))
+	switch unit {
+	case "minute", "hour", "day", "week", "month", "year":
+		return unit, nil
+	}
+	return "", fmt.Errorf("%w: %s() takes minutes, hours, days, weeks, months or years, not %q", ErrInvalidFormula, name, arg.String())
+}
+
+// formulaDateBetween returns the number of whole units from the second date
+// to the first one, which is negative if the first date is earlier.
+func formulaDateBetween(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+	unit, err := formulaUnit("dateBetween", args[2])
+	if err != nil {
+		return formulaValue{}, err
+	}
+	if args[0].kind == formulaEmpty || args[1].kind == formulaEmpty {
+		return formulaValue{}, nil
+	}
+	if args[0].kind != formulaDate || args[1].kind != formulaDate {
+		return formulaValue{}, fmt.Errorf("%w: dateBetween() takes two dates", ErrInvalidFormula)
+	}
+
+	end := utils.GetTimeForMillis(args[0].date).UTC()
+	start := utils.GetTimeForMillis(args[1].date).UTC()
+	var between float64
+	switch unit {
+	case "minute":
+		between = end.Sub(start).Minutes()
+	case "hour":
+		between = end.Sub(start).Hours()
+	case "day":
+		between = end.Sub(start).Hours() / 24
+	case "week":
+		between = end.Sub(start).Hours() / (24 * 7)
+	case "month":
+		between = float64(monthsBetween(start, end))
+	case "year":
+		between = float64(monthsBetween(start, end) / 12)
+	}
+	return formulaNumberValue(math.Trunc(between)), nil
+}
+
+// monthsBetween returns the number of whole months from start to end.
+func monthsBetween(start, end time.Time) int {
+	if end.Before(start) {
+		return -monthsBetween(end, start)
+	}
+	months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	if start.AddDate(0, months, 0).After(end) {
+		months--
+	}
+	return months
+}
+
+func formulaDateAdd(_ *formulaContext, args []formulaValue) (formulaValue, error) {
+	unit, err := formulaUnit("dateAdd", args[2])
+	if err != nil {
+		return formulaValue{}, err
+	}
+	if args[0].kind == formulaEmpty || args[1].kind == formulaEmpty {
+		return formulaValue{}, nil
+	}
+	if args[0].kind != formulaDate || args[1].kind != formulaNumber {
+		return formulaValue{}, fmt.Errorf("%w: dateAdd() takes a date and a number", ErrInvalidFormula)
+	}
+
+	date := utils.GetTimeForMillis(args[0].date).UTC()
+	amount := int(math.Trunc(args[1].number))
+	switch unit {
+	case "minute":
+		date = date.Add(time.Duration(amount) * time.Minute)
+	case "hour":
+		date = date.Add(time.Duration(amount) * time.Hour)
+	case "day":
+		date = date.AddDate(0, 0, amount)
+	case "week":
+		date = date.AddDate(0, 0, 7*amount)
+	case "month":
+		date = date.AddDate(0, amount, 0)
+	case "year":
+		date = date.AddDate(amount, 0, 0)
+	}
+	return formulaDateValue(date.UnixMilli(), args[0].dateTime || unit == "minute" || unit == "hour"), nil
+}
+
+// FormulaPropDefs returns the formula properties of a schema.
+func (ps PropSchema) FormulaPropDefs() []PropDef {
+	var formulas []PropDef
+	for _, pd := range ps {
+		if pd.Type == PropTypeFormula {
+			formulas = append(formulas, pd)
+		}
+	}
+	return formulas
+}
+
+// ComputeFormulas evaluates the formula properties of a card and stores their
+// results in the card's properties as text. The properties of formulas that
+// fail or have an empty result are unset. It returns true if a value changed.
+func ComputeFormulas(card *Card, schema PropSchema) bool {
+	formulas := schema.FormulaPropDefs()
+	if len(formulas) == 0 {
+		return false
+	}
+	if card.Properties == nil {
+		card.Properties = map[string]any{}
+	}
+
+	ctx := newFormulaContext(card, schema, utils.GetMillis())
+	changed := false
+	for _, def := range formulas {
+		current, hasCurrent := card.Properties[def.ID]
+		value, err := ctx.formulaResult(def)
+		if err != nil || value.kind == formulaEmpty {
+			if hasCurrent {
+				delete(card.Properties, def.ID)
+				changed = true
+			}
+			continue
+		}
+		if text := value.String(); !hasCurrent || current != text {
+			card.Properties[def.ID] = text
+			changed = true
+		}
+	}
+	return changed
+}
+
+// ComputeBlockFormulas evaluates the formula properties of a card block and
+// stores their results in its properties. It returns true if a value changed.
+func ComputeBlockFormulas(block *Block, schema PropSchema) bool {
+	if block.Type != TypeCard || len(schema.FormulaPropDefs()) == 0 {
+		return false
+	}
+	card, err := Block2Card(block)
+	if err != nil || !ComputeFormulas(card, schema) {
+		return false
+	}
+	if block.Fields == nil {
+		block.Fields = map[string]any{}
+	}
+	block.Fields["properties"] = card.Properties
+	return true
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func formulaTestSchema(t *testing.T, formulas map[string]string) PropSchema {
+	properties := []map[string]interface{}{
+		{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+			map[string]interface{}{"id": "todo", "value": "To Do"},
+			map[string]interface{}{"id": "done", "value": "Done"},
+		}},
+		{"id": "estimate", "name": "Estimate", "type": "number"},
+		{"id": "spent", "name": "Spent", "type": "number"},
+		{"id": "owner", "name": "Owner", "type": "text"},
+		{"id": "start", "name": "Start", "type": "date"},
+		{"id": "due", "name": "Due", "type": "date"},
+		{"id": "urgent", "name": "Urgent", "type": "checkbox"},
+	}
+	for id, formula := range formulas {
+		properties = append(properties, map[string]interface{}{"id": id, "name": id, "type": "formula", "formula": formula})
+	}
+	schema, err := ParsePropertySchema(&Board{CardProperties: properties})
+	require.NoError(t, err)
+	return schema
+}
+
+func formulaTestCard() *Card {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	due := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC).UnixMilli()
+	return &Card{
+		Title:    "Write the docs",
+		CreateAt: time.Date(2026, 2, 27, 9, 30, 0, 0, time.UTC).UnixMilli(),
+		Properties: map[string]any{
+			"status":   "todo",
+			"estimate": "8",
+			"spent":    "5.5",
+			"owner":    "alice",
+			"start":    `{"from":` + formatMillis(start) + `}`,
+			"due":      `{"from":` + formatMillis(due) + `}`,
+			"urgent":   "true",
+		},
+	}
+}
+
+func formatMillis(millis int64) string {
+	return FormatNumberValue(float64(millis))
+}
+
+func TestComputeFormulas(t *testing.T) {
+	testCases := []struct {
+		name     string
+		formula  string
+		expected any
+	}{
+		{"arithmetic", `prop("Estimate") - prop("Spent") * 2`, "-3"},
+		{"precedence", `(prop("Estimate") - prop("Spent")) * 2`, "5"},
+		{"decimal noise", `0.1 + 0.2`, "0.3"},
+		{"modulo", `7 % 3`, "1"},
+		{"concat with +", `prop("Owner") + " / " + prop("Status")`, "alice / To Do"},
+		{"concat", `concat(prop("Title"), " (", prop("Estimate"), "h)")`, "Write the docs (8h)"},
+		{"conditional", `if(prop("Spent") > prop("Estimate"), "over", "under")`, "under"},
+		{"logical", `prop("Urgent") && not (prop("Status") == "Done")`, "true"},
+		{"date difference", `dateBetween(prop("Due"), prop("Start"), "days")`, "14"},
+		{"negative date difference", `dateBetween(prop("Start"), prop("Due"), "weeks")`, "-2"},
+		{"months", `dateBetween(dateAdd(prop("Start"), 3, "months"), prop("Start"), "months")`, "3"},
+		{"date result", `dateAdd(prop("Due"), 1, "week")`, "2026-03-22"},
+		{"created time", `prop("Created")`, "2026-02-27T09:30:00Z"},
+		{"functions", `round(max(prop("Spent"), 2.25) / 3, 2)`, "1.83"},
+		{"empty arithmetic", `prop("Missing number") + 1`, nil},
+		{"empty check", `if(empty(prop("Missing number")), 0, 1)`, "0"},
+		{"case insensitive names", `upper(prop("owner"))`, "ALICE"},
+		{"other formula", `prop("double") + 1`, "17"},
+		{"division by zero", `prop("Estimate") / 0`, nil},
+		{"unknown property", `prop("Nope")`, nil},
+		{"type error", `prop("Owner") * 2`, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schema := formulaTestSchema(t, map[string]string{"result": tc.formula, "double": `prop("Estimate") * 2`})
+			// the properties below are only known to some of the formulas
+			schema["created"] = PropDef{ID: "created", Index: 100, Name: "Created", Type: PropTypeCreatedTime}
+			schema["missing"] = PropDef{ID: "missing", Index: 101, Name: "Missing number", Type: PropTypeNumber}
+
+			card := formulaTestCard()
+			card.Properties["result"] = "stale"
+			ComputeFormulas(card, schema)
+
+			value, ok := card.Properties["result"]
+			if tc.expected == nil {
+				assert.False(t, ok, "unexpected value %v", value)
+				return
+			}
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestComputeFormulasChanges(t *testing.T) {
+	schema := formulaTestSchema(t, map[string]string{"remaining": `prop("Estimate") - prop("Spent")`})
+	card := formulaTestCard()
+
+	assert.True(t, ComputeFormulas(card, schema))
+	assert.Equal(t, "2.5", card.Properties["remaining"])
+	assert.False(t, ComputeFormulas(card, schema))
+
+	card.Properties["spent"] = "6"
+	assert.True(t, ComputeFormulas(card, schema))
+	assert.Equal(t, "2", card.Properties["remaining"])
+}
+
+func TestComputeFormulasCycle(t *testing.T) {
+	schema := formulaTestSchema(t, map[string]string{
+		"a": `prop("b") + 1`,
+		"b": `prop("a") + 1`,
+		"c": `prop("Estimate") + 1`,
+	})
+	card := formulaTestCard()
+
+	ComputeFormulas(card, schema)
+	assert.NotContains(t, card.Properties, "a")
+	assert.NotContains(t, card.Properties, "b")
+	assert.Equal(t, "9", card.Properties["c"])
+}
+
+func TestComputeBlockFormulas(t *testing.T) {
+	schema := formulaTestSchema(t, map[string]string{"remaining": `prop("Estimate") - prop("Spent")`})
+	block := &Block{
+		Type:   TypeCard,
+		Fields: map[string]interface{}{"properties": map[string]interface{}{"estimate": "3", "spent": "1"}},
+	}
+
+	assert.True(t, ComputeBlockFormulas(block, schema))
+	assert.Equal(t, "2", block.Fields["properties"].(map[string]any)["remaining"])
+
+	block.Type = TypeView
+	assert.False(t, ComputeBlockFormulas(block, schema))
+}
+
+func TestParseFormula(t *testing.T) {
+	valid := []string{
+		`1 + 2 * 3`,
+		`if(prop("Done"), "yes", "no")`,
+		`IF(prop("A") >= 2 AND prop("B") != "x", 1, -1)`,
+		`concat("a \"quoted\" word")`,
+		`now()`,
+	}
+	for _, formula := range valid {
+		_, err := ParseFormula(formula)
+		assert.NoError(t, err, formula)
+	}
+
+	invalid := []string{
+		``,
+		`1 +`,
+		`(1 + 2`,
+		`"unterminated`,
+		`prop(Estimate)`,
+		`unknown(1)`,
+		`if(1, 2)`,
+		`1 = 2`,
+		`1 2`,
+	}
+	for _, formula := range invalid {
+		_, err := ParseFormula(formula)
+		assert.ErrorIs(t, err, ErrInvalidFormula, formula)
+	}
+}
+
+func TestSortCardsByFormula(t *testing.T) {
+	schema := formulaTestSchema(t, map[string]string{"points": `prop("Estimate")`})
+	cards := []*Card{
+		{ID: "a", Title: "a", Properties: map[string]any{"points": "10"}},
+		{ID: "b", Title: "b", Properties: map[string]any{"points": "9"}},
+		{ID: "c", Title: "c", Properties: map[string]any{}},
+	}
+
+	SortCards(cards, &ViewFields{SortOptions: []SortOption{{PropertyID: "points"}}}, schema, nil, nil)
+	assert.Equal(t, "b", cards[0].ID)
+	assert.Equal(t, "a", cards[1].ID)
+	assert.Equal(t, "c", cards[2].ID)
+}
+
+func TestBoardPatchFormulaValidation(t *testing.T) {
+	patch := &BoardPatch{UpdatedCardProperties: []map[string]interface{}{
+		{"id": "f", "name": "F", "type": "formula", "formula": `prop("Estimate") *`},
+	}}
+	assert.Error(t, patch.IsValid())
+
+	patch.UpdatedCardProperties[0]["formula"] = `prop("Estimate") * 2`
+	assert.NoError(t, patch.IsValid())
+}
@@ -0,0 +1,370 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+const (
+	GitHubIssueStateOpen   = "open"
+	GitHubIssueStateClosed = "closed"
+
+	maxGitHubIssueSyncErrorLength = 500
+)
+
+// GitHubLabelMapping maps a GitHub label to an option of a select property.
+// swagger:model
+type GitHubLabelMapping struct {
+	// The name of the GitHub label
+	// required: true
+	Label string `json:"label"`
+
+	// The id of the option of the label property
+	// required: true
+	OptionID string `json:"optionId"`
+}
+
+// GitHubUserMapping maps a GitHub user to a Mattermost user.
+// swagger:model
+type GitHubUserMapping struct {
+	// The login of the GitHub user
+	// required: true
+	GitHubLogin string `json:"githubLogin"`
+
+	// The id of the Mattermost user
+	// required: true
+	UserID string `json:"userId"`
+}
+
+// GitHubIssueSync synchronizes the cards of a board with the issues of a
+// GitHub repository, both ways. New issues become cards, and the title,
+// description, status, label and assignee of a card and its issue are kept
+// equal. When both changed since the last synchronization, the last change
+// wins.
+// swagger:model
+type GitHubIssueSync struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The owner of the repository
+	// required: true
+	Owner string `json:"owner"`
+
+	// The name of the repository
+	// required: true
+	Repo string `json:"repo"`
+
+	// True if the synchronization runs
+	// required: false
+	Enabled bool `json:"enabled"`
+
+	// The option of the status property of the cards of open issues. Cards
+	// moved to another status than the closed one reopen their issue.
+	// required: false
+	OpenStatusOptionID string `json:"openStatusOptionId"`
+
+	// The option of the status property of the cards of closed issues
+	// required: false
+	ClosedStatusOptionID string `json:"closedStatusOptionId"`
+
+	// The select property mapped to the labels of the issues
+	// required: false
+	LabelPropertyID string `json:"labelPropertyId"`
+
+	// The labels mapped to the options of the label property
+	// required: false
+	LabelMappings []GitHubLabelMapping `json:"labelMappings"`
+
+	// The person property mapped to the assignee of the issues
+	// required: false
+	AssigneePropertyID string `json:"assigneePropertyId"`
+
+	// The GitHub users mapped to Mattermost users
+	// required: false
+	UserMappings []GitHubUserMapping `json:"userMappings"`
+
+	// The update time of the last issue fetched from GitHub in milliseconds
+	// since the current epoch, or zero
+	// required: false
+	IssuesSyncedAt int64 `json:"issuesSyncedAt"`
+
+	// The time of the last successful synchronization in milliseconds since
+	// the current epoch, or zero
+	// required: false
+	SyncedAt int64 `json:"syncedAt"`
+
+	// The error of the last synchronization attempt, empty if it succeeded
+	// required: false
+	SyncError string `json:"syncError,omitempty"`
+
+	// The time of the last synchronization attempt in milliseconds since the
+	// current epoch, or zero
+	// required: false
+	SyncAttemptAt int64 `json:"syncAttemptAt"`
+
+	// The number of conflicts resolved by the synchronization
+	// required: false
+	ConflictCount int64 `json:"conflictCount"`
+
+	// The time of the last conflict in milliseconds since the current epoch,
+	// or zero
+	// required: false
+	LastConflictAt int64 `json:"lastConflictAt"`
+
+	// The id of the user who set up the synchronization. Their GitHub
+	// account is used to synchronize.
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// GitHubIssueSyncStatus is the state of the synchronization of a board with
+// GitHub issues.
+// swagger:model
+type GitHubIssueSyncStatus struct {
+	// The synchronization
+	// required: true
+	Sync *GitHubIssueSync `json:"sync"`
+
+	// The number of cards synchronized with an issue
+	// required: true
+	ItemCount int `json:"itemCount"`
+}
+
+// GitHubIssueSyncItem is a card synchronized with a GitHub issue, along with
+// the update times of both as of their last synchronization.
+type GitHubIssueSyncItem struct {
+	CardID  string `json:"cardId"`
+	BoardID string `json:"boardId"`
+	Number  int    `json:"number"`
+
+	// DescriptionBlockID is the text block of the card holding the body of
+	// the issue.
+	DescriptionBlockID string `json:"descriptionBlockId"`
+
+	CardUpdateAt  int64 `json:"cardUpdateAt"`
+	IssueUpdateAt int64 `json:"issueUpdateAt"`
+	SyncedAt      int64 `json:"syncedAt"`
+}
+
+// Populate populates a GitHubIssueSync with default values.
+func (s *GitHubIssueSync) Populate() {
+	if s.LabelMappings == nil {
+		s.LabelMappings = []GitHubLabelMapping{}
+	}
+	if s.UserMappings == nil {
+		s.UserMappings = []GitHubUserMapping{}
+	}
+	now := utils.GetMillis()
+	if s.CreateAt == 0 {
+		s.CreateAt = now
+	}
+	if s.UpdateAt == 0 {
+		s.UpdateAt = now
+	}
+}
+
+// IsValid validates the synchronization, without its board.
+func (s *GitHubIssueSync) IsValid() error {
+	if s.BoardID == "" {
+		return NewErrBadRequest("github issue sync board id cannot be empty")
+	}
+	if s.Owner == "" || s.Repo == "" || strings.ContainsRune(s.Owner, '/') || strings.ContainsRune(s.Repo, '/') {
+		return NewErrBadRequest("github issue sync needs a repository owner and name")
+	}
+	if s.CreatedBy == "" {
+		return NewErrBadRequest("github issue sync creator cannot be empty")
+	}
+	return nil
+}
+
+// SetSyncError records a failed synchronization attempt.
+func (s *GitHubIssueSync) SetSyncError(err error, now int64) {
+	msg := err.Error()
+	if len(msg) > maxGitHubIssueSyncErrorLength {
+		msg = msg[:maxGitHubIssueSyncErrorLength]
+	}
+	s.SyncError = msg
+	s.SyncAttemptAt = now
+}
+
+// ValidateGitHubIssueSync checks that the properties, options and mappings
+// of a synchronization exist on its board and map one to one.
+func ValidateGitHubIssueSync(board *Board, sync *GitHubIssueSync) error {
+	if err := sync.IsValid(); err != nil {
+		return err
+	}
+	if sync.BoardID != board.ID {
+		return NewErrBadRequest("github issue sync board ID does not match URL board ID")
+	}
+
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+
+	if sync.OpenStatusOptionID != "" || sync.ClosedStatusOptionID != "" {
+		statusProp, ok := schema.WorkflowPropDef(board)
+		if !ok {
+			return NewErrBadRequest("the board has no status property")
+		}
+		for _, optionID := range []string{sync.OpenStatusOptionID, sync.ClosedStatusOptionID} {
+			if _, ok := statusProp.Options[optionID]; optionID != "" && !ok {
+				return NewErrBadRequest(fmt.Sprintf("status %q does not exist", optionID))
+			}
+		}
+		if sync.OpenStatusOptionID == sync.ClosedStatusOptionID {
+			return NewErrBadRequest("open and closed issues need different statuses")
+		}
+	}
+
+	if sync.LabelPropertyID != "" {
+		labelProp, ok := schema[sync.LabelPropertyID]
+		if !ok || labelProp.Type != PropTypeSelect {
+			return NewErrBadRequest("the label property must be a select property of the board")
+		}
+		labels := map[string]bool{}
+		options := map[string]bool{}
+		for _, m := range sync.LabelMappings {
+			label := strings.ToLower(m.Label)
+			if label == "" || labels[label] || options[m.OptionID] {
+				return NewErrBadRequest(fmt.Sprintf("invalid or duplicate mapping of label %q", m.Label))
+			}
+			if _, ok := labelProp.Options[m.OptionID]; !ok {
+				return NewErrBadRequest(fmt.Sprintf("option %q does not exist", m.OptionID))
+			}
+			labels[label] = true
+			options[m.OptionID] = true
+		}
+	} else if len(sync.LabelMappings) > 0 {
+		return NewErrBadRequest("label mappings need a label property")
+	}
+
+	if sync.AssigneePropertyID != "" {
+		assigneeProp, ok := schema[sync.AssigneePropertyID]
+		if !ok || assigneeProp.Type != PropTypePerson {
+			return NewErrBadRequest("the assignee property must be a person property of the board")
+		}
+	}
+	logins := map[string]bool{}
+	users := map[string]bool{}
+	for _, m := range sync.UserMappings {
+		login := strings.ToLower(m.GitHubLogin)
+		if login == "" || m.UserID == "" || logins[login] || users[m.UserID] {
+			return NewErrBadRequest(fmt.Sprintf("invalid or duplicate mapping of GitHub user %q", m.GitHubLogin))
+		}
+		logins[login] = true
+		users[m.UserID] = true
+	}
+	return nil
+}
+
+// IssueState returns the state of the issue of a card in a status: closed
+// in the closed status, open otherwise. Without statuses to map it returns
+// false.
+func (s *GitHubIssueSync) IssueState(statusOptionID string) (string, bool) {
+	if s.ClosedStatusOptionID == "" {
+		return "", false
+	}
+	if statusOptionID == s.ClosedStatusOptionID {
+		return GitHubIssueStateClosed, true
+	}
+	return GitHubIssueStateOpen, true
+}
+
+// CardStatus returns the status of the card of an issue in a state, if it
+// has to change from its current status.
+func (s *GitHubIssueSync) CardStatus(state string, currentOptionID string) (string, bool) {
+	switch state {
+	case GitHubIssueStateClosed:
+		if s.ClosedStatusOptionID != "" && currentOptionID != s.ClosedStatusOptionID {
+			return s.ClosedStatusOptionID, true
+		}
+	case GitHubIssueStateOpen:
+		if s.OpenStatusOptionID != "" && (currentOptionID == "" || currentOptionID == s.ClosedStatusOptionID) {
+			return s.OpenStatusOptionID, true
+		}
+	}
+	return "", false
+}
+
+// IssueLabels returns the labels of an issue whose card has a label option:
+// its current labels that are not mapped, plus the label of the option.
+func (s *GitHubIssueSync) IssueLabels(current []string, optionID string) []string {
+	labels := []string{}
+	for _, label := range current {
+		if _, mapped := s.labelOption(label); !mapped {
+			labels = append(labels, label)
+		}
+	}
+	for _, m := range s.LabelMappings {
+		if m.OptionID == optionID {
+			labels = append(labels, m.Label)
+			break
+		}
+	}
+	return labels
+}
+
+// CardLabelOption returns the label option of the card of an issue with
+// labels: the option of its first mapped label, or none.
+func (s *GitHubIssueSync) CardLabelOption(labels []string) string {
+	for _, label := range labels {
+		if optionID, ok := s.labelOption(label); ok {
+			return optionID
+		}
+	}
+	return ""
+}
+
+func (s *GitHubIssueSync) labelOption(label string) (string, bool) {
+	for _, m := range s.LabelMappings {
+		if strings.EqualFold(m.Label, label) {
+			return m.OptionID, true
+		}
+	}
+	return "", false
+}
+
+// IssueAssignees returns the assignees of an issue whose card is assigned
+// to a user, and false if the user is not mapped to a GitHub user.
+func (s *GitHubIssueSync) IssueAssignees(userID string) ([]string, bool) {
+	if userID == "" {
+		return []string{}, true
+	}
+	for _, m := range s.UserMappings {
+		if m.UserID == userID {
+			return []string{m.GitHubLogin}, true
+		}
+	}
+	return nil, false
+}
+
+// CardAssignee returns the assignee of the card of an issue with assignees:
+// the user of its first mapped assignee, and false if none is mapped.
+func (s *GitHubIssueSync) CardAssignee(logins []string) (string, bool) {
+	if len(logins) == 0 {
+		return "", true
+	}
+	for _, login := range logins {
+		for _, m := range s.UserMappings {
+			if strings.EqualFold(m.GitHubLogin, login) {
+				return m.UserID, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func githubIssueSyncTestBoard() *Board {
+	return &Board{
+		ID: "board-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To Do"},
+				map[string]interface{}{"id": "doing", "value": "Doing"},
+				map[string]interface{}{"id": "done", "value": "Done"},
+			}},
+			{"id": "kind", "name": "Kind", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "bug", "value": "Bug"},
+				map[string]interface{}{"id": "feature", "value": "Feature"},
+			}},
+			{"id": "owner", "name": "Owner", "type": "person"},
+		},
+	}
+}
+
+func TestValidateGitHubIssueSync(t *testing.T) {
+	newSync := func() *GitHubIssueSync {
+		return &GitHubIssueSync{
+			BoardID:              "board-id",
+			Owner:                "owner",
+			Repo:                 "repo",
+			OpenStatusOptionID:   "todo",
+			ClosedStatusOptionID: "done",
+			LabelPropertyID:      "kind",
+			LabelMappings:        []GitHubLabelMapping{{Label: "bug", OptionID: "bug"}},
+			AssigneePropertyID:   "owner",
+			UserMappings:         []GitHubUserMapping{{GitHubLogin: "octocat", UserID: "user-id"}},
+			CreatedBy:            "user-id",
+		}
+	}
+
+	require.NoError(t, ValidateGitHubIssueSync(githubIssueSyncTestBoard(), newSync()))
+
+	testCases := []struct {
+		name   string
+		modify func(*GitHubIssueSync)
+	}{
+		{"no repository", func(s *GitHubIssueSync) { s.Repo = "" }},
+		{"other board", func(s *GitHubIssueSync) { s.BoardID = "other-board-id" }},
+		{"unknown status", func(s *GitHubIssueSync) { s.ClosedStatusOptionID = "missing" }},
+		{"same statuses", func(s *GitHubIssueSync) { s.ClosedStatusOptionID = "todo" }},
+		{"label property is not a select", func(s *GitHubIssueSync) { s.LabelPropertyID = "owner" }},
+		{"unknown label option", func(s *GitHubIssueSync) { s.LabelMappings[0].OptionID = "missing" }},
+		{"duplicate label", func(s *GitHubIssueSync) {
+			s.LabelMappings = append(s.LabelMappings, GitHubLabelMapping{Label: "BUG", OptionID: "feature"})
+		}},
+		{"label mappings without a property", func(s *GitHubIssueSync) { s.LabelPropertyID = "" }},
+		{"assignee property is not a person", func(s *GitHubIssueSync) { s.AssigneePropertyID = "kind" }},
+		{"duplicate user", func(s *GitHubIssueSync) {
+			s.UserMappings = append(s.UserMappings, GitHubUserMapping{GitHubLogin: "hubot", UserID: "user-id"})
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sync := newSync()
+			tc.modify(sync)
+			assert.Error(t, ValidateGitHubIssueSync(githubIssueSyncTestBoard(), sync))
+		})
+	}
+}
+
+func TestGitHubIssueSyncMappings(t *testing.T) {
+	sync := &GitHubIssueSync{
+		OpenStatusOptionID:   "todo",
+		ClosedStatusOptionID: "done",
+		LabelMappings:        []GitHubLabelMapping{{Label: "bug", OptionID: "bug"}, {Label: "enhancement", OptionID: "feature"}},
+		UserMappings:         []GitHubUserMapping{{GitHubLogin: "octocat", UserID: "user-id"}},
+	}
+
+	t.Run("issue state", func(t *testing.T) {
+		state, ok := sync.IssueState("done")
+		assert.True(t, ok)
+		assert.Equal(t, GitHubIssueStateClosed, state)
+
+		state, ok = sync.IssueState("doing")
+		assert.True(t, ok)
+		assert.Equal(t, GitHubIssueStateOpen, state)
+
+		_, ok = (&GitHubIssueSync{}).IssueState("done")
+		assert.False(t, ok)
+	})
+
+	t.Run("card status", func(t *testing.T) {
+		status, ok := sync.CardStatus(GitHubIssueStateClosed, "doing")
+		assert.True(t, ok)
+		assert.Equal(t, "done", status)
+
+		status, ok = sync.CardStatus(GitHubIssueStateOpen, "done")
+		assert.True(t, ok)
+		assert.Equal(t, "todo", status)
+
+		// an open issue keeps the card in the status it was moved to.
+		_, ok = sync.CardStatus(GitHubIssueStateOpen, "doing")
+		assert.False(t, ok)
+
+		_, ok = sync.CardStatus(GitHubIssueStateClosed, "done")
+		assert.False(t, ok)
+	})
+
+	t.Run("labels", func(t *testing.T) {
+		assert.Equal(t, []string{"good first issue", "enhancement"}, sync.IssueLabels([]string{"Bug", "good first issue"}, "feature"))
+		assert.Equal(t, []string{"good first issue"}, sync.IssueLabels([]string{"bug", "good first issue"}, ""))
+		assert.Equal(t, "bug", sync.CardLabelOption([]string{"good first issue", "BUG"}))
+		assert.Equal(t, "", sync.CardLabelOption([]string{"good first issue"}))
+	})
+
+	t.Run("assignees", func(t *testing.T) {
+		assignees, ok := sync.IssueAssignees("user-id")
+		assert.True(t, ok)
+		assert.Equal(t, []string{"octocat"}, assignees)
+
+		assignees, ok = sync.IssueAssignees("")
+		assert.True(t, ok)
+		assert.Empty(t, assignees)
+
+		_, ok = sync.IssueAssignees("other-user-id")
+		assert.False(t, ok)
+
+		userID, ok := sync.CardAssignee([]string{"hubot", "OctoCat"})
+		assert.True(t, ok)
+		assert.Equal(t, "user-id", userID)
+
+		_, ok = sync.CardAssignee([]string{"hubot"})
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+// GitHubEvent is a step of the GitHub lifecycle of a card that can move it
+// to another status.
+type GitHubEvent string
+
+const (
+	GitHubEventBranchCreated GitHubEvent = "branch_created"
+	GitHubEventPROpened      GitHubEvent = "pr_opened"
+	GitHubEventPRMerged      GitHubEvent = "pr_merged"
+	GitHubEventPRClosed      GitHubEvent = "pr_closed"
+)
+
+// IsValid returns true if the event is known.
+func (e GitHubEvent) IsValid() bool {
+	switch e {
+	case GitHubEventBranchCreated, GitHubEventPROpened, GitHubEventPRMerged, GitHubEventPRClosed:
+		return true
+	}
+	return false
+}
+
+// GitHubStatusRule moves the cards of a board to a status when a GitHub
+// event happens to them, such as the merge of a linked pull request.
+// swagger:model
+type GitHubStatusRule struct {
+	// The id of the rule
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board the rule belongs to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The event: branch_created, pr_opened, pr_merged or pr_closed
+	// required: true
+	Event GitHubEvent `json:"event"`
+
+	// The option of the status property the cards are moved to
+	// required: true
+	StatusOptionID string `json:"statusOptionId"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// Populate populates a GitHubStatusRule with default values.
+func (r *GitHubStatusRule) Populate() {
+	if r.ID == "" {
+		r.ID = utils.NewID(utils.IDTypeNone)
+	}
+	now := utils.GetMillis()
+	if r.CreateAt == 0 {
+		r.CreateAt = now
+	}
+	if r.UpdateAt == 0 {
+		r.UpdateAt = now
+	}
+}
+
+// IsValid validates the rule.
+func (r *GitHubStatusRule) IsValid() error {
+	if r.ID == "" {
+		return NewErrBadRequest("github status rule id cannot be empty")
+	}
+	if r.BoardID == "" {
+		return NewErrBadRequest("github status rule board id cannot be empty")
+	}
+	if !r.Event.IsValid() {
+		return NewErrBadRequest(fmt.Sprintf("invalid github event %q", r.Event))
+	}
+	if r.StatusOptionID == "" {
+		return NewErrBadRequest("github status rule status cannot be empty")
+	}
+	return nil
+}
+
+// ValidateGitHubStatusRules checks that the rules of a board have one rule
+// per event, each moving cards to an option of the status property.
+func ValidateGitHubStatusRules(board *Board, rules []*GitHubStatusRule) error {
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return err
+	}
+	statusProp, ok := schema.WorkflowPropDef(board)
+	if !ok {
+		return NewErrBadRequest("the board has no status property")
+	}
+
+	events := map[GitHubEvent]bool{}
+	for _, rule := range rules {
+		if rule == nil {
+			return NewErrBadRequest("invalid github status rule")
+		}
+		if rule.BoardID != board.ID {
+			return NewErrBadRequest("rule board ID does not match URL board ID")
+		}
+		if events[rule.Event] {
+			return NewErrBadRequest(fmt.Sprintf("there is more than one rule for %s", rule.Event))
+		}
+		events[rule.Event] = true
+		if _, ok := statusProp.Options[rule.StatusOptionID]; !ok {
+			return NewErrBadRequest(fmt.Sprintf("status %q does not exist", rule.StatusOptionID))
+		}
+	}
+	return nil
+}
+
+// PullRequestEvent returns the event of a pull request link whose state
+// changed since its previous synchronization, if any.
+func (l *CardGitHubLink) PullRequestEvent(previous *CardGitHubLink) (GitHubEvent, bool) {
+	if l.Kind != CardGitHubLinkKindPullRequest || l.SyncError != "" {
+		return "", false
+	}
+	switch {
+	case l.Merged && !previous.Merged:
+		return GitHubEventPRMerged, true
+	case l.State == "closed" && !l.Merged && previous.State != "closed":
+		return GitHubEventPRClosed, true
+	case l.State == "open" && previous.State != "open":
+		return GitHubEventPROpened, true
+	}
+	return "", false
+}
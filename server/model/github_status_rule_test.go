@@ -0,0 +1,40 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullRequestEvent(t *testing.T) {
+	pr := func(state string, merged bool) *CardGitHubLink {
+		return &CardGitHubLink{Kind: CardGitHubLinkKindPullRequest, State: state, Merged: merged}
+	}
+
+	testCases := []struct {
+		name     string
+		previous *CardGitHubLink
+		current  *CardGitHubLink
+		event    GitHubEvent
+	}{
+		{"first sync of an open pull request", &CardGitHubLink{}, pr("open", false), GitHubEventPROpened},
+		{"merged", pr("open", false), pr("closed", true), GitHubEventPRMerged},
+		{"closed without merge", pr("open", false), pr("closed", false), GitHubEventPRClosed},
+		{"reopened", pr("closed", false), pr("open", false), GitHubEventPROpened},
+		{"unchanged", pr("open", false), pr("open", false), ""},
+		{"still merged", pr("closed", true), pr("closed", true), ""},
+		{"issue", &CardGitHubLink{}, &CardGitHubLink{Kind: CardGitHubLinkKindIssue, State: "open"}, ""},
+		{"sync error", pr("open", false), &CardGitHubLink{Kind: CardGitHubLinkKindPullRequest, State: "open", SyncError: "down"}, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event, ok := tc.current.PullRequestEvent(tc.previous)
+			assert.Equal(t, tc.event, event)
+			assert.Equal(t, tc.event != "", ok)
+		})
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+var (
+	ErrInvalidIncomingWebhook        = errors.New("invalid incoming webhook")
+	ErrInvalidIncomingWebhookPayload = errors.New("invalid incoming webhook payload")
+)
+
+// Targets of an incoming webhook field mapping that are not card properties.
+const (
+	// IncomingWebhookTargetTitle maps a payload field to the card title.
+	IncomingWebhookTargetTitle = "title"
+	// IncomingWebhookTargetCode maps a payload field to the code of the card
+	// to update. Payloads without a code create a new card.
+	IncomingWebhookTargetCode = "code"
+)
+
+const maxIncomingWebhookTitleLength = 255
+
+// IncomingWebhook is a token that lets external systems create and update
+// the cards of a board without a session.
+// swagger:model
+type IncomingWebhook struct {
+	// The id of the incoming webhook
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board the cards are created in
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The token used in the webhook URL
+	// required: true
+	Token string `json:"token"`
+
+	// A name to recognize the webhook
+	// required: false
+	Title string `json:"title"`
+
+	// Maps payload fields, as dot separated paths, to "title", "code", or a
+	// card property name or id. When empty, the payload fields are used as is
+	// required: true
+	FieldMapping map[string]string `json:"fieldMapping"`
+
+	// Whether the webhook accepts payloads
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// The id of the user who created the webhook. Cards are created and
+	// modified on behalf of this user
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// Populate populates an IncomingWebhook with default values.
+func (wh *IncomingWebhook) Populate() {
+	if wh.ID == "" {
+		wh.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if wh.Token == "" {
+		wh.Token = utils.NewID(utils.IDTypeToken)
+	}
+	if wh.FieldMapping == nil {
+		wh.FieldMapping = map[string]string{}
+	}
+	if wh.CreateAt == 0 {
+		wh.CreateAt = utils.GetMillis()
+	}
+	wh.UpdateAt = wh.CreateAt
+}
+
+// IsValid validates the incoming webhook.
+func (wh *IncomingWebhook) IsValid() error {
+	if wh.ID == "" {
+		return fmt.Errorf("%w: id cannot be empty", ErrInvalidIncomingWebhook)
+	}
+	if wh.BoardID == "" {
+		return fmt.Errorf("%w: board id cannot be empty", ErrInvalidIncomingWebhook)
+	}
+	if wh.Token == "" {
+		return fmt.Errorf("%w: token cannot be empty", ErrInvalidIncomingWebhook)
+	}
+	if len(wh.Title) > maxIncomingWebhookTitleLength {
+		return fmt.Errorf("%w: title is too long", ErrInvalidIncomingWebhook)
+	}
+	for field, target := range wh.FieldMapping {
+		if strings.TrimSpace(field) == "" || strings.TrimSpace(target) == "" {
+			return fmt.Errorf("%w: field mapping entries cannot be empty", ErrInvalidIncomingWebhook)
+		}
+	}
+	if wh.CreatedBy == "" {
+		return fmt.Errorf("%w: created by cannot be empty", ErrInvalidIncomingWebhook)
+	}
+	return nil
+}
+
+// Patch returns an updated version of the incoming webhook.
+func (wh *IncomingWebhook) Patch(patch *IncomingWebhookPatch) *IncomingWebhook {
+	updated := *wh
+	if patch.Title != nil {
+		updated.Title = *patch.Title
+	}
+	if patch.FieldMapping != nil {
+		updated.FieldMapping = *patch.FieldMapping
+	}
+	if patch.Enabled != nil {
+		updated.Enabled = *patch.Enabled
+	}
+	if patch.RegenerateToken {
+		updated.Token = utils.NewID(utils.IDTypeToken)
+	}
+	updated.UpdateAt = utils.GetMillis()
+	return &updated
+}
+
+// IncomingWebhookPatch is a patch for modifying an incoming webhook.
+// swagger:model
+type IncomingWebhookPatch struct {
+	// A name to recognize the webhook
+	// required: false
+	Title *string `json:"title"`
+
+	// The new field mapping
+	// required: false
+	FieldMapping *map[string]string `json:"fieldMapping"`
+
+	// Whether the webhook accepts payloads
+	// required: false
+	Enabled *bool `json:"enabled"`
+
+	// Replaces the token, invalidating the previous webhook URL
+	// required: false
+	RegenerateToken bool `json:"regenerateToken"`
+}
+
+// IncomingWebhookCard holds the card values extracted from an incoming
+// webhook payload.
+type IncomingWebhookCard struct {
+	// Code identifies the card to update, empty to create a card
+	Code string
+
+	// Title is the card title, nil if the payload does not set it
+	Title *string
+
+	// Properties are the card property values keyed by property id
+	Properties map[string]interface{}
+}
+
+// MapIncomingWebhookPayload translates a payload into card values using a
+// field mapping and the board's property schema. With an empty mapping, the
+// top level payload fields are mapped to the target of the same name.
+func MapIncomingWebhookPayload(payload map[string]interface{}, mapping map[string]string, schema PropSchema, resolveUser CardQueryUserResolver) (*IncomingWebhookCard, error) {
+	if len(mapping) == 0 {
+		mapping = make(map[string]string, len(payload))
+		for field := range payload {
+			mapping[field] = field
+		}
+	}
+
+	card := &IncomingWebhookCard{Properties: map[string]interface{}{}}
+	for field, target := range mapping {
+		value, ok := payloadValue(payload, field)
+		if !ok || value == nil {
+			continue
+		}
+
+		switch strings.ToLower(target) {
+		case IncomingWebhookTargetTitle:
+			title, err := payloadString(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: field %q: %w", ErrInvalidIncomingWebhookPayload, field, err)
+			}
+			card.Title = &title
+			continue
+		case IncomingWebhookTargetCode:
+			code, err := payloadString(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: field %q: %w", ErrInvalidIncomingWebhookPayload, field, err)
+			}
+			card.Code = strings.TrimSpace(code)
+			continue
+		}
+
+		prop, ok := schema[target]
+		if !ok {
+			prop, ok = findPropDefByName(schema, target)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: field %q is mapped to unknown property %q", ErrInvalidIncomingWebhookPayload, field, target)
+		}
+
+		propValue, err := incomingWebhookPropValue(prop, value, resolveUser)
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %q: %w", ErrInvalidIncomingWebhookPayload, field, err)
+		}
+		card.Properties[prop.ID] = propValue
+	}
+	return card, nil
+}
+
+// payloadValue returns the value at a dot separated path of the payload.
+func payloadValue(payload map[string]interface{}, path string) (interface{}, bool) {
+	if value, ok := payload[path]; ok {
+		return value, true
+	}
+
+	var current interface{} = payload
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func payloadString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", fmt.Errorf("expected a string, got %T", value)
+	}
+}
+
+func payloadStrings(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := payloadString(item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, s)
+		}
+		return values, nil
+	case string:
+		values := []string{}
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+		return values, nil
+	default:
+		s, err := payloadString(value)
+		if err != nil {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+}
+
+// incomingWebhookPropValue converts a payload value to the stored value of a
+// card property: option ids for selects, user ids for persons, and the date
+// JSON used by the web app for dates.
+func incomingWebhookPropValue(prop PropDef, value interface{}, resolveUser CardQueryUserResolver) (interface{}, error) {
+	switch prop.Type {
+	case PropTypeSelect:
+		s, err := payloadString(value)
+		if err != nil {
+			return nil, err
+		}
+		return findPropOptionID(prop, s)
+
+	case PropTypeMultiSelect:
+		values, err := payloadStrings(value)
+		if err != nil {
+			return nil, err
+		}
+		optionIDs := make([]interface{}, 0, len(values))
+		for _, s := range values {
+			optionID, err := findPropOptionID(prop, s)
+			if err != nil {
+				return nil, err
+			}
+			optionIDs = append(optionIDs, optionID)
+		}
+		return optionIDs, nil
+
+	case PropTypePerson:
+		s, err := payloadString(value)
+		if err != nil {
+			return nil, err
+		}
+		return resolvePropUser(prop, s, resolveUser)
+
+	case PropTypeMultiPerson:
+		values, err := payloadStrings(value)
+		if err != nil {
+			return nil, err
+		}
+		userIDs := make([]interface{}, 0, len(values))
+		for _, s := range values {
+			userID, err := resolvePropUser(prop, s, resolveUser)
+			if err != nil {
+				return nil, err
+			}
+			userIDs = append(userIDs, userID)
+		}
+		return userIDs, nil
+
+	case PropTypeCheckbox:
+		s, err := payloadString(value)
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(s) {
+		case "true", "yes", "checked", "1":
+			return "true", nil
+		case "false", "no", "unchecked", "0", "":
+			return "", nil
+		}
+		return nil, fmt.Errorf("invalid value %q for checkbox %q", s, prop.Name)
+
+	case PropTypeNumber:
+		s, err := payloadString(value)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, fmt.Errorf("invalid number %q for property %q", s, prop.Name)
+		}
+		return s, nil
+
+	case PropTypeDate:
+		millis, err := payloadMillis(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date for property %q: %w", prop.Name, err)
+		}
+		return fmt.Sprintf(`{"from":%d}`, millis), nil
+
+	case PropTypeText, PropTypeURL, PropTypeEmail, PropTypePhone:
+		return payloadString(value)
+
+	default:
+		return nil, fmt.Errorf("property %q of type %s cannot be set", prop.Name, prop.Type)
+	}
+}
+
+func findPropOptionID(prop PropDef, value string) (string, error) {
+	if option, ok := prop.Options[value]; ok {
+		return option.ID, nil
+	}
+	for _, option := range prop.OrderedOptions() {
+		if strings.EqualFold(option.Value, value) {
+			return option.ID, nil
+		}
+	}
+	return "", fmt.Errorf("unknown option %q for property %q", value, prop.Name)
+}
+
+func resolvePropUser(prop PropDef, value string, resolveUser CardQueryUserResolver) (string, error) {
+	userID, err := resolveUser(value)
+	if err != nil {
+		return "", err
+	}
+	if userID == "" {
+		return "", fmt.Errorf("unknown user %q for property %q", value, prop.Name)
+	}
+	return userID, nil
+}
+
+// payloadMillis reads a date as milliseconds since the epoch, an RFC 3339
+// timestamp or a YYYY-MM-DD day.
+func payloadMillis(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case float64:
+		return int64(v), nil
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return utils.GetMillisForTime(t), nil
+		}
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			return utils.GetMillisForTime(t), nil
+		}
+		if millis, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return millis, nil
+		}
+		return 0, fmt.Errorf("unrecognized date %q", v)
+	default:
+		return 0, fmt.Errorf("expected a date, got %T", value)
+	}
+}
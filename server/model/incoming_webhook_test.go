@@ -0,0 +1,101 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapIncomingWebhookPayload(t *testing.T) {
+	schema := PropSchema{
+		"status": {ID: "status", Name: "Status", Type: PropTypeSelect, Options: map[string]PropDefOption{
+			"opt-open": {ID: "opt-open", Index: 0, Value: "Open"},
+			"opt-done": {ID: "opt-done", Index: 1, Value: "Done"},
+		}},
+		"labels": {ID: "labels", Name: "Labels", Type: PropTypeMultiSelect, Options: map[string]PropDefOption{
+			"opt-bug": {ID: "opt-bug", Index: 0, Value: "bug"},
+			"opt-ui":  {ID: "opt-ui", Index: 1, Value: "ui"},
+		}},
+		"owner":    {ID: "owner", Name: "Owner", Type: PropTypePerson},
+		"points":   {ID: "points", Name: "Points", Type: PropTypeNumber},
+		"urgent":   {ID: "urgent", Name: "Urgent", Type: PropTypeCheckbox},
+		"due":      {ID: "due", Name: "Due", Type: PropTypeDate},
+		"notes":    {ID: "notes", Name: "Notes", Type: PropTypeText},
+		"modified": {ID: "modified", Name: "Modified", Type: PropTypeUpdatedTime},
+	}
+	resolveUser := func(value string) (string, error) {
+		if value == "alice" {
+			return "alice-id", nil
+		}
+		return "", nil
+	}
+
+	t.Run("field mapping", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"issue": map[string]interface{}{
+				"key":     "AB-12",
+				"summary": "Crash on save",
+				"state":   "done",
+				"labels":  []interface{}{"bug", "UI"},
+			},
+			"assignee": "alice",
+			"estimate": float64(3),
+			"critical": true,
+			"due":      "2024-03-01",
+			"ignored":  "value",
+		}
+		mapping := map[string]string{
+			"issue.key":     "code",
+			"issue.summary": "title",
+			"issue.state":   "Status",
+			"issue.labels":  "labels",
+			"assignee":      "owner",
+			"estimate":      "Points",
+			"critical":      "urgent",
+			"due":           "Due",
+			"missing":       "notes",
+		}
+
+		card, err := MapIncomingWebhookPayload(payload, mapping, schema, resolveUser)
+		require.NoError(t, err)
+		assert.Equal(t, "AB-12", card.Code)
+		require.NotNil(t, card.Title)
+		assert.Equal(t, "Crash on save", *card.Title)
+		assert.Equal(t, map[string]interface{}{
+			"status": "opt-done",
+			"labels": []interface{}{"opt-bug", "opt-ui"},
+			"owner":  "alice-id",
+			"points": "3",
+			"urgent": "true",
+			"due":    `{"from":1709251200000}`,
+		}, card.Properties)
+	})
+
+	t.Run("empty mapping uses the payload fields", func(t *testing.T) {
+		card, err := MapIncomingWebhookPayload(map[string]interface{}{"title": "New", "Notes": "some text"}, nil, schema, resolveUser)
+		require.NoError(t, err)
+		assert.Empty(t, card.Code)
+		assert.Equal(t, "New", *card.Title)
+		assert.Equal(t, map[string]interface{}{"notes": "some text"}, card.Properties)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		for name, payload := range map[string]map[string]interface{}{
+			"unknown property": {"Severity": "high"},
+			"unknown option":   {"Status": "Blocked"},
+			"unknown user":     {"Owner": "bob"},
+			"invalid number":   {"Points": "many"},
+			"invalid checkbox": {"Urgent": "maybe"},
+			"invalid date":     {"Due": "tomorrow"},
+			"read only":        {"Modified": "2024-03-01"},
+			"object title":     {"title": map[string]interface{}{}},
+		} {
+			_, err := MapIncomingWebhookPayload(payload, nil, schema, resolveUser)
+			require.ErrorIs(t, err, ErrInvalidIncomingWebhookPayload, name)
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/mattermost/mattermost-plugin-boards/server/utils"
@@ -21,6 +22,42 @@ var ErrInvalidPropertyValue = errors.New("invalid property value")
 var ErrInvalidPropertyValueType = errors.New("invalid property value type")
 var ErrInvalidDate = errors.New("invalid date property")
 
+// Property types as defined in a board's card properties.
+const (
+	PropTypeText        = "text"
+	PropTypeNumber      = "number"
+	PropTypeSelect      = "select"
+	PropTypeMultiSelect = "multiSelect"
+	PropTypeDate        = "date"
+	PropTypePerson      = "person"
+	PropTypeMultiPerson = "multiPerson"
+	PropTypeCheckbox    = "checkbox"
+	PropTypeURL         = "url"
+	PropTypeEmail       = "email"
+	PropTypePhone       = "phone"
+	PropTypeCreatedTime = "createdTime"
+	PropTypeCreatedBy   = "createdBy"
+	PropTypeUpdatedTime = "updatedTime"
+	PropTypeUpdatedBy   = "updatedBy"
+	PropTypeFormula     = "formula"
+)
+
+// Sort rules that can be set on a property definition.
+const (
+	SortRuleDefault  = "default"
+	SortRuleByValue  = "byValue"
+	SortRuleByOrder  = "byOrder"
+	SortRuleAsNumber = "asNumber"
+)
+
+// Categories of the options of a workflow property, telling whether a card in
+// the status is still to do, in progress or done.
+const (
+	PropOptionCategoryToDo       = "todo"
+	PropOptionCategoryInProgress = "inProgress"
+	PropOptionCategoryDone       = "done"
+)
+
 // PropValueResolver allows PropDef.GetValue to further decode property values, such as
 // looking up usernames from ids.
 type PropValueResolver interface {
@@ -43,26 +80,32 @@ type PropSchema map[string]PropDef
 
 // PropDefOption represents an option within a property definition.
 type PropDefOption struct {
-	ID    string `json:"id"`
-	Index int    `json:"index"`
-	Color string `json:"color"`
-	Value string `json:"value"`
+	ID       string `json:"id"`
+	Index    int    `json:"index"`
+	Color    string `json:"color"`
+	Value    string `json:"value"`
+	Category string `json:"category,omitempty"`
 }
 
 // PropDef represents a property definition as defined in a board's Fields member.
 type PropDef struct {
-	ID      string                   `json:"id"`
-	Index   int                      `json:"index"`
-	Name    string                   `json:"name"`
-	Type    string                   `json:"type"`
-	Options map[string]PropDefOption `json:"options"`
+	ID       string                   `json:"id"`
+	Index    int                      `json:"index"`
+	Name     string                   `json:"name"`
+	Type     string                   `json:"type"`
+	SortRule string                   `json:"sortRule,omitempty"`
+	Options  map[string]PropDefOption `json:"options"`
+	Rollup   *PropRollup              `json:"rollup,omitempty"`
+	Formula  string                   `json:"formula,omitempty"`
+
+	formula *Formula
 }
 
 // GetValue resolves the value of a property if the passed value is an ID for an option,
 // otherwise returns the original value.
 func (pd PropDef) GetValue(v interface{}, resolver PropValueResolver) (string, error) {
 	switch pd.Type {
-	case "select":
+	case PropTypeSelect:
 		// v is the id of an option
 		id, ok := v.(string)
 		if !ok {
@@ -74,7 +117,7 @@ func (pd PropDef) GetValue(v interface{}, resolver PropValueResolver) (string, e
 		}
 		return strings.ToUpper(opt.Value), nil
 
-	case "date":
+	case PropTypeDate:
 		// v is a JSON string
 		date, ok := v.(string)
 		if !ok {
@@ -82,7 +125,7 @@ func (pd PropDef) GetValue(v interface{}, resolver PropValueResolver) (string, e
 		}
 		return pd.ParseDate(date)
 
-	case "person":
+	case PropTypePerson:
 		// v is a userid
 		userID, ok := v.(string)
 		if !ok {
@@ -100,7 +143,7 @@ func (pd PropDef) GetValue(v interface{}, resolver PropValueResolver) (string, e
 		}
 		return userID, nil
 
-	case "multiPerson":
+	case PropTypeMultiPerson:
 		// v is a slice of user IDs
 		userIDs, ok := v.([]interface{})
 		if !ok {
@@ -126,7 +169,7 @@ func (pd PropDef) GetValue(v interface{}, resolver PropValueResolver) (string, e
 			return strings.Join(usernames, ", "), nil
 		}
 
-	case "multiSelect":
+	case PropTypeMultiSelect:
 		// v is a slice of strings containing option ids
 		ms, ok := v.([]interface{})
 		if !ok {
@@ -148,10 +191,44 @@ func (pd PropDef) GetValue(v interface{}, resolver PropValueResolver) (string, e
 			sb.WriteString(strings.ToUpper(opt.Value))
 		}
 		return sb.String(), nil
+
+	case PropTypeFormula:
+		// v is the computed value, stored as text
+		return propValueString(v), nil
 	}
 	return fmt.Sprintf("%v", v), nil
 }
 
+// OrderedOptions returns the property options in the order they are defined on the board.
+func (pd PropDef) OrderedOptions() []PropDefOption {
+	options := make([]PropDefOption, 0, len(pd.Options))
+	for _, opt := range pd.Options {
+		options = append(options, opt)
+	}
+	sort.Slice(options, func(i, j int) bool {
+		return options[i].Index < options[j].Index
+	})
+	return options
+}
+
+// IsDoneOption returns true if an option is in the done category. When none of
+// the options has a category, the options named "Done" or "Completed" are.
+func (pd PropDef) IsDoneOption(optionID string) bool {
+	option, ok := pd.Options[optionID]
+	if !ok {
+		return false
+	}
+	if option.Category != "" {
+		return option.Category == PropOptionCategoryDone
+	}
+	for _, opt := range pd.Options {
+		if opt.Category != "" {
+			return false
+		}
+	}
+	return strings.EqualFold(option.Value, "done") || strings.EqualFold(option.Value, "completed")
+}
+
 func (pd PropDef) ParseDate(s string) (string, error) {
 	// s is a JSON snippet of the form: {"from":1642161600000, "to":1642161600000} in milliseconds UTC
 	// The UI does not yet support date ranges.
@@ -180,11 +257,12 @@ func ParsePropertySchema(board *Board) (PropSchema, error) {
 
 	for i, prop := range board.CardProperties {
 		pd := PropDef{
-			ID:      getMapString("id", prop),
-			Index:   i,
-			Name:    getMapString("name", prop),
-			Type:    getMapString("type", prop),
-			Options: make(map[string]PropDefOption),
+			ID:       getMapString("id", prop),
+			Index:    i,
+			Name:     getMapString("name", prop),
+			Type:     getMapString("type", prop),
+			SortRule: getMapString("sortRule", prop),
+			Options:  make(map[string]PropDefOption),
 		}
 		optsIface, ok := prop["options"]
 		if ok {
@@ -198,19 +276,59 @@ func ParsePropertySchema(board *Board) (PropSchema, error) {
 					return nil, ErrInvalidPropSchema
 				}
 				po := PropDefOption{
-					ID:    getMapString("id", propOpt),
-					Index: j,
-					Value: getMapString("value", propOpt),
-					Color: getMapString("color", propOpt),
+					ID:       getMapString("id", propOpt),
+					Index:    j,
+					Value:    getMapString("value", propOpt),
+					Color:    getMapString("color", propOpt),
+					Category: getMapString("category", propOpt),
 				}
 				pd.Options[po.ID] = po
 			}
 		}
+		if rollup, ok := prop["rollup"].(map[string]interface{}); ok {
+			pd.Rollup = &PropRollup{
+				Function:     RollupFunction(getMapString("function", rollup)),
+				PropertyName: getMapString("propertyName", rollup),
+			}
+		}
+		if pd.Type == PropTypeFormula {
+			// a formula that does not parse leaves the property unset
+			pd.Formula = getMapString("formula", prop)
+			pd.formula, _ = ParseFormula(pd.Formula)
+		}
 		schema[pd.ID] = pd
 	}
 	return schema, nil
 }
 
+// StatusPropDef returns the board's status property, which is the first
+// select property named "Status" (case-insensitive).
+func (ps PropSchema) StatusPropDef() (PropDef, bool) {
+	var status PropDef
+	found := false
+	for _, pd := range ps {
+		if pd.Type != PropTypeSelect || !strings.EqualFold(pd.Name, "status") {
+			continue
+		}
+		if !found || pd.Index < status.Index {
+			status = pd
+			found = true
+		}
+	}
+	return status, found
+}
+
+// WorkflowPropDef returns the workflow property of a board: the select
+// property set in the board's properties, or else its status property.
+func (ps PropSchema) WorkflowPropDef(board *Board) (PropDef, bool) {
+	if propID, err := board.GetPropertyString(BoardPropertyWorkflowPropertyID); err == nil && propID != "" {
+		if pd, ok := ps[propID]; ok && pd.Type == PropTypeSelect {
+			return pd, true
+		}
+	}
+	return ps.StatusPropDef()
+}
+
 func getMapString(key string, m map[string]interface{}) string {
 	iface, ok := m[key]
 	if !ok {
@@ -161,3 +161,54 @@ const (
 	   }
 	]`
 )
+
+func Test_StatusPropDef(t *testing.T) {
+	t.Run("first select property named status", func(t *testing.T) {
+		schema := PropSchema{
+			"text":    {ID: "text", Index: 0, Name: "Status", Type: PropTypeText},
+			"second":  {ID: "second", Index: 2, Name: "status", Type: PropTypeSelect},
+			"first":   {ID: "first", Index: 1, Name: "STATUS", Type: PropTypeSelect},
+			"another": {ID: "another", Index: 3, Name: "Priority", Type: PropTypeSelect},
+		}
+
+		prop, ok := schema.StatusPropDef()
+		require.True(t, ok)
+		assert.Equal(t, "first", prop.ID)
+	})
+
+	t.Run("no status property", func(t *testing.T) {
+		schema := PropSchema{
+			"another": {ID: "another", Index: 0, Name: "Priority", Type: PropTypeSelect},
+		}
+
+		_, ok := schema.StatusPropDef()
+		require.False(t, ok)
+	})
+}
+
+func Test_IsDoneOption(t *testing.T) {
+	t.Run("options with categories", func(t *testing.T) {
+		prop := PropDef{Options: map[string]PropDefOption{
+			"todo":    {ID: "todo", Value: "To Do", Category: PropOptionCategoryToDo},
+			"shipped": {ID: "shipped", Value: "Shipped", Category: PropOptionCategoryDone},
+			"done":    {ID: "done", Value: "Done"},
+		}}
+
+		assert.True(t, prop.IsDoneOption("shipped"))
+		assert.False(t, prop.IsDoneOption("todo"))
+		assert.False(t, prop.IsDoneOption("done"), "the names are ignored once categories are set")
+		assert.False(t, prop.IsDoneOption("unknown"))
+	})
+
+	t.Run("options without categories", func(t *testing.T) {
+		prop := PropDef{Options: map[string]PropDefOption{
+			"todo":      {ID: "todo", Value: "To Do"},
+			"done":      {ID: "done", Value: "DONE"},
+			"completed": {ID: "completed", Value: "Completed"},
+		}}
+
+		assert.True(t, prop.IsDoneOption("done"))
+		assert.True(t, prop.IsDoneOption("completed"))
+		assert.False(t, prop.IsDoneOption("todo"))
+	})
+}
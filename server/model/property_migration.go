@@ -0,0 +1,435 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+// PropertyMigration is a change to a card property of a board that also
+// rewrites the values stored in its cards: a change of type, or a merge of
+// options into another option.
+// swagger:model
+type PropertyMigration struct {
+	// The ID of the property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The new type of the property
+	// required: false
+	Type string `json:"type,omitempty"`
+
+	// The IDs of the options to merge into IntoOptionID
+	// required: false
+	MergeOptionIDs []string `json:"mergeOptionIds,omitempty"`
+
+	// The ID of the option that the merged options are merged into
+	// required: false
+	IntoOptionID string `json:"intoOptionId,omitempty"`
+}
+
+// IsValid checks that the migration either changes the type of the property
+// or merges options.
+func (pm *PropertyMigration) IsValid() error {
+	if pm.PropertyID == "" {
+		return NewErrBadRequest("the migration has no property")
+	}
+	if (pm.Type == "") == (len(pm.MergeOptionIDs) == 0) {
+		return NewErrBadRequest("a migration either changes the type of a property or merges options")
+	}
+	if len(pm.MergeOptionIDs) != 0 && pm.IntoOptionID == "" {
+		return NewErrBadRequest("the migration has no option to merge into")
+	}
+	for _, optionID := range pm.MergeOptionIDs {
+		if optionID == pm.IntoOptionID {
+			return NewErrBadRequest(fmt.Sprintf("option %q cannot be merged into itself", optionID))
+		}
+	}
+	return nil
+}
+
+// PropertyMigrationResult is the report of a property migration.
+// swagger:model
+type PropertyMigrationResult struct {
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The ID of the property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// True if the cards were not changed
+	// required: true
+	DryRun bool `json:"dryRun"`
+
+	// The property definition after the migration
+	// required: true
+	Property map[string]interface{} `json:"property"`
+
+	// The number of cards with a value for the property
+	// required: true
+	CardsChecked int `json:"cardsChecked"`
+
+	// The number of cards whose value changes
+	// required: true
+	CardsChanged int `json:"cardsChanged"`
+
+	// The number of values that cannot be converted and are removed
+	// required: true
+	ValuesDropped int `json:"valuesDropped"`
+
+	// The number of options created from the values of the cards
+	// required: true
+	OptionsCreated int `json:"optionsCreated"`
+}
+
+// PropertyMigrationPlan is a property migration computed for the cards of a
+// board, ready to be stored.
+type PropertyMigrationPlan struct {
+	// Property is the property definition after the migration.
+	Property map[string]interface{}
+
+	// CardIDs are the cards whose value changes.
+	CardIDs []string
+
+	// CardProperties are the properties of each card in CardIDs after the
+	// migration.
+	CardProperties []map[string]any
+
+	Result *PropertyMigrationResult
+}
+
+// PlanPropertyMigration computes the new definition of a property and the
+// new values of the cards of the board.
+func PlanPropertyMigration(board *Board, cards []*Card, migration *PropertyMigration) (*PropertyMigrationPlan, error) {
+	if err := migration.IsValid(); err != nil {
+		return nil, err
+	}
+
+	var rawProp map[string]interface{}
+	for _, prop := range board.CardProperties {
+		if getMapString("id", prop) == migration.PropertyID {
+			rawProp = prop
+			break
+		}
+	}
+	if rawProp == nil {
+		return nil, NewErrNotFound("card property " + migration.PropertyID)
+	}
+	schema, err := ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	def := schema[migration.PropertyID]
+
+	m := &propertyMigrator{
+		def:     def,
+		options: rawOptions(rawProp),
+		byValue: map[string]string{},
+	}
+	for _, option := range m.options {
+		opt := option.(map[string]interface{})
+		m.byValue[strings.ToLower(getMapString("value", opt))] = getMapString("id", opt)
+	}
+
+	var convert func(any) (any, bool)
+	newType := def.Type
+	if migration.Type != "" {
+		newType = migration.Type
+		if convert, err = m.converter(newType); err != nil {
+			return nil, err
+		}
+	} else {
+		if convert, err = m.merger(migration.MergeOptionIDs, migration.IntoOptionID); err != nil {
+			return nil, err
+		}
+	}
+
+	plan := &PropertyMigrationPlan{
+		Result: &PropertyMigrationResult{
+			BoardID:    board.ID,
+			PropertyID: migration.PropertyID,
+		},
+	}
+	for _, card := range cards {
+		value, ok := card.Properties[migration.PropertyID]
+		if !ok || value == nil {
+			continue
+		}
+		plan.Result.CardsChecked++
+
+		newValue, kept := convert(value)
+		if kept && reflect.DeepEqual(newValue, value) {
+			continue
+		}
+
+		properties := make(map[string]any, len(card.Properties))
+		for propID, v := range card.Properties {
+			properties[propID] = v
+		}
+		if kept {
+			properties[migration.PropertyID] = newValue
+		} else {
+			delete(properties, migration.PropertyID)
+			plan.Result.ValuesDropped++
+		}
+		plan.CardIDs = append(plan.CardIDs, card.ID)
+		plan.CardProperties = append(plan.CardProperties, properties)
+	}
+	plan.Result.CardsChanged = len(plan.CardIDs)
+	plan.Result.OptionsCreated = m.created
+
+	plan.Property = make(map[string]interface{}, len(rawProp))
+	for key, value := range rawProp {
+		plan.Property[key] = value
+	}
+	plan.Property["type"] = newType
+	if newType == PropTypeSelect || newType == PropTypeMultiSelect {
+		plan.Property["options"] = m.options
+	} else {
+		plan.Property["options"] = []interface{}{}
+	}
+	plan.Result.Property = plan.Property
+
+	return plan, nil
+}
+
+// rawOptions returns a copy of the options of a property definition as
+// stored in a board.
+func rawOptions(prop map[string]interface{}) []interface{} {
+	options := []interface{}{}
+	opts, _ := prop["options"].([]interface{})
+	for _, opt := range opts {
+		if option, ok := opt.(map[string]interface{}); ok {
+			options = append(options, option)
+		}
+	}
+	return options
+}
+
+// propertyMigrator converts the values of a property, creating the options
+// needed along the way.
+type propertyMigrator struct {
+	def     PropDef
+	options []interface{}
+	byValue map[string]string
+	created int
+}
+
+func isTextPropType(propType string) bool {
+	switch propType {
+	case PropTypeText, PropTypeURL, PropTypeEmail, PropTypePhone:
+		return true
+	}
+	return false
+}
+
+// converter returns the function converting a value of the property to the
+// new type, which reports false if the value cannot be converted.
+func (m *propertyMigrator) converter(newType string) (func(any) (any, bool), error) {
+	oldType := m.def.Type
+	switch {
+	case oldType == newType:
+		return nil, NewErrBadRequest(fmt.Sprintf("the property already has type %q", newType))
+
+	case isTextPropType(oldType) && isTextPropType(newType):
+		return m.toText, nil
+
+	case isTextPropType(oldType) && newType == PropTypeSelect:
+		return m.textToOption, nil
+
+	case isTextPropType(oldType) && newType == PropTypeMultiSelect:
+		return func(value any) (any, bool) {
+			optionID, ok := m.textToOption(value)
+			if !ok {
+				return nil, false
+			}
+			return []any{optionID}, true
+		}, nil
+
+	case isTextPropType(oldType) && newType == PropTypeNumber:
+		return func(value any) (any, bool) {
+			number, ok := ParseNumberValue(value)
+			if !ok {
+				return nil, false
+			}
+			return FormatNumberValue(number), true
+		}, nil
+
+	case oldType == PropTypeNumber && isTextPropType(newType):
+		return m.toText, nil
+
+	case oldType == PropTypeSelect && newType == PropTypeMultiSelect:
+		return func(value any) (any, bool) {
+			optionID, ok := value.(string)
+			if _, exists := m.def.Options[optionID]; !ok || !exists {
+				return nil, false
+			}
+			return []any{optionID}, true
+		}, nil
+
+	case oldType == PropTypeMultiSelect && newType == PropTypeSelect:
+		return func(value any) (any, bool) {
+			for _, optionID := range stringList(value) {
+				if _, ok := m.def.Options[optionID]; ok {
+					return optionID, true
+				}
+			}
+			return nil, false
+		}, nil
+
+	case (oldType == PropTypeSelect || oldType == PropTypeMultiSelect) && isTextPropType(newType):
+		return func(value any) (any, bool) {
+			var labels []string
+			for _, optionID := range stringList(value) {
+				if option, ok := m.def.Options[optionID]; ok {
+					labels = append(labels, option.Value)
+				}
+			}
+			if len(labels) == 0 {
+				return nil, false
+			}
+			return strings.Join(labels, ", "), true
+		}, nil
+
+	case oldType == PropTypePerson && newType == PropTypeMultiPerson:
+		return func(value any) (any, bool) {
+			userID, ok := value.(string)
+			if !ok || userID == "" {
+				return nil, false
+			}
+			return []any{userID}, true
+		}, nil
+
+	case oldType == PropTypeMultiPerson && newType == PropTypePerson:
+		return func(value any) (any, bool) {
+			userIDs := stringList(value)
+			if len(userIDs) == 0 {
+				return nil, false
+			}
+			return userIDs[0], true
+		}, nil
+	}
+
+	return nil, NewErrBadRequest(fmt.Sprintf("cannot convert a %s property to %s", oldType, newType))
+}
+
+// merger returns the function replacing the merged options of a value by the
+// option they are merged into, and removes the merged options.
+func (m *propertyMigrator) merger(mergeOptionIDs []string, intoOptionID string) (func(any) (any, bool), error) {
+	if m.def.Type != PropTypeSelect && m.def.Type != PropTypeMultiSelect {
+		return nil, NewErrBadRequest(fmt.Sprintf("cannot merge the options of a %s property", m.def.Type))
+	}
+	if _, ok := m.def.Options[intoOptionID]; !ok {
+		return nil, NewErrBadRequest(fmt.Sprintf("option %q does not exist", intoOptionID))
+	}
+	merged := make(map[string]bool, len(mergeOptionIDs))
+	for _, optionID := range mergeOptionIDs {
+		if _, ok := m.def.Options[optionID]; !ok {
+			return nil, NewErrBadRequest(fmt.Sprintf("option %q does not exist", optionID))
+		}
+		merged[optionID] = true
+	}
+
+	options := make([]interface{}, 0, len(m.options))
+	for _, option := range m.options {
+		if !merged[getMapString("id", option.(map[string]interface{}))] {
+			options = append(options, option)
+		}
+	}
+	m.options = options
+
+	if m.def.Type == PropTypeSelect {
+		return func(value any) (any, bool) {
+			if optionID, ok := value.(string); ok && merged[optionID] {
+				return intoOptionID, true
+			}
+			return value, true
+		}, nil
+	}
+	return func(value any) (any, bool) {
+		optionIDs := stringList(value)
+		newIDs := make([]any, 0, len(optionIDs))
+		seen := map[string]bool{}
+		changed := false
+		for _, optionID := range optionIDs {
+			if merged[optionID] {
+				optionID = intoOptionID
+				changed = true
+			}
+			if seen[optionID] {
+				changed = true
+				continue
+			}
+			seen[optionID] = true
+			newIDs = append(newIDs, optionID)
+		}
+		if !changed {
+			return value, true
+		}
+		return newIDs, true
+	}, nil
+}
+
+// toText returns the text of a value, which is how text and number values
+// are stored.
+func (m *propertyMigrator) toText(value any) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64, int, int64:
+		number, _ := ParseNumberValue(v)
+		return FormatNumberValue(number), true
+	}
+	return nil, false
+}
+
+// textToOption returns the option for a text value, creating it if the
+// property has no option with that text.
+func (m *propertyMigrator) textToOption(value any) (any, bool) {
+	text, ok := value.(string)
+	text = strings.TrimSpace(text)
+	if !ok || text == "" {
+		return nil, false
+	}
+	if optionID, ok := m.byValue[strings.ToLower(text)]; ok {
+		return optionID, true
+	}
+	optionID := utils.NewID(utils.IDTypeNone)
+	m.options = append(m.options, map[string]interface{}{
+		"id":    optionID,
+		"value": text,
+		"color": "propColorDefault",
+	})
+	m.byValue[strings.ToLower(text)] = optionID
+	m.created++
+	return optionID, true
+}
+
+// stringList returns the strings of a single or multi value.
+func stringList(value any) []string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
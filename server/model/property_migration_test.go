@@ -0,0 +1,143 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func migrationTestBoard() *Board {
+	return &Board{
+		ID: "board-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "team", "name": "Team", "type": "text"},
+			{"id": "status", "name": "Status", "type": "select", "options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To Do", "color": "propColorGray"},
+				map[string]interface{}{"id": "wip", "value": "Doing", "color": "propColorBlue"},
+				map[string]interface{}{"id": "done", "value": "Done", "color": "propColorGreen"},
+			}},
+			{"id": "labels", "name": "Labels", "type": "multiSelect", "options": []interface{}{
+				map[string]interface{}{"id": "bug", "value": "Bug"},
+				map[string]interface{}{"id": "defect", "value": "Defect"},
+			}},
+			{"id": "estimate", "name": "Estimate", "type": "number"},
+			{"id": "owner", "name": "Owner", "type": "person"},
+		},
+	}
+}
+
+func migrationTestCards() []*Card {
+	return []*Card{
+		{ID: "card-1", Properties: map[string]any{"team": "Core", "status": "todo", "labels": []any{"bug", "defect"}, "estimate": "3", "owner": "user-1"}},
+		{ID: "card-2", Properties: map[string]any{"team": " core ", "status": "wip", "labels": []any{"bug"}, "estimate": 2.5}},
+		{ID: "card-3", Properties: map[string]any{"team": "Mobile", "status": "done", "estimate": "soon"}},
+		{ID: "card-4", Properties: map[string]any{"team": ""}},
+	}
+}
+
+func TestPropertyMigrationIsValid(t *testing.T) {
+	assert.NoError(t, (&PropertyMigration{PropertyID: "p", Type: PropTypeSelect}).IsValid())
+	assert.NoError(t, (&PropertyMigration{PropertyID: "p", MergeOptionIDs: []string{"a"}, IntoOptionID: "b"}).IsValid())
+
+	for _, migration := range []*PropertyMigration{
+		{Type: PropTypeSelect},
+		{PropertyID: "p"},
+		{PropertyID: "p", Type: PropTypeSelect, MergeOptionIDs: []string{"a"}, IntoOptionID: "b"},
+		{PropertyID: "p", MergeOptionIDs: []string{"a"}},
+		{PropertyID: "p", MergeOptionIDs: []string{"a"}, IntoOptionID: "a"},
+	} {
+		assert.True(t, IsErrBadRequest(migration.IsValid()), "%+v", migration)
+	}
+}
+
+func TestPlanPropertyMigration(t *testing.T) {
+	t.Run("text to select creates options", func(t *testing.T) {
+		plan, err := PlanPropertyMigration(migrationTestBoard(), migrationTestCards(), &PropertyMigration{PropertyID: "team", Type: PropTypeSelect})
+		require.NoError(t, err)
+
+		options := plan.Property["options"].([]interface{})
+		require.Len(t, options, 2)
+		coreID := options[0].(map[string]interface{})["id"]
+		assert.Equal(t, "Core", options[0].(map[string]interface{})["value"])
+		assert.Equal(t, "Mobile", options[1].(map[string]interface{})["value"])
+		assert.Equal(t, PropTypeSelect, plan.Property["type"])
+		assert.Equal(t, "Team", plan.Property["name"])
+
+		assert.Equal(t, []string{"card-1", "card-2", "card-3", "card-4"}, plan.CardIDs)
+		assert.Equal(t, coreID, plan.CardProperties[0]["team"])
+		assert.Equal(t, coreID, plan.CardProperties[1]["team"])
+		assert.NotContains(t, plan.CardProperties[3], "team")
+		assert.Equal(t, "todo", plan.CardProperties[0]["status"])
+
+		assert.Equal(t, 4, plan.Result.CardsChecked)
+		assert.Equal(t, 4, plan.Result.CardsChanged)
+		assert.Equal(t, 1, plan.Result.ValuesDropped)
+		assert.Equal(t, 2, plan.Result.OptionsCreated)
+	})
+
+	t.Run("select to multi-select", func(t *testing.T) {
+		plan, err := PlanPropertyMigration(migrationTestBoard(), migrationTestCards(), &PropertyMigration{PropertyID: "status", Type: PropTypeMultiSelect})
+		require.NoError(t, err)
+		assert.Equal(t, []any{"todo"}, plan.CardProperties[0]["status"])
+		assert.Len(t, plan.Property["options"], 3)
+		assert.Equal(t, 3, plan.Result.CardsChanged)
+	})
+
+	t.Run("number to text", func(t *testing.T) {
+		plan, err := PlanPropertyMigration(migrationTestBoard(), migrationTestCards(), &PropertyMigration{PropertyID: "estimate", Type: PropTypeText})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"card-2"}, plan.CardIDs)
+		assert.Equal(t, "2.5", plan.CardProperties[0]["estimate"])
+		assert.Equal(t, 3, plan.Result.CardsChecked)
+	})
+
+	t.Run("person to multi-person", func(t *testing.T) {
+		plan, err := PlanPropertyMigration(migrationTestBoard(), migrationTestCards(), &PropertyMigration{PropertyID: "owner", Type: PropTypeMultiPerson})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"card-1"}, plan.CardIDs)
+		assert.Equal(t, []any{"user-1"}, plan.CardProperties[0]["owner"])
+	})
+
+	t.Run("unsupported conversion", func(t *testing.T) {
+		_, err := PlanPropertyMigration(migrationTestBoard(), migrationTestCards(), &PropertyMigration{PropertyID: "owner", Type: PropTypeNumber})
+		require.True(t, IsErrBadRequest(err))
+		assert.Contains(t, err.Error(), "cannot convert a person property to number")
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		_, err := PlanPropertyMigration(migrationTestBoard(), migrationTestCards(), &PropertyMigration{PropertyID: "nope", Type: PropTypeText})
+		require.True(t, IsErrNotFound(err))
+	})
+
+	t.Run("merge select options", func(t *testing.T) {
+		migration := &PropertyMigration{PropertyID: "status", MergeOptionIDs: []string{"wip"}, IntoOptionID: "todo"}
+		plan, err := PlanPropertyMigration(migrationTestBoard(), migrationTestCards(), migration)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"card-2"}, plan.CardIDs)
+		assert.Equal(t, "todo", plan.CardProperties[0]["status"])
+
+		options := plan.Property["options"].([]interface{})
+		require.Len(t, options, 2)
+		assert.Equal(t, "todo", options[0].(map[string]interface{})["id"])
+		assert.Equal(t, "done", options[1].(map[string]interface{})["id"])
+	})
+
+	t.Run("merge multi-select options", func(t *testing.T) {
+		migration := &PropertyMigration{PropertyID: "labels", MergeOptionIDs: []string{"defect"}, IntoOptionID: "bug"}
+		plan, err := PlanPropertyMigration(migrationTestBoard(), migrationTestCards(), migration)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"card-1"}, plan.CardIDs)
+		assert.Equal(t, []any{"bug"}, plan.CardProperties[0]["labels"])
+		assert.Equal(t, 2, plan.Result.CardsChecked)
+	})
+
+	t.Run("merge unknown option", func(t *testing.T) {
+		migration := &PropertyMigration{PropertyID: "status", MergeOptionIDs: []string{"gone"}, IntoOptionID: "todo"}
+		_, err := PlanPropertyMigration(migrationTestBoard(), migrationTestCards(), migration)
+		require.True(t, IsErrBadRequest(err))
+	})
+}
@@ -5,11 +5,17 @@ package model
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
+	"strings"
 
 	"github.com/mattermost/mattermost-plugin-boards/server/utils"
 )
 
+// StatusTransitionAnyStatus is the FromStatus of a rule that applies when a
+// card enters ToStatus from any status.
+const StatusTransitionAnyStatus = "*"
+
 // StatusTransitionRule represents a rule for transitioning between statuses
 // swagger:model
 type StatusTransitionRule struct {
@@ -21,7 +27,7 @@ type StatusTransitionRule struct {
 	// required: true
 	BoardID string `json:"boardId"`
 
-	// The status option ID to transition from
+	// The status option ID to transition from, or "*" for any status
 	// required: true
 	FromStatus string `json:"fromStatus"`
 
@@ -33,6 +39,19 @@ type StatusTransitionRule struct {
 	// required: true
 	Allowed bool `json:"allowed"`
 
+	// The ids of the properties that must be set before a card enters ToStatus
+	// required: false
+	RequiredProperties []string `json:"requiredProperties,omitempty"`
+
+	// The board roles allowed to make the transition. Anyone who can edit the
+	// cards may make it when empty
+	// required: false
+	AllowedRoles []BoardRole `json:"allowedRoles,omitempty"`
+
+	// Whether the transition requires a comment
+	// required: false
+	RequireComment bool `json:"requireComment"`
+
 	// The creation time in milliseconds since the current epoch
 	// required: true
 	CreateAt int64 `json:"createAt"`
@@ -76,5 +95,86 @@ func (r *StatusTransitionRule) IsValid() error {
 	if r.ToStatus == "" {
 		return NewErrBadRequest("to status is required")
 	}
+	for _, propID := range r.RequiredProperties {
+		if propID == "" {
+			return NewErrBadRequest("required property ID cannot be empty")
+		}
+	}
+	for _, role := range r.AllowedRoles {
+		switch role {
+		case BoardRoleAdmin, BoardRoleEditor, BoardRoleCommenter, BoardRoleViewer:
+		default:
+			return NewErrBadRequest(fmt.Sprintf("unknown board role %q", role))
+		}
+	}
 	return nil
 }
+
+// StatusTransitionConditionType is a condition of a status transition.
+type StatusTransitionConditionType string
+
+const (
+	StatusTransitionConditionAllowed          StatusTransitionConditionType = "allowed"
+	StatusTransitionConditionRequiredProperty StatusTransitionConditionType = "requiredProperty"
+	StatusTransitionConditionRole             StatusTransitionConditionType = "role"
+	StatusTransitionConditionComment          StatusTransitionConditionType = "comment"
+	StatusTransitionConditionBlocked          StatusTransitionConditionType = "blocked"
+)
+
+// StatusTransitionFailure is a condition of a status transition that a card
+// change does not meet.
+// swagger:model
+type StatusTransitionFailure struct {
+	// The failed condition: allowed, requiredProperty, role, comment or blocked
+	// required: true
+	Condition StatusTransitionConditionType `json:"condition"`
+
+	// The id of the missing property of a requiredProperty condition
+	// required: false
+	PropertyID string `json:"propertyId,omitempty"`
+
+	// The ids of the cards that are not done yet of a blocked condition
+	// required: false
+	BlockingCardIDs []string `json:"blockingCardIds,omitempty"`
+
+	// A description of the failed condition
+	// required: true
+	Message string `json:"message"`
+}
+
+// ErrStatusTransition is returned when a card change does not meet the
+// conditions of a status transition.
+type ErrStatusTransition struct {
+	FromStatus string
+	ToStatus   string
+	Failures   []StatusTransitionFailure
+}
+
+func (e *ErrStatusTransition) Error() string {
+	messages := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		messages = append(messages, failure.Message)
+	}
+	if e.FromStatus == "" {
+		return fmt.Sprintf("The card can't move to the %s status: %s.", e.ToStatus, strings.Join(messages, ", "))
+	}
+	return fmt.Sprintf("The %s card can't move to the %s status: %s.", e.FromStatus, e.ToStatus, strings.Join(messages, ", "))
+}
+
+// GetStatusTransitionRules returns the rules that apply when a card moves from
+// a status to another: the rule of entering the status from any status, then
+// the rule of the transition itself.
+func GetStatusTransitionRules(rules []*StatusTransitionRule, fromStatus string, toStatus string) []*StatusTransitionRule {
+	var matched []*StatusTransitionRule
+	for _, rule := range rules {
+		if rule.ToStatus == toStatus && rule.FromStatus == StatusTransitionAnyStatus {
+			matched = append(matched, rule)
+		}
+	}
+	for _, rule := range rules {
+		if rule.ToStatus == toStatus && rule.FromStatus == fromStatus && fromStatus != StatusTransitionAnyStatus {
+			matched = append(matched, rule)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import "fmt"
+
+// TrackerProvider is an issue tracker that cards can be linked to.
+type TrackerProvider string
+
+const (
+	TrackerProviderGitHub TrackerProvider = "github"
+	TrackerProviderGitLab TrackerProvider = "gitlab"
+	TrackerProviderJira   TrackerProvider = "jira"
+)
+
+// IsValid returns true if the provider is known.
+func (p TrackerProvider) IsValid() bool {
+	return p == TrackerProviderGitHub || p == TrackerProviderGitLab || p == TrackerProviderJira
+}
+
+// GetBoardTrackerProvider returns the issue tracker selected by a board,
+// GitHub if none is.
+func GetBoardTrackerProvider(board *Board) (TrackerProvider, error) {
+	value, err := board.GetPropertyString(BoardPropertyTrackerProvider)
+	if IsErrNotFound(err) || (err == nil && value == "") {
+		return TrackerProviderGitHub, nil
+	}
+	provider := TrackerProvider(value)
+	if err != nil || !provider.IsValid() {
+		return "", NewErrBadRequest(fmt.Sprintf("invalid issue tracker %v", board.Properties[BoardPropertyTrackerProvider]))
+	}
+	return provider, nil
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrNotViewBlock = errors.New("not a view block")
+
+type ViewType string
+
+const (
+	ViewTypeBoard    ViewType = "board"
+	ViewTypeTable    ViewType = "table"
+	ViewTypeGallery  ViewType = "gallery"
+	ViewTypeCalendar ViewType = "calendar"
+)
+
+const (
+	ViewVisibilityEveryone  = "everyone"
+	ViewVisibilityOwnerOnly = "owner-only"
+)
+
+// Special property ids that are not part of the board's card properties
+// but can be referenced by view filters and sort options.
+const (
+	TitlePropertyID = "__title"
+	CodePropertyID  = "code"
+	// FilterTitlePropertyID is the id used by filter clauses to reference the card title.
+	FilterTitlePropertyID = "title"
+)
+
+type FilterGroupOperation string
+
+const (
+	FilterGroupOperationAnd FilterGroupOperation = "and"
+	FilterGroupOperationOr  FilterGroupOperation = "or"
+)
+
+type FilterCondition string
+
+const (
+	FilterConditionIncludes      FilterCondition = "includes"
+	FilterConditionNotIncludes   FilterCondition = "notIncludes"
+	FilterConditionIsEmpty       FilterCondition = "isEmpty"
+	FilterConditionIsNotEmpty    FilterCondition = "isNotEmpty"
+	FilterConditionIsSet         FilterCondition = "isSet"
+	FilterConditionIsNotSet      FilterCondition = "isNotSet"
+	FilterConditionIs            FilterCondition = "is"
+	FilterConditionContains      FilterCondition = "contains"
+	FilterConditionNotContains   FilterCondition = "notContains"
+	FilterConditionStartsWith    FilterCondition = "startsWith"
+	FilterConditionNotStartsWith FilterCondition = "notStartsWith"
+	FilterConditionEndsWith      FilterCondition = "endsWith"
+	FilterConditionNotEndsWith   FilterCondition = "notEndsWith"
+	FilterConditionIsBefore      FilterCondition = "isBefore"
+	FilterConditionIsAfter       FilterCondition = "isAfter"
+)
+
+// FilterClause is a single condition of a view filter.
+// swagger:model
+type FilterClause struct {
+	// The id of the property the clause applies to
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The condition to check
+	// required: true
+	Condition FilterCondition `json:"condition"`
+
+	// The values to compare against
+	// required: false
+	Values []string `json:"values"`
+}
+
+// FilterGroup combines clauses and nested groups with an "and" or "or" operation.
+// swagger:model
+type FilterGroup struct {
+	// The operation used to combine the filters
+	// required: true
+	Operation FilterGroupOperation `json:"operation"`
+
+	// The clauses and nested groups of this group
+	// required: true
+	Filters []FilterItem `json:"filters"`
+}
+
+// FilterItem is either a FilterClause or a nested FilterGroup.
+// It serializes to the same JSON shape the webapp stores in view blocks.
+type FilterItem struct {
+	Clause *FilterClause
+	Group  *FilterGroup
+}
+
+func (fi *FilterItem) UnmarshalJSON(data []byte) error {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+
+	_, hasOperation := probe["operation"]
+	_, hasFilters := probe["filters"]
+	if hasOperation && hasFilters {
+		var group FilterGroup
+		if err := json.Unmarshal(data, &group); err != nil {
+			return err
+		}
+		fi.Group = &group
+		return nil
+	}
+
+	var clause FilterClause
+	if err := json.Unmarshal(data, &clause); err != nil {
+		return err
+	}
+	fi.Clause = &clause
+	return nil
+}
+
+func (fi FilterItem) MarshalJSON() ([]byte, error) {
+	if fi.Group != nil {
+		return json.Marshal(fi.Group)
+	}
+	return json.Marshal(fi.Clause)
+}
+
+// SortOption defines how a view sorts its cards.
+// swagger:model
+type SortOption struct {
+	// The id of the property to sort by, "__title" for the card title
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// Whether the sort order is reversed
+	// required: true
+	Reversed bool `json:"reversed"`
+}
+
+// ViewFields holds the parts of a view block's fields that control which
+// cards are shown and in what order.
+type ViewFields struct {
+	ViewType         ViewType     `json:"viewType"`
+	GroupByID        string       `json:"groupById"`
+	SortOptions      []SortOption `json:"sortOptions"`
+	VisibleOptionIDs []string     `json:"visibleOptionIds"`
+	HiddenOptionIDs  []string     `json:"hiddenOptionIds"`
+	Filter           FilterGroup  `json:"filter"`
+	CardOrder        []string     `json:"cardOrder"`
+	Visibility       string       `json:"visibility"`
+}
+
+// ParseViewFields extracts the filter, sort and grouping settings from a view block.
+func ParseViewFields(block *Block) (*ViewFields, error) {
+	if block.Type != TypeView {
+		return nil, fmt.Errorf("cannot parse view fields: %w", ErrNotViewBlock)
+	}
+
+	data, err := json.Marshal(block.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := &ViewFields{}
+	if err := json.Unmarshal(data, fields); err != nil {
+		return nil, fmt.Errorf("invalid view fields: %w", err)
+	}
+
+	if fields.ViewType == "" {
+		fields.ViewType = ViewTypeBoard
+	}
+	if fields.Filter.Operation == "" {
+		fields.Filter.Operation = FilterGroupOperationAnd
+	}
+	if fields.Visibility == "" {
+		fields.Visibility = ViewVisibilityEveryone
+	}
+
+	return fields, nil
+}
+
+// IsVisibleTo returns true if the view can be seen by the specified user.
+func (vf *ViewFields) IsVisibleTo(view *Block, userID string) bool {
+	return vf.Visibility != ViewVisibilityOwnerOnly || view.CreatedBy == userID
+}
+
+// ViewCardGroup is a group of cards as shown by a grouped view.
+// swagger:model
+type ViewCardGroup struct {
+	// The option id of the group, empty for the cards without a value
+	// required: true
+	OptionID string `json:"optionId"`
+
+	// The display value of the group
+	// required: true
+	Value string `json:"value"`
+
+	// The cards in the group
+	// required: true
+	Cards []*Card `json:"cards"`
+}
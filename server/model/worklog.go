@@ -0,0 +1,324 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+const (
+	// WorklogMaxMinutes is the longest duration of a single worklog entry.
+	WorklogMaxMinutes = 24 * 60
+
+	// WorklogMaxNoteLength is the longest note of a worklog entry.
+	WorklogMaxNoteLength = 2000
+)
+
+// WorklogGroupBy is how the entries of a worklog report are grouped.
+type WorklogGroupBy string
+
+const (
+	WorklogGroupByUser     WorklogGroupBy = "user"
+	WorklogGroupByBoard    WorklogGroupBy = "board"
+	WorklogGroupByCard     WorklogGroupBy = "card"
+	WorklogGroupByProperty WorklogGroupBy = "property"
+	WorklogGroupByDate     WorklogGroupBy = "date"
+)
+
+// IsValid returns true if the grouping is known.
+func (g WorklogGroupBy) IsValid() bool {
+	switch g {
+	case WorklogGroupByUser, WorklogGroupByBoard, WorklogGroupByCard, WorklogGroupByProperty, WorklogGroupByDate:
+		return true
+	}
+	return false
+}
+
+// Worklog is time spent by a user on a card.
+// swagger:model
+type Worklog struct {
+	// The id of the worklog entry
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The id of the user who did the work
+	// required: true
+	UserID string `json:"userId"`
+
+	// The time spent, in minutes
+	// required: true
+	Minutes int64 `json:"minutes"`
+
+	// The day the work was done, in milliseconds since the current epoch
+	// required: true
+	Date int64 `json:"date"`
+
+	// A note about the work
+	// required: false
+	Note string `json:"note"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The deleted time in milliseconds since the current epoch, or zero
+	// required: false
+	DeleteAt int64 `json:"deleteAt"`
+}
+
+// Populate populates a Worklog with default values.
+func (w *Worklog) Populate() {
+	if w.ID == "" {
+		w.ID = utils.NewID(utils.IDTypeNone)
+	}
+	now := utils.GetMillis()
+	if w.CreateAt == 0 {
+		w.CreateAt = now
+	}
+	if w.UpdateAt == 0 {
+		w.UpdateAt = now
+	}
+	if w.Date == 0 {
+		w.Date = w.CreateAt
+	}
+}
+
+// IsValid validates the worklog entry.
+func (w *Worklog) IsValid() error {
+	if w.ID == "" {
+		return NewErrBadRequest("worklog id cannot be empty")
+	}
+	if w.BoardID == "" {
+		return NewErrBadRequest("worklog board id cannot be empty")
+	}
+	if w.CardID == "" {
+		return NewErrBadRequest("worklog card id cannot be empty")
+	}
+	if w.UserID == "" {
+		return NewErrBadRequest("worklog user id cannot be empty")
+	}
+	if w.Minutes <= 0 || w.Minutes > WorklogMaxMinutes {
+		return NewErrBadRequest(fmt.Sprintf("worklog minutes must be between 1 and %d", WorklogMaxMinutes))
+	}
+	if w.Date <= 0 {
+		return NewErrBadRequest("worklog date cannot be empty")
+	}
+	if len(w.Note) > WorklogMaxNoteLength {
+		return NewErrBadRequest(fmt.Sprintf("worklog note cannot be longer than %d characters", WorklogMaxNoteLength))
+	}
+	return nil
+}
+
+// WorklogPatch is a patch for modifying a worklog entry.
+// swagger:model
+type WorklogPatch struct {
+	// The time spent, in minutes
+	// required: false
+	Minutes *int64 `json:"minutes"`
+
+	// The day the work was done, in milliseconds since the current epoch
+	// required: false
+	Date *int64 `json:"date"`
+
+	// A note about the work
+	// required: false
+	Note *string `json:"note"`
+}
+
+// Patch returns an updated version of the worklog entry.
+func (p *WorklogPatch) Patch(worklog *Worklog) *Worklog {
+	if p.Minutes != nil {
+		worklog.Minutes = *p.Minutes
+	}
+	if p.Date != nil {
+		worklog.Date = *p.Date
+	}
+	if p.Note != nil {
+		worklog.Note = *p.Note
+	}
+	return worklog
+}
+
+// QueryWorklogsOptions are the filters of a worklog query.
+type QueryWorklogsOptions struct {
+	BoardIDs []string // if not empty then only the entries of these boards are included
+	CardID   string   // if not empty then only the entries of this card are included
+	UserID   string   // if not empty then only the entries of this user are included
+	From     int64    // if non-zero then only the entries dated from this time are included
+	To       int64    // if non-zero then only the entries dated before this time are included
+}
+
+// QueryWorklogsComplianceHistoryOptions are the filters of a worklog
+// compliance history query.
+type QueryWorklogsComplianceHistoryOptions struct {
+	ModifiedSince  int64  // if non-zero then filter for records with update_at greater than ModifiedSince
+	IncludeDeleted bool   // if true then deleted entries are included
+	TeamID         string // if not empty then filter for specific team, otherwise all teams are included
+	BoardID        string // if not empty then filter for specific board, otherwise all boards are included
+	Page           int    // page number to select when paginating
+	PerPage        int    // number of entries per page (default=60)
+}
+
+// WorklogsComplianceHistoryResponse is the response body to a request for
+// worklogs history.
+// swagger:model
+type WorklogsComplianceHistoryResponse struct {
+	// True if there is a next page for pagination
+	// required: true
+	HasNext bool `json:"hasNext"`
+
+	// The array of worklog entries, deleted ones included if requested.
+	// required: true
+	Results []*Worklog `json:"results"`
+}
+
+// WorklogReport is the time logged on cards, grouped by user, board, card,
+// property value or day.
+// swagger:model
+type WorklogReport struct {
+	// How the entries are grouped
+	// required: true
+	GroupBy WorklogGroupBy `json:"groupBy"`
+
+	// The start of the date range, or zero
+	// required: false
+	From int64 `json:"from"`
+
+	// The end of the date range, or zero
+	// required: false
+	To int64 `json:"to"`
+
+	// The time logged in the date range, in minutes
+	// required: true
+	TotalMinutes int64 `json:"totalMinutes"`
+
+	// The number of entries in the date range
+	// required: true
+	Entries int `json:"entries"`
+
+	// The groups of entries
+	// required: true
+	Groups []*WorklogReportGroup `json:"groups"`
+}
+
+// WorklogReportGroup is the time logged for one user, board, card, property
+// value or day.
+// swagger:model
+type WorklogReportGroup struct {
+	// The user id, board id, card id, property value or day (YYYY-MM-DD) of
+	// the group. Entries of cards without a value for the property are
+	// grouped under an empty key.
+	// required: true
+	Key string `json:"key"`
+
+	// The time logged, in minutes
+	// required: true
+	Minutes int64 `json:"minutes"`
+
+	// The number of entries
+	// required: true
+	Entries int `json:"entries"`
+}
+
+// WorklogDateKey returns the day of a worklog entry, as used by reports
+// grouped by date.
+func WorklogDateKey(date int64) string {
+	return time.UnixMilli(date).UTC().Format("2006-01-02")
+}
+
+// BuildWorklogReport groups worklog entries under the keys returned by
+// keys. An entry with several keys, such as a card with several values of a
+// multi-select property, counts fully in each of their groups. Groups by
+// date are sorted by day, other groups by decreasing time.
+func BuildWorklogReport(worklogs []*Worklog, groupBy WorklogGroupBy, keys func(*Worklog) []string) *WorklogReport {
+	report := &WorklogReport{
+		GroupBy: groupBy,
+		Groups:  []*WorklogReportGroup{},
+	}
+	groups := map[string]*WorklogReportGroup{}
+	for _, worklog := range worklogs {
+		report.TotalMinutes += worklog.Minutes
+		report.Entries++
+		for _, key := range keys(worklog) {
+			group, ok := groups[key]
+			if !ok {
+				group = &WorklogReportGroup{Key: key}
+				groups[key] = group
+				report.Groups = append(report.Groups, group)
+			}
+			group.Minutes += worklog.Minutes
+			group.Entries++
+		}
+	}
+
+	sort.SliceStable(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if groupBy != WorklogGroupByDate && a.Minutes != b.Minutes {
+			return a.Minutes > b.Minutes
+		}
+		return a.Key < b.Key
+	})
+	return report
+}
+
+// WorklogReportOptions are the options of a worklog report.
+type WorklogReportOptions struct {
+	BoardIDs []string       // if not empty then only the entries of these boards are included
+	UserID   string         // if not empty then only the entries of this user are included
+	From     int64          // if non-zero then only the entries dated from this time are included
+	To       int64          // if non-zero then only the entries dated before this time are included
+	GroupBy  WorklogGroupBy // how the entries are grouped
+	Property string         // the name of the property to group by, for WorklogGroupByProperty
+}
+
+// IsValid checks the grouping of the report.
+func (o *WorklogReportOptions) IsValid() error {
+	if !o.GroupBy.IsValid() {
+		return NewErrBadRequest(fmt.Sprintf("invalid worklog report grouping %q", o.GroupBy))
+	}
+	if o.GroupBy == WorklogGroupByProperty && o.Property == "" {
+		return NewErrBadRequest("a worklog report grouped by property needs a property name")
+	}
+	if o.To != 0 && o.To <= o.From {
+		return NewErrBadRequest("the worklog report date range ends before it starts")
+	}
+	return nil
+}
+
+// WorklogPropertyKeys returns the report keys of the value of a property:
+// the labels of the options of select properties, and the values themselves
+// otherwise.
+func WorklogPropertyKeys(def PropDef, value any) []string {
+	var keys []string
+	for _, item := range stringList(value) {
+		if def.Type == PropTypeSelect || def.Type == PropTypeMultiSelect {
+			option, ok := def.Options[item]
+			if !ok {
+				continue
+			}
+			item = option.Value
+		}
+		keys = append(keys, item)
+	}
+	if len(keys) == 0 {
+		return []string{""}
+	}
+	return keys
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorklogIsValid(t *testing.T) {
+	valid := func() *Worklog {
+		w := &Worklog{BoardID: "board-id", CardID: "card-id", UserID: "user-id", Minutes: 30}
+		w.Populate()
+		return w
+	}
+
+	worklog := valid()
+	require.NoError(t, worklog.IsValid())
+	assert.NotEmpty(t, worklog.ID)
+	assert.Equal(t, worklog.CreateAt, worklog.Date)
+
+	testCases := map[string]func(*Worklog){
+		"no card":       func(w *Worklog) { w.CardID = "" },
+		"no user":       func(w *Worklog) { w.UserID = "" },
+		"no time":       func(w *Worklog) { w.Minutes = 0 },
+		"negative time": func(w *Worklog) { w.Minutes = -5 },
+		"over a day":    func(w *Worklog) { w.Minutes = WorklogMaxMinutes + 1 },
+		"long note":     func(w *Worklog) { w.Note = strings.Repeat("a", WorklogMaxNoteLength+1) },
+	}
+	for name, change := range testCases {
+		t.Run(name, func(t *testing.T) {
+			worklog := valid()
+			change(worklog)
+			assert.True(t, IsErrBadRequest(worklog.IsValid()))
+		})
+	}
+}
+
+func TestWorklogPatch(t *testing.T) {
+	minutes := int64(45)
+	note := "review"
+	worklog := &Worklog{Minutes: 30, Date: 1, Note: "draft"}
+
+	(&WorklogPatch{Minutes: &minutes, Note: &note}).Patch(worklog)
+	assert.Equal(t, int64(45), worklog.Minutes)
+	assert.Equal(t, int64(1), worklog.Date)
+	assert.Equal(t, "review", worklog.Note)
+}
+
+func TestWorklogReportOptionsIsValid(t *testing.T) {
+	assert.NoError(t, (&WorklogReportOptions{GroupBy: WorklogGroupByUser}).IsValid())
+	assert.NoError(t, (&WorklogReportOptions{GroupBy: WorklogGroupByProperty, Property: "Status"}).IsValid())
+	assert.Error(t, (&WorklogReportOptions{GroupBy: "week"}).IsValid())
+	assert.Error(t, (&WorklogReportOptions{GroupBy: WorklogGroupByProperty}).IsValid())
+	assert.Error(t, (&WorklogReportOptions{GroupBy: WorklogGroupByDate, From: 10, To: 5}).IsValid())
+}
+
+func TestBuildWorklogReport(t *testing.T) {
+	day1 := time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC).UnixMilli()
+	day2 := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC).UnixMilli()
+	worklogs := []*Worklog{
+		{UserID: "alice", Minutes: 30, Date: day2},
+		{UserID: "bob", Minutes: 90, Date: day1},
+		{UserID: "alice", Minutes: 45, Date: day1},
+	}
+
+	report := BuildWorklogReport(worklogs, WorklogGroupByUser, func(w *Worklog) []string { return []string{w.UserID} })
+	assert.Equal(t, int64(165), report.TotalMinutes)
+	assert.Equal(t, 3, report.Entries)
+	assert.Equal(t, []*WorklogReportGroup{
+		{Key: "bob", Minutes: 90, Entries: 1},
+		{Key: "alice", Minutes: 75, Entries: 2},
+	}, report.Groups)
+
+	report = BuildWorklogReport(worklogs, WorklogGroupByDate, func(w *Worklog) []string { return []string{WorklogDateKey(w.Date)} })
+	assert.Equal(t, []*WorklogReportGroup{
+		{Key: "2024-03-01", Minutes: 135, Entries: 2},
+		{Key: "2024-03-02", Minutes: 30, Entries: 1},
+	}, report.Groups)
+
+	report = BuildWorklogReport(nil, WorklogGroupByBoard, nil)
+	assert.Empty(t, report.Groups)
+}
+
+func TestWorklogPropertyKeys(t *testing.T) {
+	labels := PropDef{Type: PropTypeMultiSelect, Options: map[string]PropDefOption{
+		"bug": {ID: "bug", Value: "Bug"},
+		"ui":  {ID: "ui", Value: "UI"},
+	}}
+	assert.Equal(t, []string{"Bug", "UI"}, WorklogPropertyKeys(labels, []any{"bug", "ui", "gone"}))
+	assert.Equal(t, []string{""}, WorklogPropertyKeys(labels, nil))
+	assert.Equal(t, []string{"Acme"}, WorklogPropertyKeys(PropDef{Type: PropTypeText}, "Acme"))
+}
@@ -41,8 +41,13 @@ import (
 )
 
 const (
-	cleanupSessionTaskFrequency = 10 * time.Minute
-	updateMetricsTaskFrequency  = 15 * time.Minute
+	cleanupSessionTaskFrequency  = 10 * time.Minute
+	updateMetricsTaskFrequency   = 15 * time.Minute
+	deliverWebhooksTaskFrequency = 15 * time.Second
+	recurringCardsTaskFrequency  = time.Minute
+	githubLinksSyncTaskFrequency = 5 * time.Minute
+	githubReposScanTaskFrequency = 5 * time.Minute
+	githubIssueSyncTaskFrequency = time.Minute
 )
 
 type Server struct {
@@ -57,6 +62,11 @@ type Server struct {
 	metricsServer          *metrics.Service
 	metricsService         *metrics.Metrics
 	metricsUpdaterTask     *scheduler.ScheduledTask
+	webhookDeliveryTask    *scheduler.ScheduledTask
+	recurringCardsTask     *scheduler.ScheduledTask
+	githubLinksSyncTask    *scheduler.ScheduledTask
+	githubReposScanTask    *scheduler.ScheduledTask
+	githubIssueSyncTask    *scheduler.ScheduledTask
 	auditService           *audit.Audit
 	notificationService    *notify.Service
 	servicesStartStopMutex sync.Mutex
@@ -103,6 +113,9 @@ func New(params Params) (*Server, error) {
 	}
 
 	webhookClient := webhook.NewClient(params.Cfg, params.Logger)
+	if params.ServicesAPI != nil {
+		webhookClient.UseServerHTTPClient(params.ServicesAPI)
+	}
 
 	// Init metrics
 	instanceInfo := metrics.InstanceInfo{
@@ -139,6 +152,7 @@ func New(params Params) (*Server, error) {
 		Permissions:      params.PermissionsService,
 		ServicesAPI:      params.ServicesAPI,
 		SkipTemplateInit: utils.IsRunningUnitTests(),
+		Audit:            auditService,
 	}
 	app := app.New(params.Cfg, wsAdapter, appServices)
 
@@ -276,6 +290,41 @@ func (s *Server) Start() error {
 	// metricsUpdater()   Calling this immediately causes integration unit tests to fail.
 	s.metricsUpdaterTask = scheduler.CreateRecurringTask("updateMetrics", metricsUpdater, updateMetricsTaskFrequency)
 
+	deliverWebhooks := func() {
+		if err := s.app.DeliverPendingWebhooks(); err != nil {
+			s.logger.Error("Error delivering board webhooks", mlog.Err(err))
+		}
+	}
+	s.webhookDeliveryTask = scheduler.CreateRecurringTask("deliverWebhooks", deliverWebhooks, deliverWebhooksTaskFrequency)
+
+	createRecurringCards := func() {
+		if err := s.app.CreateRecurringCards(time.Now()); err != nil {
+			s.logger.Error("Error creating recurring cards", mlog.Err(err))
+		}
+	}
+	s.recurringCardsTask = scheduler.CreateRecurringTask("createRecurringCards", createRecurringCards, recurringCardsTaskFrequency)
+
+	syncGitHubLinks := func() {
+		if err := s.app.SyncCardGitHubLinks(); err != nil {
+			s.logger.Error("Error synchronizing card GitHub links", mlog.Err(err))
+		}
+	}
+	s.githubLinksSyncTask = scheduler.CreateRecurringTask("syncCardGitHubLinks", syncGitHubLinks, githubLinksSyncTaskFrequency)
+
+	scanGitHubRepos := func() {
+		if err := s.app.ScanBoardGitHubRepos(); err != nil {
+			s.logger.Error("Error scanning board GitHub repositories", mlog.Err(err))
+		}
+	}
+	s.githubReposScanTask = scheduler.CreateRecurringTask("scanBoardGitHubRepos", scanGitHubRepos, githubReposScanTaskFrequency)
+
+	syncGitHubIssues := func() {
+		if err := s.app.SyncGitHubIssues(); err != nil {
+			s.logger.Error("Error synchronizing GitHub issues", mlog.Err(err))
+		}
+	}
+	s.githubIssueSyncTask = scheduler.CreateRecurringTask("syncGitHubIssues", syncGitHubIssues, githubIssueSyncTaskFrequency)
+
 	if s.config.Telemetry {
 		firstRun := utils.GetMillis()
 		s.telemetry.RunTelemetryJob(firstRun)
@@ -317,6 +366,26 @@ func (s *Server) Shutdown() error {
 		s.metricsUpdaterTask.Cancel()
 	}
 
+	if s.webhookDeliveryTask != nil {
+		s.webhookDeliveryTask.Cancel()
+	}
+
+	if s.recurringCardsTask != nil {
+		s.recurringCardsTask.Cancel()
+	}
+
+	if s.githubLinksSyncTask != nil {
+		s.githubLinksSyncTask.Cancel()
+	}
+
+	if s.githubReposScanTask != nil {
+		s.githubReposScanTask.Cancel()
+	}
+
+	if s.githubIssueSyncTask != nil {
+		s.githubIssueSyncTask.Cancel()
+	}
+
 	if err := s.telemetry.Shutdown(); err != nil {
 		s.logger.Warn("Error occurred when shutting down telemetry", mlog.Err(err))
 	}
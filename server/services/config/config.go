@@ -62,6 +62,11 @@ type Configuration struct {
 	ShowEmailAddress         bool              `json:"show_email_address" mapstructure:"showEmailAddress"`
 	ShowFullName             bool              `json:"show_full_name" mapstructure:"showFullName"`
 	FigmaPersonalAccessToken string            `json:"figma_personal_access_token" mapstructure:"figmaPersonalAccessToken"`
+	GitLabURL                string            `json:"gitlab_url" mapstructure:"gitlabUrl"`
+	GitLabAccessToken        string            `json:"gitlab_access_token" mapstructure:"gitlabAccessToken"`
+	JiraURL                  string            `json:"jira_url" mapstructure:"jiraUrl"`
+	JiraEmail                string            `json:"jira_email" mapstructure:"jiraEmail"`
+	JiraAPIToken             string            `json:"jira_api_token" mapstructure:"jiraApiToken"`
 	AllowedBotUserIDs        []string          `json:"allowed_bot_user_ids" mapstructure:"allowedBotUserIds"`
 
 	AuthMode string `json:"authMode" mapstructure:"authMode"`
@@ -74,6 +79,9 @@ type Configuration struct {
 
 	NotifyFreqCardSeconds  int `json:"notify_freq_card_seconds" mapstructure:"notify_freq_card_seconds"`
 	NotifyFreqBoardSeconds int `json:"notify_freq_board_seconds" mapstructure:"notify_freq_board_seconds"`
+
+	DueDateReminderMinutes int `json:"due_date_reminder_minutes" mapstructure:"due_date_reminder_minutes"`
+	DueDateDigestHour      int `json:"due_date_digest_hour" mapstructure:"due_date_digest_hour"`
 }
 
 // ReadConfigFile read the configuration from the filesystem.
@@ -108,6 +116,8 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("AuthMode", "native")
 	viper.SetDefault("NotifyFreqCardSeconds", 120)    // 2 minutes after last card edit
 	viper.SetDefault("NotifyFreqBoardSeconds", 86400) // 1 day after last card edit
+	viper.SetDefault("DueDateReminderMinutes", 1440)  // 1 day before the due date
+	viper.SetDefault("DueDateDigestHour", 9)          // 9am in the user's timezone
 	viper.SetDefault("EnableDataRetention", false)
 	viper.SetDefault("FeatureFlags", map[string]string{})
 	viper.SetDefault("DataRetentionDays", 365) // 1 year is default
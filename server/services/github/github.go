@@ -44,6 +44,9 @@ const (
 	githubAPIRepoIssue    = "/repos/%s/%s/issues/%d"
 	githubAPISearchIssues = "/search/issues"
 	githubAPIRepoPull     = "/repos/%s/%s/pulls/%d"
+	githubAPIRepoPulls    = "/repos/%s/%s/pulls"
+	githubAPIRepoCommits  = "/repos/%s/%s/commits"
+	githubAPIRepoCommit   = "/repos/%s/%s/commits/%s"
 	githubAPIRefs         = "/repos/%s/%s/git/refs"
 	githubAPIRef          = "/repos/%s/%s/git/refs/heads/%s"
 	githubAPIRepo         = "/repos/%s/%s"
@@ -86,6 +89,15 @@ func New(api ServicesAPI) *Service {
 	}
 }
 
+// NewWithHTTPClient creates a GitHub service instance that calls the GitHub
+// API with the given HTTP client.
+func NewWithHTTPClient(api ServicesAPI, httpClient *http.Client) *Service {
+	return &Service{
+		api:        api,
+		httpClient: httpClient,
+	}
+}
+
 // GetUserToken retrieves the OAuth token for a user from the GitHub plugin.
 // This is the only IPC call we make — it uses query params (not the User-ID
 // header that gets overwritten by PluginHTTP).
@@ -368,6 +380,100 @@ func (s *Service) GetIssue(userID, owner, repo string, number int) (*Issue, erro
 	return &issue, nil
 }
 
+// ListIssues retrieves the issues of a repository, without its pull
+// requests, least recently updated first. With a zero time only the open
+// issues are listed, otherwise the issues updated since the time, open or
+// closed.
+func (s *Service) ListIssues(userID, owner, repo string, since time.Time) ([]Issue, error) {
+	token, err := s.GetUserToken(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user token: %w", err)
+	}
+	if token == "" {
+		return nil, ErrNotConnected
+	}
+
+	reqURL := fmt.Sprintf("%s"+githubAPIRepoIssues+"?sort=updated&direction=asc&per_page=100", githubAPIBase, owner, repo)
+	if since.IsZero() {
+		reqURL += "&state=open"
+	} else {
+		reqURL += "&state=all&since=" + url.QueryEscape(since.UTC().Format(time.RFC3339))
+	}
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	s.setGitHubHeaders(req, token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GitHub API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, s.handleGitHubError(resp)
+	}
+
+	var ghIssues []Issue
+	if err := json.NewDecoder(resp.Body).Decode(&ghIssues); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	issues := make([]Issue, 0, len(ghIssues))
+	for _, issue := range ghIssues {
+		if issue.PullRequest == nil {
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues, nil
+}
+
+// UpdateIssue changes a GitHub issue.
+func (s *Service) UpdateIssue(userID, owner, repo string, number int, req UpdateIssueRequest) (*Issue, error) {
+	token, err := s.GetUserToken(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user token: %w", err)
+	}
+	if token == "" {
+		return nil, ErrNotConnected
+	}
+
+	reqURL := fmt.Sprintf("%s"+githubAPIRepoIssue, githubAPIBase, owner, repo, number)
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPatch, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	s.setGitHubHeaders(httpReq, token)
+
+	resp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GitHub API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, s.handleGitHubError(resp)
+	}
+
+	var issue Issue
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &issue, nil
+}
+
 // SearchIssues searches for GitHub issues using the search API.
 func (s *Service) SearchIssues(userID, term string) ([]Issue, error) {
 	token, err := s.GetUserToken(userID)
@@ -442,6 +548,125 @@ func (s *Service) GetPRDetails(userID, owner, repo string, number int) (*PRDetai
 	return &pr, nil
 }
 
+// ListPullRequests retrieves the most recently updated pull requests of a
+// repository, open or closed, most recently updated first.
+func (s *Service) ListPullRequests(userID, owner, repo string) ([]PRDetails, error) {
+	token, err := s.GetUserToken(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user token: %w", err)
+	}
+	if token == "" {
+		return nil, ErrNotConnected
+	}
+
+	reqURL := fmt.Sprintf("%s"+githubAPIRepoPulls+"?state=all&sort=updated&direction=desc&per_page=100", githubAPIBase, owner, repo)
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	s.setGitHubHeaders(req, token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GitHub API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, s.handleGitHubError(resp)
+	}
+
+	var prs []PRDetails
+	if err := json.NewDecoder(resp.Body).Decode(&prs); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// The list endpoint does not return the merged flag.
+	for i := range prs {
+		prs[i].Merged = prs[i].Merged || prs[i].MergedAt != nil
+	}
+
+	return prs, nil
+}
+
+// ListCommits retrieves the most recent commits of the default branch of a
+// repository made after a time, most recent first.
+func (s *Service) ListCommits(userID, owner, repo string, since time.Time) ([]Commit, error) {
+	token, err := s.GetUserToken(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user token: %w", err)
+	}
+	if token == "" {
+		return nil, ErrNotConnected
+	}
+
+	reqURL := fmt.Sprintf("%s"+githubAPIRepoCommits+"?since=%s&per_page=100", githubAPIBase, owner, repo,
+		url.QueryEscape(since.UTC().Format(time.RFC3339)))
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	s.setGitHubHeaders(req, token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GitHub API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, s.handleGitHubError(resp)
+	}
+
+	var commits []Commit
+	if err := json.NewDecoder(resp.Body).Decode(&commits); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return commits, nil
+}
+
+// GetCommit retrieves a specific commit.
+func (s *Service) GetCommit(userID, owner, repo, sha string) (*Commit, error) {
+	token, err := s.GetUserToken(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user token: %w", err)
+	}
+	if token == "" {
+		return nil, ErrNotConnected
+	}
+
+	reqURL := fmt.Sprintf("%s"+githubAPIRepoCommit, githubAPIBase, owner, repo, url.PathEscape(sha))
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	s.setGitHubHeaders(req, token)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call GitHub API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, s.handleGitHubError(resp)
+	}
+
+	var commit Commit
+	if err := json.NewDecoder(resp.Body).Decode(&commit); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &commit, nil
+}
+
 // CreateBranch creates a new branch in a GitHub repository.
 func (s *Service) CreateBranch(userID string, req CreateBranchRequest) (*Branch, error) {
 	token, err := s.GetUserToken(userID)
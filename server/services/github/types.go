@@ -30,6 +30,16 @@ type Issue struct {
 	Labels    []Label    `json:"labels"`
 	Assignees []User     `json:"assignees"`
 	Milestone *Milestone `json:"milestone,omitempty"`
+
+	// PullRequest is set when the issue is a pull request, as the issues
+	// endpoints also return pull requests.
+	PullRequest *IssuePullRequest `json:"pull_request,omitempty"`
+}
+
+// IssuePullRequest represents the pull request of an issue.
+type IssuePullRequest struct {
+	URL     string `json:"url"`
+	HTMLURL string `json:"html_url"`
 }
 
 // User represents a GitHub user.
@@ -82,6 +92,26 @@ type PRBranch struct {
 	Repo Repository `json:"repo"`
 }
 
+// Commit represents a GitHub commit.
+type Commit struct {
+	SHA     string       `json:"sha"`
+	HTMLURL string       `json:"html_url"`
+	Commit  CommitDetail `json:"commit"`
+}
+
+// CommitDetail represents the git data of a GitHub commit.
+type CommitDetail struct {
+	Message string       `json:"message"`
+	Author  CommitAuthor `json:"author"`
+}
+
+// CommitAuthor represents the author of a git commit.
+type CommitAuthor struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+}
+
 // CreateIssueRequest represents a request to create a GitHub issue.
 type CreateIssueRequest struct {
 	Owner     string   `json:"owner"`
@@ -93,6 +123,16 @@ type CreateIssueRequest struct {
 	Milestone int      `json:"milestone,omitempty"`
 }
 
+// UpdateIssueRequest represents a change to a GitHub issue. Fields left nil
+// are not changed.
+type UpdateIssueRequest struct {
+	Title     *string   `json:"title,omitempty"`
+	Body      *string   `json:"body,omitempty"`
+	State     *string   `json:"state,omitempty"`
+	Labels    *[]string `json:"labels,omitempty"`
+	Assignees *[]string `json:"assignees,omitempty"`
+}
+
 // ConnectedResponse represents the response from the connected endpoint.
 type ConnectedResponse struct {
 	Connected      bool   `json:"connected"`
@@ -0,0 +1,192 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyautomation
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// execution holds the state of a rule running for a card. Actions that move
+// the card update the board and card seen by the next actions.
+type execution struct {
+	rule   *model.AutomationRule
+	userID string
+	board  *model.Board
+	card   *model.Card
+}
+
+// runRule runs the actions of a rule, in order, on behalf of the rule
+// creator. The actions stop at the first one that fails.
+func (b *Backend) runRule(rule *model.AutomationRule, board *model.Board, card *model.Card) error {
+	if !b.claimRun(rule.ID, card.ID) {
+		b.logger.Debug("Automation rule skipped during cooldown",
+			mlog.String("rule_id", rule.ID),
+			mlog.String("card_id", card.ID),
+		)
+		return nil
+	}
+
+	userID := rule.CreatedBy
+	if !b.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionManageBoardCards) {
+		return fmt.Errorf("automation rule %s: creator %s cannot manage the cards of board %s", rule.ID, userID, board.ID)
+	}
+
+	// work on the latest version of the card, with its code populated
+	current, err := b.appAPI.GetCardByID(card.ID)
+	if err != nil {
+		return fmt.Errorf("automation rule %s: cannot get card %s: %w", rule.ID, card.ID, err)
+	}
+
+	exec := &execution{rule: rule, userID: userID, board: board, card: current}
+	for i, action := range rule.Actions {
+		if err := b.runAction(exec, action); err != nil {
+			return fmt.Errorf("automation rule %s: action %d (%s): %w", rule.ID, i+1, action.Type, err)
+		}
+	}
+	return nil
+}
+
+func (b *Backend) runAction(exec *execution, action model.AutomationAction) error {
+	switch action.Type {
+	case model.AutomationActionSetProperty:
+		return b.setProperty(exec, action.PropertyID, action.Value)
+	case model.AutomationActionAssignPerson:
+		return b.assignPerson(exec, action)
+	case model.AutomationActionAddComment:
+		return b.addComment(exec, action)
+	case model.AutomationActionMoveToBoard:
+		return b.moveToBoard(exec, action)
+	case model.AutomationActionPostToChannel:
+		return b.postToChannel(exec, action)
+	case model.AutomationActionCreateLinkedCard:
+		return b.createLinkedCard(exec, action)
+	}
+	return fmt.Errorf("unknown action type %q", action.Type)
+}
+
+func (b *Backend) setProperty(exec *execution, propertyID string, value interface{}) error {
+	patch := &model.CardPatch{
+		UpdatedProperties: map[string]interface{}{propertyID: value},
+	}
+	card, err := b.appAPI.PatchCard(patch, exec.card.ID, exec.userID, false)
+	if err != nil {
+		return err
+	}
+	exec.card = card
+	return nil
+}
+
+func (b *Backend) assignPerson(exec *execution, action model.AutomationAction) error {
+	schema, err := model.ParsePropertySchema(exec.board)
+	if err != nil {
+		return err
+	}
+	prop, ok := schema[action.PropertyID]
+	if !ok {
+		return fmt.Errorf("unknown property %s on board %s", action.PropertyID, exec.board.ID)
+	}
+
+	if prop.Type != model.PropTypeMultiPerson {
+		return b.setProperty(exec, prop.ID, action.UserID)
+	}
+
+	assignees, _ := exec.card.Properties[prop.ID].([]interface{})
+	for _, assignee := range assignees {
+		if assignee == action.UserID {
+			return nil
+		}
+	}
+	updated := append(append([]interface{}{}, assignees...), action.UserID)
+	return b.setProperty(exec, prop.ID, updated)
+}
+
+func (b *Backend) addComment(exec *execution, action model.AutomationAction) error {
+	now := utils.GetMillis()
+	comment := &model.Block{
+		ID:         utils.NewID(utils.IDTypeBlock),
+		ParentID:   exec.card.ID,
+		BoardID:    exec.card.BoardID,
+		Schema:     1,
+		Type:       model.TypeComment,
+		Title:      b.expandText(exec, action.Text),
+		Fields:     map[string]interface{}{},
+		CreatedBy:  exec.userID,
+		ModifiedBy: exec.userID,
+		CreateAt:   now,
+		UpdateAt:   now,
+	}
+	return b.appAPI.InsertBlockAndNotify(comment, exec.userID, false)
+}
+
+func (b *Backend) moveToBoard(exec *execution, action model.AutomationAction) error {
+	if action.BoardID == exec.card.BoardID {
+		return nil
+	}
+	if !b.permissions.HasPermissionToBoard(exec.userID, action.BoardID, model.PermissionManageBoardCards) {
+		return fmt.Errorf("creator cannot manage the cards of board %s", action.BoardID)
+	}
+
+	board, err := b.appAPI.GetBoard(action.BoardID)
+	if err != nil {
+		return err
+	}
+	card, err := b.appAPI.MoveCard(exec.card.ID, board.ID, exec.userID)
+	if err != nil {
+		return err
+	}
+	exec.board = board
+	exec.card = card
+	return nil
+}
+
+func (b *Backend) postToChannel(exec *execution, action model.AutomationAction) error {
+	if !b.permissions.HasPermissionToChannel(exec.userID, action.ChannelID, mm_model.PermissionCreatePost) {
+		return fmt.Errorf("creator cannot post in channel %s", action.ChannelID)
+	}
+	return b.delivery.ChannelDeliver(action.ChannelID, b.expandText(exec, action.Text))
+}
+
+func (b *Backend) createLinkedCard(exec *execution, action model.AutomationAction) error {
+	boardID := action.BoardID
+	if boardID == "" {
+		boardID = exec.card.BoardID
+	}
+	if !b.permissions.HasPermissionToBoard(exec.userID, boardID, model.PermissionManageBoardCards) {
+		return fmt.Errorf("creator cannot manage the cards of board %s", boardID)
+	}
+
+	card := &model.Card{Title: b.expandText(exec, action.Title)}
+	card.PopulateWithBoardID(boardID)
+	if err := card.CheckValid(); err != nil {
+		return err
+	}
+	linked, err := b.appAPI.CreateCard(card, boardID, exec.userID, false)
+	if err != nil {
+		return err
+	}
+
+	relationType := action.RelationType
+	if relationType == "" {
+		relationType = model.RelationTypeRelatesTo
+	}
+	relation := &model.CardRelation{
+		SourceCardID: exec.card.ID,
+		TargetCardID: linked.ID,
+		RelationType: relationType,
+		CreatedBy:    exec.userID,
+	}
+	_, err = b.appAPI.CreateCardRelation(relation, exec.card.BoardID)
+	return err
+}
+
+func (b *Backend) expandText(exec *execution, text string) string {
+	cardURL := utils.MakeCardLink(b.serverRoot, exec.board.TeamID, exec.board.ID, exec.card.ID)
+	return model.ExpandAutomationText(text, exec.card, exec.board, cardURL)
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+package notifyautomation
+
+import "github.com/mattermost/mattermost-plugin-boards/server/model"
+
+type AppAPI interface {
+	GetAutomationRules(boardID string) ([]*model.AutomationRule, error)
+	GetEnabledAutomationRulesByTrigger(triggerType model.AutomationTriggerType) ([]*model.AutomationRule, error)
+	ClaimAutomationRuleDueCheck(rule *model.AutomationRule, until int64) (bool, error)
+
+	GetBoard(boardID string) (*model.Board, error)
+	GetCardByID(cardID string) (*model.Card, error)
+	GetCardsForBoard(boardID string, page int, perPage int) ([]*model.Card, error)
+
+	PatchCard(cardPatch *model.CardPatch, cardID string, userID string, disableNotify bool) (*model.Card, error)
+	CreateCard(card *model.Card, boardID string, userID string, disableNotify bool) (*model.Card, error)
+	MoveCard(cardID string, boardID string, userID string) (*model.Card, error)
+	InsertBlockAndNotify(block *model.Block, userID string, disableNotify bool) error
+	CreateCardRelation(relation *model.CardRelation, boardID string) (*model.CardRelation, error)
+}
+
+// ChannelDelivery posts automation messages to channels.
+type ChannelDelivery interface {
+	ChannelDeliver(channelID string, message string) error
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyautomation
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/scheduler"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	backendName = "notifyAutomation"
+
+	// ruleCooldown is the time during which a rule does not run again for the
+	// same card. It stops rules from triggering each other endlessly.
+	ruleCooldown = 10 * time.Second
+
+	dueDateCheckFrequency = time.Minute
+	dueDateCardsPerPage   = 100
+)
+
+type BackendParams struct {
+	ServerRoot  string
+	AppAPI      AppAPI
+	Permissions permissions.PermissionsService
+	Delivery    ChannelDelivery
+	Logger      mlog.LoggerIFace
+}
+
+// Backend provides the notification backend that runs board automation rules
+// when cards change.
+type Backend struct {
+	serverRoot  string
+	appAPI      AppAPI
+	permissions permissions.PermissionsService
+	delivery    ChannelDelivery
+	logger      mlog.LoggerIFace
+
+	mux     sync.Mutex
+	lastRun map[string]time.Time
+
+	dueDateTask *scheduler.ScheduledTask
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		serverRoot:  params.ServerRoot,
+		appAPI:      params.AppAPI,
+		permissions: params.Permissions,
+		delivery:    params.Delivery,
+		logger:      params.Logger,
+		lastRun:     make(map[string]time.Time),
+	}
+}
+
+func (b *Backend) Start() error {
+	checkDueDates := func() {
+		if err := b.CheckDueDates(); err != nil {
+			b.logger.Error("Error running due date automations", mlog.Err(err))
+		}
+	}
+	b.dueDateTask = scheduler.CreateRecurringTask("automationDueDates", checkDueDates, dueDateCheckFrequency)
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	if b.dueDateTask != nil {
+		b.dueDateTask.Cancel()
+	}
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	if evt.Board == nil || evt.Board.IsTemplate || evt.Action == notify.Delete {
+		return nil
+	}
+	if evt.BlockChanged == nil || evt.BlockChanged.Type != model.TypeCard {
+		return nil
+	}
+
+	card, err := model.Block2Card(evt.BlockChanged)
+	if err != nil {
+		return fmt.Errorf("cannot convert block to card: %w", err)
+	}
+	if card.IsTemplate {
+		return nil
+	}
+
+	rules, err := b.appAPI.GetAutomationRules(evt.Board.ID)
+	if err != nil {
+		return fmt.Errorf("cannot get automation rules for board %s: %w", evt.Board.ID, err)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	schema, err := model.ParsePropertySchema(evt.Board)
+	if err != nil {
+		return err
+	}
+
+	merr := merror.New()
+	for _, rule := range rules {
+		if !rule.Enabled || !matchesCardChange(rule.Trigger, schema, evt) {
+			continue
+		}
+		if err := b.runRule(rule, evt.Board, card); err != nil {
+			merr.Append(err)
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// CardRelationAdded runs the relation rules of the board of the relation's
+// source card.
+func (b *Backend) CardRelationAdded(evt notify.CardRelationEvent) error {
+	if evt.Board == nil || evt.Board.IsTemplate || evt.Card == nil {
+		return nil
+	}
+
+	card, err := model.Block2Card(evt.Card)
+	if err != nil {
+		return fmt.Errorf("cannot convert block to card: %w", err)
+	}
+
+	rules, err := b.appAPI.GetAutomationRules(evt.Board.ID)
+	if err != nil {
+		return fmt.Errorf("cannot get automation rules for board %s: %w", evt.Board.ID, err)
+	}
+
+	merr := merror.New()
+	for _, rule := range rules {
+		if !rule.Enabled || rule.Trigger.Type != model.AutomationTriggerRelationAdded {
+			continue
+		}
+		if rule.Trigger.RelationType != "" && rule.Trigger.RelationType != evt.Relation.RelationType {
+			continue
+		}
+		if err := b.runRule(rule, evt.Board, card); err != nil {
+			merr.Append(err)
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// CheckDueDates runs the due date rules for the cards whose date passed
+// since the rule was last checked. Each rule is claimed before it is checked
+// so that a date passing runs the rule once across servers.
+func (b *Backend) CheckDueDates() error {
+	rules, err := b.appAPI.GetEnabledAutomationRulesByTrigger(model.AutomationTriggerDueDatePassed)
+	if err != nil {
+		return fmt.Errorf("cannot get due date automation rules: %w", err)
+	}
+
+	now := utils.GetMillis()
+	merr := merror.New()
+	for _, rule := range rules {
+		claimed, err := b.appAPI.ClaimAutomationRuleDueCheck(rule, now)
+		if err != nil {
+			merr.Append(fmt.Errorf("cannot claim automation rule %s: %w", rule.ID, err))
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if err := b.checkRuleDueDates(rule, rule.DueCheckedAt, now); err != nil {
+			merr.Append(err)
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+func (b *Backend) checkRuleDueDates(rule *model.AutomationRule, from, until int64) error {
+	board, err := b.appAPI.GetBoard(rule.BoardID)
+	if err != nil {
+		return fmt.Errorf("cannot get board %s: %w", rule.BoardID, err)
+	}
+	if board.IsTemplate {
+		return nil
+	}
+
+	merr := merror.New()
+	for page := 0; ; page++ {
+		cards, err := b.appAPI.GetCardsForBoard(board.ID, page, dueDateCardsPerPage)
+		if err != nil {
+			return fmt.Errorf("cannot get cards for board %s: %w", board.ID, err)
+		}
+
+		for _, card := range cards {
+			due, ok := model.CardDateEnd(card, rule.Trigger.PropertyID)
+			if !ok || card.IsTemplate || due <= from || due > until {
+				continue
+			}
+			if err := b.runRule(rule, board, card); err != nil {
+				merr.Append(err)
+			}
+		}
+
+		if len(cards) < dueDateCardsPerPage {
+			break
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// matchesCardChange returns true if a trigger matches the change of a card.
+func matchesCardChange(trigger model.AutomationTrigger, schema model.PropSchema, evt notify.BlockChangeEvent) bool {
+	switch trigger.Type {
+	case model.AutomationTriggerCardCreated:
+		return evt.Action == notify.Add
+
+	case model.AutomationTriggerStatusEntered:
+		prop, ok := trigger.StatusPropDef(schema)
+		if !ok {
+			return false
+		}
+		if propertyValue(evt.BlockChanged, prop.ID) != trigger.Value {
+			return false
+		}
+		return evt.Action == notify.Add || propertyValue(evt.BlockOld, prop.ID) != trigger.Value
+
+	case model.AutomationTriggerPropertyChanged:
+		if evt.Action != notify.Update || evt.BlockOld == nil {
+			return false
+		}
+		newValue := propertyValue(evt.BlockChanged, trigger.PropertyID)
+		if reflect.DeepEqual(propertyValue(evt.BlockOld, trigger.PropertyID), newValue) {
+			return false
+		}
+		return trigger.Value == "" || valueContains(newValue, trigger.Value)
+	}
+	return false
+}
+
+func propertyValue(block *model.Block, propertyID string) interface{} {
+	if block == nil {
+		return nil
+	}
+	properties, ok := block.Fields["properties"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return properties[propertyID]
+}
+
+func valueContains(value interface{}, expected string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == expected
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == expected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// claimRun records that a rule runs for a card, and returns false if the
+// rule already ran for the card during the cooldown.
+func (b *Backend) claimRun(ruleID string, cardID string) bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	now := time.Now()
+	key := ruleID + "/" + cardID
+	if last, ok := b.lastRun[key]; ok && now.Sub(last) < ruleCooldown {
+		return false
+	}
+
+	for k, last := range b.lastRun {
+		if now.Sub(last) >= ruleCooldown {
+			delete(b.lastRun, k)
+		}
+	}
+	b.lastRun[key] = now
+	return true
+}
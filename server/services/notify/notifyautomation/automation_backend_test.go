@@ -0,0 +1,382 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyautomation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type fakeAppAPI struct {
+	board     *model.Board
+	rules     []*model.AutomationRule
+	cards     map[string]*model.Card
+	claimed   bool
+	patches   []*model.CardPatch
+	comments  []*model.Block
+	created   []*model.Card
+	relations []*model.CardRelation
+	moves     []string
+}
+
+func (f *fakeAppAPI) GetAutomationRules(_ string) ([]*model.AutomationRule, error) {
+	return f.rules, nil
+}
+
+func (f *fakeAppAPI) GetEnabledAutomationRulesByTrigger(triggerType model.AutomationTriggerType) ([]*model.AutomationRule, error) {
+	var rules []*model.AutomationRule
+	for _, rule := range f.rules {
+		if rule.Enabled && rule.Trigger.Type == triggerType {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (f *fakeAppAPI) ClaimAutomationRuleDueCheck(_ *model.AutomationRule, _ int64) (bool, error) {
+	return f.claimed, nil
+}
+
+func (f *fakeAppAPI) GetBoard(boardID string) (*model.Board, error) {
+	board := *f.board
+	board.ID = boardID
+	return &board, nil
+}
+
+func (f *fakeAppAPI) GetCardByID(cardID string) (*model.Card, error) {
+	return f.cards[cardID], nil
+}
+
+func (f *fakeAppAPI) GetCardsForBoard(_ string, _ int, _ int) ([]*model.Card, error) {
+	cards := make([]*model.Card, 0, len(f.cards))
+	for _, card := range f.cards {
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+func (f *fakeAppAPI) PatchCard(cardPatch *model.CardPatch, cardID string, _ string, _ bool) (*model.Card, error) {
+	f.patches = append(f.patches, cardPatch)
+	return cardPatch.Patch(f.cards[cardID]), nil
+}
+
+func (f *fakeAppAPI) CreateCard(card *model.Card, boardID string, _ string, _ bool) (*model.Card, error) {
+	card.ID = utils.NewID(utils.IDTypeCard)
+	card.BoardID = boardID
+	f.created = append(f.created, card)
+	return card, nil
+}
+
+func (f *fakeAppAPI) MoveCard(cardID string, boardID string, _ string) (*model.Card, error) {
+	f.moves = append(f.moves, boardID)
+	card := *f.cards[cardID]
+	card.BoardID = boardID
+	return &card, nil
+}
+
+func (f *fakeAppAPI) InsertBlockAndNotify(block *model.Block, _ string, _ bool) error {
+	f.comments = append(f.comments, block)
+	return nil
+}
+
+func (f *fakeAppAPI) CreateCardRelation(relation *model.CardRelation, _ string) (*model.CardRelation, error) {
+	f.relations = append(f.relations, relation)
+	return relation, nil
+}
+
+type fakePermissions struct {
+	allowed bool
+}
+
+func (p *fakePermissions) HasPermissionTo(_ string, _ *mm_model.Permission) bool {
+	return p.allowed
+}
+
+func (p *fakePermissions) HasPermissionToTeam(_, _ string, _ *mm_model.Permission) bool {
+	return p.allowed
+}
+
+func (p *fakePermissions) HasPermissionToChannel(_, _ string, _ *mm_model.Permission) bool {
+	return p.allowed
+}
+
+func (p *fakePermissions) HasPermissionToBoard(_, _ string, _ *mm_model.Permission) bool {
+	return p.allowed
+}
+
+type fakeDelivery struct {
+	channelID string
+	message   string
+}
+
+func (d *fakeDelivery) ChannelDeliver(channelID string, message string) error {
+	d.channelID = channelID
+	d.message = message
+	return nil
+}
+
+func testBoard() *model.Board {
+	return &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		Title:  "Roadmap",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status-prop",
+				"name": "Status",
+				"type": model.PropTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do"},
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+			{"id": "owner-prop", "name": "Owner", "type": model.PropTypeMultiPerson},
+			{"id": "due-prop", "name": "Due", "type": model.PropTypeDate},
+		},
+	}
+}
+
+func cardBlock(status string) *model.Block {
+	return &model.Block{
+		ID:      "card-id",
+		BoardID: "board-id",
+		Type:    model.TypeCard,
+		Title:   "Launch",
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status-prop": status},
+		},
+	}
+}
+
+func newTestBackend(t *testing.T, appAPI *fakeAppAPI, allowed bool) (*Backend, *fakeDelivery) {
+	delivery := &fakeDelivery{}
+	backend := New(BackendParams{
+		ServerRoot:  "http://localhost/boards",
+		AppAPI:      appAPI,
+		Permissions: &fakePermissions{allowed: allowed},
+		Delivery:    delivery,
+		Logger:      mlog.CreateConsoleTestLogger(t),
+	})
+	return backend, delivery
+}
+
+func TestBackendBlockChanged(t *testing.T) {
+	doneRule := &model.AutomationRule{
+		ID:        "done-rule",
+		BoardID:   "board-id",
+		Enabled:   true,
+		CreatedBy: "creator-id",
+		Trigger:   model.AutomationTrigger{Type: model.AutomationTriggerStatusEntered, Value: "done"},
+		Actions: []model.AutomationAction{
+			{Type: model.AutomationActionAssignPerson, PropertyID: "owner-prop", UserID: "reviewer-id"},
+			{Type: model.AutomationActionAddComment, Text: "{{card.code}} done on {{board.title}}"},
+			{Type: model.AutomationActionPostToChannel, ChannelID: "channel-id", Text: "{{card.title}}: {{card.url}}"},
+		},
+	}
+
+	newAppAPI := func() *fakeAppAPI {
+		card, err := model.Block2Card(cardBlock("done"))
+		require.NoError(t, err)
+		card.Code = "RM-1"
+		return &fakeAppAPI{
+			board: testBoard(),
+			rules: []*model.AutomationRule{doneRule},
+			cards: map[string]*model.Card{card.ID: card},
+		}
+	}
+
+	statusChange := func(from, to string) notify.BlockChangeEvent {
+		return notify.BlockChangeEvent{
+			Action:       notify.Update,
+			Board:        testBoard(),
+			Card:         cardBlock(to),
+			BlockChanged: cardBlock(to),
+			BlockOld:     cardBlock(from),
+		}
+	}
+
+	t.Run("runs the actions when the card enters the status", func(t *testing.T) {
+		appAPI := newAppAPI()
+		backend, delivery := newTestBackend(t, appAPI, true)
+
+		require.NoError(t, backend.BlockChanged(statusChange("todo", "done")))
+
+		require.Len(t, appAPI.patches, 1)
+		assert.Equal(t, []interface{}{"reviewer-id"}, appAPI.patches[0].UpdatedProperties["owner-prop"])
+		require.Len(t, appAPI.comments, 1)
+		assert.Equal(t, "RM-1 done on Roadmap", appAPI.comments[0].Title)
+		assert.EqualValues(t, model.TypeComment, appAPI.comments[0].Type)
+		assert.Equal(t, "card-id", appAPI.comments[0].ParentID)
+		assert.Equal(t, "channel-id", delivery.channelID)
+		assert.Equal(t, "Launch: "+utils.MakeCardLink("http://localhost/boards", "team-id", "board-id", "card-id"), delivery.message)
+	})
+
+	t.Run("ignores changes that keep the status", func(t *testing.T) {
+		appAPI := newAppAPI()
+		backend, _ := newTestBackend(t, appAPI, true)
+
+		require.NoError(t, backend.BlockChanged(statusChange("done", "done")))
+		assert.Empty(t, appAPI.patches)
+		assert.Empty(t, appAPI.comments)
+	})
+
+	t.Run("does not run twice for a card during the cooldown", func(t *testing.T) {
+		appAPI := newAppAPI()
+		backend, _ := newTestBackend(t, appAPI, true)
+
+		require.NoError(t, backend.BlockChanged(statusChange("todo", "done")))
+		require.NoError(t, backend.BlockChanged(statusChange("todo", "done")))
+		assert.Len(t, appAPI.comments, 1)
+	})
+
+	t.Run("fails when the creator lost access to the board", func(t *testing.T) {
+		appAPI := newAppAPI()
+		backend, _ := newTestBackend(t, appAPI, false)
+
+		require.Error(t, backend.BlockChanged(statusChange("todo", "done")))
+		assert.Empty(t, appAPI.patches)
+	})
+
+	t.Run("disabled rules do not run", func(t *testing.T) {
+		appAPI := newAppAPI()
+		disabled := *doneRule
+		disabled.Enabled = false
+		appAPI.rules = []*model.AutomationRule{&disabled}
+		backend, _ := newTestBackend(t, appAPI, true)
+
+		require.NoError(t, backend.BlockChanged(statusChange("todo", "done")))
+		assert.Empty(t, appAPI.patches)
+	})
+}
+
+func TestMatchesCardChange(t *testing.T) {
+	schema, err := model.ParsePropertySchema(testBoard())
+	require.NoError(t, err)
+
+	created := notify.BlockChangeEvent{Action: notify.Add, BlockChanged: cardBlock("todo")}
+	updated := notify.BlockChangeEvent{Action: notify.Update, BlockChanged: cardBlock("done"), BlockOld: cardBlock("todo")}
+
+	testCases := []struct {
+		name    string
+		trigger model.AutomationTrigger
+		evt     notify.BlockChangeEvent
+		matches bool
+	}{
+		{"card created", model.AutomationTrigger{Type: model.AutomationTriggerCardCreated}, created, true},
+		{"card created on update", model.AutomationTrigger{Type: model.AutomationTriggerCardCreated}, updated, false},
+		{"created in the status", model.AutomationTrigger{Type: model.AutomationTriggerStatusEntered, Value: "todo"}, created, true},
+		{"other status entered", model.AutomationTrigger{Type: model.AutomationTriggerStatusEntered, Value: "todo"}, updated, false},
+		{"property changed", model.AutomationTrigger{Type: model.AutomationTriggerPropertyChanged, PropertyID: "status-prop"}, updated, true},
+		{"property changed to value", model.AutomationTrigger{Type: model.AutomationTriggerPropertyChanged, PropertyID: "status-prop", Value: "done"}, updated, true},
+		{"property changed to other value", model.AutomationTrigger{Type: model.AutomationTriggerPropertyChanged, PropertyID: "status-prop", Value: "todo"}, updated, false},
+		{"other property", model.AutomationTrigger{Type: model.AutomationTriggerPropertyChanged, PropertyID: "owner-prop"}, updated, false},
+		{"due dates do not match changes", model.AutomationTrigger{Type: model.AutomationTriggerDueDatePassed, PropertyID: "due-prop"}, updated, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.matches, matchesCardChange(tc.trigger, schema, tc.evt))
+		})
+	}
+}
+
+func TestBackendCardRelationAdded(t *testing.T) {
+	rule := &model.AutomationRule{
+		ID:        "relation-rule",
+		BoardID:   "board-id",
+		Enabled:   true,
+		CreatedBy: "creator-id",
+		Trigger:   model.AutomationTrigger{Type: model.AutomationTriggerRelationAdded, RelationType: model.RelationTypeIsBlockedBy},
+		Actions: []model.AutomationAction{
+			{Type: model.AutomationActionCreateLinkedCard, Title: "Unblock {{card.title}}", RelationType: model.RelationTypeIsBlockedBy},
+		},
+	}
+
+	card, err := model.Block2Card(cardBlock("todo"))
+	require.NoError(t, err)
+
+	evt := func(relationType model.RelationType) notify.CardRelationEvent {
+		return notify.CardRelationEvent{
+			Board:    testBoard(),
+			Card:     cardBlock("todo"),
+			Relation: &model.CardRelation{SourceCardID: "card-id", TargetCardID: "other-id", RelationType: relationType},
+		}
+	}
+
+	t.Run("creates a linked card", func(t *testing.T) {
+		appAPI := &fakeAppAPI{board: testBoard(), rules: []*model.AutomationRule{rule}, cards: map[string]*model.Card{card.ID: card}}
+		backend, _ := newTestBackend(t, appAPI, true)
+
+		require.NoError(t, backend.CardRelationAdded(evt(model.RelationTypeIsBlockedBy)))
+
+		require.Len(t, appAPI.created, 1)
+		assert.Equal(t, "Unblock Launch", appAPI.created[0].Title)
+		assert.Equal(t, "board-id", appAPI.created[0].BoardID)
+		require.Len(t, appAPI.relations, 1)
+		assert.Equal(t, "card-id", appAPI.relations[0].SourceCardID)
+		assert.Equal(t, appAPI.created[0].ID, appAPI.relations[0].TargetCardID)
+		assert.Equal(t, model.RelationTypeIsBlockedBy, appAPI.relations[0].RelationType)
+	})
+
+	t.Run("other relation types", func(t *testing.T) {
+		appAPI := &fakeAppAPI{board: testBoard(), rules: []*model.AutomationRule{rule}, cards: map[string]*model.Card{card.ID: card}}
+		backend, _ := newTestBackend(t, appAPI, true)
+
+		require.NoError(t, backend.CardRelationAdded(evt(model.RelationTypeRelatesTo)))
+		assert.Empty(t, appAPI.created)
+	})
+}
+
+func TestBackendCheckDueDates(t *testing.T) {
+	now := utils.GetMillis()
+	rule := &model.AutomationRule{
+		ID:           "due-rule",
+		BoardID:      "board-id",
+		Enabled:      true,
+		CreatedBy:    "creator-id",
+		Trigger:      model.AutomationTrigger{Type: model.AutomationTriggerDueDatePassed, PropertyID: "due-prop"},
+		Actions:      []model.AutomationAction{{Type: model.AutomationActionMoveToBoard, BoardID: "archive-id"}},
+		DueCheckedAt: now - 60000,
+	}
+
+	newCard := func(id string, due int64) *model.Card {
+		return &model.Card{
+			ID:         id,
+			BoardID:    "board-id",
+			Properties: map[string]interface{}{"due-prop": fmt.Sprintf(`{"from":%d}`, due)},
+		}
+	}
+
+	cards := map[string]*model.Card{
+		"passed":      newCard("passed", now-30000),
+		"already-due": newCard("already-due", now-120000),
+		"upcoming":    newCard("upcoming", now+3600000),
+	}
+
+	t.Run("runs the rule for the dates that passed since the last check", func(t *testing.T) {
+		appAPI := &fakeAppAPI{board: testBoard(), rules: []*model.AutomationRule{rule}, cards: cards, claimed: true}
+		backend, _ := newTestBackend(t, appAPI, true)
+
+		require.NoError(t, backend.CheckDueDates())
+		assert.Equal(t, []string{"archive-id"}, appAPI.moves)
+	})
+
+	t.Run("rules claimed by another server are skipped", func(t *testing.T) {
+		appAPI := &fakeAppAPI{board: testBoard(), rules: []*model.AutomationRule{rule}, cards: cards}
+		backend, _ := newTestBackend(t, appAPI, true)
+
+		require.NoError(t, backend.CheckDueDates())
+		assert.Empty(t, appAPI.moves)
+	})
+}
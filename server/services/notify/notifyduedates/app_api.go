@@ -0,0 +1,19 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+package notifyduedates
+
+import "github.com/mattermost/mattermost-plugin-boards/server/model"
+
+type AppAPI interface {
+	GetBoardsForCompliance(opts model.QueryBoardsForComplianceOptions) ([]*model.Board, bool, error)
+	GetCardsForBoard(boardID string, page int, perPage int) ([]*model.Card, error)
+	GetUserTimezone(userID string) (string, error)
+
+	ClaimDueDateNotification(notification *model.DueDateNotification) (bool, error)
+	DeleteDueDateNotificationsBefore(createAt int64) error
+}
+
+// DirectDelivery sends due date notifications to users.
+type DirectDelivery interface {
+	DirectDeliver(teamID string, userID string, message string) error
+}
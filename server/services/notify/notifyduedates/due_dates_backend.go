@@ -0,0 +1,348 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyduedates
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/scheduler"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	backendName = "notifyDueDates"
+
+	dueDateCheckFrequency = 5 * time.Minute
+	boardsPerPage         = 100
+	cardsPerPage          = 100
+
+	// overdueMaxAge is how long a card stays in the overdue digest after its
+	// due date.
+	overdueMaxAge = 30 * 24 * time.Hour
+
+	// notificationRetention is how long sent notifications are remembered.
+	notificationRetention = 60 * 24 * time.Hour
+)
+
+type BackendParams struct {
+	ServerRoot      string
+	AppAPI          AppAPI
+	Permissions     permissions.PermissionsService
+	Delivery        DirectDelivery
+	Logger          mlog.LoggerIFace
+	ReminderMinutes int
+	DigestHour      int
+}
+
+// Backend provides the notification backend that reminds assignees of cards
+// due soon, and sends them a daily digest of their overdue cards.
+type Backend struct {
+	serverRoot     string
+	appAPI         AppAPI
+	permissions    permissions.PermissionsService
+	delivery       DirectDelivery
+	logger         mlog.LoggerIFace
+	reminderBefore time.Duration
+	digestHour     int
+
+	dueDateTask *scheduler.ScheduledTask
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		serverRoot:     params.ServerRoot,
+		appAPI:         params.AppAPI,
+		permissions:    params.Permissions,
+		delivery:       params.Delivery,
+		logger:         params.Logger,
+		reminderBefore: time.Duration(params.ReminderMinutes) * time.Minute,
+		digestHour:     params.DigestHour,
+	}
+}
+
+func (b *Backend) Start() error {
+	checkDueDates := func() {
+		if err := b.CheckDueDates(time.Now()); err != nil {
+			b.logger.Error("Error sending due date notifications", mlog.Err(err))
+		}
+	}
+	b.dueDateTask = scheduler.CreateRecurringTask("dueDateNotifications", checkDueDates, dueDateCheckFrequency)
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	if b.dueDateTask != nil {
+		b.dueDateTask.Cancel()
+	}
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+func (b *Backend) BlockChanged(_ notify.BlockChangeEvent) error {
+	// due dates are checked on a schedule rather than when cards change.
+	return nil
+}
+
+// overdueCard is a card listed in the overdue digest of a user.
+type overdueCard struct {
+	board *model.Board
+	card  *model.Card
+	prop  model.PropDef
+	due   model.CardDueDate
+	dueAt time.Time
+}
+
+// dueDateCheck holds the state of one scan of the due dates.
+type dueDateCheck struct {
+	now       time.Time
+	locations map[string]*time.Location
+	overdue   map[string][]overdueCard
+}
+
+// CheckDueDates scans the cards with date properties, reminds their
+// assignees of the cards due soon and sends the daily overdue digests. Each
+// notification is claimed before it is sent, so that it is sent once across
+// servers.
+func (b *Backend) CheckDueDates(now time.Time) error {
+	check := &dueDateCheck{
+		now:       now,
+		locations: make(map[string]*time.Location),
+		overdue:   make(map[string][]overdueCard),
+	}
+
+	merr := merror.New()
+	for page := 0; ; page++ {
+		opts := model.QueryBoardsForComplianceOptions{Page: page, PerPage: boardsPerPage}
+		boards, hasMore, err := b.appAPI.GetBoardsForCompliance(opts)
+		if err != nil {
+			return fmt.Errorf("cannot get boards: %w", err)
+		}
+
+		for _, board := range boards {
+			if err := b.checkBoard(check, board); err != nil {
+				merr.Append(err)
+			}
+		}
+
+		if !hasMore {
+			break
+		}
+	}
+
+	if b.digestHour >= 0 {
+		for userID, cards := range check.overdue {
+			if err := b.sendOverdueDigest(check, userID, cards); err != nil {
+				merr.Append(err)
+			}
+		}
+	}
+
+	if err := b.appAPI.DeleteDueDateNotificationsBefore(now.Add(-notificationRetention).UnixMilli()); err != nil {
+		merr.Append(fmt.Errorf("cannot delete old due date notifications: %w", err))
+	}
+	return merr.ErrorOrNil()
+}
+
+func (b *Backend) checkBoard(check *dueDateCheck, board *model.Board) error {
+	if board.IsTemplate {
+		return nil
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return fmt.Errorf("cannot parse the properties of board %s: %w", board.ID, err)
+	}
+
+	var dateProps, personProps []model.PropDef
+	for _, prop := range schema {
+		switch prop.Type {
+		case model.PropTypeDate:
+			dateProps = append(dateProps, prop)
+		case model.PropTypePerson, model.PropTypeMultiPerson:
+			personProps = append(personProps, prop)
+		}
+	}
+	if len(dateProps) == 0 || len(personProps) == 0 {
+		return nil
+	}
+	sort.Slice(dateProps, func(i, j int) bool { return dateProps[i].Index < dateProps[j].Index })
+	workflowProp, hasWorkflow := schema.WorkflowPropDef(board)
+
+	merr := merror.New()
+	for page := 0; ; page++ {
+		cards, err := b.appAPI.GetCardsForBoard(board.ID, page, cardsPerPage)
+		if err != nil {
+			return fmt.Errorf("cannot get cards for board %s: %w", board.ID, err)
+		}
+
+		for _, card := range cards {
+			if card.IsTemplate {
+				continue
+			}
+			if hasWorkflow {
+				// done cards need no reminder, even when their date passed
+				optionID, _ := card.Properties[workflowProp.ID].(string)
+				if workflowProp.IsDoneOption(optionID) {
+					continue
+				}
+			}
+			assignees := cardAssignees(card, personProps)
+			for _, prop := range dateProps {
+				due, ok := model.GetCardDueDate(card, prop.ID)
+				if !ok {
+					continue
+				}
+				for _, userID := range assignees {
+					if err := b.checkAssignee(check, board, card, prop, due, userID); err != nil {
+						merr.Append(err)
+					}
+				}
+			}
+		}
+
+		if len(cards) < cardsPerPage {
+			break
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// checkAssignee reminds an assignee of a card due soon, or records the card
+// for the assignee's overdue digest.
+func (b *Backend) checkAssignee(check *dueDateCheck, board *model.Board, card *model.Card, prop model.PropDef, due model.CardDueDate, userID string) error {
+	if !b.permissions.HasPermissionToBoard(userID, board.ID, model.PermissionViewBoard) {
+		return nil
+	}
+
+	loc := b.userLocation(check, userID)
+	dueAt := due.DueAt(loc)
+
+	if !check.now.Before(dueAt) {
+		if check.now.Sub(dueAt) <= overdueMaxAge {
+			check.overdue[userID] = append(check.overdue[userID], overdueCard{
+				board: board,
+				card:  card,
+				prop:  prop,
+				due:   due,
+				dueAt: dueAt,
+			})
+		}
+		return nil
+	}
+
+	if b.reminderBefore <= 0 || dueAt.Sub(check.now) > b.reminderBefore {
+		return nil
+	}
+
+	claimed, err := b.appAPI.ClaimDueDateNotification(&model.DueDateNotification{
+		Kind:     model.DueDateNotificationReminder,
+		UserID:   userID,
+		CardID:   card.ID,
+		Key:      due.ReminderKey(),
+		CreateAt: check.now.UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot claim due date reminder for card %s: %w", card.ID, err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	message := formatReminder(b.serverRoot, board, card, prop, due.Format(loc))
+	if err := b.delivery.DirectDeliver(board.TeamID, userID, message); err != nil {
+		return fmt.Errorf("cannot send due date reminder for card %s to user %s: %w", card.ID, userID, err)
+	}
+	return nil
+}
+
+// sendOverdueDigest sends the overdue digest of a user once a day, after the
+// digest hour of the user's timezone.
+func (b *Backend) sendOverdueDigest(check *dueDateCheck, userID string, cards []overdueCard) error {
+	localNow := check.now.In(b.userLocation(check, userID))
+	if localNow.Hour() < b.digestHour {
+		return nil
+	}
+
+	claimed, err := b.appAPI.ClaimDueDateNotification(&model.DueDateNotification{
+		Kind:     model.DueDateNotificationOverdueDigest,
+		UserID:   userID,
+		Key:      model.DigestKey(localNow),
+		CreateAt: check.now.UnixMilli(),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot claim overdue digest for user %s: %w", userID, err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	sort.Slice(cards, func(i, j int) bool { return cards[i].dueAt.Before(cards[j].dueAt) })
+
+	message := formatOverdueDigest(b.serverRoot, cards, localNow.Location())
+	if err := b.delivery.DirectDeliver(cards[0].board.TeamID, userID, message); err != nil {
+		return fmt.Errorf("cannot send overdue digest to user %s: %w", userID, err)
+	}
+	return nil
+}
+
+// userLocation returns the location of the user's timezone, or UTC if it is
+// not set or unknown.
+func (b *Backend) userLocation(check *dueDateCheck, userID string) *time.Location {
+	if loc, ok := check.locations[userID]; ok {
+		return loc
+	}
+
+	loc := time.UTC
+	timezone, err := b.appAPI.GetUserTimezone(userID)
+	if err != nil {
+		b.logger.Warn("Cannot get user timezone for due date notifications",
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+	} else if timezone != "" {
+		if l, err := time.LoadLocation(timezone); err == nil {
+			loc = l
+		}
+	}
+
+	check.locations[userID] = loc
+	return loc
+}
+
+// cardAssignees returns the users set in the person properties of a card.
+func cardAssignees(card *model.Card, personProps []model.PropDef) []string {
+	var userIDs []string
+	seen := make(map[string]bool)
+	add := func(value interface{}) {
+		userID, ok := value.(string)
+		if !ok || userID == "" || seen[userID] {
+			return
+		}
+		seen[userID] = true
+		userIDs = append(userIDs, userID)
+	}
+
+	for _, prop := range personProps {
+		switch value := card.Properties[prop.ID].(type) {
+		case string:
+			add(value)
+		case []interface{}:
+			for _, item := range value {
+				add(item)
+			}
+		}
+	}
+	return userIDs
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyduedates
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type fakeAppAPI struct {
+	boards    []*model.Board
+	cards     map[string][]*model.Card
+	timezones map[string]string
+	claimed   map[string]bool
+}
+
+func (a *fakeAppAPI) GetBoardsForCompliance(_ model.QueryBoardsForComplianceOptions) ([]*model.Board, bool, error) {
+	return a.boards, false, nil
+}
+
+func (a *fakeAppAPI) GetCardsForBoard(boardID string, page int, _ int) ([]*model.Card, error) {
+	if page > 0 {
+		return []*model.Card{}, nil
+	}
+	return a.cards[boardID], nil
+}
+
+func (a *fakeAppAPI) GetUserTimezone(userID string) (string, error) {
+	return a.timezones[userID], nil
+}
+
+func (a *fakeAppAPI) ClaimDueDateNotification(notification *model.DueDateNotification) (bool, error) {
+	key := fmt.Sprintf("%s/%s/%s/%s", notification.Kind, notification.UserID, notification.CardID, notification.Key)
+	if a.claimed[key] {
+		return false, nil
+	}
+	a.claimed[key] = true
+	return true, nil
+}
+
+func (a *fakeAppAPI) DeleteDueDateNotificationsBefore(_ int64) error {
+	return nil
+}
+
+type fakePermissions struct{}
+
+func (p *fakePermissions) HasPermissionTo(_ string, _ *mm_model.Permission) bool {
+	return true
+}
+
+func (p *fakePermissions) HasPermissionToTeam(_, _ string, _ *mm_model.Permission) bool {
+	return true
+}
+
+func (p *fakePermissions) HasPermissionToChannel(_, _ string, _ *mm_model.Permission) bool {
+	return true
+}
+
+func (p *fakePermissions) HasPermissionToBoard(userID, _ string, _ *mm_model.Permission) bool {
+	return userID != "no-access"
+}
+
+type fakeDelivery struct {
+	messages map[string][]string
+}
+
+func (d *fakeDelivery) DirectDeliver(_ string, userID string, message string) error {
+	d.messages[userID] = append(d.messages[userID], message)
+	return nil
+}
+
+func setupBackend(t *testing.T, cards ...*model.Card) (*Backend, *fakeDelivery) {
+	t.Helper()
+
+	board := &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		Title:  "Roadmap",
+		CardProperties: []map[string]interface{}{
+			{"id": "due", "name": "Due", "type": model.PropTypeDate},
+			{"id": "owner", "name": "Owner", "type": model.PropTypePerson},
+			{"id": "reviewers", "name": "Reviewers", "type": model.PropTypeMultiPerson},
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": model.PropTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "opt-todo", "value": "To Do"},
+					map[string]interface{}{"id": "opt-done", "value": "Done"},
+				},
+			},
+		},
+	}
+	appAPI := &fakeAppAPI{
+		boards: []*model.Board{board},
+		cards:  map[string][]*model.Card{board.ID: cards},
+		timezones: map[string]string{
+			"user-honolulu": "Pacific/Honolulu",
+			"user-tokyo":    "Asia/Tokyo",
+		},
+		claimed: make(map[string]bool),
+	}
+	delivery := &fakeDelivery{messages: make(map[string][]string)}
+
+	backend := New(BackendParams{
+		ServerRoot:      "http://localhost",
+		AppAPI:          appAPI,
+		Permissions:     &fakePermissions{},
+		Delivery:        delivery,
+		Logger:          mlog.CreateConsoleTestLogger(t),
+		ReminderMinutes: 24 * 60,
+		DigestHour:      9,
+	})
+	return backend, delivery
+}
+
+func newCard(id string, day time.Time, assignees map[string]interface{}) *model.Card {
+	properties := map[string]interface{}{
+		"due": fmt.Sprintf(`{"from":%d}`, day.UnixMilli()),
+	}
+	for k, v := range assignees {
+		properties[k] = v
+	}
+	return &model.Card{ID: id, BoardID: "board-id", Title: "Card " + id, Properties: properties}
+}
+
+func TestCheckDueDatesReminders(t *testing.T) {
+	// cards due on October 18, with a reminder one day before
+	dueDay := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, time.October, 17, 8, 0, 0, 0, time.UTC)
+
+	card := newCard("card-1", dueDay, map[string]interface{}{
+		"owner":     "user-utc",
+		"reviewers": []interface{}{"user-honolulu", "user-utc", "no-access"},
+	})
+	backend, delivery := setupBackend(t, card)
+
+	require.NoError(t, backend.CheckDueDates(now))
+
+	require.Len(t, delivery.messages["user-utc"], 1)
+	assert.Contains(t, delivery.messages["user-utc"][0], "[Card card-1](http://localhost/team/team-id/board-id/0/card-1)")
+	assert.Contains(t, delivery.messages["user-utc"][0], "is due on October 18, 2026 (Due)")
+	assert.Empty(t, delivery.messages["no-access"])
+
+	// the day starts later in Honolulu, more than a day from now
+	assert.Empty(t, delivery.messages["user-honolulu"])
+
+	t.Run("reminders are sent once", func(t *testing.T) {
+		require.NoError(t, backend.CheckDueDates(now.Add(5*time.Minute)))
+		assert.Len(t, delivery.messages["user-utc"], 1)
+	})
+
+	t.Run("reminder in the user's timezone", func(t *testing.T) {
+		require.NoError(t, backend.CheckDueDates(now.Add(3*time.Hour)))
+		assert.Len(t, delivery.messages["user-honolulu"], 1)
+	})
+}
+
+func TestCheckDueDatesOverdueDigest(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 8, 0, 0, 0, time.UTC)
+	cards := []*model.Card{
+		newCard("late", time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC), map[string]interface{}{"owner": "user-tokyo"}),
+		newCard("later", time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC), map[string]interface{}{"owner": "user-tokyo"}),
+		newCard("utc", time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC), map[string]interface{}{"owner": "user-utc"}),
+		newCard("ancient", time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), map[string]interface{}{"owner": "user-tokyo"}),
+	}
+	backend, delivery := setupBackend(t, cards...)
+
+	require.NoError(t, backend.CheckDueDates(now))
+
+	// it is 5pm in Tokyo, and 8am in UTC
+	require.Len(t, delivery.messages["user-tokyo"], 1)
+	digest := delivery.messages["user-tokyo"][0]
+	assert.Contains(t, digest, "You have 2 overdue cards:")
+	assert.Less(t, strings.Index(digest, "Card late"), strings.Index(digest, "Card later"))
+	assert.NotContains(t, digest, "Card ancient")
+	assert.Empty(t, delivery.messages["user-utc"])
+
+	require.NoError(t, backend.CheckDueDates(now.Add(time.Hour)))
+	assert.Len(t, delivery.messages["user-tokyo"], 1, "the digest is sent once a day")
+	require.Len(t, delivery.messages["user-utc"], 1)
+	assert.Contains(t, delivery.messages["user-utc"][0], "You have 1 overdue cards:")
+}
+
+func TestCheckDueDatesSkipsDoneCards(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 8, 0, 0, 0, time.UTC)
+	dueSoon := newCard("due-soon", time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC), map[string]interface{}{
+		"owner":  "user-utc",
+		"status": "opt-done",
+	})
+	overdue := newCard("overdue", time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC), map[string]interface{}{
+		"owner":  "user-tokyo",
+		"status": "opt-done",
+	})
+	open := newCard("open", time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC), map[string]interface{}{
+		"owner":  "user-tokyo",
+		"status": "opt-todo",
+	})
+	backend, delivery := setupBackend(t, dueSoon, overdue, open)
+
+	require.NoError(t, backend.CheckDueDates(now))
+
+	assert.Empty(t, delivery.messages["user-utc"])
+	require.Len(t, delivery.messages["user-tokyo"], 1)
+	digest := delivery.messages["user-tokyo"][0]
+	assert.Contains(t, digest, "You have 1 overdue cards:")
+	assert.Contains(t, digest, "Card open")
+	assert.NotContains(t, digest, "Card overdue")
+}
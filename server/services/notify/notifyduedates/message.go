@@ -0,0 +1,53 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyduedates
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+const (
+	// TODO: localize these when i18n is available.
+	defReminderTemplate   = "Reminder: the card [%s](%s) in board [%s](%s) is due on %s (%s)."
+	defDigestTemplate     = "You have %d overdue cards:"
+	defDigestCardTemplate = "- [%s](%s) in board [%s](%s) was due on %s (%s)"
+	defDigestMoreTemplate = "- ...and %d more"
+	defUntitledCard       = "Untitled"
+
+	maxDigestCards = 25
+)
+
+func formatReminder(serverRoot string, board *model.Board, card *model.Card, prop model.PropDef, due string) string {
+	return fmt.Sprintf(defReminderTemplate,
+		cardTitle(card), utils.MakeCardLink(serverRoot, board.TeamID, board.ID, card.ID),
+		board.Title, utils.MakeBoardLink(serverRoot, board.TeamID, board.ID),
+		due, prop.Name)
+}
+
+func formatOverdueDigest(serverRoot string, cards []overdueCard, loc *time.Location) string {
+	lines := []string{fmt.Sprintf(defDigestTemplate, len(cards))}
+	for i, c := range cards {
+		if i == maxDigestCards {
+			lines = append(lines, fmt.Sprintf(defDigestMoreTemplate, len(cards)-maxDigestCards))
+			break
+		}
+		lines = append(lines, fmt.Sprintf(defDigestCardTemplate,
+			cardTitle(c.card), utils.MakeCardLink(serverRoot, c.board.TeamID, c.board.ID, c.card.ID),
+			c.board.Title, utils.MakeBoardLink(serverRoot, c.board.TeamID, c.board.ID),
+			c.due.Format(loc), c.prop.Name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func cardTitle(card *model.Card) string {
+	if card.Title == "" {
+		return defUntitledCard
+	}
+	return card.Title
+}
@@ -36,12 +36,29 @@ func extractText(s string, mention string, limits limits) string {
 	if !strings.HasPrefix(mention, "@") {
 		mention = "@" + mention
 	}
+	return extractAround(s, limits, func(l string) int {
+		return strings.Index(l, mention)
+	})
+}
+
+// ExtractSnippet returns the part of the input string around the first case
+// insensitive occurrence of term, limited the same way as mention extracts.
+func ExtractSnippet(s string, term string) string {
+	term = strings.ToLower(term)
+	return extractAround(s, newLimits(), func(l string) int {
+		return strings.Index(strings.ToLower(l), term)
+	})
+}
+
+// extractAround returns the text around the first line for which index
+// finds a match.
+func extractAround(s string, limits limits, index func(string) int) string {
 	lines := strings.Split(s, "\n")
 
-	// find first line with mention
+	// find first line with a match
 	found := -1
 	for i, l := range lines {
-		if strings.Contains(l, mention) {
+		if index(l) >= 0 {
 			found = i
 			break
 		}
@@ -54,8 +71,8 @@ func extractText(s string, mention string, limits limits) string {
 	suffix := safeConcat(lines, found+1, found+limits.suffixLines+1)
 	combined := strings.TrimSpace(strings.Join([]string{prefix, lines[found], suffix}, "\n"))
 
-	// find mention position within
-	pos := strings.Index(combined, mention)
+	// find match position within
+	pos := index(combined)
 	pos = max(pos, 0)
 
 	return safeSubstr(combined, pos-limits.prefixMaxChars, pos+limits.suffixMaxChars)
@@ -63,6 +63,26 @@ func Test_extractText(t *testing.T) {
 	}
 }
 
+func Test_ExtractSnippet(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		term string
+		want string
+	}{
+		{name: "case insensitive", want: join(s3, s4, s5, s6, s7[:11]), s: allConcat, term: "fast five"},
+		{name: "not found", want: "", s: allConcat, term: "bogus"},
+		{name: "first line", want: join(s0, s1, s2), s: allConcat, term: "zero"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractSnippet(tt.s, tt.term); got != tt.want {
+				t.Errorf("ExtractSnippet()\ngot:\n%v\nwant:\n%v\n", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_safeConcat(t *testing.T) {
 	type args struct {
 		lines []string
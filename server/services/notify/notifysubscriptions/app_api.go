@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
 )
 
 type AppAPI interface {
@@ -15,6 +17,7 @@ type AppAPI interface {
 	GetBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error)
 
 	GetUserByID(userID string) (*model.User, error)
+	GetUserPreferences(userID string) (mm_model.Preferences, error)
 
 	CreateSubscription(sub *model.Subscription) (*model.Subscription, error)
 	GetSubscribersForBlock(blockID string) ([]*model.Subscriber, error)
@@ -22,4 +25,10 @@ type AppAPI interface {
 
 	UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error)
 	GetNextNotificationHint(remove bool) (*model.NotificationHint, error)
+
+	UpsertActivityDigestChange(change *model.ActivityDigestChange) error
+	GetActivityDigestChanges(since int64) ([]*model.ActivityDigestChange, error)
+	DeleteActivityDigestChangesBefore(updateAt int64) error
+	GetActivityDigestSentAt(userID string) (int64, error)
+	ClaimActivityDigest(userID string, lastSentAt int64, sentAt int64) (bool, error)
 }
@@ -0,0 +1,279 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifysubscriptions
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/scheduler"
+	"github.com/wiggin77/merror"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	digestCheckFrequency = 15 * time.Minute
+
+	// digestChangeRetention is how long card changes are kept for the
+	// digests. It is longer than the weekly period so that a late digest
+	// still finds its changes.
+	digestChangeRetention = 8 * 24 * time.Hour
+
+	// maxDigestCards is the number of cards listed in one digest.
+	maxDigestCards = 50
+)
+
+// digester sends the users who opted in a digest of the changes of the boards
+// and cards they are subscribed to, once a day or once a week, instead of a
+// notification per change.
+type digester struct {
+	serverRoot  string
+	store       AppAPI
+	permissions permissions.PermissionsService
+	delivery    SubscriptionDelivery
+	logger      mlog.LoggerIFace
+
+	task *scheduler.ScheduledTask
+}
+
+func newDigester(params BackendParams) *digester {
+	return &digester{
+		serverRoot:  params.ServerRoot,
+		store:       params.AppAPI,
+		permissions: params.Permissions,
+		delivery:    params.Delivery,
+		logger:      params.Logger,
+	}
+}
+
+func (d *digester) start() {
+	sendDigests := func() {
+		if err := d.sendDigests(time.Now()); err != nil {
+			d.logger.Error("Error sending activity digests", mlog.Err(err))
+		}
+	}
+	d.task = scheduler.CreateRecurringTask("activityDigests", sendDigests, digestCheckFrequency)
+}
+
+func (d *digester) stop() {
+	if d.task != nil {
+		d.task.Cancel()
+	}
+}
+
+// userDigestFrequency returns the activity digest frequency of a user, or
+// off if it cannot be read so that the user keeps receiving notifications.
+func userDigestFrequency(store AppAPI, userID string) model.ActivityDigestFrequency {
+	preferences, err := store.GetUserPreferences(userID)
+	if err != nil {
+		return model.ActivityDigestOff
+	}
+	return model.GetActivityDigestFrequency(preferences)
+}
+
+// sendDigests sends the digests that are due. Each digest covers the changes
+// since the previous one, and is claimed before it is sent so that it is sent
+// once across servers.
+func (d *digester) sendDigests(now time.Time) error {
+	changes, err := d.store.GetActivityDigestChanges(now.Add(-digestChangeRetention).UnixMilli())
+	if err != nil {
+		return fmt.Errorf("cannot get activity digest changes: %w", err)
+	}
+
+	merr := merror.New()
+
+	// collect the changed cards of each subscribed user.
+	userChanges := make(map[string][]*model.ActivityDigestChange)
+	boardSubs := make(map[string][]*model.Subscriber)
+	for _, change := range changes {
+		subs, ok := boardSubs[change.BoardID]
+		if !ok {
+			if subs, err = d.store.GetSubscribersForBlock(change.BoardID); err != nil {
+				merr.Append(fmt.Errorf("cannot fetch subscribers for board %s: %w", change.BoardID, err))
+			}
+			boardSubs[change.BoardID] = subs
+		}
+		cardSubs, err := d.store.GetSubscribersForBlock(change.CardID)
+		if err != nil {
+			merr.Append(fmt.Errorf("cannot fetch subscribers for card %s: %w", change.CardID, err))
+		}
+
+		seen := make(map[string]bool)
+		for _, sub := range append(cardSubs, subs...) {
+			if sub.SubscriberType != model.SubTypeUser || seen[sub.SubscriberID] {
+				continue
+			}
+			seen[sub.SubscriberID] = true
+			userChanges[sub.SubscriberID] = append(userChanges[sub.SubscriberID], change)
+		}
+	}
+
+	for userID, changes := range userChanges {
+		if err := d.sendDigest(userID, changes, now); err != nil {
+			merr.Append(err)
+		}
+	}
+
+	if err := d.store.DeleteActivityDigestChangesBefore(now.Add(-digestChangeRetention).UnixMilli()); err != nil {
+		merr.Append(fmt.Errorf("cannot delete old activity digest changes: %w", err))
+	}
+	return merr.ErrorOrNil()
+}
+
+// sendDigest sends the digest of a user if the user's period has elapsed.
+func (d *digester) sendDigest(userID string, changes []*model.ActivityDigestChange, now time.Time) error {
+	frequency := userDigestFrequency(d.store, userID)
+	if frequency == model.ActivityDigestOff {
+		return nil
+	}
+
+	lastSentAt, err := d.store.GetActivityDigestSentAt(userID)
+	if model.IsErrNotFound(err) {
+		// the digest starts with the first change the user was not notified of.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot get activity digest of user %s: %w", userID, err)
+	}
+	if now.Sub(time.UnixMilli(lastSentAt)) < frequency.Period() {
+		return nil
+	}
+
+	var pending []*model.ActivityDigestChange
+	for _, change := range changes {
+		if change.UpdateAt > lastSentAt {
+			pending = append(pending, change)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	claimed, err := d.store.ClaimActivityDigest(userID, lastSentAt, now.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("cannot claim activity digest of user %s: %w", userID, err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	boards, diffs := d.generateDigestDiffs(userID, pending, lastSentAt)
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	attachments, err := d.digestAttachments(frequency, boards, diffs)
+	if err != nil {
+		return fmt.Errorf("cannot write activity digest of user %s: %w", userID, err)
+	}
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	if err := d.delivery.SubscriptionDeliverSlackAttachments(boards[0].TeamID, userID, model.SubTypeUser, attachments); err != nil {
+		return fmt.Errorf("cannot deliver activity digest to user %s: %w", userID, err)
+	}
+	return nil
+}
+
+// generateDigestDiffs returns the diffs of the changed cards since the last
+// digest, keyed by board, and the boards sorted by title. Changes made only
+// by the user and cards of boards the user can no longer view are left out.
+func (d *digester) generateDigestDiffs(userID string, changes []*model.ActivityDigestChange, lastSentAt int64) ([]*model.Board, map[string][]*Diff) {
+	var boards []*model.Board
+	diffs := make(map[string][]*Diff)
+
+	for _, change := range changes {
+		if !d.permissions.HasPermissionToBoard(userID, change.BoardID, model.PermissionViewBoard) {
+			continue
+		}
+
+		board, card, err := d.store.GetBoardAndCardByID(change.CardID)
+		if err != nil || board == nil || card == nil {
+			d.logger.Warn("Cannot get the card of an activity digest change",
+				mlog.String("card_id", change.CardID),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		dg := &diffGenerator{
+			board:        board,
+			card:         card,
+			store:        d.store,
+			hint:         &model.NotificationHint{BlockType: model.TypeCard, BlockID: card.ID},
+			lastNotifyAt: lastSentAt,
+			logger:       d.logger,
+		}
+		cardDiffs, err := dg.generateDiffs()
+		if err != nil {
+			d.logger.Warn("Cannot generate the diffs of an activity digest change",
+				mlog.String("card_id", change.CardID),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		for _, diff := range cardDiffs {
+			// don't notify the user of their own changes.
+			if _, isAuthor := diff.Authors[userID]; isAuthor && len(diff.Authors) == 1 {
+				continue
+			}
+			if _, ok := diffs[board.ID]; !ok {
+				boards = append(boards, board)
+			}
+			diffs[board.ID] = append(diffs[board.ID], diff)
+		}
+	}
+
+	sort.Slice(boards, func(i, j int) bool { return boards[i].Title < boards[j].Title })
+	return boards, diffs
+}
+
+// digestAttachments renders a digest with the card change templates, under a
+// heading for each board.
+func (d *digester) digestAttachments(frequency model.ActivityDigestFrequency, boards []*model.Board, diffs map[string][]*Diff) ([]*mm_model.SlackAttachment, error) {
+	opts := makeDiffConvOpts(d.serverRoot, d.logger)
+
+	title := fmt.Sprintf("#### Your %s digest of board activity", frequency)
+	attachments := []*mm_model.SlackAttachment{{Pretext: title, Fallback: title}}
+
+	cards := 0
+	truncated := false
+	for _, board := range boards {
+		boardAttachments, err := Diffs2SlackAttachments(diffs[board.ID], opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(boardAttachments) == 0 {
+			continue
+		}
+		if cards+len(boardAttachments) > maxDigestCards {
+			boardAttachments = boardAttachments[:maxDigestCards-cards]
+			truncated = true
+		}
+		if len(boardAttachments) == 0 {
+			break
+		}
+
+		heading := "##### " + opts.MakeBoardLink(board)
+		attachments = append(attachments, &mm_model.SlackAttachment{Pretext: heading, Fallback: heading})
+		attachments = append(attachments, boardAttachments...)
+		cards += len(boardAttachments)
+	}
+
+	if cards == 0 {
+		return nil, nil
+	}
+	if truncated {
+		more := fmt.Sprintf("More cards changed, only the first %d are listed.", maxDigestCards)
+		attachments = append(attachments, &mm_model.SlackAttachment{Pretext: more, Fallback: more})
+	}
+	return attachments, nil
+}
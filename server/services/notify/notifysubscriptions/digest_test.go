@@ -0,0 +1,282 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifysubscriptions
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type fakeAppAPI struct {
+	boards      map[string]*model.Board
+	history     map[string][]*model.Block
+	subscribers map[string][]*model.Subscriber
+	frequencies map[string]model.ActivityDigestFrequency
+	changes     map[string]*model.ActivityDigestChange
+	sentAt      map[string]int64
+}
+
+func (a *fakeAppAPI) GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error) {
+	var blocks []*model.Block
+	for _, block := range a.history[blockID] {
+		if opts.BeforeUpdateAt != 0 && block.UpdateAt >= opts.BeforeUpdateAt {
+			continue
+		}
+		if opts.AfterUpdateAt != 0 && block.UpdateAt <= opts.AfterUpdateAt {
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	if opts.Descending {
+		sort.Slice(blocks, func(i, j int) bool { return blocks[i].UpdateAt > blocks[j].UpdateAt })
+	}
+	if opts.Limit != 0 && uint64(len(blocks)) > opts.Limit {
+		blocks = blocks[:opts.Limit]
+	}
+	return blocks, nil
+}
+
+func (a *fakeAppAPI) GetBlockHistoryNewestChildren(_ string, _ model.QueryBlockHistoryChildOptions) ([]*model.Block, bool, error) {
+	return nil, false, nil
+}
+
+func (a *fakeAppAPI) GetBoardAndCardByID(blockID string) (*model.Board, *model.Block, error) {
+	versions := a.history[blockID]
+	if len(versions) == 0 {
+		return nil, nil, model.NewErrNotFound(blockID)
+	}
+	card := versions[len(versions)-1]
+	return a.boards[card.BoardID], card, nil
+}
+
+func (a *fakeAppAPI) GetUserByID(userID string) (*model.User, error) {
+	return &model.User{ID: userID, Username: userID}, nil
+}
+
+func (a *fakeAppAPI) GetUserPreferences(userID string) (mm_model.Preferences, error) {
+	frequency, ok := a.frequencies[userID]
+	if !ok {
+		return mm_model.Preferences{}, nil
+	}
+	return mm_model.Preferences{{
+		UserId:   userID,
+		Category: model.PreferencesCategoryFocalboard,
+		Name:     model.PreferenceActivityDigest,
+		Value:    string(frequency),
+	}}, nil
+}
+
+func (a *fakeAppAPI) CreateSubscription(sub *model.Subscription) (*model.Subscription, error) {
+	return sub, nil
+}
+
+func (a *fakeAppAPI) GetSubscribersForBlock(blockID string) ([]*model.Subscriber, error) {
+	return a.subscribers[blockID], nil
+}
+
+func (a *fakeAppAPI) UpdateSubscribersNotifiedAt(_ string, _ int64) error {
+	return nil
+}
+
+func (a *fakeAppAPI) UpsertNotificationHint(hint *model.NotificationHint, _ time.Duration) (*model.NotificationHint, error) {
+	return hint, nil
+}
+
+func (a *fakeAppAPI) GetNextNotificationHint(_ bool) (*model.NotificationHint, error) {
+	return nil, model.NewErrNotFound("hint")
+}
+
+func (a *fakeAppAPI) UpsertActivityDigestChange(change *model.ActivityDigestChange) error {
+	a.changes[change.CardID] = change
+	return nil
+}
+
+func (a *fakeAppAPI) GetActivityDigestChanges(since int64) ([]*model.ActivityDigestChange, error) {
+	changes := []*model.ActivityDigestChange{}
+	for _, change := range a.changes {
+		if change.UpdateAt > since {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func (a *fakeAppAPI) DeleteActivityDigestChangesBefore(updateAt int64) error {
+	for cardID, change := range a.changes {
+		if change.UpdateAt < updateAt {
+			delete(a.changes, cardID)
+		}
+	}
+	return nil
+}
+
+func (a *fakeAppAPI) GetActivityDigestSentAt(userID string) (int64, error) {
+	sentAt, ok := a.sentAt[userID]
+	if !ok {
+		return 0, model.NewErrNotFound(userID)
+	}
+	return sentAt, nil
+}
+
+func (a *fakeAppAPI) ClaimActivityDigest(userID string, lastSentAt int64, sentAt int64) (bool, error) {
+	if a.sentAt[userID] != lastSentAt {
+		return false, nil
+	}
+	a.sentAt[userID] = sentAt
+	return true, nil
+}
+
+type fakePermissions struct {
+	// denied holds the "userID/boardID" pairs without access to the board.
+	denied map[string]bool
+}
+
+func (p *fakePermissions) HasPermissionTo(_ string, _ *mm_model.Permission) bool {
+	return true
+}
+
+func (p *fakePermissions) HasPermissionToTeam(_, _ string, _ *mm_model.Permission) bool {
+	return true
+}
+
+func (p *fakePermissions) HasPermissionToChannel(_, _ string, _ *mm_model.Permission) bool {
+	return true
+}
+
+func (p *fakePermissions) HasPermissionToBoard(userID, boardID string, _ *mm_model.Permission) bool {
+	return !p.denied[userID+"/"+boardID]
+}
+
+type fakeDelivery struct {
+	attachments map[string][][]*mm_model.SlackAttachment
+}
+
+func (d *fakeDelivery) SubscriptionDeliverSlackAttachments(_ string, subscriberID string, _ model.SubscriberType, attachments []*mm_model.SlackAttachment) error {
+	d.attachments[subscriberID] = append(d.attachments[subscriberID], attachments)
+	return nil
+}
+
+func userSub(userID string, notifiedAt int64) *model.Subscriber {
+	return &model.Subscriber{SubscriberType: model.SubTypeUser, SubscriberID: userID, NotifiedAt: notifiedAt}
+}
+
+func cardVersion(id string, boardID string, title string, modifiedBy string, updateAt time.Time) *model.Block {
+	return &model.Block{
+		ID:         id,
+		BoardID:    boardID,
+		Type:       model.TypeCard,
+		Title:      title,
+		ModifiedBy: modifiedBy,
+		UpdateAt:   updateAt.UnixMilli(),
+		Fields:     map[string]interface{}{},
+	}
+}
+
+func setupDigestTest(t *testing.T, now time.Time) (*fakeAppAPI, *fakeDelivery, BackendParams) {
+	t.Helper()
+
+	changedAt := now.Add(-2 * time.Hour)
+	appAPI := &fakeAppAPI{
+		boards: map[string]*model.Board{
+			"roadmap": {ID: "roadmap", TeamID: "team-id", Title: "Roadmap"},
+			"backlog": {ID: "backlog", TeamID: "team-id", Title: "Backlog"},
+		},
+		history: map[string][]*model.Block{
+			"card-1": {
+				cardVersion("card-1", "roadmap", "Old title", "alice", now.Add(-72*time.Hour)),
+				cardVersion("card-1", "roadmap", "New title", "alice", changedAt),
+			},
+			"card-2": {
+				cardVersion("card-2", "backlog", "Added card", "alice", changedAt),
+			},
+		},
+		subscribers: map[string][]*model.Subscriber{
+			"roadmap": {userSub("bob", 0), userSub("carol", 0), userSub("dave", 0)},
+			"card-1":  {userSub("alice", 0)},
+			"card-2":  {userSub("bob", 0)},
+		},
+		frequencies: map[string]model.ActivityDigestFrequency{
+			"alice": model.ActivityDigestDaily,
+			"bob":   model.ActivityDigestDaily,
+			"carol": model.ActivityDigestWeekly,
+		},
+		changes: map[string]*model.ActivityDigestChange{
+			"card-1": {BoardID: "roadmap", CardID: "card-1", UpdateAt: changedAt.UnixMilli()},
+			"card-2": {BoardID: "backlog", CardID: "card-2", UpdateAt: changedAt.UnixMilli()},
+		},
+		sentAt: map[string]int64{},
+	}
+	delivery := &fakeDelivery{attachments: make(map[string][][]*mm_model.SlackAttachment)}
+
+	params := BackendParams{
+		ServerRoot:  "http://localhost",
+		AppAPI:      appAPI,
+		Permissions: &fakePermissions{},
+		Delivery:    delivery,
+		Logger:      mlog.CreateConsoleTestLogger(t),
+	}
+	return appAPI, delivery, params
+}
+
+func TestSendDigests(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 9, 0, 0, 0, time.UTC)
+	appAPI, delivery, params := setupDigestTest(t, now)
+	for _, userID := range []string{"alice", "bob", "carol"} {
+		appAPI.sentAt[userID] = now.Add(-25 * time.Hour).UnixMilli()
+	}
+
+	d := newDigester(params)
+	require.NoError(t, d.sendDigests(now))
+
+	require.Len(t, delivery.attachments["bob"], 1)
+	digest := delivery.attachments["bob"][0]
+	require.Len(t, digest, 5)
+	assert.Equal(t, "#### Your daily digest of board activity", digest[0].Pretext)
+	assert.Equal(t, "##### [Backlog](http://localhost/team/team-id/backlog)", digest[1].Pretext)
+	assert.Contains(t, digest[2].Pretext, "has added the card [Added card]")
+	assert.Equal(t, "##### [Roadmap](http://localhost/team/team-id/roadmap)", digest[3].Pretext)
+	assert.Contains(t, digest[4].Pretext, "has modified the card [New title]")
+	assert.Equal(t, now.UnixMilli(), appAPI.sentAt["bob"])
+
+	// the weekly digest is not due yet, the only changes of alice are her
+	// own, and dave did not opt in.
+	assert.Empty(t, delivery.attachments["carol"])
+	assert.Empty(t, delivery.attachments["alice"])
+	assert.Empty(t, delivery.attachments["dave"])
+
+	t.Run("digests are sent once per period", func(t *testing.T) {
+		require.NoError(t, d.sendDigests(now.Add(time.Hour)))
+		assert.Len(t, delivery.attachments["bob"], 1)
+	})
+
+	t.Run("weekly digest", func(t *testing.T) {
+		require.NoError(t, d.sendDigests(now.Add(6*24*time.Hour)))
+		require.Len(t, delivery.attachments["carol"], 1)
+		assert.Equal(t, "#### Your weekly digest of board activity", delivery.attachments["carol"][0][0].Pretext)
+	})
+}
+
+func TestNotifySubscribersSkipsDigestUsers(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 9, 0, 0, 0, time.UTC)
+	appAPI, delivery, params := setupDigestTest(t, now)
+	notifiedAt := now.Add(-3 * time.Hour).UnixMilli()
+	appAPI.subscribers["card-1"] = []*model.Subscriber{userSub("bob", notifiedAt), userSub("dave", notifiedAt)}
+
+	n := newNotifier(params)
+	hint := &model.NotificationHint{BlockType: model.TypeCard, BlockID: "card-1", ModifiedByID: "alice"}
+	require.NoError(t, n.notifySubscribers(hint))
+
+	assert.Len(t, delivery.attachments["dave"], 1)
+	assert.Empty(t, delivery.attachments["bob"])
+	assert.Equal(t, notifiedAt, appAPI.sentAt["bob"], "the digest starts with the first change bob was not notified of")
+}
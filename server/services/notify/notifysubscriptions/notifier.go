@@ -199,16 +199,7 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 		diffAuthors.Append(d.Authors)
 	}
 
-	opts := DiffConvOpts{
-		Language: "en", // TODO: use correct language when i18n is available on server.
-		MakeCardLink: func(block *model.Block, board *model.Board, card *model.Block) string {
-			return fmt.Sprintf("[%s](%s)", block.Title, utils.MakeCardLink(n.serverRoot, board.TeamID, board.ID, card.ID))
-		},
-		MakeBoardLink: func(board *model.Board) string {
-			return fmt.Sprintf("[%s](%s)", board.Title, utils.MakeBoardLink(n.serverRoot, board.TeamID, board.ID))
-		},
-		Logger: n.logger,
-	}
+	opts := makeDiffConvOpts(n.serverRoot, n.logger)
 
 	attachments, err := Diffs2SlackAttachments(diffs, opts)
 	if err != nil {
@@ -229,6 +220,12 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 				continue
 			}
 
+			// users who opted in the activity digest get the changes in their next digest.
+			if sub.SubscriberType == model.SubTypeUser && userDigestFrequency(n.store, sub.SubscriberID) != model.ActivityDigestOff {
+				n.startDigest(sub, oldestNotifiedAt)
+				continue
+			}
+
 			// make sure the subscriber still has permissions for the board.
 			if !n.permissions.HasPermissionToBoard(sub.SubscriberID, board.ID, model.PermissionViewBoard) {
 				n.logger.Debug("notifySubscribers - skipping non-board member",
@@ -279,3 +276,35 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 
 	return merr.ErrorOrNil()
 }
+
+// startDigest starts the activity digest of a subscriber with the changes the
+// subscriber was not notified of, unless the digest has already started.
+func (n *notifier) startDigest(sub *model.Subscriber, oldestNotifiedAt int64) {
+	startAt := sub.NotifiedAt
+	if startAt == 0 {
+		startAt = oldestNotifiedAt
+	}
+	if startAt == 0 {
+		startAt = utils.GetMillis() - 1
+	}
+
+	if _, err := n.store.ClaimActivityDigest(sub.SubscriberID, 0, startAt); err != nil {
+		n.logger.Error("Cannot start activity digest",
+			mlog.String("subscriber_id", sub.SubscriberID),
+			mlog.Err(err),
+		)
+	}
+}
+
+func makeDiffConvOpts(serverRoot string, logger mlog.LoggerIFace) DiffConvOpts {
+	return DiffConvOpts{
+		Language: "en", // TODO: use correct language when i18n is available on server.
+		MakeCardLink: func(block *model.Block, board *model.Board, card *model.Block) string {
+			return fmt.Sprintf("[%s](%s)", block.Title, utils.MakeCardLink(serverRoot, board.TeamID, board.ID, card.ID))
+		},
+		MakeBoardLink: func(board *model.Board) string {
+			return fmt.Sprintf("[%s](%s)", board.Title, utils.MakeBoardLink(serverRoot, board.TeamID, board.ID))
+		},
+		Logger: logger,
+	}
+}
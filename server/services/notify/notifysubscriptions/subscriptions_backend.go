@@ -12,8 +12,10 @@ import (
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/permissions"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
 	"github.com/wiggin77/merror"
 
+	mm_model "github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
@@ -33,10 +35,12 @@ type BackendParams struct {
 
 // Backend provides the notification backend for subscriptions.
 type Backend struct {
+	serverRoot             string
 	appAPI                 AppAPI
 	permissions            permissions.PermissionsService
 	delivery               SubscriptionDelivery
 	notifier               *notifier
+	digester               *digester
 	logger                 mlog.LoggerIFace
 	notifyFreqCardSeconds  int
 	notifyFreqBoardSeconds int
@@ -44,10 +48,12 @@ type Backend struct {
 
 func New(params BackendParams) *Backend {
 	return &Backend{
+		serverRoot:             params.ServerRoot,
 		appAPI:                 params.AppAPI,
 		delivery:               params.Delivery,
 		permissions:            params.Permissions,
 		notifier:               newNotifier(params),
+		digester:               newDigester(params),
 		logger:                 params.Logger,
 		notifyFreqCardSeconds:  params.NotifyFreqCardSeconds,
 		notifyFreqBoardSeconds: params.NotifyFreqBoardSeconds,
@@ -60,12 +66,14 @@ func (b *Backend) Start() error {
 		mlog.Int("freq_board", b.notifyFreqBoardSeconds),
 	)
 	b.notifier.start()
+	b.digester.start()
 	return nil
 }
 
 func (b *Backend) ShutDown() error {
 	b.logger.Debug("Stopping subscriptions backend")
 	b.notifier.stop()
+	b.digester.stop()
 	_ = b.logger.Flush()
 	return nil
 }
@@ -121,6 +129,18 @@ func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
 		}
 	}
 
+	// record the card change for the activity digests.
+	if evt.Card != nil && !evt.Board.IsTemplate {
+		change := &model.ActivityDigestChange{
+			BoardID:  evt.Board.ID,
+			CardID:   evt.Card.ID,
+			UpdateAt: utils.GetMillis(),
+		}
+		if err = b.appAPI.UpsertActivityDigestChange(change); err != nil {
+			merr.Append(fmt.Errorf("cannot record activity digest change for card %s: %w", evt.Card.ID, err))
+		}
+	}
+
 	// notify board subscribers
 	subs, err := b.appAPI.GetSubscribersForBlock(evt.Board.ID)
 	if err != nil {
@@ -221,3 +241,47 @@ func (b *Backend) OnMention(userID string, evt notify.BlockChangeEvent) {
 		mlog.String("card_id", evt.Card.ID),
 	)
 }
+
+// CardUnblocked satisfies the `UnblockBackend` interface and tells the
+// subscribers of a card that the last card blocking it is done.
+func (b *Backend) CardUnblocked(evt notify.CardUnblockedEvent) error {
+	subs, err := b.appAPI.GetSubscribersForBlock(evt.Card.ID)
+	if err != nil {
+		return fmt.Errorf("cannot fetch subscribers for card %s: %w", evt.Card.ID, err)
+	}
+
+	opts := makeDiffConvOpts(b.serverRoot, b.logger)
+	cardLink := opts.MakeCardLink(evt.Card, evt.Board, evt.Card)
+	blockerText := fmt.Sprintf("%s is no longer blocked: %s, the last card blocking it, is done.", cardLink, evt.Blocker.Title)
+	hiddenBlockerText := fmt.Sprintf("%s is no longer blocked: the last card blocking it is done.", cardLink)
+
+	merr := merror.New()
+	for _, sub := range subs {
+		// don't notify the user who completed the blocker.
+		if sub.SubscriberType == model.SubTypeUser && sub.SubscriberID == evt.ModifiedBy.UserID {
+			continue
+		}
+
+		// make sure the subscriber still has permissions for the board.
+		if !b.permissions.HasPermissionToBoard(sub.SubscriberID, evt.Board.ID, model.PermissionViewBoard) {
+			b.logger.Debug("CardUnblocked - skipping non-board member",
+				mlog.String("subscriber_id", sub.SubscriberID),
+				mlog.String("board_id", evt.Board.ID),
+			)
+			continue
+		}
+
+		// the blocker is only named to subscribers who can view it.
+		text := hiddenBlockerText
+		if evt.Blocker.BoardID == evt.Board.ID ||
+			b.permissions.HasPermissionToBoard(sub.SubscriberID, evt.Blocker.BoardID, model.PermissionViewBoard) {
+			text = blockerText
+		}
+		attachments := []*mm_model.SlackAttachment{{Pretext: text, Fallback: text}}
+
+		if err := b.delivery.SubscriptionDeliverSlackAttachments(evt.TeamID, sub.SubscriberID, sub.SubscriberType, attachments); err != nil {
+			merr.Append(fmt.Errorf("cannot deliver unblocked notification to subscriber %s: %w", sub.SubscriberID, err))
+		}
+	}
+	return merr.ErrorOrNil()
+}
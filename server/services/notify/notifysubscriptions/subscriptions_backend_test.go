@@ -0,0 +1,77 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifysubscriptions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+)
+
+func TestCardUnblocked(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 9, 0, 0, 0, time.UTC)
+	appAPI, delivery, params := setupDigestTest(t, now)
+	appAPI.subscribers["card-1"] = []*model.Subscriber{
+		userSub("alice", 0),
+		userSub("bob", 0),
+		{SubscriberType: model.SubTypeChannel, SubscriberID: "channel-id"},
+	}
+
+	b := New(params)
+	err := b.CardUnblocked(notify.CardUnblockedEvent{
+		TeamID:     "team-id",
+		Board:      appAPI.boards["roadmap"],
+		Card:       appAPI.history["card-1"][1],
+		Blocker:    &model.Card{ID: "card-2", BoardID: "backlog", Title: "Added card"},
+		ModifiedBy: &model.BoardMember{BoardID: "backlog", UserID: "alice"},
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, delivery.attachments["alice"], "the user who completed the blocker is not notified")
+	require.Len(t, delivery.attachments["bob"], 1)
+	require.Len(t, delivery.attachments["channel-id"], 1)
+	assert.Equal(t,
+		"[New title](http://localhost/team/team-id/roadmap/0/card-1) is no longer blocked: Added card, the last card blocking it, is done.",
+		delivery.attachments["bob"][0][0].Pretext)
+}
+
+func TestCardUnblockedPermissions(t *testing.T) {
+	now := time.Date(2026, time.October, 17, 9, 0, 0, 0, time.UTC)
+	appAPI, delivery, params := setupDigestTest(t, now)
+	appAPI.subscribers["card-1"] = []*model.Subscriber{
+		userSub("bob", 0),
+		userSub("carol", 0),
+		userSub("dave", 0),
+	}
+	params.Permissions = &fakePermissions{denied: map[string]bool{
+		"carol/backlog": true,
+		"dave/roadmap":  true,
+	}}
+
+	b := New(params)
+	err := b.CardUnblocked(notify.CardUnblockedEvent{
+		TeamID:     "team-id",
+		Board:      appAPI.boards["roadmap"],
+		Card:       appAPI.history["card-1"][1],
+		Blocker:    &model.Card{ID: "card-2", BoardID: "backlog", Title: "Added card"},
+		ModifiedBy: &model.BoardMember{BoardID: "backlog", UserID: "alice"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, delivery.attachments["bob"], 1)
+	assert.Contains(t, delivery.attachments["bob"][0][0].Pretext, "Added card")
+
+	require.Len(t, delivery.attachments["carol"], 1)
+	assert.Equal(t,
+		"[New title](http://localhost/team/team-id/roadmap/0/card-1) is no longer blocked: the last card blocking it is done.",
+		delivery.attachments["carol"][0][0].Pretext,
+		"the blocker is not named to a subscriber who cannot view its board")
+
+	assert.Empty(t, delivery.attachments["dave"], "a subscriber who cannot view the board is not notified")
+}
@@ -0,0 +1,9 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+package notifywebhooks
+
+import "github.com/mattermost/mattermost-plugin-boards/server/model"
+
+type AppAPI interface {
+	EnqueueWebhookEvent(board *model.Board, payload *model.WebhookEventPayload) error
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifywebhooks
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	backendName = "notifyWebhooks"
+)
+
+type BackendParams struct {
+	AppAPI AppAPI
+	Logger mlog.LoggerIFace
+}
+
+// Backend provides the notification backend that turns block changes into
+// board webhook events.
+type Backend struct {
+	appAPI AppAPI
+	logger mlog.LoggerIFace
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		appAPI: params.AppAPI,
+		logger: params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	if evt.Board == nil || evt.Card == nil || evt.Board.IsTemplate {
+		return nil
+	}
+
+	card, err := model.Block2Card(evt.Card)
+	if err != nil {
+		return fmt.Errorf("cannot convert block to card: %w", err)
+	}
+	if card.IsTemplate {
+		return nil
+	}
+
+	payloads, err := eventPayloads(evt, card)
+	if err != nil {
+		return err
+	}
+
+	merr := merror.New()
+	for _, payload := range payloads {
+		if evt.ModifiedBy != nil {
+			payload.ActorID = evt.ModifiedBy.UserID
+		}
+		if err := b.appAPI.EnqueueWebhookEvent(evt.Board, payload); err != nil {
+			merr.Append(fmt.Errorf("cannot queue %s webhook event for board %s: %w", payload.Event, evt.Board.ID, err))
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// eventPayloads returns the webhook events of a block change. Changes to the
+// content blocks of a card, other than new comments, are not sent.
+func eventPayloads(evt notify.BlockChangeEvent, card *model.Card) ([]*model.WebhookEventPayload, error) {
+	block := evt.BlockChanged
+
+	switch block.Type {
+	case model.TypeCard:
+		switch evt.Action {
+		case notify.Add:
+			return []*model.WebhookEventPayload{{Event: model.WebhookEventCardCreated, Card: card}}, nil
+		case notify.Delete:
+			return []*model.WebhookEventPayload{{Event: model.WebhookEventCardDeleted, Card: card}}, nil
+		case notify.Update:
+			payloads := []*model.WebhookEventPayload{{Event: model.WebhookEventCardUpdated, Card: card}}
+			statusChange, err := getStatusChange(evt.Board, evt.BlockOld, block)
+			if err != nil {
+				return nil, err
+			}
+			if statusChange != nil {
+				payloads = append(payloads, &model.WebhookEventPayload{
+					Event:  model.WebhookEventStatusChanged,
+					Card:   card,
+					Status: statusChange,
+				})
+			}
+			return payloads, nil
+		}
+	case model.TypeComment:
+		if evt.Action == notify.Add {
+			return []*model.WebhookEventPayload{{Event: model.WebhookEventCommentAdded, Card: card, Comment: block}}, nil
+		}
+	}
+	return nil, nil
+}
+
+// getStatusChange returns the change of the board's workflow property between
+// two versions of a card, or nil if the status did not change.
+func getStatusChange(board *model.Board, oldCard, newCard *model.Block) (*model.WebhookStatusChange, error) {
+	if oldCard == nil {
+		return nil, nil
+	}
+
+	schema, err := model.ParsePropertySchema(board)
+	if err != nil {
+		return nil, err
+	}
+	statusProp, ok := schema.WorkflowPropDef(board)
+	if !ok {
+		return nil, nil
+	}
+
+	from := getPropertyValue(oldCard, statusProp.ID)
+	to := getPropertyValue(newCard, statusProp.ID)
+	if from == to {
+		return nil, nil
+	}
+
+	return &model.WebhookStatusChange{
+		PropertyID: statusProp.ID,
+		From:       from,
+		FromValue:  statusProp.Options[from].Value,
+		To:         to,
+		ToValue:    statusProp.Options[to].Value,
+	}, nil
+}
+
+func getPropertyValue(block *model.Block, propertyID string) string {
+	properties, ok := block.Fields["properties"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := properties[propertyID].(string)
+	return value
+}
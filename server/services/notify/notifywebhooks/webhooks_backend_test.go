@@ -0,0 +1,124 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifywebhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/notify"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+type fakeAppAPI struct {
+	boards   []*model.Board
+	payloads []*model.WebhookEventPayload
+}
+
+func (f *fakeAppAPI) EnqueueWebhookEvent(board *model.Board, payload *model.WebhookEventPayload) error {
+	f.boards = append(f.boards, board)
+	f.payloads = append(f.payloads, payload)
+	return nil
+}
+
+func TestBackendBlockChanged(t *testing.T) {
+	board := &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status-prop",
+				"name": "Status",
+				"type": model.PropTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do"},
+					map[string]interface{}{"id": "done", "value": "Done"},
+				},
+			},
+		},
+	}
+	modifiedBy := &model.BoardMember{BoardID: board.ID, UserID: "user-id"}
+
+	newCard := func(status string) *model.Block {
+		return &model.Block{
+			ID:      "card-id",
+			BoardID: board.ID,
+			Type:    model.TypeCard,
+			Fields: map[string]interface{}{
+				"properties": map[string]interface{}{"status-prop": status},
+			},
+		}
+	}
+
+	run := func(t *testing.T, evt notify.BlockChangeEvent) []*model.WebhookEventPayload {
+		appAPI := &fakeAppAPI{}
+		backend := New(BackendParams{AppAPI: appAPI, Logger: mlog.CreateConsoleTestLogger(t)})
+		require.NoError(t, backend.BlockChanged(evt))
+		return appAPI.payloads
+	}
+
+	t.Run("card created", func(t *testing.T) {
+		card := newCard("todo")
+		payloads := run(t, notify.BlockChangeEvent{Action: notify.Add, Board: board, Card: card, BlockChanged: card, ModifiedBy: modifiedBy})
+		require.Len(t, payloads, 1)
+		assert.Equal(t, model.WebhookEventCardCreated, payloads[0].Event)
+		assert.Equal(t, "card-id", payloads[0].Card.ID)
+		assert.Equal(t, "user-id", payloads[0].ActorID)
+	})
+
+	t.Run("card deleted", func(t *testing.T) {
+		card := newCard("todo")
+		payloads := run(t, notify.BlockChangeEvent{Action: notify.Delete, Board: board, Card: card, BlockChanged: card, BlockOld: card, ModifiedBy: modifiedBy})
+		require.Len(t, payloads, 1)
+		assert.Equal(t, model.WebhookEventCardDeleted, payloads[0].Event)
+	})
+
+	t.Run("card updated without status change", func(t *testing.T) {
+		oldCard := newCard("todo")
+		card := newCard("todo")
+		card.Title = "renamed"
+		payloads := run(t, notify.BlockChangeEvent{Action: notify.Update, Board: board, Card: card, BlockChanged: card, BlockOld: oldCard, ModifiedBy: modifiedBy})
+		require.Len(t, payloads, 1)
+		assert.Equal(t, model.WebhookEventCardUpdated, payloads[0].Event)
+	})
+
+	t.Run("status changed", func(t *testing.T) {
+		oldCard := newCard("todo")
+		card := newCard("done")
+		payloads := run(t, notify.BlockChangeEvent{Action: notify.Update, Board: board, Card: card, BlockChanged: card, BlockOld: oldCard, ModifiedBy: modifiedBy})
+		require.Len(t, payloads, 2)
+		assert.Equal(t, model.WebhookEventCardUpdated, payloads[0].Event)
+		assert.Equal(t, model.WebhookEventStatusChanged, payloads[1].Event)
+		assert.Equal(t, &model.WebhookStatusChange{
+			PropertyID: "status-prop",
+			From:       "todo",
+			FromValue:  "To Do",
+			To:         "done",
+			ToValue:    "Done",
+		}, payloads[1].Status)
+	})
+
+	t.Run("comment added", func(t *testing.T) {
+		card := newCard("todo")
+		comment := &model.Block{ID: "comment-id", ParentID: card.ID, BoardID: board.ID, Type: model.TypeComment, Title: "hello"}
+		payloads := run(t, notify.BlockChangeEvent{Action: notify.Add, Board: board, Card: card, BlockChanged: comment, ModifiedBy: modifiedBy})
+		require.Len(t, payloads, 1)
+		assert.Equal(t, model.WebhookEventCommentAdded, payloads[0].Event)
+		assert.Equal(t, "comment-id", payloads[0].Comment.ID)
+	})
+
+	t.Run("content blocks and templates are ignored", func(t *testing.T) {
+		card := newCard("todo")
+		text := &model.Block{ID: "text-id", ParentID: card.ID, BoardID: board.ID, Type: model.TypeText}
+		assert.Empty(t, run(t, notify.BlockChangeEvent{Action: notify.Add, Board: board, Card: card, BlockChanged: text, ModifiedBy: modifiedBy}))
+
+		template := newCard("todo")
+		template.Fields["isTemplate"] = true
+		assert.Empty(t, run(t, notify.BlockChangeEvent{Action: notify.Add, Board: board, Card: template, BlockChanged: template, ModifiedBy: modifiedBy}))
+	})
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+)
+
+// ChannelDeliver posts a message to a channel as the boards bot.
+func (pd *PluginDelivery) ChannelDeliver(channelID string, message string) error {
+	if _, err := pd.api.GetChannelByID(channelID); err != nil {
+		return fmt.Errorf("cannot find channel %s: %w", channelID, err)
+	}
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channelID,
+		Message:   message,
+	}
+
+	if _, err := pd.api.CreatePost(post); err != nil {
+		return fmt.Errorf("cannot post to channel %s: %w", channelID, err)
+	}
+	return nil
+}
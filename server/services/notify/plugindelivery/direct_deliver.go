@@ -0,0 +1,29 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+)
+
+// DirectDeliver sends a direct message from the boards bot to a user.
+func (pd *PluginDelivery) DirectDeliver(teamID string, userID string, message string) error {
+	channel, err := pd.getDirectChannel(teamID, userID, pd.botID)
+	if err != nil {
+		return fmt.Errorf("cannot get direct channel for user %s: %w", userID, err)
+	}
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channel.Id,
+		Message:   message,
+	}
+
+	if _, err := pd.api.CreatePost(post); err != nil {
+		return fmt.Errorf("cannot send direct message to user %s: %w", userID, err)
+	}
+	return nil
+}
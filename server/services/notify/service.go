@@ -30,6 +30,24 @@ type BlockChangeEvent struct {
 	ModifiedBy   *model.BoardMember
 }
 
+// CardRelationEvent describes a relation added from a card to another card.
+type CardRelationEvent struct {
+	TeamID     string
+	Board      *model.Board
+	Card       *model.Block
+	Relation   *model.CardRelation
+	ModifiedBy *model.BoardMember
+}
+
+// CardUnblockedEvent describes a card whose last open blocker was completed.
+type CardUnblockedEvent struct {
+	TeamID     string
+	Board      *model.Board
+	Card       *model.Block
+	Blocker    *model.Card
+	ModifiedBy *model.BoardMember
+}
+
 // Backend provides an interface for sending notifications.
 type Backend interface {
 	Start() error
@@ -38,6 +56,18 @@ type Backend interface {
 	Name() string
 }
 
+// RelationBackend is implemented by backends that are also informed of new
+// card relations.
+type RelationBackend interface {
+	CardRelationAdded(evt CardRelationEvent) error
+}
+
+// UnblockBackend is implemented by backends that are also informed of cards
+// that are no longer blocked.
+type UnblockBackend interface {
+	CardUnblocked(evt CardUnblockedEvent) error
+}
+
 // Service is a service that sends notifications based on block activity using one or more backends.
 type Service struct {
 	mux      sync.RWMutex
@@ -106,3 +136,45 @@ func (s *Service) BlockChanged(evt BlockChangeEvent) {
 		}
 	}
 }
+
+// CardRelationAdded should be called whenever a card relation is created.
+// Backends implementing RelationBackend are informed of the event.
+func (s *Service) CardRelationAdded(evt CardRelationEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		relationBackend, ok := backend.(RelationBackend)
+		if !ok {
+			continue
+		}
+		if err := relationBackend.CardRelationAdded(evt); err != nil {
+			s.logger.Error("Error delivering relation notification",
+				mlog.String("backend", backend.Name()),
+				mlog.String("relation_id", evt.Relation.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// CardUnblocked should be called whenever the last open blocker of a card is
+// completed. Backends implementing UnblockBackend are informed of the event.
+func (s *Service) CardUnblocked(evt CardUnblockedEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		unblockBackend, ok := backend.(UnblockBackend)
+		if !ok {
+			continue
+		}
+		if err := unblockBackend.CardUnblocked(evt); err != nil {
+			s.logger.Error("Error delivering unblocked card notification",
+				mlog.String("backend", backend.Name()),
+				mlog.String("card_id", evt.Card.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
@@ -65,6 +65,111 @@ func (mr *MockStoreMockRecorder) CanSeeUser(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanSeeUser", reflect.TypeOf((*MockStore)(nil).CanSeeUser), arg0, arg1)
 }
 
+// ClaimActivityDigest mocks base method.
+func (m *MockStore) ClaimActivityDigest(arg0 string, arg1, arg2 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimActivityDigest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimActivityDigest indicates an expected call of ClaimActivityDigest.
+func (mr *MockStoreMockRecorder) ClaimActivityDigest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimActivityDigest", reflect.TypeOf((*MockStore)(nil).ClaimActivityDigest), arg0, arg1, arg2)
+}
+
+// ClaimAutomationRuleDueCheck mocks base method.
+func (m *MockStore) ClaimAutomationRuleDueCheck(arg0 *model.AutomationRule, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimAutomationRuleDueCheck", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimAutomationRuleDueCheck indicates an expected call of ClaimAutomationRuleDueCheck.
+func (mr *MockStoreMockRecorder) ClaimAutomationRuleDueCheck(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimAutomationRuleDueCheck", reflect.TypeOf((*MockStore)(nil).ClaimAutomationRuleDueCheck), arg0, arg1)
+}
+
+// ClaimDueDateNotification mocks base method.
+func (m *MockStore) ClaimDueDateNotification(arg0 *model.DueDateNotification) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueDateNotification", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueDateNotification indicates an expected call of ClaimDueDateNotification.
+func (mr *MockStoreMockRecorder) ClaimDueDateNotification(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueDateNotification", reflect.TypeOf((*MockStore)(nil).ClaimDueDateNotification), arg0)
+}
+
+// ClaimWebhookDelivery mocks base method.
+func (m *MockStore) ClaimWebhookDelivery(arg0 *model.WebhookDelivery, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDelivery", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDelivery indicates an expected call of ClaimWebhookDelivery.
+func (mr *MockStoreMockRecorder) ClaimWebhookDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDelivery", reflect.TypeOf((*MockStore)(nil).ClaimWebhookDelivery), arg0, arg1)
+}
+
+// CreateAutomationRule mocks base method.
+func (m *MockStore) CreateAutomationRule(arg0 *model.AutomationRule) (*model.AutomationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAutomationRule", arg0)
+	ret0, _ := ret[0].(*model.AutomationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAutomationRule indicates an expected call of CreateAutomationRule.
+func (mr *MockStoreMockRecorder) CreateAutomationRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAutomationRule", reflect.TypeOf((*MockStore)(nil).CreateAutomationRule), arg0)
+}
+
+// CreateBoardGitHubRepo mocks base method.
+func (m *MockStore) CreateBoardGitHubRepo(arg0 *model.BoardGitHubRepo) (*model.BoardGitHubRepo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardGitHubRepo", arg0)
+	ret0, _ := ret[0].(*model.BoardGitHubRepo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardGitHubRepo indicates an expected call of CreateBoardGitHubRepo.
+func (mr *MockStoreMockRecorder) CreateBoardGitHubRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardGitHubRepo", reflect.TypeOf((*MockStore)(nil).CreateBoardGitHubRepo), arg0)
+}
+
+// CreateBoardWebhook mocks base method.
+func (m *MockStore) CreateBoardWebhook(arg0 *model.BoardWebhook) (*model.BoardWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBoardWebhook", arg0)
+	ret0, _ := ret[0].(*model.BoardWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBoardWebhook indicates an expected call of CreateBoardWebhook.
+func (mr *MockStoreMockRecorder) CreateBoardWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardWebhook", reflect.TypeOf((*MockStore)(nil).CreateBoardWebhook), arg0)
+}
+
 // CreateBoardsAndBlocks mocks base method.
 func (m *MockStore) CreateBoardsAndBlocks(arg0 *model.BoardsAndBlocks, arg1 string) (*model.BoardsAndBlocks, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +201,21 @@ func (mr *MockStoreMockRecorder) CreateBoardsAndBlocksWithAdmin(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardsAndBlocksWithAdmin", reflect.TypeOf((*MockStore)(nil).CreateBoardsAndBlocksWithAdmin), arg0, arg1)
 }
 
+// CreateCardGitHubLink mocks base method.
+func (m *MockStore) CreateCardGitHubLink(arg0 *model.CardGitHubLink) (*model.CardGitHubLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCardGitHubLink", arg0)
+	ret0, _ := ret[0].(*model.CardGitHubLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCardGitHubLink indicates an expected call of CreateCardGitHubLink.
+func (mr *MockStoreMockRecorder) CreateCardGitHubLink(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCardGitHubLink", reflect.TypeOf((*MockStore)(nil).CreateCardGitHubLink), arg0)
+}
+
 // CreateCardRelation mocks base method.
 func (m *MockStore) CreateCardRelation(arg0 *model.CardRelation) (*model.CardRelation, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +245,21 @@ func (mr *MockStoreMockRecorder) CreateCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0)
 }
 
+// CreateIncomingWebhook mocks base method.
+func (m *MockStore) CreateIncomingWebhook(arg0 *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIncomingWebhook", arg0)
+	ret0, _ := ret[0].(*model.IncomingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIncomingWebhook indicates an expected call of CreateIncomingWebhook.
+func (mr *MockStoreMockRecorder) CreateIncomingWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIncomingWebhook", reflect.TypeOf((*MockStore)(nil).CreateIncomingWebhook), arg0)
+}
+
 // CreateSubscription mocks base method.
 func (m *MockStore) CreateSubscription(arg0 *model.Subscription) (*model.Subscription, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +275,21 @@ func (mr *MockStoreMockRecorder) CreateSubscription(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockStore)(nil).CreateSubscription), arg0)
 }
 
+// CreateWorklog mocks base method.
+func (m *MockStore) CreateWorklog(arg0 *model.Worklog) (*model.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorklog", arg0)
+	ret0, _ := ret[0].(*model.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWorklog indicates an expected call of CreateWorklog.
+func (mr *MockStoreMockRecorder) CreateWorklog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorklog", reflect.TypeOf((*MockStore)(nil).CreateWorklog), arg0)
+}
+
 // DBType mocks base method.
 func (m *MockStore) DBType() string {
 	m.ctrl.T.Helper()
@@ -168,6 +318,34 @@ func (mr *MockStoreMockRecorder) DBVersion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBVersion", reflect.TypeOf((*MockStore)(nil).DBVersion))
 }
 
+// DeleteActivityDigestChangesBefore mocks base method.
+func (m *MockStore) DeleteActivityDigestChangesBefore(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteActivityDigestChangesBefore", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteActivityDigestChangesBefore indicates an expected call of DeleteActivityDigestChangesBefore.
+func (mr *MockStoreMockRecorder) DeleteActivityDigestChangesBefore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActivityDigestChangesBefore", reflect.TypeOf((*MockStore)(nil).DeleteActivityDigestChangesBefore), arg0)
+}
+
+// DeleteAutomationRule mocks base method.
+func (m *MockStore) DeleteAutomationRule(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAutomationRule", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAutomationRule indicates an expected call of DeleteAutomationRule.
+func (mr *MockStoreMockRecorder) DeleteAutomationRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAutomationRule", reflect.TypeOf((*MockStore)(nil).DeleteAutomationRule), arg0)
+}
+
 // DeleteBlock mocks base method.
 func (m *MockStore) DeleteBlock(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -210,6 +388,20 @@ func (mr *MockStoreMockRecorder) DeleteBoard(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoard", reflect.TypeOf((*MockStore)(nil).DeleteBoard), arg0, arg1)
 }
 
+// DeleteBoardGitHubRepo mocks base method.
+func (m *MockStore) DeleteBoardGitHubRepo(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardGitHubRepo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardGitHubRepo indicates an expected call of DeleteBoardGitHubRepo.
+func (mr *MockStoreMockRecorder) DeleteBoardGitHubRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardGitHubRepo", reflect.TypeOf((*MockStore)(nil).DeleteBoardGitHubRepo), arg0)
+}
+
 // DeleteBoardRecord mocks base method.
 func (m *MockStore) DeleteBoardRecord(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -224,6 +416,20 @@ func (mr *MockStoreMockRecorder) DeleteBoardRecord(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardRecord", reflect.TypeOf((*MockStore)(nil).DeleteBoardRecord), arg0, arg1)
 }
 
+// DeleteBoardWebhook mocks base method.
+func (m *MockStore) DeleteBoardWebhook(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBoardWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBoardWebhook indicates an expected call of DeleteBoardWebhook.
+func (mr *MockStoreMockRecorder) DeleteBoardWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardWebhook", reflect.TypeOf((*MockStore)(nil).DeleteBoardWebhook), arg0)
+}
+
 // DeleteBoardsAndBlocks mocks base method.
 func (m *MockStore) DeleteBoardsAndBlocks(arg0 *model.DeleteBoardsAndBlocks, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -238,6 +444,48 @@ func (mr *MockStoreMockRecorder) DeleteBoardsAndBlocks(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardsAndBlocks", reflect.TypeOf((*MockStore)(nil).DeleteBoardsAndBlocks), arg0, arg1)
 }
 
+// DeleteCardGitHubLink mocks base method.
+func (m *MockStore) DeleteCardGitHubLink(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardGitHubLink", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardGitHubLink indicates an expected call of DeleteCardGitHubLink.
+func (mr *MockStoreMockRecorder) DeleteCardGitHubLink(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardGitHubLink", reflect.TypeOf((*MockStore)(nil).DeleteCardGitHubLink), arg0)
+}
+
+// DeleteCardParent mocks base method.
+func (m *MockStore) DeleteCardParent(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardParent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardParent indicates an expected call of DeleteCardParent.
+func (mr *MockStoreMockRecorder) DeleteCardParent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardParent", reflect.TypeOf((*MockStore)(nil).DeleteCardParent), arg0)
+}
+
+// DeleteCardRecurrence mocks base method.
+func (m *MockStore) DeleteCardRecurrence(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardRecurrence", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardRecurrence indicates an expected call of DeleteCardRecurrence.
+func (mr *MockStoreMockRecorder) DeleteCardRecurrence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardRecurrence", reflect.TypeOf((*MockStore)(nil).DeleteCardRecurrence), arg0)
+}
+
 // DeleteCardRelation mocks base method.
 func (m *MockStore) DeleteCardRelation(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -266,6 +514,62 @@ func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1, arg2)
 }
 
+// DeleteDueDateNotificationsBefore mocks base method.
+func (m *MockStore) DeleteDueDateNotificationsBefore(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDueDateNotificationsBefore", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDueDateNotificationsBefore indicates an expected call of DeleteDueDateNotificationsBefore.
+func (mr *MockStoreMockRecorder) DeleteDueDateNotificationsBefore(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDueDateNotificationsBefore", reflect.TypeOf((*MockStore)(nil).DeleteDueDateNotificationsBefore), arg0)
+}
+
+// DeleteGitHubIssueSync mocks base method.
+func (m *MockStore) DeleteGitHubIssueSync(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGitHubIssueSync", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGitHubIssueSync indicates an expected call of DeleteGitHubIssueSync.
+func (mr *MockStoreMockRecorder) DeleteGitHubIssueSync(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGitHubIssueSync", reflect.TypeOf((*MockStore)(nil).DeleteGitHubIssueSync), arg0)
+}
+
+// DeleteGitHubIssueSyncItem mocks base method.
+func (m *MockStore) DeleteGitHubIssueSyncItem(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGitHubIssueSyncItem", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGitHubIssueSyncItem indicates an expected call of DeleteGitHubIssueSyncItem.
+func (mr *MockStoreMockRecorder) DeleteGitHubIssueSyncItem(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGitHubIssueSyncItem", reflect.TypeOf((*MockStore)(nil).DeleteGitHubIssueSyncItem), arg0)
+}
+
+// DeleteIncomingWebhook mocks base method.
+func (m *MockStore) DeleteIncomingWebhook(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIncomingWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIncomingWebhook indicates an expected call of DeleteIncomingWebhook.
+func (mr *MockStoreMockRecorder) DeleteIncomingWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncomingWebhook", reflect.TypeOf((*MockStore)(nil).DeleteIncomingWebhook), arg0)
+}
+
 // DeleteMember mocks base method.
 func (m *MockStore) DeleteMember(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -322,6 +626,20 @@ func (mr *MockStoreMockRecorder) DeleteSubscription(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockStore)(nil).DeleteSubscription), arg0, arg1)
 }
 
+// DeleteWorklog mocks base method.
+func (m *MockStore) DeleteWorklog(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorklog", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorklog indicates an expected call of DeleteWorklog.
+func (mr *MockStoreMockRecorder) DeleteWorklog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorklog", reflect.TypeOf((*MockStore)(nil).DeleteWorklog), arg0)
+}
+
 // DuplicateBlock mocks base method.
 func (m *MockStore) DuplicateBlock(arg0, arg1, arg2 string, arg3 bool) ([]*model.Block, error) {
 	m.ctrl.T.Helper()
@@ -368,6 +686,36 @@ func (mr *MockStoreMockRecorder) GetActiveUserCount(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveUserCount", reflect.TypeOf((*MockStore)(nil).GetActiveUserCount), arg0)
 }
 
+// GetActivityDigestChanges mocks base method.
+func (m *MockStore) GetActivityDigestChanges(arg0 int64) ([]*model.ActivityDigestChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivityDigestChanges", arg0)
+	ret0, _ := ret[0].([]*model.ActivityDigestChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivityDigestChanges indicates an expected call of GetActivityDigestChanges.
+func (mr *MockStoreMockRecorder) GetActivityDigestChanges(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivityDigestChanges", reflect.TypeOf((*MockStore)(nil).GetActivityDigestChanges), arg0)
+}
+
+// GetActivityDigestSentAt mocks base method.
+func (m *MockStore) GetActivityDigestSentAt(arg0 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivityDigestSentAt", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivityDigestSentAt indicates an expected call of GetActivityDigestSentAt.
+func (mr *MockStoreMockRecorder) GetActivityDigestSentAt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivityDigestSentAt", reflect.TypeOf((*MockStore)(nil).GetActivityDigestSentAt), arg0)
+}
+
 // GetAllTeams mocks base method.
 func (m *MockStore) GetAllTeams() ([]*model.Team, error) {
 	m.ctrl.T.Helper()
@@ -383,6 +731,36 @@ func (mr *MockStoreMockRecorder) GetAllTeams() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTeams", reflect.TypeOf((*MockStore)(nil).GetAllTeams))
 }
 
+// GetAutomationRule mocks base method.
+func (m *MockStore) GetAutomationRule(arg0 string) (*model.AutomationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutomationRule", arg0)
+	ret0, _ := ret[0].(*model.AutomationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutomationRule indicates an expected call of GetAutomationRule.
+func (mr *MockStoreMockRecorder) GetAutomationRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutomationRule", reflect.TypeOf((*MockStore)(nil).GetAutomationRule), arg0)
+}
+
+// GetAutomationRules mocks base method.
+func (m *MockStore) GetAutomationRules(arg0 string) ([]*model.AutomationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutomationRules", arg0)
+	ret0, _ := ret[0].([]*model.AutomationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutomationRules indicates an expected call of GetAutomationRules.
+func (mr *MockStoreMockRecorder) GetAutomationRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutomationRules", reflect.TypeOf((*MockStore)(nil).GetAutomationRules), arg0)
+}
+
 // GetBlock mocks base method.
 func (m *MockStore) GetBlock(arg0 string) (*model.Block, error) {
 	m.ctrl.T.Helper()
@@ -642,6 +1020,51 @@ func (mr *MockStoreMockRecorder) GetBoardCount(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardCount", reflect.TypeOf((*MockStore)(nil).GetBoardCount), arg0)
 }
 
+// GetBoardGitHubRepo mocks base method.
+func (m *MockStore) GetBoardGitHubRepo(arg0 string) (*model.BoardGitHubRepo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardGitHubRepo", arg0)
+	ret0, _ := ret[0].(*model.BoardGitHubRepo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardGitHubRepo indicates an expected call of GetBoardGitHubRepo.
+func (mr *MockStoreMockRecorder) GetBoardGitHubRepo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardGitHubRepo", reflect.TypeOf((*MockStore)(nil).GetBoardGitHubRepo), arg0)
+}
+
+// GetBoardGitHubRepos mocks base method.
+func (m *MockStore) GetBoardGitHubRepos(arg0 string) ([]*model.BoardGitHubRepo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardGitHubRepos", arg0)
+	ret0, _ := ret[0].([]*model.BoardGitHubRepo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardGitHubRepos indicates an expected call of GetBoardGitHubRepos.
+func (mr *MockStoreMockRecorder) GetBoardGitHubRepos(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardGitHubRepos", reflect.TypeOf((*MockStore)(nil).GetBoardGitHubRepos), arg0)
+}
+
+// GetBoardGitHubReposToScan mocks base method.
+func (m *MockStore) GetBoardGitHubReposToScan(arg0 int64, arg1 int) ([]*model.BoardGitHubRepo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardGitHubReposToScan", arg0, arg1)
+	ret0, _ := ret[0].([]*model.BoardGitHubRepo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardGitHubReposToScan indicates an expected call of GetBoardGitHubReposToScan.
+func (mr *MockStoreMockRecorder) GetBoardGitHubReposToScan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardGitHubReposToScan", reflect.TypeOf((*MockStore)(nil).GetBoardGitHubReposToScan), arg0, arg1)
+}
+
 // GetBoardHistory mocks base method.
 func (m *MockStore) GetBoardHistory(arg0 string, arg1 model.QueryBoardHistoryOptions) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -672,6 +1095,36 @@ func (mr *MockStoreMockRecorder) GetBoardMemberHistory(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardMemberHistory", reflect.TypeOf((*MockStore)(nil).GetBoardMemberHistory), arg0, arg1, arg2)
 }
 
+// GetBoardWebhook mocks base method.
+func (m *MockStore) GetBoardWebhook(arg0 string) (*model.BoardWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardWebhook", arg0)
+	ret0, _ := ret[0].(*model.BoardWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardWebhook indicates an expected call of GetBoardWebhook.
+func (mr *MockStoreMockRecorder) GetBoardWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardWebhook", reflect.TypeOf((*MockStore)(nil).GetBoardWebhook), arg0)
+}
+
+// GetBoardWebhooks mocks base method.
+func (m *MockStore) GetBoardWebhooks(arg0 string) ([]*model.BoardWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardWebhooks", arg0)
+	ret0, _ := ret[0].([]*model.BoardWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardWebhooks indicates an expected call of GetBoardWebhooks.
+func (mr *MockStoreMockRecorder) GetBoardWebhooks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardWebhooks", reflect.TypeOf((*MockStore)(nil).GetBoardWebhooks), arg0)
+}
+
 // GetBoardsComplianceHistory mocks base method.
 func (m *MockStore) GetBoardsComplianceHistory(arg0 model.QueryBoardsComplianceHistoryOptions) ([]*model.BoardHistory, bool, error) {
 	m.ctrl.T.Helper()
@@ -747,112 +1200,367 @@ func (m *MockStore) GetCardByCode(arg0 string) (*model.Block, *model.Board, erro
 // GetCardByCode indicates an expected call of GetCardByCode.
 func (mr *MockStoreMockRecorder) GetCardByCode(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardByCode", reflect.TypeOf((*MockStore)(nil).GetCardByCode), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardByCode", reflect.TypeOf((*MockStore)(nil).GetCardByCode), arg0)
+}
+
+// GetCardChildren mocks base method.
+func (m *MockStore) GetCardChildren(arg0 string) ([]*model.CardParent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardChildren", arg0)
+	ret0, _ := ret[0].([]*model.CardParent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardChildren indicates an expected call of GetCardChildren.
+func (mr *MockStoreMockRecorder) GetCardChildren(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardChildren", reflect.TypeOf((*MockStore)(nil).GetCardChildren), arg0)
+}
+
+// GetCardGitHubLink mocks base method.
+func (m *MockStore) GetCardGitHubLink(arg0 string) (*model.CardGitHubLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardGitHubLink", arg0)
+	ret0, _ := ret[0].(*model.CardGitHubLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardGitHubLink indicates an expected call of GetCardGitHubLink.
+func (mr *MockStoreMockRecorder) GetCardGitHubLink(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardGitHubLink", reflect.TypeOf((*MockStore)(nil).GetCardGitHubLink), arg0)
+}
+
+// GetCardGitHubLinks mocks base method.
+func (m *MockStore) GetCardGitHubLinks(arg0 string) ([]*model.CardGitHubLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardGitHubLinks", arg0)
+	ret0, _ := ret[0].([]*model.CardGitHubLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardGitHubLinks indicates an expected call of GetCardGitHubLinks.
+func (mr *MockStoreMockRecorder) GetCardGitHubLinks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardGitHubLinks", reflect.TypeOf((*MockStore)(nil).GetCardGitHubLinks), arg0)
+}
+
+// GetCardGitHubLinksToSync mocks base method.
+func (m *MockStore) GetCardGitHubLinksToSync(arg0 int64, arg1 int) ([]*model.CardGitHubLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardGitHubLinksToSync", arg0, arg1)
+	ret0, _ := ret[0].([]*model.CardGitHubLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardGitHubLinksToSync indicates an expected call of GetCardGitHubLinksToSync.
+func (mr *MockStoreMockRecorder) GetCardGitHubLinksToSync(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardGitHubLinksToSync", reflect.TypeOf((*MockStore)(nil).GetCardGitHubLinksToSync), arg0, arg1)
+}
+
+// GetCardLimitTimestamp mocks base method.
+func (m *MockStore) GetCardLimitTimestamp() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardLimitTimestamp")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardLimitTimestamp indicates an expected call of GetCardLimitTimestamp.
+func (mr *MockStoreMockRecorder) GetCardLimitTimestamp() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardLimitTimestamp", reflect.TypeOf((*MockStore)(nil).GetCardLimitTimestamp))
+}
+
+// GetCardParent mocks base method.
+func (m *MockStore) GetCardParent(arg0 string) (*model.CardParent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardParent", arg0)
+	ret0, _ := ret[0].(*model.CardParent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardParent indicates an expected call of GetCardParent.
+func (mr *MockStoreMockRecorder) GetCardParent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardParent", reflect.TypeOf((*MockStore)(nil).GetCardParent), arg0)
+}
+
+// GetCardRecurrence mocks base method.
+func (m *MockStore) GetCardRecurrence(arg0 string) (*model.CardRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardRecurrence", arg0)
+	ret0, _ := ret[0].(*model.CardRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardRecurrence indicates an expected call of GetCardRecurrence.
+func (mr *MockStoreMockRecorder) GetCardRecurrence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardRecurrence", reflect.TypeOf((*MockStore)(nil).GetCardRecurrence), arg0)
+}
+
+// GetCardRelation mocks base method.
+func (m *MockStore) GetCardRelation(arg0 string) (*model.CardRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardRelation", arg0)
+	ret0, _ := ret[0].(*model.CardRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardRelation indicates an expected call of GetCardRelation.
+func (mr *MockStoreMockRecorder) GetCardRelation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardRelation", reflect.TypeOf((*MockStore)(nil).GetCardRelation), arg0)
+}
+
+// GetCardRelations mocks base method.
+func (m *MockStore) GetCardRelations(arg0 string) ([]*model.CardRelationWithCard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardRelations", arg0)
+	ret0, _ := ret[0].([]*model.CardRelationWithCard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardRelations indicates an expected call of GetCardRelations.
+func (mr *MockStoreMockRecorder) GetCardRelations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardRelations", reflect.TypeOf((*MockStore)(nil).GetCardRelations), arg0)
+}
+
+// GetCardRelationsForCards mocks base method.
+func (m *MockStore) GetCardRelationsForCards(arg0 []string) ([]*model.CardRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardRelationsForCards", arg0)
+	ret0, _ := ret[0].([]*model.CardRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardRelationsForCards indicates an expected call of GetCardRelationsForCards.
+func (mr *MockStoreMockRecorder) GetCardRelationsForCards(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardRelationsForCards", reflect.TypeOf((*MockStore)(nil).GetCardRelationsForCards), arg0)
+}
+
+// GetCardsCount mocks base method.
+func (m *MockStore) GetCardsCount() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardsCount")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardsCount indicates an expected call of GetCardsCount.
+func (mr *MockStoreMockRecorder) GetCardsCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardsCount", reflect.TypeOf((*MockStore)(nil).GetCardsCount))
+}
+
+// GetCategory mocks base method.
+func (m *MockStore) GetCategory(arg0 string) (*model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0)
+	ret0, _ := ret[0].(*model.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockStoreMockRecorder) GetCategory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0)
+}
+
+// GetChannel mocks base method.
+func (m *MockStore) GetChannel(arg0, arg1 string) (*model0.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannel", arg0, arg1)
+	ret0, _ := ret[0].(*model0.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannel indicates an expected call of GetChannel.
+func (mr *MockStoreMockRecorder) GetChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockStore)(nil).GetChannel), arg0, arg1)
+}
+
+// GetDueCardRecurrences mocks base method.
+func (m *MockStore) GetDueCardRecurrences(arg0 int64) ([]*model.CardRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueCardRecurrences", arg0)
+	ret0, _ := ret[0].([]*model.CardRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueCardRecurrences indicates an expected call of GetDueCardRecurrences.
+func (mr *MockStoreMockRecorder) GetDueCardRecurrences(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueCardRecurrences", reflect.TypeOf((*MockStore)(nil).GetDueCardRecurrences), arg0)
+}
+
+// GetDueWebhookDeliveries mocks base method.
+func (m *MockStore) GetDueWebhookDeliveries(arg0 int64, arg1 uint64) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueWebhookDeliveries", arg0, arg1)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueWebhookDeliveries indicates an expected call of GetDueWebhookDeliveries.
+func (mr *MockStoreMockRecorder) GetDueWebhookDeliveries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).GetDueWebhookDeliveries), arg0, arg1)
+}
+
+// GetEnabledAutomationRulesByTrigger mocks base method.
+func (m *MockStore) GetEnabledAutomationRulesByTrigger(arg0 model.AutomationTriggerType) ([]*model.AutomationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabledAutomationRulesByTrigger", arg0)
+	ret0, _ := ret[0].([]*model.AutomationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabledAutomationRulesByTrigger indicates an expected call of GetEnabledAutomationRulesByTrigger.
+func (mr *MockStoreMockRecorder) GetEnabledAutomationRulesByTrigger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabledAutomationRulesByTrigger", reflect.TypeOf((*MockStore)(nil).GetEnabledAutomationRulesByTrigger), arg0)
+}
+
+// GetFileInfo mocks base method.
+func (m *MockStore) GetFileInfo(arg0 string) (*model0.FileInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileInfo", arg0)
+	ret0, _ := ret[0].(*model0.FileInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileInfo indicates an expected call of GetFileInfo.
+func (mr *MockStoreMockRecorder) GetFileInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileInfo", reflect.TypeOf((*MockStore)(nil).GetFileInfo), arg0)
 }
 
-// GetCardLimitTimestamp mocks base method.
-func (m *MockStore) GetCardLimitTimestamp() (int64, error) {
+// GetGitHubIssueSync mocks base method.
+func (m *MockStore) GetGitHubIssueSync(arg0 string) (*model.GitHubIssueSync, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCardLimitTimestamp")
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "GetGitHubIssueSync", arg0)
+	ret0, _ := ret[0].(*model.GitHubIssueSync)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCardLimitTimestamp indicates an expected call of GetCardLimitTimestamp.
-func (mr *MockStoreMockRecorder) GetCardLimitTimestamp() *gomock.Call {
+// GetGitHubIssueSync indicates an expected call of GetGitHubIssueSync.
+func (mr *MockStoreMockRecorder) GetGitHubIssueSync(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardLimitTimestamp", reflect.TypeOf((*MockStore)(nil).GetCardLimitTimestamp))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubIssueSync", reflect.TypeOf((*MockStore)(nil).GetGitHubIssueSync), arg0)
 }
 
-// GetCardRelation mocks base method.
-func (m *MockStore) GetCardRelation(arg0 string) (*model.CardRelation, error) {
+// GetGitHubIssueSyncItems mocks base method.
+func (m *MockStore) GetGitHubIssueSyncItems(arg0 string) ([]*model.GitHubIssueSyncItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCardRelation", arg0)
-	ret0, _ := ret[0].(*model.CardRelation)
+	ret := m.ctrl.Call(m, "GetGitHubIssueSyncItems", arg0)
+	ret0, _ := ret[0].([]*model.GitHubIssueSyncItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCardRelation indicates an expected call of GetCardRelation.
-func (mr *MockStoreMockRecorder) GetCardRelation(arg0 interface{}) *gomock.Call {
+// GetGitHubIssueSyncItems indicates an expected call of GetGitHubIssueSyncItems.
+func (mr *MockStoreMockRecorder) GetGitHubIssueSyncItems(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardRelation", reflect.TypeOf((*MockStore)(nil).GetCardRelation), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubIssueSyncItems", reflect.TypeOf((*MockStore)(nil).GetGitHubIssueSyncItems), arg0)
 }
 
-// GetCardRelations mocks base method.
-func (m *MockStore) GetCardRelations(arg0 string) ([]*model.CardRelationWithCard, error) {
+// GetGitHubIssueSyncsToRun mocks base method.
+func (m *MockStore) GetGitHubIssueSyncsToRun(arg0 int64, arg1 int) ([]*model.GitHubIssueSync, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCardRelations", arg0)
-	ret0, _ := ret[0].([]*model.CardRelationWithCard)
+	ret := m.ctrl.Call(m, "GetGitHubIssueSyncsToRun", arg0, arg1)
+	ret0, _ := ret[0].([]*model.GitHubIssueSync)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCardRelations indicates an expected call of GetCardRelations.
-func (mr *MockStoreMockRecorder) GetCardRelations(arg0 interface{}) *gomock.Call {
+// GetGitHubIssueSyncsToRun indicates an expected call of GetGitHubIssueSyncsToRun.
+func (mr *MockStoreMockRecorder) GetGitHubIssueSyncsToRun(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardRelations", reflect.TypeOf((*MockStore)(nil).GetCardRelations), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubIssueSyncsToRun", reflect.TypeOf((*MockStore)(nil).GetGitHubIssueSyncsToRun), arg0, arg1)
 }
 
-// GetCardsCount mocks base method.
-func (m *MockStore) GetCardsCount() (int64, error) {
+// GetGitHubStatusRules mocks base method.
+func (m *MockStore) GetGitHubStatusRules(arg0 string) ([]*model.GitHubStatusRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCardsCount")
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "GetGitHubStatusRules", arg0)
+	ret0, _ := ret[0].([]*model.GitHubStatusRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCardsCount indicates an expected call of GetCardsCount.
-func (mr *MockStoreMockRecorder) GetCardsCount() *gomock.Call {
+// GetGitHubStatusRules indicates an expected call of GetGitHubStatusRules.
+func (mr *MockStoreMockRecorder) GetGitHubStatusRules(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardsCount", reflect.TypeOf((*MockStore)(nil).GetCardsCount))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubStatusRules", reflect.TypeOf((*MockStore)(nil).GetGitHubStatusRules), arg0)
 }
 
-// GetCategory mocks base method.
-func (m *MockStore) GetCategory(arg0 string) (*model.Category, error) {
+// GetIncomingWebhook mocks base method.
+func (m *MockStore) GetIncomingWebhook(arg0 string) (*model.IncomingWebhook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategory", arg0)
-	ret0, _ := ret[0].(*model.Category)
+	ret := m.ctrl.Call(m, "GetIncomingWebhook", arg0)
+	ret0, _ := ret[0].(*model.IncomingWebhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategory indicates an expected call of GetCategory.
-func (mr *MockStoreMockRecorder) GetCategory(arg0 interface{}) *gomock.Call {
+// GetIncomingWebhook indicates an expected call of GetIncomingWebhook.
+func (mr *MockStoreMockRecorder) GetIncomingWebhook(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomingWebhook", reflect.TypeOf((*MockStore)(nil).GetIncomingWebhook), arg0)
 }
 
-// GetChannel mocks base method.
-func (m *MockStore) GetChannel(arg0, arg1 string) (*model0.Channel, error) {
+// GetIncomingWebhookByToken mocks base method.
+func (m *MockStore) GetIncomingWebhookByToken(arg0 string) (*model.IncomingWebhook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetChannel", arg0, arg1)
-	ret0, _ := ret[0].(*model0.Channel)
+	ret := m.ctrl.Call(m, "GetIncomingWebhookByToken", arg0)
+	ret0, _ := ret[0].(*model.IncomingWebhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetChannel indicates an expected call of GetChannel.
-func (mr *MockStoreMockRecorder) GetChannel(arg0, arg1 interface{}) *gomock.Call {
+// GetIncomingWebhookByToken indicates an expected call of GetIncomingWebhookByToken.
+func (mr *MockStoreMockRecorder) GetIncomingWebhookByToken(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockStore)(nil).GetChannel), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomingWebhookByToken", reflect.TypeOf((*MockStore)(nil).GetIncomingWebhookByToken), arg0)
 }
 
-// GetFileInfo mocks base method.
-func (m *MockStore) GetFileInfo(arg0 string) (*model0.FileInfo, error) {
+// GetIncomingWebhooks mocks base method.
+func (m *MockStore) GetIncomingWebhooks(arg0 string) ([]*model.IncomingWebhook, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFileInfo", arg0)
-	ret0, _ := ret[0].(*model0.FileInfo)
+	ret := m.ctrl.Call(m, "GetIncomingWebhooks", arg0)
+	ret0, _ := ret[0].([]*model.IncomingWebhook)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetFileInfo indicates an expected call of GetFileInfo.
-func (mr *MockStoreMockRecorder) GetFileInfo(arg0 interface{}) *gomock.Call {
+// GetIncomingWebhooks indicates an expected call of GetIncomingWebhooks.
+func (mr *MockStoreMockRecorder) GetIncomingWebhooks(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileInfo", reflect.TypeOf((*MockStore)(nil).GetFileInfo), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomingWebhooks", reflect.TypeOf((*MockStore)(nil).GetIncomingWebhooks), arg0)
 }
 
 // GetLicense mocks base method.
@@ -1319,6 +2027,82 @@ func (mr *MockStoreMockRecorder) GetUsersList(arg0, arg1, arg2 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersList", reflect.TypeOf((*MockStore)(nil).GetUsersList), arg0, arg1, arg2)
 }
 
+// GetWebhookDeliveries mocks base method.
+func (m *MockStore) GetWebhookDeliveries(arg0 string, arg1, arg2 int) ([]*model.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockStoreMockRecorder) GetWebhookDeliveries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).GetWebhookDeliveries), arg0, arg1, arg2)
+}
+
+// GetWorklog mocks base method.
+func (m *MockStore) GetWorklog(arg0 string) (*model.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorklog", arg0)
+	ret0, _ := ret[0].(*model.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorklog indicates an expected call of GetWorklog.
+func (mr *MockStoreMockRecorder) GetWorklog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorklog", reflect.TypeOf((*MockStore)(nil).GetWorklog), arg0)
+}
+
+// GetWorklogTotals mocks base method.
+func (m *MockStore) GetWorklogTotals(arg0 []string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorklogTotals", arg0)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorklogTotals indicates an expected call of GetWorklogTotals.
+func (mr *MockStoreMockRecorder) GetWorklogTotals(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorklogTotals", reflect.TypeOf((*MockStore)(nil).GetWorklogTotals), arg0)
+}
+
+// GetWorklogs mocks base method.
+func (m *MockStore) GetWorklogs(arg0 model.QueryWorklogsOptions) ([]*model.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorklogs", arg0)
+	ret0, _ := ret[0].([]*model.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorklogs indicates an expected call of GetWorklogs.
+func (mr *MockStoreMockRecorder) GetWorklogs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorklogs", reflect.TypeOf((*MockStore)(nil).GetWorklogs), arg0)
+}
+
+// GetWorklogsComplianceHistory mocks base method.
+func (m *MockStore) GetWorklogsComplianceHistory(arg0 model.QueryWorklogsComplianceHistoryOptions) ([]*model.Worklog, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorklogsComplianceHistory", arg0)
+	ret0, _ := ret[0].([]*model.Worklog)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWorklogsComplianceHistory indicates an expected call of GetWorklogsComplianceHistory.
+func (mr *MockStoreMockRecorder) GetWorklogsComplianceHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorklogsComplianceHistory", reflect.TypeOf((*MockStore)(nil).GetWorklogsComplianceHistory), arg0)
+}
+
 // InsertBlock mocks base method.
 func (m *MockStore) InsertBlock(arg0 *model.Block, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1378,6 +2162,20 @@ func (mr *MockStoreMockRecorder) InsertBoardWithAdmin(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoardWithAdmin", reflect.TypeOf((*MockStore)(nil).InsertBoardWithAdmin), arg0, arg1)
 }
 
+// InsertWebhookDeliveries mocks base method.
+func (m *MockStore) InsertWebhookDeliveries(arg0 []*model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWebhookDeliveries", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertWebhookDeliveries indicates an expected call of InsertWebhookDeliveries.
+func (mr *MockStoreMockRecorder) InsertWebhookDeliveries(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWebhookDeliveries", reflect.TypeOf((*MockStore)(nil).InsertWebhookDeliveries), arg0)
+}
+
 // IsStatusTransitionAllowed mocks base method.
 func (m *MockStore) IsStatusTransitionAllowed(arg0, arg1, arg2 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1393,6 +2191,20 @@ func (mr *MockStoreMockRecorder) IsStatusTransitionAllowed(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsStatusTransitionAllowed", reflect.TypeOf((*MockStore)(nil).IsStatusTransitionAllowed), arg0, arg1, arg2)
 }
 
+// MoveCardToBoard mocks base method.
+func (m *MockStore) MoveCardToBoard(arg0 *model.Block, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCardToBoard", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCardToBoard indicates an expected call of MoveCardToBoard.
+func (mr *MockStoreMockRecorder) MoveCardToBoard(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCardToBoard", reflect.TypeOf((*MockStore)(nil).MoveCardToBoard), arg0, arg1, arg2)
+}
+
 // PatchBlock mocks base method.
 func (m *MockStore) PatchBlock(arg0 string, arg1 *model.BlockPatch, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -1407,6 +2219,20 @@ func (mr *MockStoreMockRecorder) PatchBlock(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBlock", reflect.TypeOf((*MockStore)(nil).PatchBlock), arg0, arg1, arg2)
 }
 
+// PatchBlockProperties mocks base method.
+func (m *MockStore) PatchBlockProperties(arg0 string, arg1 map[string]interface{}, arg2 []string, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchBlockProperties", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PatchBlockProperties indicates an expected call of PatchBlockProperties.
+func (mr *MockStoreMockRecorder) PatchBlockProperties(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBlockProperties", reflect.TypeOf((*MockStore)(nil).PatchBlockProperties), arg0, arg1, arg2, arg3)
+}
+
 // PatchBlocks mocks base method.
 func (m *MockStore) PatchBlocks(arg0 *model.BlockPatchBatch, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1524,6 +2350,20 @@ func (mr *MockStoreMockRecorder) ReorderCategoryBoards(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderCategoryBoards", reflect.TypeOf((*MockStore)(nil).ReorderCategoryBoards), arg0, arg1)
 }
 
+// ReplaceGitHubStatusRules mocks base method.
+func (m *MockStore) ReplaceGitHubStatusRules(arg0 string, arg1 []*model.GitHubStatusRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceGitHubStatusRules", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceGitHubStatusRules indicates an expected call of ReplaceGitHubStatusRules.
+func (mr *MockStoreMockRecorder) ReplaceGitHubStatusRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceGitHubStatusRules", reflect.TypeOf((*MockStore)(nil).ReplaceGitHubStatusRules), arg0, arg1)
+}
+
 // ReplaceStatusTransitionRules mocks base method.
 func (m *MockStore) ReplaceStatusTransitionRules(arg0 string, arg1 []*model.StatusTransitionRule) error {
 	m.ctrl.T.Helper()
@@ -1581,6 +2421,34 @@ func (mr *MockStoreMockRecorder) SaveFileInfo(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFileInfo", reflect.TypeOf((*MockStore)(nil).SaveFileInfo), arg0)
 }
 
+// SaveGitHubIssueSync mocks base method.
+func (m *MockStore) SaveGitHubIssueSync(arg0 *model.GitHubIssueSync) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveGitHubIssueSync", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveGitHubIssueSync indicates an expected call of SaveGitHubIssueSync.
+func (mr *MockStoreMockRecorder) SaveGitHubIssueSync(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGitHubIssueSync", reflect.TypeOf((*MockStore)(nil).SaveGitHubIssueSync), arg0)
+}
+
+// SaveGitHubIssueSyncItem mocks base method.
+func (m *MockStore) SaveGitHubIssueSyncItem(arg0 *model.GitHubIssueSyncItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveGitHubIssueSyncItem", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveGitHubIssueSyncItem indicates an expected call of SaveGitHubIssueSyncItem.
+func (mr *MockStoreMockRecorder) SaveGitHubIssueSyncItem(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGitHubIssueSyncItem", reflect.TypeOf((*MockStore)(nil).SaveGitHubIssueSyncItem), arg0)
+}
+
 // SaveMember mocks base method.
 func (m *MockStore) SaveMember(arg0 *model.BoardMember) (*model.BoardMember, error) {
 	m.ctrl.T.Helper()
@@ -1610,6 +2478,21 @@ func (mr *MockStoreMockRecorder) SaveStatusTransitionRules(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveStatusTransitionRules", reflect.TypeOf((*MockStore)(nil).SaveStatusTransitionRules), arg0)
 }
 
+// SearchBlocks mocks base method.
+func (m *MockStore) SearchBlocks(arg0 model.QueryBlockSearchOptions) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBlocks", arg0)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBlocks indicates an expected call of SearchBlocks.
+func (mr *MockStoreMockRecorder) SearchBlocks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBlocks", reflect.TypeOf((*MockStore)(nil).SearchBlocks), arg0)
+}
+
 // SearchBoardsForUser mocks base method.
 func (m *MockStore) SearchBoardsForUser(arg0 string, arg1 model.BoardSearchField, arg2 string, arg3 bool) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -1640,6 +2523,21 @@ func (mr *MockStoreMockRecorder) SearchBoardsForUserInTeam(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBoardsForUserInTeam", reflect.TypeOf((*MockStore)(nil).SearchBoardsForUserInTeam), arg0, arg1, arg2)
 }
 
+// SearchCards mocks base method.
+func (m *MockStore) SearchCards(arg0 *model.CardSearch, arg1, arg2 int) ([]*model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCards", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCards indicates an expected call of SearchCards.
+func (mr *MockStoreMockRecorder) SearchCards(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCards", reflect.TypeOf((*MockStore)(nil).SearchCards), arg0, arg1, arg2)
+}
+
 // SearchUserChannels mocks base method.
 func (m *MockStore) SearchUserChannels(arg0, arg1, arg2 string) ([]*model0.Channel, error) {
 	m.ctrl.T.Helper()
@@ -1698,6 +2596,21 @@ func (mr *MockStoreMockRecorder) SetBoardVisibility(arg0, arg1, arg2, arg3 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBoardVisibility", reflect.TypeOf((*MockStore)(nil).SetBoardVisibility), arg0, arg1, arg2, arg3)
 }
 
+// SetCardParent mocks base method.
+func (m *MockStore) SetCardParent(arg0 *model.CardParent) (*model.CardParent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCardParent", arg0)
+	ret0, _ := ret[0].(*model.CardParent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCardParent indicates an expected call of SetCardParent.
+func (mr *MockStoreMockRecorder) SetCardParent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCardParent", reflect.TypeOf((*MockStore)(nil).SetCardParent), arg0)
+}
+
 // SetSystemSetting mocks base method.
 func (m *MockStore) SetSystemSetting(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1754,6 +2667,64 @@ func (mr *MockStoreMockRecorder) UndeleteBoard(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBoard", reflect.TypeOf((*MockStore)(nil).UndeleteBoard), arg0, arg1)
 }
 
+// UpdateAutomationRule mocks base method.
+func (m *MockStore) UpdateAutomationRule(arg0 *model.AutomationRule) (*model.AutomationRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAutomationRule", arg0)
+	ret0, _ := ret[0].(*model.AutomationRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAutomationRule indicates an expected call of UpdateAutomationRule.
+func (mr *MockStoreMockRecorder) UpdateAutomationRule(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAutomationRule", reflect.TypeOf((*MockStore)(nil).UpdateAutomationRule), arg0)
+}
+
+// UpdateBoardGitHubRepoScannedAt mocks base method.
+func (m *MockStore) UpdateBoardGitHubRepoScannedAt(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardGitHubRepoScannedAt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBoardGitHubRepoScannedAt indicates an expected call of UpdateBoardGitHubRepoScannedAt.
+func (mr *MockStoreMockRecorder) UpdateBoardGitHubRepoScannedAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardGitHubRepoScannedAt", reflect.TypeOf((*MockStore)(nil).UpdateBoardGitHubRepoScannedAt), arg0, arg1)
+}
+
+// UpdateBoardWebhook mocks base method.
+func (m *MockStore) UpdateBoardWebhook(arg0 *model.BoardWebhook) (*model.BoardWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoardWebhook", arg0)
+	ret0, _ := ret[0].(*model.BoardWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoardWebhook indicates an expected call of UpdateBoardWebhook.
+func (mr *MockStoreMockRecorder) UpdateBoardWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoardWebhook", reflect.TypeOf((*MockStore)(nil).UpdateBoardWebhook), arg0)
+}
+
+// UpdateCardGitHubLinkSync mocks base method.
+func (m *MockStore) UpdateCardGitHubLinkSync(arg0 *model.CardGitHubLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCardGitHubLinkSync", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCardGitHubLinkSync indicates an expected call of UpdateCardGitHubLinkSync.
+func (mr *MockStoreMockRecorder) UpdateCardGitHubLinkSync(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCardGitHubLinkSync", reflect.TypeOf((*MockStore)(nil).UpdateCardGitHubLinkSync), arg0)
+}
+
 // UpdateCardLimitTimestamp mocks base method.
 func (m *MockStore) UpdateCardLimitTimestamp(arg0 int) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1769,6 +2740,21 @@ func (mr *MockStoreMockRecorder) UpdateCardLimitTimestamp(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCardLimitTimestamp", reflect.TypeOf((*MockStore)(nil).UpdateCardLimitTimestamp), arg0)
 }
 
+// UpdateCardRecurrenceNextAt mocks base method.
+func (m *MockStore) UpdateCardRecurrenceNextAt(arg0 string, arg1, arg2 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCardRecurrenceNextAt", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCardRecurrenceNextAt indicates an expected call of UpdateCardRecurrenceNextAt.
+func (mr *MockStoreMockRecorder) UpdateCardRecurrenceNextAt(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCardRecurrenceNextAt", reflect.TypeOf((*MockStore)(nil).UpdateCardRecurrenceNextAt), arg0, arg1, arg2)
+}
+
 // UpdateCardRelation mocks base method.
 func (m *MockStore) UpdateCardRelation(arg0 *model.CardRelation) (*model.CardRelation, error) {
 	m.ctrl.T.Helper()
@@ -1798,6 +2784,35 @@ func (mr *MockStoreMockRecorder) UpdateCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), arg0)
 }
 
+// UpdateGitHubIssueSyncState mocks base method.
+func (m *MockStore) UpdateGitHubIssueSyncState(arg0 *model.GitHubIssueSync) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGitHubIssueSyncState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGitHubIssueSyncState indicates an expected call of UpdateGitHubIssueSyncState.
+func (mr *MockStoreMockRecorder) UpdateGitHubIssueSyncState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGitHubIssueSyncState", reflect.TypeOf((*MockStore)(nil).UpdateGitHubIssueSyncState), arg0)
+}
+
+// UpdateIncomingWebhook mocks base method.
+func (m *MockStore) UpdateIncomingWebhook(arg0 *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIncomingWebhook", arg0)
+	ret0, _ := ret[0].(*model.IncomingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIncomingWebhook indicates an expected call of UpdateIncomingWebhook.
+func (mr *MockStoreMockRecorder) UpdateIncomingWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIncomingWebhook", reflect.TypeOf((*MockStore)(nil).UpdateIncomingWebhook), arg0)
+}
+
 // UpdateSubscribersNotifiedAt mocks base method.
 func (m *MockStore) UpdateSubscribersNotifiedAt(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -1812,6 +2827,64 @@ func (mr *MockStoreMockRecorder) UpdateSubscribersNotifiedAt(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscribersNotifiedAt", reflect.TypeOf((*MockStore)(nil).UpdateSubscribersNotifiedAt), arg0, arg1)
 }
 
+// UpdateWebhookDelivery mocks base method.
+func (m *MockStore) UpdateWebhookDelivery(arg0 *model.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookDelivery", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookDelivery indicates an expected call of UpdateWebhookDelivery.
+func (mr *MockStoreMockRecorder) UpdateWebhookDelivery(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDelivery", reflect.TypeOf((*MockStore)(nil).UpdateWebhookDelivery), arg0)
+}
+
+// UpdateWorklog mocks base method.
+func (m *MockStore) UpdateWorklog(arg0 *model.Worklog) (*model.Worklog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorklog", arg0)
+	ret0, _ := ret[0].(*model.Worklog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWorklog indicates an expected call of UpdateWorklog.
+func (mr *MockStoreMockRecorder) UpdateWorklog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorklog", reflect.TypeOf((*MockStore)(nil).UpdateWorklog), arg0)
+}
+
+// UpsertActivityDigestChange mocks base method.
+func (m *MockStore) UpsertActivityDigestChange(arg0 *model.ActivityDigestChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertActivityDigestChange", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertActivityDigestChange indicates an expected call of UpsertActivityDigestChange.
+func (mr *MockStoreMockRecorder) UpsertActivityDigestChange(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertActivityDigestChange", reflect.TypeOf((*MockStore)(nil).UpsertActivityDigestChange), arg0)
+}
+
+// UpsertCardRecurrence mocks base method.
+func (m *MockStore) UpsertCardRecurrence(arg0 *model.CardRecurrence) (*model.CardRecurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCardRecurrence", arg0)
+	ret0, _ := ret[0].(*model.CardRecurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertCardRecurrence indicates an expected call of UpsertCardRecurrence.
+func (mr *MockStoreMockRecorder) UpsertCardRecurrence(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCardRecurrence", reflect.TypeOf((*MockStore)(nil).UpsertCardRecurrence), arg0)
+}
+
 // UpsertNotificationHint mocks base method.
 func (m *MockStore) UpsertNotificationHint(arg0 *model.NotificationHint, arg1 time.Duration) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
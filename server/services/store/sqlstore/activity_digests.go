@@ -0,0 +1,132 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// upsertActivityDigestChange records the latest change of a card for the
+// activity digests.
+func (s *SQLStore) upsertActivityDigestChange(db sq.BaseRunner, change *model.ActivityDigestChange) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"activity_digest_changes").
+		Columns("card_id", "board_id", "update_at").
+		Values(change.CardID, change.BoardID, change.UpdateAt)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE board_id = ?, update_at = ?", change.BoardID, change.UpdateAt)
+	} else {
+		query = query.Suffix("ON CONFLICT (card_id) DO UPDATE SET board_id = EXCLUDED.board_id, update_at = EXCLUDED.update_at")
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("upsertActivityDigestChange error", mlog.String("card_id", change.CardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getActivityDigestChanges returns the cards changed after a time.
+func (s *SQLStore) getActivityDigestChanges(db sq.BaseRunner, since int64) ([]*model.ActivityDigestChange, error) {
+	query := s.getQueryBuilder(db).
+		Select("card_id", "board_id", "update_at").
+		From(s.tablePrefix+"activity_digest_changes").
+		Where(sq.Gt{"update_at": since}).
+		OrderBy("board_id", "update_at")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getActivityDigestChanges error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	changes := []*model.ActivityDigestChange{}
+	for rows.Next() {
+		var change model.ActivityDigestChange
+		if err := rows.Scan(&change.CardID, &change.BoardID, &change.UpdateAt); err != nil {
+			s.logger.Error("getActivityDigestChanges scan error", mlog.Err(err))
+			return nil, err
+		}
+		changes = append(changes, &change)
+	}
+	return changes, rows.Err()
+}
+
+func (s *SQLStore) deleteActivityDigestChangesBefore(db sq.BaseRunner, updateAt int64) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "activity_digest_changes").
+		Where(sq.Lt{"update_at": updateAt})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("deleteActivityDigestChangesBefore error", mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getActivityDigestSentAt returns the time of the last activity digest of a
+// user.
+func (s *SQLStore) getActivityDigestSentAt(db sq.BaseRunner, userID string) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Select("sent_at").
+		From(s.tablePrefix + "activity_digests").
+		Where(sq.Eq{"user_id": userID})
+
+	var sentAt int64
+	if err := query.QueryRow().Scan(&sentAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, model.NewErrNotFound("activity digest UserID=" + userID)
+		}
+		s.logger.Error("getActivityDigestSentAt error", mlog.Err(err))
+		return 0, err
+	}
+	return sentAt, nil
+}
+
+// claimActivityDigest moves the time of the last activity digest of a user,
+// if it is still the expected one, or records it if lastSentAt is zero. It
+// returns false if another server sent the digest first.
+func (s *SQLStore) claimActivityDigest(db sq.BaseRunner, userID string, lastSentAt int64, sentAt int64) (bool, error) {
+	var result sql.Result
+	var err error
+
+	if lastSentAt == 0 {
+		query := s.getQueryBuilder(db).
+			Insert(s.tablePrefix+"activity_digests").
+			Columns("user_id", "sent_at").
+			Values(userID, sentAt)
+
+		if s.dbType == model.MysqlDBType {
+			query = query.Suffix("ON DUPLICATE KEY UPDATE user_id = user_id")
+		} else {
+			query = query.Suffix("ON CONFLICT (user_id) DO NOTHING")
+		}
+		result, err = query.Exec()
+	} else {
+		query := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"activity_digests").
+			Set("sent_at", sentAt).
+			Where(sq.Eq{"user_id": userID}).
+			Where(sq.Eq{"sent_at": lastSentAt})
+		result, err = query.Exec()
+	}
+	if err != nil {
+		s.logger.Error("claimActivityDigest error", mlog.Err(err))
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
@@ -0,0 +1,284 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func automationRuleFields(tableAlias string) []string {
+	if tableAlias != "" && tableAlias[len(tableAlias)-1] != '.' {
+		tableAlias += "."
+	}
+
+	return []string{
+		tableAlias + "id",
+		tableAlias + "board_id",
+		"COALESCE(" + tableAlias + "title, '')",
+		tableAlias + "enabled",
+		tableAlias + "trigger_config",
+		tableAlias + "actions_config",
+		tableAlias + "created_by",
+		tableAlias + "modified_by",
+		tableAlias + "create_at",
+		tableAlias + "update_at",
+		tableAlias + "due_checked_at",
+	}
+}
+
+func (s *SQLStore) automationRuleFromRow(row sq.RowScanner) (*model.AutomationRule, error) {
+	var rule model.AutomationRule
+	var triggerJSON string
+	var actionsJSON string
+
+	err := row.Scan(
+		&rule.ID,
+		&rule.BoardID,
+		&rule.Title,
+		&rule.Enabled,
+		&triggerJSON,
+		&actionsJSON,
+		&rule.CreatedBy,
+		&rule.ModifiedBy,
+		&rule.CreateAt,
+		&rule.UpdateAt,
+		&rule.DueCheckedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(triggerJSON), &rule.Trigger); err != nil {
+		return nil, fmt.Errorf("cannot parse trigger of automation rule %s: %w", rule.ID, err)
+	}
+	if err := json.Unmarshal([]byte(actionsJSON), &rule.Actions); err != nil {
+		return nil, fmt.Errorf("cannot parse actions of automation rule %s: %w", rule.ID, err)
+	}
+
+	return &rule, nil
+}
+
+func (s *SQLStore) automationRulesFromRows(rows *sql.Rows) ([]*model.AutomationRule, error) {
+	rules := []*model.AutomationRule{}
+	for rows.Next() {
+		rule, err := s.automationRuleFromRow(rows)
+		if err != nil {
+			s.logger.Error("automationRulesFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func marshalAutomationRule(rule *model.AutomationRule) (string, string, error) {
+	triggerJSON, err := json.Marshal(rule.Trigger)
+	if err != nil {
+		return "", "", err
+	}
+	actionsJSON, err := json.Marshal(rule.Actions)
+	if err != nil {
+		return "", "", err
+	}
+	return string(triggerJSON), string(actionsJSON), nil
+}
+
+func (s *SQLStore) createAutomationRule(db sq.BaseRunner, rule *model.AutomationRule) (*model.AutomationRule, error) {
+	rule.Populate()
+
+	if err := rule.IsValid(); err != nil {
+		return nil, err
+	}
+
+	triggerJSON, actionsJSON, err := marshalAutomationRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"automation_rules").
+		Columns(
+			"id",
+			"board_id",
+			"title",
+			"enabled",
+			"trigger_type",
+			"trigger_config",
+			"actions_config",
+			"created_by",
+			"modified_by",
+			"create_at",
+			"update_at",
+			"due_checked_at",
+		).
+		Values(
+			rule.ID,
+			rule.BoardID,
+			rule.Title,
+			rule.Enabled,
+			rule.Trigger.Type,
+			triggerJSON,
+			actionsJSON,
+			rule.CreatedBy,
+			rule.ModifiedBy,
+			rule.CreateAt,
+			rule.UpdateAt,
+			rule.DueCheckedAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("createAutomationRule error", mlog.Err(err))
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *SQLStore) getAutomationRule(db sq.BaseRunner, ruleID string) (*model.AutomationRule, error) {
+	query := s.getQueryBuilder(db).
+		Select(automationRuleFields("")...).
+		From(s.tablePrefix + "automation_rules").
+		Where(sq.Eq{"id": ruleID})
+
+	rule, err := s.automationRuleFromRow(query.QueryRow())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.NewErrNotFound("automation rule ID=" + ruleID)
+		}
+		s.logger.Error("getAutomationRule error", mlog.Err(err))
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *SQLStore) getAutomationRules(db sq.BaseRunner, boardID string) ([]*model.AutomationRule, error) {
+	query := s.getQueryBuilder(db).
+		Select(automationRuleFields("")...).
+		From(s.tablePrefix+"automation_rules").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getAutomationRules error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.automationRulesFromRows(rows)
+}
+
+func (s *SQLStore) getEnabledAutomationRulesByTrigger(db sq.BaseRunner, triggerType model.AutomationTriggerType) ([]*model.AutomationRule, error) {
+	query := s.getQueryBuilder(db).
+		Select(automationRuleFields("")...).
+		From(s.tablePrefix+"automation_rules").
+		Where(sq.Eq{"trigger_type": triggerType}).
+		Where(sq.Eq{"enabled": true}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getEnabledAutomationRulesByTrigger error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.automationRulesFromRows(rows)
+}
+
+func (s *SQLStore) updateAutomationRule(db sq.BaseRunner, rule *model.AutomationRule) (*model.AutomationRule, error) {
+	if err := rule.IsValid(); err != nil {
+		return nil, err
+	}
+
+	triggerJSON, actionsJSON, err := marshalAutomationRule(rule)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"automation_rules").
+		Set("title", rule.Title).
+		Set("enabled", rule.Enabled).
+		Set("trigger_type", rule.Trigger.Type).
+		Set("trigger_config", triggerJSON).
+		Set("actions_config", actionsJSON).
+		Set("modified_by", rule.ModifiedBy).
+		Set("update_at", rule.UpdateAt).
+		Set("due_checked_at", rule.DueCheckedAt).
+		Where(sq.Eq{"id": rule.ID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("updateAutomationRule error", mlog.Err(err))
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, model.NewErrNotFound("automation rule ID=" + rule.ID)
+	}
+
+	return rule, nil
+}
+
+// claimAutomationRuleDueCheck moves the due date watermark of a rule from
+// checkedAt to until. It returns false if another server moved it first.
+func (s *SQLStore) claimAutomationRuleDueCheck(db sq.BaseRunner, rule *model.AutomationRule, until int64) (bool, error) {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"automation_rules").
+		Set("due_checked_at", until).
+		Where(sq.Eq{"id": rule.ID}).
+		Where(sq.Eq{"due_checked_at": rule.DueCheckedAt})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("claimAutomationRuleDueCheck error", mlog.Err(err))
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+func (s *SQLStore) deleteAutomationRule(db sq.BaseRunner, ruleID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "automation_rules").
+		Where(sq.Eq{"id": ruleID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("deleteAutomationRule error", mlog.Err(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return model.NewErrNotFound("automation rule ID=" + ruleID)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// searchBlocks runs a full-text search over the titles of the indexed block
+// types and returns the matching blocks, most relevant first.
+func (s *SQLStore) searchBlocks(db sq.BaseRunner, opts model.QueryBlockSearchOptions) ([]*model.Block, error) {
+	words := model.SearchWords(opts.Terms)
+	if len(words) == 0 || len(opts.BoardIDs) == 0 {
+		return []*model.Block{}, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("b.")...).
+		From(s.tablePrefix + "blocks as b").
+		Where(sq.Eq{"b.board_id": opts.BoardIDs}).
+		Where(sq.Eq{"b.type": model.BlockSearchTypes}).
+		Where(sq.Eq{"b.delete_at": 0}).
+		Where(s.notTemplateCardCondition())
+
+	switch s.dbType {
+	case model.PostgresDBType:
+		// the expression must match the one of idx_blocks_title_fulltext
+		tsQuery := strings.Join(words, " ")
+		query = query.
+			Where("to_tsvector('simple', COALESCE(b.title, '')) @@ plainto_tsquery('simple', ?)", tsQuery).
+			OrderByClause("ts_rank(to_tsvector('simple', COALESCE(b.title, '')), plainto_tsquery('simple', ?)) DESC", tsQuery)
+	case model.MysqlDBType:
+		required := make([]string, len(words))
+		for i, word := range words {
+			required[i] = "+" + word
+		}
+		query = query.
+			Where("MATCH (b.title) AGAINST (? IN BOOLEAN MODE)", strings.Join(required, " ")).
+			OrderByClause("MATCH (b.title) AGAINST (? IN NATURAL LANGUAGE MODE) DESC", strings.Join(words, " "))
+	default:
+		for _, word := range words {
+			query = query.Where(sq.Like{"lower(b.title)": "%" + strings.ToLower(word) + "%"})
+		}
+	}
+
+	query = query.OrderBy("b.update_at DESC", "b.id")
+
+	if opts.Page != 0 {
+		query = query.Offset(offset(opts.Page, opts.PerPage))
+	}
+
+	if opts.PerPage > 0 {
+		query = query.Limit(limit(opts.PerPage))
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`searchBlocks ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
@@ -405,6 +405,33 @@ func (s *SQLStore) patchBlock(db sq.BaseRunner, blockID string, blockPatch *mode
 	return s.insertBlock(db, block, userID)
 }
 
+// patchBlockProperties sets and removes properties of a block, keeping the
+// properties stored since the caller read the block.
+func (s *SQLStore) patchBlockProperties(db sq.BaseRunner, blockID string, updatedProperties map[string]any, deletedProperties []string, userID string) error {
+	block, err := s.getBlock(db, blockID)
+	if err != nil {
+		return err
+	}
+
+	properties := map[string]any{}
+	if current, ok := block.Fields["properties"].(map[string]any); ok {
+		for propID, value := range current {
+			properties[propID] = value
+		}
+	}
+	for propID, value := range updatedProperties {
+		properties[propID] = value
+	}
+	for _, propID := range deletedProperties {
+		delete(properties, propID)
+	}
+	if block.Fields == nil {
+		block.Fields = map[string]any{}
+	}
+	block.Fields["properties"] = properties
+	return s.insertBlock(db, block, userID)
+}
+
 func (s *SQLStore) patchBlocks(db sq.BaseRunner, blockPatches *model.BlockPatchBatch, userID string) error {
 	for i, blockID := range blockPatches.BlockIDs {
 		err := s.patchBlock(db, blockID, &blockPatches.BlockPatches[i], userID)
@@ -740,7 +767,8 @@ func (s *SQLStore) getCardByCode(db sq.BaseRunner, code string) (*model.Block, *
 	}
 
 	if len(blocks) == 0 {
-		return nil, nil, model.NewErrNotFound("card with code " + code)
+		// the card may have moved to another board since.
+		return s.getCardByCodeAlias(db, boardCode, number, code)
 	}
 
 	card := blocks[0]
@@ -1060,6 +1088,61 @@ func (s *SQLStore) duplicateBlock(db sq.BaseRunner, boardID string, blockID stri
 	return allBlocks, nil
 }
 
+// moveCardToBoard moves a card and its content blocks to another board. The
+// card is stored with its new fields and number, and a history entry is
+// written for every moved block.
+func (s *SQLStore) moveCardToBoard(db sq.BaseRunner, card *model.Block, boardID string, userID string) error {
+	children, err := s.getBlocksWithParent(db, card.BoardID, card.ID)
+	if err != nil {
+		return err
+	}
+
+	// the code of the card on its board stays an alias of the card.
+	oldCard, err := s.getBlock(db, card.ID)
+	if err != nil {
+		return err
+	}
+	if oldCard.Number > 0 {
+		if err := s.insertCardCodeAlias(db, oldCard.BoardID, oldCard.Number, card.ID); err != nil {
+			return err
+		}
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"blocks").
+		Set("board_id", boardID).
+		Where(sq.Eq{"board_id": card.BoardID}).
+		Where(sq.Or{
+			sq.Eq{"id": card.ID},
+			sq.Eq{"parent_id": card.ID},
+		})
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("moveCardToBoard error", mlog.String("cardID", card.ID), mlog.Err(err))
+		return err
+	}
+
+	if err := s.moveWorklogsToBoard(db, card.ID, boardID); err != nil {
+		return err
+	}
+
+	if err := s.moveCardGitHubLinksToBoard(db, card.ID, boardID); err != nil {
+		return err
+	}
+
+	// the card no longer belongs to the board synchronized with its issue.
+	if err := s.deleteGitHubIssueSyncItem(db, card.ID); err != nil {
+		return err
+	}
+
+	for _, block := range append([]*model.Block{card}, children...) {
+		block.BoardID = boardID
+		if err := s.insertBlock(db, block, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *SQLStore) deleteBlockChildren(db sq.BaseRunner, boardID string, parentID string, modifiedBy string) error {
 	now := utils.GetMillis()
 
@@ -483,6 +483,18 @@ func (s *SQLStore) deleteBoardAndChildren(db sq.BaseRunner, boardID, userID stri
 		return err
 	}
 
+	if err := s.deleteGitHubStatusRulesForBoard(db, boardID); err != nil {
+		return err
+	}
+
+	if err := s.deleteBoardGitHubReposForBoard(db, boardID); err != nil {
+		return err
+	}
+
+	if err := s.deleteGitHubIssueSync(db, boardID); err != nil {
+		return err
+	}
+
 	if keepChildren {
 		return nil
 	}
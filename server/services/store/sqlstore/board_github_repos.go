@@ -0,0 +1,190 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func boardGitHubRepoFields() []string {
+	return []string{
+		"id",
+		"board_id",
+		"owner",
+		"repo",
+		"scanned_at",
+		"created_by",
+		"create_at",
+	}
+}
+
+func (s *SQLStore) boardGitHubReposFromRows(rows *sql.Rows) ([]*model.BoardGitHubRepo, error) {
+	repos := []*model.BoardGitHubRepo{}
+	for rows.Next() {
+		var repo model.BoardGitHubRepo
+		err := rows.Scan(
+			&repo.ID,
+			&repo.BoardID,
+			&repo.Owner,
+			&repo.Repo,
+			&repo.ScannedAt,
+			&repo.CreatedBy,
+			&repo.CreateAt,
+		)
+		if err != nil {
+			s.logger.Error("boardGitHubReposFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		repos = append(repos, &repo)
+	}
+	return repos, rows.Err()
+}
+
+func (s *SQLStore) createBoardGitHubRepo(db sq.BaseRunner, repo *model.BoardGitHubRepo) (*model.BoardGitHubRepo, error) {
+	repo.Populate()
+	if err := repo.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_github_repos").
+		Columns(boardGitHubRepoFields()...).
+		Values(
+			repo.ID,
+			repo.BoardID,
+			repo.Owner,
+			repo.Repo,
+			repo.ScannedAt,
+			repo.CreatedBy,
+			repo.CreateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("createBoardGitHubRepo error", mlog.String("board_id", repo.BoardID), mlog.Err(err))
+		return nil, err
+	}
+	return repo, nil
+}
+
+func (s *SQLStore) getBoardGitHubRepo(db sq.BaseRunner, repoID string) (*model.BoardGitHubRepo, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardGitHubRepoFields()...).
+		From(s.tablePrefix + "board_github_repos").
+		Where(sq.Eq{"id": repoID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getBoardGitHubRepo error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	repos, err := s.boardGitHubReposFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(repos) == 0 {
+		return nil, model.NewErrNotFound("github repository ID=" + repoID)
+	}
+	return repos[0], nil
+}
+
+// getBoardGitHubRepos returns the repositories connected to a board, oldest
+// first.
+func (s *SQLStore) getBoardGitHubRepos(db sq.BaseRunner, boardID string) ([]*model.BoardGitHubRepo, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardGitHubRepoFields()...).
+		From(s.tablePrefix+"board_github_repos").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getBoardGitHubRepos error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardGitHubReposFromRows(rows)
+}
+
+// getBoardGitHubReposToScan returns the repositories whose last scan is
+// older than a time, least recently scanned first.
+func (s *SQLStore) getBoardGitHubReposToScan(db sq.BaseRunner, scannedBefore int64, limit int) ([]*model.BoardGitHubRepo, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardGitHubRepoFields()...).
+		From(s.tablePrefix+"board_github_repos").
+		Where(sq.Lt{"scanned_at": scannedBefore}).
+		OrderBy("scanned_at", "id").
+		Limit(uint64(limit))
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getBoardGitHubReposToScan error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardGitHubReposFromRows(rows)
+}
+
+func (s *SQLStore) updateBoardGitHubRepoScannedAt(db sq.BaseRunner, repoID string, scannedAt int64) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_github_repos").
+		Set("scanned_at", scannedAt).
+		Where(sq.Eq{"id": repoID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("updateBoardGitHubRepoScannedAt error", mlog.String("repo_id", repoID), mlog.Err(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return model.NewErrNotFound("github repository ID=" + repoID)
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteBoardGitHubRepo(db sq.BaseRunner, repoID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_github_repos").
+		Where(sq.Eq{"id": repoID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("deleteBoardGitHubRepo error", mlog.Err(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return model.NewErrNotFound("github repository ID=" + repoID)
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteBoardGitHubReposForBoard(db sq.BaseRunner, boardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_github_repos").
+		Where(sq.Eq{"board_id": boardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("deleteBoardGitHubReposForBoard error", mlog.Err(err))
+		return err
+	}
+	return nil
+}
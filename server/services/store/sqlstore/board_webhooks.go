@@ -0,0 +1,408 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func boardWebhookFields(tableAlias string) []string {
+	if tableAlias != "" && tableAlias[len(tableAlias)-1] != '.' {
+		tableAlias += "."
+	}
+
+	return []string{
+		tableAlias + "id",
+		tableAlias + "board_id",
+		tableAlias + "url",
+		tableAlias + "secret",
+		tableAlias + "events",
+		tableAlias + "enabled",
+		tableAlias + "created_by",
+		tableAlias + "create_at",
+		tableAlias + "update_at",
+	}
+}
+
+func (s *SQLStore) boardWebhookFromRow(row sq.RowScanner) (*model.BoardWebhook, error) {
+	var webhook model.BoardWebhook
+	var eventsJSON string
+
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.BoardID,
+		&webhook.URL,
+		&webhook.Secret,
+		&eventsJSON,
+		&webhook.Enabled,
+		&webhook.CreatedBy,
+		&webhook.CreateAt,
+		&webhook.UpdateAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(eventsJSON), &webhook.Events); err != nil {
+		return nil, fmt.Errorf("cannot parse events of webhook %s: %w", webhook.ID, err)
+	}
+
+	return &webhook, nil
+}
+
+func (s *SQLStore) boardWebhooksFromRows(rows *sql.Rows) ([]*model.BoardWebhook, error) {
+	webhooks := []*model.BoardWebhook{}
+
+	for rows.Next() {
+		webhook, err := s.boardWebhookFromRow(rows)
+		if err != nil {
+			s.logger.Error("boardWebhooksFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error("boardWebhooksFromRows iteration error", mlog.Err(err))
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (s *SQLStore) createBoardWebhook(db sq.BaseRunner, webhook *model.BoardWebhook) (*model.BoardWebhook, error) {
+	if err := webhook.Populate(); err != nil {
+		return nil, err
+	}
+
+	if err := webhook.IsValid(); err != nil {
+		return nil, err
+	}
+
+	eventsJSON, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_webhooks").
+		Columns(boardWebhookFields("")...).
+		Values(
+			webhook.ID,
+			webhook.BoardID,
+			webhook.URL,
+			webhook.Secret,
+			string(eventsJSON),
+			webhook.Enabled,
+			webhook.CreatedBy,
+			webhook.CreateAt,
+			webhook.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("createBoardWebhook error", mlog.Err(err))
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *SQLStore) getBoardWebhook(db sq.BaseRunner, webhookID string) (*model.BoardWebhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardWebhookFields("")...).
+		From(s.tablePrefix + "board_webhooks").
+		Where(sq.Eq{"id": webhookID})
+
+	webhook, err := s.boardWebhookFromRow(query.QueryRow())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.NewErrNotFound("board webhook ID=" + webhookID)
+		}
+		s.logger.Error("getBoardWebhook error", mlog.Err(err))
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *SQLStore) getBoardWebhooks(db sq.BaseRunner, boardID string) ([]*model.BoardWebhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardWebhookFields("")...).
+		From(s.tablePrefix+"board_webhooks").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getBoardWebhooks error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardWebhooksFromRows(rows)
+}
+
+func (s *SQLStore) updateBoardWebhook(db sq.BaseRunner, webhook *model.BoardWebhook) (*model.BoardWebhook, error) {
+	if err := webhook.IsValid(); err != nil {
+		return nil, err
+	}
+
+	eventsJSON, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"board_webhooks").
+		Set("url", webhook.URL).
+		Set("events", string(eventsJSON)).
+		Set("enabled", webhook.Enabled).
+		Set("update_at", webhook.UpdateAt).
+		Where(sq.Eq{"id": webhook.ID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("updateBoardWebhook error", mlog.Err(err))
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, model.NewErrNotFound("board webhook ID=" + webhook.ID)
+	}
+
+	return webhook, nil
+}
+
+func (s *SQLStore) deleteBoardWebhook(db sq.BaseRunner, webhookID string) error {
+	deleteDeliveries := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "webhook_deliveries").
+		Where(sq.Eq{"webhook_id": webhookID})
+
+	if _, err := deleteDeliveries.Exec(); err != nil {
+		s.logger.Error("deleteBoardWebhook deliveries error", mlog.Err(err))
+		return err
+	}
+
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "board_webhooks").
+		Where(sq.Eq{"id": webhookID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("deleteBoardWebhook error", mlog.Err(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return model.NewErrNotFound("board webhook ID=" + webhookID)
+	}
+
+	return nil
+}
+
+func webhookDeliveryFields(tableAlias string) []string {
+	if tableAlias != "" && tableAlias[len(tableAlias)-1] != '.' {
+		tableAlias += "."
+	}
+
+	return []string{
+		tableAlias + "id",
+		tableAlias + "webhook_id",
+		tableAlias + "board_id",
+		tableAlias + "event",
+		tableAlias + "payload",
+		tableAlias + "status",
+		tableAlias + "attempts",
+		tableAlias + "next_attempt_at",
+		tableAlias + "last_attempt_at",
+		tableAlias + "response_code",
+		"COALESCE(" + tableAlias + "last_error, '')",
+		tableAlias + "create_at",
+	}
+}
+
+func (s *SQLStore) webhookDeliveriesFromRows(rows *sql.Rows) ([]*model.WebhookDelivery, error) {
+	deliveries := []*model.WebhookDelivery{}
+
+	for rows.Next() {
+		var delivery model.WebhookDelivery
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.BoardID,
+			&delivery.Event,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastAttemptAt,
+			&delivery.ResponseCode,
+			&delivery.Error,
+			&delivery.CreateAt,
+		)
+		if err != nil {
+			s.logger.Error("webhookDeliveriesFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.logger.Error("webhookDeliveriesFromRows iteration error", mlog.Err(err))
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (s *SQLStore) insertWebhookDeliveries(db sq.BaseRunner, deliveries []*model.WebhookDelivery) error {
+	for _, delivery := range deliveries {
+		query := s.getQueryBuilder(db).
+			Insert(s.tablePrefix+"webhook_deliveries").
+			Columns(
+				"id",
+				"webhook_id",
+				"board_id",
+				"event",
+				"payload",
+				"status",
+				"attempts",
+				"next_attempt_at",
+				"last_attempt_at",
+				"response_code",
+				"last_error",
+				"create_at",
+			).
+			Values(
+				delivery.ID,
+				delivery.WebhookID,
+				delivery.BoardID,
+				delivery.Event,
+				delivery.Payload,
+				delivery.Status,
+				delivery.Attempts,
+				delivery.NextAttemptAt,
+				delivery.LastAttemptAt,
+				delivery.ResponseCode,
+				delivery.Error,
+				delivery.CreateAt,
+			)
+
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error("insertWebhookDeliveries error", mlog.String("webhookID", delivery.WebhookID), mlog.Err(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// getDueWebhookDeliveries returns the pending deliveries whose next attempt
+// is due, oldest first.
+func (s *SQLStore) getDueWebhookDeliveries(db sq.BaseRunner, now int64, limit uint64) ([]*model.WebhookDelivery, error) {
+	query := s.getQueryBuilder(db).
+		Select(webhookDeliveryFields("")...).
+		From(s.tablePrefix+"webhook_deliveries").
+		Where(sq.Eq{"status": model.WebhookDeliveryStatusPending}).
+		Where(sq.LtOrEq{"next_attempt_at": now}).
+		OrderBy("next_attempt_at", "create_at").
+		Limit(limit)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getDueWebhookDeliveries error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.webhookDeliveriesFromRows(rows)
+}
+
+// claimWebhookDelivery moves the next attempt of a pending delivery to
+// claimUntil, provided no one else claimed it since it was read. It returns
+// false if the delivery was claimed by another server.
+func (s *SQLStore) claimWebhookDelivery(db sq.BaseRunner, delivery *model.WebhookDelivery, claimUntil int64) (bool, error) {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"webhook_deliveries").
+		Set("next_attempt_at", claimUntil).
+		Where(sq.Eq{
+			"id":              delivery.ID,
+			"status":          model.WebhookDeliveryStatusPending,
+			"next_attempt_at": delivery.NextAttemptAt,
+		})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("claimWebhookDelivery error", mlog.Err(err))
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	delivery.NextAttemptAt = claimUntil
+	return true, nil
+}
+
+func (s *SQLStore) updateWebhookDelivery(db sq.BaseRunner, delivery *model.WebhookDelivery) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"webhook_deliveries").
+		Set("status", delivery.Status).
+		Set("attempts", delivery.Attempts).
+		Set("next_attempt_at", delivery.NextAttemptAt).
+		Set("last_attempt_at", delivery.LastAttemptAt).
+		Set("response_code", delivery.ResponseCode).
+		Set("last_error", delivery.Error).
+		Where(sq.Eq{"id": delivery.ID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("updateWebhookDelivery error", mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) getWebhookDeliveries(db sq.BaseRunner, webhookID string, page, perPage int) ([]*model.WebhookDelivery, error) {
+	query := s.getQueryBuilder(db).
+		Select(webhookDeliveryFields("")...).
+		From(s.tablePrefix+"webhook_deliveries").
+		Where(sq.Eq{"webhook_id": webhookID}).
+		OrderBy("create_at DESC", "id")
+
+	if page != 0 {
+		query = query.Offset(offset(page, perPage))
+	}
+
+	if perPage > 0 {
+		query = query.Limit(limit(perPage))
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getWebhookDeliveries error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.webhookDeliveriesFromRows(rows)
+}
@@ -202,6 +202,10 @@ func (s *SQLStore) duplicateBoard(db sq.BaseRunner, boardID string, userID strin
 				FromStatus: rule.FromStatus,
 				ToStatus:   rule.ToStatus,
 				Allowed:    rule.Allowed,
+
+				RequiredProperties: rule.RequiredProperties,
+				AllowedRoles:       rule.AllowedRoles,
+				RequireComment:     rule.RequireComment,
 			}
 			newRules[i] = newRule
 		}
@@ -0,0 +1,71 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// insertCardCodeAlias records that a number of a board belongs to a card that
+// moved to another board, so that its former code still finds it.
+func (s *SQLStore) insertCardCodeAlias(db sq.BaseRunner, boardID string, number int64, cardID string) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_code_aliases").
+		Columns("board_id", "number", "card_id", "create_at").
+		Values(boardID, number, cardID, utils.GetMillis())
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE card_id = ?", cardID)
+	} else {
+		query = query.Suffix("ON CONFLICT (board_id, number) DO UPDATE SET card_id = EXCLUDED.card_id")
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("insertCardCodeAlias error", mlog.String("card_id", cardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getCardByCodeAlias returns the card that had a code before it moved to
+// another board, and its current board.
+func (s *SQLStore) getCardByCodeAlias(db sq.BaseRunner, boardCode string, number int64, code string) (*model.Block, *model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select("a.card_id").
+		From(s.tablePrefix + "card_code_aliases as a").
+		Join(s.tablePrefix + "boards as board ON a.board_id = board.id").
+		Where(sq.Eq{"board.code": boardCode}).
+		Where(sq.Eq{"a.number": number}).
+		Where(sq.Eq{"board.delete_at": 0})
+
+	var cardID string
+	if err := query.QueryRow().Scan(&cardID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, model.NewErrNotFound("card with code " + code)
+		}
+		s.logger.Error("getCardByCodeAlias error", mlog.Err(err))
+		return nil, nil, err
+	}
+
+	card, err := s.getBlock(db, cardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if card.Type != model.TypeCard || card.DeleteAt != 0 {
+		return nil, nil, model.NewErrNotFound("card with code " + code)
+	}
+
+	board, err := s.getBoard(db, card.BoardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return card, board, nil
+}
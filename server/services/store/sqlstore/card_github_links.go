@@ -0,0 +1,300 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func cardGitHubLinkFields(prefix string) []string {
+	return []string{
+		prefix + "id",
+		prefix + "board_id",
+		prefix + "card_id",
+		"COALESCE(" + prefix + "provider, 'github')",
+		prefix + "kind",
+		prefix + "owner",
+		prefix + "repo",
+		"COALESCE(" + prefix + "project, '')",
+		prefix + "number",
+		"COALESCE(" + prefix + "sha, '')",
+		"COALESCE(" + prefix + "url, '')",
+		"COALESCE(" + prefix + "title, '')",
+		"COALESCE(" + prefix + "state, '')",
+		"COALESCE(" + prefix + "labels, '[]')",
+		"COALESCE(" + prefix + "assignees, '[]')",
+		prefix + "merged",
+		prefix + "synced_at",
+		"COALESCE(" + prefix + "sync_error, '')",
+		prefix + "sync_attempt_at",
+		prefix + "created_by",
+		prefix + "create_at",
+		prefix + "update_at",
+	}
+}
+
+func (s *SQLStore) cardGitHubLinksFromRows(rows *sql.Rows) ([]*model.CardGitHubLink, error) {
+	links := []*model.CardGitHubLink{}
+	for rows.Next() {
+		var link model.CardGitHubLink
+		var labelsJSON, assigneesJSON string
+		err := rows.Scan(
+			&link.ID,
+			&link.BoardID,
+			&link.CardID,
+			&link.Provider,
+			&link.Kind,
+			&link.Owner,
+			&link.Repo,
+			&link.Project,
+			&link.Number,
+			&link.SHA,
+			&link.URL,
+			&link.Title,
+			&link.State,
+			&labelsJSON,
+			&assigneesJSON,
+			&link.Merged,
+			&link.SyncedAt,
+			&link.SyncError,
+			&link.SyncAttemptAt,
+			&link.CreatedBy,
+			&link.CreateAt,
+			&link.UpdateAt,
+		)
+		if err != nil {
+			s.logger.Error("cardGitHubLinksFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(labelsJSON), &link.Labels); err != nil {
+			return nil, fmt.Errorf("cannot parse labels of github link %s: %w", link.ID, err)
+		}
+		if err := json.Unmarshal([]byte(assigneesJSON), &link.Assignees); err != nil {
+			return nil, fmt.Errorf("cannot parse assignees of github link %s: %w", link.ID, err)
+		}
+		links = append(links, &link)
+	}
+	return links, rows.Err()
+}
+
+func (s *SQLStore) createCardGitHubLink(db sq.BaseRunner, link *model.CardGitHubLink) (*model.CardGitHubLink, error) {
+	if err := link.Populate(); err != nil {
+		return nil, err
+	}
+	if err := link.IsValid(); err != nil {
+		return nil, err
+	}
+
+	labelsJSON, err := json.Marshal(link.Labels)
+	if err != nil {
+		return nil, err
+	}
+	assigneesJSON, err := json.Marshal(link.Assignees)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_github_links").
+		Columns(
+			"id",
+			"board_id",
+			"card_id",
+			"provider",
+			"kind",
+			"owner",
+			"repo",
+			"project",
+			"number",
+			"sha",
+			"url",
+			"title",
+			"state",
+			"labels",
+			"assignees",
+			"merged",
+			"synced_at",
+			"sync_error",
+			"sync_attempt_at",
+			"created_by",
+			"create_at",
+			"update_at",
+		).
+		Values(
+			link.ID,
+			link.BoardID,
+			link.CardID,
+			link.Provider,
+			link.Kind,
+			link.Owner,
+			link.Repo,
+			link.Project,
+			link.Number,
+			link.SHA,
+			link.URL,
+			link.Title,
+			link.State,
+			string(labelsJSON),
+			string(assigneesJSON),
+			link.Merged,
+			link.SyncedAt,
+			link.SyncError,
+			link.SyncAttemptAt,
+			link.CreatedBy,
+			link.CreateAt,
+			link.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("createCardGitHubLink error", mlog.String("card_id", link.CardID), mlog.Err(err))
+		return nil, err
+	}
+	return link, nil
+}
+
+func (s *SQLStore) getCardGitHubLink(db sq.BaseRunner, linkID string) (*model.CardGitHubLink, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardGitHubLinkFields("")...).
+		From(s.tablePrefix + "card_github_links").
+		Where(sq.Eq{"id": linkID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCardGitHubLink error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	links, err := s.cardGitHubLinksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(links) == 0 {
+		return nil, model.NewErrNotFound("github link ID=" + linkID)
+	}
+	return links[0], nil
+}
+
+// getCardGitHubLinks returns the links of a card, oldest first.
+func (s *SQLStore) getCardGitHubLinks(db sq.BaseRunner, cardID string) ([]*model.CardGitHubLink, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardGitHubLinkFields("")...).
+		From(s.tablePrefix+"card_github_links").
+		Where(sq.Eq{"card_id": cardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCardGitHubLinks error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardGitHubLinksFromRows(rows)
+}
+
+// getCardGitHubLinksToSync returns the issue and pull request links whose
+// last synchronization attempt is older than a time, least recently
+// attempted first. Commits do not change, so their links are not returned.
+func (s *SQLStore) getCardGitHubLinksToSync(db sq.BaseRunner, attemptedBefore int64, limit int) ([]*model.CardGitHubLink, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardGitHubLinkFields("")...).
+		From(s.tablePrefix+"card_github_links").
+		Where(sq.Lt{"sync_attempt_at": attemptedBefore}).
+		Where(sq.NotEq{"kind": model.CardGitHubLinkKindCommit}).
+		OrderBy("sync_attempt_at", "id").
+		Limit(uint64(limit))
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCardGitHubLinksToSync error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardGitHubLinksFromRows(rows)
+}
+
+// updateCardGitHubLinkSync stores the state of the linked item and the
+// outcome of its last synchronization.
+func (s *SQLStore) updateCardGitHubLinkSync(db sq.BaseRunner, link *model.CardGitHubLink) error {
+	labelsJSON, err := json.Marshal(link.Labels)
+	if err != nil {
+		return err
+	}
+	assigneesJSON, err := json.Marshal(link.Assignees)
+	if err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"card_github_links").
+		Set("url", link.URL).
+		Set("title", link.Title).
+		Set("state", link.State).
+		Set("labels", string(labelsJSON)).
+		Set("assignees", string(assigneesJSON)).
+		Set("merged", link.Merged).
+		Set("synced_at", link.SyncedAt).
+		Set("sync_error", link.SyncError).
+		Set("sync_attempt_at", link.SyncAttemptAt).
+		Set("update_at", link.UpdateAt).
+		Where(sq.Eq{"id": link.ID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("updateCardGitHubLinkSync error", mlog.String("link_id", link.ID), mlog.Err(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return model.NewErrNotFound("github link ID=" + link.ID)
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteCardGitHubLink(db sq.BaseRunner, linkID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_github_links").
+		Where(sq.Eq{"id": linkID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("deleteCardGitHubLink error", mlog.Err(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return model.NewErrNotFound("github link ID=" + linkID)
+	}
+	return nil
+}
+
+func (s *SQLStore) moveCardGitHubLinksToBoard(db sq.BaseRunner, cardID string, boardID string) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"card_github_links").
+		Set("board_id", boardID).
+		Where(sq.Eq{"card_id": cardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("moveCardGitHubLinksToBoard error", mlog.String("card_id", cardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
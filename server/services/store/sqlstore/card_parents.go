@@ -0,0 +1,130 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var cardParentFields = []string{
+	"card_id",
+	"parent_card_id",
+	"created_by",
+	"create_at",
+}
+
+func (s *SQLStore) cardParentFromRow(row sq.RowScanner) (*model.CardParent, error) {
+	var parent model.CardParent
+	err := row.Scan(
+		&parent.CardID,
+		&parent.ParentCardID,
+		&parent.CreatedBy,
+		&parent.CreateAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &parent, nil
+}
+
+// setCardParent sets the parent of a card, replacing the previous one if any.
+func (s *SQLStore) setCardParent(db sq.BaseRunner, parent *model.CardParent) (*model.CardParent, error) {
+	parent.Populate()
+	if err := parent.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_parents").
+		Columns(cardParentFields...).
+		Values(parent.CardID, parent.ParentCardID, parent.CreatedBy, parent.CreateAt)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix(
+			"ON DUPLICATE KEY UPDATE parent_card_id = ?, created_by = ?, create_at = ?",
+			parent.ParentCardID, parent.CreatedBy, parent.CreateAt)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (card_id)
+			 DO UPDATE SET parent_card_id = EXCLUDED.parent_card_id, created_by = EXCLUDED.created_by, create_at = EXCLUDED.create_at`,
+		)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("setCardParent error", mlog.String("card_id", parent.CardID), mlog.Err(err))
+		return nil, err
+	}
+	return parent, nil
+}
+
+func (s *SQLStore) getCardParent(db sq.BaseRunner, cardID string) (*model.CardParent, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardParentFields...).
+		From(s.tablePrefix + "card_parents").
+		Where(sq.Eq{"card_id": cardID})
+
+	parent, err := s.cardParentFromRow(query.QueryRow())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.NewErrNotFound("card parent CardID=" + cardID)
+		}
+		s.logger.Error("getCardParent error", mlog.Err(err))
+		return nil, err
+	}
+	return parent, nil
+}
+
+// getCardChildren returns the links of the children of a card.
+func (s *SQLStore) getCardChildren(db sq.BaseRunner, parentCardID string) ([]*model.CardParent, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardParentFields...).
+		From(s.tablePrefix+"card_parents").
+		Where(sq.Eq{"parent_card_id": parentCardID}).
+		OrderBy("create_at", "card_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCardChildren error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	children := []*model.CardParent{}
+	for rows.Next() {
+		child, err := s.cardParentFromRow(rows)
+		if err != nil {
+			s.logger.Error("getCardChildren scan error", mlog.Err(err))
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	return children, rows.Err()
+}
+
+func (s *SQLStore) deleteCardParent(db sq.BaseRunner, cardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_parents").
+		Where(sq.Eq{"card_id": cardID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("deleteCardParent error", mlog.Err(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return model.NewErrNotFound("card parent CardID=" + cardID)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+var cardRecurrenceFields = []string{
+	"card_id",
+	"board_id",
+	"frequency",
+	"interval_count",
+	"COALESCE(weekdays, '')",
+	"month_day",
+	"COALESCE(date_property_id, '')",
+	"COALESCE(time_zone, '')",
+	"start_at",
+	"next_at",
+	"created_by",
+	"modified_by",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) cardRecurrenceFromRow(row sq.RowScanner) (*model.CardRecurrence, error) {
+	var recurrence model.CardRecurrence
+	var weekdays string
+
+	err := row.Scan(
+		&recurrence.CardID,
+		&recurrence.BoardID,
+		&recurrence.Frequency,
+		&recurrence.Interval,
+		&weekdays,
+		&recurrence.MonthDay,
+		&recurrence.DatePropertyID,
+		&recurrence.TimeZone,
+		&recurrence.StartAt,
+		&recurrence.NextAt,
+		&recurrence.CreatedBy,
+		&recurrence.ModifiedBy,
+		&recurrence.CreateAt,
+		&recurrence.UpdateAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if weekdays != "" {
+		recurrence.Weekdays = strings.Split(weekdays, ",")
+	}
+	return &recurrence, nil
+}
+
+// upsertCardRecurrence sets the recurrence of a card, replacing the previous
+// one if any.
+func (s *SQLStore) upsertCardRecurrence(db sq.BaseRunner, recurrence *model.CardRecurrence) (*model.CardRecurrence, error) {
+	if err := recurrence.IsValid(); err != nil {
+		return nil, err
+	}
+
+	now := utils.GetMillis()
+	weekdays := strings.Join(recurrence.Weekdays, ",")
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_recurrences").
+		Columns(
+			"card_id",
+			"board_id",
+			"frequency",
+			"interval_count",
+			"weekdays",
+			"month_day",
+			"date_property_id",
+			"time_zone",
+			"start_at",
+			"next_at",
+			"created_by",
+			"modified_by",
+			"create_at",
+			"update_at",
+		).
+		Values(
+			recurrence.CardID,
+			recurrence.BoardID,
+			recurrence.Frequency,
+			recurrence.Interval,
+			weekdays,
+			recurrence.MonthDay,
+			recurrence.DatePropertyID,
+			recurrence.TimeZone,
+			recurrence.StartAt,
+			recurrence.NextAt,
+			recurrence.ModifiedBy,
+			recurrence.ModifiedBy,
+			now,
+			now,
+		)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix(
+			`ON DUPLICATE KEY UPDATE frequency = ?, interval_count = ?, weekdays = ?, month_day = ?,
+			 date_property_id = ?, time_zone = ?, start_at = ?, next_at = ?, modified_by = ?, update_at = ?`,
+			recurrence.Frequency, recurrence.Interval, weekdays, recurrence.MonthDay,
+			recurrence.DatePropertyID, recurrence.TimeZone, recurrence.StartAt, recurrence.NextAt, recurrence.ModifiedBy, now)
+	} else {
+		query = query.Suffix(
+			`ON CONFLICT (card_id)
+			 DO UPDATE SET frequency = EXCLUDED.frequency, interval_count = EXCLUDED.interval_count, weekdays = EXCLUDED.weekdays,
+			   month_day = EXCLUDED.month_day, date_property_id = EXCLUDED.date_property_id, time_zone = EXCLUDED.time_zone,
+			   start_at = EXCLUDED.start_at, next_at = EXCLUDED.next_at, modified_by = EXCLUDED.modified_by, update_at = EXCLUDED.update_at`,
+		)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("upsertCardRecurrence error", mlog.String("card_id", recurrence.CardID), mlog.Err(err))
+		return nil, err
+	}
+
+	return s.getCardRecurrence(db, recurrence.CardID)
+}
+
+func (s *SQLStore) getCardRecurrence(db sq.BaseRunner, cardID string) (*model.CardRecurrence, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardRecurrenceFields...).
+		From(s.tablePrefix + "card_recurrences").
+		Where(sq.Eq{"card_id": cardID})
+
+	recurrence, err := s.cardRecurrenceFromRow(query.QueryRow())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.NewErrNotFound("card recurrence CardID=" + cardID)
+		}
+		s.logger.Error("getCardRecurrence error", mlog.Err(err))
+		return nil, err
+	}
+
+	return recurrence, nil
+}
+
+// getDueCardRecurrences returns the recurrences with an occurrence due at or
+// before a time.
+func (s *SQLStore) getDueCardRecurrences(db sq.BaseRunner, until int64) ([]*model.CardRecurrence, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardRecurrenceFields...).
+		From(s.tablePrefix+"card_recurrences").
+		Where(sq.Gt{"next_at": 0}).
+		Where(sq.LtOrEq{"next_at": until}).
+		OrderBy("next_at", "card_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getDueCardRecurrences error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	recurrences := []*model.CardRecurrence{}
+	for rows.Next() {
+		recurrence, err := s.cardRecurrenceFromRow(rows)
+		if err != nil {
+			s.logger.Error("getDueCardRecurrences scan error", mlog.Err(err))
+			return nil, err
+		}
+		recurrences = append(recurrences, recurrence)
+	}
+	return recurrences, rows.Err()
+}
+
+// updateCardRecurrenceNextAt moves the next occurrence of a recurrence, if it
+// is still the expected one. It returns false if another server moved it
+// first.
+func (s *SQLStore) updateCardRecurrenceNextAt(db sq.BaseRunner, cardID string, from int64, to int64) (bool, error) {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"card_recurrences").
+		Set("next_at", to).
+		Where(sq.Eq{"card_id": cardID}).
+		Where(sq.Eq{"next_at": from})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("updateCardRecurrenceNextAt error", mlog.Err(err))
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+func (s *SQLStore) deleteCardRecurrence(db sq.BaseRunner, cardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_recurrences").
+		Where(sq.Eq{"card_id": cardID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("deleteCardRecurrence error", mlog.Err(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return model.NewErrNotFound("card recurrence CardID=" + cardID)
+	}
+	return nil
+}
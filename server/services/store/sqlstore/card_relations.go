@@ -207,3 +207,28 @@ func (s *SQLStore) deleteCardRelation(db sq.BaseRunner, relationID string) error
 
 	return nil
 }
+
+// getCardRelationsForCards returns the relations where any of the cards is
+// the source or the target, without the related cards.
+func (s *SQLStore) getCardRelationsForCards(db sq.BaseRunner, cardIDs []string) ([]*model.CardRelation, error) {
+	if len(cardIDs) == 0 {
+		return []*model.CardRelation{}, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(s.cardRelationFields("")...).
+		From(s.tablePrefix + "card_relations").
+		Where(sq.Or{
+			sq.Eq{"source_card_id": cardIDs},
+			sq.Eq{"target_card_id": cardIDs},
+		})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getCardRelationsForCards error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardRelationsFromRows(rows)
+}
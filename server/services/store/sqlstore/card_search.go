@@ -0,0 +1,141 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (s *SQLStore) searchCards(db sq.BaseRunner, search *model.CardSearch, page, perPage int) ([]*model.Block, error) {
+	if len(search.Boards) == 0 {
+		return []*model.Block{}, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields("b.")...).
+		From(s.tablePrefix + "blocks as b").
+		Where(sq.Eq{"b.type": model.TypeCard}).
+		Where(sq.Eq{"b.delete_at": 0}).
+		Where(s.notTemplateCardCondition()).
+		Where(s.cardSearchBoardsCondition(search.Boards))
+
+	for _, text := range search.Text {
+		query = query.Where(sq.Like{"lower(b.title)": "%" + strings.ToLower(text) + "%"})
+	}
+
+	if search.CreatedAt.From != 0 {
+		query = query.Where(sq.GtOrEq{"b.create_at": search.CreatedAt.From})
+	}
+	if search.CreatedAt.To != 0 {
+		query = query.Where(sq.Lt{"b.create_at": search.CreatedAt.To})
+	}
+	if search.UpdatedAt.From != 0 {
+		query = query.Where(sq.GtOrEq{"b.update_at": search.UpdatedAt.From})
+	}
+	if search.UpdatedAt.To != 0 {
+		query = query.Where(sq.Lt{"b.update_at": search.UpdatedAt.To})
+	}
+
+	query = query.OrderBy("b.update_at DESC", "b.id")
+
+	if page != 0 {
+		query = query.Offset(offset(page, perPage))
+	}
+
+	if perPage > 0 {
+		query = query.Limit(limit(perPage))
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`searchCards ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
+// cardSearchBoardsCondition restricts the search to the given boards, each
+// one with its own property conditions as property IDs differ between boards.
+func (s *SQLStore) cardSearchBoardsCondition(boards []model.CardSearchBoard) sq.Sqlizer {
+	unconditionedBoardIDs := []string{}
+	conditions := sq.Or{}
+
+	for _, board := range boards {
+		if len(board.Properties) == 0 {
+			unconditionedBoardIDs = append(unconditionedBoardIDs, board.BoardID)
+			continue
+		}
+
+		boardCondition := sq.And{sq.Eq{"b.board_id": board.BoardID}}
+		for _, property := range board.Properties {
+			boardCondition = append(boardCondition, s.cardPropertyCondition(property))
+		}
+		conditions = append(conditions, boardCondition)
+	}
+
+	if len(unconditionedBoardIDs) > 0 {
+		conditions = append(conditions, sq.Eq{"b.board_id": unconditionedBoardIDs})
+	}
+
+	return conditions
+}
+
+func (s *SQLStore) cardPropertyCondition(property model.CardSearchProperty) sq.Sqlizer {
+	expr, arg := s.cardPropertyValueExpr(property.PropertyID)
+
+	if property.Match == model.CardSearchMatchNotEquals {
+		conditions := sq.And{}
+		for _, value := range property.Values {
+			conditions = append(conditions, sq.Expr("COALESCE("+expr+", '') <> ?", arg, value))
+		}
+		return conditions
+	}
+
+	conditions := sq.Or{}
+	for _, value := range property.Values {
+		switch property.Match {
+		case model.CardSearchMatchContains:
+			conditions = append(conditions, sq.Expr("LOWER("+expr+") LIKE ?", arg, "%"+strings.ToLower(value)+"%"))
+		case model.CardSearchMatchArrayContains:
+			// array values are stored as JSON arrays of IDs, so the quoted
+			// ID can only match a whole element
+			conditions = append(conditions, sq.Expr(expr+" LIKE ?", arg, `%"`+value+`"%`))
+		default:
+			conditions = append(conditions, sq.Expr(expr+" = ?", arg, value))
+		}
+	}
+	return conditions
+}
+
+// cardPropertyValueExpr returns the SQL expression that extracts the text
+// value of a card property and the argument it needs.
+func (s *SQLStore) cardPropertyValueExpr(propertyID string) (string, string) {
+	switch s.dbType {
+	case model.PostgresDBType:
+		return "b.fields->'properties'->>?", propertyID
+	case model.MysqlDBType:
+		return "JSON_UNQUOTE(JSON_EXTRACT(b.fields, ?))", `$.properties."` + propertyID + `"`
+	default:
+		return "json_extract(b.fields, ?)", `$.properties."` + propertyID + `"`
+	}
+}
+
+func (s *SQLStore) notTemplateCardCondition() sq.Sqlizer {
+	switch s.dbType {
+	case model.PostgresDBType:
+		return sq.Expr("COALESCE(b.fields->>'isTemplate', 'false') <> 'true'")
+	case model.MysqlDBType:
+		return sq.Expr("COALESCE(JSON_UNQUOTE(JSON_EXTRACT(b.fields, '$.isTemplate')), 'false') <> 'true'")
+	default:
+		return sq.Expr("COALESCE(json_extract(b.fields, '$.isTemplate'), 0) = 0")
+	}
+}
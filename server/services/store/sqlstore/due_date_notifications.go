@@ -0,0 +1,55 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// claimDueDateNotification records a due date notification, and returns false
+// if it was already recorded, by this or by another server.
+func (s *SQLStore) claimDueDateNotification(db sq.BaseRunner, notification *model.DueDateNotification) (bool, error) {
+	if notification.CreateAt == 0 {
+		notification.CreateAt = utils.GetMillis()
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"due_date_notifications").
+		Columns("kind", "user_id", "card_id", "notify_key", "create_at").
+		Values(notification.Kind, notification.UserID, notification.CardID, notification.Key, notification.CreateAt)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE kind = kind")
+	} else {
+		query = query.Suffix("ON CONFLICT (kind, user_id, card_id, notify_key) DO NOTHING")
+	}
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("claimDueDateNotification error", mlog.Err(err))
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+func (s *SQLStore) deleteDueDateNotificationsBefore(db sq.BaseRunner, createAt int64) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "due_date_notifications").
+		Where(sq.Lt{"create_at": createAt})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("deleteDueDateNotificationsBefore error", mlog.Err(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func githubIssueSyncFields() []string {
+	return []string{
+		"board_id",
+		"owner",
+		"repo",
+		"enabled",
+		"COALESCE(open_status_option_id, '')",
+		"COALESCE(closed_status_option_id, '')",
+		"COALESCE(label_property_id, '')",
+		"COALESCE(label_mappings, '[]')",
+		"COALESCE(assignee_property_id, '')",
+		"COALESCE(user_mappings, '[]')",
+		"issues_synced_at",
+		"synced_at",
+		"COALESCE(sync_error, '')",
+		"sync_attempt_at",
+		"conflict_count",
+		"last_conflict_at",
+		"created_by",
+		"create_at",
+		"update_at",
+	}
+}
+
+func githubIssueSyncItemFields() []string {
+	return []string{
+		"card_id",
+		"board_id",
+		"number",
+		"COALESCE(description_block_id, '')",
+		"card_update_at",
+		"issue_update_at",
+		"synced_at",
+	}
+}
+
+func (s *SQLStore) githubIssueSyncsFromRows(rows *sql.Rows) ([]*model.GitHubIssueSync, error) {
+	syncs := []*model.GitHubIssueSync{}
+	for rows.Next() {
+		var sync model.GitHubIssueSync
+		var labelMappingsJSON, userMappingsJSON string
+		err := rows.Scan(
+			&sync.BoardID,
+			&sync.Owner,
+			&sync.Repo,
+			&sync.Enabled,
+			&sync.OpenStatusOptionID,
+			&sync.ClosedStatusOptionID,
+			&sync.LabelPropertyID,
+			&labelMappingsJSON,
+			&sync.AssigneePropertyID,
+			&userMappingsJSON,
+			&sync.IssuesSyncedAt,
+			&sync.SyncedAt,
+			&sync.SyncError,
+			&sync.SyncAttemptAt,
+			&sync.ConflictCount,
+			&sync.LastConflictAt,
+			&sync.CreatedBy,
+			&sync.CreateAt,
+			&sync.UpdateAt,
+		)
+		if err != nil {
+			s.logger.Error("githubIssueSyncsFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(labelMappingsJSON), &sync.LabelMappings); err != nil {
+			return nil, fmt.Errorf("cannot parse label mappings of github issue sync %s: %w", sync.BoardID, err)
+		}
+		if err := json.Unmarshal([]byte(userMappingsJSON), &sync.UserMappings); err != nil {
+			return nil, fmt.Errorf("cannot parse user mappings of github issue sync %s: %w", sync.BoardID, err)
+		}
+		syncs = append(syncs, &sync)
+	}
+	return syncs, rows.Err()
+}
+
+func (s *SQLStore) getGitHubIssueSync(db sq.BaseRunner, boardID string) (*model.GitHubIssueSync, error) {
+	query := s.getQueryBuilder(db).
+		Select(githubIssueSyncFields()...).
+		From(s.tablePrefix + "github_issue_syncs").
+		Where(sq.Eq{"board_id": boardID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getGitHubIssueSync error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	syncs, err := s.githubIssueSyncsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(syncs) == 0 {
+		return nil, model.NewErrNotFound("github issue sync of board ID=" + boardID)
+	}
+	return syncs[0], nil
+}
+
+// getGitHubIssueSyncsToRun returns the enabled synchronizations whose last
+// attempt is older than a time, least recently attempted first.
+func (s *SQLStore) getGitHubIssueSyncsToRun(db sq.BaseRunner, attemptedBefore int64, limit int) ([]*model.GitHubIssueSync, error) {
+	query := s.getQueryBuilder(db).
+		Select(githubIssueSyncFields()...).
+		From(s.tablePrefix+"github_issue_syncs").
+		Where(sq.Eq{"enabled": true}).
+		Where(sq.Lt{"sync_attempt_at": attemptedBefore}).
+		OrderBy("sync_attempt_at", "board_id").
+		Limit(uint64(limit))
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getGitHubIssueSyncsToRun error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.githubIssueSyncsFromRows(rows)
+}
+
+// saveGitHubIssueSync creates or updates the settings of the synchronization
+// of a board. Its synchronization state is kept on update.
+func (s *SQLStore) saveGitHubIssueSync(db sq.BaseRunner, sync *model.GitHubIssueSync) error {
+	sync.Populate()
+	if err := sync.IsValid(); err != nil {
+		return err
+	}
+
+	labelMappingsJSON, err := json.Marshal(sync.LabelMappings)
+	if err != nil {
+		return err
+	}
+	userMappingsJSON, err := json.Marshal(sync.UserMappings)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.getGitHubIssueSync(db, sync.BoardID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return err
+	}
+
+	if model.IsErrNotFound(err) {
+		query := s.getQueryBuilder(db).
+			Insert(s.tablePrefix+"github_issue_syncs").
+			Columns(
+				"board_id",
+				"owner",
+				"repo",
+				"enabled",
+				"open_status_option_id",
+				"closed_status_option_id",
+				"label_property_id",
+				"label_mappings",
+				"assignee_property_id",
+				"user_mappings",
+				"issues_synced_at",
+				"synced_at",
+				"sync_error",
+				"sync_attempt_at",
+				"conflict_count",
+				"last_conflict_at",
+				"created_by",
+				"create_at",
+				"update_at",
+			).
+			Values(
+				sync.BoardID,
+				sync.Owner,
+				sync.Repo,
+				sync.Enabled,
+				sync.OpenStatusOptionID,
+				sync.ClosedStatusOptionID,
+				sync.LabelPropertyID,
+				string(labelMappingsJSON),
+				sync.AssigneePropertyID,
+				string(userMappingsJSON),
+				sync.IssuesSyncedAt,
+				sync.SyncedAt,
+				sync.SyncError,
+				sync.SyncAttemptAt,
+				sync.ConflictCount,
+				sync.LastConflictAt,
+				sync.CreatedBy,
+				sync.CreateAt,
+				sync.UpdateAt,
+			)
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error("saveGitHubIssueSync insert error", mlog.String("board_id", sync.BoardID), mlog.Err(err))
+			return err
+		}
+		return nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"github_issue_syncs").
+		Set("owner", sync.Owner).
+		Set("repo", sync.Repo).
+		Set("enabled", sync.Enabled).
+		Set("open_status_option_id", sync.OpenStatusOptionID).
+		Set("closed_status_option_id", sync.ClosedStatusOptionID).
+		Set("label_property_id", sync.LabelPropertyID).
+		Set("label_mappings", string(labelMappingsJSON)).
+		Set("assignee_property_id", sync.AssigneePropertyID).
+		Set("user_mappings", string(userMappingsJSON)).
+		Set("created_by", sync.CreatedBy).
+		Set("update_at", sync.UpdateAt).
+		Where(sq.Eq{"board_id": sync.BoardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("saveGitHubIssueSync update error", mlog.String("board_id", sync.BoardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// updateGitHubIssueSyncState stores the outcome of a synchronization.
+func (s *SQLStore) updateGitHubIssueSyncState(db sq.BaseRunner, sync *model.GitHubIssueSync) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"github_issue_syncs").
+		Set("issues_synced_at", sync.IssuesSyncedAt).
+		Set("synced_at", sync.SyncedAt).
+		Set("sync_error", sync.SyncError).
+		Set("sync_attempt_at", sync.SyncAttemptAt).
+		Set("conflict_count", sync.ConflictCount).
+		Set("last_conflict_at", sync.LastConflictAt).
+		Where(sq.Eq{"board_id": sync.BoardID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("updateGitHubIssueSyncState error", mlog.String("board_id", sync.BoardID), mlog.Err(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return model.NewErrNotFound("github issue sync of board ID=" + sync.BoardID)
+	}
+	return nil
+}
+
+// deleteGitHubIssueSync removes the synchronization of a board along with
+// its items. The cards are kept.
+func (s *SQLStore) deleteGitHubIssueSync(db sq.BaseRunner, boardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "github_issue_syncs").
+		Where(sq.Eq{"board_id": boardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("deleteGitHubIssueSync error", mlog.Err(err))
+		return err
+	}
+
+	itemsQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "github_issue_sync_items").
+		Where(sq.Eq{"board_id": boardID})
+
+	if _, err := itemsQuery.Exec(); err != nil {
+		s.logger.Error("deleteGitHubIssueSync items error", mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) getGitHubIssueSyncItems(db sq.BaseRunner, boardID string) ([]*model.GitHubIssueSyncItem, error) {
+	query := s.getQueryBuilder(db).
+		Select(githubIssueSyncItemFields()...).
+		From(s.tablePrefix + "github_issue_sync_items").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("number")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getGitHubIssueSyncItems error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	items := []*model.GitHubIssueSyncItem{}
+	for rows.Next() {
+		var item model.GitHubIssueSyncItem
+		if err := rows.Scan(
+			&item.CardID,
+			&item.BoardID,
+			&item.Number,
+			&item.DescriptionBlockID,
+			&item.CardUpdateAt,
+			&item.IssueUpdateAt,
+			&item.SyncedAt,
+		); err != nil {
+			s.logger.Error("getGitHubIssueSyncItems scan error", mlog.Err(err))
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	return items, rows.Err()
+}
+
+// saveGitHubIssueSyncItem creates or updates the item of a card.
+func (s *SQLStore) saveGitHubIssueSyncItem(db sq.BaseRunner, item *model.GitHubIssueSyncItem) error {
+	if err := s.deleteGitHubIssueSyncItem(db, item.CardID); err != nil {
+		return err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"github_issue_sync_items").
+		Columns(
+			"card_id",
+			"board_id",
+			"number",
+			"description_block_id",
+			"card_update_at",
+			"issue_update_at",
+			"synced_at",
+		).
+		Values(
+			item.CardID,
+			item.BoardID,
+			item.Number,
+			item.DescriptionBlockID,
+			item.CardUpdateAt,
+			item.IssueUpdateAt,
+			item.SyncedAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("saveGitHubIssueSyncItem error", mlog.String("card_id", item.CardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteGitHubIssueSyncItem(db sq.BaseRunner, cardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "github_issue_sync_items").
+		Where(sq.Eq{"card_id": cardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("deleteGitHubIssueSyncItem error", mlog.Err(err))
+		return err
+	}
+	return nil
+}
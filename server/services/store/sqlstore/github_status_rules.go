@@ -0,0 +1,104 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func githubStatusRuleFields() []string {
+	return []string{
+		"id",
+		"board_id",
+		"event",
+		"status_option_id",
+		"create_at",
+		"update_at",
+	}
+}
+
+func (s *SQLStore) getGitHubStatusRules(db sq.BaseRunner, boardID string) ([]*model.GitHubStatusRule, error) {
+	query := s.getQueryBuilder(db).
+		Select(githubStatusRuleFields()...).
+		From(s.tablePrefix+"github_status_rules").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getGitHubStatusRules error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	rules := []*model.GitHubStatusRule{}
+	for rows.Next() {
+		var rule model.GitHubStatusRule
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.BoardID,
+			&rule.Event,
+			&rule.StatusOptionID,
+			&rule.CreateAt,
+			&rule.UpdateAt,
+		); err != nil {
+			s.logger.Error("getGitHubStatusRules scan error", mlog.Err(err))
+			return nil, err
+		}
+		rules = append(rules, &rule)
+	}
+	return rules, rows.Err()
+}
+
+func (s *SQLStore) replaceGitHubStatusRules(db sq.BaseRunner, boardID string, rules []*model.GitHubStatusRule) error {
+	now := utils.GetMillis()
+	for _, rule := range rules {
+		rule.Populate()
+		rule.UpdateAt = now
+		if err := rule.IsValid(); err != nil {
+			return err
+		}
+		if rule.BoardID != boardID {
+			return model.NewErrBadRequest("rule board ID does not match")
+		}
+	}
+
+	if err := s.deleteGitHubStatusRulesForBoard(db, boardID); err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		query := s.getQueryBuilder(db).
+			Insert(s.tablePrefix+"github_status_rules").
+			Columns(githubStatusRuleFields()...).
+			Values(
+				rule.ID,
+				rule.BoardID,
+				rule.Event,
+				rule.StatusOptionID,
+				rule.CreateAt,
+				rule.UpdateAt,
+			)
+		if _, err := query.Exec(); err != nil {
+			s.logger.Error("replaceGitHubStatusRules error", mlog.String("board_id", boardID), mlog.Err(err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteGitHubStatusRulesForBoard(db sq.BaseRunner, boardID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "github_status_rules").
+		Where(sq.Eq{"board_id": boardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("deleteGitHubStatusRulesForBoard error", mlog.Err(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func incomingWebhookFields(tableAlias string) []string {
+	if tableAlias != "" && tableAlias[len(tableAlias)-1] != '.' {
+		tableAlias += "."
+	}
+
+	return []string{
+		tableAlias + "id",
+		tableAlias + "board_id",
+		tableAlias + "token",
+		"COALESCE(" + tableAlias + "title, '')",
+		tableAlias + "field_mapping",
+		tableAlias + "enabled",
+		tableAlias + "created_by",
+		tableAlias + "create_at",
+		tableAlias + "update_at",
+	}
+}
+
+func (s *SQLStore) incomingWebhookFromRow(row sq.RowScanner) (*model.IncomingWebhook, error) {
+	var webhook model.IncomingWebhook
+	var mappingJSON string
+
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.BoardID,
+		&webhook.Token,
+		&webhook.Title,
+		&mappingJSON,
+		&webhook.Enabled,
+		&webhook.CreatedBy,
+		&webhook.CreateAt,
+		&webhook.UpdateAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(mappingJSON), &webhook.FieldMapping); err != nil {
+		return nil, fmt.Errorf("cannot parse field mapping of incoming webhook %s: %w", webhook.ID, err)
+	}
+
+	return &webhook, nil
+}
+
+func (s *SQLStore) createIncomingWebhook(db sq.BaseRunner, webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	webhook.Populate()
+
+	if err := webhook.IsValid(); err != nil {
+		return nil, err
+	}
+
+	mappingJSON, err := json.Marshal(webhook.FieldMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"incoming_webhooks").
+		Columns(
+			"id",
+			"board_id",
+			"token",
+			"title",
+			"field_mapping",
+			"enabled",
+			"created_by",
+			"create_at",
+			"update_at",
+		).
+		Values(
+			webhook.ID,
+			webhook.BoardID,
+			webhook.Token,
+			webhook.Title,
+			string(mappingJSON),
+			webhook.Enabled,
+			webhook.CreatedBy,
+			webhook.CreateAt,
+			webhook.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("createIncomingWebhook error", mlog.Err(err))
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *SQLStore) getIncomingWebhookWhere(db sq.BaseRunner, where sq.Eq, description string) (*model.IncomingWebhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(incomingWebhookFields("")...).
+		From(s.tablePrefix + "incoming_webhooks").
+		Where(where)
+
+	webhook, err := s.incomingWebhookFromRow(query.QueryRow())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, model.NewErrNotFound(description)
+		}
+		s.logger.Error("getIncomingWebhook error", mlog.Err(err))
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *SQLStore) getIncomingWebhook(db sq.BaseRunner, webhookID string) (*model.IncomingWebhook, error) {
+	return s.getIncomingWebhookWhere(db, sq.Eq{"id": webhookID}, "incoming webhook ID="+webhookID)
+}
+
+func (s *SQLStore) getIncomingWebhookByToken(db sq.BaseRunner, token string) (*model.IncomingWebhook, error) {
+	return s.getIncomingWebhookWhere(db, sq.Eq{"token": token}, "incoming webhook")
+}
+
+func (s *SQLStore) getIncomingWebhooks(db sq.BaseRunner, boardID string) ([]*model.IncomingWebhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(incomingWebhookFields("")...).
+		From(s.tablePrefix+"incoming_webhooks").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getIncomingWebhooks error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	webhooks := []*model.IncomingWebhook{}
+	for rows.Next() {
+		webhook, err := s.incomingWebhookFromRow(rows)
+		if err != nil {
+			s.logger.Error("getIncomingWebhooks scan error", mlog.Err(err))
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (s *SQLStore) updateIncomingWebhook(db sq.BaseRunner, webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	if err := webhook.IsValid(); err != nil {
+		return nil, err
+	}
+
+	mappingJSON, err := json.Marshal(webhook.FieldMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"incoming_webhooks").
+		Set("token", webhook.Token).
+		Set("title", webhook.Title).
+		Set("field_mapping", string(mappingJSON)).
+		Set("enabled", webhook.Enabled).
+		Set("update_at", webhook.UpdateAt).
+		Where(sq.Eq{"id": webhook.ID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("updateIncomingWebhook error", mlog.Err(err))
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, model.NewErrNotFound("incoming webhook ID=" + webhook.ID)
+	}
+
+	return webhook, nil
+}
+
+func (s *SQLStore) deleteIncomingWebhook(db sq.BaseRunner, webhookID string) error {
+	query := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "incoming_webhooks").
+		Where(sq.Eq{"id": webhookID})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("deleteIncomingWebhook error", mlog.Err(err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return model.NewErrNotFound("incoming webhook ID=" + webhookID)
+	}
+
+	return nil
+}
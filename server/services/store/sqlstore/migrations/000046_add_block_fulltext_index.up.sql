@@ -0,0 +1,30 @@
+-- Full-text index over the titles of card, text, comment and checkbox blocks.
+-- The index is defined on the blocks table itself, so the database keeps it
+-- current on every insert, patch and delete of a block.
+
+{{if .postgres}}
+CREATE INDEX IF NOT EXISTS idx_blocks_title_fulltext ON {{.prefix}}blocks
+    USING GIN (to_tsvector('simple', COALESCE(title, '')))
+    WHERE type IN ('card', 'text', 'comment', 'checkbox');
+{{end}}
+
+{{if .mysql}}
+SET @stmt = (SELECT IF(
+    (
+      SELECT COUNT(index_name) FROM INFORMATION_SCHEMA.STATISTICS
+      WHERE table_name = '{{.prefix}}blocks'
+      AND table_schema = DATABASE()
+      AND index_name = 'idx_blocks_title_fulltext'
+    ) > 0,
+    'SELECT 1;',
+    'CREATE FULLTEXT INDEX idx_blocks_title_fulltext ON {{.prefix}}blocks (title);'
+));
+PREPARE createFulltextIndexIfNeeded FROM @stmt;
+EXECUTE createFulltextIndexIfNeeded;
+DEALLOCATE PREPARE createFulltextIndexIfNeeded;
+{{end}}
+
+{{if .sqlite}}
+-- SQLite has no full-text index here, searches fall back to LIKE matching.
+SELECT 1;
+{{end}}
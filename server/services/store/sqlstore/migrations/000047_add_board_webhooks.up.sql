@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}board_webhooks (
+    id VARCHAR(36) PRIMARY KEY,
+    board_id VARCHAR(36) NOT NULL,
+    url TEXT NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    -- JSON array of the subscribed events, empty for all events
+    events TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}webhook_deliveries (
+    id VARCHAR(36) PRIMARY KEY,
+    webhook_id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    event VARCHAR(50) NOT NULL,
+    payload {{if .mysql}}MEDIUMTEXT{{else}}TEXT{{end}} NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at BIGINT NOT NULL,
+    last_attempt_at BIGINT NOT NULL DEFAULT 0,
+    response_code INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    create_at BIGINT
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "board_webhooks" "board_id" }}
+{{ createIndexIfNeeded "webhook_deliveries" "webhook_id, create_at" }}
+{{ createIndexIfNeeded "webhook_deliveries" "status, next_attempt_at" }}
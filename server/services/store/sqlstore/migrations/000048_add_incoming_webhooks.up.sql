@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}incoming_webhooks (
+    id VARCHAR(36) PRIMARY KEY,
+    board_id VARCHAR(36) NOT NULL,
+    token VARCHAR(100) NOT NULL,
+    title VARCHAR(255),
+    -- JSON object mapping payload fields to card title, code or properties
+    field_mapping TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "incoming_webhooks" "board_id" }}
+{{ createIndexIfNeeded "incoming_webhooks" "token" }}
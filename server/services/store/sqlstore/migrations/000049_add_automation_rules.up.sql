@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}automation_rules (
+    id VARCHAR(36) PRIMARY KEY,
+    board_id VARCHAR(36) NOT NULL,
+    title VARCHAR(255),
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    trigger_type VARCHAR(50) NOT NULL,
+    -- JSON encoded trigger and actions of the rule
+    trigger_config TEXT NOT NULL,
+    actions_config TEXT NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    modified_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT,
+    due_checked_at BIGINT NOT NULL DEFAULT 0
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "automation_rules" "board_id" }}
+{{ createIndexIfNeeded "automation_rules" "trigger_type" }}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}due_date_notifications (
+    kind VARCHAR(50) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    card_id VARCHAR(36) NOT NULL,
+    notify_key VARCHAR(100) NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (kind, user_id, card_id, notify_key)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "due_date_notifications" "create_at" }}
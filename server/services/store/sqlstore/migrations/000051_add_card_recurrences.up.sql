@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_recurrences (
+    card_id VARCHAR(36) PRIMARY KEY,
+    board_id VARCHAR(36) NOT NULL,
+    frequency VARCHAR(20) NOT NULL,
+    interval_count INTEGER NOT NULL DEFAULT 1,
+    -- comma separated RRULE weekday codes of a weekly recurrence
+    weekdays VARCHAR(50),
+    month_day INTEGER NOT NULL DEFAULT 0,
+    date_property_id VARCHAR(50),
+    time_zone VARCHAR(100),
+    start_at BIGINT NOT NULL DEFAULT 0,
+    next_at BIGINT NOT NULL DEFAULT 0,
+    created_by VARCHAR(36) NOT NULL,
+    modified_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "card_recurrences" "board_id" }}
+{{ createIndexIfNeeded "card_recurrences" "next_at" }}
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}activity_digest_changes (
+    card_id VARCHAR(36) PRIMARY KEY,
+    board_id VARCHAR(36) NOT NULL,
+    update_at BIGINT
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}activity_digests (
+    user_id VARCHAR(36) PRIMARY KEY,
+    sent_at BIGINT NOT NULL DEFAULT 0
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "activity_digest_changes" "update_at" }}
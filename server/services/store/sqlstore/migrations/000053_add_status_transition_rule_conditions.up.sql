@@ -0,0 +1,4 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "status_transition_rules" "required_properties" "TEXT" "" }}
+{{ addColumnIfNeeded "status_transition_rules" "allowed_roles" "VARCHAR(100)" "" }}
+{{ addColumnIfNeeded "status_transition_rules" "require_comment" "BOOLEAN" "NOT NULL DEFAULT false" }}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_code_aliases (
+    board_id VARCHAR(36) NOT NULL,
+    number BIGINT NOT NULL,
+    card_id VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (board_id, number)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "card_code_aliases" "card_id" }}
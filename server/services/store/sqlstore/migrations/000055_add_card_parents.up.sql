@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_parents (
+    card_id VARCHAR(36) NOT NULL,
+    parent_card_id VARCHAR(36) NOT NULL,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (card_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "card_parents" "parent_card_id" }}
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}worklogs (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    card_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    minutes BIGINT NOT NULL,
+    work_date BIGINT NOT NULL,
+    note TEXT,
+    create_at BIGINT,
+    update_at BIGINT,
+    delete_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "worklogs" "card_id" }}
+{{ createIndexIfNeeded "worklogs" "board_id, work_date" }}
+{{ createIndexIfNeeded "worklogs" "update_at" }}
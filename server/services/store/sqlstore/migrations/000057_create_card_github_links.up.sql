@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_github_links (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    card_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    owner VARCHAR(100) NOT NULL,
+    repo VARCHAR(100) NOT NULL,
+    number INT NOT NULL,
+    url TEXT,
+    title TEXT,
+    state VARCHAR(20),
+    -- JSON arrays of the label names and assignee logins
+    labels TEXT,
+    assignees TEXT,
+    merged BOOLEAN NOT NULL DEFAULT false,
+    synced_at BIGINT NOT NULL DEFAULT 0,
+    sync_error TEXT,
+    sync_attempt_at BIGINT NOT NULL DEFAULT 0,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "card_github_links" "card_id" }}
+{{ createIndexIfNeeded "card_github_links" "sync_attempt_at" }}
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}github_status_rules (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    event VARCHAR(50) NOT NULL,
+    status_option_id VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "github_status_rules" "board_id" }}
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}board_github_repos (
+    id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    owner VARCHAR(100) NOT NULL,
+    repo VARCHAR(100) NOT NULL,
+    scanned_at BIGINT NOT NULL DEFAULT 0,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "board_github_repos" "board_id" }}
+{{ createIndexIfNeeded "board_github_repos" "scanned_at" }}
+
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "card_github_links" "sha" "VARCHAR(40)" "" }}
@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}github_issue_syncs (
+    board_id VARCHAR(36) NOT NULL,
+    owner VARCHAR(100) NOT NULL,
+    repo VARCHAR(100) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    open_status_option_id VARCHAR(36),
+    closed_status_option_id VARCHAR(36),
+    label_property_id VARCHAR(36),
+    label_mappings TEXT,
+    assignee_property_id VARCHAR(36),
+    user_mappings TEXT,
+    issues_synced_at BIGINT NOT NULL DEFAULT 0,
+    synced_at BIGINT NOT NULL DEFAULT 0,
+    sync_error TEXT,
+    sync_attempt_at BIGINT NOT NULL DEFAULT 0,
+    conflict_count BIGINT NOT NULL DEFAULT 0,
+    last_conflict_at BIGINT NOT NULL DEFAULT 0,
+    created_by VARCHAR(36) NOT NULL,
+    create_at BIGINT,
+    update_at BIGINT,
+    PRIMARY KEY (board_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE TABLE IF NOT EXISTS {{.prefix}}github_issue_sync_items (
+    card_id VARCHAR(36) NOT NULL,
+    board_id VARCHAR(36) NOT NULL,
+    number BIGINT NOT NULL,
+    description_block_id VARCHAR(36),
+    card_update_at BIGINT NOT NULL DEFAULT 0,
+    issue_update_at BIGINT NOT NULL DEFAULT 0,
+    synced_at BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (card_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+{{- /* createIndexIfNeeded tableName columns */ -}}
+{{ createIndexIfNeeded "github_issue_syncs" "sync_attempt_at" }}
+{{ createIndexIfNeeded "github_issue_sync_items" "board_id" }}
@@ -0,0 +1,3 @@
+{{- /* addColumnIfNeeded tableName columnName datatype constraint */ -}}
+{{ addColumnIfNeeded "card_github_links" "provider" "VARCHAR(16)" "" }}
+{{ addColumnIfNeeded "card_github_links" "project" "VARCHAR(255)" "" }}
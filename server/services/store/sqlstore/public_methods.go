@@ -51,6 +51,41 @@ func (s *SQLStore) CanSeeUser(seerID string, seenID string) (bool, error) {
 
 }
 
+func (s *SQLStore) ClaimActivityDigest(userID string, lastSentAt int64, sentAt int64) (bool, error) {
+	return s.claimActivityDigest(s.db, userID, lastSentAt, sentAt)
+
+}
+
+func (s *SQLStore) ClaimAutomationRuleDueCheck(rule *model.AutomationRule, until int64) (bool, error) {
+	return s.claimAutomationRuleDueCheck(s.db, rule, until)
+
+}
+
+func (s *SQLStore) ClaimDueDateNotification(notification *model.DueDateNotification) (bool, error) {
+	return s.claimDueDateNotification(s.db, notification)
+
+}
+
+func (s *SQLStore) ClaimWebhookDelivery(delivery *model.WebhookDelivery, claimUntil int64) (bool, error) {
+	return s.claimWebhookDelivery(s.db, delivery, claimUntil)
+
+}
+
+func (s *SQLStore) CreateAutomationRule(rule *model.AutomationRule) (*model.AutomationRule, error) {
+	return s.createAutomationRule(s.db, rule)
+
+}
+
+func (s *SQLStore) CreateBoardGitHubRepo(repo *model.BoardGitHubRepo) (*model.BoardGitHubRepo, error) {
+	return s.createBoardGitHubRepo(s.db, repo)
+
+}
+
+func (s *SQLStore) CreateBoardWebhook(webhook *model.BoardWebhook) (*model.BoardWebhook, error) {
+	return s.createBoardWebhook(s.db, webhook)
+
+}
+
 func (s *SQLStore) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.createBoardsAndBlocks(s.db, bab, userID)
@@ -99,6 +134,11 @@ func (s *SQLStore) CreateBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, us
 
 }
 
+func (s *SQLStore) CreateCardGitHubLink(link *model.CardGitHubLink) (*model.CardGitHubLink, error) {
+	return s.createCardGitHubLink(s.db, link)
+
+}
+
 func (s *SQLStore) CreateCardRelation(relation *model.CardRelation) (*model.CardRelation, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.createCardRelation(s.db, relation)
@@ -147,11 +187,31 @@ func (s *SQLStore) CreateCategory(category model.Category) error {
 
 }
 
+func (s *SQLStore) CreateIncomingWebhook(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	return s.createIncomingWebhook(s.db, webhook)
+
+}
+
 func (s *SQLStore) CreateSubscription(sub *model.Subscription) (*model.Subscription, error) {
 	return s.createSubscription(s.db, sub)
 
 }
 
+func (s *SQLStore) CreateWorklog(worklog *model.Worklog) (*model.Worklog, error) {
+	return s.createWorklog(s.db, worklog)
+
+}
+
+func (s *SQLStore) DeleteActivityDigestChangesBefore(updateAt int64) error {
+	return s.deleteActivityDigestChangesBefore(s.db, updateAt)
+
+}
+
+func (s *SQLStore) DeleteAutomationRule(ruleID string) error {
+	return s.deleteAutomationRule(s.db, ruleID)
+
+}
+
 func (s *SQLStore) DeleteBlock(blockID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBlock(s.db, blockID, modifiedBy)
@@ -205,11 +265,40 @@ func (s *SQLStore) DeleteBoard(boardID string, userID string) error {
 
 }
 
+func (s *SQLStore) DeleteBoardGitHubRepo(repoID string) error {
+	return s.deleteBoardGitHubRepo(s.db, repoID)
+
+}
+
 func (s *SQLStore) DeleteBoardRecord(boardID string, modifiedBy string) error {
 	return s.deleteBoardRecord(s.db, boardID, modifiedBy)
 
 }
 
+func (s *SQLStore) DeleteBoardWebhook(webhookID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteBoardWebhook(s.db, webhookID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteBoardWebhook(tx, webhookID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBoardWebhook"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBoardsAndBlocks(s.db, dbab, userID)
@@ -234,6 +323,21 @@ func (s *SQLStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, user
 
 }
 
+func (s *SQLStore) DeleteCardGitHubLink(linkID string) error {
+	return s.deleteCardGitHubLink(s.db, linkID)
+
+}
+
+func (s *SQLStore) DeleteCardParent(cardID string) error {
+	return s.deleteCardParent(s.db, cardID)
+
+}
+
+func (s *SQLStore) DeleteCardRecurrence(cardID string) error {
+	return s.deleteCardRecurrence(s.db, cardID)
+
+}
+
 func (s *SQLStore) DeleteCardRelation(relationID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteCardRelation(s.db, relationID)
@@ -263,6 +367,45 @@ func (s *SQLStore) DeleteCategory(categoryID string, userID string, teamID strin
 
 }
 
+func (s *SQLStore) DeleteDueDateNotificationsBefore(createAt int64) error {
+	return s.deleteDueDateNotificationsBefore(s.db, createAt)
+
+}
+
+func (s *SQLStore) DeleteGitHubIssueSync(boardID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteGitHubIssueSync(s.db, boardID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.deleteGitHubIssueSync(tx, boardID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteGitHubIssueSync"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) DeleteGitHubIssueSyncItem(cardID string) error {
+	return s.deleteGitHubIssueSyncItem(s.db, cardID)
+
+}
+
+func (s *SQLStore) DeleteIncomingWebhook(webhookID string) error {
+	return s.deleteIncomingWebhook(s.db, webhookID)
+
+}
+
 func (s *SQLStore) DeleteMember(boardID string, userID string) error {
 	return s.deleteMember(s.db, boardID, userID)
 
@@ -302,6 +445,11 @@ func (s *SQLStore) DeleteSubscription(blockID string, subscriberID string) error
 
 }
 
+func (s *SQLStore) DeleteWorklog(worklogID string) error {
+	return s.deleteWorklog(s.db, worklogID)
+
+}
+
 func (s *SQLStore) DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.duplicateBlock(s.db, boardID, blockID, userID, asTemplate)
@@ -355,11 +503,31 @@ func (s *SQLStore) GetActiveUserCount(updatedSecondsAgo int64) (int, error) {
 
 }
 
+func (s *SQLStore) GetActivityDigestChanges(since int64) ([]*model.ActivityDigestChange, error) {
+	return s.getActivityDigestChanges(s.db, since)
+
+}
+
+func (s *SQLStore) GetActivityDigestSentAt(userID string) (int64, error) {
+	return s.getActivityDigestSentAt(s.db, userID)
+
+}
+
 func (s *SQLStore) GetAllTeams() ([]*model.Team, error) {
 	return s.getAllTeams(s.db)
 
 }
 
+func (s *SQLStore) GetAutomationRule(ruleID string) (*model.AutomationRule, error) {
+	return s.getAutomationRule(s.db, ruleID)
+
+}
+
+func (s *SQLStore) GetAutomationRules(boardID string) ([]*model.AutomationRule, error) {
+	return s.getAutomationRules(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetBlock(blockID string) (*model.Block, error) {
 	return s.getBlock(s.db, blockID)
 
@@ -445,6 +613,21 @@ func (s *SQLStore) GetBoardCount(includeDeleted bool) (int64, error) {
 
 }
 
+func (s *SQLStore) GetBoardGitHubRepo(repoID string) (*model.BoardGitHubRepo, error) {
+	return s.getBoardGitHubRepo(s.db, repoID)
+
+}
+
+func (s *SQLStore) GetBoardGitHubRepos(boardID string) ([]*model.BoardGitHubRepo, error) {
+	return s.getBoardGitHubRepos(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetBoardGitHubReposToScan(scannedBefore int64, limit int) ([]*model.BoardGitHubRepo, error) {
+	return s.getBoardGitHubReposToScan(s.db, scannedBefore, limit)
+
+}
+
 func (s *SQLStore) GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error) {
 	return s.getBoardHistory(s.db, boardID, opts)
 
@@ -455,6 +638,16 @@ func (s *SQLStore) GetBoardMemberHistory(boardID string, userID string, limit ui
 
 }
 
+func (s *SQLStore) GetBoardWebhook(webhookID string) (*model.BoardWebhook, error) {
+	return s.getBoardWebhook(s.db, webhookID)
+
+}
+
+func (s *SQLStore) GetBoardWebhooks(boardID string) ([]*model.BoardWebhook, error) {
+	return s.getBoardWebhooks(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetBoardsComplianceHistory(opts model.QueryBoardsComplianceHistoryOptions) ([]*model.BoardHistory, bool, error) {
 	return s.getBoardsComplianceHistory(s.db, opts)
 
@@ -480,11 +673,41 @@ func (s *SQLStore) GetCardByCode(code string) (*model.Block, *model.Board, error
 
 }
 
+func (s *SQLStore) GetCardChildren(parentCardID string) ([]*model.CardParent, error) {
+	return s.getCardChildren(s.db, parentCardID)
+
+}
+
+func (s *SQLStore) GetCardGitHubLink(linkID string) (*model.CardGitHubLink, error) {
+	return s.getCardGitHubLink(s.db, linkID)
+
+}
+
+func (s *SQLStore) GetCardGitHubLinks(cardID string) ([]*model.CardGitHubLink, error) {
+	return s.getCardGitHubLinks(s.db, cardID)
+
+}
+
+func (s *SQLStore) GetCardGitHubLinksToSync(attemptedBefore int64, limit int) ([]*model.CardGitHubLink, error) {
+	return s.getCardGitHubLinksToSync(s.db, attemptedBefore, limit)
+
+}
+
 func (s *SQLStore) GetCardLimitTimestamp() (int64, error) {
 	return s.getCardLimitTimestamp(s.db)
 
 }
 
+func (s *SQLStore) GetCardParent(cardID string) (*model.CardParent, error) {
+	return s.getCardParent(s.db, cardID)
+
+}
+
+func (s *SQLStore) GetCardRecurrence(cardID string) (*model.CardRecurrence, error) {
+	return s.getCardRecurrence(s.db, cardID)
+
+}
+
 func (s *SQLStore) GetCardRelation(relationID string) (*model.CardRelation, error) {
 	return s.getCardRelation(s.db, relationID)
 
@@ -495,6 +718,11 @@ func (s *SQLStore) GetCardRelations(cardID string) ([]*model.CardRelationWithCar
 
 }
 
+func (s *SQLStore) GetCardRelationsForCards(cardIDs []string) ([]*model.CardRelation, error) {
+	return s.getCardRelationsForCards(s.db, cardIDs)
+
+}
+
 func (s *SQLStore) GetCardsCount() (int64, error) {
 	return s.getCardsCount(s.db)
 
@@ -510,11 +738,61 @@ func (s *SQLStore) GetChannel(teamID string, channelID string) (*mmModel.Channel
 
 }
 
+func (s *SQLStore) GetDueCardRecurrences(until int64) ([]*model.CardRecurrence, error) {
+	return s.getDueCardRecurrences(s.db, until)
+
+}
+
+func (s *SQLStore) GetDueWebhookDeliveries(now int64, limit uint64) ([]*model.WebhookDelivery, error) {
+	return s.getDueWebhookDeliveries(s.db, now, limit)
+
+}
+
+func (s *SQLStore) GetEnabledAutomationRulesByTrigger(triggerType model.AutomationTriggerType) ([]*model.AutomationRule, error) {
+	return s.getEnabledAutomationRulesByTrigger(s.db, triggerType)
+
+}
+
 func (s *SQLStore) GetFileInfo(id string) (*mmModel.FileInfo, error) {
 	return s.getFileInfo(s.db, id)
 
 }
 
+func (s *SQLStore) GetGitHubIssueSync(boardID string) (*model.GitHubIssueSync, error) {
+	return s.getGitHubIssueSync(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetGitHubIssueSyncItems(boardID string) ([]*model.GitHubIssueSyncItem, error) {
+	return s.getGitHubIssueSyncItems(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetGitHubIssueSyncsToRun(attemptedBefore int64, limit int) ([]*model.GitHubIssueSync, error) {
+	return s.getGitHubIssueSyncsToRun(s.db, attemptedBefore, limit)
+
+}
+
+func (s *SQLStore) GetGitHubStatusRules(boardID string) ([]*model.GitHubStatusRule, error) {
+	return s.getGitHubStatusRules(s.db, boardID)
+
+}
+
+func (s *SQLStore) GetIncomingWebhook(webhookID string) (*model.IncomingWebhook, error) {
+	return s.getIncomingWebhook(s.db, webhookID)
+
+}
+
+func (s *SQLStore) GetIncomingWebhookByToken(token string) (*model.IncomingWebhook, error) {
+	return s.getIncomingWebhookByToken(s.db, token)
+
+}
+
+func (s *SQLStore) GetIncomingWebhooks(boardID string) ([]*model.IncomingWebhook, error) {
+	return s.getIncomingWebhooks(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetLicense() *mmModel.License {
 	return s.getLicense(s.db)
 
@@ -670,6 +948,31 @@ func (s *SQLStore) GetUsersList(userIDs []string, showEmail bool, showName bool)
 
 }
 
+func (s *SQLStore) GetWebhookDeliveries(webhookID string, page int, perPage int) ([]*model.WebhookDelivery, error) {
+	return s.getWebhookDeliveries(s.db, webhookID, page, perPage)
+
+}
+
+func (s *SQLStore) GetWorklog(worklogID string) (*model.Worklog, error) {
+	return s.getWorklog(s.db, worklogID)
+
+}
+
+func (s *SQLStore) GetWorklogTotals(cardIDs []string) (map[string]int64, error) {
+	return s.getWorklogTotals(s.db, cardIDs)
+
+}
+
+func (s *SQLStore) GetWorklogs(opts model.QueryWorklogsOptions) ([]*model.Worklog, error) {
+	return s.getWorklogs(s.db, opts)
+
+}
+
+func (s *SQLStore) GetWorklogsComplianceHistory(opts model.QueryWorklogsComplianceHistoryOptions) ([]*model.Worklog, bool, error) {
+	return s.getWorklogsComplianceHistory(s.db, opts)
+
+}
+
 func (s *SQLStore) InsertBlock(block *model.Block, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.insertBlock(s.db, block, userID)
@@ -747,11 +1050,59 @@ func (s *SQLStore) InsertBoardWithAdmin(board *model.Board, userID string) (*mod
 
 }
 
+func (s *SQLStore) InsertWebhookDeliveries(deliveries []*model.WebhookDelivery) error {
+	if s.dbType == model.SqliteDBType {
+		return s.insertWebhookDeliveries(s.db, deliveries)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.insertWebhookDeliveries(tx, deliveries)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertWebhookDeliveries"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) IsStatusTransitionAllowed(boardID string, fromStatus string, toStatus string) (bool, error) {
 	return s.isStatusTransitionAllowed(s.db, boardID, fromStatus, toStatus)
 
 }
 
+func (s *SQLStore) MoveCardToBoard(card *model.Block, boardID string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.moveCardToBoard(s.db, card, boardID, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.moveCardToBoard(tx, card, boardID, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "MoveCardToBoard"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.patchBlock(s.db, blockID, blockPatch, userID)
@@ -776,6 +1127,30 @@ func (s *SQLStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, user
 
 }
 
+func (s *SQLStore) PatchBlockProperties(blockID string, updatedProperties map[string]any, deletedProperties []string, userID string) error {
+	if s.dbType == model.SqliteDBType {
+		return s.patchBlockProperties(s.db, blockID, updatedProperties, deletedProperties, userID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.patchBlockProperties(tx, blockID, updatedProperties, deletedProperties, userID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PatchBlockProperties"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.patchBlocks(s.db, blockPatches, userID)
@@ -873,6 +1248,30 @@ func (s *SQLStore) ReorderCategoryBoards(categoryID string, newBoardsOrder []str
 
 }
 
+func (s *SQLStore) ReplaceGitHubStatusRules(boardID string, rules []*model.GitHubStatusRule) error {
+	if s.dbType == model.SqliteDBType {
+		return s.replaceGitHubStatusRules(s.db, boardID, rules)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.replaceGitHubStatusRules(tx, boardID, rules)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "ReplaceGitHubStatusRules"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) ReplaceStatusTransitionRules(boardID string, rules []*model.StatusTransitionRule) error {
 	if s.dbType == model.SqliteDBType {
 		return s.replaceStatusTransitionRules(s.db, boardID, rules)
@@ -931,6 +1330,54 @@ func (s *SQLStore) SaveFileInfo(fileInfo *mmModel.FileInfo) error {
 
 }
 
+func (s *SQLStore) SaveGitHubIssueSync(sync *model.GitHubIssueSync) error {
+	if s.dbType == model.SqliteDBType {
+		return s.saveGitHubIssueSync(s.db, sync)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.saveGitHubIssueSync(tx, sync)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SaveGitHubIssueSync"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (s *SQLStore) SaveGitHubIssueSyncItem(item *model.GitHubIssueSyncItem) error {
+	if s.dbType == model.SqliteDBType {
+		return s.saveGitHubIssueSyncItem(s.db, item)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return txErr
+	}
+	err := s.saveGitHubIssueSyncItem(tx, item)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "SaveGitHubIssueSyncItem"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
 func (s *SQLStore) SaveMember(bm *model.BoardMember) (*model.BoardMember, error) {
 	return s.saveMember(s.db, bm)
 
@@ -960,6 +1407,11 @@ func (s *SQLStore) SaveStatusTransitionRules(rules []*model.StatusTransitionRule
 
 }
 
+func (s *SQLStore) SearchBlocks(opts model.QueryBlockSearchOptions) ([]*model.Block, error) {
+	return s.searchBlocks(s.db, opts)
+
+}
+
 func (s *SQLStore) SearchBoardsForUser(term string, searchField model.BoardSearchField, userID string, includePublicBoards bool) ([]*model.Board, error) {
 	return s.searchBoardsForUser(s.db, term, searchField, userID, includePublicBoards)
 
@@ -970,6 +1422,11 @@ func (s *SQLStore) SearchBoardsForUserInTeam(teamID string, term string, userID
 
 }
 
+func (s *SQLStore) SearchCards(search *model.CardSearch, page int, perPage int) ([]*model.Block, error) {
+	return s.searchCards(s.db, search, page, perPage)
+
+}
+
 func (s *SQLStore) SearchUserChannels(teamID string, userID string, query string) ([]*mmModel.Channel, error) {
 	return s.searchUserChannels(s.db, teamID, userID, query)
 
@@ -990,6 +1447,11 @@ func (s *SQLStore) SetBoardVisibility(userID string, categoryID string, boardID
 
 }
 
+func (s *SQLStore) SetCardParent(parent *model.CardParent) (*model.CardParent, error) {
+	return s.setCardParent(s.db, parent)
+
+}
+
 func (s *SQLStore) SetSystemSetting(key string, value string) error {
 	return s.setSystemSetting(s.db, key, value)
 
@@ -1043,11 +1505,36 @@ func (s *SQLStore) UndeleteBoard(boardID string, modifiedBy string) error {
 
 }
 
+func (s *SQLStore) UpdateAutomationRule(rule *model.AutomationRule) (*model.AutomationRule, error) {
+	return s.updateAutomationRule(s.db, rule)
+
+}
+
+func (s *SQLStore) UpdateBoardGitHubRepoScannedAt(repoID string, scannedAt int64) error {
+	return s.updateBoardGitHubRepoScannedAt(s.db, repoID, scannedAt)
+
+}
+
+func (s *SQLStore) UpdateBoardWebhook(webhook *model.BoardWebhook) (*model.BoardWebhook, error) {
+	return s.updateBoardWebhook(s.db, webhook)
+
+}
+
+func (s *SQLStore) UpdateCardGitHubLinkSync(link *model.CardGitHubLink) error {
+	return s.updateCardGitHubLinkSync(s.db, link)
+
+}
+
 func (s *SQLStore) UpdateCardLimitTimestamp(cardLimit int) (int64, error) {
 	return s.updateCardLimitTimestamp(s.db, cardLimit)
 
 }
 
+func (s *SQLStore) UpdateCardRecurrenceNextAt(cardID string, from int64, to int64) (bool, error) {
+	return s.updateCardRecurrenceNextAt(s.db, cardID, from, to)
+
+}
+
 func (s *SQLStore) UpdateCardRelation(relation *model.CardRelation) (*model.CardRelation, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.updateCardRelation(s.db, relation)
@@ -1077,11 +1564,41 @@ func (s *SQLStore) UpdateCategory(category model.Category) error {
 
 }
 
+func (s *SQLStore) UpdateGitHubIssueSyncState(sync *model.GitHubIssueSync) error {
+	return s.updateGitHubIssueSyncState(s.db, sync)
+
+}
+
+func (s *SQLStore) UpdateIncomingWebhook(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	return s.updateIncomingWebhook(s.db, webhook)
+
+}
+
 func (s *SQLStore) UpdateSubscribersNotifiedAt(blockID string, notifiedAt int64) error {
 	return s.updateSubscribersNotifiedAt(s.db, blockID, notifiedAt)
 
 }
 
+func (s *SQLStore) UpdateWebhookDelivery(delivery *model.WebhookDelivery) error {
+	return s.updateWebhookDelivery(s.db, delivery)
+
+}
+
+func (s *SQLStore) UpdateWorklog(worklog *model.Worklog) (*model.Worklog, error) {
+	return s.updateWorklog(s.db, worklog)
+
+}
+
+func (s *SQLStore) UpsertActivityDigestChange(change *model.ActivityDigestChange) error {
+	return s.upsertActivityDigestChange(s.db, change)
+
+}
+
+func (s *SQLStore) UpsertCardRecurrence(recurrence *model.CardRecurrence) (*model.CardRecurrence, error) {
+	return s.upsertCardRecurrence(s.db, recurrence)
+
+}
+
 func (s *SQLStore) UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error) {
 	return s.upsertNotificationHint(s.db, hint, notificationFreq)
 
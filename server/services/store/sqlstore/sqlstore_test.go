@@ -28,6 +28,19 @@ func TestSQLStore(t *testing.T) {
 	t.Run("StoreTestCategoryStore", func(t *testing.T) { storetests.StoreTestCategoryStore(t, SetupTests) })
 	t.Run("StoreTestCategoryBoardsStore", func(t *testing.T) { storetests.StoreTestCategoryBoardsStore(t, SetupTests) })
 	t.Run("ComplianceHistoryStore", func(t *testing.T) { storetests.StoreTestComplianceHistoryStore(t, SetupTests) })
+	t.Run("BoardWebhookStore", func(t *testing.T) { storetests.StoreTestBoardWebhookStore(t, SetupTests) })
+	t.Run("IncomingWebhookStore", func(t *testing.T) { storetests.StoreTestIncomingWebhookStore(t, SetupTests) })
+	t.Run("DueDateNotificationStore", func(t *testing.T) { storetests.StoreTestDueDateNotificationStore(t, SetupTests) })
+	t.Run("CardRecurrenceStore", func(t *testing.T) { storetests.StoreTestCardRecurrenceStore(t, SetupTests) })
+	t.Run("ActivityDigestStore", func(t *testing.T) { storetests.StoreTestActivityDigestStore(t, SetupTests) })
+	t.Run("CardCodeAliasStore", func(t *testing.T) { storetests.StoreTestCardCodeAliasStore(t, SetupTests) })
+	t.Run("WorklogStore", func(t *testing.T) { storetests.StoreTestWorklogStore(t, SetupTests) })
+	t.Run("CardGitHubLinkStore", func(t *testing.T) { storetests.StoreTestCardGitHubLinkStore(t, SetupTests) })
+	t.Run("BoardGitHubRepoStore", func(t *testing.T) { storetests.StoreTestBoardGitHubRepoStore(t, SetupTests) })
+	t.Run("AutomationRuleStore", func(t *testing.T) { storetests.StoreTestAutomationRuleStore(t, SetupTests) })
+	t.Run("CardParentStore", func(t *testing.T) { storetests.StoreTestCardParentStore(t, SetupTests) })
+	t.Run("GitHubStatusRuleStore", func(t *testing.T) { storetests.StoreTestGitHubStatusRuleStore(t, SetupTests) })
+	t.Run("GitHubIssueSyncStore", func(t *testing.T) { storetests.StoreTestGitHubIssueSyncStore(t, SetupTests) })
 }
 
 //  tests for  utility functions inside sqlstore.go
@@ -4,8 +4,7 @@
 package sqlstore
 
 import (
-	"database/sql"
-	"errors"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
@@ -20,6 +19,24 @@ func (s *SQLStore) statusTransitionRuleFields() []string {
 		"from_status",
 		"to_status",
 		"allowed",
+		"required_properties",
+		"allowed_roles",
+		"require_comment",
+		"create_at",
+		"update_at",
+	}
+}
+
+func (s *SQLStore) statusTransitionRuleSelectFields() []string {
+	return []string{
+		"id",
+		"board_id",
+		"from_status",
+		"to_status",
+		"allowed",
+		"COALESCE(required_properties, '')",
+		"COALESCE(allowed_roles, '')",
+		"require_comment",
 		"create_at",
 		"update_at",
 	}
@@ -27,24 +44,45 @@ func (s *SQLStore) statusTransitionRuleFields() []string {
 
 func (s *SQLStore) statusTransitionRuleFromRow(row sq.RowScanner) (*model.StatusTransitionRule, error) {
 	var rule model.StatusTransitionRule
+	var requiredProperties, allowedRoles string
 	err := row.Scan(
 		&rule.ID,
 		&rule.BoardID,
 		&rule.FromStatus,
 		&rule.ToStatus,
 		&rule.Allowed,
+		&requiredProperties,
+		&allowedRoles,
+		&rule.RequireComment,
 		&rule.CreateAt,
 		&rule.UpdateAt,
 	)
 	if err != nil {
 		return nil, err
 	}
+
+	if requiredProperties != "" {
+		rule.RequiredProperties = strings.Split(requiredProperties, ",")
+	}
+	if allowedRoles != "" {
+		for _, role := range strings.Split(allowedRoles, ",") {
+			rule.AllowedRoles = append(rule.AllowedRoles, model.BoardRole(role))
+		}
+	}
 	return &rule, nil
 }
 
+func joinBoardRoles(roles []model.BoardRole) string {
+	values := make([]string, len(roles))
+	for i, role := range roles {
+		values[i] = string(role)
+	}
+	return strings.Join(values, ",")
+}
+
 func (s *SQLStore) getStatusTransitionRules(db sq.BaseRunner, boardID string) ([]*model.StatusTransitionRule, error) {
 	query := s.getQueryBuilder(db).
-		Select(s.statusTransitionRuleFields()...).
+		Select(s.statusTransitionRuleSelectFields()...).
 		From(s.tablePrefix + "status_transition_rules").
 		Where(sq.Eq{"board_id": boardID})
 
@@ -87,6 +125,9 @@ func (s *SQLStore) saveStatusTransitionRules(db sq.BaseRunner, rules []*model.St
 			return err
 		}
 
+		requiredProperties := strings.Join(rule.RequiredProperties, ",")
+		allowedRoles := joinBoardRoles(rule.AllowedRoles)
+
 		query := s.getQueryBuilder(db).
 			Insert(s.tablePrefix+"status_transition_rules").
 			Columns(s.statusTransitionRuleFields()...).
@@ -96,16 +137,22 @@ func (s *SQLStore) saveStatusTransitionRules(db sq.BaseRunner, rules []*model.St
 				rule.FromStatus,
 				rule.ToStatus,
 				rule.Allowed,
+				requiredProperties,
+				allowedRoles,
+				rule.RequireComment,
 				rule.CreateAt,
 				rule.UpdateAt,
 			)
 
 		if s.dbType == model.MysqlDBType {
-			query = query.Suffix("ON DUPLICATE KEY UPDATE allowed = ?, update_at = ?", rule.Allowed, rule.UpdateAt)
+			query = query.Suffix(
+				`ON DUPLICATE KEY UPDATE allowed = ?, required_properties = ?, allowed_roles = ?, require_comment = ?, update_at = ?`,
+				rule.Allowed, requiredProperties, allowedRoles, rule.RequireComment, rule.UpdateAt)
 		} else {
 			query = query.Suffix(`
 				ON CONFLICT (board_id, from_status, to_status)
-				DO UPDATE SET allowed = EXCLUDED.allowed, update_at = EXCLUDED.update_at
+				DO UPDATE SET allowed = EXCLUDED.allowed, required_properties = EXCLUDED.required_properties,
+				  allowed_roles = EXCLUDED.allowed_roles, require_comment = EXCLUDED.require_comment, update_at = EXCLUDED.update_at
 			`)
 		}
 
@@ -162,27 +209,39 @@ func (s *SQLStore) deleteStatusTransitionRulesForBoard(db sq.BaseRunner, boardID
 	return nil
 }
 
+// isStatusTransitionAllowed checks the rule of a transition, or else the rule
+// of entering its status from any status.
 func (s *SQLStore) isStatusTransitionAllowed(db sq.BaseRunner, boardID, fromStatus, toStatus string) (bool, error) {
 	// If no rules exist for this board, all transitions are allowed
 	query := s.getQueryBuilder(db).
-		Select("allowed").
+		Select("from_status", "allowed").
 		From(s.tablePrefix + "status_transition_rules").
 		Where(sq.Eq{
 			"board_id":    boardID,
-			"from_status": fromStatus,
+			"from_status": []string{fromStatus, model.StatusTransitionAnyStatus},
 			"to_status":   toStatus,
 		})
 
-	var allowed bool
-	err := query.QueryRow().Scan(&allowed)
+	rows, err := query.Query()
 	if err != nil {
-		// If no rule found, transition is allowed by default
-		if errors.Is(err, sql.ErrNoRows) {
-			return true, nil
-		}
 		s.logger.Error("isStatusTransitionAllowed ERROR", mlog.Err(err))
 		return false, err
 	}
+	defer s.CloseRows(rows)
 
-	return allowed, nil
+	// If no rule found, transition is allowed by default
+	allowed := true
+	for rows.Next() {
+		var ruleFromStatus string
+		var ruleAllowed bool
+		if err := rows.Scan(&ruleFromStatus, &ruleAllowed); err != nil {
+			s.logger.Error("isStatusTransitionAllowed scan ERROR", mlog.Err(err))
+			return false, err
+		}
+		if ruleFromStatus == fromStatus {
+			return ruleAllowed, nil
+		}
+		allowed = ruleAllowed
+	}
+	return allowed, rows.Err()
 }
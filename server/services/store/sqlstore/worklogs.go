@@ -0,0 +1,305 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func worklogFields(prefix string) []string {
+	return []string{
+		prefix + "id",
+		prefix + "board_id",
+		prefix + "card_id",
+		prefix + "user_id",
+		prefix + "minutes",
+		prefix + "work_date",
+		"COALESCE(" + prefix + "note, '')",
+		prefix + "create_at",
+		prefix + "update_at",
+		prefix + "delete_at",
+	}
+}
+
+func (s *SQLStore) worklogsFromRows(rows *sql.Rows) ([]*model.Worklog, error) {
+	worklogs := []*model.Worklog{}
+	for rows.Next() {
+		var worklog model.Worklog
+		err := rows.Scan(
+			&worklog.ID,
+			&worklog.BoardID,
+			&worklog.CardID,
+			&worklog.UserID,
+			&worklog.Minutes,
+			&worklog.Date,
+			&worklog.Note,
+			&worklog.CreateAt,
+			&worklog.UpdateAt,
+			&worklog.DeleteAt,
+		)
+		if err != nil {
+			s.logger.Error("worklogsFromRows scan error", mlog.Err(err))
+			return nil, err
+		}
+		worklogs = append(worklogs, &worklog)
+	}
+	return worklogs, rows.Err()
+}
+
+func (s *SQLStore) createWorklog(db sq.BaseRunner, worklog *model.Worklog) (*model.Worklog, error) {
+	worklog.Populate()
+	if err := worklog.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"worklogs").
+		Columns(
+			"id",
+			"board_id",
+			"card_id",
+			"user_id",
+			"minutes",
+			"work_date",
+			"note",
+			"create_at",
+			"update_at",
+			"delete_at",
+		).
+		Values(
+			worklog.ID,
+			worklog.BoardID,
+			worklog.CardID,
+			worklog.UserID,
+			worklog.Minutes,
+			worklog.Date,
+			worklog.Note,
+			worklog.CreateAt,
+			worklog.UpdateAt,
+			worklog.DeleteAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("createWorklog error", mlog.String("card_id", worklog.CardID), mlog.Err(err))
+		return nil, err
+	}
+	return worklog, nil
+}
+
+func (s *SQLStore) getWorklog(db sq.BaseRunner, worklogID string) (*model.Worklog, error) {
+	query := s.getQueryBuilder(db).
+		Select(worklogFields("")...).
+		From(s.tablePrefix + "worklogs").
+		Where(sq.Eq{"id": worklogID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getWorklog error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	worklogs, err := s.worklogsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(worklogs) == 0 {
+		return nil, model.NewErrNotFound("worklog ID=" + worklogID)
+	}
+	return worklogs[0], nil
+}
+
+// getWorklogs returns the entries matching the options, oldest first.
+func (s *SQLStore) getWorklogs(db sq.BaseRunner, opts model.QueryWorklogsOptions) ([]*model.Worklog, error) {
+	query := s.getQueryBuilder(db).
+		Select(worklogFields("")...).
+		From(s.tablePrefix+"worklogs").
+		Where(sq.Eq{"delete_at": 0}).
+		OrderBy("work_date", "create_at", "id")
+
+	if len(opts.BoardIDs) != 0 {
+		query = query.Where(sq.Eq{"board_id": opts.BoardIDs})
+	}
+	if opts.CardID != "" {
+		query = query.Where(sq.Eq{"card_id": opts.CardID})
+	}
+	if opts.UserID != "" {
+		query = query.Where(sq.Eq{"user_id": opts.UserID})
+	}
+	if opts.From != 0 {
+		query = query.Where(sq.GtOrEq{"work_date": opts.From})
+	}
+	if opts.To != 0 {
+		query = query.Where(sq.Lt{"work_date": opts.To})
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getWorklogs error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.worklogsFromRows(rows)
+}
+
+// getWorklogTotals returns the minutes logged on each of the cards that
+// have entries.
+func (s *SQLStore) getWorklogTotals(db sq.BaseRunner, cardIDs []string) (map[string]int64, error) {
+	totals := map[string]int64{}
+	if len(cardIDs) == 0 {
+		return totals, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select("card_id", "SUM(minutes)").
+		From(s.tablePrefix + "worklogs").
+		Where(sq.Eq{"card_id": cardIDs}).
+		Where(sq.Eq{"delete_at": 0}).
+		GroupBy("card_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("getWorklogTotals error", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var cardID string
+		var minutes int64
+		if err := rows.Scan(&cardID, &minutes); err != nil {
+			s.logger.Error("getWorklogTotals scan error", mlog.Err(err))
+			return nil, err
+		}
+		totals[cardID] = minutes
+	}
+	return totals, rows.Err()
+}
+
+func (s *SQLStore) updateWorklog(db sq.BaseRunner, worklog *model.Worklog) (*model.Worklog, error) {
+	worklog.UpdateAt = utils.GetMillis()
+	if err := worklog.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"worklogs").
+		Set("minutes", worklog.Minutes).
+		Set("work_date", worklog.Date).
+		Set("note", worklog.Note).
+		Set("update_at", worklog.UpdateAt).
+		Where(sq.Eq{"id": worklog.ID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("updateWorklog error", mlog.Err(err))
+		return nil, err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, model.NewErrNotFound("worklog ID=" + worklog.ID)
+	}
+	return worklog, nil
+}
+
+// deleteWorklog marks an entry as deleted, keeping it for the compliance
+// history.
+func (s *SQLStore) deleteWorklog(db sq.BaseRunner, worklogID string) error {
+	now := utils.GetMillis()
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"worklogs").
+		Set("update_at", now).
+		Set("delete_at", now).
+		Where(sq.Eq{"id": worklogID}).
+		Where(sq.Eq{"delete_at": 0})
+
+	result, err := query.Exec()
+	if err != nil {
+		s.logger.Error("deleteWorklog error", mlog.Err(err))
+		return err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound("worklog ID=" + worklogID)
+	}
+	return nil
+}
+
+func (s *SQLStore) getWorklogsComplianceHistory(db sq.BaseRunner, opts model.QueryWorklogsComplianceHistoryOptions) ([]*model.Worklog, bool, error) {
+	query := s.getQueryBuilder(db).
+		Select(worklogFields("w.")...).
+		From(s.tablePrefix+"worklogs as w").
+		Join(s.tablePrefix+"boards as brd on brd.id=w.board_id").
+		Where(sq.Gt{"w.update_at": opts.ModifiedSince}).
+		OrderBy("w.update_at desc", "w.id")
+
+	if !opts.IncludeDeleted {
+		query = query.Where(sq.Eq{"w.delete_at": 0})
+	}
+
+	if opts.TeamID != "" {
+		query = query.Where(sq.Eq{"brd.team_id": opts.TeamID})
+	}
+
+	if opts.BoardID != "" {
+		query = query.Where(sq.Eq{"w.board_id": opts.BoardID})
+	}
+
+	if opts.Page != 0 {
+		query = query.Offset(offset(opts.Page, opts.PerPage))
+	}
+
+	if opts.PerPage > 0 {
+		// N+1 to check if there's a next page for pagination
+		query = query.Limit(limit(opts.PerPage) + 1)
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`GetWorklogsComplianceHistory ERROR`, mlog.Err(err))
+		return nil, false, err
+	}
+	defer s.CloseRows(rows)
+
+	history, err := s.worklogsFromRows(rows)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var hasMore bool
+	if opts.PerPage > 0 && len(history) > opts.PerPage {
+		history = history[0:opts.PerPage]
+		hasMore = true
+	}
+	return history, hasMore, nil
+}
+
+// moveWorklogsToBoard moves the entries of a card along with the card.
+func (s *SQLStore) moveWorklogsToBoard(db sq.BaseRunner, cardID string, boardID string) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"worklogs").
+		Set("board_id", boardID).
+		Where(sq.Eq{"card_id": cardID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("moveWorklogsToBoard error", mlog.String("card_id", cardID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
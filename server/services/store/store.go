@@ -39,8 +39,12 @@ type Store interface {
 	GetBlock(blockID string) (*model.Block, error)
 	GetCardByCode(code string) (*model.Block, *model.Board, error)
 	GetNextCardNumber(boardID string) (int64, error)
+	SearchCards(search *model.CardSearch, page, perPage int) ([]*model.Block, error)
+	SearchBlocks(opts model.QueryBlockSearchOptions) ([]*model.Block, error)
 	// @withTransaction
 	PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error
+	// @withTransaction
+	PatchBlockProperties(blockID string, updatedProperties map[string]any, deletedProperties []string, userID string) error
 	GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
 	GetBlockHistoryDescendants(boardID string, opts model.QueryBlockHistoryOptions) ([]*model.Block, error)
 	GetBlockHistoryNewestChildren(parentID string, opts model.QueryBlockHistoryChildOptions) ([]*model.Block, bool, error)
@@ -53,6 +57,8 @@ type Store interface {
 	DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]*model.Block, error)
 	// @withTransaction
 	PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error
+	// @withTransaction
+	MoveCardToBoard(card *model.Block, boardID string, userID string) error
 
 	Shutdown() error
 
@@ -172,11 +178,110 @@ type Store interface {
 	CreateCardRelation(relation *model.CardRelation) (*model.CardRelation, error)
 	GetCardRelations(cardID string) ([]*model.CardRelationWithCard, error)
 	GetCardRelation(relationID string) (*model.CardRelation, error)
+	GetCardRelationsForCards(cardIDs []string) ([]*model.CardRelation, error)
 	// @withTransaction
 	UpdateCardRelation(relation *model.CardRelation) (*model.CardRelation, error)
 	// @withTransaction
 	DeleteCardRelation(relationID string) error
 
+	// Board Webhooks
+	CreateBoardWebhook(webhook *model.BoardWebhook) (*model.BoardWebhook, error)
+	GetBoardWebhook(webhookID string) (*model.BoardWebhook, error)
+	GetBoardWebhooks(boardID string) ([]*model.BoardWebhook, error)
+	UpdateBoardWebhook(webhook *model.BoardWebhook) (*model.BoardWebhook, error)
+	// @withTransaction
+	DeleteBoardWebhook(webhookID string) error
+	// @withTransaction
+	InsertWebhookDeliveries(deliveries []*model.WebhookDelivery) error
+	GetDueWebhookDeliveries(now int64, limit uint64) ([]*model.WebhookDelivery, error)
+	ClaimWebhookDelivery(delivery *model.WebhookDelivery, claimUntil int64) (bool, error)
+	UpdateWebhookDelivery(delivery *model.WebhookDelivery) error
+	GetWebhookDeliveries(webhookID string, page, perPage int) ([]*model.WebhookDelivery, error)
+
+	// Incoming Webhooks
+	CreateIncomingWebhook(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error)
+	GetIncomingWebhook(webhookID string) (*model.IncomingWebhook, error)
+	GetIncomingWebhookByToken(token string) (*model.IncomingWebhook, error)
+	GetIncomingWebhooks(boardID string) ([]*model.IncomingWebhook, error)
+	UpdateIncomingWebhook(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error)
+	DeleteIncomingWebhook(webhookID string) error
+
+	// Automation Rules
+	CreateAutomationRule(rule *model.AutomationRule) (*model.AutomationRule, error)
+	GetAutomationRule(ruleID string) (*model.AutomationRule, error)
+	GetAutomationRules(boardID string) ([]*model.AutomationRule, error)
+	GetEnabledAutomationRulesByTrigger(triggerType model.AutomationTriggerType) ([]*model.AutomationRule, error)
+	UpdateAutomationRule(rule *model.AutomationRule) (*model.AutomationRule, error)
+	ClaimAutomationRuleDueCheck(rule *model.AutomationRule, until int64) (bool, error)
+	DeleteAutomationRule(ruleID string) error
+
+	// Due date notifications
+	ClaimDueDateNotification(notification *model.DueDateNotification) (bool, error)
+	DeleteDueDateNotificationsBefore(createAt int64) error
+
+	// Card recurrences
+	UpsertCardRecurrence(recurrence *model.CardRecurrence) (*model.CardRecurrence, error)
+	GetCardRecurrence(cardID string) (*model.CardRecurrence, error)
+	GetDueCardRecurrences(until int64) ([]*model.CardRecurrence, error)
+	UpdateCardRecurrenceNextAt(cardID string, from int64, to int64) (bool, error)
+	DeleteCardRecurrence(cardID string) error
+
+	// Card hierarchy
+	SetCardParent(parent *model.CardParent) (*model.CardParent, error)
+	GetCardParent(cardID string) (*model.CardParent, error)
+	GetCardChildren(parentCardID string) ([]*model.CardParent, error)
+	DeleteCardParent(cardID string) error
+
+	// Worklogs
+	CreateWorklog(worklog *model.Worklog) (*model.Worklog, error)
+	GetWorklog(worklogID string) (*model.Worklog, error)
+	GetWorklogs(opts model.QueryWorklogsOptions) ([]*model.Worklog, error)
+	GetWorklogTotals(cardIDs []string) (map[string]int64, error)
+	UpdateWorklog(worklog *model.Worklog) (*model.Worklog, error)
+	DeleteWorklog(worklogID string) error
+	GetWorklogsComplianceHistory(opts model.QueryWorklogsComplianceHistoryOptions) ([]*model.Worklog, bool, error)
+
+	// Card GitHub links
+	CreateCardGitHubLink(link *model.CardGitHubLink) (*model.CardGitHubLink, error)
+	GetCardGitHubLink(linkID string) (*model.CardGitHubLink, error)
+	GetCardGitHubLinks(cardID string) ([]*model.CardGitHubLink, error)
+	GetCardGitHubLinksToSync(attemptedBefore int64, limit int) ([]*model.CardGitHubLink, error)
+	UpdateCardGitHubLinkSync(link *model.CardGitHubLink) error
+	DeleteCardGitHubLink(linkID string) error
+
+	// GitHub status rules
+	GetGitHubStatusRules(boardID string) ([]*model.GitHubStatusRule, error)
+	// @withTransaction
+	ReplaceGitHubStatusRules(boardID string, rules []*model.GitHubStatusRule) error
+
+	// Board GitHub repositories
+	CreateBoardGitHubRepo(repo *model.BoardGitHubRepo) (*model.BoardGitHubRepo, error)
+	GetBoardGitHubRepo(repoID string) (*model.BoardGitHubRepo, error)
+	GetBoardGitHubRepos(boardID string) ([]*model.BoardGitHubRepo, error)
+	GetBoardGitHubReposToScan(scannedBefore int64, limit int) ([]*model.BoardGitHubRepo, error)
+	UpdateBoardGitHubRepoScannedAt(repoID string, scannedAt int64) error
+	DeleteBoardGitHubRepo(repoID string) error
+
+	// GitHub issue synchronizations
+	GetGitHubIssueSync(boardID string) (*model.GitHubIssueSync, error)
+	GetGitHubIssueSyncsToRun(attemptedBefore int64, limit int) ([]*model.GitHubIssueSync, error)
+	// @withTransaction
+	SaveGitHubIssueSync(sync *model.GitHubIssueSync) error
+	UpdateGitHubIssueSyncState(sync *model.GitHubIssueSync) error
+	// @withTransaction
+	DeleteGitHubIssueSync(boardID string) error
+	GetGitHubIssueSyncItems(boardID string) ([]*model.GitHubIssueSyncItem, error)
+	// @withTransaction
+	SaveGitHubIssueSyncItem(item *model.GitHubIssueSyncItem) error
+	DeleteGitHubIssueSyncItem(cardID string) error
+
+	// Activity digests
+	UpsertActivityDigestChange(change *model.ActivityDigestChange) error
+	GetActivityDigestChanges(since int64) ([]*model.ActivityDigestChange, error)
+	DeleteActivityDigestChangesBefore(updateAt int64) error
+	GetActivityDigestSentAt(userID string) (int64, error)
+	ClaimActivityDigest(userID string, lastSentAt int64, sentAt int64) (bool, error)
+
 	DBType() string
 	DBVersion() string
 
@@ -0,0 +1,114 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestActivityDigestStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("ActivityDigestChanges", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testActivityDigestChanges(t, store)
+	})
+	t.Run("ClaimActivityDigest", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testClaimActivityDigest(t, store)
+	})
+}
+
+func testActivityDigestChanges(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+
+	old := &model.ActivityDigestChange{CardID: utils.NewID(utils.IDTypeCard), BoardID: testBoardID, UpdateAt: now - 10000}
+	first := &model.ActivityDigestChange{CardID: utils.NewID(utils.IDTypeCard), BoardID: testBoardID, UpdateAt: now - 2000}
+	second := &model.ActivityDigestChange{CardID: utils.NewID(utils.IDTypeCard), BoardID: testBoardID, UpdateAt: now - 1000}
+	other := &model.ActivityDigestChange{CardID: utils.NewID(utils.IDTypeCard), BoardID: otherBoardID, UpdateAt: now - 1000}
+	for _, change := range []*model.ActivityDigestChange{old, second, first, other} {
+		require.NoError(t, store.UpsertActivityDigestChange(change))
+	}
+
+	t.Run("get the changes since a time", func(t *testing.T) {
+		changes, err := store.GetActivityDigestChanges(now - 5000)
+		require.NoError(t, err)
+		require.Len(t, changes, 3)
+
+		// grouped by board, oldest first
+		var boardChanges []*model.ActivityDigestChange
+		for _, change := range changes {
+			if change.BoardID == testBoardID {
+				boardChanges = append(boardChanges, change)
+			}
+		}
+		assert.Equal(t, []*model.ActivityDigestChange{first, second}, boardChanges)
+		assert.Contains(t, changes, other)
+	})
+
+	t.Run("keep the latest change of a card", func(t *testing.T) {
+		moved := &model.ActivityDigestChange{CardID: old.CardID, BoardID: otherBoardID, UpdateAt: now}
+		require.NoError(t, store.UpsertActivityDigestChange(moved))
+
+		changes, err := store.GetActivityDigestChanges(now - 1)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, moved, changes[0])
+	})
+
+	t.Run("delete the changes before a time", func(t *testing.T) {
+		require.NoError(t, store.DeleteActivityDigestChangesBefore(now))
+
+		changes, err := store.GetActivityDigestChanges(0)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, old.CardID, changes[0].CardID)
+	})
+}
+
+func testClaimActivityDigest(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+
+	t.Run("no digest sent yet", func(t *testing.T) {
+		_, err := store.GetActivityDigestSentAt(testUserID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("claim the first digest", func(t *testing.T) {
+		claimed, err := store.ClaimActivityDigest(testUserID, 0, now)
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		// another server sends the same first digest
+		claimed, err = store.ClaimActivityDigest(testUserID, 0, now)
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		sentAt, err := store.GetActivityDigestSentAt(testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, now, sentAt)
+	})
+
+	t.Run("claim the next digest", func(t *testing.T) {
+		claimed, err := store.ClaimActivityDigest(testUserID, now, now+60000)
+		require.NoError(t, err)
+		require.True(t, claimed)
+
+		claimed, err = store.ClaimActivityDigest(testUserID, now, now+60000)
+		require.NoError(t, err)
+		require.False(t, claimed)
+
+		sentAt, err := store.GetActivityDigestSentAt(testUserID)
+		require.NoError(t, err)
+		assert.Equal(t, now+60000, sentAt)
+	})
+}
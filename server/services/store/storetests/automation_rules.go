@@ -0,0 +1,184 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestAutomationRuleStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAutomationRule", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAutomationRule(t, store)
+	})
+	t.Run("GetEnabledAutomationRulesByTrigger", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetEnabledAutomationRulesByTrigger(t, store)
+	})
+	t.Run("UpdateAutomationRule", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateAutomationRule(t, store)
+	})
+	t.Run("ClaimAutomationRuleDueCheck", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testClaimAutomationRuleDueCheck(t, store)
+	})
+	t.Run("DeleteAutomationRule", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteAutomationRule(t, store)
+	})
+}
+
+func createTestAutomationRule(t *testing.T, store store.Store, boardID string, trigger model.AutomationTrigger, enabled bool) *model.AutomationRule {
+	rule, err := store.CreateAutomationRule(&model.AutomationRule{
+		BoardID:   boardID,
+		Title:     "Welcome",
+		Enabled:   enabled,
+		Trigger:   trigger,
+		Actions:   []model.AutomationAction{{Type: model.AutomationActionAddComment, Text: "Welcome {{card.title}}"}},
+		CreatedBy: testUserID,
+	})
+	require.NoError(t, err)
+	return rule
+}
+
+func testCreateAutomationRule(t *testing.T, store store.Store) {
+	cardCreated := model.AutomationTrigger{Type: model.AutomationTriggerCardCreated}
+
+	t.Run("create and get", func(t *testing.T) {
+		rule := createTestAutomationRule(t, store, testBoardID, cardCreated, true)
+		require.NotEmpty(t, rule.ID)
+		assert.Equal(t, testUserID, rule.ModifiedBy)
+		assert.Equal(t, rule.CreateAt, rule.DueCheckedAt)
+
+		retrieved, err := store.GetAutomationRule(rule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, rule, retrieved)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		_, err := store.CreateAutomationRule(&model.AutomationRule{
+			BoardID:   testBoardID,
+			Trigger:   cardCreated,
+			CreatedBy: testUserID,
+		})
+		require.ErrorIs(t, err, model.ErrInvalidAutomationRule)
+	})
+
+	t.Run("get the rules of a board", func(t *testing.T) {
+		boardID := utils.NewID(utils.IDTypeBoard)
+		first := createTestAutomationRule(t, store, boardID, cardCreated, true)
+		second := createTestAutomationRule(t, store, boardID, cardCreated, false)
+		createTestAutomationRule(t, store, utils.NewID(utils.IDTypeBoard), cardCreated, true)
+
+		rules, err := store.GetAutomationRules(boardID)
+		require.NoError(t, err)
+		require.Len(t, rules, 2)
+		ids := []string{rules[0].ID, rules[1].ID}
+		assert.ElementsMatch(t, []string{first.ID, second.ID}, ids)
+	})
+
+	t.Run("not existing rule", func(t *testing.T) {
+		_, err := store.GetAutomationRule("nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testGetEnabledAutomationRulesByTrigger(t *testing.T, store store.Store) {
+	enabled := createTestAutomationRule(t, store, testBoardID, model.AutomationTrigger{Type: model.AutomationTriggerCardCreated}, true)
+	createTestAutomationRule(t, store, testBoardID, model.AutomationTrigger{Type: model.AutomationTriggerCardCreated}, false)
+	createTestAutomationRule(t, store, testBoardID, model.AutomationTrigger{Type: model.AutomationTriggerStatusEntered, Value: "opt-done"}, true)
+
+	rules, err := store.GetEnabledAutomationRulesByTrigger(model.AutomationTriggerCardCreated)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, enabled.ID, rules[0].ID)
+
+	rules, err = store.GetEnabledAutomationRulesByTrigger(model.AutomationTriggerDueDatePassed)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+}
+
+func testUpdateAutomationRule(t *testing.T, store store.Store) {
+	rule := createTestAutomationRule(t, store, testBoardID, model.AutomationTrigger{Type: model.AutomationTriggerCardCreated}, true)
+
+	t.Run("update", func(t *testing.T) {
+		title := "Done"
+		enabled := false
+		trigger := model.AutomationTrigger{Type: model.AutomationTriggerStatusEntered, PropertyID: "status", Value: "opt-done"}
+		actions := []model.AutomationAction{
+			{Type: model.AutomationActionSetProperty, PropertyID: "priority", Value: "opt-low"},
+			{Type: model.AutomationActionPostToChannel, ChannelID: "channel-id", Text: "{{card.title}} is done"},
+		}
+		updated := rule.Patch(&model.AutomationRulePatch{Title: &title, Enabled: &enabled, Trigger: &trigger, Actions: &actions})
+		updated.ModifiedBy = "other-user-id"
+		_, err := store.UpdateAutomationRule(updated)
+		require.NoError(t, err)
+
+		retrieved, err := store.GetAutomationRule(rule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, updated, retrieved)
+
+		rules, err := store.GetEnabledAutomationRulesByTrigger(model.AutomationTriggerStatusEntered)
+		require.NoError(t, err)
+		require.Empty(t, rules)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		actions := []model.AutomationAction{}
+		_, err := store.UpdateAutomationRule(rule.Patch(&model.AutomationRulePatch{Actions: &actions}))
+		require.ErrorIs(t, err, model.ErrInvalidAutomationRule)
+	})
+
+	t.Run("not existing rule", func(t *testing.T) {
+		missing := *rule
+		missing.ID = utils.NewID(utils.IDTypeNone)
+		_, err := store.UpdateAutomationRule(&missing)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testClaimAutomationRuleDueCheck(t *testing.T, store store.Store) {
+	rule := createTestAutomationRule(t, store, testBoardID, model.AutomationTrigger{Type: model.AutomationTriggerDueDatePassed, PropertyID: "due"}, true)
+	until := rule.DueCheckedAt + 60000
+
+	// two servers read the same watermark
+	first := *rule
+	second := *rule
+
+	claimed, err := store.ClaimAutomationRuleDueCheck(&first, until)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = store.ClaimAutomationRuleDueCheck(&second, until)
+	require.NoError(t, err)
+	require.False(t, claimed)
+
+	retrieved, err := store.GetAutomationRule(rule.ID)
+	require.NoError(t, err)
+	assert.Equal(t, until, retrieved.DueCheckedAt)
+}
+
+func testDeleteAutomationRule(t *testing.T, store store.Store) {
+	rule := createTestAutomationRule(t, store, testBoardID, model.AutomationTrigger{Type: model.AutomationTriggerCardCreated}, true)
+
+	require.NoError(t, store.DeleteAutomationRule(rule.ID))
+
+	_, err := store.GetAutomationRule(rule.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	require.True(t, model.IsErrNotFound(store.DeleteAutomationRule(rule.ID)))
+}
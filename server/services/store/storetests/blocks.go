@@ -40,6 +40,11 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testPatchBlock(t, store)
 	})
+	t.Run("PatchBlockProperties", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testPatchBlockProperties(t, store)
+	})
 	t.Run("PatchBlocks", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -348,6 +353,42 @@ func testInsertBlocks(t *testing.T, store store.Store) {
 	})
 }
 
+func testPatchBlockProperties(t *testing.T, store store.Store) {
+	boardID := "board-id-1"
+
+	block := &model.Block{
+		ID:         "id-test",
+		BoardID:    boardID,
+		Type:       model.TypeCard,
+		Title:      "card",
+		ModifiedBy: testUserID,
+		Fields: map[string]interface{}{
+			"icon":       "😀",
+			"properties": map[string]interface{}{"status": "todo", "total": "1", "average": "1"},
+		},
+	}
+	require.NoError(t, store.InsertBlock(block, testUserID))
+
+	t.Run("not existing block id", func(t *testing.T) {
+		err := store.PatchBlockProperties("invalid-block-id", map[string]any{"total": "2"}, nil, testUserID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("sets and removes properties", func(t *testing.T) {
+		// Wait for not colliding the ID+insert_at key
+		time.Sleep(1 * time.Millisecond)
+
+		err := store.PatchBlockProperties("id-test", map[string]any{"total": "4"}, []string{"average"}, "user-id-2")
+		require.NoError(t, err)
+
+		retrievedBlock, err := store.GetBlock("id-test")
+		require.NoError(t, err)
+		require.Equal(t, "user-id-2", retrievedBlock.ModifiedBy)
+		require.Equal(t, "😀", retrievedBlock.Fields["icon"])
+		require.Equal(t, map[string]interface{}{"status": "todo", "total": "4"}, retrievedBlock.Fields["properties"])
+	})
+}
+
 func testPatchBlock(t *testing.T, store store.Store) {
 	userID := testUserID
 	boardID := "board-id-1"
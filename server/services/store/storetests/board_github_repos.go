@@ -0,0 +1,150 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestBoardGitHubRepoStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateBoardGitHubRepo", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateBoardGitHubRepo(t, store)
+	})
+	t.Run("GetBoardGitHubReposToScan", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardGitHubReposToScan(t, store)
+	})
+	t.Run("DeleteBoardGitHubRepo", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardGitHubRepo(t, store)
+	})
+}
+
+func createTestBoardGitHubRepo(t *testing.T, store store.Store, boardID string, repoName string, scannedAt int64) *model.BoardGitHubRepo {
+	repo, err := store.CreateBoardGitHubRepo(&model.BoardGitHubRepo{
+		BoardID:   boardID,
+		Owner:     "owner",
+		Repo:      repoName,
+		ScannedAt: scannedAt,
+		CreatedBy: testUserID,
+	})
+	require.NoError(t, err)
+	return repo
+}
+
+func testCreateBoardGitHubRepo(t *testing.T, store store.Store) {
+	t.Run("create and get", func(t *testing.T) {
+		repo := createTestBoardGitHubRepo(t, store, testBoardID, "repo", 0)
+		require.NotEmpty(t, repo.ID)
+		require.NotZero(t, repo.CreateAt)
+
+		retrieved, err := store.GetBoardGitHubRepo(repo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, repo, retrieved)
+	})
+
+	t.Run("invalid repository", func(t *testing.T) {
+		_, err := store.CreateBoardGitHubRepo(&model.BoardGitHubRepo{
+			BoardID:   testBoardID,
+			Owner:     "owner/repo",
+			CreatedBy: testUserID,
+		})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("get the repositories of a board", func(t *testing.T) {
+		boardID := utils.NewID(utils.IDTypeBoard)
+		first := createTestBoardGitHubRepo(t, store, boardID, "first", 0)
+		second := createTestBoardGitHubRepo(t, store, boardID, "second", 0)
+		createTestBoardGitHubRepo(t, store, utils.NewID(utils.IDTypeBoard), "other", 0)
+
+		repos, err := store.GetBoardGitHubRepos(boardID)
+		require.NoError(t, err)
+		require.Len(t, repos, 2)
+		ids := []string{repos[0].ID, repos[1].ID}
+		assert.ElementsMatch(t, []string{first.ID, second.ID}, ids)
+	})
+
+	t.Run("not existing repository", func(t *testing.T) {
+		_, err := store.GetBoardGitHubRepo("nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testGetBoardGitHubReposToScan(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	later := createTestBoardGitHubRepo(t, store, testBoardID, "later", now-1000)
+	earlier := createTestBoardGitHubRepo(t, store, testBoardID, "earlier", now-2000)
+	createTestBoardGitHubRepo(t, store, testBoardID, "scanned", now)
+
+	t.Run("least recently scanned first", func(t *testing.T) {
+		repos, err := store.GetBoardGitHubReposToScan(now, 10)
+		require.NoError(t, err)
+		require.Len(t, repos, 2)
+		assert.Equal(t, earlier.ID, repos[0].ID)
+		assert.Equal(t, later.ID, repos[1].ID)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		repos, err := store.GetBoardGitHubReposToScan(now, 1)
+		require.NoError(t, err)
+		require.Len(t, repos, 1)
+		assert.Equal(t, earlier.ID, repos[0].ID)
+	})
+
+	t.Run("update the scan time", func(t *testing.T) {
+		require.NoError(t, store.UpdateBoardGitHubRepoScannedAt(earlier.ID, now))
+
+		retrieved, err := store.GetBoardGitHubRepo(earlier.ID)
+		require.NoError(t, err)
+		assert.Equal(t, now, retrieved.ScannedAt)
+
+		repos, err := store.GetBoardGitHubReposToScan(now, 10)
+		require.NoError(t, err)
+		require.Len(t, repos, 1)
+		assert.Equal(t, later.ID, repos[0].ID)
+
+		require.True(t, model.IsErrNotFound(store.UpdateBoardGitHubRepoScannedAt("nonexistent", now)))
+	})
+}
+
+func testDeleteBoardGitHubRepo(t *testing.T, store store.Store) {
+	t.Run("delete a repository", func(t *testing.T) {
+		repo := createTestBoardGitHubRepo(t, store, testBoardID, "repo", 0)
+
+		require.NoError(t, store.DeleteBoardGitHubRepo(repo.ID))
+
+		_, err := store.GetBoardGitHubRepo(repo.ID)
+		require.True(t, model.IsErrNotFound(err))
+
+		require.True(t, model.IsErrNotFound(store.DeleteBoardGitHubRepo(repo.ID)))
+	})
+
+	t.Run("the repositories go with their board", func(t *testing.T) {
+		board, err := store.InsertBoard(&model.Board{
+			ID:     utils.NewID(utils.IDTypeBoard),
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+		}, testUserID)
+		require.NoError(t, err)
+		createTestBoardGitHubRepo(t, store, board.ID, "repo", 0)
+
+		require.NoError(t, store.DeleteBoard(board.ID, testUserID))
+
+		repos, err := store.GetBoardGitHubRepos(board.ID)
+		require.NoError(t, err)
+		require.Empty(t, repos)
+	})
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestBoardWebhookStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateBoardWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateBoardWebhook(t, store)
+	})
+	t.Run("UpdateBoardWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateBoardWebhook(t, store)
+	})
+	t.Run("DeleteBoardWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardWebhook(t, store)
+	})
+	t.Run("WebhookDeliveries", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testWebhookDeliveries(t, store)
+	})
+	t.Run("ClaimWebhookDelivery", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testClaimWebhookDelivery(t, store)
+	})
+}
+
+func createTestBoardWebhook(t *testing.T, store store.Store, boardID string) *model.BoardWebhook {
+	webhook, err := store.CreateBoardWebhook(&model.BoardWebhook{
+		BoardID:   boardID,
+		URL:       "https://example.com/hook",
+		Events:    []model.WebhookEvent{model.WebhookEventCardCreated},
+		Enabled:   true,
+		CreatedBy: testUserID,
+	})
+	require.NoError(t, err)
+	return webhook
+}
+
+func testCreateBoardWebhook(t *testing.T, store store.Store) {
+	t.Run("create and get", func(t *testing.T) {
+		webhook := createTestBoardWebhook(t, store, testBoardID)
+		require.NotEmpty(t, webhook.ID)
+		require.NotEmpty(t, webhook.Secret)
+
+		retrieved, err := store.GetBoardWebhook(webhook.ID)
+		require.NoError(t, err)
+		assert.Equal(t, webhook, retrieved)
+	})
+
+	t.Run("invalid webhook", func(t *testing.T) {
+		_, err := store.CreateBoardWebhook(&model.BoardWebhook{BoardID: testBoardID, URL: "ftp://example.com", CreatedBy: testUserID})
+		require.ErrorIs(t, err, model.ErrInvalidBoardWebhook)
+	})
+
+	t.Run("get the webhooks of a board", func(t *testing.T) {
+		boardID := utils.NewID(utils.IDTypeBoard)
+		first := createTestBoardWebhook(t, store, boardID)
+		second := createTestBoardWebhook(t, store, boardID)
+		createTestBoardWebhook(t, store, utils.NewID(utils.IDTypeBoard))
+
+		webhooks, err := store.GetBoardWebhooks(boardID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 2)
+		ids := []string{webhooks[0].ID, webhooks[1].ID}
+		assert.ElementsMatch(t, []string{first.ID, second.ID}, ids)
+	})
+
+	t.Run("not existing webhook", func(t *testing.T) {
+		_, err := store.GetBoardWebhook("nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testUpdateBoardWebhook(t *testing.T, store store.Store) {
+	webhook := createTestBoardWebhook(t, store, testBoardID)
+
+	t.Run("update", func(t *testing.T) {
+		url := "https://example.com/other"
+		events := []model.WebhookEvent{model.WebhookEventCommentAdded, model.WebhookEventStatusChanged}
+		enabled := false
+		_, err := store.UpdateBoardWebhook(webhook.Patch(&model.BoardWebhookPatch{URL: &url, Events: &events, Enabled: &enabled}))
+		require.NoError(t, err)
+
+		retrieved, err := store.GetBoardWebhook(webhook.ID)
+		require.NoError(t, err)
+		assert.Equal(t, url, retrieved.URL)
+		assert.Equal(t, events, retrieved.Events)
+		assert.False(t, retrieved.Enabled)
+		assert.Equal(t, webhook.Secret, retrieved.Secret)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		url := "http://127.0.0.1/hook"
+		_, err := store.UpdateBoardWebhook(webhook.Patch(&model.BoardWebhookPatch{URL: &url}))
+		require.ErrorIs(t, err, model.ErrInvalidBoardWebhook)
+	})
+
+	t.Run("not existing webhook", func(t *testing.T) {
+		missing := *webhook
+		missing.ID = utils.NewID(utils.IDTypeNone)
+		_, err := store.UpdateBoardWebhook(&missing)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testDeleteBoardWebhook(t *testing.T, store store.Store) {
+	webhook := createTestBoardWebhook(t, store, testBoardID)
+	delivery := model.NewWebhookDelivery(webhook, model.WebhookEventCardCreated, "{}")
+	require.NoError(t, store.InsertWebhookDeliveries([]*model.WebhookDelivery{delivery}))
+
+	require.NoError(t, store.DeleteBoardWebhook(webhook.ID))
+
+	_, err := store.GetBoardWebhook(webhook.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	// the delivery log goes with the webhook
+	deliveries, err := store.GetWebhookDeliveries(webhook.ID, 0, 0)
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+
+	require.True(t, model.IsErrNotFound(store.DeleteBoardWebhook(webhook.ID)))
+}
+
+func testWebhookDeliveries(t *testing.T, store store.Store) {
+	webhook := createTestBoardWebhook(t, store, testBoardID)
+	now := utils.GetMillis()
+
+	due := model.NewWebhookDelivery(webhook, model.WebhookEventCardCreated, `{"event":"card.created"}`)
+	due.NextAttemptAt = now - 1000
+	due.CreateAt = now - 3000
+	later := model.NewWebhookDelivery(webhook, model.WebhookEventCardUpdated, "{}")
+	later.NextAttemptAt = now + 60000
+	later.CreateAt = now - 2000
+	delivered := model.NewWebhookDelivery(webhook, model.WebhookEventCardDeleted, "{}")
+	delivered.Status = model.WebhookDeliveryStatusDelivered
+	delivered.NextAttemptAt = now - 2000
+	delivered.CreateAt = now - 1000
+	require.NoError(t, store.InsertWebhookDeliveries([]*model.WebhookDelivery{due, later, delivered}))
+
+	t.Run("get due deliveries", func(t *testing.T) {
+		deliveries, err := store.GetDueWebhookDeliveries(now, 10)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, due, deliveries[0])
+	})
+
+	t.Run("update delivery", func(t *testing.T) {
+		due.Status = model.WebhookDeliveryStatusFailed
+		due.Attempts = model.MaxWebhookDeliveryAttempts
+		due.LastAttemptAt = now
+		due.ResponseCode = 502
+		due.Error = "webhook responded with status 502"
+		require.NoError(t, store.UpdateWebhookDelivery(due))
+
+		deliveries, err := store.GetDueWebhookDeliveries(now, 10)
+		require.NoError(t, err)
+		require.Empty(t, deliveries)
+	})
+
+	t.Run("delivery log, newest first", func(t *testing.T) {
+		deliveries, err := store.GetWebhookDeliveries(webhook.ID, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 3)
+		assert.Equal(t, delivered.ID, deliveries[0].ID)
+		assert.Equal(t, later.ID, deliveries[1].ID)
+		assert.Equal(t, due, deliveries[2])
+
+		deliveries, err = store.GetWebhookDeliveries(webhook.ID, 1, 2)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, due.ID, deliveries[0].ID)
+	})
+}
+
+func testClaimWebhookDelivery(t *testing.T, store store.Store) {
+	webhook := createTestBoardWebhook(t, store, testBoardID)
+	now := utils.GetMillis()
+
+	delivery := model.NewWebhookDelivery(webhook, model.WebhookEventCardCreated, "{}")
+	delivery.NextAttemptAt = now - 1000
+	require.NoError(t, store.InsertWebhookDeliveries([]*model.WebhookDelivery{delivery}))
+
+	// two servers read the same due delivery
+	first := *delivery
+	second := *delivery
+
+	claimed, err := store.ClaimWebhookDelivery(&first, now+60000)
+	require.NoError(t, err)
+	require.True(t, claimed)
+	assert.Equal(t, now+60000, first.NextAttemptAt)
+
+	claimed, err = store.ClaimWebhookDelivery(&second, now+60000)
+	require.NoError(t, err)
+	require.False(t, claimed)
+
+	deliveries, err := store.GetDueWebhookDeliveries(now, 10)
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestCardCodeAliasStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("MoveCardToBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMoveCardToBoard(t, store)
+	})
+}
+
+func createTestCodeBoard(t *testing.T, store store.Store, code string) *model.Board {
+	board, err := store.InsertBoard(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+		Code:   code,
+	}, testUserID)
+	require.NoError(t, err)
+	return board
+}
+
+func testMoveCardToBoard(t *testing.T, store store.Store) {
+	source := createTestCodeBoard(t, store, "SRC")
+	target := createTestCodeBoard(t, store, "DST")
+
+	number, err := store.GetNextCardNumber(source.ID)
+	require.NoError(t, err)
+	card := &model.Block{
+		ID:      utils.NewID(utils.IDTypeCard),
+		BoardID: source.ID,
+		Type:    model.TypeCard,
+		Title:   "card",
+		Number:  number,
+	}
+	require.NoError(t, store.InsertBlock(card, testUserID))
+	child := &model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  source.ID,
+		ParentID: card.ID,
+		Type:     model.TypeText,
+		Title:    "content",
+	}
+	require.NoError(t, store.InsertBlock(child, testUserID))
+	previousCode := fmt.Sprintf("%s-%d", source.Code, number)
+
+	t.Run("get a card by its code", func(t *testing.T) {
+		rCard, rBoard, err := store.GetCardByCode(previousCode)
+		require.NoError(t, err)
+		assert.Equal(t, card.ID, rCard.ID)
+		assert.Equal(t, source.ID, rBoard.ID)
+	})
+
+	newNumber, err := store.GetNextCardNumber(target.ID)
+	require.NoError(t, err)
+	moved := *card
+	moved.Number = newNumber
+	require.NoError(t, store.MoveCardToBoard(&moved, target.ID, testUserID))
+
+	t.Run("the card and its content move", func(t *testing.T) {
+		rCard, err := store.GetBlock(card.ID)
+		require.NoError(t, err)
+		assert.Equal(t, target.ID, rCard.BoardID)
+		assert.Equal(t, newNumber, rCard.Number)
+
+		children, err := store.GetBlocksWithParent(target.ID, card.ID)
+		require.NoError(t, err)
+		require.Len(t, children, 1)
+		assert.Equal(t, child.ID, children[0].ID)
+
+		children, err = store.GetBlocksWithParent(source.ID, card.ID)
+		require.NoError(t, err)
+		require.Empty(t, children)
+	})
+
+	t.Run("get the card by its new code", func(t *testing.T) {
+		rCard, rBoard, err := store.GetCardByCode(fmt.Sprintf("%s-%d", target.Code, newNumber))
+		require.NoError(t, err)
+		assert.Equal(t, card.ID, rCard.ID)
+		assert.Equal(t, target.ID, rBoard.ID)
+	})
+
+	t.Run("get the card by its previous code", func(t *testing.T) {
+		rCard, rBoard, err := store.GetCardByCode(previousCode)
+		require.NoError(t, err)
+		assert.Equal(t, card.ID, rCard.ID)
+		assert.Equal(t, target.ID, rBoard.ID)
+	})
+
+	t.Run("not existing code", func(t *testing.T) {
+		_, _, err := store.GetCardByCode(fmt.Sprintf("%s-%d", source.Code, newNumber+1000))
+		require.True(t, model.IsErrNotFound(err))
+
+		_, _, err = store.GetCardByCode("invalid")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("deleted card", func(t *testing.T) {
+		require.NoError(t, store.DeleteBlock(card.ID, testUserID))
+
+		_, _, err := store.GetCardByCode(previousCode)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
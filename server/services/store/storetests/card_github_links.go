@@ -0,0 +1,202 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestCardGitHubLinkStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateCardGitHubLink", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateCardGitHubLink(t, store)
+	})
+	t.Run("GetCardGitHubLinksToSync", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCardGitHubLinksToSync(t, store)
+	})
+	t.Run("UpdateCardGitHubLinkSync", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateCardGitHubLinkSync(t, store)
+	})
+	t.Run("DeleteCardGitHubLink", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCardGitHubLink(t, store)
+	})
+}
+
+func createTestCardGitHubLink(t *testing.T, store store.Store, cardID string, url string, syncAttemptAt int64) *model.CardGitHubLink {
+	link, err := store.CreateCardGitHubLink(&model.CardGitHubLink{
+		BoardID:       testBoardID,
+		CardID:        cardID,
+		URL:           url,
+		SyncAttemptAt: syncAttemptAt,
+		CreatedBy:     testUserID,
+	})
+	require.NoError(t, err)
+	return link
+}
+
+func testCreateCardGitHubLink(t *testing.T, store store.Store) {
+	cardID := utils.NewID(utils.IDTypeCard)
+
+	t.Run("create and get", func(t *testing.T) {
+		link := createTestCardGitHubLink(t, store, cardID, "https://github.com/owner/repo/issues/12", 0)
+		require.NotEmpty(t, link.ID)
+		assert.Equal(t, model.TrackerProviderGitHub, link.Provider)
+		assert.Equal(t, model.CardGitHubLinkKindIssue, link.Kind)
+		assert.Equal(t, "owner", link.Owner)
+		assert.Equal(t, "repo", link.Repo)
+		assert.Equal(t, 12, link.Number)
+
+		retrieved, err := store.GetCardGitHubLink(link.ID)
+		require.NoError(t, err)
+		assert.Equal(t, link, retrieved)
+	})
+
+	t.Run("issue of another tracker", func(t *testing.T) {
+		link, err := store.CreateCardGitHubLink(&model.CardGitHubLink{
+			BoardID:   testBoardID,
+			CardID:    utils.NewID(utils.IDTypeCard),
+			Provider:  model.TrackerProviderJira,
+			Kind:      model.CardGitHubLinkKindIssue,
+			Project:   "PROJ",
+			Number:    7,
+			CreatedBy: testUserID,
+		})
+		require.NoError(t, err)
+
+		retrieved, err := store.GetCardGitHubLink(link.ID)
+		require.NoError(t, err)
+		assert.Equal(t, link, retrieved)
+	})
+
+	t.Run("invalid link", func(t *testing.T) {
+		_, err := store.CreateCardGitHubLink(&model.CardGitHubLink{
+			BoardID:   testBoardID,
+			CardID:    cardID,
+			URL:       "https://example.com/owner/repo/issues/12",
+			CreatedBy: testUserID,
+		})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("get the links of a card", func(t *testing.T) {
+		otherCardID := utils.NewID(utils.IDTypeCard)
+		first := createTestCardGitHubLink(t, store, otherCardID, "https://github.com/owner/repo/issues/1", 0)
+		second := createTestCardGitHubLink(t, store, otherCardID, "https://github.com/owner/repo/pull/2", 0)
+
+		links, err := store.GetCardGitHubLinks(otherCardID)
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+		ids := []string{links[0].ID, links[1].ID}
+		assert.ElementsMatch(t, []string{first.ID, second.ID}, ids)
+	})
+
+	t.Run("not existing link", func(t *testing.T) {
+		_, err := store.GetCardGitHubLink("nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testGetCardGitHubLinksToSync(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	cardID := utils.NewID(utils.IDTypeCard)
+
+	later := createTestCardGitHubLink(t, store, cardID, "https://github.com/owner/repo/issues/1", now-1000)
+	earlier := createTestCardGitHubLink(t, store, cardID, "https://github.com/owner/repo/pull/2", now-2000)
+	createTestCardGitHubLink(t, store, cardID, "https://github.com/owner/repo/issues/3", now)
+	// commits do not change, so they are never synchronized
+	_, err := store.CreateCardGitHubLink(&model.CardGitHubLink{
+		BoardID:   testBoardID,
+		CardID:    cardID,
+		Kind:      model.CardGitHubLinkKindCommit,
+		Owner:     "owner",
+		Repo:      "repo",
+		SHA:       "0123456789abcdef",
+		CreatedBy: testUserID,
+	})
+	require.NoError(t, err)
+
+	t.Run("least recently attempted first", func(t *testing.T) {
+		links, err := store.GetCardGitHubLinksToSync(now, 10)
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+		assert.Equal(t, earlier.ID, links[0].ID)
+		assert.Equal(t, later.ID, links[1].ID)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		links, err := store.GetCardGitHubLinksToSync(now, 1)
+		require.NoError(t, err)
+		require.Len(t, links, 1)
+		assert.Equal(t, earlier.ID, links[0].ID)
+	})
+}
+
+func testUpdateCardGitHubLinkSync(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	link := createTestCardGitHubLink(t, store, utils.NewID(utils.IDTypeCard), "https://github.com/owner/repo/pull/5", now-1000)
+
+	t.Run("synchronized", func(t *testing.T) {
+		link.Title = "Fix the build"
+		link.State = "closed"
+		link.Labels = []string{"bug"}
+		link.Assignees = []string{"octocat"}
+		link.Merged = true
+		link.SyncedAt = now
+		link.SyncAttemptAt = now
+		link.UpdateAt = now
+		require.NoError(t, store.UpdateCardGitHubLinkSync(link))
+
+		retrieved, err := store.GetCardGitHubLink(link.ID)
+		require.NoError(t, err)
+		assert.Equal(t, link, retrieved)
+
+		links, err := store.GetCardGitHubLinksToSync(now, 10)
+		require.NoError(t, err)
+		require.Empty(t, links)
+	})
+
+	t.Run("failed synchronization keeps the state", func(t *testing.T) {
+		link.SetSyncError(errors.New("rate limited"), now+1000)
+		require.NoError(t, store.UpdateCardGitHubLinkSync(link))
+
+		retrieved, err := store.GetCardGitHubLink(link.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "rate limited", retrieved.SyncError)
+		assert.Equal(t, now+1000, retrieved.SyncAttemptAt)
+		assert.Equal(t, now, retrieved.SyncedAt)
+		assert.Equal(t, "Fix the build", retrieved.Title)
+	})
+
+	t.Run("not existing link", func(t *testing.T) {
+		missing := *link
+		missing.ID = utils.NewID(utils.IDTypeNone)
+		require.True(t, model.IsErrNotFound(store.UpdateCardGitHubLinkSync(&missing)))
+	})
+}
+
+func testDeleteCardGitHubLink(t *testing.T, store store.Store) {
+	link := createTestCardGitHubLink(t, store, utils.NewID(utils.IDTypeCard), "https://github.com/owner/repo/issues/9", 0)
+
+	require.NoError(t, store.DeleteCardGitHubLink(link.ID))
+
+	_, err := store.GetCardGitHubLink(link.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	require.True(t, model.IsErrNotFound(store.DeleteCardGitHubLink(link.ID)))
+}
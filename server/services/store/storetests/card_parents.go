@@ -0,0 +1,125 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestCardParentStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SetCardParent", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSetCardParent(t, store)
+	})
+	t.Run("GetCardChildren", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetCardChildren(t, store)
+	})
+	t.Run("DeleteCardParent", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCardParent(t, store)
+	})
+}
+
+func setTestCardParent(t *testing.T, store store.Store, cardID string, parentCardID string, createAt int64) *model.CardParent {
+	parent, err := store.SetCardParent(&model.CardParent{
+		CardID:       cardID,
+		ParentCardID: parentCardID,
+		CreatedBy:    testUserID,
+		CreateAt:     createAt,
+	})
+	require.NoError(t, err)
+	return parent
+}
+
+func testSetCardParent(t *testing.T, store store.Store) {
+	cardID := utils.NewID(utils.IDTypeCard)
+
+	t.Run("set and get", func(t *testing.T) {
+		parent, err := store.SetCardParent(&model.CardParent{
+			CardID:       cardID,
+			ParentCardID: utils.NewID(utils.IDTypeCard),
+			CreatedBy:    testUserID,
+		})
+		require.NoError(t, err)
+		require.NotZero(t, parent.CreateAt)
+
+		retrieved, err := store.GetCardParent(cardID)
+		require.NoError(t, err)
+		assert.Equal(t, parent, retrieved)
+	})
+
+	t.Run("replace the parent of a card", func(t *testing.T) {
+		parent := setTestCardParent(t, store, cardID, utils.NewID(utils.IDTypeCard), 2000)
+		parent.CreatedBy = "other-user-id"
+		parent, err := store.SetCardParent(parent)
+		require.NoError(t, err)
+
+		retrieved, err := store.GetCardParent(cardID)
+		require.NoError(t, err)
+		assert.Equal(t, parent, retrieved)
+	})
+
+	t.Run("card as its own parent", func(t *testing.T) {
+		_, err := store.SetCardParent(&model.CardParent{CardID: cardID, ParentCardID: cardID, CreatedBy: testUserID})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("card without parent", func(t *testing.T) {
+		_, err := store.GetCardParent(utils.NewID(utils.IDTypeCard))
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testGetCardChildren(t *testing.T, store store.Store) {
+	parentCardID := utils.NewID(utils.IDTypeCard)
+	second := setTestCardParent(t, store, utils.NewID(utils.IDTypeCard), parentCardID, 2000)
+	first := setTestCardParent(t, store, utils.NewID(utils.IDTypeCard), parentCardID, 1000)
+	setTestCardParent(t, store, utils.NewID(utils.IDTypeCard), utils.NewID(utils.IDTypeCard), 1000)
+
+	t.Run("oldest first", func(t *testing.T) {
+		children, err := store.GetCardChildren(parentCardID)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.CardParent{first, second}, children)
+	})
+
+	t.Run("a child moved to another parent", func(t *testing.T) {
+		setTestCardParent(t, store, first.CardID, utils.NewID(utils.IDTypeCard), 3000)
+
+		children, err := store.GetCardChildren(parentCardID)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.CardParent{second}, children)
+	})
+
+	t.Run("card without children", func(t *testing.T) {
+		children, err := store.GetCardChildren(utils.NewID(utils.IDTypeCard))
+		require.NoError(t, err)
+		require.Empty(t, children)
+	})
+}
+
+func testDeleteCardParent(t *testing.T, store store.Store) {
+	parent := setTestCardParent(t, store, utils.NewID(utils.IDTypeCard), utils.NewID(utils.IDTypeCard), 1000)
+
+	require.NoError(t, store.DeleteCardParent(parent.CardID))
+
+	_, err := store.GetCardParent(parent.CardID)
+	require.True(t, model.IsErrNotFound(err))
+
+	children, err := store.GetCardChildren(parent.ParentCardID)
+	require.NoError(t, err)
+	require.Empty(t, children)
+
+	require.True(t, model.IsErrNotFound(store.DeleteCardParent(parent.CardID)))
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestCardRecurrenceStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("UpsertCardRecurrence", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpsertCardRecurrence(t, store)
+	})
+	t.Run("GetDueCardRecurrences", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDueCardRecurrences(t, store)
+	})
+	t.Run("UpdateCardRecurrenceNextAt", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateCardRecurrenceNextAt(t, store)
+	})
+	t.Run("DeleteCardRecurrence", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCardRecurrence(t, store)
+	})
+}
+
+func createTestCardRecurrence(t *testing.T, store store.Store, nextAt int64) *model.CardRecurrence {
+	recurrence, err := store.UpsertCardRecurrence(&model.CardRecurrence{
+		CardID:     utils.NewID(utils.IDTypeCard),
+		BoardID:    testBoardID,
+		Frequency:  model.RecurrenceDaily,
+		Interval:   1,
+		TimeZone:   "UTC",
+		NextAt:     nextAt,
+		ModifiedBy: testUserID,
+	})
+	require.NoError(t, err)
+	return recurrence
+}
+
+func testUpsertCardRecurrence(t *testing.T, store store.Store) {
+	t.Run("insert and get", func(t *testing.T) {
+		recurrence := createTestCardRecurrence(t, store, 1000)
+		assert.Equal(t, testUserID, recurrence.CreatedBy)
+		assert.NotZero(t, recurrence.CreateAt)
+		assert.Empty(t, recurrence.Weekdays)
+
+		retrieved, err := store.GetCardRecurrence(recurrence.CardID)
+		require.NoError(t, err)
+		assert.Equal(t, recurrence, retrieved)
+	})
+
+	t.Run("replace the recurrence of a card", func(t *testing.T) {
+		recurrence := createTestCardRecurrence(t, store, 1000)
+
+		updated, err := store.UpsertCardRecurrence(&model.CardRecurrence{
+			CardID:         recurrence.CardID,
+			BoardID:        recurrence.BoardID,
+			Frequency:      model.RecurrenceWeekly,
+			Interval:       2,
+			Weekdays:       []string{"MO", "FR"},
+			DatePropertyID: "due-date-property",
+			TimeZone:       "Europe/Paris",
+			StartAt:        500,
+			NextAt:         2000,
+			ModifiedBy:     "other-user-id",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, model.RecurrenceWeekly, updated.Frequency)
+		assert.Equal(t, 2, updated.Interval)
+		assert.Equal(t, []string{"MO", "FR"}, updated.Weekdays)
+		assert.Equal(t, "due-date-property", updated.DatePropertyID)
+		assert.Equal(t, "Europe/Paris", updated.TimeZone)
+		assert.Equal(t, int64(500), updated.StartAt)
+		assert.Equal(t, int64(2000), updated.NextAt)
+		assert.Equal(t, "other-user-id", updated.ModifiedBy)
+
+		// the creation is kept
+		assert.Equal(t, testUserID, updated.CreatedBy)
+		assert.Equal(t, recurrence.CreateAt, updated.CreateAt)
+	})
+
+	t.Run("invalid recurrence", func(t *testing.T) {
+		_, err := store.UpsertCardRecurrence(&model.CardRecurrence{
+			CardID:     utils.NewID(utils.IDTypeCard),
+			BoardID:    testBoardID,
+			Frequency:  model.RecurrenceWeekly,
+			Interval:   1,
+			TimeZone:   "UTC",
+			ModifiedBy: testUserID,
+		})
+		require.ErrorIs(t, err, model.ErrInvalidCardRecurrence)
+	})
+
+	t.Run("not existing recurrence", func(t *testing.T) {
+		_, err := store.GetCardRecurrence("nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testGetDueCardRecurrences(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	later := createTestCardRecurrence(t, store, now-1000)
+	earlier := createTestCardRecurrence(t, store, now-2000)
+	createTestCardRecurrence(t, store, now+60000)
+	// a recurrence that does not occur again is never due
+	createTestCardRecurrence(t, store, 0)
+
+	recurrences, err := store.GetDueCardRecurrences(now)
+	require.NoError(t, err)
+	require.Len(t, recurrences, 2)
+	assert.Equal(t, earlier.CardID, recurrences[0].CardID)
+	assert.Equal(t, later.CardID, recurrences[1].CardID)
+}
+
+func testUpdateCardRecurrenceNextAt(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	recurrence := createTestCardRecurrence(t, store, now-1000)
+
+	// two servers read the same due occurrence
+	moved, err := store.UpdateCardRecurrenceNextAt(recurrence.CardID, recurrence.NextAt, now+60000)
+	require.NoError(t, err)
+	require.True(t, moved)
+
+	moved, err = store.UpdateCardRecurrenceNextAt(recurrence.CardID, recurrence.NextAt, now+60000)
+	require.NoError(t, err)
+	require.False(t, moved)
+
+	retrieved, err := store.GetCardRecurrence(recurrence.CardID)
+	require.NoError(t, err)
+	assert.Equal(t, now+60000, retrieved.NextAt)
+
+	recurrences, err := store.GetDueCardRecurrences(now)
+	require.NoError(t, err)
+	require.Empty(t, recurrences)
+}
+
+func testDeleteCardRecurrence(t *testing.T, store store.Store) {
+	recurrence := createTestCardRecurrence(t, store, 1000)
+
+	require.NoError(t, store.DeleteCardRecurrence(recurrence.CardID))
+
+	_, err := store.GetCardRecurrence(recurrence.CardID)
+	require.True(t, model.IsErrNotFound(err))
+
+	require.True(t, model.IsErrNotFound(store.DeleteCardRecurrence(recurrence.CardID)))
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestDueDateNotificationStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("ClaimDueDateNotification", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testClaimDueDateNotification(t, store)
+	})
+	t.Run("DeleteDueDateNotificationsBefore", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteDueDateNotificationsBefore(t, store)
+	})
+}
+
+func testClaimDueDateNotification(t *testing.T, store store.Store) {
+	cardID := utils.NewID(utils.IDTypeCard)
+	reminder := &model.DueDateNotification{
+		Kind:   model.DueDateNotificationReminder,
+		UserID: testUserID,
+		CardID: cardID,
+		Key:    "due-date-property:1700000000000",
+	}
+
+	t.Run("claim once", func(t *testing.T) {
+		claimed, err := store.ClaimDueDateNotification(reminder)
+		require.NoError(t, err)
+		require.True(t, claimed)
+		require.NotZero(t, reminder.CreateAt)
+
+		// another server sends the same reminder
+		duplicate := *reminder
+		claimed, err = store.ClaimDueDateNotification(&duplicate)
+		require.NoError(t, err)
+		require.False(t, claimed)
+	})
+
+	t.Run("other notifications are claimed separately", func(t *testing.T) {
+		others := []model.DueDateNotification{
+			{Kind: model.DueDateNotificationOverdueDigest, UserID: testUserID, CardID: cardID, Key: reminder.Key},
+			{Kind: model.DueDateNotificationReminder, UserID: "other-user-id", CardID: cardID, Key: reminder.Key},
+			{Kind: model.DueDateNotificationReminder, UserID: testUserID, CardID: utils.NewID(utils.IDTypeCard), Key: reminder.Key},
+			{Kind: model.DueDateNotificationReminder, UserID: testUserID, CardID: cardID, Key: "due-date-property:1800000000000"},
+		}
+		for i := range others {
+			claimed, err := store.ClaimDueDateNotification(&others[i])
+			require.NoError(t, err)
+			require.True(t, claimed)
+		}
+	})
+}
+
+func testDeleteDueDateNotificationsBefore(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	old := &model.DueDateNotification{
+		Kind:     model.DueDateNotificationOverdueDigest,
+		UserID:   testUserID,
+		Key:      "2024-01-01",
+		CreateAt: now - 10000,
+	}
+	recent := &model.DueDateNotification{
+		Kind:     model.DueDateNotificationOverdueDigest,
+		UserID:   testUserID,
+		Key:      "2024-01-02",
+		CreateAt: now,
+	}
+	for _, notification := range []*model.DueDateNotification{old, recent} {
+		claimed, err := store.ClaimDueDateNotification(notification)
+		require.NoError(t, err)
+		require.True(t, claimed)
+	}
+
+	require.NoError(t, store.DeleteDueDateNotificationsBefore(now-5000))
+
+	// the deleted notification can be claimed again, the recent one cannot
+	claimed, err := store.ClaimDueDateNotification(old)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = store.ClaimDueDateNotification(recent)
+	require.NoError(t, err)
+	require.False(t, claimed)
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestGitHubIssueSyncStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveGitHubIssueSync", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveGitHubIssueSync(t, store)
+	})
+	t.Run("GetGitHubIssueSyncsToRun", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetGitHubIssueSyncsToRun(t, store)
+	})
+	t.Run("GitHubIssueSyncItems", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGitHubIssueSyncItems(t, store)
+	})
+	t.Run("DeleteGitHubIssueSync", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteGitHubIssueSync(t, store)
+	})
+}
+
+func createTestGitHubIssueSync(t *testing.T, store store.Store, boardID string, enabled bool, syncAttemptAt int64) *model.GitHubIssueSync {
+	sync := &model.GitHubIssueSync{
+		BoardID:   boardID,
+		Owner:     "owner",
+		Repo:      "repo",
+		Enabled:   enabled,
+		CreatedBy: testUserID,
+	}
+	require.NoError(t, store.SaveGitHubIssueSync(sync))
+	if syncAttemptAt != 0 {
+		sync.SyncAttemptAt = syncAttemptAt
+		require.NoError(t, store.UpdateGitHubIssueSyncState(sync))
+	}
+	return sync
+}
+
+func testSaveGitHubIssueSync(t *testing.T, store store.Store) {
+	t.Run("create and get", func(t *testing.T) {
+		sync := &model.GitHubIssueSync{
+			BoardID:              testBoardID,
+			Owner:                "owner",
+			Repo:                 "repo",
+			Enabled:              true,
+			OpenStatusOptionID:   "opt-open",
+			ClosedStatusOptionID: "opt-closed",
+			LabelPropertyID:      "labels",
+			LabelMappings:        []model.GitHubLabelMapping{{Label: "bug", OptionID: "opt-bug"}},
+			AssigneePropertyID:   "assignee",
+			UserMappings:         []model.GitHubUserMapping{{GitHubLogin: "octocat", UserID: testUserID}},
+			CreatedBy:            testUserID,
+		}
+		require.NoError(t, store.SaveGitHubIssueSync(sync))
+
+		retrieved, err := store.GetGitHubIssueSync(testBoardID)
+		require.NoError(t, err)
+		assert.Equal(t, sync, retrieved)
+	})
+
+	t.Run("update the settings and keep the state", func(t *testing.T) {
+		sync, err := store.GetGitHubIssueSync(testBoardID)
+		require.NoError(t, err)
+		sync.IssuesSyncedAt = 1000
+		sync.SyncedAt = 2000
+		sync.SyncAttemptAt = 2000
+		sync.ConflictCount = 1
+		sync.LastConflictAt = 1500
+		require.NoError(t, store.UpdateGitHubIssueSyncState(sync))
+
+		settings := &model.GitHubIssueSync{
+			BoardID:   testBoardID,
+			Owner:     "other-owner",
+			Repo:      "other-repo",
+			CreatedBy: "other-user-id",
+		}
+		require.NoError(t, store.SaveGitHubIssueSync(settings))
+
+		retrieved, err := store.GetGitHubIssueSync(testBoardID)
+		require.NoError(t, err)
+		assert.Equal(t, "other-owner", retrieved.Owner)
+		assert.Equal(t, "other-repo", retrieved.Repo)
+		assert.False(t, retrieved.Enabled)
+		assert.Empty(t, retrieved.LabelMappings)
+		assert.Empty(t, retrieved.UserMappings)
+		assert.Equal(t, int64(1000), retrieved.IssuesSyncedAt)
+		assert.Equal(t, int64(2000), retrieved.SyncedAt)
+		assert.Equal(t, int64(1), retrieved.ConflictCount)
+		assert.Equal(t, int64(1500), retrieved.LastConflictAt)
+	})
+
+	t.Run("failed synchronization", func(t *testing.T) {
+		sync, err := store.GetGitHubIssueSync(testBoardID)
+		require.NoError(t, err)
+		sync.SetSyncError(errors.New("rate limited"), 3000)
+		require.NoError(t, store.UpdateGitHubIssueSyncState(sync))
+
+		retrieved, err := store.GetGitHubIssueSync(testBoardID)
+		require.NoError(t, err)
+		assert.Equal(t, "rate limited", retrieved.SyncError)
+		assert.Equal(t, int64(3000), retrieved.SyncAttemptAt)
+		assert.Equal(t, int64(2000), retrieved.SyncedAt)
+	})
+
+	t.Run("invalid synchronization", func(t *testing.T) {
+		err := store.SaveGitHubIssueSync(&model.GitHubIssueSync{BoardID: testBoardID, Owner: "owner/repo", CreatedBy: testUserID})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("not existing synchronization", func(t *testing.T) {
+		_, err := store.GetGitHubIssueSync(utils.NewID(utils.IDTypeBoard))
+		require.True(t, model.IsErrNotFound(err))
+
+		missing := &model.GitHubIssueSync{BoardID: utils.NewID(utils.IDTypeBoard)}
+		require.True(t, model.IsErrNotFound(store.UpdateGitHubIssueSyncState(missing)))
+	})
+}
+
+func testGetGitHubIssueSyncsToRun(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+	later := createTestGitHubIssueSync(t, store, utils.NewID(utils.IDTypeBoard), true, now-1000)
+	earlier := createTestGitHubIssueSync(t, store, utils.NewID(utils.IDTypeBoard), true, now-2000)
+	createTestGitHubIssueSync(t, store, utils.NewID(utils.IDTypeBoard), true, now)
+	createTestGitHubIssueSync(t, store, utils.NewID(utils.IDTypeBoard), false, now-3000)
+
+	t.Run("least recently attempted first", func(t *testing.T) {
+		syncs, err := store.GetGitHubIssueSyncsToRun(now, 10)
+		require.NoError(t, err)
+		require.Len(t, syncs, 2)
+		assert.Equal(t, earlier.BoardID, syncs[0].BoardID)
+		assert.Equal(t, later.BoardID, syncs[1].BoardID)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		syncs, err := store.GetGitHubIssueSyncsToRun(now, 1)
+		require.NoError(t, err)
+		require.Len(t, syncs, 1)
+		assert.Equal(t, earlier.BoardID, syncs[0].BoardID)
+	})
+}
+
+func testGitHubIssueSyncItems(t *testing.T, store store.Store) {
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+	second := &model.GitHubIssueSyncItem{CardID: utils.NewID(utils.IDTypeCard), BoardID: testBoardID, Number: 2, CardUpdateAt: 1000, IssueUpdateAt: 1000, SyncedAt: 1000}
+	first := &model.GitHubIssueSyncItem{CardID: utils.NewID(utils.IDTypeCard), BoardID: testBoardID, Number: 1, DescriptionBlockID: utils.NewID(utils.IDTypeBlock), SyncedAt: 1000}
+	other := &model.GitHubIssueSyncItem{CardID: utils.NewID(utils.IDTypeCard), BoardID: otherBoardID, Number: 1}
+	for _, item := range []*model.GitHubIssueSyncItem{second, first, other} {
+		require.NoError(t, store.SaveGitHubIssueSyncItem(item))
+	}
+
+	t.Run("by issue number", func(t *testing.T) {
+		items, err := store.GetGitHubIssueSyncItems(testBoardID)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.GitHubIssueSyncItem{first, second}, items)
+	})
+
+	t.Run("update an item", func(t *testing.T) {
+		second.CardUpdateAt = 2000
+		second.IssueUpdateAt = 3000
+		second.SyncedAt = 3000
+		require.NoError(t, store.SaveGitHubIssueSyncItem(second))
+
+		items, err := store.GetGitHubIssueSyncItems(testBoardID)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.GitHubIssueSyncItem{first, second}, items)
+	})
+
+	t.Run("delete an item", func(t *testing.T) {
+		require.NoError(t, store.DeleteGitHubIssueSyncItem(first.CardID))
+
+		items, err := store.GetGitHubIssueSyncItems(testBoardID)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.GitHubIssueSyncItem{second}, items)
+	})
+}
+
+func testDeleteGitHubIssueSync(t *testing.T, store store.Store) {
+	t.Run("delete the synchronization and its items", func(t *testing.T) {
+		createTestGitHubIssueSync(t, store, testBoardID, true, 0)
+		require.NoError(t, store.SaveGitHubIssueSyncItem(&model.GitHubIssueSyncItem{CardID: utils.NewID(utils.IDTypeCard), BoardID: testBoardID, Number: 1}))
+
+		require.NoError(t, store.DeleteGitHubIssueSync(testBoardID))
+
+		_, err := store.GetGitHubIssueSync(testBoardID)
+		require.True(t, model.IsErrNotFound(err))
+
+		items, err := store.GetGitHubIssueSyncItems(testBoardID)
+		require.NoError(t, err)
+		require.Empty(t, items)
+	})
+
+	t.Run("the synchronization goes with its board", func(t *testing.T) {
+		board, err := store.InsertBoard(&model.Board{
+			ID:     utils.NewID(utils.IDTypeBoard),
+			TeamID: testTeamID,
+			Type:   model.BoardTypeOpen,
+		}, testUserID)
+		require.NoError(t, err)
+		createTestGitHubIssueSync(t, store, board.ID, true, 0)
+
+		require.NoError(t, store.DeleteBoard(board.ID, testUserID))
+
+		_, err = store.GetGitHubIssueSync(board.ID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestGitHubStatusRuleStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("ReplaceGitHubStatusRules", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testReplaceGitHubStatusRules(t, store)
+	})
+	t.Run("DeleteBoardGitHubStatusRules", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBoardGitHubStatusRules(t, store)
+	})
+}
+
+func testReplaceGitHubStatusRules(t *testing.T, store store.Store) {
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+	otherRules := []*model.GitHubStatusRule{
+		{BoardID: otherBoardID, Event: model.GitHubEventPRMerged, StatusOptionID: "opt-done"},
+	}
+	require.NoError(t, store.ReplaceGitHubStatusRules(otherBoardID, otherRules))
+
+	t.Run("no rules", func(t *testing.T) {
+		rules, err := store.GetGitHubStatusRules(testBoardID)
+		require.NoError(t, err)
+		require.Empty(t, rules)
+	})
+
+	t.Run("set the rules of a board", func(t *testing.T) {
+		rules := []*model.GitHubStatusRule{
+			{BoardID: testBoardID, Event: model.GitHubEventBranchCreated, StatusOptionID: "opt-doing", CreateAt: 1000},
+			{BoardID: testBoardID, Event: model.GitHubEventPRMerged, StatusOptionID: "opt-done", CreateAt: 2000},
+		}
+		require.NoError(t, store.ReplaceGitHubStatusRules(testBoardID, rules))
+		require.NotEmpty(t, rules[0].ID)
+
+		retrieved, err := store.GetGitHubStatusRules(testBoardID)
+		require.NoError(t, err)
+		assert.Equal(t, rules, retrieved)
+	})
+
+	t.Run("replace the rules of a board", func(t *testing.T) {
+		rules := []*model.GitHubStatusRule{
+			{BoardID: testBoardID, Event: model.GitHubEventPROpened, StatusOptionID: "opt-review"},
+		}
+		require.NoError(t, store.ReplaceGitHubStatusRules(testBoardID, rules))
+
+		retrieved, err := store.GetGitHubStatusRules(testBoardID)
+		require.NoError(t, err)
+		assert.Equal(t, rules, retrieved)
+
+		// the rules of other boards are kept
+		retrieved, err = store.GetGitHubStatusRules(otherBoardID)
+		require.NoError(t, err)
+		assert.Equal(t, otherRules, retrieved)
+	})
+
+	t.Run("invalid rule keeps the previous rules", func(t *testing.T) {
+		err := store.ReplaceGitHubStatusRules(testBoardID, []*model.GitHubStatusRule{
+			{BoardID: testBoardID, Event: "pr_reopened", StatusOptionID: "opt-doing"},
+		})
+		require.True(t, model.IsErrBadRequest(err))
+
+		err = store.ReplaceGitHubStatusRules(testBoardID, []*model.GitHubStatusRule{
+			{BoardID: otherBoardID, Event: model.GitHubEventPROpened, StatusOptionID: "opt-doing"},
+		})
+		require.True(t, model.IsErrBadRequest(err))
+
+		retrieved, err := store.GetGitHubStatusRules(testBoardID)
+		require.NoError(t, err)
+		require.Len(t, retrieved, 1)
+		assert.Equal(t, model.GitHubEventPROpened, retrieved[0].Event)
+	})
+
+	t.Run("remove the rules of a board", func(t *testing.T) {
+		require.NoError(t, store.ReplaceGitHubStatusRules(testBoardID, []*model.GitHubStatusRule{}))
+
+		retrieved, err := store.GetGitHubStatusRules(testBoardID)
+		require.NoError(t, err)
+		require.Empty(t, retrieved)
+	})
+}
+
+func testDeleteBoardGitHubStatusRules(t *testing.T, store store.Store) {
+	board, err := store.InsertBoard(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+	}, testUserID)
+	require.NoError(t, err)
+	require.NoError(t, store.ReplaceGitHubStatusRules(board.ID, []*model.GitHubStatusRule{
+		{BoardID: board.ID, Event: model.GitHubEventPRMerged, StatusOptionID: "opt-done"},
+	}))
+
+	require.NoError(t, store.DeleteBoard(board.ID, testUserID))
+
+	rules, err := store.GetGitHubStatusRules(board.ID)
+	require.NoError(t, err)
+	require.Empty(t, rules)
+}
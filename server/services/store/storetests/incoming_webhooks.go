@@ -0,0 +1,137 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestIncomingWebhookStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateIncomingWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateIncomingWebhook(t, store)
+	})
+	t.Run("UpdateIncomingWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateIncomingWebhook(t, store)
+	})
+	t.Run("DeleteIncomingWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteIncomingWebhook(t, store)
+	})
+}
+
+func createTestIncomingWebhook(t *testing.T, store store.Store, boardID string) *model.IncomingWebhook {
+	webhook, err := store.CreateIncomingWebhook(&model.IncomingWebhook{
+		BoardID:      boardID,
+		Title:        "Alerts",
+		FieldMapping: map[string]string{"alert.name": "title", "alert.severity": "Priority"},
+		Enabled:      true,
+		CreatedBy:    testUserID,
+	})
+	require.NoError(t, err)
+	return webhook
+}
+
+func testCreateIncomingWebhook(t *testing.T, store store.Store) {
+	t.Run("create and get", func(t *testing.T) {
+		webhook := createTestIncomingWebhook(t, store, testBoardID)
+		require.NotEmpty(t, webhook.ID)
+		require.NotEmpty(t, webhook.Token)
+
+		retrieved, err := store.GetIncomingWebhook(webhook.ID)
+		require.NoError(t, err)
+		assert.Equal(t, webhook, retrieved)
+
+		retrieved, err = store.GetIncomingWebhookByToken(webhook.Token)
+		require.NoError(t, err)
+		assert.Equal(t, webhook, retrieved)
+	})
+
+	t.Run("invalid webhook", func(t *testing.T) {
+		_, err := store.CreateIncomingWebhook(&model.IncomingWebhook{BoardID: testBoardID})
+		require.ErrorIs(t, err, model.ErrInvalidIncomingWebhook)
+	})
+
+	t.Run("get the webhooks of a board", func(t *testing.T) {
+		boardID := utils.NewID(utils.IDTypeBoard)
+		first := createTestIncomingWebhook(t, store, boardID)
+		second := createTestIncomingWebhook(t, store, boardID)
+		createTestIncomingWebhook(t, store, utils.NewID(utils.IDTypeBoard))
+
+		webhooks, err := store.GetIncomingWebhooks(boardID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 2)
+		ids := []string{webhooks[0].ID, webhooks[1].ID}
+		assert.ElementsMatch(t, []string{first.ID, second.ID}, ids)
+	})
+
+	t.Run("not existing webhook", func(t *testing.T) {
+		_, err := store.GetIncomingWebhook("nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+
+		_, err = store.GetIncomingWebhookByToken("nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testUpdateIncomingWebhook(t *testing.T, store store.Store) {
+	webhook := createTestIncomingWebhook(t, store, testBoardID)
+
+	t.Run("update and regenerate the token", func(t *testing.T) {
+		title := "Incidents"
+		mapping := map[string]string{"incident.title": "title"}
+		enabled := false
+		updated, err := store.UpdateIncomingWebhook(webhook.Patch(&model.IncomingWebhookPatch{
+			Title:           &title,
+			FieldMapping:    &mapping,
+			Enabled:         &enabled,
+			RegenerateToken: true,
+		}))
+		require.NoError(t, err)
+		require.NotEqual(t, webhook.Token, updated.Token)
+
+		retrieved, err := store.GetIncomingWebhook(webhook.ID)
+		require.NoError(t, err)
+		assert.Equal(t, updated, retrieved)
+
+		// the previous token no longer finds the webhook
+		_, err = store.GetIncomingWebhookByToken(webhook.Token)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("invalid field mapping", func(t *testing.T) {
+		mapping := map[string]string{"alert.name": " "}
+		_, err := store.UpdateIncomingWebhook(webhook.Patch(&model.IncomingWebhookPatch{FieldMapping: &mapping}))
+		require.ErrorIs(t, err, model.ErrInvalidIncomingWebhook)
+	})
+
+	t.Run("not existing webhook", func(t *testing.T) {
+		missing := *webhook
+		missing.ID = utils.NewID(utils.IDTypeNone)
+		_, err := store.UpdateIncomingWebhook(&missing)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testDeleteIncomingWebhook(t *testing.T, store store.Store) {
+	webhook := createTestIncomingWebhook(t, store, testBoardID)
+
+	require.NoError(t, store.DeleteIncomingWebhook(webhook.ID))
+
+	_, err := store.GetIncomingWebhook(webhook.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	require.True(t, model.IsErrNotFound(store.DeleteIncomingWebhook(webhook.ID)))
+}
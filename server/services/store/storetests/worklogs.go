@@ -0,0 +1,266 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/store"
+	"github.com/mattermost/mattermost-plugin-boards/server/utils"
+)
+
+func StoreTestWorklogStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateWorklog", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateWorklog(t, store)
+	})
+	t.Run("GetWorklogs", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetWorklogs(t, store)
+	})
+	t.Run("UpdateWorklog", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateWorklog(t, store)
+	})
+	t.Run("DeleteWorklog", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteWorklog(t, store)
+	})
+	t.Run("GetWorklogsComplianceHistory", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetWorklogsComplianceHistory(t, store)
+	})
+	t.Run("MoveWorklogsWithCard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMoveWorklogsWithCard(t, store)
+	})
+}
+
+func createTestWorklog(t *testing.T, store store.Store, boardID, cardID, userID string, minutes, date int64) *model.Worklog {
+	worklog, err := store.CreateWorklog(&model.Worklog{
+		BoardID: boardID,
+		CardID:  cardID,
+		UserID:  userID,
+		Minutes: minutes,
+		Date:    date,
+	})
+	require.NoError(t, err)
+	return worklog
+}
+
+func testCreateWorklog(t *testing.T, store store.Store) {
+	t.Run("create and get", func(t *testing.T) {
+		worklog, err := store.CreateWorklog(&model.Worklog{
+			BoardID: testBoardID,
+			CardID:  utils.NewID(utils.IDTypeCard),
+			UserID:  testUserID,
+			Minutes: 90,
+			Note:    "code review",
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, worklog.ID)
+		// an entry without a date is dated when it is created
+		assert.Equal(t, worklog.CreateAt, worklog.Date)
+
+		retrieved, err := store.GetWorklog(worklog.ID)
+		require.NoError(t, err)
+		assert.Equal(t, worklog, retrieved)
+	})
+
+	t.Run("invalid worklog", func(t *testing.T) {
+		_, err := store.CreateWorklog(&model.Worklog{
+			BoardID: testBoardID,
+			CardID:  utils.NewID(utils.IDTypeCard),
+			UserID:  testUserID,
+		})
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("not existing worklog", func(t *testing.T) {
+		_, err := store.GetWorklog("nonexistent")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testGetWorklogs(t *testing.T, store store.Store) {
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+	cardID := utils.NewID(utils.IDTypeCard)
+	otherCardID := utils.NewID(utils.IDTypeCard)
+
+	second := createTestWorklog(t, store, testBoardID, cardID, testUserID, 30, 2000)
+	first := createTestWorklog(t, store, testBoardID, cardID, testUserID, 60, 1000)
+	other := createTestWorklog(t, store, testBoardID, otherCardID, "other-user-id", 15, 3000)
+	elsewhere := createTestWorklog(t, store, otherBoardID, utils.NewID(utils.IDTypeCard), testUserID, 45, 1500)
+
+	ids := func(worklogs []*model.Worklog) []string {
+		result := make([]string, 0, len(worklogs))
+		for _, worklog := range worklogs {
+			result = append(result, worklog.ID)
+		}
+		return result
+	}
+
+	testCases := []struct {
+		name     string
+		opts     model.QueryWorklogsOptions
+		expected []string
+	}{
+		{"by board, oldest first", model.QueryWorklogsOptions{BoardIDs: []string{testBoardID}}, []string{first.ID, second.ID, other.ID}},
+		{"by boards", model.QueryWorklogsOptions{BoardIDs: []string{testBoardID, otherBoardID}}, []string{first.ID, elsewhere.ID, second.ID, other.ID}},
+		{"by card", model.QueryWorklogsOptions{CardID: cardID}, []string{first.ID, second.ID}},
+		{"by user", model.QueryWorklogsOptions{BoardIDs: []string{testBoardID}, UserID: "other-user-id"}, []string{other.ID}},
+		{"by dates", model.QueryWorklogsOptions{From: 1500, To: 3000}, []string{elsewhere.ID, second.ID}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			worklogs, err := store.GetWorklogs(tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ids(worklogs))
+		})
+	}
+
+	t.Run("totals by card", func(t *testing.T) {
+		totals, err := store.GetWorklogTotals([]string{cardID, otherCardID, utils.NewID(utils.IDTypeCard)})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int64{cardID: 90, otherCardID: 15}, totals)
+
+		totals, err = store.GetWorklogTotals(nil)
+		require.NoError(t, err)
+		assert.Empty(t, totals)
+	})
+}
+
+func testUpdateWorklog(t *testing.T, store store.Store) {
+	worklog := createTestWorklog(t, store, testBoardID, utils.NewID(utils.IDTypeCard), testUserID, 60, 1000)
+
+	t.Run("update", func(t *testing.T) {
+		updated := *worklog
+		updated.Minutes = 120
+		updated.Date = 2000
+		updated.Note = "pairing"
+		_, err := store.UpdateWorklog(&updated)
+		require.NoError(t, err)
+
+		retrieved, err := store.GetWorklog(worklog.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(120), retrieved.Minutes)
+		assert.Equal(t, int64(2000), retrieved.Date)
+		assert.Equal(t, "pairing", retrieved.Note)
+		assert.Equal(t, worklog.CardID, retrieved.CardID)
+	})
+
+	t.Run("invalid minutes", func(t *testing.T) {
+		updated := *worklog
+		updated.Minutes = model.WorklogMaxMinutes + 1
+		_, err := store.UpdateWorklog(&updated)
+		require.True(t, model.IsErrBadRequest(err))
+	})
+
+	t.Run("not existing worklog", func(t *testing.T) {
+		missing := *worklog
+		missing.ID = utils.NewID(utils.IDTypeNone)
+		_, err := store.UpdateWorklog(&missing)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testDeleteWorklog(t *testing.T, store store.Store) {
+	cardID := utils.NewID(utils.IDTypeCard)
+	worklog := createTestWorklog(t, store, testBoardID, cardID, testUserID, 60, 1000)
+
+	require.NoError(t, store.DeleteWorklog(worklog.ID))
+
+	_, err := store.GetWorklog(worklog.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	worklogs, err := store.GetWorklogs(model.QueryWorklogsOptions{CardID: cardID})
+	require.NoError(t, err)
+	require.Empty(t, worklogs)
+
+	totals, err := store.GetWorklogTotals([]string{cardID})
+	require.NoError(t, err)
+	require.Empty(t, totals)
+
+	require.True(t, model.IsErrNotFound(store.DeleteWorklog(worklog.ID)))
+}
+
+func testGetWorklogsComplianceHistory(t *testing.T, store store.Store) {
+	board, err := store.InsertBoard(&model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: testTeamID,
+		Type:   model.BoardTypeOpen,
+	}, testUserID)
+	require.NoError(t, err)
+
+	cardID := utils.NewID(utils.IDTypeCard)
+	kept := createTestWorklog(t, store, board.ID, cardID, testUserID, 60, 1000)
+	deleted := createTestWorklog(t, store, board.ID, cardID, testUserID, 30, 1000)
+	require.NoError(t, store.DeleteWorklog(deleted.ID))
+
+	t.Run("without deleted entries", func(t *testing.T) {
+		history, hasMore, err := store.GetWorklogsComplianceHistory(model.QueryWorklogsComplianceHistoryOptions{BoardID: board.ID})
+		require.NoError(t, err)
+		require.False(t, hasMore)
+		require.Len(t, history, 1)
+		assert.Equal(t, kept.ID, history[0].ID)
+	})
+
+	t.Run("with deleted entries", func(t *testing.T) {
+		history, hasMore, err := store.GetWorklogsComplianceHistory(model.QueryWorklogsComplianceHistoryOptions{
+			TeamID:         testTeamID,
+			IncludeDeleted: true,
+		})
+		require.NoError(t, err)
+		require.False(t, hasMore)
+		require.Len(t, history, 2)
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		history, hasMore, err := store.GetWorklogsComplianceHistory(model.QueryWorklogsComplianceHistoryOptions{
+			BoardID:        board.ID,
+			IncludeDeleted: true,
+			PerPage:        1,
+		})
+		require.NoError(t, err)
+		require.True(t, hasMore)
+		require.Len(t, history, 1)
+	})
+
+	t.Run("other team", func(t *testing.T) {
+		history, _, err := store.GetWorklogsComplianceHistory(model.QueryWorklogsComplianceHistoryOptions{
+			TeamID:         utils.NewID(utils.IDTypeTeam),
+			IncludeDeleted: true,
+		})
+		require.NoError(t, err)
+		require.Empty(t, history)
+	})
+}
+
+func testMoveWorklogsWithCard(t *testing.T, store store.Store) {
+	targetBoardID := utils.NewID(utils.IDTypeBoard)
+	card := &model.Block{
+		ID:      utils.NewID(utils.IDTypeCard),
+		BoardID: testBoardID,
+		Type:    model.TypeCard,
+	}
+	require.NoError(t, store.InsertBlock(card, testUserID))
+	worklog := createTestWorklog(t, store, testBoardID, card.ID, testUserID, 60, 1000)
+
+	moved := *card
+	require.NoError(t, store.MoveCardToBoard(&moved, targetBoardID, testUserID))
+
+	retrieved, err := store.GetWorklog(worklog.ID)
+	require.NoError(t, err)
+	assert.Equal(t, targetBoardID, retrieved.BoardID)
+}
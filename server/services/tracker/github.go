@@ -0,0 +1,151 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package tracker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/github"
+)
+
+// GitHubProvider is the GitHub tracker. It uses the GitHub account that
+// each user connected through the GitHub plugin. Projects are repositories,
+// as owner/repo.
+type GitHubProvider struct {
+	service *github.Service
+}
+
+// NewGitHubProvider creates the GitHub tracker on top of the GitHub service.
+func NewGitHubProvider(service *github.Service) *GitHubProvider {
+	return &GitHubProvider{service: service}
+}
+
+// ID returns the id of the tracker.
+func (p *GitHubProvider) ID() model.TrackerProvider {
+	return model.TrackerProviderGitHub
+}
+
+// GetConnectStatus returns whether the user connected their GitHub account.
+func (p *GitHubProvider) GetConnectStatus(userID string) (*ConnectStatus, error) {
+	status, err := p.service.GetConnectedStatus(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &ConnectStatus{
+		Provider:  model.TrackerProviderGitHub,
+		Connected: status.Connected,
+		Username:  status.GitHubUsername,
+	}, nil
+}
+
+// SearchIssues searches the issues with the GitHub search syntax.
+func (p *GitHubProvider) SearchIssues(userID, project, text string) ([]Issue, error) {
+	query := strings.TrimSpace(text + " is:issue")
+	if project != "" {
+		if _, _, err := splitGitHubProject(project); err != nil {
+			return nil, err
+		}
+		query += " repo:" + project
+	}
+
+	found, err := p.service.SearchIssues(userID, query)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, 0, len(found))
+	for i := range found {
+		if len(issues) == searchLimit {
+			break
+		}
+		// search results do not have their repository, it is read from
+		// their URL.
+		_, owner, repo, _, err := model.ParseGitHubItemURL(found[i].HTMLURL)
+		if err != nil {
+			continue
+		}
+		issues = append(issues, gitHubIssue(owner+"/"+repo, &found[i]))
+	}
+	return issues, nil
+}
+
+// CreateIssue creates an issue in a repository.
+func (p *GitHubProvider) CreateIssue(userID string, req CreateIssueRequest) (*Issue, error) {
+	owner, repo, err := splitGitHubProject(req.Project)
+	if err != nil {
+		return nil, err
+	}
+	created, err := p.service.CreateIssue(userID, github.CreateIssueRequest{
+		Owner:  owner,
+		Repo:   repo,
+		Title:  req.Title,
+		Body:   req.Body,
+		Labels: req.Labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+	issue := gitHubIssue(req.Project, created)
+	return &issue, nil
+}
+
+// GetIssue returns an issue of a repository.
+func (p *GitHubProvider) GetIssue(userID, project string, number int) (*Issue, error) {
+	owner, repo, err := splitGitHubProject(project)
+	if err != nil {
+		return nil, err
+	}
+	found, err := p.service.GetIssue(userID, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	issue := gitHubIssue(project, found)
+	return &issue, nil
+}
+
+// ParseIssueURL returns the repository and number of an issue from its URL,
+// such as https://github.com/owner/repo/issues/12.
+func (p *GitHubProvider) ParseIssueURL(issueURL string) (string, int, error) {
+	kind, owner, repo, number, err := model.ParseGitHubItemURL(issueURL)
+	if err != nil {
+		return "", 0, err
+	}
+	if kind != model.CardGitHubLinkKindIssue {
+		return "", 0, model.NewErrBadRequest(fmt.Sprintf("%q is not the URL of a GitHub issue", issueURL))
+	}
+	return owner + "/" + repo, number, nil
+}
+
+func splitGitHubProject(project string) (string, string, error) {
+	owner, repo, ok := strings.Cut(project, "/")
+	if !ok || owner == "" || repo == "" || strings.ContainsRune(repo, '/') {
+		return "", "", model.NewErrBadRequest(fmt.Sprintf("%q is not a GitHub repository, as owner/repo", project))
+	}
+	return owner, repo, nil
+}
+
+func gitHubIssue(project string, issue *github.Issue) Issue {
+	labels := make([]string, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		labels = append(labels, label.Name)
+	}
+	assignees := make([]string, 0, len(issue.Assignees))
+	for _, assignee := range issue.Assignees {
+		assignees = append(assignees, assignee.Login)
+	}
+	return Issue{
+		Provider:  model.TrackerProviderGitHub,
+		Project:   project,
+		Number:    issue.Number,
+		Reference: fmt.Sprintf("%s#%d", project, issue.Number),
+		Title:     issue.Title,
+		Body:      issue.Body,
+		State:     issue.State,
+		URL:       issue.HTMLURL,
+		Labels:    labels,
+		Assignees: assignees,
+		UpdatedAt: issue.UpdatedAt,
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package tracker
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+const (
+	gitLabAPIUser          = "/api/v4/user"
+	gitLabAPIIssues        = "/api/v4/issues"
+	gitLabAPIProjectIssues = "/api/v4/projects/%s/issues"
+	gitLabAPIProjectIssue  = "/api/v4/projects/%s/issues/%d"
+
+	gitLabStateOpened = "opened"
+)
+
+// GitLabProvider is the GitLab tracker, called through the REST API of a
+// GitLab instance with the access token of the plugin settings. Projects are
+// project paths, such as group/subgroup/project.
+type GitLabProvider struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewGitLabProvider creates the GitLab tracker of an instance.
+func NewGitLabProvider(baseURL, token string) *GitLabProvider {
+	return NewGitLabProviderWithHTTPClient(baseURL, token, newHTTPClient())
+}
+
+// NewGitLabProviderWithHTTPClient creates the GitLab tracker of an instance
+// that calls its API with the given HTTP client.
+func NewGitLabProviderWithHTTPClient(baseURL, token string, httpClient *http.Client) *GitLabProvider {
+	return &GitLabProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// gitLabIssue is the GitLab API issue.
+type gitLabIssue struct {
+	IID         int       `json:"iid"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	State       string    `json:"state"`
+	WebURL      string    `json:"web_url"`
+	Labels      []string  `json:"labels"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Assignees   []struct {
+		Username string `json:"username"`
+	} `json:"assignees"`
+	References struct {
+		Full string `json:"full"`
+	} `json:"references"`
+}
+
+// ID returns the id of the tracker.
+func (p *GitLabProvider) ID() model.TrackerProvider {
+	return model.TrackerProviderGitLab
+}
+
+// GetConnectStatus returns whether the instance is configured, and the user
+// of its access token. All the users share the access token.
+func (p *GitLabProvider) GetConnectStatus(_ string) (*ConnectStatus, error) {
+	status := &ConnectStatus{Provider: model.TrackerProviderGitLab}
+	if p.baseURL == "" || p.token == "" {
+		return status, nil
+	}
+
+	var user struct {
+		Username string `json:"username"`
+	}
+	if err := p.call(http.MethodGet, gitLabAPIUser, nil, &user); err != nil {
+		return nil, err
+	}
+	status.Connected = true
+	status.Username = user.Username
+	return status, nil
+}
+
+// SearchIssues returns the issues whose title or description match a text,
+// most recently updated first.
+func (p *GitLabProvider) SearchIssues(_, project, text string) ([]Issue, error) {
+	query := url.Values{}
+	query.Set("search", text)
+	query.Set("order_by", "updated_at")
+	query.Set("per_page", strconv.Itoa(searchLimit))
+
+	path := gitLabAPIIssues
+	if project != "" {
+		path = fmt.Sprintf(gitLabAPIProjectIssues, url.PathEscape(project))
+	} else {
+		query.Set("scope", "all")
+	}
+
+	var found []gitLabIssue
+	if err := p.call(http.MethodGet, path+"?"+query.Encode(), nil, &found); err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, 0, len(found))
+	for i := range found {
+		issues = append(issues, p.issue(project, &found[i]))
+	}
+	return issues, nil
+}
+
+// CreateIssue creates an issue in a project.
+func (p *GitLabProvider) CreateIssue(_ string, req CreateIssueRequest) (*Issue, error) {
+	if req.Project == "" {
+		return nil, model.NewErrBadRequest("a GitLab issue needs a project")
+	}
+	body := map[string]string{
+		"title":       req.Title,
+		"description": req.Body,
+	}
+	if len(req.Labels) > 0 {
+		body["labels"] = strings.Join(req.Labels, ",")
+	}
+
+	var created gitLabIssue
+	if err := p.call(http.MethodPost, fmt.Sprintf(gitLabAPIProjectIssues, url.PathEscape(req.Project)), body, &created); err != nil {
+		return nil, err
+	}
+	issue := p.issue(req.Project, &created)
+	return &issue, nil
+}
+
+// GetIssue returns an issue of a project by its internal id.
+func (p *GitLabProvider) GetIssue(_, project string, number int) (*Issue, error) {
+	var found gitLabIssue
+	if err := p.call(http.MethodGet, fmt.Sprintf(gitLabAPIProjectIssue, url.PathEscape(project), number), nil, &found); err != nil {
+		return nil, err
+	}
+	issue := p.issue(project, &found)
+	return &issue, nil
+}
+
+// ParseIssueURL returns the project and internal id of an issue of the
+// instance from its URL, such as https://gitlab.com/group/project/-/issues/12.
+func (p *GitLabProvider) ParseIssueURL(issueURL string) (string, int, error) {
+	invalid := fmt.Errorf("%w: %q", ErrInvalidURL, issueURL)
+
+	path, ok := p.instancePath(issueURL)
+	if !ok {
+		return "", 0, invalid
+	}
+	project, number, ok := strings.Cut(path, "/-/issues/")
+	if !ok {
+		return "", 0, invalid
+	}
+	iid, err := strconv.Atoi(strings.TrimRight(number, "/"))
+	if err != nil || iid <= 0 || project == "" {
+		return "", 0, invalid
+	}
+	return project, iid, nil
+}
+
+// instancePath returns the path of a URL of the instance, relative to the
+// instance.
+func (p *GitLabProvider) instancePath(rawURL string) (string, bool) {
+	base, err := url.Parse(p.baseURL)
+	if err != nil || p.baseURL == "" {
+		return "", false
+	}
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || !strings.EqualFold(u.Host, base.Host) {
+		return "", false
+	}
+	path, ok := strings.CutPrefix(u.Path, strings.TrimRight(base.Path, "/")+"/")
+	return path, ok
+}
+
+func (p *GitLabProvider) call(method, path string, body, out any) error {
+	if p.baseURL == "" || p.token == "" {
+		return ErrNotConfigured
+	}
+	return doJSON(p.httpClient, method, p.baseURL+path, func(req *http.Request) {
+		req.Header.Set("PRIVATE-TOKEN", p.token)
+	}, body, out)
+}
+
+// issue converts an issue of the API. Its project is read from its
+// reference, as searches over all the projects do not have one.
+func (p *GitLabProvider) issue(project string, found *gitLabIssue) Issue {
+	if ref, _, ok := strings.Cut(found.References.Full, "#"); ok && ref != "" {
+		project = ref
+	}
+	state := StateClosed
+	if found.State == gitLabStateOpened {
+		state = StateOpen
+	}
+	labels := found.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	assignees := make([]string, 0, len(found.Assignees))
+	for _, assignee := range found.Assignees {
+		assignees = append(assignees, assignee.Username)
+	}
+	return Issue{
+		Provider:  model.TrackerProviderGitLab,
+		Project:   project,
+		Number:    found.IID,
+		Reference: fmt.Sprintf("%s#%d", project, found.IID),
+		Title:     found.Title,
+		Body:      found.Description,
+		State:     state,
+		URL:       found.WebURL,
+		Labels:    labels,
+		Assignees: assignees,
+		UpdatedAt: found.UpdatedAt,
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package tracker
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGitLab(t *testing.T, handler http.HandlerFunc) (*GitLabProvider, *httptest.Server) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "glpat-test", r.Header.Get("PRIVATE-TOKEN"))
+		handler(w, r)
+	}))
+	return NewGitLabProviderWithHTTPClient(server.URL+"/", "glpat-test", server.Client()), server
+}
+
+const gitLabTestIssue = `{
+	"iid": 12,
+	"title": "Crash on save",
+	"description": "Steps",
+	"state": "opened",
+	"web_url": "https://gitlab.example.com/group/sub/project/-/issues/12",
+	"labels": ["bug"],
+	"assignees": [{"username": "jdoe"}],
+	"updated_at": "2024-03-01T10:00:00Z",
+	"references": {"full": "group/sub/project#12"}
+}`
+
+func TestGitLabProvider(t *testing.T) {
+	t.Run("connect status", func(t *testing.T) {
+		p, server := newTestGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/user", r.URL.Path)
+			_, _ = w.Write([]byte(`{"username": "boards-bot"}`))
+		})
+		defer server.Close()
+
+		status, err := p.GetConnectStatus("user-id")
+		require.NoError(t, err)
+		assert.True(t, status.Connected)
+		assert.Equal(t, "boards-bot", status.Username)
+		assert.Equal(t, model.TrackerProviderGitLab, status.Provider)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		p := NewGitLabProvider("", "")
+
+		status, err := p.GetConnectStatus("user-id")
+		require.NoError(t, err)
+		assert.False(t, status.Connected)
+
+		_, err = p.GetIssue("user-id", "group/project", 1)
+		assert.True(t, errors.Is(err, ErrNotConfigured))
+	})
+
+	t.Run("get issue", func(t *testing.T) {
+		p, server := newTestGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/projects/group%2Fsub%2Fproject/issues/12", r.URL.EscapedPath())
+			_, _ = w.Write([]byte(gitLabTestIssue))
+		})
+		defer server.Close()
+
+		issue, err := p.GetIssue("user-id", "group/sub/project", 12)
+		require.NoError(t, err)
+		assert.Equal(t, "group/sub/project#12", issue.Reference)
+		assert.Equal(t, "Crash on save", issue.Title)
+		assert.Equal(t, StateOpen, issue.State)
+		assert.Equal(t, []string{"bug"}, issue.Labels)
+		assert.Equal(t, []string{"jdoe"}, issue.Assignees)
+	})
+
+	t.Run("search all projects", func(t *testing.T) {
+		p, server := newTestGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v4/issues", r.URL.Path)
+			assert.Equal(t, "crash", r.URL.Query().Get("search"))
+			assert.Equal(t, "all", r.URL.Query().Get("scope"))
+			_, _ = w.Write([]byte("[" + gitLabTestIssue + "]"))
+		})
+		defer server.Close()
+
+		issues, err := p.SearchIssues("user-id", "", "crash")
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.Equal(t, "group/sub/project", issues[0].Project)
+		assert.Equal(t, 12, issues[0].Number)
+	})
+
+	t.Run("create issue", func(t *testing.T) {
+		p, server := newTestGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v4/projects/group%2Fproject/issues", r.URL.EscapedPath())
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "New issue", body["title"])
+			assert.Equal(t, "bug,ui", body["labels"])
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"iid": 3, "title": "New issue", "state": "opened", "references": {"full": "group/project#3"}}`))
+		})
+		defer server.Close()
+
+		issue, err := p.CreateIssue("user-id", CreateIssueRequest{Project: "group/project", Title: "New issue", Labels: []string{"bug", "ui"}})
+		require.NoError(t, err)
+		assert.Equal(t, 3, issue.Number)
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		p, server := newTestGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		defer server.Close()
+
+		_, err := p.GetIssue("user-id", "group/project", 99)
+		assert.True(t, errors.Is(err, ErrAPICall))
+	})
+}
+
+func TestGitLabParseIssueURL(t *testing.T) {
+	p := NewGitLabProvider("https://gitlab.example.com", "token")
+
+	project, number, err := p.ParseIssueURL("https://gitlab.example.com/group/sub/project/-/issues/12")
+	require.NoError(t, err)
+	assert.Equal(t, "group/sub/project", project)
+	assert.Equal(t, 12, number)
+
+	for _, invalid := range []string{
+		"https://gitlab.com/group/project/-/issues/12",
+		"https://gitlab.example.com/group/project/-/merge_requests/12",
+		"https://gitlab.example.com/group/project/-/issues/abc",
+	} {
+		_, _, err := p.ParseIssueURL(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package tracker
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+const (
+	jiraAPIMyself = "/rest/api/2/myself"
+	jiraAPIIssues = "/rest/api/2/issue"
+	jiraAPIIssue  = "/rest/api/2/issue/%s"
+
+	// Jira Cloud replaced the search endpoint of Jira Data Center.
+	jiraAPISearch      = "/rest/api/2/search"
+	jiraAPISearchCloud = "/rest/api/2/search/jql"
+
+	jiraIssueFields = "summary,description,status,labels,assignee,updated"
+
+	// jiraIssueType is the type of the issues created in Jira.
+	jiraIssueType = "Task"
+
+	jiraStatusCategoryDone = "done"
+	jiraTimeLayout         = "2006-01-02T15:04:05.000-0700"
+)
+
+var jiraProjectKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// JiraProvider is the Jira tracker, called through the REST API of a Jira
+// site with the credentials of the plugin settings: an email and API token on
+// Jira Cloud, or a personal access token on Jira Data Center. Projects are
+// project keys, and issue numbers are the number of their issue key.
+type JiraProvider struct {
+	baseURL    string
+	email      string
+	token      string
+	httpClient *http.Client
+}
+
+// NewJiraProvider creates the Jira tracker of a site.
+func NewJiraProvider(baseURL, email, token string) *JiraProvider {
+	return NewJiraProviderWithHTTPClient(baseURL, email, token, newHTTPClient())
+}
+
+// NewJiraProviderWithHTTPClient creates the Jira tracker of a site that
+// calls its API with the given HTTP client.
+func NewJiraProviderWithHTTPClient(baseURL, email, token string, httpClient *http.Client) *JiraProvider {
+	return &JiraProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		email:      email,
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// jiraIssue is the Jira API issue.
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string   `json:"summary"`
+		Description string   `json:"description"`
+		Labels      []string `json:"labels"`
+		Updated     string   `json:"updated"`
+		Status      struct {
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"status"`
+		Assignee *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"assignee"`
+	} `json:"fields"`
+}
+
+// ID returns the id of the tracker.
+func (p *JiraProvider) ID() model.TrackerProvider {
+	return model.TrackerProviderJira
+}
+
+// GetConnectStatus returns whether the site is configured, and the user of
+// its credentials. All the users share the credentials.
+func (p *JiraProvider) GetConnectStatus(_ string) (*ConnectStatus, error) {
+	status := &ConnectStatus{Provider: model.TrackerProviderJira}
+	if p.baseURL == "" || p.token == "" {
+		return status, nil
+	}
+
+	var user struct {
+		DisplayName string `json:"displayName"`
+	}
+	if err := p.call(http.MethodGet, jiraAPIMyself, nil, &user); err != nil {
+		return nil, err
+	}
+	status.Connected = true
+	status.Username = user.DisplayName
+	return status, nil
+}
+
+// SearchIssues returns the issues whose text matches, most recently updated
+// first.
+func (p *JiraProvider) SearchIssues(_, project, text string) ([]Issue, error) {
+	clauses := []string{}
+	if project != "" {
+		if !jiraProjectKeyPattern.MatchString(project) {
+			return nil, model.NewErrBadRequest(fmt.Sprintf("%q is not a Jira project key", project))
+		}
+		clauses = append(clauses, "project = "+jqlString(project))
+	}
+	if text = strings.TrimSpace(text); text != "" {
+		clauses = append(clauses, "text ~ "+jqlString(text))
+	}
+
+	query := url.Values{}
+	query.Set("jql", strings.Join(clauses, " AND ")+" ORDER BY updated DESC")
+	query.Set("fields", jiraIssueFields)
+	query.Set("maxResults", strconv.Itoa(searchLimit))
+
+	path := jiraAPISearch
+	if p.email != "" {
+		path = jiraAPISearchCloud
+	}
+	var result struct {
+		Issues []jiraIssue `json:"issues"`
+	}
+	if err := p.call(http.MethodGet, path+"?"+query.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, 0, len(result.Issues))
+	for i := range result.Issues {
+		issue, err := p.issue(&result.Issues[i])
+		if err != nil {
+			continue
+		}
+		issues = append(issues, *issue)
+	}
+	return issues, nil
+}
+
+// CreateIssue creates a task in a project.
+func (p *JiraProvider) CreateIssue(userID string, req CreateIssueRequest) (*Issue, error) {
+	if !jiraProjectKeyPattern.MatchString(req.Project) {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("%q is not a Jira project key", req.Project))
+	}
+	fields := map[string]any{
+		"project":     map[string]string{"key": req.Project},
+		"summary":     req.Title,
+		"description": req.Body,
+		"issuetype":   map[string]string{"name": jiraIssueType},
+	}
+	if len(req.Labels) > 0 {
+		fields["labels"] = req.Labels
+	}
+
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := p.call(http.MethodPost, jiraAPIIssues, map[string]any{"fields": fields}, &created); err != nil {
+		return nil, err
+	}
+	// the creation only returns the key of the issue.
+	project, number, err := splitJiraKey(created.Key)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetIssue(userID, project, number)
+}
+
+// GetIssue returns an issue of a project.
+func (p *JiraProvider) GetIssue(_, project string, number int) (*Issue, error) {
+	if !jiraProjectKeyPattern.MatchString(project) {
+		return nil, model.NewErrBadRequest(fmt.Sprintf("%q is not a Jira project key", project))
+	}
+	key := fmt.Sprintf("%s-%d", project, number)
+
+	var found jiraIssue
+	if err := p.call(http.MethodGet, fmt.Sprintf(jiraAPIIssue, key)+"?fields="+jiraIssueFields, nil, &found); err != nil {
+		return nil, err
+	}
+	return p.issue(&found)
+}
+
+// ParseIssueURL returns the project and number of an issue of the site from
+// its URL, such as https://example.atlassian.net/browse/PROJ-12.
+func (p *JiraProvider) ParseIssueURL(issueURL string) (string, int, error) {
+	invalid := fmt.Errorf("%w: %q", ErrInvalidURL, issueURL)
+
+	base, err := url.Parse(p.baseURL)
+	if err != nil || p.baseURL == "" {
+		return "", 0, invalid
+	}
+	u, err := url.Parse(strings.TrimSpace(issueURL))
+	if err != nil || !strings.EqualFold(u.Host, base.Host) {
+		return "", 0, invalid
+	}
+	key, ok := strings.CutPrefix(u.Path, strings.TrimRight(base.Path, "/")+"/browse/")
+	if !ok {
+		return "", 0, invalid
+	}
+	project, number, err := splitJiraKey(strings.TrimRight(key, "/"))
+	if err != nil {
+		return "", 0, invalid
+	}
+	return project, number, nil
+}
+
+func (p *JiraProvider) call(method, path string, body, out any) error {
+	if p.baseURL == "" || p.token == "" {
+		return ErrNotConfigured
+	}
+	return doJSON(p.httpClient, method, p.baseURL+path, func(req *http.Request) {
+		if p.email != "" {
+			req.SetBasicAuth(p.email, p.token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+p.token)
+		}
+	}, body, out)
+}
+
+func (p *JiraProvider) issue(found *jiraIssue) (*Issue, error) {
+	project, number, err := splitJiraKey(found.Key)
+	if err != nil {
+		return nil, err
+	}
+	state := StateOpen
+	if found.Fields.Status.StatusCategory.Key == jiraStatusCategoryDone {
+		state = StateClosed
+	}
+	labels := found.Fields.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	assignees := []string{}
+	if found.Fields.Assignee != nil {
+		assignees = append(assignees, found.Fields.Assignee.DisplayName)
+	}
+	updatedAt, _ := time.Parse(jiraTimeLayout, found.Fields.Updated)
+
+	return &Issue{
+		Provider:  model.TrackerProviderJira,
+		Project:   project,
+		Number:    number,
+		Reference: found.Key,
+		Title:     found.Fields.Summary,
+		Body:      found.Fields.Description,
+		State:     state,
+		URL:       p.baseURL + "/browse/" + found.Key,
+		Labels:    labels,
+		Assignees: assignees,
+		UpdatedAt: updatedAt,
+	}, nil
+}
+
+// splitJiraKey returns the project and number of an issue key, such as
+// PROJ-12.
+func splitJiraKey(key string) (string, int, error) {
+	i := strings.LastIndexByte(key, '-')
+	if i <= 0 {
+		return "", 0, fmt.Errorf("invalid Jira issue key %q", key)
+	}
+	number, err := strconv.Atoi(key[i+1:])
+	if err != nil || number <= 0 || !jiraProjectKeyPattern.MatchString(key[:i]) {
+		return "", 0, fmt.Errorf("invalid Jira issue key %q", key)
+	}
+	return key[:i], number, nil
+}
+
+// jqlString quotes a string for a JQL query.
+func jqlString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package tracker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jiraTestIssue = `{
+	"key": "PROJ-12",
+	"fields": {
+		"summary": "Crash on save",
+		"description": "Steps",
+		"labels": ["bug"],
+		"updated": "2024-03-01T10:00:00.000+0000",
+		"status": {"statusCategory": {"key": "done"}},
+		"assignee": {"displayName": "Jane Doe"}
+	}
+}`
+
+func TestJiraProvider(t *testing.T) {
+	t.Run("get issue on jira cloud", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			email, token, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "bot@example.com", email)
+			assert.Equal(t, "api-token", token)
+			assert.Equal(t, "/rest/api/2/issue/PROJ-12", r.URL.Path)
+			_, _ = w.Write([]byte(jiraTestIssue))
+		}))
+		defer server.Close()
+		p := NewJiraProviderWithHTTPClient(server.URL, "bot@example.com", "api-token", server.Client())
+
+		issue, err := p.GetIssue("user-id", "PROJ", 12)
+		require.NoError(t, err)
+		assert.Equal(t, "PROJ-12", issue.Reference)
+		assert.Equal(t, "PROJ", issue.Project)
+		assert.Equal(t, 12, issue.Number)
+		assert.Equal(t, StateClosed, issue.State)
+		assert.Equal(t, []string{"Jane Doe"}, issue.Assignees)
+		assert.Equal(t, server.URL+"/browse/PROJ-12", issue.URL)
+		assert.Equal(t, 2024, issue.UpdatedAt.Year())
+	})
+
+	t.Run("search on jira data center", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer pat", r.Header.Get("Authorization"))
+			assert.Equal(t, "/rest/api/2/search", r.URL.Path)
+			assert.Equal(t, `project = "PROJ" AND text ~ "say \"hi\"" ORDER BY updated DESC`, r.URL.Query().Get("jql"))
+			_, _ = w.Write([]byte(`{"issues": [` + jiraTestIssue + `]}`))
+		}))
+		defer server.Close()
+		p := NewJiraProviderWithHTTPClient(server.URL, "", "pat", server.Client())
+
+		issues, err := p.SearchIssues("user-id", "PROJ", `say "hi"`)
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.Equal(t, "Crash on save", issues[0].Title)
+	})
+
+	t.Run("create issue", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				assert.Equal(t, "/rest/api/2/issue", r.URL.Path)
+				var body struct {
+					Fields map[string]any `json:"fields"`
+				}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "New issue", body.Fields["summary"])
+				assert.Equal(t, map[string]any{"key": "PROJ"}, body.Fields["project"])
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id": "10001", "key": "PROJ-12"}`))
+				return
+			}
+			_, _ = w.Write([]byte(jiraTestIssue))
+		}))
+		defer server.Close()
+		p := NewJiraProviderWithHTTPClient(server.URL, "", "pat", server.Client())
+
+		issue, err := p.CreateIssue("user-id", CreateIssueRequest{Project: "PROJ", Title: "New issue"})
+		require.NoError(t, err)
+		assert.Equal(t, "PROJ-12", issue.Reference)
+	})
+
+	t.Run("invalid project key", func(t *testing.T) {
+		p := NewJiraProvider("https://example.atlassian.net", "", "pat")
+		_, err := p.GetIssue("user-id", "PROJ/../x", 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestJiraParseIssueURL(t *testing.T) {
+	p := NewJiraProvider("https://example.atlassian.net", "", "pat")
+
+	project, number, err := p.ParseIssueURL("https://example.atlassian.net/browse/PROJ-12")
+	require.NoError(t, err)
+	assert.Equal(t, "PROJ", project)
+	assert.Equal(t, 12, number)
+
+	for _, invalid := range []string{
+		"https://other.atlassian.net/browse/PROJ-12",
+		"https://example.atlassian.net/browse/PROJ",
+		"https://example.atlassian.net/projects/PROJ",
+	} {
+		_, _, err := p.ParseIssueURL(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
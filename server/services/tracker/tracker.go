@@ -0,0 +1,152 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package tracker provides the issue trackers that cards can be linked to.
+// Each tracker implements Provider; GitHub goes through the GitHub plugin,
+// GitLab and Jira are called through their REST APIs with the credentials
+// set in the plugin settings.
+package tracker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+// Static errors for the tracker providers.
+var (
+	ErrNotConfigured = errors.New("the issue tracker is not configured")
+	ErrAPICall       = errors.New("issue tracker API error")
+	ErrInvalidURL    = errors.New("not the URL of an issue of the tracker")
+)
+
+// The states of the issues of all the trackers.
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+)
+
+const (
+	// searchLimit is the largest number of issues returned by a search.
+	searchLimit = 20
+
+	// requestTimeout is the timeout of the calls to the tracker APIs.
+	requestTimeout = 30 * time.Second
+)
+
+// Provider is an issue tracker. Issues are identified by their project, whose
+// format depends on the tracker, and their number within the project.
+type Provider interface {
+	// ID returns the id of the tracker.
+	ID() model.TrackerProvider
+
+	// GetConnectStatus returns whether a user can use the tracker, and as
+	// which account.
+	GetConnectStatus(userID string) (*ConnectStatus, error)
+
+	// SearchIssues returns the issues matching a text, in a project if it is
+	// not empty.
+	SearchIssues(userID, project, text string) ([]Issue, error)
+
+	// CreateIssue creates an issue.
+	CreateIssue(userID string, req CreateIssueRequest) (*Issue, error)
+
+	// GetIssue returns an issue.
+	GetIssue(userID, project string, number int) (*Issue, error)
+
+	// ParseIssueURL returns the project and number of an issue from its URL.
+	ParseIssueURL(issueURL string) (string, int, error)
+}
+
+// ConnectStatus is whether a user can use a tracker, and as which account.
+type ConnectStatus struct {
+	Provider  model.TrackerProvider `json:"provider"`
+	Connected bool                  `json:"connected"`
+	Username  string                `json:"username,omitempty"`
+}
+
+// Issue is an issue of a tracker.
+type Issue struct {
+	Provider model.TrackerProvider `json:"provider"`
+
+	// Project is owner/repo on GitHub, the project path on GitLab and the
+	// project key on Jira.
+	Project string `json:"project"`
+	Number  int    `json:"number"`
+
+	// Reference is the short reference of the issue, such as owner/repo#12,
+	// group/project#12 or PROJ-12.
+	Reference string `json:"reference"`
+
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"` // StateOpen or StateClosed
+	URL       string    `json:"url"`
+	Labels    []string  `json:"labels"`
+	Assignees []string  `json:"assignees"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateIssueRequest represents a request to create an issue.
+type CreateIssueRequest struct {
+	Project string   `json:"project"`
+	Title   string   `json:"title"`
+	Body    string   `json:"body"`
+	Labels  []string `json:"labels,omitempty"`
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: requestTimeout}
+}
+
+// doJSON calls a tracker API with a JSON body, if any, and decodes its JSON
+// response into out, if not nil.
+func doJSON(client *http.Client, method, reqURL string, authorize func(*http.Request), body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, reqURL, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	authorize(req)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call the tracker API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return apiError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// apiError returns the error of a failed call to a tracker API.
+func apiError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Errorf("%w: status %d: %s", ErrAPICall, resp.StatusCode, string(body))
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+)
+
+const (
+	// HeaderEvent carries the event type of a board webhook delivery.
+	HeaderEvent = "X-Boards-Event"
+	// HeaderDelivery carries the delivery id, stable across retries.
+	HeaderDelivery = "X-Boards-Delivery"
+	// HeaderSignature carries the HMAC-SHA256 signature of the body.
+	HeaderSignature = "X-Boards-Signature"
+
+	signaturePrefix = "sha256="
+
+	deliveryTimeout     = 10 * time.Second
+	maxResponseBodySize = 64 * 1024
+
+	retryBaseDelay = 30 * time.Second
+	retryMaxDelay  = time.Hour
+)
+
+// Sign returns the signature of a payload, as sent in the X-Boards-Signature
+// header: "sha256=" followed by the hex encoded HMAC-SHA256 of the body keyed
+// with the webhook secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks a signature produced by Sign in constant time.
+func VerifySignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// RetryDelay returns the delay before the next attempt of a delivery that
+// failed for the given number of attempts. The delay doubles with every
+// attempt and is capped at one hour.
+func RetryDelay(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	delay := retryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= retryMaxDelay {
+			return retryMaxDelay
+		}
+	}
+	return delay
+}
+
+// Deliver posts a signed delivery to a board webhook. It returns the HTTP
+// status code of the response, and an error if the request failed or the
+// endpoint did not answer with a 2xx status.
+func (wh *Client) Deliver(webhook *model.BoardWebhook, delivery *model.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(delivery.Event))
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderSignature, Sign(webhook.Secret, body))
+
+	resp, err := wh.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2020-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-boards/server/model"
+	"github.com/mattermost/mattermost-plugin-boards/server/services/config"
+
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/httpservice"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func TestSign(t *testing.T) {
+	// HMAC-SHA256 test vector from RFC 4231, test case 2
+	signature := Sign("Jefe", []byte("what do ya want for nothing?"))
+	assert.Equal(t, "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", signature)
+
+	assert.True(t, VerifySignature("Jefe", []byte("what do ya want for nothing?"), signature))
+	assert.False(t, VerifySignature("other", []byte("what do ya want for nothing?"), signature))
+	assert.False(t, VerifySignature("Jefe", []byte("tampered"), signature))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Duration(0), RetryDelay(0))
+	assert.Equal(t, 30*time.Second, RetryDelay(1))
+	assert.Equal(t, time.Minute, RetryDelay(2))
+	assert.Equal(t, 2*time.Minute, RetryDelay(3))
+	assert.Equal(t, 32*time.Minute, RetryDelay(7))
+	assert.Equal(t, time.Hour, RetryDelay(8))
+	assert.Equal(t, time.Hour, RetryDelay(100))
+}
+
+func TestClientDeliver(t *testing.T) {
+	logger, _ := mlog.NewLogger()
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	client := NewClient(&config.Configuration{}, logger)
+
+	delivery := &model.WebhookDelivery{
+		ID:      "delivery-id",
+		Event:   model.WebhookEventCardCreated,
+		Payload: `{"event":"card.created"}`,
+	}
+
+	t.Run("signed request", func(t *testing.T) {
+		var received *http.Request
+		var body []byte
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer ts.Close()
+
+		status, err := client.Deliver(&model.BoardWebhook{URL: ts.URL, Secret: "secret"}, delivery)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+
+		require.NotNil(t, received)
+		assert.Equal(t, delivery.Payload, string(body))
+		assert.Equal(t, "card.created", received.Header.Get(HeaderEvent))
+		assert.Equal(t, "delivery-id", received.Header.Get(HeaderDelivery))
+		assert.True(t, VerifySignature("secret", body, received.Header.Get(HeaderSignature)))
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer ts.Close()
+
+		status, err := client.Deliver(&model.BoardWebhook{URL: ts.URL, Secret: "secret"}, delivery)
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadGateway, status)
+	})
+
+	t.Run("unreachable endpoint", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		url := ts.URL
+		ts.Close()
+
+		status, err := client.Deliver(&model.BoardWebhook{URL: url, Secret: "secret"}, delivery)
+		require.Error(t, err)
+		assert.Equal(t, 0, status)
+	})
+}
+
+type testServerConfig struct {
+	config *mm_model.Config
+}
+
+func (c *testServerConfig) GetConfig() *mm_model.Config {
+	return c.config
+}
+
+func TestClientDeliverWithServerHTTPClient(t *testing.T) {
+	logger, _ := mlog.NewLogger()
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	serverConfig := &testServerConfig{config: &mm_model.Config{}}
+	serverConfig.config.SetDefaults()
+	client := NewClient(&config.Configuration{}, logger)
+	client.UseServerHTTPClient(serverConfig)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	delivery := &model.WebhookDelivery{ID: "delivery-id", Event: model.WebhookEventCardCreated, Payload: "{}"}
+
+	t.Run("internal address", func(t *testing.T) {
+		status, err := client.Deliver(&model.BoardWebhook{URL: ts.URL, Secret: "secret"}, delivery)
+		require.ErrorIs(t, err, httpservice.ErrAddressForbidden)
+		assert.Equal(t, 0, status)
+	})
+
+	t.Run("allowed internal address", func(t *testing.T) {
+		allowed := "127.0.0.1"
+		serverConfig.config.ServiceSettings.AllowedUntrustedInternalConnections = &allowed
+
+		status, err := client.Deliver(&model.BoardWebhook{URL: ts.URL, Secret: "secret"}, delivery)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+	})
+}
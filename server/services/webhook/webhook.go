@@ -12,6 +12,8 @@ import (
 	"github.com/mattermost/mattermost-plugin-boards/server/model"
 	"github.com/mattermost/mattermost-plugin-boards/server/services/config"
 
+	mm_model "github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/httpservice"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
@@ -26,7 +28,11 @@ func (wh *Client) NotifyUpdate(block *model.Block) {
 		wh.logger.Fatal("NotifyUpdate: json.Marshal", mlog.Err(err))
 	}
 	for _, url := range wh.config.WebhookUpdate {
-		resp, _ := http.Post(url, "application/json", bytes.NewBuffer(json)) //nolint:gosec
+		resp, err := http.Post(url, "application/json", bytes.NewBuffer(json)) //nolint:gosec
+		if err != nil {
+			wh.logger.Warn("webhook.NotifyUpdate: post failed", mlog.String("url", url), mlog.Err(err))
+			continue
+		}
 		_, _ = io.ReadAll(resp.Body)
 		resp.Body.Close()
 
@@ -36,14 +42,39 @@ func (wh *Client) NotifyUpdate(block *model.Block) {
 
 // Client is a webhook client.
 type Client struct {
-	config *config.Configuration
-	logger mlog.LoggerIFace
+	config     *config.Configuration
+	logger     mlog.LoggerIFace
+	httpClient *http.Client
 }
 
 // NewClient creates a new Client.
 func NewClient(config *config.Configuration, logger mlog.LoggerIFace) *Client {
 	return &Client{
-		config: config,
-		logger: logger,
+		config:     config,
+		logger:     logger,
+		httpClient: &http.Client{Timeout: deliveryTimeout},
 	}
 }
+
+// ServerConfigService gives the configuration of the Mattermost server.
+type ServerConfigService interface {
+	GetConfig() *mm_model.Config
+}
+
+type serverConfigAdapter struct {
+	service ServerConfigService
+}
+
+func (a serverConfigAdapter) Config() *mm_model.Config {
+	return a.service.GetConfig()
+}
+
+// UseServerHTTPClient makes the deliveries to board webhooks go through the
+// HTTP client of the Mattermost server, which refuses to connect to the
+// internal addresses not listed in its AllowedUntrustedInternalConnections
+// setting.
+func (wh *Client) UseServerHTTPClient(service ServerConfigService) {
+	httpClient := httpservice.MakeHTTPService(serverConfigAdapter{service}).MakeClient(false)
+	httpClient.Timeout = deliveryTimeout
+	wh.httpClient = httpClient
+}
@@ -40,3 +40,21 @@ func TestClientUpdateNotify(t *testing.T) {
 		t.Error("webhook url not be notified")
 	}
 }
+
+func TestClientUpdateNotifyUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := ts.URL
+	ts.Close()
+
+	logger, _ := mlog.NewLogger()
+	defer func() {
+		err := logger.Shutdown()
+		assert.NoError(t, err)
+	}()
+
+	client := NewClient(&config.Configuration{WebhookUpdate: []string{url}}, logger)
+
+	assert.NotPanics(t, func() {
+		client.NotifyUpdate(&model.Block{})
+	})
+}
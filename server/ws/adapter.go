@@ -18,6 +18,7 @@ const (
 	websocketActionUpdateMember             = "UPDATE_MEMBER"
 	websocketActionDeleteMember             = "DELETE_MEMBER"
 	websocketActionUpdateBlock              = "UPDATE_BLOCK"
+	websocketActionUpdateBlocks             = "UPDATE_BLOCKS"
 	websocketActionUpdateConfig             = "UPDATE_CLIENT_CONFIG"
 	websocketActionUpdateCategory           = "UPDATE_CATEGORY"
 	websocketActionUpdateCategoryBoard      = "UPDATE_BOARD_CATEGORY"
@@ -37,6 +38,7 @@ type Store interface {
 type Adapter interface {
 	BroadcastBlockChange(teamID string, block *model.Block)
 	BroadcastBlockDelete(teamID, blockID, boardID string)
+	BroadcastBlocksChange(teamID, boardID string, blocks []*model.Block)
 	BroadcastBoardChange(teamID string, board *model.Board)
 	BroadcastBoardDelete(teamID, boardID string)
 	BroadcastMemberChange(teamID, boardID string, member *model.BoardMember)
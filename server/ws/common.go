@@ -22,6 +22,14 @@ type UpdateBlockMsg struct {
 	Block  *model.Block `json:"block"`
 }
 
+// UpdateBlocksMsg is sent when many blocks of a board change at once.
+type UpdateBlocksMsg struct {
+	Action  string         `json:"action"`
+	TeamID  string         `json:"teamId"`
+	BoardID string         `json:"boardId"`
+	Blocks  []*model.Block `json:"blocks"`
+}
+
 // UpdateBoardMsg is sent on block updates.
 type UpdateBoardMsg struct {
 	Action string       `json:"action"`
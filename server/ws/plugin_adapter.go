@@ -588,6 +588,23 @@ func (pa *PluginAdapter) BroadcastBlockDelete(teamID, blockID, boardID string) {
 	pa.BroadcastBlockChange(teamID, block)
 }
 
+func (pa *PluginAdapter) BroadcastBlocksChange(teamID, boardID string, blocks []*model.Block) {
+	pa.logger.Trace("BroadcastingBlocksChange",
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", boardID),
+		mlog.Int("blockCount", len(blocks)),
+	)
+
+	message := UpdateBlocksMsg{
+		Action:  websocketActionUpdateBlocks,
+		TeamID:  teamID,
+		BoardID: boardID,
+		Blocks:  blocks,
+	}
+
+	pa.sendBoardMessage(teamID, boardID, utils.StructToMap(message))
+}
+
 func (pa *PluginAdapter) BroadcastBoardChange(teamID string, board *model.Board) {
 	pa.logger.Debug("BroadcastingBoardChange",
 		mlog.String("teamID", teamID),
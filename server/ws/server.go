@@ -541,6 +541,49 @@ func (ws *Server) BroadcastBlockChange(teamID string, block *model.Block) {
 	}
 }
 
+func (ws *Server) BroadcastBlocksChange(teamID, boardID string, blocks []*model.Block) {
+	message := UpdateBlocksMsg{
+		Action:  websocketActionUpdateBlocks,
+		TeamID:  teamID,
+		BoardID: boardID,
+		Blocks:  blocks,
+	}
+
+	listeners := ws.getListenersForTeamAndBoard(teamID, boardID)
+	for _, block := range blocks {
+		for _, blockID := range []string{block.ID, block.ParentID} {
+			listeners = append(listeners, ws.getListenersForBlock(blockID)...)
+		}
+	}
+	ws.logger.Trace("listener(s) for teamID and boardID",
+		mlog.Int("listener_count", len(listeners)),
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", boardID),
+	)
+
+	// a listener subscribed to several of the blocks gets the message once
+	notified := make(map[*websocketSession]bool, len(listeners))
+	for _, listener := range listeners {
+		if notified[listener] {
+			continue
+		}
+		notified[listener] = true
+
+		ws.logger.Debug("Broadcast blocks change",
+			mlog.String("teamID", teamID),
+			mlog.String("boardID", boardID),
+			mlog.Int("blockCount", len(blocks)),
+			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
+		)
+
+		err := listener.WriteJSON(message)
+		if err != nil {
+			ws.logger.Error("broadcast error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
+
 func (ws *Server) BroadcastCategoryChange(category model.Category) {
 	message := UpdateCategoryMessage{
 		Action:   websocketActionUpdateCategory,